@@ -17,21 +17,29 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"errors"
 	"flag"
 	"net/http"
 	"os"
 	"strings"
+	"time"
 
 	"go.uber.org/zap/zapcore"
-	"k8s.io/apimachinery/pkg/api/meta"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	corelisters "k8s.io/client-go/listers/core/v1"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
+	toolscache "k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
@@ -41,10 +49,16 @@ import (
 	volcanoclient "volcano.sh/apis/pkg/client/clientset/versioned"
 
 	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	kubeflowv2beta1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v2beta1"
 	"github.com/kubeflow/training-operator/pkg/cert"
+	trainingoperatorcommon "github.com/kubeflow/training-operator/pkg/common"
 	"github.com/kubeflow/training-operator/pkg/config"
 	controllerv1 "github.com/kubeflow/training-operator/pkg/controller.v1"
 	"github.com/kubeflow/training-operator/pkg/controller.v1/common"
+	"github.com/kubeflow/training-operator/pkg/controller.v1/registry"
+	"github.com/kubeflow/training-operator/pkg/features"
+	"github.com/kubeflow/training-operator/pkg/storageversion"
+	"github.com/kubeflow/training-operator/pkg/util/podsecurity"
 	"github.com/kubeflow/training-operator/pkg/webhooks"
 	//+kubebuilder:scaffold:imports
 )
@@ -52,8 +66,13 @@ import (
 const (
 	// EnvKubeflowNamespace is an environment variable for namespace when deployed on kubernetes
 	EnvKubeflowNamespace = "KUBEFLOW_NAMESPACE"
+	// EnvPodNamespace and EnvPodServiceAccount are the environment variables of the namespace
+	// and the service account of the operator pod.
+	EnvPodNamespace      = "MY_POD_NAMESPACE"
+	EnvPodServiceAccount = "MY_POD_SERVICE_ACCOUNT"
 
-	webhookConfigurationName = "validator.training-operator.kubeflow.org"
+	webhookConfigurationName         = "validator.training-operator.kubeflow.org"
+	mutatingWebhookConfigurationName = "defaulter.training-operator.kubeflow.org"
 )
 
 var (
@@ -64,6 +83,7 @@ var (
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 	utilruntime.Must(kubeflowv1.AddToScheme(scheme))
+	utilruntime.Must(kubeflowv2beta1.AddToScheme(scheme))
 	utilruntime.Must(v1beta1.AddToScheme(scheme))
 	utilruntime.Must(schedulerpluginsv1alpha1.AddToScheme(scheme))
 	//+kubebuilder:scaffold:scheme
@@ -73,28 +93,58 @@ func main() {
 	var metricsAddr string
 	var enableLeaderElection bool
 	var leaderElectionID string
+	var leaseDuration time.Duration
+	var renewDeadline time.Duration
+	var retryPeriod time.Duration
 	var probeAddr string
 	var enabledSchemes controllerv1.EnabledSchemes
 	var gangSchedulerName string
 	var namespace string
 	var controllerThreads int
+	controllerThreadsPerKind := controllerv1.ControllerThreads{}
 	var webhookServerPort int
 	var webhookServiceName string
 	var webhookSecretName string
+	var configFile string
+	var nodePreemptionTaints string
 
+	flag.StringVar(&configFile, "config", "", "The configuration file of the training operator, a "+config.FileKind+" of "+
+		config.FileAPIVersion+" setting the controllers, the gang scheduler, the default images, the metrics and the webhook. "+
+		"The flags set on the command line take precedence over the file.")
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
 	flag.StringVar(&leaderElectionID, "leader-election-id", "1ca428e5.training-operator.kubeflow.org", "The ID for leader election.")
+	flag.DurationVar(&leaseDuration, "leader-election-lease-duration", 15*time.Second,
+		"The duration that non-leader candidates will wait after observing a leadership renewal until attempting to acquire leadership.")
+	flag.DurationVar(&renewDeadline, "leader-election-renew-deadline", 10*time.Second,
+		"The duration that the acting leader will retry refreshing leadership before giving up.")
+	flag.DurationVar(&retryPeriod, "leader-election-retry-period", 2*time.Second,
+		"The duration the leader election clients should wait between tries of actions.")
 	flag.Var(&enabledSchemes, "enable-scheme", "Enable scheme(s) as --enable-scheme=tfjob --enable-scheme=pytorchjob, case insensitive."+
-		" Now supporting TFJob, PyTorchJob, XGBoostJob, PaddleJob, JAXJob. By default, all supported schemes will be enabled.")
+		" Now supporting TFJob, PyTorchJob, XGBoostJob, MPIJob, PaddleJob, JAXJob. By default, all supported schemes whose CRDs "+
+		"are installed will be enabled.")
+	flag.Var(&enabledSchemes, "enable-schemes", "Enable a comma separated list of schemes as --enable-schemes=tfjob,pytorchjob, "+
+		"case insensitive. The CRDs of the enabled schemes must be installed.")
 	flag.StringVar(&gangSchedulerName, "gang-scheduler-name", "", "Now Supporting volcano and scheduler-plugins."+
 		" Note: If you set another scheduler name, the training-operator assumes it's the scheduler-plugins.")
 	flag.StringVar(&namespace, "namespace", os.Getenv(EnvKubeflowNamespace), "The namespace to monitor kubeflow jobs. If unset, it monitors all namespaces cluster-wide."+
 		"If set, it only monitors kubeflow jobs in the given namespace.")
 	flag.IntVar(&controllerThreads, "controller-threads", 1, "Number of worker threads used by the controller.")
+	flag.Var(controllerThreadsPerKind, "controller-threads-per-kind", "Number of worker threads used by the controller of a kind as "+
+		"--controller-threads-per-kind=tfjob=4 --controller-threads-per-kind=pytorchjob=8, case insensitive. Overrides --controller-threads for the kind.")
+
+	// Workqueue related flags
+	flag.DurationVar(&config.Config.WorkQueueBaseDelay, "workqueue-base-delay",
+		config.WorkQueueBaseDelayDefault, "The delay of the first retry of a failed reconcile of a job, doubled on every retry.")
+	flag.DurationVar(&config.Config.WorkQueueMaxDelay, "workqueue-max-delay",
+		config.WorkQueueMaxDelayDefault, "The maximum delay of the retries of the failed reconciles of a job.")
+	flag.Float64Var(&config.Config.WorkQueueQPS, "workqueue-qps",
+		config.WorkQueueQPSDefault, "The overall rate of the reconciles of the jobs of a kind, per second.")
+	flag.IntVar(&config.Config.WorkQueueBurst, "workqueue-burst",
+		config.WorkQueueBurstDefault, "The overall burst of the reconciles of the jobs of a kind.")
 
 	// PyTorch related flags
 	flag.StringVar(&config.Config.PyTorchInitContainerImage, "pytorch-init-container-image",
@@ -108,11 +158,189 @@ func main() {
 	flag.StringVar(&config.Config.MPIKubectlDeliveryImage, "mpi-kubectl-delivery-image",
 		config.MPIKubectlDeliveryImageDefault, "The image for mpi launcher init container")
 
+	// TensorBoard related flags
+	flag.StringVar(&config.Config.TensorBoardImage, "tensorboard-image",
+		config.TensorBoardImageDefault, "The image of the TensorBoards requested by the spec.tensorboard of the jobs which don't set their image")
+
+	// Datasets related flags
+	flag.StringVar(&config.Config.DatasetInitializerImage, "dataset-initializer-image",
+		config.DatasetInitializerImageDefault, "The image of the init containers downloading the S3, GCS and HTTP spec.datasets of the jobs, which runs rclone")
+
+	// Metrics collector related flags
+	flag.StringVar(&config.Config.MetricsCollectorImage, "metrics-collector-image",
+		config.MetricsCollectorImageDefault, "The image of the metrics collector pushing the metrics of the jobs annotated with the mlflow metrics destination, and streaming the log of the jobs annotated with the StdOut metrics collector, which runs a POSIX shell with awk and curl")
+	flag.StringVar(&config.Config.KatibMetricsCollectorImage, "katib-metrics-collector-image",
+		config.KatibMetricsCollectorImageDefault, "The image of the Katib file metrics collector pushing the metrics of the jobs annotated with the katib metrics destination")
+	flag.StringVar(&config.Config.KatibDBManagerAddress, "katib-db-manager-address",
+		config.KatibDBManagerAddressDefault, "The address of the Katib DB manager the metrics of the jobs annotated with the katib metrics destination are pushed to")
+
+	// Event related flags
+	flag.DurationVar(&config.Config.EventDeduplicationWindow, "event-deduplication-window",
+		config.EventDeduplicationWindowDefault, "The window within which the events identical to an event already emitted for a job are dropped. "+
+			"Set to 0 to emit every event.")
+
+	// Clock related flags
+	flag.DurationVar(&config.Config.ClockSkewTolerance, "clock-skew-tolerance",
+		config.ClockSkewToleranceDefault, "The tolerated skew between the timestamps of a job stamped by the API server and by the controller. "+
+			"A timestamp ahead of the local clock by no more than the tolerance counts as the current time in the TTL, active deadline and duration computations.")
+
+	// Resync related flags
+	flag.DurationVar(&config.Config.RunningJobResyncPeriod, "running-job-resync-period", config.RunningJobResyncPeriodDefault,
+		"The period after which a running job is reconciled again without any event of its pods, so that the active deadline "+
+			"and the watchdogs of the jobs whose pods stay healthy are evaluated and their metrics refreshed. Set to 0 to only "+
+			"reconcile the running jobs on the events of their pods.")
+
+	// Storage version related flags
+	flag.Float64Var(&config.Config.StorageVersionMigrationQPS, "storage-version-migration-qps", config.StorageVersionMigrationQPSDefault,
+		"The rate of the rewrites of the jobs stored in another version than the storage version of their CRD, per second. "+
+			"Once all the jobs of a CRD are rewritten, the old versions are dropped from its storedVersions so that they can be "+
+			"removed from the CRD. Set to 0 to disable the migration.")
+
+	// Ownership related flags
+	flag.BoolVar(&config.Config.StrictOwnership, "strict-ownership", false, "Fail a job with a NameCollision condition when one of its children, "+
+		"e.g. a pod, a service or a ConfigMap, collides with an existing object of the same name which the job does not control, "+
+		"instead of adopting the object or retrying the creation.")
+
+	// Restart related flags
+	flag.IntVar(&config.Config.MaxConcurrentRestarts, "max-concurrent-restarts", 0, "The maximum number of jobs of all kinds restarting at the same time. "+
+		"The jobs which need to restart beyond it are queued with a QueuedForRestart condition. Set to 0 for no limit.")
+
+	// Network tuning related flags
+	flag.BoolVar(&config.Config.InjectNetworkTuning, "inject-network-tuning", false, "Inject the NCCL and Gloo env vars of the network tuning ConfigMap, "+
+		"e.g. NCCL_SOCKET_IFNAME, NCCL_IB_DISABLE or GLOO_SOCKET_IFNAME, into the pods of the jobs. "+
+		"A job can override it with the injectNetworkTuning field of its runPolicy.")
+	flag.StringVar(&config.Config.NetworkTuningEnvDir, "network-tuning-env-dir", config.NetworkTuningEnvDirDefault,
+		"The directory where the network tuning ConfigMap is mounted. Its keys prefixed with NCCL_ or GLOO_ are the injected env vars.")
+
+	// Service mesh related flags
+	flag.StringVar(&config.Config.ServiceMeshMode, "service-mesh-mode", string(common.ServiceMeshModeNone), "How the pods of the jobs are made "+
+		"compatible with the Istio service mesh, whose sidecar keeps the pods running once the training has finished: "+
+		"None leaves the pods as is, DisableSidecar disables the injection of the sidecar, and QuitSidecar asks the sidecar of a pod "+
+		"to quit once its other containers have terminated. A job can override it with the kubeflow.org/service-mesh-mode annotation.")
+
+	// Accelerator related flags
+	flag.StringVar(&config.Config.AcceleratorDefaultsFile, "accelerator-defaults-file", config.AcceleratorDefaultsFileDefault,
+		"The YAML file mapping the accelerator resources, e.g. nvidia.com/gpu, to the nodeSelector and the tolerations "+
+			"added to the pods of the jobs requesting them. The nodeSelector and the tolerations of a pod template take precedence.")
+
+	// Resource profile related flags
+	flag.StringVar(&config.Config.ResourceProfilesFile, "resource-profiles-file", config.ResourceProfilesFileDefault,
+		"The YAML file mapping the names of the resource profiles, e.g. gpu-large, to the requests and the limits set on the main "+
+			"container of the replicas setting them as their resourceProfile. The requests and the limits of a container take precedence.")
+
+	// Node failure related flags
+	flag.DurationVar(&config.Config.NodeFailureTimeout, "node-failure-timeout", config.NodeFailureTimeoutDefault,
+		"The time after which the pods of the jobs bound to a node which is not Ready, e.g. NotReady or unreachable, "+
+			"are force-deleted and recreated on another node. Set to 0 to leave such pods to the node lifecycle controller.")
+
+	// Image pull related flags
+	flag.Func("default-image-pull-secrets", "A comma-separated list of the image pull secrets, e.g. the credentials of the "+
+		"registry of the platform, appended to the pods of the jobs which do not reference them. The secrets must exist "+
+		"in the namespaces of the jobs.", func(value string) error {
+		names, err := common.ParseImagePullSecrets(value)
+		config.Config.DefaultImagePullSecrets = append(config.Config.DefaultImagePullSecrets, names...)
+		return err
+	})
+
+	// Security related flags
+	flag.BoolVar(&config.Config.RestrictedSecurityDefaults, "restricted-security-defaults", false,
+		"Set the security context fields required by the restricted Pod Security Standard which the pods of the jobs "+
+			"leave unset: runAsNonRoot, the RuntimeDefault seccomp profile, no privilege escalation and the ALL "+
+			"capabilities dropped. The images of the jobs, the kubectl-delivery image included, must run as non-root.")
+	flag.StringVar(&config.Config.PodSecurityConfigFile, "pod-security-config-file", "",
+		"The PodSecurityConfiguration of the PodSecurity admission of the API server, whose defaults and exemptions are "+
+			"honored when the pods of the jobs are checked against the policy of their namespace before they are created. "+
+			"If unset, the namespaces without the pod-security.kubernetes.io/enforce label are privileged and nothing is exempt.")
+	flag.StringVar(&config.Config.PodSecurityUsername, "pod-security-username", serviceAccountUsername(),
+		"The username the pods of the jobs are created by, matched against the exempt usernames of the PodSecurityConfiguration. "+
+			"Defaults to the service account of the operator.")
+
+	// Network policy related flags
+	flag.BoolVar(&config.Config.CreateNetworkPolicies, "create-network-policies", false,
+		"Create a NetworkPolicy per job restricting the ingress of its pods to the other pods of the job and to the "+
+			"--network-policy-allowed-cidrs, so that the training traffic of the tenants is isolated. The NetworkPolicies "+
+			"are deleted with the jobs.")
+	flag.Func("network-policy-allowed-cidrs", "A comma-separated list of the CIDRs, e.g. of the monitoring or of the ingress "+
+		"of the cluster, allowed to reach the pods of the jobs isolated by --create-network-policies.", func(value string) error {
+		cidrs, err := common.ParseNetworkPolicyCIDRs(value)
+		config.Config.NetworkPolicyAllowedCIDRs = append(config.Config.NetworkPolicyAllowedCIDRs, cidrs...)
+		return err
+	})
+
+	// Preemption related flags
+	flag.StringVar(&nodePreemptionTaints, "node-preemption-taints", config.NodePreemptionTaintsDefault,
+		"A comma-separated list of the keys of the taints set on the nodes about to be reclaimed, e.g. the spot instances. "+
+			"The jobs with pods on a node with one of these taints, or being deleted, are checkpointed and restarted "+
+			"before the node goes away. Set to an empty value to only react to the evictions of the pods.")
+
+	flag.BoolVar(&config.Config.EnablePriorityPreemption, "enable-priority-preemption", false,
+		"Suspend the running jobs of lower priority in the same queue, or in the same namespace for the jobs without "+
+			"queue, when a job can't be scheduled, until they free the resources requested by the job. The priorities "+
+			"are the values of the priority classes of the SchedulingPolicies of the jobs. The suspended jobs of the "+
+			"queues of --job-queues-file wait in their queue again once the job is running, the others stay suspended "+
+			"until they are resumed by their owners.")
+
+	// Queueing related flags
+	flag.StringVar(&config.Config.JobQueuesFile, "job-queues-file", "",
+		"The YAML file mapping the names of the queues of the operator to their policy, FIFO or Fair, and to their resources. "+
+			"The jobs whose SchedulingPolicy sets one of these queues are Pending until the resources they request fit in the "+
+			"resources of the queue left by the jobs it admitted, as a lightweight alternative to the queues of a gang scheduler.")
+
+	// Disruption related flags
+	flag.BoolVar(&config.Config.CreatePodDisruptionBudgets, "create-pod-disruption-budgets", false,
+		"Create a PodDisruptionBudget allowing no unavailable pod per running job, so that the voluntary disruptions, "+
+			"e.g. the node drains, wait for the jobs instead of silently breaking their synchronous training. The "+
+			"PodDisruptionBudgets are deleted once the jobs are finished or suspended.")
+
+	// Adoption related flags
+	flag.BoolVar(&config.Config.DisableOrphanPodAdoption, "disable-orphan-pod-adoption", false,
+		"Leave the orphan pods matching the labels of the jobs as is instead of adopting them, e.g. during a migration of the operator.")
+	flag.Float64Var(&config.Config.OrphanPodAdoptionQPS, "orphan-pod-adoption-qps", config.OrphanPodAdoptionQPSDefault,
+		"The overall rate of the adoptions of the orphan pods by the jobs of all kinds, per second, so that the orphan pods "+
+			"left by an upgrade of the operator are not all patched at once. Set to 0 for no limit.")
+	flag.IntVar(&config.Config.OrphanPodAdoptionBurst, "orphan-pod-adoption-burst", config.OrphanPodAdoptionBurstDefault,
+		"The overall burst of the adoptions of the orphan pods.")
+	flag.IntVar(&config.Config.OrphanPodAdoptionBatchSize, "orphan-pod-adoption-batch-size", config.OrphanPodAdoptionBatchSizeDefault,
+		"The maximum number of orphan pods adopted by a job per reconcile. Set to 0 for no limit.")
+
+	// Priority class related flags
+	flag.Func("default-priority-classes", "A comma-separated list of <namespace>=<priority class> pairs of the priority "+
+		"classes set to the pods and the PodGroups of the jobs of the namespaces which do not set one, e.g. "+
+		"--default-priority-classes=research=low-priority,production=high-priority.", func(value string) error {
+		priorityClasses, err := common.ParseDefaultPriorityClasses(value)
+		if config.Config.DefaultPriorityClasses == nil {
+			config.Config.DefaultPriorityClasses = map[string]string{}
+		}
+		for namespace, priorityClass := range priorityClasses {
+			config.Config.DefaultPriorityClasses[namespace] = priorityClass
+		}
+		return err
+	})
+
+	// Shutdown related flags
+	flag.DurationVar(&config.Config.ShutdownDrainTimeout, "shutdown-drain-timeout", config.ShutdownDrainTimeoutDefault,
+		"The time given on SIGTERM to the reconciles in flight and the jobs still queued to complete their status writes "+
+			"before the operator exits. Set to 0 to cancel the reconciles as soon as the shutdown starts.")
+
+	// Controller identity related flags
+	flag.StringVar(&config.Config.ControllerIdentity, "controller-identity", "",
+		"The identity of the operator deployment, e.g. stable or canary, which reconciles the jobs pinned to it by the "+
+			"kubeflow.org/controller-identity annotation. The deployments of different identities elect separate leaders.")
+	flag.BoolVar(&config.Config.SkipUnpinnedJobs, "skip-unpinned-jobs", false,
+		"Skip the jobs without the kubeflow.org/controller-identity annotation, e.g. in a canary deployment running "+
+			"side by side with the stable deployment which reconciles them.")
+
+	// Feature gates
+	flag.Var(features.Default, "feature-gates", "A set of <feature>=<true|false> pairs of the features not enabled by default, "+
+		"e.g. --feature-gates=StatusDiffLogging=true to log a structured diff of the status of a job on each of its updates.")
+
 	// Cert generation flags
 	flag.IntVar(&webhookServerPort, "webhook-server-port", 9443, "Endpoint port for the webhook server.")
 	flag.StringVar(&webhookServiceName, "webhook-service-name", "training-operator", "Name of the Service used as part of the DNSName")
 	flag.StringVar(&webhookSecretName, "webhook-secret-name", "training-operator-webhook-cert", "Name of the Secret to store CA  and server certs")
 
+	// The log level and the format are set with --zap-log-level and --zap-encoder,
+	// e.g. --zap-log-level=info --zap-encoder=json for logs ingested by a log pipeline.
 	opts := zap.Options{
 		Development:     true,
 		StacktraceLevel: zapcore.DPanicLevel,
@@ -120,14 +348,55 @@ func main() {
 	opts.BindFlags(flag.CommandLine)
 	flag.Parse()
 
-	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
+	logger := zap.New(zap.UseFlagOptions(&opts))
+	ctrl.SetLogger(logger)
+	// Route the logs of client-go through the same logger, so that all logs share the same format.
+	klog.SetLogger(logger)
+
+	var configFileOpts *config.File
+	if configFile != "" {
+		var err error
+		if configFileOpts, err = config.LoadFile(configFile); err == nil {
+			err = configFileOpts.Apply(flag.CommandLine)
+		}
+		if err != nil {
+			setupLog.Error(err, "invalid --config", "file", configFile)
+			os.Exit(1)
+		}
+	}
+
+	if errs := validation.IsDNS1123Label(config.Config.ControllerIdentity); config.Config.ControllerIdentity != "" && len(errs) != 0 {
+		setupLog.Error(errors.New(strings.Join(errs, ", ")), "invalid --controller-identity", "identity", config.Config.ControllerIdentity)
+		os.Exit(1)
+	}
+
+	if err := common.ValidateRateLimiterConfig(); err != nil {
+		setupLog.Error(err, "invalid workqueue flags")
+		os.Exit(1)
+	}
+
+	preemptionTaints, err := common.ParseNodePreemptionTaints(nodePreemptionTaints)
+	if err != nil {
+		setupLog.Error(err, "invalid --node-preemption-taints", "taints", nodePreemptionTaints)
+		os.Exit(1)
+	}
+	config.Config.NodePreemptionTaints = preemptionTaints
 
-	var cacheOpts cache.Options
+	if !common.ValidServiceMeshMode(config.Config.ServiceMeshMode) {
+		setupLog.Error(errors.New("unknown service mesh mode"), "invalid --service-mesh-mode", "mode", config.Config.ServiceMeshMode)
+		os.Exit(1)
+	}
+
+	cacheOpts := cache.Options{
+		// The operator only reads the Deployments of the TensorBoards of the jobs, so the other
+		// Deployments of the cluster are not cached.
+		ByObject: map[client.Object]cache.ByObject{
+			&appsv1.Deployment{}: {Label: common.TensorBoardSelector()},
+		},
+	}
 	if namespace != "" {
-		cacheOpts = cache.Options{
-			DefaultNamespaces: map[string]cache.Config{
-				namespace: {},
-			},
+		cacheOpts.DefaultNamespaces = map[string]cache.Config{
+			namespace: {},
 		}
 	}
 
@@ -141,8 +410,17 @@ func main() {
 		}),
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
-		LeaderElectionID:       leaderElectionID,
+		LeaderElectionID:       common.ControllerLeaderElectionID(leaderElectionID, config.Config.ControllerIdentity),
+		LeaseDuration:          &leaseDuration,
+		RenewDeadline:          &renewDeadline,
+		RetryPeriod:            &retryPeriod,
 		Cache:                  cacheOpts,
+		// Only the leader reconciles the jobs, as the expectations of the created pods and services
+		// are not shared between the replicas. The lease is released on shutdown, so that another
+		// replica takes over without waiting for the lease to expire.
+		LeaderElectionReleaseOnCancel: enableLeaderElection,
+		// Wait for the controllers draining their work on shutdown.
+		GracefulShutdownTimeout: gracefulShutdownTimeout(config.Config.ShutdownDrainTimeout),
 	})
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
@@ -152,9 +430,11 @@ func main() {
 	certsReady := make(chan struct{})
 	defer close(certsReady)
 	certGenerationConfig := cert.Config{
-		WebhookSecretName:        webhookSecretName,
-		WebhookServiceName:       webhookServiceName,
-		WebhookConfigurationName: webhookConfigurationName,
+		WebhookSecretName:                webhookSecretName,
+		WebhookServiceName:               webhookServiceName,
+		WebhookConfigurationName:         webhookConfigurationName,
+		MutatingWebhookConfigurationName: mutatingWebhookConfigurationName,
+		ConversionCRDNames:               []string{kubeflowv1.MPIJobPlural + "." + kubeflowv1.GroupVersion.Group},
 	}
 	if err = cert.ManageCerts(mgr, certGenerationConfig, certsReady); err != nil {
 		setupLog.Error(err, "Unable to set up cert rotation")
@@ -162,43 +442,190 @@ func main() {
 	}
 
 	setupProbeEndpoints(mgr, certsReady)
+	// The jobs of the registry shared by the controllers are reported by the job collectors.
+	trainingoperatorcommon.RegisterJobCollectors(registry.Default)
 	// Set up controllers using goroutines to start the manager quickly.
-	go setupControllers(mgr, enabledSchemes, gangSchedulerName, controllerThreads, certsReady)
+	go setupControllers(mgr, enabledSchemes, gangSchedulerName, controllerThreads, controllerThreadsPerKind, certsReady)
 
 	//+kubebuilder:scaffold:builder
 
+	ctx, cancel := context.WithCancel(ctrl.SetupSignalHandler())
+	defer cancel()
+	if configFileOpts != nil && configFileOpts.ReloadOnChange {
+		// The operator exits once the configuration file changes, and is restarted by its Deployment
+		// with the new configuration.
+		watcher, err := config.NewFileWatcher(configFile, cancel)
+		if err == nil {
+			err = mgr.Add(watcher)
+		}
+		if err != nil {
+			setupLog.Error(err, "unable to watch the configuration file", "file", configFile)
+			os.Exit(1)
+		}
+	}
+
+	if config.Config.StorageVersionMigrationQPS > 0 {
+		// The jobs stored in the old versions are rewritten in the storage version of their CRD
+		// after it is bumped, so that the old versions can be removed from the CRD.
+		var crdNames []string
+		for _, plural := range []string{kubeflowv1.TFJobPlural, kubeflowv1.PyTorchJobPlural, kubeflowv1.XGBoostJobPlural,
+			kubeflowv1.MPIJobPlural, kubeflowv1.PaddleJobPlural, kubeflowv1.JAXJobPlural} {
+			crdNames = append(crdNames, plural+"."+kubeflowv1.GroupVersion.Group)
+		}
+		migrator := storageversion.NewMigrator(mgr.GetClient(), mgr.GetAPIReader(), crdNames, config.Config.StorageVersionMigrationQPS)
+		if err := mgr.Add(migrator); err != nil {
+			setupLog.Error(err, "unable to set up the storage version migrator")
+			os.Exit(1)
+		}
+	}
+
 	setupLog.Info("starting manager")
-	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+	if err := mgr.Start(ctx); err != nil {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
 }
 
-func setupControllers(mgr ctrl.Manager, enabledSchemes controllerv1.EnabledSchemes, gangSchedulerName string, controllerThreads int, certsReady <-chan struct{}) {
+func setupControllers(mgr ctrl.Manager, enabledSchemes controllerv1.EnabledSchemes, gangSchedulerName string, controllerThreads int,
+	controllerThreadsPerKind controllerv1.ControllerThreads, certsReady <-chan struct{}) {
 	setupLog.Info("Waiting for certificate generation to complete")
 	<-certsReady
 	setupLog.Info("Certs ready")
 
 	setupLog.Info("registering controllers...")
 	// Prepare GangSchedulingSetupFunc
+	// The clients of the PodGroups are only created if their CRD is installed, so that the operator
+	// runs in the clusters without volcano or scheduler-plugins.
+	var podGroupClients common.PodGroupClients
+	if crdInstalled(mgr, common.VolcanoPodGroupGVK) {
+		podGroupClients.Volcano = volcanoclient.NewForConfigOrDie(mgr.GetConfig())
+	}
+	if crdInstalled(mgr, common.SchedulerPluginsPodGroupGVK) {
+		podGroupClients.SchedulerPlugins = mgr.GetClient()
+	}
+	// The jobs are scheduled without gang scheduling if the CRD of the PodGroups of the gang
+	// scheduler is missing, rather than failing to create their PodGroups.
 	gangSchedulingSetupFunc := common.GenNonGangSchedulerSetupFunc()
-	if strings.EqualFold(gangSchedulerName, string(common.GangSchedulerVolcano)) {
-		cfg := mgr.GetConfig()
-		volcanoClientSet := volcanoclient.NewForConfigOrDie(cfg)
-		gangSchedulingSetupFunc = common.GenVolcanoSetupFunc(volcanoClientSet)
-		gvk := v1beta1.SchemeGroupVersion.WithKind("PodGroup")
-		validateCRD(mgr, gvk)
-	} else if gangSchedulerName != "" {
-		gangSchedulingSetupFunc = common.GenSchedulerPluginsSetupFunc(mgr.GetClient(), gangSchedulerName)
-		gvk := schedulerpluginsv1alpha1.SchemeGroupVersion.WithKind("PodGroup")
-		validateCRD(mgr, gvk)
+	isVolcano := strings.EqualFold(gangSchedulerName, string(common.GangSchedulerVolcano))
+	switch {
+	case isVolcano && podGroupClients.Volcano != nil:
+		gangSchedulingSetupFunc = common.GenVolcanoSetupFunc(podGroupClients.Volcano)
+	case !isVolcano && gangSchedulerName != "" && podGroupClients.SchedulerPlugins != nil:
+		gangSchedulingSetupFunc = common.GenSchedulerPluginsSetupFunc(podGroupClients.SchedulerPlugins, gangSchedulerName)
+	case gangSchedulerName != "":
+		gvk := common.PodGroupGVK(common.GangScheduler(gangSchedulerName))
+		setupLog.Error(errors.New("crd might be missing, please install crd"), "gang scheduling is disabled",
+			"gangSchedulerName", gangSchedulerName, "apiVersion", gvk.GroupVersion().String(), "kind", gvk.Kind)
 	}
 
+	// Prepare the JobControllerOptions shared by the controllers of all kinds.
+	options := common.JobControllerOptions{
+		// The PodGroups recorded in the status of the jobs are deleted even if they were created by
+		// another gang scheduler, e.g. before the gang scheduling was disabled.
+		PodGroupClients: podGroupClients,
+		// The RayClusters requested by the jobs are handled as unstructured objects, so that the
+		// operator doesn't depend on KubeRay unless a job requests a RayCluster.
+		RayClusterClient: mgr.GetClient(),
+		// The TensorBoards are only created for the jobs which request one.
+		TensorBoardClient: mgr.GetClient(),
+	}
+	// The NetworkPolicies of the jobs are only created in the opt-in mode, since they deny the
+	// ingress of the pods of the jobs from outside of the jobs.
+	if config.Config.CreateNetworkPolicies {
+		options.NetworkPolicyClient = mgr.GetClient()
+	}
+	// The node drains are blocked by the running jobs only in the opt-in mode.
+	if config.Config.CreatePodDisruptionBudgets {
+		options.PodDisruptionBudgetClient = mgr.GetClient()
+	}
+	// The jobs are restarted ahead of the preemptions of their nodes unless no preemption taint is configured.
+	if len(config.Config.NodePreemptionTaints) > 0 {
+		if err := common.IndexPodNodeName(context.Background(), mgr.GetFieldIndexer()); err != nil {
+			setupLog.Error(err, "unable to index the pods by node")
+			os.Exit(1)
+		}
+		options.PreemptionClient = mgr.GetClient()
+	}
+	// The running jobs are suspended for the unschedulable jobs of higher priority only in the opt-in mode.
+	if config.Config.EnablePriorityPreemption {
+		options.PriorityPreemptionClient = mgr.GetClient()
+	}
+	// The queues are shared by the controllers of all kinds, so that their budgets are operator-wide.
+	if config.Config.JobQueuesFile != "" {
+		queues, err := common.LoadJobQueues(config.Config.JobQueuesFile)
+		if err != nil {
+			setupLog.Error(err, "unable to load the job queues", "file", config.Config.JobQueuesFile)
+			os.Exit(1)
+		}
+		options.JobQueues = queues
+	}
+	// A single RestartLimiter is shared by the controllers of all kinds, so that the limit is operator-wide.
+	if config.Config.MaxConcurrentRestarts > 0 {
+		options.RestartLimiter = common.NewRestartLimiter(config.Config.MaxConcurrentRestarts)
+	}
+	// A single AdoptionLimiter is shared by the controllers of all kinds, so that the rate is operator-wide.
+	if config.Config.OrphanPodAdoptionQPS > 0 && config.Config.OrphanPodAdoptionBurst > 0 {
+		options.AdoptionLimiter = common.NewAdoptionLimiter(
+			config.Config.OrphanPodAdoptionQPS, config.Config.OrphanPodAdoptionBurst, config.Config.OrphanPodAdoptionBatchSize)
+	}
+
+	// The nodes of the pods are only watched to reschedule the pods of the failed nodes.
+	if config.Config.NodeFailureTimeout > 0 {
+		informer, err := mgr.GetCache().GetInformer(context.Background(), &corev1.Node{})
+		if err != nil {
+			setupLog.Error(err, "unable to get the informer of the nodes")
+			os.Exit(1)
+		}
+		indexInformer, ok := informer.(toolscache.SharedIndexInformer)
+		if !ok {
+			setupLog.Error(errors.New("informer is not indexed"), "unable to get the informer of the nodes")
+			os.Exit(1)
+		}
+		options.NodeLister = corelisters.NewNodeLister(indexInformer.GetIndexer())
+	}
+
+	// The namespaces of the jobs are watched to check their pods against the PodSecurity policy of
+	// the namespaces before the pods are created.
+	podSecurityChecker, err := podsecurity.NewChecker(config.Config.PodSecurityConfigFile, config.Config.PodSecurityUsername)
+	if err != nil {
+		setupLog.Error(err, "unable to load the pod security configuration", "file", config.Config.PodSecurityConfigFile)
+		os.Exit(1)
+	}
+	informer, err := mgr.GetCache().GetInformer(context.Background(), &corev1.Namespace{})
+	if err != nil {
+		setupLog.Error(err, "unable to get the informer of the namespaces")
+		os.Exit(1)
+	}
+	indexInformer, ok := informer.(toolscache.SharedIndexInformer)
+	if !ok {
+		setupLog.Error(errors.New("informer is not indexed"), "unable to get the informer of the namespaces")
+		os.Exit(1)
+	}
+	options.PodSecurityChecker = podSecurityChecker
+	options.NamespaceLister = corelisters.NewNamespaceLister(indexInformer.GetIndexer())
+
 	// TODO: We need a general manager. all rest reconciler addsToManager
 	// Based on the user configuration, we start different controllers
-	if enabledSchemes.Empty() {
+	// The schemes enabled by default are the ones whose CRDs are installed, so that the operator
+	// doesn't need the CRDs of the kinds it doesn't reconcile. The CRDs of the schemes enabled
+	// explicitly must be installed.
+	fillAll := enabledSchemes.Empty()
+	if fillAll {
 		enabledSchemes.FillAll()
 	}
+	installed, missing, err := enabledSchemes.Installed(mgr.GetRESTMapper())
+	if err != nil {
+		setupLog.Error(err, "unable to get the crds of the schemes")
+		os.Exit(1)
+	}
+	if len(missing) != 0 && !fillAll {
+		setupLog.Error(errors.New("crd might be missing, please install crd"), "schemes are not installed", "schemes", missing.String())
+		os.Exit(1)
+	}
+	if len(missing) != 0 {
+		setupLog.Info("Skipping the schemes whose crds are not installed", "schemes", missing.String())
+	}
+	enabledSchemes = installed
 	errMsg := "failed to set up controllers"
 	for _, s := range enabledSchemes {
 		setupReconcilerFunc, supportedReconciler := controllerv1.SupportedSchemeReconciler[s]
@@ -206,7 +633,7 @@ func setupControllers(mgr ctrl.Manager, enabledSchemes controllerv1.EnabledSchem
 			setupLog.Error(errors.New(errMsg), "scheme is not supported", "scheme", s)
 			os.Exit(1)
 		}
-		if err := setupReconcilerFunc(mgr, gangSchedulingSetupFunc, controllerThreads); err != nil {
+		if err := setupReconcilerFunc(mgr, gangSchedulingSetupFunc, options, controllerThreadsPerKind.Get(s, controllerThreads)); err != nil {
 			setupLog.Error(errors.New(errMsg), "unable to create controller", "scheme", s)
 			os.Exit(1)
 		}
@@ -250,14 +677,33 @@ func setupProbeEndpoints(mgr ctrl.Manager, certsReady <-chan struct{}) {
 	}
 }
 
-func validateCRD(mgr ctrl.Manager, gvk schema.GroupVersionKind) {
-	_, err := mgr.GetRESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version)
+// podGroupInstalled returns whether the CRD of the PodGroups of the kind is installed.
+func crdInstalled(mgr ctrl.Manager, gvk schema.GroupVersionKind) bool {
+	installed, err := common.KindInstalled(mgr.GetRESTMapper(), gvk)
 	if err != nil {
-		if meta.IsNoMatchError(err) {
-			setupLog.Error(err, "crd might be missing, please install crd", "apiVersion", gvk.GroupVersion().String(), "kind", gvk.Kind)
-			os.Exit(1)
-		}
 		setupLog.Error(err, "unable to get crd", "apiVersion", gvk.GroupVersion().String(), "kind", gvk.Kind)
 		os.Exit(1)
 	}
+	return installed
+}
+
+// gracefulShutdownTimeout returns the time the manager waits for its runnables on shutdown: the
+// drain timeout of the controllers with a margin for the reconciles canceled at its expiry to
+// return, or nil for the default of controller-runtime if the controllers do not drain their work.
+func gracefulShutdownTimeout(drainTimeout time.Duration) *time.Duration {
+	if drainTimeout <= 0 {
+		return nil
+	}
+	timeout := drainTimeout + 5*time.Second
+	return &timeout
+}
+
+// serviceAccountUsername returns the username of the service account of the operator pod, or an
+// empty string outside of a pod.
+func serviceAccountUsername() string {
+	namespace, name := os.Getenv(EnvPodNamespace), os.Getenv(EnvPodServiceAccount)
+	if namespace == "" || name == "" {
+		return ""
+	}
+	return "system:serviceaccount:" + namespace + ":" + name
 }
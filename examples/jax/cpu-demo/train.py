@@ -13,11 +13,9 @@ jax.config.update("jax_cpu_collectives_implementation", "gloo")
 
 def _main(argv):
 
-    process_id = int(os.getenv("PROCESS_ID"))
-    num_processes = int(os.getenv("NUM_PROCESSES"))
-    coordinator_address = os.getenv("COORDINATOR_ADDRESS")
-    coordinator_port = int(os.getenv("COORDINATOR_PORT"))
-    coordinator_address = f"{coordinator_address}:{coordinator_port}"
+    process_id = int(os.getenv("JAX_PROCESS_ID"))
+    num_processes = int(os.getenv("JAX_NUM_PROCESSES"))
+    coordinator_address = os.getenv("JAX_COORDINATOR_ADDRESS")
 
     jax.distributed.initialize(
         coordinator_address=coordinator_address,
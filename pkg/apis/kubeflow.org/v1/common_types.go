@@ -18,9 +18,16 @@ import (
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 const (
+	// ControllerLabelPrefix is the prefix of the labels which the controller sets on the pods of
+	// the jobs. It is reserved to the controller: the other labels and the annotations of the
+	// pod templates of the jobs are propagated to their pods verbatim.
+	ControllerLabelPrefix = "training.kubeflow.org/"
+
 	// ReplicaIndexLabel represents the label key for the replica-index, e.g. 0, 1, 2.. etc
 	ReplicaIndexLabel = "training.kubeflow.org/replica-index"
 
@@ -36,11 +43,135 @@ const (
 	// JobRoleLabel represents the label key for the job role, e.g. master.
 	JobRoleLabel = "training.kubeflow.org/job-role"
 
+	// TensorBoardLabel represents the label key of the pods of the TensorBoard of a job, the value
+	// is the job name.
+	TensorBoardLabel = "training.kubeflow.org/tensorboard"
+
+	// RunIDLabel represents the label key for the run ID of the job, set on its pods and the other
+	// resources created for it, so that their logs and metrics can be correlated across retries.
+	RunIDLabel = "training.kubeflow.org/run-id"
+
 	// KubeflowJobsController represents the value of the default jobs controller
 	KubeflowJobsController = "kubeflow.org/training-operator"
 
 	// MultiKueueController represents the MultiKueue controller
 	MultiKueueController = "kueue.x-k8s.io/multikueue"
+
+	// RestartedAtAnnotation represents the annotation key which requests a full restart of a running job
+	// when its value changes, e.g. to the current time. The value is copied to the pods of the job,
+	// and the pods with another value are deleted and recreated.
+	RestartedAtAnnotation = "kubeflow.org/restartedAt"
+
+	// CheckpointDeadlineAnnotation represents the annotation key set by the operator on the running pods
+	// asked to save a checkpoint by the CheckpointPolicy of their job, to the RFC 3339 time after which
+	// they are deleted whether the checkpoint completed or not.
+	CheckpointDeadlineAnnotation = "kubeflow.org/checkpoint-deadline"
+
+	// ServiceMeshModeAnnotation represents the annotation key which sets the service mesh compatibility
+	// mode of a job, overriding the --service-mesh-mode flag of the operator. With DisableSidecar, the
+	// injection of the Istio sidecar into the pods of the job is disabled. With QuitSidecar, the Istio
+	// sidecar of a pod is asked to quit once the other containers of the pod have terminated, so that
+	// the pod completes. With None, the pods are left as is.
+	ServiceMeshModeAnnotation = "kubeflow.org/service-mesh-mode"
+
+	// DryRunAnnotation represents the annotation key which holds the creation of the launcher and
+	// worker pods of an MPIJob for review when its value is "true". The rendered pods are written
+	// into the <job-name>-review ConfigMap and the job is PendingApproval until it is approved.
+	DryRunAnnotation = "kubeflow.org/dry-run"
+
+	// ApprovedAnnotation represents the annotation key which approves the creation of the pods of
+	// a job held by the DryRunAnnotation when its value is "true".
+	ApprovedAnnotation = "kubeflow.org/approved"
+
+	// RunIDAnnotation represents the annotation key which sets the run ID of a job, e.g. to the ID of
+	// the run of an experiment tracker. It must be a valid label value. The run ID defaults to the
+	// UID of the job, and a change only applies to the pods and resources created afterwards.
+	RunIDAnnotation = "kubeflow.org/run-id"
+
+	// ControllerIdentityAnnotation represents the annotation key which pins a job to the operator deployment
+	// started with the same --controller-identity, e.g. stable or canary, so that exactly one of the deployments
+	// running side by side reconciles the job. The jobs without the annotation are reconciled by the deployments
+	// which are not started with --skip-unpinned-jobs.
+	ControllerIdentityAnnotation = "kubeflow.org/controller-identity"
+
+	// PreemptionRestartCountAnnotation represents the annotation key set by the operator to the number
+	// of restarts of a job ahead of the reclaim of the node of one of its pods, e.g. a spot instance.
+	PreemptionRestartCountAnnotation = "kubeflow.org/preemption-restart-count"
+
+	// PreemptionRestartCauseAnnotation represents the annotation key set by the operator to the cause
+	// of the last restart of a job ahead of the reclaim of the node of one of its pods.
+	PreemptionRestartCauseAnnotation = "kubeflow.org/preemption-restart-cause"
+
+	// PreemptionRestartTimeAnnotation represents the annotation key set by the operator to the time, in
+	// RFC 3339, of the last restart of a job ahead of the reclaim of the node of one of its pods. The next
+	// such restart is delayed by a backoff growing with the number of restarts.
+	PreemptionRestartTimeAnnotation = "kubeflow.org/preemption-restart-time"
+
+	// PriorityPreemptedByAnnotation represents the annotation key set by the operator on a job it suspended
+	// to free the resources of a job of higher priority, to the kind, namespace and name of the latter, e.g.
+	// "PyTorchJob default/train". The jobs of the queues of the operator are resumed once the job of higher
+	// priority is running, the others stay suspended until they are resumed by their owners.
+	PriorityPreemptedByAnnotation = "kubeflow.org/priority-preempted-by"
+
+	// PriorityPreemptedJobsAnnotation represents the annotation key set by the operator on a job of higher
+	// priority to the comma-separated kinds, namespaces and names of the jobs it last suspended to free
+	// their resources for the job, e.g. "PyTorchJob default/a,TFJob default/b". No more job is suspended
+	// for the job until the pods of these jobs are gone and the grace period of the preemption has passed.
+	PriorityPreemptedJobsAnnotation = "kubeflow.org/priority-preempted-jobs"
+
+	// PriorityPreemptionTimeAnnotation represents the annotation key set by the operator on a job of higher
+	// priority to the time, in RFC 3339, the jobs of its PriorityPreemptedJobsAnnotation were suspended.
+	PriorityPreemptionTimeAnnotation = "kubeflow.org/priority-preemption-time"
+
+	// JobClassAnnotation represents the annotation key set by the operator to the name of the
+	// TrainingJobClass whose defaults are merged into a job, so that they are merged only once.
+	JobClassAnnotation = "kubeflow.org/job-class"
+
+	// MetricsCollectorAnnotation represents the annotation key which injects a metrics collector into
+	// the pod of the master role of a job, pushing the final values of its metrics to the destination
+	// set in the MetricsDestinationAnnotation, without changing the training image. The value is
+	// where the metrics are read from: StdOut, the log of the main container, which the service
+	// account of the pod must be allowed to get, or File, the file set in the MetricsFileAnnotation.
+	// The metrics are read as <name>=<value> pairs.
+	MetricsCollectorAnnotation = "kubeflow.org/metrics-collector"
+
+	// MetricsFileAnnotation represents the annotation key which sets the absolute path of the file
+	// of the metrics written by the main container of a job with the File metrics collector.
+	MetricsFileAnnotation = "kubeflow.org/metrics-file"
+
+	// MetricsNamesAnnotation represents the annotation key which sets the comma-separated names of
+	// the metrics collected from a job, e.g. accuracy,loss.
+	MetricsNamesAnnotation = "kubeflow.org/metrics-names"
+
+	// MetricsDestinationAnnotation represents the annotation key which sets where the metrics
+	// collected from a job are pushed: mlflow, to a new run of the experiment set in the
+	// MLflowExperimentIDAnnotation, or katib, to the observation log of the Katib trial set in the
+	// KatibTrialLabel of the job.
+	MetricsDestinationAnnotation = "kubeflow.org/metrics-destination"
+
+	// MLflowTrackingURIAnnotation represents the annotation key which sets the URL of the MLflow
+	// tracking server the metrics of a job are pushed to, e.g. http://mlflow.mlflow:5000.
+	MLflowTrackingURIAnnotation = "kubeflow.org/mlflow-tracking-uri"
+
+	// MLflowExperimentIDAnnotation represents the annotation key which sets the ID of the MLflow
+	// experiment of the run of a job. Defaults to the default experiment 0.
+	MLflowExperimentIDAnnotation = "kubeflow.org/mlflow-experiment-id"
+
+	// KatibTrialLabel represents the label key set by Katib to the name of the trial of the jobs it
+	// runs. Only the metrics of the jobs of a trial can be pushed to Katib.
+	KatibTrialLabel = "katib.kubeflow.org/trial"
+)
+
+const (
+	// MetricsCollectorStdOut collects the metrics from the log of the main container.
+	MetricsCollectorStdOut = "StdOut"
+	// MetricsCollectorFile collects the metrics from the file set in the MetricsFileAnnotation.
+	MetricsCollectorFile = "File"
+
+	// MetricsDestinationMLflow pushes the metrics to an MLflow tracking server.
+	MetricsDestinationMLflow = "mlflow"
+	// MetricsDestinationKatib pushes the metrics to the Katib DB manager.
+	MetricsDestinationKatib = "katib"
 )
 
 // JobStatus represents the current observed state of the training Job.
@@ -48,6 +179,16 @@ type JobStatus struct {
 	// Conditions is an array of current observed job conditions.
 	Conditions []JobCondition `json:"conditions,omitempty"`
 
+	// Phase is the phase of the job rolled up from its conditions: Created, Running,
+	// Restarting, Succeeded, Failed or Suspended.
+	// +optional
+	Phase JobPhase `json:"phase,omitempty"`
+
+	// RunID is the run ID of the job, set as the training.kubeflow.org/run-id label of its pods
+	// and of the other resources created for it.
+	// +optional
+	RunID string `json:"runID,omitempty"`
+
 	// ReplicaStatuses is map of ReplicaType and ReplicaStatus,
 	// specifies the status of each replica.
 	ReplicaStatuses map[ReplicaType]*ReplicaStatus `json:"replicaStatuses,omitempty"`
@@ -62,10 +203,73 @@ type JobStatus struct {
 	// It is represented in RFC3339 form and is in UTC.
 	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
 
+	// Represents the duration of the job from its StartTime to its CompletionTime.
+	// It is set when the job succeeds or fails.
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
 	// Represents last time when the job was reconciled. It is not guaranteed to
 	// be set in happens-before order across separate operations.
 	// It is represented in RFC3339 form and is in UTC.
 	LastReconcileTime *metav1.Time `json:"lastReconcileTime,omitempty"`
+
+	// GangScheduling records the PodGroup created for the job by a gang scheduler,
+	// so that it is cleaned up even if the gang scheduling is disabled or switched
+	// to another scheduler afterwards.
+	GangScheduling *GangSchedulingStatus `json:"gangScheduling,omitempty"`
+
+	// GangRestarts is the number of times all the pods of the job were restarted together
+	// because a pod of a replica type whose RestartPolicy is GangRestart failed. The job
+	// fails once it exceeds the runPolicy.backoffLimit.
+	// +optional
+	GangRestarts int32 `json:"gangRestarts,omitempty"`
+
+	// TensorBoardURL is the in-cluster URL of the TensorBoard requested by the spec.tensorboard
+	// of the job, set once its Deployment and Service are created.
+	// +optional
+	TensorBoardURL string `json:"tensorBoardURL,omitempty"`
+
+	// Outputs are the URIs of the artifacts declared by the spec.outputs of the job, e.g. its
+	// trained model, recorded once the job has succeeded.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Outputs []OutputStatus `json:"outputs,omitempty"`
+}
+
+// JobPhase is the phase of a job rolled up from its conditions.
+// +kubebuilder:validation:Enum=Created;Running;Restarting;Succeeded;Failed;Suspended
+type JobPhase string
+
+const (
+	// JobPhaseCreated means the job has been accepted by the system and none of its
+	// pods is running yet.
+	JobPhaseCreated JobPhase = "Created"
+
+	// JobPhaseRunning means the job is running.
+	JobPhaseRunning JobPhase = "Running"
+
+	// JobPhaseRestarting means some pods of the job are being restarted.
+	JobPhaseRestarting JobPhase = "Restarting"
+
+	// JobPhaseSucceeded means the job has succeeded.
+	JobPhaseSucceeded JobPhase = "Succeeded"
+
+	// JobPhaseFailed means the job has failed.
+	JobPhaseFailed JobPhase = "Failed"
+
+	// JobPhaseSuspended means the job is suspended.
+	JobPhaseSuspended JobPhase = "Suspended"
+)
+
+// GangSchedulingStatus represents the PodGroup created for a job by a gang scheduler.
+type GangSchedulingStatus struct {
+	// SchedulerName is the name of the gang scheduler the PodGroup was created for,
+	// e.g. volcano or the name of the scheduler-plugins scheduler.
+	SchedulerName string `json:"schedulerName"`
+
+	// MinMember is the minimum number of members last set on the PodGroup.
+	MinMember int32 `json:"minMember,omitempty"`
 }
 
 // ReplicaType represents the type of the replica. Each operator needs to define its
@@ -90,6 +294,39 @@ type ReplicaStatus struct {
 	// matchExpressions are ANDed. An empty Selector matches all objects. A null
 	// Selector matches no objects.
 	Selector string `json:"selector,omitempty"`
+
+	// The number of failed pods which did not fail the job, as they are
+	// within the tolerated failures of the replica type.
+	ToleratedFailed int32 `json:"toleratedFailed,omitempty"`
+
+	// FailureReason is the reason of the failure of a failed pod of the replica type,
+	// or of a Pending pod of the replica type whose image cannot be pulled.
+	// +optional
+	FailureReason PodFailureReason `json:"failureReason,omitempty"`
+
+	// Pods are the pods of the replica type ordered by their replica index, e.g. for the SDKs
+	// and the UIs to locate their logs without listing the pods.
+	// +optional
+	// +listType=atomic
+	Pods []ReplicaPodStatus `json:"pods,omitempty"`
+}
+
+// ReplicaPodStatus represents the current observed state of a pod of a replica type.
+type ReplicaPodStatus struct {
+	// Name of the pod.
+	Name string `json:"name"`
+
+	// Phase of the pod.
+	// +optional
+	Phase v1.PodPhase `json:"phase,omitempty"`
+
+	// HostIP is the IP address of the node the pod is bound to.
+	// +optional
+	HostIP string `json:"hostIP,omitempty"`
+
+	// PodIP is the IP address allocated to the pod.
+	// +optional
+	PodIP string `json:"podIP,omitempty"`
 }
 
 // ReplicaSpec is a description of the replica
@@ -104,9 +341,30 @@ type ReplicaSpec struct {
 	Template v1.PodTemplateSpec `json:"template,omitempty"`
 
 	// Restart policy for all replicas within the job.
-	// One of Always, OnFailure, Never and ExitCode.
+	// One of Always, OnFailure, Never, ExitCode and GangRestart.
 	// Default to Never.
 	RestartPolicy RestartPolicy `json:"restartPolicy,omitempty"`
+
+	// ToleratedFailures is the number of failed pods of the replica type which
+	// do not fail the job. It is only supported for the Evaluator replica type of TFJob,
+	// so that a few failed evaluator pods do not fail the whole training.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	ToleratedFailures *int32 `json:"toleratedFailures,omitempty"`
+
+	// CapacityType is the capacity type of the nodes the pods of the replica type run on.
+	// One of spot, on-demand and prefer-spot. The pods get the node affinity and the tolerations
+	// which match the spot node pools of EKS, GKE and AKS.
+	// Defaults to unset, the pods can run on any node.
+	// +kubebuilder:validation:Enum=spot;on-demand;prefer-spot
+	// +optional
+	CapacityType CapacityType `json:"capacityType,omitempty"`
+
+	// ResourceProfile is the name of the resource profile of the operator, e.g. gpu-large,
+	// whose requests and limits are set on the main container of the pods of the replica type.
+	// The requests and the limits of the container take precedence over the profile.
+	// +optional
+	ResourceProfile string `json:"resourceProfile,omitempty"`
 }
 
 // JobCondition describes the state of the job at a certain point.
@@ -157,9 +415,59 @@ const (
 	// reached phase failed with no restarting.
 	// The training has failed its execution.
 	JobFailed JobConditionType = "Failed"
+
+	// JobHostsInsufficient means the launcher of an elastic MPIJob runs with fewer
+	// running workers than the minReplicas of its elastic policy.
+	// The condition is removed once enough workers are running again.
+	JobHostsInsufficient JobConditionType = "HostsInsufficient"
+
+	// JobQueuedForRestart means the job needs to restart, but waits for the restarts of
+	// other jobs since the operator limits the number of jobs restarting at the same time.
+	// The condition is false once the job restarts.
+	JobQueuedForRestart JobConditionType = "QueuedForRestart"
+
+	// JobPendingApproval means the pods of the job are rendered for review instead of
+	// being created, because of the dry-run annotation of the job.
+	// The condition is false once the job is approved.
+	JobPendingApproval JobConditionType = "PendingApproval"
+
+	// JobWaitingForDependencies means the pods of the job are not created yet because
+	// PersistentVolumeClaims, Secrets or ConfigMaps referenced by their templates don't exist.
+	// The condition is false once all of them exist.
+	JobWaitingForDependencies JobConditionType = "WaitingForDependencies"
+
+	// JobQueued means the pods of the job are not created yet because its PodGroup is not
+	// admitted by the gang scheduler, e.g. while the quota of its queue is exhausted.
+	// The condition is removed once the PodGroup is admitted.
+	JobQueued JobConditionType = "Queued"
+
+	// JobScheduling means some pods of the job are reported as unschedulable by the scheduler.
+	// The condition is removed once none of the pods is unschedulable.
+	JobScheduling JobConditionType = "Scheduling"
+
+	// JobQuotaBlocked means the creation of some pods of the job is rejected because it would
+	// exceed a ResourceQuota of the namespace, and is retried until the pods fit in the quota.
+	// The condition is removed once the pods are created.
+	JobQuotaBlocked JobConditionType = "QuotaBlocked"
+
+	// JobQuotaExceeded means the missing pods of the job are not created yet because their
+	// requests don't fit in the ResourceQuotas of the namespace, so that no partial gang is
+	// created. The condition is false once the pods fit in the quotas.
+	JobQuotaExceeded JobConditionType = "QuotaExceeded"
+
+	// JobPullingImages means some pods of the job which is starting wait for their images to
+	// be pulled, with the number of pods pulling and done pulling their images in its message.
+	// The condition is removed once the images of all the pods are pulled or the job is running.
+	JobPullingImages JobConditionType = "PullingImages"
+
+	// JobPending means the pods of the job are not created yet because it waits in its queue
+	// of the operator until the resources of the queue admit it.
+	// The condition is false once the job is admitted, and removed when the job is suspended.
+	JobPending JobConditionType = "Pending"
 )
 
 // CleanPodPolicy describes how to deal with pods when the job is finished.
+// +kubebuilder:validation:Enum=All;Running;None
 type CleanPodPolicy string
 
 const (
@@ -173,6 +481,7 @@ const (
 // Only one of the following restart policies may be specified.
 // If none of the following policies is specified, the default one
 // is RestartPolicyAlways.
+// +kubebuilder:validation:Enum=Always;OnFailure;Never;ExitCode;GangRestart
 type RestartPolicy string
 
 const (
@@ -186,6 +495,27 @@ const (
 	// - 1-127: permanent error, do not restart.
 	// - 128-255: retryable error, will restart the pod.
 	RestartPolicyExitCode RestartPolicy = "ExitCode"
+
+	// RestartPolicyGangRestart policy means that all the pods of the job are deleted and
+	// recreated together when a pod of the replica fails, as the synchronous distributed
+	// training can't go on with a single replica restarted. The PodGroup of the job is
+	// recreated as well when the gang scheduling is enabled.
+	RestartPolicyGangRestart RestartPolicy = "GangRestart"
+)
+
+// CapacityType is the capacity type of the nodes the pods of a replica type run on.
+type CapacityType string
+
+const (
+	// CapacityTypeSpot schedules the pods on spot nodes only.
+	CapacityTypeSpot CapacityType = "spot"
+
+	// CapacityTypeOnDemand schedules the pods on on-demand nodes only.
+	CapacityTypeOnDemand CapacityType = "on-demand"
+
+	// CapacityTypePreferSpot schedules the pods on spot nodes if possible,
+	// and on on-demand nodes otherwise.
+	CapacityTypePreferSpot CapacityType = "prefer-spot"
 )
 
 // RunPolicy encapsulates various runtime policies of the distributed training
@@ -215,6 +545,16 @@ type RunPolicy struct {
 	// +optional
 	SchedulingPolicy *SchedulingPolicy `json:"schedulingPolicy,omitempty"`
 
+	// FailurePolicy defines how failed pods are handled based on the exit codes
+	// of their containers. It takes precedence over the RestartPolicy of the replicas.
+	// +optional
+	FailurePolicy *FailurePolicy `json:"failurePolicy,omitempty"`
+
+	// CheckpointPolicy defines how running pods are asked to save a checkpoint
+	// before they are deleted by the controller.
+	// +optional
+	CheckpointPolicy *CheckpointPolicy `json:"checkpointPolicy,omitempty"`
+
 	// suspend specifies whether the Job controller should create Pods or not.
 	// If a Job is created with suspend set to true, no Pods are created by
 	// the Job controller. If a Job is suspended after creation (i.e. the
@@ -228,6 +568,14 @@ type RunPolicy struct {
 	// +optional
 	Suspend *bool `json:"suspend,omitempty"`
 
+	// Standalone collapses the job to a single pod of its primary replica type, e.g. the
+	// Master of a PyTorchJob or the first Worker of a JAXJob, without services, so that
+	// manifests can be smoke-tested in CI with the same CRD used in production.
+	// The pod runs as a world of size 1 with its rendezvous address set to localhost.
+	// Not supported by MPIJob, which rejects it.
+	// +optional
+	Standalone *bool `json:"standalone,omitempty"`
+
 	// ManagedBy is used to indicate the controller or entity that manages a job.
 	// The value must be either an empty, 'kubeflow.org/training-operator' or
 	// 'kueue.x-k8s.io/multikueue'.
@@ -237,8 +585,206 @@ type RunPolicy struct {
 	// with 'kueue.x-k8s.io/multikueue' to the Kueue.
 	// The field is immutable.
 	ManagedBy *string `json:"managedBy,omitempty"`
+
+	// RayClusterSpec is the spec of a transient RayCluster of KubeRay, e.g. for RLlib or the
+	// preprocessing of the data, created alongside the job and deleted once the job is finished
+	// or suspended. The cluster is named <job name>-ray, so that its head service is
+	// <job name>-ray-head-svc.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	// +optional
+	RayClusterSpec *runtime.RawExtension `json:"rayClusterSpec,omitempty"`
+
+	// InjectNetworkTuning defines whether the NCCL and Gloo env vars of the network tuning
+	// ConfigMap of the operator, e.g. NCCL_SOCKET_IFNAME, NCCL_IB_DISABLE or GLOO_SOCKET_IFNAME,
+	// are injected into the pods of the job. The env vars defined in the containers or in the
+	// common env of the job take precedence. Defaults to the --inject-network-tuning flag of
+	// the operator.
+	// +optional
+	InjectNetworkTuning *bool `json:"injectNetworkTuning,omitempty"`
+
+	// EnvInjectionPolicy disables categories of the env vars injected by the operator into
+	// the pods of the job, e.g. for images which break with them. The rest of the lifecycle
+	// of the job is still managed by the operator.
+	// +optional
+	EnvInjectionPolicy *EnvInjectionPolicy `json:"envInjectionPolicy,omitempty"`
+
+	// StartPolicy brings up the pods of the job in waves instead of all at once, e.g. to
+	// smooth the image pulls and the churn of the CNI when starting jobs of 1000+ pods.
+	// +optional
+	StartPolicy *StartPolicy `json:"startPolicy,omitempty"`
+
+	// TopologyPolicy places the pods of the job in the domains of a node topology, e.g. packed
+	// in a zone or spread across racks, since the performance of the collective communications
+	// of NCCL depends heavily on the co-location of the replicas.
+	// +optional
+	TopologyPolicy *TopologyPolicy `json:"topologyPolicy,omitempty"`
+
+	// RetainServices keeps the services of the job once it is finished, until the job is deleted,
+	// e.g. when its TTLSecondsAfterFinished expires, so that the ports of the pods, e.g. the TensorBoard
+	// or the profiler of the chief, can still be reached with kubectl port-forward. The pods are kept
+	// with the None CleanPodPolicy. Defaults to false.
+	// +optional
+	RetainServices *bool `json:"retainServices,omitempty"`
+
+	// Extensions holds the settings of the job which are unknown to this version of the operator,
+	// e.g. the settings of a newer SDK or of another controller reconciling the job. They are kept
+	// as is rather than pruned by the API server, and they are not interpreted by the operator.
+	// The other unknown fields of the job are pruned.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	// +optional
+	Extensions *runtime.RawExtension `json:"extensions,omitempty"`
+}
+
+// FailurePolicy describes how failed pods are handled based on the exit codes of their containers.
+type FailurePolicy struct {
+	// Rules are evaluated in order, the first rule matching the exit code of a failed pod
+	// determines the action. Failed pods which do not match any rule are handled
+	// according to the RestartPolicy of their replica.
+	// +listType=atomic
+	Rules []FailurePolicyRule `json:"rules"`
+}
+
+// FailurePolicyRule maps container exit codes or pod failure reasons to an action.
+// A rule matches a failed pod if either its exit code or its failure reason is listed.
+type FailurePolicyRule struct {
+	// Action to take when the exit code of a failed pod matches the rule.
+	// One of Restart, Ignore and FailJob.
+	// +kubebuilder:validation:Enum=Restart;Ignore;FailJob
+	Action FailurePolicyAction `json:"action"`
+
+	// ExitCodes is the list of exit codes of the default container matched by the rule.
+	// +listType=set
+	// +optional
+	ExitCodes []int32 `json:"exitCodes,omitempty"`
+
+	// FailureReasons is the list of the failure reasons of the pods matched by the rule,
+	// e.g. Evicted with the Ignore action to always retry the evicted pods, or OOMKilled with
+	// the FailJob action to never retry the pods which ran out of memory. A Pending pod whose
+	// image cannot be pulled is only matched by the FailJob rules, as the kubelet retries the pull.
+	// +listType=set
+	// +optional
+	FailureReasons []PodFailureReason `json:"failureReasons,omitempty"`
+}
+
+// PodFailureReason is the class of the failure of a pod.
+// +kubebuilder:validation:Enum=OOMKilled;Evicted;ImagePullBackOff;NodeNotReady;Error
+type PodFailureReason string
+
+const (
+	// PodFailureReasonOOMKilled means a container of the pod ran out of memory.
+	PodFailureReasonOOMKilled PodFailureReason = "OOMKilled"
+
+	// PodFailureReasonEvicted means the pod was evicted, e.g. by the kubelet under node
+	// pressure, through the eviction API or by a preemption.
+	PodFailureReasonEvicted PodFailureReason = "Evicted"
+
+	// PodFailureReasonImagePullBackOff means the image of a container of the Pending pod
+	// cannot be pulled.
+	PodFailureReasonImagePullBackOff PodFailureReason = "ImagePullBackOff"
+
+	// PodFailureReasonNodeNotReady means the node of the pod was lost or not ready.
+	PodFailureReasonNodeNotReady PodFailureReason = "NodeNotReady"
+
+	// PodFailureReasonError means the pod failed for another reason, e.g. a non-zero
+	// exit code of a container.
+	PodFailureReasonError PodFailureReason = "Error"
+)
+
+// FailurePolicyAction is the action taken for a failed pod matching a FailurePolicyRule.
+type FailurePolicyAction string
+
+const (
+	// FailurePolicyActionRestart recreates the failed pod, the failure is counted
+	// towards the BackoffLimit.
+	FailurePolicyActionRestart FailurePolicyAction = "Restart"
+
+	// FailurePolicyActionIgnore recreates the failed pod, the failure is not counted
+	// towards the BackoffLimit.
+	FailurePolicyActionIgnore FailurePolicyAction = "Ignore"
+
+	// FailurePolicyActionFailJob marks the job as failed immediately.
+	FailurePolicyActionFailJob FailurePolicyAction = "FailJob"
+)
+
+// CheckpointPolicy describes how running pods are asked to save a checkpoint before they are
+// deleted by the controller, e.g. when the job is suspended, scaled down or cleaned up.
+type CheckpointPolicy struct {
+	// Command is executed in the default container of every running pod before it is deleted.
+	// To send a signal to the training process, use a command like ["kill", "-USR1", "1"].
+	// +listType=atomic
+	Command []string `json:"command"`
+
+	// GracefulCheckpointSeconds is the maximum duration in seconds to wait for the command to
+	// complete before the pod is deleted. Defaults to 30.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	GracefulCheckpointSeconds *int32 `json:"gracefulCheckpointSeconds,omitempty"`
+}
+
+// EnvInjectionPolicy describes which categories of env vars are not injected by the operator
+// into the containers of the pods of a job. The network tuning env vars are controlled by the
+// injectNetworkTuning field of the RunPolicy instead.
+type EnvInjectionPolicy struct {
+	// DisableClusterSpec disables the env vars describing the cluster of the job to the training
+	// framework, e.g. TF_CONFIG of TFJob, MASTER_ADDR, WORLD_SIZE and RANK of PyTorchJob or
+	// PADDLE_MASTER of PaddleJob. The init containers and the services of the job are kept.
+	// +optional
+	DisableClusterSpec bool `json:"disableClusterSpec,omitempty"`
+
+	// DisableMPI disables the env vars of the MPI implementation of MPIJob, e.g.
+	// OMPI_MCA_plm_rsh_agent or I_MPI_HYDRA_BOOTSTRAP. The hostfile and the kubexec script
+	// are still mounted into the launcher.
+	// +optional
+	DisableMPI bool `json:"disableMPI,omitempty"`
 }
 
+// StartPolicy describes how the pods of each replica type of a job are created in waves.
+// The pods of the next wave are created once all the pods created before are ready, and the
+// launcher of an MPIJob once all its workers are ready. Since the pods of a gang are only
+// scheduled together, it must not be used with the gang scheduling.
+type StartPolicy struct {
+	// WaveSize is the number of the pods of each replica type created per wave, or their
+	// percentage of the replicas of the type rounded up, e.g. 25%.
+	// +kubebuilder:validation:XIntOrString
+	WaveSize intstr.IntOrString `json:"waveSize"`
+}
+
+// TopologyPolicy describes how the pods of all the replica types of a job are placed in the
+// domains of a node topology. The controller translates it into a pod affinity or a topology
+// spread constraint of the pods, added to the ones of their templates.
+type TopologyPolicy struct {
+	// Placement of the pods in the domains of the topology. Pack prefers running all the pods
+	// in the same domain, Same requires it, and Spread prefers spreading them evenly across
+	// the domains. One of Pack, Same and Spread.
+	// +kubebuilder:validation:Enum=Pack;Same;Spread
+	Placement TopologyPlacement `json:"placement"`
+
+	// TopologyKey is the key of the node label whose values are the domains of the topology,
+	// e.g. topology.kubernetes.io/zone or the rack label of the cluster.
+	// Defaults to topology.kubernetes.io/zone.
+	// +optional
+	TopologyKey string `json:"topologyKey,omitempty"`
+}
+
+// TopologyPlacement is the placement of the pods of a job in the domains of a node topology.
+type TopologyPlacement string
+
+const (
+	// TopologyPlacementPack adds a preferred pod affinity to the pods, so that the scheduler
+	// runs them in the domain of the other pods of the job when it can.
+	TopologyPlacementPack TopologyPlacement = "Pack"
+	// TopologyPlacementSame adds a required pod affinity to the pods, so that they all run in
+	// the domain of the first scheduled pod of the job.
+	TopologyPlacementSame TopologyPlacement = "Same"
+	// TopologyPlacementSpread adds a topology spread constraint to the pods, so that the scheduler
+	// spreads them evenly across the domains when it can.
+	TopologyPlacementSpread TopologyPlacement = "Spread"
+)
+
 // SchedulingPolicy encapsulates various scheduling policies of the distributed training
 // job, for example `minAvailable` for gang-scheduling.
 type SchedulingPolicy struct {
@@ -248,4 +794,92 @@ type SchedulingPolicy struct {
 	MinResources           *map[v1.ResourceName]resource.Quantity `json:"minResources,omitempty"`
 	PriorityClass          string                                 `json:"priorityClass,omitempty"`
 	ScheduleTimeoutSeconds *int32                                 `json:"scheduleTimeoutSeconds,omitempty"`
+
+	// SameTopology is the key of a node label, such as topology.kubernetes.io/zone, whose value
+	// must be the same on the nodes of all the pods of the job. The controller adds a required
+	// pod affinity to the pods, so that all the replicas run in the topology domain of the first
+	// scheduled pod, since the traffic between the replicas is slower and costlier across domains.
+	// +optional
+	SameTopology string `json:"sameTopology,omitempty"`
+}
+
+// JobReference references a training job in the namespace of the job referencing it.
+type JobReference struct {
+	// Kind of the referenced job, e.g. PyTorchJob. Defaults to the kind of the job referencing it.
+	// +optional
+	Kind string `json:"kind,omitempty"`
+
+	// Name of the referenced job.
+	Name string `json:"name"`
+}
+
+// TensorBoardSpec is the TensorBoard served alongside a job for its logs.
+type TensorBoardSpec struct {
+	// LogDir is the directory of the logs read by TensorBoard, either a path in a
+	// PersistentVolumeClaim of the namespace as pvc://<claim-name>/<path>, mounted read-only,
+	// or an S3 URL as s3://<bucket>/<path>.
+	// +kubebuilder:validation:Pattern=`^(pvc|s3)://[^/]+(/.*)?$`
+	LogDir string `json:"logDir"`
+
+	// Image of the TensorBoard container. Defaults to the --tensorboard-image of the operator.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// Env is the list of the environment variables set in the TensorBoard container, e.g. the
+	// credentials and the endpoint of S3.
+	// +optional
+	Env []v1.EnvVar `json:"env,omitempty"`
+}
+
+// OutputSpec declares an artifact produced by a job, e.g. its trained model, whose URI is
+// recorded in the status of the job once it has succeeded.
+type OutputSpec struct {
+	// Name of the output, e.g. model.
+	Name string `json:"name"`
+
+	// URI of the output when it is known before the job runs, e.g. s3://models/mnist. Otherwise,
+	// e.g. when the training chooses a versioned location, the main container of the pod of the
+	// master role reports the URI as a name=uri line of its termination message, whose path is
+	// set in the KUBEFLOW_OUTPUTS_FILE env var of the container.
+	// +optional
+	URI string `json:"uri,omitempty"`
+}
+
+// OutputStatus is the URI of an output of a job.
+type OutputStatus struct {
+	// Name of the output in the spec.outputs of the job.
+	Name string `json:"name"`
+
+	// URI of the output.
+	URI string `json:"uri"`
+}
+
+// DatasetSpec is a dataset staged into the pods of a job before their containers start.
+type DatasetSpec struct {
+	// Name of the dataset, which names its volume and the init container downloading it.
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=50
+	Name string `json:"name"`
+
+	// Source of the dataset, either a path in a PersistentVolumeClaim of the namespace as
+	// pvc://<claim-name>/<path>, mounted read-only, or a location downloaded into an emptyDir
+	// volume by an init container: an S3 or a GCS location as s3://<bucket>/<path> or
+	// gs://<bucket>/<path>, or the http:// or https:// URL of a file.
+	// +kubebuilder:validation:Pattern=`^((pvc|s3|gs)://[^/]+(/.*)?|https?://.+)$`
+	Source string `json:"source"`
+
+	// MountPath is where the dataset is mounted in the main container of the pods.
+	// +kubebuilder:validation:Pattern=`^/`
+	MountPath string `json:"mountPath"`
+
+	// SizeLimit is the size limit of the emptyDir volume the dataset is downloaded into, e.g.
+	// 100Gi, beyond which the pod is evicted rather than filling the local storage of its node.
+	// It is not set for the pvc:// datasets.
+	// +optional
+	SizeLimit *resource.Quantity `json:"sizeLimit,omitempty"`
+
+	// Env is the list of the environment variables set in the init container downloading the
+	// dataset, e.g. the credentials and the endpoint of S3 or of GCS.
+	// +optional
+	Env []v1.EnvVar `json:"env,omitempty"`
 }
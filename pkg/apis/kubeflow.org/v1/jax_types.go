@@ -15,6 +15,7 @@
 package v1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -45,6 +46,10 @@ const (
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 //+kubebuilder:printcolumn:name="State",type=string,JSONPath=`.status.conditions[-1:].type`
+//+kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+//+kubebuilder:printcolumn:name="Started",type=date,JSONPath=`.status.startTime`
+//+kubebuilder:printcolumn:name="Completed",type=date,JSONPath=`.status.completionTime`
+//+kubebuilder:printcolumn:name="Duration",type=string,JSONPath=`.status.duration`
 //+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 // +kubebuilder:subresource:scale:specpath=.spec.jaxReplicaSpecs.Worker.replicas,statuspath=.status.replicaStatuses.Worker.active,selectorpath=.status.replicaStatuses.Worker.selector
 
@@ -71,12 +76,54 @@ type JAXJobSpec struct {
 	//+kubebuilder:validation:Optional
 	RunPolicy RunPolicy `json:"runPolicy"`
 
+	// JobClassName is the name of the TrainingJobClass whose defaults are merged into the job
+	// when it is admitted by the training operator.
+	// +optional
+	JobClassName string `json:"jobClassName,omitempty"`
+
+	// DependsOn are the jobs of the namespace which must succeed before the pods of the job are
+	// created, e.g. the preprocessing job of a training job. The job waits for them, and fails
+	// if one of them fails.
+	// +optional
+	DependsOn []JobReference `json:"dependsOn,omitempty"`
+
+	// TensorBoard requests a TensorBoard serving the logs of the job, whose URL is reported in
+	// its status. The TensorBoard is deleted with the pods of the job, according to its
+	// CleanPodPolicy.
+	// +optional
+	TensorBoard *TensorBoardSpec `json:"tensorboard,omitempty"`
+
+	// Outputs declares the artifacts produced by the job, e.g. its trained model, whose URIs
+	// are recorded in its status once it has succeeded.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Outputs []OutputSpec `json:"outputs,omitempty"`
+
+	// Datasets are staged into the pods of all the replicas of the job before their containers
+	// start, so that the training reads them from the mount paths of its main container.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Datasets []DatasetSpec `json:"datasets,omitempty"`
+
 	// A map of JAXReplicaType (type) to ReplicaSpec (value). Specifies the JAX cluster configuration.
 	// For example,
 	//   {
 	//     "Worker": JAXReplicaSpec,
 	//   }
 	JAXReplicaSpecs map[ReplicaType]*ReplicaSpec `json:"jaxReplicaSpecs"`
+
+	// CommonEnv is the list of the environment variables set in the main container of
+	// every replica, e.g. proxy settings or credentials referenced by a secretKeyRef.
+	// The variables defined in the container of a replica take precedence.
+	// +optional
+	CommonEnv []corev1.EnvVar `json:"commonEnv,omitempty"`
+
+	// CommonEnvFrom is the list of the sources of the environment variables of the main
+	// container of every replica. The sources of the container of a replica take precedence.
+	// +optional
+	CommonEnvFrom []corev1.EnvFromSource `json:"commonEnvFrom,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
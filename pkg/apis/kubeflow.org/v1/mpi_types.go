@@ -15,7 +15,11 @@
 package v1
 
 import (
+	"text/template"
+
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 const (
@@ -32,6 +36,9 @@ const (
 	MPIJobPlural = "mpijobs"
 	// MPIJobSingular is the singular for TFJob.
 	MPIJobSingular = "mpijob"
+	// SlotsPerWorkerAuto is the SlotsPerWorker of the MPIJobs whose slots per worker are the GPUs
+	// in the limits of the containers of the workers.
+	SlotsPerWorkerAuto = "auto"
 	// MPIJobFrameworkName is the name of the ML Framework
 	MPIJobFrameworkName = "mpi"
 	// MPIJobReplicaTypeLauncher is the type for launcher replica.
@@ -46,7 +53,12 @@ const (
 // +kubebuilder:object:root=true
 // +kubebuilder:printcolumn:JSONPath=`.metadata.creationTimestamp`,name="Age",type=date
 // +kubebuilder:printcolumn:JSONPath=`.status.conditions[-1:].type`,name="State",type=string
+// +kubebuilder:printcolumn:JSONPath=`.status.phase`,name="Phase",type=string
+// +kubebuilder:printcolumn:JSONPath=`.status.startTime`,name="Started",type=date
+// +kubebuilder:printcolumn:JSONPath=`.status.completionTime`,name="Completed",type=date
+// +kubebuilder:printcolumn:JSONPath=`.status.duration`,name="Duration",type=string
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
 
 type MPIJob struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -57,10 +69,12 @@ type MPIJob struct {
 
 type MPIJobSpec struct {
 
-	// Specifies the number of slots per worker used in hostfile.
+	// Specifies the number of slots per worker used in hostfile, or `auto` to
+	// use the GPUs in the limits of the containers of the workers.
 	// Defaults to 1.
+	// +kubebuilder:validation:XValidation:rule="type(self) == int ? self >= 1 : self == 'auto'", message="slotsPerWorker must be a positive integer or auto"
 	// +optional
-	SlotsPerWorker *int32 `json:"slotsPerWorker,omitempty"`
+	SlotsPerWorker *intstr.IntOrString `json:"slotsPerWorker,omitempty"`
 
 	// CleanPodPolicy defines the policy that whether to kill pods after the job completes.
 	// Defaults to None.
@@ -70,14 +84,194 @@ type MPIJobSpec struct {
 	// specify the MPI replicas to run.
 	MPIReplicaSpecs map[ReplicaType]*ReplicaSpec `json:"mpiReplicaSpecs"`
 
+	// CommonEnv is the list of the environment variables set in the main container of
+	// every replica, e.g. proxy settings or credentials referenced by a secretKeyRef.
+	// The variables defined in the container of a replica take precedence.
+	// +optional
+	CommonEnv []corev1.EnvVar `json:"commonEnv,omitempty"`
+
+	// CommonEnvFrom is the list of the sources of the environment variables of the main
+	// container of every replica. The sources of the container of a replica take precedence.
+	// +optional
+	CommonEnvFrom []corev1.EnvFromSource `json:"commonEnvFrom,omitempty"`
+
 	// MainContainer specifies name of the main container which
 	// executes the MPI code.
 	MainContainer string `json:"mainContainer,omitempty"`
 
+	// PreflightCheck, if set to true, makes the controller verify that the worker
+	// containers provide the runtime pieces required by the kubexec bootstrap
+	// (a /bin/sh reachable through `kubectl exec`) before the launcher is created.
+	// The MPIJob is marked as failed if the check does not pass.
+	// Defaults to false.
+	// +optional
+	PreflightCheck *bool `json:"preflightCheck,omitempty"`
+
+	// HostnameSource is the source of the worker hosts written in the hostfile and the discover_hosts.sh script.
+	// One of PodName, PodIP and HostAliases. PodIP uses the IPs of the running worker pods, refreshed when they
+	// change, for clusters where the DNS resolution of the pod names is slow or unreliable. HostAliases keeps the
+	// pod names, resolved through the hostAliases of the launcher pod, which is created once all the workers are
+	// running and is not updated afterwards, so it suits the jobs whose workers are not replaced.
+	// Defaults to PodName.
+	// +kubebuilder:validation:Enum=PodName;PodIP;HostAliases
+	// +optional
+	HostnameSource HostnameSource `json:"hostnameSource,omitempty"`
+
+	// MPIImplementation is the MPI implementation of the images, which selects the format of the hostfile
+	// and the environment variables configuring the remote shell agent and the ports of the MPI processes.
+	// One of OpenMPI, IntelMPI and MPICH. When unset, the launcher gets the remote shell agent variables of
+	// both Open MPI and Intel MPI, and the hostfile uses the Open MPI format.
+	// +kubebuilder:validation:Enum=OpenMPI;IntelMPI;MPICH
+	// +optional
+	MPIImplementation MPIImplementation `json:"mpiImplementation,omitempty"`
+
+	// BindGPUsPerRank, if set to true, generates the gpu_wrapper.sh script in the ConfigMap of the job,
+	// mounted at /etc/mpi in the launcher and the workers, which sets CUDA_VISIBLE_DEVICES to the GPUs of the
+	// local rank of the MPI process before running the command given as its arguments, e.g.
+	// `mpirun /etc/mpi/gpu_wrapper.sh python train.py`, so that the ranks sharing a worker don't all use
+	// GPU 0. The GPUs of a worker are split evenly between its slots, and the ranks share a GPU when the
+	// worker has more slots than GPUs.
+	// Defaults to false.
+	// +optional
+	BindGPUsPerRank *bool `json:"bindGPUsPerRank,omitempty"`
+
+	// LauncherAsJob, if set to true, runs the launcher in a batch/v1 Job instead of a bare pod,
+	// so that transient failures of the launcher are retried by the Job up to
+	// RunPolicy.BackoffLimit times before the MPIJob is marked as failed.
+	// MPIJobs created through the v2beta1 API always run the launcher as a Job.
+	// Defaults to false.
+	// +optional
+	LauncherAsJob *bool `json:"launcherAsJob,omitempty"`
+
+	// ElasticPolicy configures the discover_hosts.sh script used by elastic Horovod to find the
+	// running workers.
+	// +optional
+	ElasticPolicy *MPIElasticPolicy `json:"elasticPolicy,omitempty"`
+
+	// HostfileTemplate customizes the entries of the hosts in the hostfile and in the discover_hosts.sh
+	// script, for the MPI distributions expecting another format than the one of MPIImplementation.
+	// +optional
+	HostfileTemplate *MPIHostfileTemplate `json:"hostfileTemplate,omitempty"`
+
 	// `RunPolicy` encapsulates various runtime policies of the distributed training
 	// job, for example how to clean up resources and how long the job can stay
 	// active.
 	RunPolicy RunPolicy `json:"runPolicy,omitempty"`
+
+	// JobClassName is the name of the TrainingJobClass whose defaults are merged into the job
+	// when it is admitted by the training operator.
+	// +optional
+	JobClassName string `json:"jobClassName,omitempty"`
+
+	// DependsOn are the jobs of the namespace which must succeed before the pods of the job are
+	// created, e.g. the preprocessing job of a training job. The job waits for them, and fails
+	// if one of them fails.
+	// +optional
+	DependsOn []JobReference `json:"dependsOn,omitempty"`
+
+	// TensorBoard requests a TensorBoard serving the logs of the job, whose URL is reported in
+	// its status. The TensorBoard is deleted with the pods of the job, according to its
+	// CleanPodPolicy.
+	// +optional
+	TensorBoard *TensorBoardSpec `json:"tensorboard,omitempty"`
+
+	// Outputs declares the artifacts produced by the job, e.g. its trained model, whose URIs
+	// are recorded in its status once it has succeeded.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Outputs []OutputSpec `json:"outputs,omitempty"`
+
+	// Datasets are staged into the pods of all the replicas of the job before their containers
+	// start, so that the training reads them from the mount paths of its main container.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Datasets []DatasetSpec `json:"datasets,omitempty"`
+}
+
+// HostnameSource is the source of the worker hosts of an MPIJob.
+type HostnameSource string
+
+const (
+	// HostnameSourcePodName uses the names of the worker pods, resolved through the DNS.
+	HostnameSourcePodName HostnameSource = "PodName"
+	// HostnameSourcePodIP uses the IPs of the worker pods.
+	HostnameSourcePodIP HostnameSource = "PodIP"
+	// HostnameSourceHostAliases uses the names of the worker pods, resolved through the hostAliases
+	// of the launcher pod.
+	HostnameSourceHostAliases HostnameSource = "HostAliases"
+)
+
+// MPIImplementation is the MPI implementation used by an MPIJob.
+type MPIImplementation string
+
+const (
+	// MPIImplementationOpenMPI is Open MPI.
+	MPIImplementationOpenMPI MPIImplementation = "OpenMPI"
+	// MPIImplementationIntelMPI is Intel MPI.
+	MPIImplementationIntelMPI MPIImplementation = "IntelMPI"
+	// MPIImplementationMPICH is MPICH.
+	MPIImplementationMPICH MPIImplementation = "MPICH"
+)
+
+// MPIElasticPolicy is the contract of the discover_hosts.sh script with elastic Horovod.
+// The script lists one `host:slots` line per running worker, and the launcher when it
+// requests GPUs. When SlotsPerWorker is unset, the slots of a worker are the GPUs requested
+// by its pod, so that the slots follow the changes of the GPU count of the workers.
+type MPIElasticPolicy struct {
+	// MinReplicas is the minimum number of running workers needed by elastic Horovod.
+	// While the launcher runs with fewer running workers, the HostsInsufficient
+	// condition of the MPIJob is set.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+
+	// RefreshIntervalSeconds is the interval in seconds at which the controller refreshes
+	// discover_hosts.sh from the running workers, in addition to the refreshes triggered
+	// by the changes of the worker pods.
+	// Defaults to 0, which disables the periodic refresh.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	RefreshIntervalSeconds *int32 `json:"refreshIntervalSeconds,omitempty"`
+}
+
+// MPIHostfileTemplate are the Go templates of the entries of the hosts of an MPIJob, rendered for
+// the launcher, when it requests GPUs, and for each worker with the fields:
+//   - .Host: the host of the pod, its name or the host given by HostnameSource.
+//   - .PodName: the name of the pod.
+//   - .Namespace: the namespace of the MPIJob.
+//   - .Slots: the slots of the pod.
+//
+// e.g. `{{.Host}} slots={{.Slots}} max_slots={{.Slots}}`, or `{{.Host}}.{{.Namespace}}.example.com:{{.Slots}}`
+// for a custom domain suffix.
+type MPIHostfileTemplate struct {
+	// Hostfile is the template of the line of a host in the hostfile.
+	// Defaults to the format of MPIImplementation.
+	// +optional
+	Hostfile string `json:"hostfile,omitempty"`
+
+	// DiscoverHosts is the template of the host echoed by the discover_hosts.sh script.
+	// Defaults to `{{.Host}}:{{.Slots}}`.
+	// +optional
+	DiscoverHosts string `json:"discoverHosts,omitempty"`
+}
+
+// ParseMPIHostTemplate parses a template of a MPIHostfileTemplate. The templates fail to render
+// the fields which are not documented.
+func ParseMPIHostTemplate(text string) (*template.Template, error) {
+	return template.New("host").Option("missingkey=error").Parse(text)
+}
+
+// MPIHostTemplateData returns the fields with which the templates of a MPIHostfileTemplate are
+// rendered for a host.
+func MPIHostTemplateData(host, podName, namespace string, slots int) map[string]interface{} {
+	return map[string]interface{}{
+		"Host":      host,
+		"PodName":   podName,
+		"Namespace": namespace,
+		"Slots":     slots,
+	}
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
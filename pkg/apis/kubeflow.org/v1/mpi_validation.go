@@ -16,6 +16,7 @@ package v1
 
 import (
 	"fmt"
+	"io"
 )
 
 func ValidateV1MpiJobSpec(c *MPIJobSpec) error {
@@ -64,6 +65,33 @@ func ValidateV1MpiJobSpec(c *MPIJobSpec) error {
 	if !launcherExists {
 		return fmt.Errorf("MPIReplicaSpec is not valid: Master ReplicaSpec must be present")
 	}
+	if c.ElasticPolicy != nil && c.ElasticPolicy.MinReplicas != nil {
+		worker := c.MPIReplicaSpecs[MPIJobReplicaTypeWorker]
+		if worker != nil && worker.Replicas != nil && *c.ElasticPolicy.MinReplicas > *worker.Replicas {
+			return fmt.Errorf("ElasticPolicy is not valid: minReplicas %d is greater than the %d Worker replicas",
+				*c.ElasticPolicy.MinReplicas, *worker.Replicas)
+		}
+	}
+	if c.HostfileTemplate != nil {
+		if err := validateMPIHostTemplate(c.HostfileTemplate.Hostfile); err != nil {
+			return fmt.Errorf("HostfileTemplate is not valid: hostfile: %v", err)
+		}
+		if err := validateMPIHostTemplate(c.HostfileTemplate.DiscoverHosts); err != nil {
+			return fmt.Errorf("HostfileTemplate is not valid: discoverHosts: %v", err)
+		}
+	}
 	return nil
 
 }
+
+// validateMPIHostTemplate checks that the template parses and renders the fields of a host.
+func validateMPIHostTemplate(text string) error {
+	if text == "" {
+		return nil
+	}
+	tmpl, err := ParseMPIHostTemplate(text)
+	if err != nil {
+		return err
+	}
+	return tmpl.Execute(io.Discard, MPIHostTemplateData("test-worker-0", "test-worker-0", "default", 1))
+}
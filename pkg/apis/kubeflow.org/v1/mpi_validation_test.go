@@ -85,6 +85,70 @@ func TestValidateV1MpiJobSpec(t *testing.T) {
 				},
 			},
 		},
+		{
+			ElasticPolicy: &MPIElasticPolicy{MinReplicas: ptr.To[int32](3)},
+			MPIReplicaSpecs: map[ReplicaType]*ReplicaSpec{
+				MPIJobReplicaTypeLauncher: &ReplicaSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{
+								corev1.Container{
+									Name:  "horovod",
+									Image: "horovod/horovod:latest",
+								},
+							},
+						},
+					},
+				},
+				MPIJobReplicaTypeWorker: &ReplicaSpec{
+					Replicas: ptr.To[int32](2),
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{
+								corev1.Container{
+									Name:  "horovod",
+									Image: "horovod/horovod:latest",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			HostfileTemplate: &MPIHostfileTemplate{Hostfile: "{{.Hostname}} slots={{.Slots}}"},
+			MPIReplicaSpecs: map[ReplicaType]*ReplicaSpec{
+				MPIJobReplicaTypeLauncher: &ReplicaSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{
+								corev1.Container{
+									Name:  "horovod",
+									Image: "horovod/horovod:latest",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			HostfileTemplate: &MPIHostfileTemplate{DiscoverHosts: "{{.Host}:{{.Slots}}"},
+			MPIReplicaSpecs: map[ReplicaType]*ReplicaSpec{
+				MPIJobReplicaTypeLauncher: &ReplicaSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{
+								corev1.Container{
+									Name:  "horovod",
+									Image: "horovod/horovod:latest",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 	for _, c := range testCases {
 		err := ValidateV1MpiJobSpec(&c)
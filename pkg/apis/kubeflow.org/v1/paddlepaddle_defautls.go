@@ -55,6 +55,7 @@ func setPaddleTypeNamesToCamelCase(paddleJob *PaddleJob) {
 	replicaTypes := []ReplicaType{
 		PaddleJobReplicaTypeMaster,
 		PaddleJobReplicaTypeWorker,
+		PaddleJobReplicaTypePServer,
 	}
 	for _, replicaType := range replicaTypes {
 		setTypeNameToCamelCase(paddleJob.Spec.PaddleReplicaSpecs, replicaType)
@@ -63,14 +64,19 @@ func setPaddleTypeNamesToCamelCase(paddleJob *PaddleJob) {
 
 // SetDefaults_PaddleJob sets any unspecified values to defaults.
 func SetDefaults_PaddleJob(job *PaddleJob) {
-	// Set default cleanpod policy to None.
-	if job.Spec.RunPolicy.CleanPodPolicy == nil {
-		job.Spec.RunPolicy.CleanPodPolicy = CleanPodPolicyPointer(CleanPodPolicyNone)
-	}
-
 	// Update the key of PaddleReplicaSpecs to camel case.
 	setPaddleTypeNamesToCamelCase(job)
 
+	// Set default cleanpod policy to None, or to Running in heterogeneous mode,
+	// since the parameter servers keep running once the trainers complete.
+	if job.Spec.RunPolicy.CleanPodPolicy == nil {
+		if _, ok := job.Spec.PaddleReplicaSpecs[PaddleJobReplicaTypePServer]; ok {
+			job.Spec.RunPolicy.CleanPodPolicy = CleanPodPolicyPointer(CleanPodPolicyRunning)
+		} else {
+			job.Spec.RunPolicy.CleanPodPolicy = CleanPodPolicyPointer(CleanPodPolicyNone)
+		}
+	}
+
 	for _, spec := range job.Spec.PaddleReplicaSpecs {
 		setDefaultReplicas(spec, 1)
 		setDefaultRestartPolicy(spec, PaddleJobDefaultRestartPolicy)
@@ -16,6 +16,7 @@ package v1
 
 import (
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -41,6 +42,9 @@ const (
 	PaddleJobReplicaTypeMaster ReplicaType = "Master"
 	// PaddleJobReplicaTypeWorker is the type for workers of distributed Paddle.
 	PaddleJobReplicaTypeWorker ReplicaType = "Worker"
+	// PaddleJobReplicaTypePServer is the type for the parameter servers of the heterogeneous mode,
+	// where the workers are the collective trainers.
+	PaddleJobReplicaTypePServer ReplicaType = "PServer"
 )
 
 // +genclient
@@ -49,6 +53,10 @@ const (
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 //+kubebuilder:printcolumn:name="State",type=string,JSONPath=`.status.conditions[-1:].type`
+//+kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+//+kubebuilder:printcolumn:name="Started",type=date,JSONPath=`.status.startTime`
+//+kubebuilder:printcolumn:name="Completed",type=date,JSONPath=`.status.completionTime`
+//+kubebuilder:printcolumn:name="Duration",type=string,JSONPath=`.status.duration`
 //+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 // +kubebuilder:subresource:scale:specpath=.spec.paddleReplicaSpecs.Worker.replicas,statuspath=.status.replicaStatuses.Worker.active,selectorpath=.status.replicaStatuses.Worker.selector
 
@@ -75,6 +83,37 @@ type PaddleJobSpec struct {
 	//+kubebuilder:validation:Optional
 	RunPolicy RunPolicy `json:"runPolicy"`
 
+	// JobClassName is the name of the TrainingJobClass whose defaults are merged into the job
+	// when it is admitted by the training operator.
+	// +optional
+	JobClassName string `json:"jobClassName,omitempty"`
+
+	// DependsOn are the jobs of the namespace which must succeed before the pods of the job are
+	// created, e.g. the preprocessing job of a training job. The job waits for them, and fails
+	// if one of them fails.
+	// +optional
+	DependsOn []JobReference `json:"dependsOn,omitempty"`
+
+	// TensorBoard requests a TensorBoard serving the logs of the job, whose URL is reported in
+	// its status. The TensorBoard is deleted with the pods of the job, according to its
+	// CleanPodPolicy.
+	// +optional
+	TensorBoard *TensorBoardSpec `json:"tensorboard,omitempty"`
+
+	// Outputs declares the artifacts produced by the job, e.g. its trained model, whose URIs
+	// are recorded in its status once it has succeeded.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Outputs []OutputSpec `json:"outputs,omitempty"`
+
+	// Datasets are staged into the pods of all the replicas of the job before their containers
+	// start, so that the training reads them from the mount paths of its main container.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Datasets []DatasetSpec `json:"datasets,omitempty"`
+
 	// ElasticPolicy holds the elastic policy for paddle job.
 	ElasticPolicy *PaddleElasticPolicy `json:"elasticPolicy,omitempty"`
 
@@ -84,7 +123,20 @@ type PaddleJobSpec struct {
 	//     "Master": PaddleReplicaSpec,
 	//     "Worker": PaddleReplicaSpec,
 	//   }
+	// The PServer replicas run the job in heterogeneous mode, where the parameter servers serve
+	// the Worker replicas training in collective mode.
 	PaddleReplicaSpecs map[ReplicaType]*ReplicaSpec `json:"paddleReplicaSpecs"`
+
+	// CommonEnv is the list of the environment variables set in the main container of
+	// every replica, e.g. proxy settings or credentials referenced by a secretKeyRef.
+	// The variables defined in the container of a replica take precedence.
+	// +optional
+	CommonEnv []corev1.EnvVar `json:"commonEnv,omitempty"`
+
+	// CommonEnvFrom is the list of the sources of the environment variables of the main
+	// container of every replica. The sources of the container of a replica take precedence.
+	// +optional
+	CommonEnvFrom []corev1.EnvFromSource `json:"commonEnvFrom,omitempty"`
 }
 
 type PaddleElasticPolicy struct {
@@ -15,6 +15,7 @@
 package v1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -44,6 +45,10 @@ const (
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 //+kubebuilder:printcolumn:name="State",type=string,JSONPath=`.status.conditions[-1:].type`
+//+kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+//+kubebuilder:printcolumn:name="Started",type=date,JSONPath=`.status.startTime`
+//+kubebuilder:printcolumn:name="Completed",type=date,JSONPath=`.status.completionTime`
+//+kubebuilder:printcolumn:name="Duration",type=string,JSONPath=`.status.duration`
 //+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // TFJob represents a TFJob resource.
@@ -73,8 +78,40 @@ type TFJobSpec struct {
 	//+kubebuilder:validation:Optional
 	RunPolicy RunPolicy `json:"runPolicy"`
 
+	// JobClassName is the name of the TrainingJobClass whose defaults are merged into the job
+	// when it is admitted by the training operator.
+	// +optional
+	JobClassName string `json:"jobClassName,omitempty"`
+
+	// DependsOn are the jobs of the namespace which must succeed before the pods of the job are
+	// created, e.g. the preprocessing job of a training job. The job waits for them, and fails
+	// if one of them fails.
+	// +optional
+	DependsOn []JobReference `json:"dependsOn,omitempty"`
+
+	// TensorBoard requests a TensorBoard serving the logs of the job, whose URL is reported in
+	// its status. The TensorBoard is deleted with the pods of the job, according to its
+	// CleanPodPolicy.
+	// +optional
+	TensorBoard *TensorBoardSpec `json:"tensorboard,omitempty"`
+
+	// Outputs declares the artifacts produced by the job, e.g. its trained model, whose URIs
+	// are recorded in its status once it has succeeded.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Outputs []OutputSpec `json:"outputs,omitempty"`
+
+	// Datasets are staged into the pods of all the replicas of the job before their containers
+	// start, so that the training reads them from the mount paths of its main container.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Datasets []DatasetSpec `json:"datasets,omitempty"`
+
 	// SuccessPolicy defines the policy to mark the TFJob as succeeded.
 	// Default to "", using the default rules.
+	// Supported values are "", "ChiefOrMaster" and "AllWorkers".
 	// +optional
 	SuccessPolicy *SuccessPolicy `json:"successPolicy,omitempty"`
 
@@ -86,15 +123,54 @@ type TFJobSpec struct {
 	//   }
 	TFReplicaSpecs map[ReplicaType]*ReplicaSpec `json:"tfReplicaSpecs"`
 
+	// CommonEnv is the list of the environment variables set in the main container of
+	// every replica, e.g. proxy settings or credentials referenced by a secretKeyRef.
+	// The variables defined in the container of a replica take precedence.
+	// +optional
+	CommonEnv []corev1.EnvVar `json:"commonEnv,omitempty"`
+
+	// CommonEnvFrom is the list of the sources of the environment variables of the main
+	// container of every replica. The sources of the container of a replica take precedence.
+	// +optional
+	CommonEnvFrom []corev1.EnvFromSource `json:"commonEnvFrom,omitempty"`
+
 	// A switch to enable dynamic worker
 	EnableDynamicWorker bool `json:"enableDynamicWorker,omitempty"`
+
+	// TFConfigStrategy defines how the cluster of the TFJob is passed to its replicas.
+	// Defaults to "TFConfig", the TF_CONFIG JSON.
+	// "GRPCWorkerCache" sets TF_GRPC_WORKER_CACHE to the cluster in the "ps|host:port;host:port,worker|host:port" form,
+	// and the task of the replica in TF_TASK_TYPE and TF_TASK_INDEX.
+	// "None" sets none of them, for the users who build their own cluster, e.g. with a cluster resolver.
+	// +kubebuilder:validation:Enum=TFConfig;GRPCWorkerCache;None
+	// +optional
+	TFConfigStrategy *TFConfigStrategy `json:"tfConfigStrategy,omitempty"`
 }
 
+// TFConfigStrategy is the way the cluster of a TFJob is passed to its replicas.
+type TFConfigStrategy string
+
+const (
+	// TFConfigStrategyTFConfig sets the TF_CONFIG JSON.
+	TFConfigStrategyTFConfig TFConfigStrategy = "TFConfig"
+	// TFConfigStrategyGRPCWorkerCache sets TF_GRPC_WORKER_CACHE, TF_TASK_TYPE and TF_TASK_INDEX.
+	TFConfigStrategyGRPCWorkerCache TFConfigStrategy = "GRPCWorkerCache"
+	// TFConfigStrategyNone sets no cluster environment variable.
+	TFConfigStrategyNone TFConfigStrategy = "None"
+)
+
 // SuccessPolicy is the success policy.
 type SuccessPolicy string
 
 const (
-	SuccessPolicyDefault    SuccessPolicy = ""
+	// SuccessPolicyDefault marks the job as succeeded when the Chief/Master replica
+	// completes, or when worker 0 completes if there is no Chief/Master replica.
+	SuccessPolicyDefault SuccessPolicy = ""
+	// SuccessPolicyChiefOrMaster marks the job as succeeded as soon as the Chief/Master
+	// replica (or worker 0 if there is none) completes, regardless of other workers.
+	SuccessPolicyChiefOrMaster SuccessPolicy = "ChiefOrMaster"
+	// SuccessPolicyAllWorkers marks the job as succeeded only when the Chief/Master
+	// replica, if any, and all workers have completed.
 	SuccessPolicyAllWorkers SuccessPolicy = "AllWorkers"
 )
 
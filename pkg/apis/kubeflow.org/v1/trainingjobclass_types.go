@@ -0,0 +1,95 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// TrainingJobClassKind is the kind name.
+	TrainingJobClassKind = "TrainingJobClass"
+	// TrainingJobClassPlural is the plural for TrainingJobClass.
+	TrainingJobClassPlural = "trainingjobclasses"
+	// TrainingJobClassSingular is the singular for TrainingJobClass.
+	TrainingJobClassSingular = "trainingjobclass"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +resource:path=trainingjobclass
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Cluster
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// TrainingJobClass holds the defaults of an organization merged into the jobs of all kinds
+// which set its name in their spec.jobClassName, when they are admitted by the training operator.
+type TrainingJobClass struct {
+	// Standard Kubernetes type metadata.
+	metav1.TypeMeta `json:",inline"`
+
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Specification of the defaults of the jobs of the class.
+	Spec TrainingJobClassSpec `json:"spec,omitempty"`
+}
+
+// TrainingJobClassSpec is the defaults of the jobs of a TrainingJobClass. The values set by
+// the jobs take precedence.
+type TrainingJobClassSpec struct {
+	// Tolerations are added to the pods of every replica, unless they already tolerate them.
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// NodeSelector is added to the node selector of the pods of every replica, for the keys
+	// they don't select yet.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Env is the list of the environment variables set in the containers of every replica
+	// which don't define them.
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// Images maps the names of the containers to the images set in the containers of that
+	// name which have no image.
+	// +optional
+	Images map[string]string `json:"images,omitempty"`
+
+	// RunPolicy is merged into the runPolicy of the jobs, for the fields they don't set,
+	// except managedBy and suspend.
+	// +optional
+	RunPolicy *RunPolicy `json:"runPolicy,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +resource:path=trainingjobclasses
+//+kubebuilder:object:root=true
+
+// TrainingJobClassList is a list of TrainingJobClasses.
+type TrainingJobClassList struct {
+	// Standard type metadata.
+	metav1.TypeMeta `json:",inline"`
+
+	// Standard list metadata.
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	// List of TrainingJobClasses.
+	Items []TrainingJobClass `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TrainingJobClass{}, &TrainingJobClassList{})
+}
@@ -25,8 +25,63 @@ import (
 	resource "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	intstr "k8s.io/apimachinery/pkg/util/intstr"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CheckpointPolicy) DeepCopyInto(out *CheckpointPolicy) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.GracefulCheckpointSeconds != nil {
+		in, out := &in.GracefulCheckpointSeconds, &out.GracefulCheckpointSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckpointPolicy.
+func (in *CheckpointPolicy) DeepCopy() *CheckpointPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(CheckpointPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatasetSpec) DeepCopyInto(out *DatasetSpec) {
+	*out = *in
+	if in.SizeLimit != nil {
+		in, out := &in.SizeLimit, &out.SizeLimit
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatasetSpec.
+func (in *DatasetSpec) DeepCopy() *DatasetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DatasetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ElasticPolicy) DeepCopyInto(out *ElasticPolicy) {
 	*out = *in
@@ -100,6 +155,87 @@ func (in *ElasticPolicy) DeepCopy() *ElasticPolicy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvInjectionPolicy) DeepCopyInto(out *EnvInjectionPolicy) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvInjectionPolicy.
+func (in *EnvInjectionPolicy) DeepCopy() *EnvInjectionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvInjectionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailurePolicy) DeepCopyInto(out *FailurePolicy) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]FailurePolicyRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailurePolicy.
+func (in *FailurePolicy) DeepCopy() *FailurePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(FailurePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailurePolicyRule) DeepCopyInto(out *FailurePolicyRule) {
+	*out = *in
+	if in.ExitCodes != nil {
+		in, out := &in.ExitCodes, &out.ExitCodes
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+	if in.FailureReasons != nil {
+		in, out := &in.FailureReasons, &out.FailureReasons
+		*out = make([]PodFailureReason, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailurePolicyRule.
+func (in *FailurePolicyRule) DeepCopy() *FailurePolicyRule {
+	if in == nil {
+		return nil
+	}
+	out := new(FailurePolicyRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GangSchedulingStatus) DeepCopyInto(out *GangSchedulingStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GangSchedulingStatus.
+func (in *GangSchedulingStatus) DeepCopy() *GangSchedulingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GangSchedulingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *JAXJob) DeepCopyInto(out *JAXJob) {
 	*out = *in
@@ -165,6 +301,28 @@ func (in *JAXJobList) DeepCopyObject() runtime.Object {
 func (in *JAXJobSpec) DeepCopyInto(out *JAXJobSpec) {
 	*out = *in
 	in.RunPolicy.DeepCopyInto(&out.RunPolicy)
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]JobReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.TensorBoard != nil {
+		in, out := &in.TensorBoard, &out.TensorBoard
+		*out = new(TensorBoardSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Outputs != nil {
+		in, out := &in.Outputs, &out.Outputs
+		*out = make([]OutputSpec, len(*in))
+		copy(*out, *in)
+	}
+	if in.Datasets != nil {
+		in, out := &in.Datasets, &out.Datasets
+		*out = make([]DatasetSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.JAXReplicaSpecs != nil {
 		in, out := &in.JAXReplicaSpecs, &out.JAXReplicaSpecs
 		*out = make(map[ReplicaType]*ReplicaSpec, len(*in))
@@ -180,6 +338,20 @@ func (in *JAXJobSpec) DeepCopyInto(out *JAXJobSpec) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.CommonEnv != nil {
+		in, out := &in.CommonEnv, &out.CommonEnv
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CommonEnvFrom != nil {
+		in, out := &in.CommonEnvFrom, &out.CommonEnvFrom
+		*out = make([]corev1.EnvFromSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -211,6 +383,22 @@ func (in *JobCondition) DeepCopy() *JobCondition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobReference) DeepCopyInto(out *JobReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobReference.
+func (in *JobReference) DeepCopy() *JobReference {
+	if in == nil {
+		return nil
+	}
+	out := new(JobReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *JobStatus) DeepCopyInto(out *JobStatus) {
 	*out = *in
@@ -244,10 +432,25 @@ func (in *JobStatus) DeepCopyInto(out *JobStatus) {
 		in, out := &in.CompletionTime, &out.CompletionTime
 		*out = (*in).DeepCopy()
 	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	if in.LastReconcileTime != nil {
 		in, out := &in.LastReconcileTime, &out.LastReconcileTime
 		*out = (*in).DeepCopy()
 	}
+	if in.GangScheduling != nil {
+		in, out := &in.GangScheduling, &out.GangScheduling
+		*out = new(GangSchedulingStatus)
+		**out = **in
+	}
+	if in.Outputs != nil {
+		in, out := &in.Outputs, &out.Outputs
+		*out = make([]OutputStatus, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -261,6 +464,48 @@ func (in *JobStatus) DeepCopy() *JobStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MPIElasticPolicy) DeepCopyInto(out *MPIElasticPolicy) {
+	*out = *in
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RefreshIntervalSeconds != nil {
+		in, out := &in.RefreshIntervalSeconds, &out.RefreshIntervalSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MPIElasticPolicy.
+func (in *MPIElasticPolicy) DeepCopy() *MPIElasticPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(MPIElasticPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MPIHostfileTemplate) DeepCopyInto(out *MPIHostfileTemplate) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MPIHostfileTemplate.
+func (in *MPIHostfileTemplate) DeepCopy() *MPIHostfileTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(MPIHostfileTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MPIJob) DeepCopyInto(out *MPIJob) {
 	*out = *in
@@ -327,7 +572,7 @@ func (in *MPIJobSpec) DeepCopyInto(out *MPIJobSpec) {
 	*out = *in
 	if in.SlotsPerWorker != nil {
 		in, out := &in.SlotsPerWorker, &out.SlotsPerWorker
-		*out = new(int32)
+		*out = new(intstr.IntOrString)
 		**out = **in
 	}
 	if in.CleanPodPolicy != nil {
@@ -350,7 +595,68 @@ func (in *MPIJobSpec) DeepCopyInto(out *MPIJobSpec) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.CommonEnv != nil {
+		in, out := &in.CommonEnv, &out.CommonEnv
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CommonEnvFrom != nil {
+		in, out := &in.CommonEnvFrom, &out.CommonEnvFrom
+		*out = make([]corev1.EnvFromSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PreflightCheck != nil {
+		in, out := &in.PreflightCheck, &out.PreflightCheck
+		*out = new(bool)
+		**out = **in
+	}
+	if in.BindGPUsPerRank != nil {
+		in, out := &in.BindGPUsPerRank, &out.BindGPUsPerRank
+		*out = new(bool)
+		**out = **in
+	}
+	if in.LauncherAsJob != nil {
+		in, out := &in.LauncherAsJob, &out.LauncherAsJob
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ElasticPolicy != nil {
+		in, out := &in.ElasticPolicy, &out.ElasticPolicy
+		*out = new(MPIElasticPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HostfileTemplate != nil {
+		in, out := &in.HostfileTemplate, &out.HostfileTemplate
+		*out = new(MPIHostfileTemplate)
+		**out = **in
+	}
 	in.RunPolicy.DeepCopyInto(&out.RunPolicy)
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]JobReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.TensorBoard != nil {
+		in, out := &in.TensorBoard, &out.TensorBoard
+		*out = new(TensorBoardSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Outputs != nil {
+		in, out := &in.Outputs, &out.Outputs
+		*out = make([]OutputSpec, len(*in))
+		copy(*out, *in)
+	}
+	if in.Datasets != nil {
+		in, out := &in.Datasets, &out.Datasets
+		*out = make([]DatasetSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -364,6 +670,38 @@ func (in *MPIJobSpec) DeepCopy() *MPIJobSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OutputSpec) DeepCopyInto(out *OutputSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OutputSpec.
+func (in *OutputSpec) DeepCopy() *OutputSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OutputSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OutputStatus) DeepCopyInto(out *OutputStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OutputStatus.
+func (in *OutputStatus) DeepCopy() *OutputStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OutputStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PaddleElasticPolicy) DeepCopyInto(out *PaddleElasticPolicy) {
 	*out = *in
@@ -467,6 +805,28 @@ func (in *PaddleJobList) DeepCopyObject() runtime.Object {
 func (in *PaddleJobSpec) DeepCopyInto(out *PaddleJobSpec) {
 	*out = *in
 	in.RunPolicy.DeepCopyInto(&out.RunPolicy)
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]JobReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.TensorBoard != nil {
+		in, out := &in.TensorBoard, &out.TensorBoard
+		*out = new(TensorBoardSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Outputs != nil {
+		in, out := &in.Outputs, &out.Outputs
+		*out = make([]OutputSpec, len(*in))
+		copy(*out, *in)
+	}
+	if in.Datasets != nil {
+		in, out := &in.Datasets, &out.Datasets
+		*out = make([]DatasetSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.ElasticPolicy != nil {
 		in, out := &in.ElasticPolicy, &out.ElasticPolicy
 		*out = new(PaddleElasticPolicy)
@@ -487,6 +847,20 @@ func (in *PaddleJobSpec) DeepCopyInto(out *PaddleJobSpec) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.CommonEnv != nil {
+		in, out := &in.CommonEnv, &out.CommonEnv
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CommonEnvFrom != nil {
+		in, out := &in.CommonEnvFrom, &out.CommonEnvFrom
+		*out = make([]corev1.EnvFromSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -565,11 +939,38 @@ func (in *PyTorchJobList) DeepCopyObject() runtime.Object {
 func (in *PyTorchJobSpec) DeepCopyInto(out *PyTorchJobSpec) {
 	*out = *in
 	in.RunPolicy.DeepCopyInto(&out.RunPolicy)
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]JobReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.TensorBoard != nil {
+		in, out := &in.TensorBoard, &out.TensorBoard
+		*out = new(TensorBoardSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Outputs != nil {
+		in, out := &in.Outputs, &out.Outputs
+		*out = make([]OutputSpec, len(*in))
+		copy(*out, *in)
+	}
+	if in.Datasets != nil {
+		in, out := &in.Datasets, &out.Datasets
+		*out = make([]DatasetSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.ElasticPolicy != nil {
 		in, out := &in.ElasticPolicy, &out.ElasticPolicy
 		*out = new(ElasticPolicy)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SuccessPolicy != nil {
+		in, out := &in.SuccessPolicy, &out.SuccessPolicy
+		*out = new(SuccessPolicy)
+		**out = **in
+	}
 	if in.PyTorchReplicaSpecs != nil {
 		in, out := &in.PyTorchReplicaSpecs, &out.PyTorchReplicaSpecs
 		*out = make(map[ReplicaType]*ReplicaSpec, len(*in))
@@ -585,6 +986,20 @@ func (in *PyTorchJobSpec) DeepCopyInto(out *PyTorchJobSpec) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.CommonEnv != nil {
+		in, out := &in.CommonEnv, &out.CommonEnv
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CommonEnvFrom != nil {
+		in, out := &in.CommonEnvFrom, &out.CommonEnvFrom
+		*out = make([]corev1.EnvFromSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.NprocPerNode != nil {
 		in, out := &in.NprocPerNode, &out.NprocPerNode
 		*out = new(string)
@@ -619,6 +1034,55 @@ func (in *RDZVConf) DeepCopy() *RDZVConf {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RabitPolicy) DeepCopyInto(out *RabitPolicy) {
+	*out = *in
+	if in.TrackerPort != nil {
+		in, out := &in.TrackerPort, &out.TrackerPort
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NumWorkers != nil {
+		in, out := &in.NumWorkers, &out.NumWorkers
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabitPolicy.
+func (in *RabitPolicy) DeepCopy() *RabitPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RabitPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicaPodStatus) DeepCopyInto(out *ReplicaPodStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicaPodStatus.
+func (in *ReplicaPodStatus) DeepCopy() *ReplicaPodStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicaPodStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ReplicaSpec) DeepCopyInto(out *ReplicaSpec) {
 	*out = *in
@@ -628,6 +1092,11 @@ func (in *ReplicaSpec) DeepCopyInto(out *ReplicaSpec) {
 		**out = **in
 	}
 	in.Template.DeepCopyInto(&out.Template)
+	if in.ToleratedFailures != nil {
+		in, out := &in.ToleratedFailures, &out.ToleratedFailures
+		*out = new(int32)
+		**out = **in
+	}
 	return
 }
 
@@ -649,6 +1118,11 @@ func (in *ReplicaStatus) DeepCopyInto(out *ReplicaStatus) {
 		*out = new(metav1.LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Pods != nil {
+		in, out := &in.Pods, &out.Pods
+		*out = make([]ReplicaPodStatus, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -690,16 +1164,66 @@ func (in *RunPolicy) DeepCopyInto(out *RunPolicy) {
 		*out = new(SchedulingPolicy)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.FailurePolicy != nil {
+		in, out := &in.FailurePolicy, &out.FailurePolicy
+		*out = new(FailurePolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CheckpointPolicy != nil {
+		in, out := &in.CheckpointPolicy, &out.CheckpointPolicy
+		*out = new(CheckpointPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Suspend != nil {
 		in, out := &in.Suspend, &out.Suspend
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Standalone != nil {
+		in, out := &in.Standalone, &out.Standalone
+		*out = new(bool)
+		**out = **in
+	}
 	if in.ManagedBy != nil {
 		in, out := &in.ManagedBy, &out.ManagedBy
 		*out = new(string)
 		**out = **in
 	}
+	if in.RayClusterSpec != nil {
+		in, out := &in.RayClusterSpec, &out.RayClusterSpec
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InjectNetworkTuning != nil {
+		in, out := &in.InjectNetworkTuning, &out.InjectNetworkTuning
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EnvInjectionPolicy != nil {
+		in, out := &in.EnvInjectionPolicy, &out.EnvInjectionPolicy
+		*out = new(EnvInjectionPolicy)
+		**out = **in
+	}
+	if in.StartPolicy != nil {
+		in, out := &in.StartPolicy, &out.StartPolicy
+		*out = new(StartPolicy)
+		**out = **in
+	}
+	if in.TopologyPolicy != nil {
+		in, out := &in.TopologyPolicy, &out.TopologyPolicy
+		*out = new(TopologyPolicy)
+		**out = **in
+	}
+	if in.RetainServices != nil {
+		in, out := &in.RetainServices, &out.RetainServices
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Extensions != nil {
+		in, out := &in.Extensions, &out.Extensions
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -750,6 +1274,23 @@ func (in *SchedulingPolicy) DeepCopy() *SchedulingPolicy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StartPolicy) DeepCopyInto(out *StartPolicy) {
+	*out = *in
+	out.WaveSize = in.WaveSize
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StartPolicy.
+func (in *StartPolicy) DeepCopy() *StartPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(StartPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TFJob) DeepCopyInto(out *TFJob) {
 	*out = *in
@@ -815,6 +1356,28 @@ func (in *TFJobList) DeepCopyObject() runtime.Object {
 func (in *TFJobSpec) DeepCopyInto(out *TFJobSpec) {
 	*out = *in
 	in.RunPolicy.DeepCopyInto(&out.RunPolicy)
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]JobReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.TensorBoard != nil {
+		in, out := &in.TensorBoard, &out.TensorBoard
+		*out = new(TensorBoardSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Outputs != nil {
+		in, out := &in.Outputs, &out.Outputs
+		*out = make([]OutputSpec, len(*in))
+		copy(*out, *in)
+	}
+	if in.Datasets != nil {
+		in, out := &in.Datasets, &out.Datasets
+		*out = make([]DatasetSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.SuccessPolicy != nil {
 		in, out := &in.SuccessPolicy, &out.SuccessPolicy
 		*out = new(SuccessPolicy)
@@ -835,6 +1398,25 @@ func (in *TFJobSpec) DeepCopyInto(out *TFJobSpec) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.CommonEnv != nil {
+		in, out := &in.CommonEnv, &out.CommonEnv
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CommonEnvFrom != nil {
+		in, out := &in.CommonEnvFrom, &out.CommonEnvFrom
+		*out = make([]corev1.EnvFromSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TFConfigStrategy != nil {
+		in, out := &in.TFConfigStrategy, &out.TFConfigStrategy
+		*out = new(TFConfigStrategy)
+		**out = **in
+	}
 	return
 }
 
@@ -848,6 +1430,154 @@ func (in *TFJobSpec) DeepCopy() *TFJobSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TensorBoardSpec) DeepCopyInto(out *TensorBoardSpec) {
+	*out = *in
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TensorBoardSpec.
+func (in *TensorBoardSpec) DeepCopy() *TensorBoardSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TensorBoardSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TopologyPolicy) DeepCopyInto(out *TopologyPolicy) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopologyPolicy.
+func (in *TopologyPolicy) DeepCopy() *TopologyPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(TopologyPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrainingJobClass) DeepCopyInto(out *TrainingJobClass) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrainingJobClass.
+func (in *TrainingJobClass) DeepCopy() *TrainingJobClass {
+	if in == nil {
+		return nil
+	}
+	out := new(TrainingJobClass)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TrainingJobClass) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrainingJobClassList) DeepCopyInto(out *TrainingJobClassList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TrainingJobClass, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrainingJobClassList.
+func (in *TrainingJobClassList) DeepCopy() *TrainingJobClassList {
+	if in == nil {
+		return nil
+	}
+	out := new(TrainingJobClassList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TrainingJobClassList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrainingJobClassSpec) DeepCopyInto(out *TrainingJobClassSpec) {
+	*out = *in
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.RunPolicy != nil {
+		in, out := &in.RunPolicy, &out.RunPolicy
+		*out = new(RunPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrainingJobClassSpec.
+func (in *TrainingJobClassSpec) DeepCopy() *TrainingJobClassSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TrainingJobClassSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *XGBoostJob) DeepCopyInto(out *XGBoostJob) {
 	*out = *in
@@ -913,6 +1643,28 @@ func (in *XGBoostJobList) DeepCopyObject() runtime.Object {
 func (in *XGBoostJobSpec) DeepCopyInto(out *XGBoostJobSpec) {
 	*out = *in
 	in.RunPolicy.DeepCopyInto(&out.RunPolicy)
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]JobReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.TensorBoard != nil {
+		in, out := &in.TensorBoard, &out.TensorBoard
+		*out = new(TensorBoardSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Outputs != nil {
+		in, out := &in.Outputs, &out.Outputs
+		*out = make([]OutputSpec, len(*in))
+		copy(*out, *in)
+	}
+	if in.Datasets != nil {
+		in, out := &in.Datasets, &out.Datasets
+		*out = make([]DatasetSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.XGBReplicaSpecs != nil {
 		in, out := &in.XGBReplicaSpecs, &out.XGBReplicaSpecs
 		*out = make(map[ReplicaType]*ReplicaSpec, len(*in))
@@ -928,6 +1680,25 @@ func (in *XGBoostJobSpec) DeepCopyInto(out *XGBoostJobSpec) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.CommonEnv != nil {
+		in, out := &in.CommonEnv, &out.CommonEnv
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CommonEnvFrom != nil {
+		in, out := &in.CommonEnvFrom, &out.CommonEnvFrom
+		*out = make([]corev1.EnvFromSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RabitPolicy != nil {
+		in, out := &in.RabitPolicy, &out.RabitPolicy
+		*out = new(RabitPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
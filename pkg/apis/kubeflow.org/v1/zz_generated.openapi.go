@@ -27,85 +27,205 @@ import (
 
 func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenAPIDefinition {
 	return map[string]common.OpenAPIDefinition{
-		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.ElasticPolicy":       schema_pkg_apis_kubefloworg_v1_ElasticPolicy(ref),
-		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.JAXJob":              schema_pkg_apis_kubefloworg_v1_JAXJob(ref),
-		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.JAXJobList":          schema_pkg_apis_kubefloworg_v1_JAXJobList(ref),
-		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.JAXJobSpec":          schema_pkg_apis_kubefloworg_v1_JAXJobSpec(ref),
-		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.JobCondition":        schema_pkg_apis_kubefloworg_v1_JobCondition(ref),
-		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.JobStatus":           schema_pkg_apis_kubefloworg_v1_JobStatus(ref),
-		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.MPIJob":              schema_pkg_apis_kubefloworg_v1_MPIJob(ref),
-		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.MPIJobList":          schema_pkg_apis_kubefloworg_v1_MPIJobList(ref),
-		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.MPIJobSpec":          schema_pkg_apis_kubefloworg_v1_MPIJobSpec(ref),
-		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.PaddleElasticPolicy": schema_pkg_apis_kubefloworg_v1_PaddleElasticPolicy(ref),
-		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.PaddleJob":           schema_pkg_apis_kubefloworg_v1_PaddleJob(ref),
-		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.PaddleJobList":       schema_pkg_apis_kubefloworg_v1_PaddleJobList(ref),
-		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.PaddleJobSpec":       schema_pkg_apis_kubefloworg_v1_PaddleJobSpec(ref),
-		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.PyTorchJob":          schema_pkg_apis_kubefloworg_v1_PyTorchJob(ref),
-		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.PyTorchJobList":      schema_pkg_apis_kubefloworg_v1_PyTorchJobList(ref),
-		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.PyTorchJobSpec":      schema_pkg_apis_kubefloworg_v1_PyTorchJobSpec(ref),
-		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.RDZVConf":            schema_pkg_apis_kubefloworg_v1_RDZVConf(ref),
-		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.ReplicaSpec":         schema_pkg_apis_kubefloworg_v1_ReplicaSpec(ref),
-		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.ReplicaStatus":       schema_pkg_apis_kubefloworg_v1_ReplicaStatus(ref),
-		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.RunPolicy":           schema_pkg_apis_kubefloworg_v1_RunPolicy(ref),
-		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.SchedulingPolicy":    schema_pkg_apis_kubefloworg_v1_SchedulingPolicy(ref),
-		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.TFJob":               schema_pkg_apis_kubefloworg_v1_TFJob(ref),
-		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.TFJobList":           schema_pkg_apis_kubefloworg_v1_TFJobList(ref),
-		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.TFJobSpec":           schema_pkg_apis_kubefloworg_v1_TFJobSpec(ref),
-		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.XGBoostJob":          schema_pkg_apis_kubefloworg_v1_XGBoostJob(ref),
-		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.XGBoostJobList":      schema_pkg_apis_kubefloworg_v1_XGBoostJobList(ref),
-		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.XGBoostJobSpec":      schema_pkg_apis_kubefloworg_v1_XGBoostJobSpec(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.APIGroup":                                      schema_pkg_apis_meta_v1_APIGroup(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.APIGroupList":                                  schema_pkg_apis_meta_v1_APIGroupList(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.APIResource":                                   schema_pkg_apis_meta_v1_APIResource(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.APIResourceList":                               schema_pkg_apis_meta_v1_APIResourceList(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.APIVersions":                                   schema_pkg_apis_meta_v1_APIVersions(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.ApplyOptions":                                  schema_pkg_apis_meta_v1_ApplyOptions(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.Condition":                                     schema_pkg_apis_meta_v1_Condition(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.CreateOptions":                                 schema_pkg_apis_meta_v1_CreateOptions(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.DeleteOptions":                                 schema_pkg_apis_meta_v1_DeleteOptions(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.Duration":                                      schema_pkg_apis_meta_v1_Duration(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.FieldsV1":                                      schema_pkg_apis_meta_v1_FieldsV1(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.GetOptions":                                    schema_pkg_apis_meta_v1_GetOptions(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.GroupKind":                                     schema_pkg_apis_meta_v1_GroupKind(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.GroupResource":                                 schema_pkg_apis_meta_v1_GroupResource(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.GroupVersion":                                  schema_pkg_apis_meta_v1_GroupVersion(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.GroupVersionForDiscovery":                      schema_pkg_apis_meta_v1_GroupVersionForDiscovery(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.GroupVersionKind":                              schema_pkg_apis_meta_v1_GroupVersionKind(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.GroupVersionResource":                          schema_pkg_apis_meta_v1_GroupVersionResource(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.InternalEvent":                                 schema_pkg_apis_meta_v1_InternalEvent(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector":                                 schema_pkg_apis_meta_v1_LabelSelector(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelectorRequirement":                      schema_pkg_apis_meta_v1_LabelSelectorRequirement(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.List":                                          schema_pkg_apis_meta_v1_List(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta":                                      schema_pkg_apis_meta_v1_ListMeta(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.ListOptions":                                   schema_pkg_apis_meta_v1_ListOptions(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.ManagedFieldsEntry":                            schema_pkg_apis_meta_v1_ManagedFieldsEntry(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.MicroTime":                                     schema_pkg_apis_meta_v1_MicroTime(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta":                                    schema_pkg_apis_meta_v1_ObjectMeta(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.OwnerReference":                                schema_pkg_apis_meta_v1_OwnerReference(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.PartialObjectMetadata":                         schema_pkg_apis_meta_v1_PartialObjectMetadata(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.PartialObjectMetadataList":                     schema_pkg_apis_meta_v1_PartialObjectMetadataList(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.Patch":                                         schema_pkg_apis_meta_v1_Patch(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.PatchOptions":                                  schema_pkg_apis_meta_v1_PatchOptions(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.Preconditions":                                 schema_pkg_apis_meta_v1_Preconditions(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.RootPaths":                                     schema_pkg_apis_meta_v1_RootPaths(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.ServerAddressByClientCIDR":                     schema_pkg_apis_meta_v1_ServerAddressByClientCIDR(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.Status":                                        schema_pkg_apis_meta_v1_Status(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.StatusCause":                                   schema_pkg_apis_meta_v1_StatusCause(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.StatusDetails":                                 schema_pkg_apis_meta_v1_StatusDetails(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.Table":                                         schema_pkg_apis_meta_v1_Table(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.TableColumnDefinition":                         schema_pkg_apis_meta_v1_TableColumnDefinition(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.TableOptions":                                  schema_pkg_apis_meta_v1_TableOptions(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.TableRow":                                      schema_pkg_apis_meta_v1_TableRow(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.TableRowCondition":                             schema_pkg_apis_meta_v1_TableRowCondition(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.Time":                                          schema_pkg_apis_meta_v1_Time(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.Timestamp":                                     schema_pkg_apis_meta_v1_Timestamp(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.TypeMeta":                                      schema_pkg_apis_meta_v1_TypeMeta(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.UpdateOptions":                                 schema_pkg_apis_meta_v1_UpdateOptions(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.WatchEvent":                                    schema_pkg_apis_meta_v1_WatchEvent(ref),
-		"k8s.io/apimachinery/pkg/runtime.RawExtension":                                       schema_k8sio_apimachinery_pkg_runtime_RawExtension(ref),
-		"k8s.io/apimachinery/pkg/runtime.TypeMeta":                                           schema_k8sio_apimachinery_pkg_runtime_TypeMeta(ref),
-		"k8s.io/apimachinery/pkg/runtime.Unknown":                                            schema_k8sio_apimachinery_pkg_runtime_Unknown(ref),
-		"k8s.io/apimachinery/pkg/version.Info":                                               schema_k8sio_apimachinery_pkg_version_Info(ref),
+		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.CheckpointPolicy":     schema_pkg_apis_kubefloworg_v1_CheckpointPolicy(ref),
+		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.DatasetSpec":          schema_pkg_apis_kubefloworg_v1_DatasetSpec(ref),
+		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.ElasticPolicy":        schema_pkg_apis_kubefloworg_v1_ElasticPolicy(ref),
+		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.EnvInjectionPolicy":   schema_pkg_apis_kubefloworg_v1_EnvInjectionPolicy(ref),
+		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.FailurePolicy":        schema_pkg_apis_kubefloworg_v1_FailurePolicy(ref),
+		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.FailurePolicyRule":    schema_pkg_apis_kubefloworg_v1_FailurePolicyRule(ref),
+		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.GangSchedulingStatus": schema_pkg_apis_kubefloworg_v1_GangSchedulingStatus(ref),
+		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.JAXJob":               schema_pkg_apis_kubefloworg_v1_JAXJob(ref),
+		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.JAXJobList":           schema_pkg_apis_kubefloworg_v1_JAXJobList(ref),
+		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.JAXJobSpec":           schema_pkg_apis_kubefloworg_v1_JAXJobSpec(ref),
+		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.JobCondition":         schema_pkg_apis_kubefloworg_v1_JobCondition(ref),
+		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.JobReference":         schema_pkg_apis_kubefloworg_v1_JobReference(ref),
+		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.JobStatus":            schema_pkg_apis_kubefloworg_v1_JobStatus(ref),
+		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.MPIElasticPolicy":     schema_pkg_apis_kubefloworg_v1_MPIElasticPolicy(ref),
+		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.MPIHostfileTemplate":  schema_pkg_apis_kubefloworg_v1_MPIHostfileTemplate(ref),
+		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.MPIJob":               schema_pkg_apis_kubefloworg_v1_MPIJob(ref),
+		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.MPIJobList":           schema_pkg_apis_kubefloworg_v1_MPIJobList(ref),
+		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.MPIJobSpec":           schema_pkg_apis_kubefloworg_v1_MPIJobSpec(ref),
+		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.OutputSpec":           schema_pkg_apis_kubefloworg_v1_OutputSpec(ref),
+		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.OutputStatus":         schema_pkg_apis_kubefloworg_v1_OutputStatus(ref),
+		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.PaddleElasticPolicy":  schema_pkg_apis_kubefloworg_v1_PaddleElasticPolicy(ref),
+		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.PaddleJob":            schema_pkg_apis_kubefloworg_v1_PaddleJob(ref),
+		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.PaddleJobList":        schema_pkg_apis_kubefloworg_v1_PaddleJobList(ref),
+		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.PaddleJobSpec":        schema_pkg_apis_kubefloworg_v1_PaddleJobSpec(ref),
+		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.PyTorchJob":           schema_pkg_apis_kubefloworg_v1_PyTorchJob(ref),
+		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.PyTorchJobList":       schema_pkg_apis_kubefloworg_v1_PyTorchJobList(ref),
+		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.PyTorchJobSpec":       schema_pkg_apis_kubefloworg_v1_PyTorchJobSpec(ref),
+		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.RDZVConf":             schema_pkg_apis_kubefloworg_v1_RDZVConf(ref),
+		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.RabitPolicy":          schema_pkg_apis_kubefloworg_v1_RabitPolicy(ref),
+		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.ReplicaPodStatus":     schema_pkg_apis_kubefloworg_v1_ReplicaPodStatus(ref),
+		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.ReplicaSpec":          schema_pkg_apis_kubefloworg_v1_ReplicaSpec(ref),
+		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.ReplicaStatus":        schema_pkg_apis_kubefloworg_v1_ReplicaStatus(ref),
+		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.RunPolicy":            schema_pkg_apis_kubefloworg_v1_RunPolicy(ref),
+		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.SchedulingPolicy":     schema_pkg_apis_kubefloworg_v1_SchedulingPolicy(ref),
+		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.StartPolicy":          schema_pkg_apis_kubefloworg_v1_StartPolicy(ref),
+		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.TFJob":                schema_pkg_apis_kubefloworg_v1_TFJob(ref),
+		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.TFJobList":            schema_pkg_apis_kubefloworg_v1_TFJobList(ref),
+		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.TFJobSpec":            schema_pkg_apis_kubefloworg_v1_TFJobSpec(ref),
+		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.TensorBoardSpec":      schema_pkg_apis_kubefloworg_v1_TensorBoardSpec(ref),
+		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.TopologyPolicy":       schema_pkg_apis_kubefloworg_v1_TopologyPolicy(ref),
+		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.TrainingJobClass":     schema_pkg_apis_kubefloworg_v1_TrainingJobClass(ref),
+		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.TrainingJobClassList": schema_pkg_apis_kubefloworg_v1_TrainingJobClassList(ref),
+		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.TrainingJobClassSpec": schema_pkg_apis_kubefloworg_v1_TrainingJobClassSpec(ref),
+		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.XGBoostJob":           schema_pkg_apis_kubefloworg_v1_XGBoostJob(ref),
+		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.XGBoostJobList":       schema_pkg_apis_kubefloworg_v1_XGBoostJobList(ref),
+		"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.XGBoostJobSpec":       schema_pkg_apis_kubefloworg_v1_XGBoostJobSpec(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.APIGroup":                                       schema_pkg_apis_meta_v1_APIGroup(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.APIGroupList":                                   schema_pkg_apis_meta_v1_APIGroupList(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.APIResource":                                    schema_pkg_apis_meta_v1_APIResource(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.APIResourceList":                                schema_pkg_apis_meta_v1_APIResourceList(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.APIVersions":                                    schema_pkg_apis_meta_v1_APIVersions(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.ApplyOptions":                                   schema_pkg_apis_meta_v1_ApplyOptions(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.Condition":                                      schema_pkg_apis_meta_v1_Condition(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.CreateOptions":                                  schema_pkg_apis_meta_v1_CreateOptions(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.DeleteOptions":                                  schema_pkg_apis_meta_v1_DeleteOptions(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.Duration":                                       schema_pkg_apis_meta_v1_Duration(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.FieldsV1":                                       schema_pkg_apis_meta_v1_FieldsV1(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.GetOptions":                                     schema_pkg_apis_meta_v1_GetOptions(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.GroupKind":                                      schema_pkg_apis_meta_v1_GroupKind(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.GroupResource":                                  schema_pkg_apis_meta_v1_GroupResource(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.GroupVersion":                                   schema_pkg_apis_meta_v1_GroupVersion(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.GroupVersionForDiscovery":                       schema_pkg_apis_meta_v1_GroupVersionForDiscovery(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.GroupVersionKind":                               schema_pkg_apis_meta_v1_GroupVersionKind(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.GroupVersionResource":                           schema_pkg_apis_meta_v1_GroupVersionResource(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.InternalEvent":                                  schema_pkg_apis_meta_v1_InternalEvent(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector":                                  schema_pkg_apis_meta_v1_LabelSelector(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelectorRequirement":                       schema_pkg_apis_meta_v1_LabelSelectorRequirement(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.List":                                           schema_pkg_apis_meta_v1_List(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta":                                       schema_pkg_apis_meta_v1_ListMeta(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.ListOptions":                                    schema_pkg_apis_meta_v1_ListOptions(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.ManagedFieldsEntry":                             schema_pkg_apis_meta_v1_ManagedFieldsEntry(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.MicroTime":                                      schema_pkg_apis_meta_v1_MicroTime(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta":                                     schema_pkg_apis_meta_v1_ObjectMeta(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.OwnerReference":                                 schema_pkg_apis_meta_v1_OwnerReference(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.PartialObjectMetadata":                          schema_pkg_apis_meta_v1_PartialObjectMetadata(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.PartialObjectMetadataList":                      schema_pkg_apis_meta_v1_PartialObjectMetadataList(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.Patch":                                          schema_pkg_apis_meta_v1_Patch(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.PatchOptions":                                   schema_pkg_apis_meta_v1_PatchOptions(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.Preconditions":                                  schema_pkg_apis_meta_v1_Preconditions(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.RootPaths":                                      schema_pkg_apis_meta_v1_RootPaths(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.ServerAddressByClientCIDR":                      schema_pkg_apis_meta_v1_ServerAddressByClientCIDR(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.Status":                                         schema_pkg_apis_meta_v1_Status(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.StatusCause":                                    schema_pkg_apis_meta_v1_StatusCause(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.StatusDetails":                                  schema_pkg_apis_meta_v1_StatusDetails(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.Table":                                          schema_pkg_apis_meta_v1_Table(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.TableColumnDefinition":                          schema_pkg_apis_meta_v1_TableColumnDefinition(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.TableOptions":                                   schema_pkg_apis_meta_v1_TableOptions(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.TableRow":                                       schema_pkg_apis_meta_v1_TableRow(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.TableRowCondition":                              schema_pkg_apis_meta_v1_TableRowCondition(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.Time":                                           schema_pkg_apis_meta_v1_Time(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.Timestamp":                                      schema_pkg_apis_meta_v1_Timestamp(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.TypeMeta":                                       schema_pkg_apis_meta_v1_TypeMeta(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.UpdateOptions":                                  schema_pkg_apis_meta_v1_UpdateOptions(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.WatchEvent":                                     schema_pkg_apis_meta_v1_WatchEvent(ref),
+		"k8s.io/apimachinery/pkg/runtime.RawExtension":                                        schema_k8sio_apimachinery_pkg_runtime_RawExtension(ref),
+		"k8s.io/apimachinery/pkg/runtime.TypeMeta":                                            schema_k8sio_apimachinery_pkg_runtime_TypeMeta(ref),
+		"k8s.io/apimachinery/pkg/runtime.Unknown":                                             schema_k8sio_apimachinery_pkg_runtime_Unknown(ref),
+		"k8s.io/apimachinery/pkg/version.Info":                                                schema_k8sio_apimachinery_pkg_version_Info(ref),
+	}
+}
+
+func schema_pkg_apis_kubefloworg_v1_CheckpointPolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "CheckpointPolicy describes how running pods are asked to save a checkpoint before they are deleted by the controller, e.g. when the job is suspended, scaled down or cleaned up.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"command": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Command is executed in the default container of every running pod before it is deleted. To send a signal to the training process, use a command like [\"kill\", \"-USR1\", \"1\"].",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"gracefulCheckpointSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "GracefulCheckpointSeconds is the maximum duration in seconds to wait for the command to complete before the pod is deleted. Defaults to 30.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+				Required: []string{"command"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_kubefloworg_v1_DatasetSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DatasetSpec is a dataset staged into the pods of a job before their containers start.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the dataset, which names its volume and the init container downloading it.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"source": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Source of the dataset, either a path in a PersistentVolumeClaim of the namespace as pvc://<claim-name>/<path>, mounted read-only, or a location downloaded into an emptyDir volume by an init container: an S3 or a GCS location as s3://<bucket>/<path> or gs://<bucket>/<path>, or the http:// or https:// URL of a file.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"mountPath": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MountPath is where the dataset is mounted in the main container of the pods.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"sizeLimit": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SizeLimit is the size limit of the emptyDir volume the dataset is downloaded into, e.g. 100Gi, beyond which the pod is evicted rather than filling the local storage of its node. It is not set for the pvc:// datasets.",
+							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+						},
+					},
+					"env": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Env is the list of the environment variables set in the init container downloading the dataset, e.g. the credentials and the endpoint of S3 or of GCS.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.EnvVar"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"name", "source", "mountPath"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/api/core/v1.EnvVar", "k8s.io/apimachinery/pkg/api/resource.Quantity"},
 	}
 }
 
@@ -209,6 +329,159 @@ func schema_pkg_apis_kubefloworg_v1_ElasticPolicy(ref common.ReferenceCallback)
 	}
 }
 
+func schema_pkg_apis_kubefloworg_v1_EnvInjectionPolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "EnvInjectionPolicy describes which categories of env vars are not injected by the operator into the containers of the pods of a job. The network tuning env vars are controlled by the injectNetworkTuning field of the RunPolicy instead.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"disableClusterSpec": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DisableClusterSpec disables the env vars describing the cluster of the job to the training framework, e.g. TF_CONFIG of TFJob, MASTER_ADDR, WORLD_SIZE and RANK of PyTorchJob or PADDLE_MASTER of PaddleJob. The init containers and the services of the job are kept.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"disableMPI": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DisableMPI disables the env vars of the MPI implementation of MPIJob, e.g. OMPI_MCA_plm_rsh_agent or I_MPI_HYDRA_BOOTSTRAP. The hostfile and the kubexec script are still mounted into the launcher.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_kubefloworg_v1_FailurePolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "FailurePolicy describes how failed pods are handled based on the exit codes of their containers.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"rules": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Rules are evaluated in order, the first rule matching the exit code of a failed pod determines the action. Failed pods which do not match any rule are handled according to the RestartPolicy of their replica.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.FailurePolicyRule"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"rules"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.FailurePolicyRule"},
+	}
+}
+
+func schema_pkg_apis_kubefloworg_v1_FailurePolicyRule(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "FailurePolicyRule maps container exit codes or pod failure reasons to an action. A rule matches a failed pod if either its exit code or its failure reason is listed.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"action": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Action to take when the exit code of a failed pod matches the rule. One of Restart, Ignore and FailJob.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"exitCodes": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "set",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "ExitCodes is the list of exit codes of the default container matched by the rule.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: 0,
+										Type:    []string{"integer"},
+										Format:  "int32",
+									},
+								},
+							},
+						},
+					},
+					"failureReasons": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "set",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "FailureReasons is the list of the failure reasons of the pods matched by the rule, e.g. Evicted with the Ignore action to always retry the evicted pods, or OOMKilled with the FailJob action to never retry the pods which ran out of memory. A Pending pod whose image cannot be pulled is only matched by the FailJob rules, as the kubelet retries the pull.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"action"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_kubefloworg_v1_GangSchedulingStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "GangSchedulingStatus represents the PodGroup created for a job by a gang scheduler.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"schedulerName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SchedulerName is the name of the gang scheduler the PodGroup was created for, e.g. volcano or the name of the scheduler-plugins scheduler.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"minMember": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MinMember is the minimum number of members last set on the PodGroup.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+				Required: []string{"schedulerName"},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_kubefloworg_v1_JAXJob(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -323,57 +596,156 @@ func schema_pkg_apis_kubefloworg_v1_JAXJobSpec(ref common.ReferenceCallback) com
 							Ref:         ref("github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.RunPolicy"),
 						},
 					},
-					"jaxReplicaSpecs": {
+					"jobClassName": {
 						SchemaProps: spec.SchemaProps{
-							Description: "A map of JAXReplicaType (type) to ReplicaSpec (value). Specifies the JAX cluster configuration. For example,\n  {\n    \"Worker\": JAXReplicaSpec,\n  }",
-							Type:        []string{"object"},
-							AdditionalProperties: &spec.SchemaOrBool{
-								Allows: true,
+							Description: "JobClassName is the name of the TrainingJobClass whose defaults are merged into the job when it is admitted by the training operator.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"dependsOn": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DependsOn are the jobs of the namespace which must succeed before the pods of the job are created, e.g. the preprocessing job of a training job. The job waits for them, and fails if one of them fails.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
-										Ref: ref("github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.ReplicaSpec"),
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.JobReference"),
 									},
 								},
 							},
 						},
 					},
-				},
-				Required: []string{"runPolicy", "jaxReplicaSpecs"},
-			},
-		},
-		Dependencies: []string{
-			"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.ReplicaSpec", "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.RunPolicy"},
-	}
-}
-
-func schema_pkg_apis_kubefloworg_v1_JobCondition(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Description: "JobCondition describes the state of the job at a certain point.",
-				Type:        []string{"object"},
-				Properties: map[string]spec.Schema{
-					"type": {
+					"tensorboard": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Type of job condition.",
-							Default:     "",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "TensorBoard requests a TensorBoard serving the logs of the job, whose URL is reported in its status. The TensorBoard is deleted with the pods of the job, according to its CleanPodPolicy.",
+							Ref:         ref("github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.TensorBoardSpec"),
 						},
 					},
-					"status": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Status of the condition, one of True, False, Unknown.",
-							Default:     "",
-							Type:        []string{"string"},
-							Format:      "",
+					"outputs": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"name",
+								},
+								"x-kubernetes-list-type": "map",
+							},
 						},
-					},
-					"reason": {
 						SchemaProps: spec.SchemaProps{
-							Description: "The reason for the condition's last transition.",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "Outputs declares the artifacts produced by the job, e.g. its trained model, whose URIs are recorded in its status once it has succeeded.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.OutputSpec"),
+									},
+								},
+							},
+						},
+					},
+					"datasets": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"name",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Datasets are staged into the pods of all the replicas of the job before their containers start, so that the training reads them from the mount paths of its main container.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.DatasetSpec"),
+									},
+								},
+							},
+						},
+					},
+					"jaxReplicaSpecs": {
+						SchemaProps: spec.SchemaProps{
+							Description: "A map of JAXReplicaType (type) to ReplicaSpec (value). Specifies the JAX cluster configuration. For example,\n  {\n    \"Worker\": JAXReplicaSpec,\n  }",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.ReplicaSpec"),
+									},
+								},
+							},
+						},
+					},
+					"commonEnv": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CommonEnv is the list of the environment variables set in the main container of every replica, e.g. proxy settings or credentials referenced by a secretKeyRef. The variables defined in the container of a replica take precedence.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.EnvVar"),
+									},
+								},
+							},
+						},
+					},
+					"commonEnvFrom": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CommonEnvFrom is the list of the sources of the environment variables of the main container of every replica. The sources of the container of a replica take precedence.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.EnvFromSource"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"runPolicy", "jaxReplicaSpecs"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.DatasetSpec", "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.JobReference", "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.OutputSpec", "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.ReplicaSpec", "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.RunPolicy", "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.TensorBoardSpec", "k8s.io/api/core/v1.EnvFromSource", "k8s.io/api/core/v1.EnvVar", "k8s.io/apimachinery/pkg/util/intstr.IntOrString"},
+	}
+}
+
+func schema_pkg_apis_kubefloworg_v1_JobCondition(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "JobCondition describes the state of the job at a certain point.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"type": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Type of job condition.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Status of the condition, one of True, False, Unknown.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"reason": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The reason for the condition's last transition.",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
 					"message": {
@@ -404,6 +776,35 @@ func schema_pkg_apis_kubefloworg_v1_JobCondition(ref common.ReferenceCallback) c
 	}
 }
 
+func schema_pkg_apis_kubefloworg_v1_JobReference(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "JobReference references a training job in the namespace of the job referencing it.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind of the referenced job, e.g. PyTorchJob. Defaults to the kind of the job referencing it.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the referenced job.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_kubefloworg_v1_JobStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -425,6 +826,20 @@ func schema_pkg_apis_kubefloworg_v1_JobStatus(ref common.ReferenceCallback) comm
 							},
 						},
 					},
+					"phase": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Phase is the phase of the job rolled up from its conditions: Created, Running, Restarting, Succeeded, Failed or Suspended.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"runID": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RunID is the run ID of the job, set as the training.kubeflow.org/run-id label of its pods and of the other resources created for it.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"replicaStatuses": {
 						SchemaProps: spec.SchemaProps{
 							Description: "ReplicaStatuses is map of ReplicaType and ReplicaStatus, specifies the status of each replica.",
@@ -451,17 +866,119 @@ func schema_pkg_apis_kubefloworg_v1_JobStatus(ref common.ReferenceCallback) comm
 							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
 						},
 					},
+					"duration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Represents the duration of the job from its StartTime to its CompletionTime. It is set when the job succeeds or fails.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
 					"lastReconcileTime": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Represents last time when the job was reconciled. It is not guaranteed to be set in happens-before order across separate operations. It is represented in RFC3339 form and is in UTC.",
 							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
 						},
 					},
+					"gangRestarts": {
+						SchemaProps: spec.SchemaProps{
+							Description: "GangRestarts is the number of times all the pods of the job were restarted together because a pod of a replica type whose RestartPolicy is GangRestart failed. The job fails once it exceeds the runPolicy.backoffLimit.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"gangScheduling": {
+						SchemaProps: spec.SchemaProps{
+							Description: "GangScheduling records the PodGroup created for the job by a gang scheduler, so that it is cleaned up even if the gang scheduling is disabled or switched to another scheduler afterwards.",
+							Ref:         ref("github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.GangSchedulingStatus"),
+						},
+					},
+					"tensorBoardURL": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TensorBoardURL is the in-cluster URL of the TensorBoard requested by the spec.tensorboard of the job, set once its Deployment and Service are created.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"outputs": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"name",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Outputs are the URIs of the artifacts declared by the spec.outputs of the job, e.g. its trained model, recorded once the job has succeeded.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.OutputStatus"),
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.JobCondition", "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.ReplicaStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+			"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.GangSchedulingStatus", "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.JobCondition", "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.OutputStatus", "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.ReplicaStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_pkg_apis_kubefloworg_v1_MPIElasticPolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "MPIElasticPolicy is the contract of the discover_hosts.sh script with elastic Horovod. The script lists one `host:slots` line per running worker, and the launcher when it requests GPUs. When SlotsPerWorker is unset, the slots of a worker are the GPUs requested by its pod, so that the slots follow the changes of the GPU count of the workers.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"minReplicas": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MinReplicas is the minimum number of running workers needed by elastic Horovod. While the launcher runs with fewer running workers, the HostsInsufficient condition of the MPIJob is set.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"refreshIntervalSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RefreshIntervalSeconds is the interval in seconds at which the controller refreshes discover_hosts.sh from the running workers, in addition to the refreshes triggered by the changes of the worker pods. Defaults to 0, which disables the periodic refresh.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_kubefloworg_v1_MPIHostfileTemplate(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "MPIHostfileTemplate are the Go templates of the entries of the hosts of an MPIJob, rendered for the launcher, when it requests GPUs, and for each worker with the fields:\n  - .Host: the host of the pod, its name or the host given by HostnameSource.\n  - .PodName: the name of the pod.\n  - .Namespace: the namespace of the MPIJob.\n  - .Slots: the slots of the pod.\n\ne.g. `{{.Host}} slots={{.Slots}} max_slots={{.Slots}}`, or `{{.Host}}.{{.Namespace}}.example.com:{{.Slots}}` for a custom domain suffix.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"hostfile": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Hostfile is the template of the line of a host in the hostfile. Defaults to the format of MPIImplementation.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"discoverHosts": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DiscoverHosts is the template of the host echoed by the discover_hosts.sh script. Defaults to `{{.Host}}:{{.Slots}}`.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
 	}
 }
 
@@ -567,9 +1084,8 @@ func schema_pkg_apis_kubefloworg_v1_MPIJobSpec(ref common.ReferenceCallback) com
 				Properties: map[string]spec.Schema{
 					"slotsPerWorker": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Specifies the number of slots per worker used in hostfile. Defaults to 1.",
-							Type:        []string{"integer"},
-							Format:      "int32",
+							Description: "Specifies the number of slots per worker used in hostfile, or `auto` to use the GPUs in the limits of the containers of the workers. Defaults to 1.",
+							Ref:         ref("k8s.io/apimachinery/pkg/util/intstr.IntOrString"),
 						},
 					},
 					"cleanPodPolicy": {
@@ -593,6 +1109,34 @@ func schema_pkg_apis_kubefloworg_v1_MPIJobSpec(ref common.ReferenceCallback) com
 							},
 						},
 					},
+					"commonEnv": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CommonEnv is the list of the environment variables set in the main container of every replica, e.g. proxy settings or credentials referenced by a secretKeyRef. The variables defined in the container of a replica take precedence.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.EnvVar"),
+									},
+								},
+							},
+						},
+					},
+					"commonEnvFrom": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CommonEnvFrom is the list of the sources of the environment variables of the main container of every replica. The sources of the container of a replica take precedence.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.EnvFromSource"),
+									},
+								},
+							},
+						},
+					},
 					"mainContainer": {
 						SchemaProps: spec.SchemaProps{
 							Description: "MainContainer specifies name of the main container which executes the MPI code.",
@@ -600,40 +1144,217 @@ func schema_pkg_apis_kubefloworg_v1_MPIJobSpec(ref common.ReferenceCallback) com
 							Format:      "",
 						},
 					},
-					"runPolicy": {
+					"preflightCheck": {
 						SchemaProps: spec.SchemaProps{
-							Description: "`RunPolicy` encapsulates various runtime policies of the distributed training job, for example how to clean up resources and how long the job can stay active.",
-							Default:     map[string]interface{}{},
-							Ref:         ref("github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.RunPolicy"),
+							Description: "PreflightCheck, if set to true, makes the controller verify that the worker containers provide the runtime pieces required by the kubexec bootstrap (a /bin/sh reachable through `kubectl exec`) before the launcher is created. The MPIJob is marked as failed if the check does not pass. Defaults to false.",
+							Type:        []string{"boolean"},
+							Format:      "",
 						},
 					},
-				},
-				Required: []string{"mpiReplicaSpecs"},
-			},
-		},
-		Dependencies: []string{
-			"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.ReplicaSpec", "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.RunPolicy"},
-	}
-}
-
-func schema_pkg_apis_kubefloworg_v1_PaddleElasticPolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Type: []string{"object"},
-				Properties: map[string]spec.Schema{
-					"minReplicas": {
+					"elasticPolicy": {
 						SchemaProps: spec.SchemaProps{
-							Description: "minReplicas is the lower limit for the number of replicas to which the training job can scale down.  It defaults to null.",
-							Type:        []string{"integer"},
-							Format:      "int32",
+							Description: "ElasticPolicy configures the discover_hosts.sh script used by elastic Horovod to find the running workers.",
+							Ref:         ref("github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.MPIElasticPolicy"),
 						},
 					},
-					"maxReplicas": {
+					"hostnameSource": {
 						SchemaProps: spec.SchemaProps{
-							Description: "upper limit for the number of pods that can be set by the autoscaler; cannot be smaller than MinReplicas, defaults to null.",
-							Type:        []string{"integer"},
-							Format:      "int32",
+							Description: "HostnameSource is the source of the worker hosts written in the hostfile and the discover_hosts.sh script. One of PodName, PodIP and HostAliases. PodIP uses the IPs of the running worker pods, refreshed when they change, for clusters where the DNS resolution of the pod names is slow or unreliable. HostAliases keeps the pod names, resolved through the hostAliases of the launcher pod, which is created once all the workers are running and is not updated afterwards, so it suits the jobs whose workers are not replaced. Defaults to PodName.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"mpiImplementation": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MPIImplementation is the MPI implementation of the images, which selects the format of the hostfile and the environment variables configuring the remote shell agent and the ports of the MPI processes. One of OpenMPI, IntelMPI and MPICH. When unset, the launcher gets the remote shell agent variables of both Open MPI and Intel MPI, and the hostfile uses the Open MPI format.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"bindGPUsPerRank": {
+						SchemaProps: spec.SchemaProps{
+							Description: "BindGPUsPerRank, if set to true, generates the gpu_wrapper.sh script in the ConfigMap of the job, mounted at /etc/mpi in the launcher and the workers, which sets CUDA_VISIBLE_DEVICES to the GPUs of the local rank of the MPI process before running the command given as its arguments, e.g. `mpirun /etc/mpi/gpu_wrapper.sh python train.py`, so that the ranks sharing a worker don't all use GPU 0. The GPUs of a worker are split evenly between its slots, and the ranks share a GPU when the worker has more slots than GPUs. Defaults to false.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"launcherAsJob": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LauncherAsJob, if set to true, runs the launcher in a batch/v1 Job instead of a bare pod, so that transient failures of the launcher are retried by the Job up to RunPolicy.BackoffLimit times before the MPIJob is marked as failed. MPIJobs created through the v2beta1 API always run the launcher as a Job. Defaults to false.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"hostfileTemplate": {
+						SchemaProps: spec.SchemaProps{
+							Description: "HostfileTemplate customizes the entries of the hosts in the hostfile and in the discover_hosts.sh script, for the MPI distributions expecting another format than the one of MPIImplementation.",
+							Ref:         ref("github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.MPIHostfileTemplate"),
+						},
+					},
+					"runPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "`RunPolicy` encapsulates various runtime policies of the distributed training job, for example how to clean up resources and how long the job can stay active.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.RunPolicy"),
+						},
+					},
+					"jobClassName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "JobClassName is the name of the TrainingJobClass whose defaults are merged into the job when it is admitted by the training operator.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"dependsOn": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DependsOn are the jobs of the namespace which must succeed before the pods of the job are created, e.g. the preprocessing job of a training job. The job waits for them, and fails if one of them fails.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.JobReference"),
+									},
+								},
+							},
+						},
+					},
+					"tensorboard": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TensorBoard requests a TensorBoard serving the logs of the job, whose URL is reported in its status. The TensorBoard is deleted with the pods of the job, according to its CleanPodPolicy.",
+							Ref:         ref("github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.TensorBoardSpec"),
+						},
+					},
+					"outputs": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"name",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Outputs declares the artifacts produced by the job, e.g. its trained model, whose URIs are recorded in its status once it has succeeded.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.OutputSpec"),
+									},
+								},
+							},
+						},
+					},
+					"datasets": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"name",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Datasets are staged into the pods of all the replicas of the job before their containers start, so that the training reads them from the mount paths of its main container.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.DatasetSpec"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"mpiReplicaSpecs"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.DatasetSpec", "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.JobReference", "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.MPIElasticPolicy", "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.MPIHostfileTemplate", "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.OutputSpec", "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.ReplicaSpec", "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.RunPolicy", "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.TensorBoardSpec", "k8s.io/api/core/v1.EnvFromSource", "k8s.io/api/core/v1.EnvVar", "k8s.io/apimachinery/pkg/util/intstr.IntOrString"},
+	}
+}
+
+func schema_pkg_apis_kubefloworg_v1_OutputSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "OutputSpec declares an artifact produced by a job, e.g. its trained model, whose URI is recorded in the status of the job once it has succeeded.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the output, e.g. model.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"uri": {
+						SchemaProps: spec.SchemaProps{
+							Description: "URI of the output when it is known before the job runs, e.g. s3://models/mnist. Otherwise, e.g. when the training chooses a versioned location, the main container of the pod of the master role reports the URI as a name=uri line of its termination message, whose path is set in the KUBEFLOW_OUTPUTS_FILE env var of the container.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_kubefloworg_v1_OutputStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "OutputStatus is the URI of an output of a job.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the output in the spec.outputs of the job.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"uri": {
+						SchemaProps: spec.SchemaProps{
+							Description: "URI of the output.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name", "uri"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_kubefloworg_v1_PaddleElasticPolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"minReplicas": {
+						SchemaProps: spec.SchemaProps{
+							Description: "minReplicas is the lower limit for the number of replicas to which the training job can scale down.  It defaults to null.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"maxReplicas": {
+						SchemaProps: spec.SchemaProps{
+							Description: "upper limit for the number of pods that can be set by the autoscaler; cannot be smaller than MinReplicas, defaults to null.",
+							Type:        []string{"integer"},
+							Format:      "int32",
 						},
 					},
 					"maxRestarts": {
@@ -779,6 +1500,77 @@ func schema_pkg_apis_kubefloworg_v1_PaddleJobSpec(ref common.ReferenceCallback)
 							Ref:         ref("github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.RunPolicy"),
 						},
 					},
+					"jobClassName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "JobClassName is the name of the TrainingJobClass whose defaults are merged into the job when it is admitted by the training operator.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"dependsOn": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DependsOn are the jobs of the namespace which must succeed before the pods of the job are created, e.g. the preprocessing job of a training job. The job waits for them, and fails if one of them fails.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.JobReference"),
+									},
+								},
+							},
+						},
+					},
+					"tensorboard": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TensorBoard requests a TensorBoard serving the logs of the job, whose URL is reported in its status. The TensorBoard is deleted with the pods of the job, according to its CleanPodPolicy.",
+							Ref:         ref("github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.TensorBoardSpec"),
+						},
+					},
+					"outputs": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"name",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Outputs declares the artifacts produced by the job, e.g. its trained model, whose URIs are recorded in its status once it has succeeded.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.OutputSpec"),
+									},
+								},
+							},
+						},
+					},
+					"datasets": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"name",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Datasets are staged into the pods of all the replicas of the job before their containers start, so that the training reads them from the mount paths of its main container.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.DatasetSpec"),
+									},
+								},
+							},
+						},
+					},
 					"elasticPolicy": {
 						SchemaProps: spec.SchemaProps{
 							Description: "ElasticPolicy holds the elastic policy for paddle job.",
@@ -787,7 +1579,7 @@ func schema_pkg_apis_kubefloworg_v1_PaddleJobSpec(ref common.ReferenceCallback)
 					},
 					"paddleReplicaSpecs": {
 						SchemaProps: spec.SchemaProps{
-							Description: "A map of PaddleReplicaType (type) to ReplicaSpec (value). Specifies the Paddle cluster configuration. For example,\n  {\n    \"Master\": PaddleReplicaSpec,\n    \"Worker\": PaddleReplicaSpec,\n  }",
+							Description: "A map of PaddleReplicaType (type) to ReplicaSpec (value). Specifies the Paddle cluster configuration. For example,\n  {\n    \"Master\": PaddleReplicaSpec,\n    \"Worker\": PaddleReplicaSpec,\n  }\nThe PServer replicas run the job in heterogeneous mode, where the parameter servers serve the Worker replicas training in collective mode.",
 							Type:        []string{"object"},
 							AdditionalProperties: &spec.SchemaOrBool{
 								Allows: true,
@@ -799,12 +1591,40 @@ func schema_pkg_apis_kubefloworg_v1_PaddleJobSpec(ref common.ReferenceCallback)
 							},
 						},
 					},
+					"commonEnv": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CommonEnv is the list of the environment variables set in the main container of every replica, e.g. proxy settings or credentials referenced by a secretKeyRef. The variables defined in the container of a replica take precedence.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.EnvVar"),
+									},
+								},
+							},
+						},
+					},
+					"commonEnvFrom": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CommonEnvFrom is the list of the sources of the environment variables of the main container of every replica. The sources of the container of a replica take precedence.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.EnvFromSource"),
+									},
+								},
+							},
+						},
+					},
 				},
 				Required: []string{"runPolicy", "paddleReplicaSpecs"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.PaddleElasticPolicy", "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.ReplicaSpec", "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.RunPolicy"},
+			"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.DatasetSpec", "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.JobReference", "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.OutputSpec", "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.PaddleElasticPolicy", "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.ReplicaSpec", "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.RunPolicy", "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.TensorBoardSpec", "k8s.io/api/core/v1.EnvFromSource", "k8s.io/api/core/v1.EnvVar", "k8s.io/apimachinery/pkg/util/intstr.IntOrString"},
 	}
 }
 
@@ -922,44 +1742,150 @@ func schema_pkg_apis_kubefloworg_v1_PyTorchJobSpec(ref common.ReferenceCallback)
 							Ref:         ref("github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.RunPolicy"),
 						},
 					},
-					"elasticPolicy": {
+					"jobClassName": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.ElasticPolicy"),
+							Description: "JobClassName is the name of the TrainingJobClass whose defaults are merged into the job when it is admitted by the training operator.",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"pytorchReplicaSpecs": {
+					"dependsOn": {
 						SchemaProps: spec.SchemaProps{
-							Description: "A map of PyTorchReplicaType (type) to ReplicaSpec (value). Specifies the PyTorch cluster configuration. For example,\n  {\n    \"Master\": PyTorchReplicaSpec,\n    \"Worker\": PyTorchReplicaSpec,\n  }",
-							Type:        []string{"object"},
-							AdditionalProperties: &spec.SchemaOrBool{
-								Allows: true,
+							Description: "DependsOn are the jobs of the namespace which must succeed before the pods of the job are created, e.g. the preprocessing job of a training job. The job waits for them, and fails if one of them fails.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
-										Ref: ref("github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.ReplicaSpec"),
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.JobReference"),
 									},
 								},
 							},
 						},
 					},
-					"nprocPerNode": {
+					"tensorboard": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Number of workers per node; supported values: [auto, cpu, gpu, int]. For more, https://github.com/pytorch/pytorch/blob/26f7f470df64d90e092081e39507e4ac751f55d6/torch/distributed/run.py#L629-L658. Defaults to auto.",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "TensorBoard requests a TensorBoard serving the logs of the job, whose URL is reported in its status. The TensorBoard is deleted with the pods of the job, according to its CleanPodPolicy.",
+							Ref:         ref("github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.TensorBoardSpec"),
 						},
 					},
-				},
-				Required: []string{"runPolicy", "pytorchReplicaSpecs"},
-			},
-		},
-		Dependencies: []string{
-			"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.ElasticPolicy", "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.ReplicaSpec", "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.RunPolicy"},
-	}
-}
-
-func schema_pkg_apis_kubefloworg_v1_RDZVConf(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
+					"outputs": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"name",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Outputs declares the artifacts produced by the job, e.g. its trained model, whose URIs are recorded in its status once it has succeeded.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.OutputSpec"),
+									},
+								},
+							},
+						},
+					},
+					"datasets": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"name",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Datasets are staged into the pods of all the replicas of the job before their containers start, so that the training reads them from the mount paths of its main container.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.DatasetSpec"),
+									},
+								},
+							},
+						},
+					},
+					"elasticPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.ElasticPolicy"),
+						},
+					},
+					"successPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SuccessPolicy defines the policy to mark the PyTorchJob as succeeded. Default to \"\", using the default rules. Supported values are \"\", \"ChiefOrMaster\" and \"AllWorkers\".",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"pytorchReplicaSpecs": {
+						SchemaProps: spec.SchemaProps{
+							Description: "A map of PyTorchReplicaType (type) to ReplicaSpec (value). Specifies the PyTorch cluster configuration. For example,\n  {\n    \"Master\": PyTorchReplicaSpec,\n    \"Worker\": PyTorchReplicaSpec,\n  }",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.ReplicaSpec"),
+									},
+								},
+							},
+						},
+					},
+					"commonEnv": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CommonEnv is the list of the environment variables set in the main container of every replica, e.g. proxy settings or credentials referenced by a secretKeyRef. The variables defined in the container of a replica take precedence.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.EnvVar"),
+									},
+								},
+							},
+						},
+					},
+					"commonEnvFrom": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CommonEnvFrom is the list of the sources of the environment variables of the main container of every replica. The sources of the container of a replica take precedence.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.EnvFromSource"),
+									},
+								},
+							},
+						},
+					},
+					"nprocPerNode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Number of workers per node; supported values: [auto, cpu, gpu, int]. For more, https://github.com/pytorch/pytorch/blob/26f7f470df64d90e092081e39507e4ac751f55d6/torch/distributed/run.py#L629-L658. Defaults to auto.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"runPolicy", "pytorchReplicaSpecs"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.DatasetSpec", "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.ElasticPolicy", "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.JobReference", "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.OutputSpec", "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.ReplicaSpec", "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.RunPolicy", "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.TensorBoardSpec", "k8s.io/api/core/v1.EnvFromSource", "k8s.io/api/core/v1.EnvVar", "k8s.io/apimachinery/pkg/util/intstr.IntOrString"},
+	}
+}
+
+func schema_pkg_apis_kubefloworg_v1_RDZVConf(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
 				Type: []string{"object"},
 				Properties: map[string]spec.Schema{
@@ -981,6 +1907,92 @@ func schema_pkg_apis_kubefloworg_v1_RDZVConf(ref common.ReferenceCallback) commo
 	}
 }
 
+func schema_pkg_apis_kubefloworg_v1_RabitPolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RabitPolicy configures the Rabit tracker of an XGBoostJob. The controller passes it to the master and the workers through the env vars of their containers.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"trackerPort": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TrackerPort is the port the Rabit tracker listens on, set in the MASTER_PORT env var. Defaults to the xgboostjob-port of the master container.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"numWorkers": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NumWorkers is the number of workers the Rabit tracker waits for, set in the WORLD_SIZE env var. Defaults to the total number of replicas, the master included.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"env": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Env is the list of the extra Rabit env vars, e.g. DMLC_WORKER_CONNECT_RETRY, set in the containers of the master and the workers. The env vars set by the controller can not be overridden.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.EnvVar"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/api/core/v1.EnvVar"},
+	}
+}
+
+func schema_pkg_apis_kubefloworg_v1_ReplicaPodStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ReplicaPodStatus represents the current observed state of a pod of a replica type.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the pod.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"phase": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Phase of the pod.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"hostIP": {
+						SchemaProps: spec.SchemaProps{
+							Description: "HostIP is the IP address of the node the pod is bound to.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"podIP": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PodIP is the IP address allocated to the pod.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_kubefloworg_v1_ReplicaSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -1004,7 +2016,28 @@ func schema_pkg_apis_kubefloworg_v1_ReplicaSpec(ref common.ReferenceCallback) co
 					},
 					"restartPolicy": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Restart policy for all replicas within the job. One of Always, OnFailure, Never and ExitCode. Default to Never.",
+							Description: "Restart policy for all replicas within the job. One of Always, OnFailure, Never, ExitCode and GangRestart. Default to Never.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"toleratedFailures": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ToleratedFailures is the number of failed pods of the replica type which do not fail the job. It is only supported for the Evaluator replica type of TFJob, so that a few failed evaluator pods do not fail the whole training.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"capacityType": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CapacityType is the capacity type of the nodes the pods of the replica type run on. One of spot, on-demand and prefer-spot. The pods get the node affinity and the tolerations which match the spot node pools of EKS, GKE and AKS. Defaults to unset, the pods can run on any node.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"resourceProfile": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ResourceProfile is the name of the resource profile of the operator, e.g. gpu-large, whose requests and limits are set on the main container of the pods of the replica type. The requests and the limits of the container take precedence over the profile.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
@@ -1058,11 +2091,44 @@ func schema_pkg_apis_kubefloworg_v1_ReplicaStatus(ref common.ReferenceCallback)
 							Format:      "",
 						},
 					},
+					"toleratedFailed": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The number of failed pods which did not fail the job, as they are within the tolerated failures of the replica type.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"failureReason": {
+						SchemaProps: spec.SchemaProps{
+							Description: "FailureReason is the reason of the failure of a failed pod of the replica type, or of a Pending pod of the replica type whose image cannot be pulled.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"pods": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Pods are the pods of the replica type ordered by their replica index, e.g. for the SDKs and the UIs to locate their logs without listing the pods.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.ReplicaPodStatus"),
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"},
+			"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.ReplicaPodStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"},
 	}
 }
 
@@ -1075,117 +2141,540 @@ func schema_pkg_apis_kubefloworg_v1_RunPolicy(ref common.ReferenceCallback) comm
 				Properties: map[string]spec.Schema{
 					"cleanPodPolicy": {
 						SchemaProps: spec.SchemaProps{
-							Description: "CleanPodPolicy defines the policy to kill pods after the job completes. Default to None.",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "CleanPodPolicy defines the policy to kill pods after the job completes. Default to None.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"ttlSecondsAfterFinished": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TTLSecondsAfterFinished is the TTL to clean up jobs. It may take extra ReconcilePeriod seconds for the cleanup, since reconcile gets called periodically. Default to infinite.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"activeDeadlineSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Specifies the duration in seconds relative to the startTime that the job may be active before the system tries to terminate it; value must be positive integer.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"backoffLimit": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Optional number of retries before marking this job failed.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"schedulingPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SchedulingPolicy defines the policy related to scheduling, e.g. gang-scheduling",
+							Ref:         ref("github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.SchedulingPolicy"),
+						},
+					},
+					"failurePolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "FailurePolicy defines how failed pods are handled based on the exit codes of their containers. It takes precedence over the RestartPolicy of the replicas.",
+							Ref:         ref("github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.FailurePolicy"),
+						},
+					},
+					"checkpointPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CheckpointPolicy defines how running pods are asked to save a checkpoint before they are deleted by the controller.",
+							Ref:         ref("github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.CheckpointPolicy"),
+						},
+					},
+					"suspend": {
+						SchemaProps: spec.SchemaProps{
+							Description: "suspend specifies whether the Job controller should create Pods or not. If a Job is created with suspend set to true, no Pods are created by the Job controller. If a Job is suspended after creation (i.e. the flag goes from false to true), the Job controller will delete all active Pods and PodGroups associated with this Job. Users must design their workload to gracefully handle this. Suspending a Job will reset the StartTime field of the Job.\n\nDefaults to false.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"standalone": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Standalone collapses the job to a single pod of its primary replica type, e.g. the Master of a PyTorchJob or the first Worker of a JAXJob, without services, so that manifests can be smoke-tested in CI with the same CRD used in production. The pod runs as a world of size 1 with its rendezvous address set to localhost. Not supported by MPIJob, which rejects it.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"managedBy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ManagedBy is used to indicate the controller or entity that manages a job. The value must be either an empty, 'kubeflow.org/training-operator' or 'kueue.x-k8s.io/multikueue'. The training-operator reconciles a job which doesn't have this field at all or the field value is the reserved string 'kubeflow.org/training-operator', but delegates reconciling the job with 'kueue.x-k8s.io/multikueue' to the Kueue. The field is immutable.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"rayClusterSpec": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RayClusterSpec is the spec of a transient RayCluster of KubeRay, e.g. for RLlib or the preprocessing of the data, created alongside the job and deleted once the job is finished or suspended. The cluster is named <job name>-ray, so that its head service is <job name>-ray-head-svc.",
+							Ref:         ref("k8s.io/apimachinery/pkg/runtime.RawExtension"),
+						},
+					},
+					"injectNetworkTuning": {
+						SchemaProps: spec.SchemaProps{
+							Description: "InjectNetworkTuning defines whether the NCCL and Gloo env vars of the network tuning ConfigMap of the operator, e.g. NCCL_SOCKET_IFNAME, NCCL_IB_DISABLE or GLOO_SOCKET_IFNAME, are injected into the pods of the job. The env vars defined in the containers or in the common env of the job take precedence. Defaults to the --inject-network-tuning flag of the operator.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"envInjectionPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EnvInjectionPolicy disables categories of the env vars injected by the operator into the pods of the job, e.g. for images which break with them. The rest of the lifecycle of the job is still managed by the operator.",
+							Ref:         ref("github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.EnvInjectionPolicy"),
+						},
+					},
+					"startPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StartPolicy brings up the pods of the job in waves instead of all at once, e.g. to smooth the image pulls and the churn of the CNI when starting jobs of 1000+ pods.",
+							Ref:         ref("github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.StartPolicy"),
+						},
+					},
+					"topologyPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TopologyPolicy places the pods of the job in the domains of a node topology, e.g. packed in a zone or spread across racks, since the performance of the collective communications of NCCL depends heavily on the co-location of the replicas.",
+							Ref:         ref("github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.TopologyPolicy"),
+						},
+					},
+					"retainServices": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RetainServices keeps the services of the job once it is finished, until the job is deleted, e.g. when its TTLSecondsAfterFinished expires, so that the ports of the pods, e.g. the TensorBoard or the profiler of the chief, can still be reached with kubectl port-forward. The pods are kept with the None CleanPodPolicy. Defaults to false.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"extensions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Extensions holds the settings of the job which are unknown to this version of the operator, e.g. the settings of a newer SDK or of another controller reconciling the job. They are kept as is rather than pruned by the API server, and they are not interpreted by the operator. The other unknown fields of the job are pruned.",
+							Ref:         ref("k8s.io/apimachinery/pkg/runtime.RawExtension"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.CheckpointPolicy", "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.EnvInjectionPolicy", "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.FailurePolicy", "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.SchedulingPolicy", "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.StartPolicy", "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.TopologyPolicy", "k8s.io/apimachinery/pkg/runtime.RawExtension"},
+	}
+}
+
+func schema_pkg_apis_kubefloworg_v1_SchedulingPolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SchedulingPolicy encapsulates various scheduling policies of the distributed training job, for example `minAvailable` for gang-scheduling.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"minAvailable": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int32",
+						},
+					},
+					"queue": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"minResources": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+									},
+								},
+							},
+						},
+					},
+					"priorityClass": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"scheduleTimeoutSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int32",
+						},
+					},
+					"sameTopology": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SameTopology is the key of a node label, such as topology.kubernetes.io/zone, whose value must be the same on the nodes of all the pods of the job. The controller adds a required pod affinity to the pods, so that all the replicas run in the topology domain of the first scheduled pod, since the traffic between the replicas is slower and costlier across domains.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/api/resource.Quantity"},
+	}
+}
+
+func schema_pkg_apis_kubefloworg_v1_StartPolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "StartPolicy describes how the pods of each replica type of a job are created in waves. The pods of the next wave are created once all the pods created before are ready, and the launcher of an MPIJob once all its workers are ready. Since the pods of a gang are only scheduled together, it must not be used with the gang scheduling.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"waveSize": {
+						SchemaProps: spec.SchemaProps{
+							Description: "WaveSize is the number of the pods of each replica type created per wave, or their percentage of the replicas of the type rounded up, e.g. 25%.",
+							Ref:         ref("k8s.io/apimachinery/pkg/util/intstr.IntOrString"),
+						},
+					},
+				},
+				Required: []string{"waveSize"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/util/intstr.IntOrString"},
+	}
+}
+
+func schema_pkg_apis_kubefloworg_v1_TFJob(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TFJob represents a TFJob resource.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Specification of the desired state of the TFJob.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.TFJobSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Most recently observed status of the TFJob. Populated by the system. Read-only.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.JobStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.JobStatus", "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.TFJobSpec", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_pkg_apis_kubefloworg_v1_TFJobList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TFJobList is a list of TFJobs.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Standard list metadata.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Description: "List of TFJobs.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.TFJob"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"items"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.TFJob", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+	}
+}
+
+func schema_pkg_apis_kubefloworg_v1_TFJobSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TFJobSpec is a desired state description of the TFJob.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"runPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RunPolicy encapsulates various runtime policies of the distributed training job, for example how to clean up resources and how long the job can stay active.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.RunPolicy"),
+						},
+					},
+					"jobClassName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "JobClassName is the name of the TrainingJobClass whose defaults are merged into the job when it is admitted by the training operator.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"dependsOn": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DependsOn are the jobs of the namespace which must succeed before the pods of the job are created, e.g. the preprocessing job of a training job. The job waits for them, and fails if one of them fails.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.JobReference"),
+									},
+								},
+							},
+						},
+					},
+					"tensorboard": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TensorBoard requests a TensorBoard serving the logs of the job, whose URL is reported in its status. The TensorBoard is deleted with the pods of the job, according to its CleanPodPolicy.",
+							Ref:         ref("github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.TensorBoardSpec"),
+						},
+					},
+					"outputs": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"name",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Outputs declares the artifacts produced by the job, e.g. its trained model, whose URIs are recorded in its status once it has succeeded.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.OutputSpec"),
+									},
+								},
+							},
+						},
+					},
+					"datasets": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"name",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Datasets are staged into the pods of all the replicas of the job before their containers start, so that the training reads them from the mount paths of its main container.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.DatasetSpec"),
+									},
+								},
+							},
 						},
 					},
-					"ttlSecondsAfterFinished": {
+					"successPolicy": {
 						SchemaProps: spec.SchemaProps{
-							Description: "TTLSecondsAfterFinished is the TTL to clean up jobs. It may take extra ReconcilePeriod seconds for the cleanup, since reconcile gets called periodically. Default to infinite.",
-							Type:        []string{"integer"},
-							Format:      "int32",
+							Description: "SuccessPolicy defines the policy to mark the TFJob as succeeded. Default to \"\", using the default rules. Supported values are \"\", \"ChiefOrMaster\" and \"AllWorkers\".",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"activeDeadlineSeconds": {
+					"tfReplicaSpecs": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Specifies the duration in seconds relative to the startTime that the job may be active before the system tries to terminate it; value must be positive integer.",
-							Type:        []string{"integer"},
-							Format:      "int64",
+							Description: "A map of TFReplicaType (type) to ReplicaSpec (value). Specifies the TF cluster configuration. For example,\n  {\n    \"PS\": ReplicaSpec,\n    \"Worker\": ReplicaSpec,\n  }",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.ReplicaSpec"),
+									},
+								},
+							},
 						},
 					},
-					"backoffLimit": {
+					"commonEnv": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional number of retries before marking this job failed.",
-							Type:        []string{"integer"},
-							Format:      "int32",
+							Description: "CommonEnv is the list of the environment variables set in the main container of every replica, e.g. proxy settings or credentials referenced by a secretKeyRef. The variables defined in the container of a replica take precedence.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.EnvVar"),
+									},
+								},
+							},
 						},
 					},
-					"schedulingPolicy": {
+					"commonEnvFrom": {
 						SchemaProps: spec.SchemaProps{
-							Description: "SchedulingPolicy defines the policy related to scheduling, e.g. gang-scheduling",
-							Ref:         ref("github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.SchedulingPolicy"),
+							Description: "CommonEnvFrom is the list of the sources of the environment variables of the main container of every replica. The sources of the container of a replica take precedence.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.EnvFromSource"),
+									},
+								},
+							},
 						},
 					},
-					"suspend": {
+					"enableDynamicWorker": {
 						SchemaProps: spec.SchemaProps{
-							Description: "suspend specifies whether the Job controller should create Pods or not. If a Job is created with suspend set to true, no Pods are created by the Job controller. If a Job is suspended after creation (i.e. the flag goes from false to true), the Job controller will delete all active Pods and PodGroups associated with this Job. Users must design their workload to gracefully handle this. Suspending a Job will reset the StartTime field of the Job.\n\nDefaults to false.",
+							Description: "A switch to enable dynamic worker",
 							Type:        []string{"boolean"},
 							Format:      "",
 						},
 					},
-					"managedBy": {
+					"tfConfigStrategy": {
 						SchemaProps: spec.SchemaProps{
-							Description: "ManagedBy is used to indicate the controller or entity that manages a job. The value must be either an empty, 'kubeflow.org/training-operator' or 'kueue.x-k8s.io/multikueue'. The training-operator reconciles a job which doesn't have this field at all or the field value is the reserved string 'kubeflow.org/training-operator', but delegates reconciling the job with 'kueue.x-k8s.io/multikueue' to the Kueue. The field is immutable.",
+							Description: "TFConfigStrategy defines how the cluster of the TFJob is passed to its replicas. Defaults to \"TFConfig\", the TF_CONFIG JSON. \"GRPCWorkerCache\" sets TF_GRPC_WORKER_CACHE to the cluster in the \"ps|host:port;host:port,worker|host:port\" form, and the task of the replica in TF_TASK_TYPE and TF_TASK_INDEX. \"None\" sets none of them, for the users who build their own cluster, e.g. with a cluster resolver.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
 				},
+				Required: []string{"runPolicy", "tfReplicaSpecs"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.SchedulingPolicy"},
+			"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.DatasetSpec", "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.JobReference", "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.OutputSpec", "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.ReplicaSpec", "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.RunPolicy", "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.TensorBoardSpec", "k8s.io/api/core/v1.EnvFromSource", "k8s.io/api/core/v1.EnvVar", "k8s.io/apimachinery/pkg/util/intstr.IntOrString"},
 	}
 }
 
-func schema_pkg_apis_kubefloworg_v1_SchedulingPolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_kubefloworg_v1_TensorBoardSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "SchedulingPolicy encapsulates various scheduling policies of the distributed training job, for example `minAvailable` for gang-scheduling.",
+				Description: "TensorBoardSpec is the TensorBoard served alongside a job for its logs.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"minAvailable": {
+					"logDir": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"integer"},
-							Format: "int32",
+							Description: "LogDir is the directory of the logs read by TensorBoard, either a path in a PersistentVolumeClaim of the namespace as pvc://<claim-name>/<path>, mounted read-only, or an S3 URL as s3://<bucket>/<path>.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"queue": {
+					"image": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Description: "Image of the TensorBoard container. Defaults to the --tensorboard-image of the operator.",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"minResources": {
+					"env": {
 						SchemaProps: spec.SchemaProps{
-							Type: []string{"object"},
-							AdditionalProperties: &spec.SchemaOrBool{
-								Allows: true,
+							Description: "Env is the list of the environment variables set in the TensorBoard container, e.g. the credentials and the endpoint of S3.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
-										Ref: ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.EnvVar"),
 									},
 								},
 							},
 						},
 					},
-					"priorityClass": {
+				},
+				Required: []string{"logDir"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/api/core/v1.EnvVar"},
+	}
+}
+
+func schema_pkg_apis_kubefloworg_v1_TopologyPolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TopologyPolicy describes how the pods of all the replica types of a job are placed in the domains of a node topology. The controller translates it into a pod affinity or a topology spread constraint of the pods, added to the ones of their templates.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"placement": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Description: "Placement of the pods in the domains of the topology. Pack prefers running all the pods in the same domain, Same requires it, and Spread prefers spreading them evenly across the domains. One of Pack, Same and Spread.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"scheduleTimeoutSeconds": {
+					"topologyKey": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"integer"},
-							Format: "int32",
+							Description: "TopologyKey is the key of the node label whose values are the domains of the topology, e.g. topology.kubernetes.io/zone or the rack label of the cluster. Defaults to topology.kubernetes.io/zone.",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
 				},
+				Required: []string{"placement"},
 			},
 		},
-		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/api/resource.Quantity"},
 	}
 }
 
-func schema_pkg_apis_kubefloworg_v1_TFJob(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_kubefloworg_v1_TrainingJobClass(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "TFJob represents a TFJob resource.",
+				Description: "TrainingJobClass holds the defaults of an organization merged into the jobs of all kinds which set its name in their spec.jobClassName, when they are admitted by the training operator.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
 					"kind": {
@@ -1210,31 +2699,24 @@ func schema_pkg_apis_kubefloworg_v1_TFJob(ref common.ReferenceCallback) common.O
 					},
 					"spec": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Specification of the desired state of the TFJob.",
-							Default:     map[string]interface{}{},
-							Ref:         ref("github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.TFJobSpec"),
-						},
-					},
-					"status": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Most recently observed status of the TFJob. Populated by the system. Read-only.",
+							Description: "Specification of the defaults of the jobs of the class.",
 							Default:     map[string]interface{}{},
-							Ref:         ref("github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.JobStatus"),
+							Ref:         ref("github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.TrainingJobClassSpec"),
 						},
 					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.JobStatus", "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.TFJobSpec", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+			"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.TrainingJobClassSpec", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
 	}
 }
 
-func schema_pkg_apis_kubefloworg_v1_TFJobList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_kubefloworg_v1_TrainingJobClassList(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "TFJobList is a list of TFJobs.",
+				Description: "TrainingJobClassList is a list of TrainingJobClasses.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
 					"kind": {
@@ -1260,13 +2742,13 @@ func schema_pkg_apis_kubefloworg_v1_TFJobList(ref common.ReferenceCallback) comm
 					},
 					"items": {
 						SchemaProps: spec.SchemaProps{
-							Description: "List of TFJobs.",
+							Description: "List of TrainingJobClasses.",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.TFJob"),
+										Ref:     ref("github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.TrainingJobClass"),
 									},
 								},
 							},
@@ -1277,58 +2759,88 @@ func schema_pkg_apis_kubefloworg_v1_TFJobList(ref common.ReferenceCallback) comm
 			},
 		},
 		Dependencies: []string{
-			"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.TFJob", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+			"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.TrainingJobClass", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
 	}
 }
 
-func schema_pkg_apis_kubefloworg_v1_TFJobSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_kubefloworg_v1_TrainingJobClassSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "TFJobSpec is a desired state description of the TFJob.",
+				Description: "TrainingJobClassSpec is the defaults of the jobs of a TrainingJobClass. The values set by the jobs take precedence.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"runPolicy": {
+					"tolerations": {
 						SchemaProps: spec.SchemaProps{
-							Description: "RunPolicy encapsulates various runtime policies of the distributed training job, for example how to clean up resources and how long the job can stay active.",
-							Default:     map[string]interface{}{},
-							Ref:         ref("github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.RunPolicy"),
+							Description: "Tolerations are added to the pods of every replica, unless they already tolerate them.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.Toleration"),
+									},
+								},
+							},
 						},
 					},
-					"successPolicy": {
+					"nodeSelector": {
 						SchemaProps: spec.SchemaProps{
-							Description: "SuccessPolicy defines the policy to mark the TFJob as succeeded. Default to \"\", using the default rules.",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "NodeSelector is added to the node selector of the pods of every replica, for the keys they don't select yet.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
 						},
 					},
-					"tfReplicaSpecs": {
+					"env": {
 						SchemaProps: spec.SchemaProps{
-							Description: "A map of TFReplicaType (type) to ReplicaSpec (value). Specifies the TF cluster configuration. For example,\n  {\n    \"PS\": ReplicaSpec,\n    \"Worker\": ReplicaSpec,\n  }",
+							Description: "Env is the list of the environment variables set in the containers of every replica which don't define them.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.EnvVar"),
+									},
+								},
+							},
+						},
+					},
+					"images": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Images maps the names of the containers to the images set in the containers of that name which have no image.",
 							Type:        []string{"object"},
 							AdditionalProperties: &spec.SchemaOrBool{
 								Allows: true,
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
-										Ref: ref("github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.ReplicaSpec"),
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
 									},
 								},
 							},
 						},
 					},
-					"enableDynamicWorker": {
+					"runPolicy": {
 						SchemaProps: spec.SchemaProps{
-							Description: "A switch to enable dynamic worker",
-							Type:        []string{"boolean"},
-							Format:      "",
+							Description: "RunPolicy is merged into the runPolicy of the jobs, for the fields they don't set, except managedBy and suspend.",
+							Ref:         ref("github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.RunPolicy"),
 						},
 					},
 				},
-				Required: []string{"runPolicy", "tfReplicaSpecs"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.ReplicaSpec", "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.RunPolicy"},
+			"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.RunPolicy", "k8s.io/api/core/v1.EnvVar", "k8s.io/api/core/v1.Toleration"},
 	}
 }
 
@@ -1442,6 +2954,77 @@ func schema_pkg_apis_kubefloworg_v1_XGBoostJobSpec(ref common.ReferenceCallback)
 							Ref:         ref("github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.RunPolicy"),
 						},
 					},
+					"jobClassName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "JobClassName is the name of the TrainingJobClass whose defaults are merged into the job when it is admitted by the training operator.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"dependsOn": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DependsOn are the jobs of the namespace which must succeed before the pods of the job are created, e.g. the preprocessing job of a training job. The job waits for them, and fails if one of them fails.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.JobReference"),
+									},
+								},
+							},
+						},
+					},
+					"tensorboard": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TensorBoard requests a TensorBoard serving the logs of the job, whose URL is reported in its status. The TensorBoard is deleted with the pods of the job, according to its CleanPodPolicy.",
+							Ref:         ref("github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.TensorBoardSpec"),
+						},
+					},
+					"outputs": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"name",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Outputs declares the artifacts produced by the job, e.g. its trained model, whose URIs are recorded in its status once it has succeeded.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.OutputSpec"),
+									},
+								},
+							},
+						},
+					},
+					"datasets": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"name",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Datasets are staged into the pods of all the replicas of the job before their containers start, so that the training reads them from the mount paths of its main container.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.DatasetSpec"),
+									},
+								},
+							},
+						},
+					},
 					"xgbReplicaSpecs": {
 						SchemaProps: spec.SchemaProps{
 							Type: []string{"object"},
@@ -1455,12 +3038,46 @@ func schema_pkg_apis_kubefloworg_v1_XGBoostJobSpec(ref common.ReferenceCallback)
 							},
 						},
 					},
+					"commonEnv": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CommonEnv is the list of the environment variables set in the main container of every replica, e.g. proxy settings or credentials referenced by a secretKeyRef. The variables defined in the container of a replica take precedence.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.EnvVar"),
+									},
+								},
+							},
+						},
+					},
+					"commonEnvFrom": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CommonEnvFrom is the list of the sources of the environment variables of the main container of every replica. The sources of the container of a replica take precedence.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.EnvFromSource"),
+									},
+								},
+							},
+						},
+					},
+					"rabitPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RabitPolicy configures the Rabit tracker run by the master and the workers connecting to it.",
+							Ref:         ref("github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.RabitPolicy"),
+						},
+					},
 				},
 				Required: []string{"runPolicy", "xgbReplicaSpecs"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.ReplicaSpec", "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.RunPolicy"},
+			"github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.DatasetSpec", "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.JobReference", "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.OutputSpec", "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.RabitPolicy", "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.ReplicaSpec", "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.RunPolicy", "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1.TensorBoardSpec", "k8s.io/api/core/v1.EnvFromSource", "k8s.io/api/core/v1.EnvVar", "k8s.io/apimachinery/pkg/util/intstr.IntOrString"},
 	}
 }
 
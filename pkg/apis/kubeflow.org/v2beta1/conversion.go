@@ -0,0 +1,127 @@
+/*
+Copyright 2024 The Kubeflow Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2beta1
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+)
+
+var _ conversion.Convertible = &MPIJob{}
+
+// ConvertTo converts this MPIJob to the v1 hub version.
+func (src *MPIJob) ConvertTo(dstRaw conversion.Hub) error {
+	dst, ok := dstRaw.(*kubeflowv1.MPIJob)
+	if !ok {
+		return fmt.Errorf("unsupported hub type %T", dstRaw)
+	}
+	src.ObjectMeta.DeepCopyInto(&dst.ObjectMeta)
+	src.Status.DeepCopyInto(&dst.Status)
+
+	spec := src.Spec.DeepCopy()
+	dst.Spec = kubeflowv1.MPIJobSpec{
+		MPIReplicaSpecs:   spec.MPIReplicaSpecs,
+		CommonEnv:         spec.CommonEnv,
+		CommonEnvFrom:     spec.CommonEnvFrom,
+		MainContainer:     spec.MainContainer,
+		PreflightCheck:    spec.PreflightCheck,
+		HostnameSource:    spec.HostnameSource,
+		MPIImplementation: spec.MPIImplementation,
+		BindGPUsPerRank:   spec.BindGPUsPerRank,
+		ElasticPolicy:     spec.ElasticPolicy,
+		HostfileTemplate:  spec.HostfileTemplate,
+		JobClassName:      spec.JobClassName,
+		DependsOn:         spec.DependsOn,
+		TensorBoard:       spec.TensorBoard,
+		Outputs:           spec.Outputs,
+		Datasets:          spec.Datasets,
+		RunPolicy:         spec.RunPolicy,
+		LauncherAsJob:     ptr.To(true),
+	}
+	if spec.SlotsPerWorker != nil {
+		dst.Spec.SlotsPerWorker = ptr.To(intstr.FromInt32(*spec.SlotsPerWorker))
+	}
+	if _, ok := dst.Annotations[SlotsPerWorkerAutoAnnotation]; ok {
+		dst.Spec.SlotsPerWorker = ptr.To(intstr.FromString(kubeflowv1.SlotsPerWorkerAuto))
+		delete(dst.Annotations, SlotsPerWorkerAutoAnnotation)
+	}
+	if _, ok := dst.Annotations[LauncherAsPodAnnotation]; ok {
+		dst.Spec.LauncherAsJob = nil
+		delete(dst.Annotations, LauncherAsPodAnnotation)
+	}
+	if len(dst.Annotations) == 0 {
+		dst.Annotations = nil
+	}
+	return nil
+}
+
+// ConvertFrom converts the v1 hub version to this MPIJob.
+func (dst *MPIJob) ConvertFrom(srcRaw conversion.Hub) error {
+	src, ok := srcRaw.(*kubeflowv1.MPIJob)
+	if !ok {
+		return fmt.Errorf("unsupported hub type %T", srcRaw)
+	}
+	src.ObjectMeta.DeepCopyInto(&dst.ObjectMeta)
+	src.Status.DeepCopyInto(&dst.Status)
+
+	spec := src.Spec.DeepCopy()
+	dst.Spec = MPIJobSpec{
+		MPIReplicaSpecs:   spec.MPIReplicaSpecs,
+		CommonEnv:         spec.CommonEnv,
+		CommonEnvFrom:     spec.CommonEnvFrom,
+		MainContainer:     spec.MainContainer,
+		PreflightCheck:    spec.PreflightCheck,
+		HostnameSource:    spec.HostnameSource,
+		MPIImplementation: spec.MPIImplementation,
+		BindGPUsPerRank:   spec.BindGPUsPerRank,
+		ElasticPolicy:     spec.ElasticPolicy,
+		HostfileTemplate:  spec.HostfileTemplate,
+		JobClassName:      spec.JobClassName,
+		DependsOn:         spec.DependsOn,
+		TensorBoard:       spec.TensorBoard,
+		Outputs:           spec.Outputs,
+		Datasets:          spec.Datasets,
+		RunPolicy:         spec.RunPolicy,
+	}
+	// The deprecated spec.cleanPodPolicy only exists in v1; the validation
+	// guarantees it does not contradict runPolicy.cleanPodPolicy.
+	if dst.Spec.RunPolicy.CleanPodPolicy == nil {
+		dst.Spec.RunPolicy.CleanPodPolicy = spec.CleanPodPolicy
+	}
+	if !ptr.Deref(spec.LauncherAsJob, false) {
+		if dst.Annotations == nil {
+			dst.Annotations = map[string]string{}
+		}
+		dst.Annotations[LauncherAsPodAnnotation] = "true"
+	}
+	if slots := spec.SlotsPerWorker; slots != nil {
+		if slots.Type == intstr.Int {
+			dst.Spec.SlotsPerWorker = ptr.To(slots.IntVal)
+		} else {
+			if dst.Annotations == nil {
+				dst.Annotations = map[string]string{}
+			}
+			dst.Annotations[SlotsPerWorkerAutoAnnotation] = "true"
+		}
+	}
+	return nil
+}
@@ -0,0 +1,229 @@
+/*
+Copyright 2024 The Kubeflow Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2beta1
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+)
+
+func TestConvertFromV1(t *testing.T) {
+	cases := map[string]struct {
+		v1Job *kubeflowv1.MPIJob
+		want  *MPIJob
+	}{
+		"launcher as job": {
+			v1Job: &kubeflowv1.MPIJob{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+				Spec: kubeflowv1.MPIJobSpec{
+					SlotsPerWorker: ptr.To(intstr.FromInt32(2)),
+					LauncherAsJob:  ptr.To(true),
+					RunPolicy: kubeflowv1.RunPolicy{
+						BackoffLimit: ptr.To[int32](3),
+					},
+				},
+			},
+			want: &MPIJob{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+				Spec: MPIJobSpec{
+					SlotsPerWorker: ptr.To[int32](2),
+					RunPolicy: kubeflowv1.RunPolicy{
+						BackoffLimit: ptr.To[int32](3),
+					},
+				},
+			},
+		},
+		"launcher as pod with the deprecated cleanPodPolicy": {
+			v1Job: &kubeflowv1.MPIJob{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+				Spec: kubeflowv1.MPIJobSpec{
+					CleanPodPolicy: kubeflowv1.CleanPodPolicyPointer(kubeflowv1.CleanPodPolicyAll),
+				},
+			},
+			want: &MPIJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test",
+					Namespace:   "default",
+					Annotations: map[string]string{LauncherAsPodAnnotation: "true"},
+				},
+				Spec: MPIJobSpec{
+					RunPolicy: kubeflowv1.RunPolicy{
+						CleanPodPolicy: kubeflowv1.CleanPodPolicyPointer(kubeflowv1.CleanPodPolicyAll),
+					},
+				},
+			},
+		},
+		"extensions": {
+			v1Job: &kubeflowv1.MPIJob{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+				Spec: kubeflowv1.MPIJobSpec{
+					LauncherAsJob: ptr.To(true),
+					RunPolicy: kubeflowv1.RunPolicy{
+						Extensions: &runtime.RawExtension{Raw: []byte(`{"newerSetting":true}`)},
+					},
+				},
+			},
+			want: &MPIJob{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+				Spec: MPIJobSpec{
+					RunPolicy: kubeflowv1.RunPolicy{
+						Extensions: &runtime.RawExtension{Raw: []byte(`{"newerSetting":true}`)},
+					},
+				},
+			},
+		},
+		"common env": {
+			v1Job: &kubeflowv1.MPIJob{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+				Spec: kubeflowv1.MPIJobSpec{
+					CommonEnv: []corev1.EnvVar{{Name: "HTTP_PROXY", Value: "http://proxy:3128"}},
+					CommonEnvFrom: []corev1.EnvFromSource{{
+						SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "credentials"}},
+					}},
+					LauncherAsJob: ptr.To(true),
+				},
+			},
+			want: &MPIJob{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+				Spec: MPIJobSpec{
+					CommonEnv: []corev1.EnvVar{{Name: "HTTP_PROXY", Value: "http://proxy:3128"}},
+					CommonEnvFrom: []corev1.EnvFromSource{{
+						SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "credentials"}},
+					}},
+				},
+			},
+		},
+		"auto slots per worker": {
+			v1Job: &kubeflowv1.MPIJob{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+				Spec: kubeflowv1.MPIJobSpec{
+					SlotsPerWorker: ptr.To(intstr.FromString(kubeflowv1.SlotsPerWorkerAuto)),
+					LauncherAsJob:  ptr.To(true),
+				},
+			},
+			want: &MPIJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test",
+					Namespace:   "default",
+					Annotations: map[string]string{SlotsPerWorkerAutoAnnotation: "true"},
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := &MPIJob{}
+			if err := got.ConvertFrom(tc.v1Job); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Unexpected v2beta1 MPIJob (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestConvertToV1(t *testing.T) {
+	cases := map[string]struct {
+		job  *MPIJob
+		want *kubeflowv1.MPIJob
+	}{
+		"created as v2beta1": {
+			job: &MPIJob{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+				Spec: MPIJobSpec{
+					MainContainer: "mpi",
+				},
+			},
+			want: &kubeflowv1.MPIJob{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+				Spec: kubeflowv1.MPIJobSpec{
+					MainContainer: "mpi",
+					LauncherAsJob: ptr.To(true),
+				},
+			},
+		},
+		"common env": {
+			job: &MPIJob{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+				Spec: MPIJobSpec{
+					CommonEnv: []corev1.EnvVar{{Name: "HTTP_PROXY", Value: "http://proxy:3128"}},
+					CommonEnvFrom: []corev1.EnvFromSource{{
+						ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "settings"}},
+					}},
+				},
+			},
+			want: &kubeflowv1.MPIJob{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+				Spec: kubeflowv1.MPIJobSpec{
+					CommonEnv: []corev1.EnvVar{{Name: "HTTP_PROXY", Value: "http://proxy:3128"}},
+					CommonEnvFrom: []corev1.EnvFromSource{{
+						ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "settings"}},
+					}},
+					LauncherAsJob: ptr.To(true),
+				},
+			},
+		},
+		"round trip of a v1 job with the launcher as pod": {
+			job: &MPIJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test",
+					Namespace:   "default",
+					Annotations: map[string]string{LauncherAsPodAnnotation: "true"},
+				},
+			},
+			want: &kubeflowv1.MPIJob{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+			},
+		},
+		"round trip of a v1 job with auto slots per worker": {
+			job: &MPIJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test",
+					Namespace:   "default",
+					Annotations: map[string]string{SlotsPerWorkerAutoAnnotation: "true"},
+				},
+			},
+			want: &kubeflowv1.MPIJob{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+				Spec: kubeflowv1.MPIJobSpec{
+					SlotsPerWorker: ptr.To(intstr.FromString(kubeflowv1.SlotsPerWorkerAuto)),
+					LauncherAsJob:  ptr.To(true),
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := &kubeflowv1.MPIJob{}
+			if err := tc.job.ConvertTo(got); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Unexpected v1 MPIJob (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
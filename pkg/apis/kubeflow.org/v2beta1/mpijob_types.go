@@ -0,0 +1,173 @@
+/*
+Copyright 2024 The Kubeflow Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+)
+
+const (
+	// LauncherAsPodAnnotation is set on the v2beta1 view of a v1 MPIJob whose launcher
+	// runs in a bare pod, so that converting the object back to v1 keeps it that way.
+	LauncherAsPodAnnotation = "kubeflow.org/mpi-launcher-as-pod"
+	// SlotsPerWorkerAutoAnnotation is set on the v2beta1 view of a v1 MPIJob whose slotsPerWorker
+	// is auto, which has no v2beta1 equivalent, so that converting the object back to v1 keeps it.
+	SlotsPerWorkerAutoAnnotation = "kubeflow.org/mpi-slots-per-worker-auto"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +resource:path=mpijob
+// +kubebuilder:object:root=true
+// +kubebuilder:printcolumn:JSONPath=`.metadata.creationTimestamp`,name="Age",type=date
+// +kubebuilder:printcolumn:JSONPath=`.status.conditions[-1:].type`,name="State",type=string
+// +kubebuilder:subresource:status
+
+// MPIJob is the v2beta1 version of the MPIJob. The launcher always runs in a batch/v1 Job,
+// which retries it on failures up to runPolicy.backoffLimit times.
+type MPIJob struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              MPIJobSpec           `json:"spec,omitempty"`
+	Status            kubeflowv1.JobStatus `json:"status,omitempty"`
+}
+
+type MPIJobSpec struct {
+	// Specifies the number of slots per worker used in hostfile.
+	// Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	SlotsPerWorker *int32 `json:"slotsPerWorker,omitempty"`
+
+	// `MPIReplicaSpecs` contains maps from `MPIReplicaType` to `ReplicaSpec` that
+	// specify the MPI replicas to run.
+	MPIReplicaSpecs map[kubeflowv1.ReplicaType]*kubeflowv1.ReplicaSpec `json:"mpiReplicaSpecs"`
+
+	// CommonEnv is the list of the environment variables set in the main container of
+	// every replica, e.g. proxy settings or credentials referenced by a secretKeyRef.
+	// The variables defined in the container of a replica take precedence.
+	// +optional
+	CommonEnv []corev1.EnvVar `json:"commonEnv,omitempty"`
+
+	// CommonEnvFrom is the list of the sources of the environment variables of the main
+	// container of every replica. The sources of the container of a replica take precedence.
+	// +optional
+	CommonEnvFrom []corev1.EnvFromSource `json:"commonEnvFrom,omitempty"`
+
+	// MainContainer specifies name of the main container which
+	// executes the MPI code.
+	MainContainer string `json:"mainContainer,omitempty"`
+
+	// PreflightCheck, if set to true, makes the controller verify that the worker
+	// containers provide the runtime pieces required by the kubexec bootstrap
+	// (a /bin/sh reachable through `kubectl exec`) before the launcher is created.
+	// The MPIJob is marked as failed if the check does not pass.
+	// Defaults to false.
+	// +optional
+	PreflightCheck *bool `json:"preflightCheck,omitempty"`
+
+	// HostnameSource is the source of the worker hosts written in the hostfile and the discover_hosts.sh script.
+	// One of PodName, PodIP and HostAliases. PodIP uses the IPs of the running worker pods, refreshed when they
+	// change, for clusters where the DNS resolution of the pod names is slow or unreliable. HostAliases keeps the
+	// pod names, resolved through the hostAliases of the launcher pod, which is created once all the workers are
+	// running and is not updated afterwards, so it suits the jobs whose workers are not replaced.
+	// Defaults to PodName.
+	// +kubebuilder:validation:Enum=PodName;PodIP;HostAliases
+	// +optional
+	HostnameSource kubeflowv1.HostnameSource `json:"hostnameSource,omitempty"`
+
+	// MPIImplementation is the MPI implementation of the images, which selects the format of the hostfile
+	// and the environment variables configuring the remote shell agent and the ports of the MPI processes.
+	// One of OpenMPI, IntelMPI and MPICH. When unset, the launcher gets the remote shell agent variables of
+	// both Open MPI and Intel MPI, and the hostfile uses the Open MPI format.
+	// +kubebuilder:validation:Enum=OpenMPI;IntelMPI;MPICH
+	// +optional
+	MPIImplementation kubeflowv1.MPIImplementation `json:"mpiImplementation,omitempty"`
+
+	// BindGPUsPerRank, if set to true, generates the gpu_wrapper.sh script in the ConfigMap of the job,
+	// mounted at /etc/mpi in the launcher and the workers, which sets CUDA_VISIBLE_DEVICES to the GPUs of the
+	// local rank of the MPI process before running the command given as its arguments, e.g.
+	// `mpirun /etc/mpi/gpu_wrapper.sh python train.py`, so that the ranks sharing a worker don't all use
+	// GPU 0. The GPUs of a worker are split evenly between its slots, and the ranks share a GPU when the
+	// worker has more slots than GPUs.
+	// Defaults to false.
+	// +optional
+	BindGPUsPerRank *bool `json:"bindGPUsPerRank,omitempty"`
+
+	// ElasticPolicy configures the discover_hosts.sh script used by elastic Horovod to find the
+	// running workers.
+	// +optional
+	ElasticPolicy *kubeflowv1.MPIElasticPolicy `json:"elasticPolicy,omitempty"`
+
+	// HostfileTemplate customizes the entries of the hosts in the hostfile and in the discover_hosts.sh
+	// script, for the MPI distributions expecting another format than the one of MPIImplementation.
+	// +optional
+	HostfileTemplate *kubeflowv1.MPIHostfileTemplate `json:"hostfileTemplate,omitempty"`
+
+	// JobClassName is the name of the TrainingJobClass whose defaults are merged into the job
+	// when it is admitted by the training operator.
+	// +optional
+	JobClassName string `json:"jobClassName,omitempty"`
+
+	// DependsOn are the jobs of the namespace which must succeed before the pods of the job are
+	// created, e.g. the preprocessing job of a training job. The job waits for them, and fails
+	// if one of them fails.
+	// +optional
+	DependsOn []kubeflowv1.JobReference `json:"dependsOn,omitempty"`
+
+	// TensorBoard requests a TensorBoard serving the logs of the job, whose URL is reported in
+	// its status. The TensorBoard is deleted with the pods of the job, according to its
+	// CleanPodPolicy.
+	// +optional
+	TensorBoard *kubeflowv1.TensorBoardSpec `json:"tensorboard,omitempty"`
+
+	// Outputs declares the artifacts produced by the job, e.g. its trained model, whose URIs
+	// are recorded in its status once it has succeeded.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Outputs []kubeflowv1.OutputSpec `json:"outputs,omitempty"`
+
+	// Datasets are staged into the pods of all the replicas of the job before their containers
+	// start, so that the training reads them from the mount paths of its main container.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Datasets []kubeflowv1.DatasetSpec `json:"datasets,omitempty"`
+
+	// `RunPolicy` encapsulates various runtime policies of the distributed training
+	// job, for example how to clean up resources and how long the job can stay
+	// active. The BackoffLimit is the backoff limit of the launcher Job.
+	RunPolicy kubeflowv1.RunPolicy `json:"runPolicy,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +resource:path=mpijobs
+// +kubebuilder:object:root=true
+
+type MPIJobList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MPIJob `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&MPIJob{}, &MPIJobList{})
+}
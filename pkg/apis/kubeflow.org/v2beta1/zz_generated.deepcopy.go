@@ -0,0 +1,180 @@
+//go:build !ignore_autogenerated
+// +build !ignore_autogenerated
+
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by deepcopy-gen. DO NOT EDIT.
+
+package v2beta1
+
+import (
+	v1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	corev1 "k8s.io/api/core/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MPIJob) DeepCopyInto(out *MPIJob) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MPIJob.
+func (in *MPIJob) DeepCopy() *MPIJob {
+	if in == nil {
+		return nil
+	}
+	out := new(MPIJob)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MPIJob) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MPIJobList) DeepCopyInto(out *MPIJobList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]MPIJob, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MPIJobList.
+func (in *MPIJobList) DeepCopy() *MPIJobList {
+	if in == nil {
+		return nil
+	}
+	out := new(MPIJobList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MPIJobList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MPIJobSpec) DeepCopyInto(out *MPIJobSpec) {
+	*out = *in
+	if in.SlotsPerWorker != nil {
+		in, out := &in.SlotsPerWorker, &out.SlotsPerWorker
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MPIReplicaSpecs != nil {
+		in, out := &in.MPIReplicaSpecs, &out.MPIReplicaSpecs
+		*out = make(map[v1.ReplicaType]*v1.ReplicaSpec, len(*in))
+		for key, val := range *in {
+			var outVal *v1.ReplicaSpec
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = new(v1.ReplicaSpec)
+				(*in).DeepCopyInto(*out)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.CommonEnv != nil {
+		in, out := &in.CommonEnv, &out.CommonEnv
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CommonEnvFrom != nil {
+		in, out := &in.CommonEnvFrom, &out.CommonEnvFrom
+		*out = make([]corev1.EnvFromSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PreflightCheck != nil {
+		in, out := &in.PreflightCheck, &out.PreflightCheck
+		*out = new(bool)
+		**out = **in
+	}
+	if in.BindGPUsPerRank != nil {
+		in, out := &in.BindGPUsPerRank, &out.BindGPUsPerRank
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ElasticPolicy != nil {
+		in, out := &in.ElasticPolicy, &out.ElasticPolicy
+		*out = new(v1.MPIElasticPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HostfileTemplate != nil {
+		in, out := &in.HostfileTemplate, &out.HostfileTemplate
+		*out = new(v1.MPIHostfileTemplate)
+		**out = **in
+	}
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]v1.JobReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.TensorBoard != nil {
+		in, out := &in.TensorBoard, &out.TensorBoard
+		*out = new(v1.TensorBoardSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Outputs != nil {
+		in, out := &in.Outputs, &out.Outputs
+		*out = make([]v1.OutputSpec, len(*in))
+		copy(*out, *in)
+	}
+	if in.Datasets != nil {
+		in, out := &in.Datasets, &out.Datasets
+		*out = make([]v1.DatasetSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.RunPolicy.DeepCopyInto(&out.RunPolicy)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MPIJobSpec.
+func (in *MPIJobSpec) DeepCopy() *MPIJobSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MPIJobSpec)
+	in.DeepCopyInto(out)
+	return out
+}
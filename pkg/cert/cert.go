@@ -36,10 +36,16 @@ type Config struct {
 	WebhookServiceName       string
 	WebhookSecretName        string
 	WebhookConfigurationName string
+	// MutatingWebhookConfigurationName is the name of the MutatingWebhookConfiguration.
+	MutatingWebhookConfigurationName string
+	// ConversionCRDNames are the names of the CustomResourceDefinitions served by the conversion webhook.
+	ConversionCRDNames []string
 }
 
 //+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;update
 //+kubebuilder:rbac:groups="admissionregistration.k8s.io",resources=validatingwebhookconfigurations,verbs=get;list;watch;update
+//+kubebuilder:rbac:groups="admissionregistration.k8s.io",resources=mutatingwebhookconfigurations,verbs=get;list;watch;update
+//+kubebuilder:rbac:groups="apiextensions.k8s.io",resources=customresourcedefinitions,verbs=get;list;watch;update
 
 // ManageCerts creates all certs for webhooks.
 func ManageCerts(mgr ctrl.Manager, cfg Config, setupFinished chan struct{}) error {
@@ -49,6 +55,23 @@ func ManageCerts(mgr ctrl.Manager, cfg Config, setupFinished chan struct{}) erro
 		dnsName = fmt.Sprintf("%s.%s.svc", cfg.WebhookServiceName, ns)
 	)
 
+	webhooks := []cert.WebhookInfo{
+		{
+			Type: cert.Validating,
+			Name: cfg.WebhookConfigurationName,
+		},
+		{
+			Type: cert.Mutating,
+			Name: cfg.MutatingWebhookConfigurationName,
+		},
+	}
+	for _, name := range cfg.ConversionCRDNames {
+		webhooks = append(webhooks, cert.WebhookInfo{
+			Type: cert.CRDConversion,
+			Name: name,
+		})
+	}
+
 	return cert.AddRotator(mgr, &cert.CertRotator{
 		SecretKey: types.NamespacedName{
 			Namespace: ns,
@@ -59,10 +82,7 @@ func ManageCerts(mgr ctrl.Manager, cfg Config, setupFinished chan struct{}) erro
 		CAOrganization: caOrganization,
 		DNSName:        dnsName,
 		IsReady:        setupFinished,
-		Webhooks: []cert.WebhookInfo{{
-			Type: cert.Validating,
-			Name: cfg.WebhookConfigurationName,
-		}},
+		Webhooks:       webhooks,
 		// When training-operator is running in the leader election mode,
 		// we expect webhook server will run in primary and secondary instance
 		RequireLeaderElection: false,
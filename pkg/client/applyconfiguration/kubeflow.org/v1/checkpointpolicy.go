@@ -0,0 +1,48 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+// CheckpointPolicyApplyConfiguration represents an declarative configuration of the CheckpointPolicy type for use
+// with apply.
+type CheckpointPolicyApplyConfiguration struct {
+	Command                   []string `json:"command,omitempty"`
+	GracefulCheckpointSeconds *int32   `json:"gracefulCheckpointSeconds,omitempty"`
+}
+
+// CheckpointPolicyApplyConfiguration constructs an declarative configuration of the CheckpointPolicy type for use with
+// apply.
+func CheckpointPolicy() *CheckpointPolicyApplyConfiguration {
+	return &CheckpointPolicyApplyConfiguration{}
+}
+
+// WithCommand adds the given value to the Command field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Command field.
+func (b *CheckpointPolicyApplyConfiguration) WithCommand(values ...string) *CheckpointPolicyApplyConfiguration {
+	for i := range values {
+		b.Command = append(b.Command, values[i])
+	}
+	return b
+}
+
+// WithGracefulCheckpointSeconds sets the GracefulCheckpointSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the GracefulCheckpointSeconds field is set to the value of the last call.
+func (b *CheckpointPolicyApplyConfiguration) WithGracefulCheckpointSeconds(value int32) *CheckpointPolicyApplyConfiguration {
+	b.GracefulCheckpointSeconds = &value
+	return b
+}
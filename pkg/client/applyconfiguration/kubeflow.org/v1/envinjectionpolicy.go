@@ -0,0 +1,46 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+// EnvInjectionPolicyApplyConfiguration represents an declarative configuration of the EnvInjectionPolicy type for use
+// with apply.
+type EnvInjectionPolicyApplyConfiguration struct {
+	DisableClusterSpec *bool `json:"disableClusterSpec,omitempty"`
+	DisableMPI         *bool `json:"disableMPI,omitempty"`
+}
+
+// EnvInjectionPolicyApplyConfiguration constructs an declarative configuration of the EnvInjectionPolicy type for use with
+// apply.
+func EnvInjectionPolicy() *EnvInjectionPolicyApplyConfiguration {
+	return &EnvInjectionPolicyApplyConfiguration{}
+}
+
+// WithDisableClusterSpec sets the DisableClusterSpec field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DisableClusterSpec field is set to the value of the last call.
+func (b *EnvInjectionPolicyApplyConfiguration) WithDisableClusterSpec(value bool) *EnvInjectionPolicyApplyConfiguration {
+	b.DisableClusterSpec = &value
+	return b
+}
+
+// WithDisableMPI sets the DisableMPI field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DisableMPI field is set to the value of the last call.
+func (b *EnvInjectionPolicyApplyConfiguration) WithDisableMPI(value bool) *EnvInjectionPolicyApplyConfiguration {
+	b.DisableMPI = &value
+	return b
+}
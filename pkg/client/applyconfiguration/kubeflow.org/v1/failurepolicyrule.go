@@ -0,0 +1,63 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+)
+
+// FailurePolicyRuleApplyConfiguration represents an declarative configuration of the FailurePolicyRule type for use
+// with apply.
+type FailurePolicyRuleApplyConfiguration struct {
+	Action         *v1.FailurePolicyAction `json:"action,omitempty"`
+	ExitCodes      []int32                 `json:"exitCodes,omitempty"`
+	FailureReasons []v1.PodFailureReason   `json:"failureReasons,omitempty"`
+}
+
+// FailurePolicyRuleApplyConfiguration constructs an declarative configuration of the FailurePolicyRule type for use with
+// apply.
+func FailurePolicyRule() *FailurePolicyRuleApplyConfiguration {
+	return &FailurePolicyRuleApplyConfiguration{}
+}
+
+// WithAction sets the Action field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Action field is set to the value of the last call.
+func (b *FailurePolicyRuleApplyConfiguration) WithAction(value v1.FailurePolicyAction) *FailurePolicyRuleApplyConfiguration {
+	b.Action = &value
+	return b
+}
+
+// WithExitCodes adds the given value to the ExitCodes field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ExitCodes field.
+func (b *FailurePolicyRuleApplyConfiguration) WithExitCodes(values ...int32) *FailurePolicyRuleApplyConfiguration {
+	for i := range values {
+		b.ExitCodes = append(b.ExitCodes, values[i])
+	}
+	return b
+}
+
+// WithFailureReasons adds the given value to the FailureReasons field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the FailureReasons field.
+func (b *FailurePolicyRuleApplyConfiguration) WithFailureReasons(values ...v1.PodFailureReason) *FailurePolicyRuleApplyConfiguration {
+	for i := range values {
+		b.FailureReasons = append(b.FailureReasons, values[i])
+	}
+	return b
+}
@@ -0,0 +1,46 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+// GangSchedulingStatusApplyConfiguration represents an declarative configuration of the GangSchedulingStatus type for use
+// with apply.
+type GangSchedulingStatusApplyConfiguration struct {
+	SchedulerName *string `json:"schedulerName,omitempty"`
+	MinMember     *int32  `json:"minMember,omitempty"`
+}
+
+// GangSchedulingStatusApplyConfiguration constructs an declarative configuration of the GangSchedulingStatus type for use with
+// apply.
+func GangSchedulingStatus() *GangSchedulingStatusApplyConfiguration {
+	return &GangSchedulingStatusApplyConfiguration{}
+}
+
+// WithSchedulerName sets the SchedulerName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SchedulerName field is set to the value of the last call.
+func (b *GangSchedulingStatusApplyConfiguration) WithSchedulerName(value string) *GangSchedulingStatusApplyConfiguration {
+	b.SchedulerName = &value
+	return b
+}
+
+// WithMinMember sets the MinMember field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MinMember field is set to the value of the last call.
+func (b *GangSchedulingStatusApplyConfiguration) WithMinMember(value int32) *GangSchedulingStatusApplyConfiguration {
+	b.MinMember = &value
+	return b
+}
@@ -25,10 +25,17 @@ import (
 // with apply.
 type JobStatusApplyConfiguration struct {
 	Conditions        []JobConditionApplyConfiguration                           `json:"conditions,omitempty"`
+	Phase             *kubefloworgv1.JobPhase                                    `json:"phase,omitempty"`
+	RunID             *string                                                    `json:"runID,omitempty"`
 	ReplicaStatuses   map[kubefloworgv1.ReplicaType]*kubefloworgv1.ReplicaStatus `json:"replicaStatuses,omitempty"`
 	StartTime         *metav1.Time                                               `json:"startTime,omitempty"`
 	CompletionTime    *metav1.Time                                               `json:"completionTime,omitempty"`
+	Duration          *metav1.Duration                                           `json:"duration,omitempty"`
 	LastReconcileTime *metav1.Time                                               `json:"lastReconcileTime,omitempty"`
+	GangScheduling    *GangSchedulingStatusApplyConfiguration                    `json:"gangScheduling,omitempty"`
+	GangRestarts      *int32                                                     `json:"gangRestarts,omitempty"`
+	TensorBoardURL    *string                                                    `json:"tensorBoardURL,omitempty"`
+	Outputs           []OutputStatusApplyConfiguration                           `json:"outputs,omitempty"`
 }
 
 // JobStatusApplyConfiguration constructs an declarative configuration of the JobStatus type for use with
@@ -50,6 +57,22 @@ func (b *JobStatusApplyConfiguration) WithConditions(values ...*JobConditionAppl
 	return b
 }
 
+// WithPhase sets the Phase field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Phase field is set to the value of the last call.
+func (b *JobStatusApplyConfiguration) WithPhase(value kubefloworgv1.JobPhase) *JobStatusApplyConfiguration {
+	b.Phase = &value
+	return b
+}
+
+// WithRunID sets the RunID field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RunID field is set to the value of the last call.
+func (b *JobStatusApplyConfiguration) WithRunID(value string) *JobStatusApplyConfiguration {
+	b.RunID = &value
+	return b
+}
+
 // WithReplicaStatuses puts the entries into the ReplicaStatuses field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, the entries provided by each call will be put on the ReplicaStatuses field,
@@ -80,6 +103,14 @@ func (b *JobStatusApplyConfiguration) WithCompletionTime(value metav1.Time) *Job
 	return b
 }
 
+// WithDuration sets the Duration field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Duration field is set to the value of the last call.
+func (b *JobStatusApplyConfiguration) WithDuration(value metav1.Duration) *JobStatusApplyConfiguration {
+	b.Duration = &value
+	return b
+}
+
 // WithLastReconcileTime sets the LastReconcileTime field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the LastReconcileTime field is set to the value of the last call.
@@ -87,3 +118,40 @@ func (b *JobStatusApplyConfiguration) WithLastReconcileTime(value metav1.Time) *
 	b.LastReconcileTime = &value
 	return b
 }
+
+// WithGangScheduling sets the GangScheduling field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the GangScheduling field is set to the value of the last call.
+func (b *JobStatusApplyConfiguration) WithGangScheduling(value *GangSchedulingStatusApplyConfiguration) *JobStatusApplyConfiguration {
+	b.GangScheduling = value
+	return b
+}
+
+// WithGangRestarts sets the GangRestarts field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the GangRestarts field is set to the value of the last call.
+func (b *JobStatusApplyConfiguration) WithGangRestarts(value int32) *JobStatusApplyConfiguration {
+	b.GangRestarts = &value
+	return b
+}
+
+// WithTensorBoardURL sets the TensorBoardURL field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TensorBoardURL field is set to the value of the last call.
+func (b *JobStatusApplyConfiguration) WithTensorBoardURL(value string) *JobStatusApplyConfiguration {
+	b.TensorBoardURL = &value
+	return b
+}
+
+// WithOutputs adds the given value to the Outputs field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Outputs field.
+func (b *JobStatusApplyConfiguration) WithOutputs(values ...*OutputStatusApplyConfiguration) *JobStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithOutputs")
+		}
+		b.Outputs = append(b.Outputs, *values[i])
+	}
+	return b
+}
@@ -0,0 +1,46 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+// MPIElasticPolicyApplyConfiguration represents an declarative configuration of the MPIElasticPolicy type for use
+// with apply.
+type MPIElasticPolicyApplyConfiguration struct {
+	MinReplicas            *int32 `json:"minReplicas,omitempty"`
+	RefreshIntervalSeconds *int32 `json:"refreshIntervalSeconds,omitempty"`
+}
+
+// MPIElasticPolicyApplyConfiguration constructs an declarative configuration of the MPIElasticPolicy type for use with
+// apply.
+func MPIElasticPolicy() *MPIElasticPolicyApplyConfiguration {
+	return &MPIElasticPolicyApplyConfiguration{}
+}
+
+// WithMinReplicas sets the MinReplicas field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MinReplicas field is set to the value of the last call.
+func (b *MPIElasticPolicyApplyConfiguration) WithMinReplicas(value int32) *MPIElasticPolicyApplyConfiguration {
+	b.MinReplicas = &value
+	return b
+}
+
+// WithRefreshIntervalSeconds sets the RefreshIntervalSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RefreshIntervalSeconds field is set to the value of the last call.
+func (b *MPIElasticPolicyApplyConfiguration) WithRefreshIntervalSeconds(value int32) *MPIElasticPolicyApplyConfiguration {
+	b.RefreshIntervalSeconds = &value
+	return b
+}
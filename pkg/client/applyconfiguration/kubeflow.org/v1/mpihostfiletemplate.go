@@ -0,0 +1,46 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+// MPIHostfileTemplateApplyConfiguration represents an declarative configuration of the MPIHostfileTemplate type for use
+// with apply.
+type MPIHostfileTemplateApplyConfiguration struct {
+	Hostfile      *string `json:"hostfile,omitempty"`
+	DiscoverHosts *string `json:"discoverHosts,omitempty"`
+}
+
+// MPIHostfileTemplateApplyConfiguration constructs an declarative configuration of the MPIHostfileTemplate type for use with
+// apply.
+func MPIHostfileTemplate() *MPIHostfileTemplateApplyConfiguration {
+	return &MPIHostfileTemplateApplyConfiguration{}
+}
+
+// WithHostfile sets the Hostfile field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Hostfile field is set to the value of the last call.
+func (b *MPIHostfileTemplateApplyConfiguration) WithHostfile(value string) *MPIHostfileTemplateApplyConfiguration {
+	b.Hostfile = &value
+	return b
+}
+
+// WithDiscoverHosts sets the DiscoverHosts field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DiscoverHosts field is set to the value of the last call.
+func (b *MPIHostfileTemplateApplyConfiguration) WithDiscoverHosts(value string) *MPIHostfileTemplateApplyConfiguration {
+	b.DiscoverHosts = &value
+	return b
+}
@@ -18,16 +18,32 @@ package v1
 
 import (
 	v1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	corev1 "k8s.io/api/core/v1"
+	intstr "k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // MPIJobSpecApplyConfiguration represents an declarative configuration of the MPIJobSpec type for use
 // with apply.
 type MPIJobSpecApplyConfiguration struct {
-	SlotsPerWorker  *int32                             `json:"slotsPerWorker,omitempty"`
-	CleanPodPolicy  *v1.CleanPodPolicy                 `json:"cleanPodPolicy,omitempty"`
-	MPIReplicaSpecs map[v1.ReplicaType]*v1.ReplicaSpec `json:"mpiReplicaSpecs,omitempty"`
-	MainContainer   *string                            `json:"mainContainer,omitempty"`
-	RunPolicy       *RunPolicyApplyConfiguration       `json:"runPolicy,omitempty"`
+	SlotsPerWorker    *intstr.IntOrString                    `json:"slotsPerWorker,omitempty"`
+	CleanPodPolicy    *v1.CleanPodPolicy                     `json:"cleanPodPolicy,omitempty"`
+	MPIReplicaSpecs   map[v1.ReplicaType]*v1.ReplicaSpec     `json:"mpiReplicaSpecs,omitempty"`
+	CommonEnv         []corev1.EnvVar                        `json:"commonEnv,omitempty"`
+	CommonEnvFrom     []corev1.EnvFromSource                 `json:"commonEnvFrom,omitempty"`
+	MainContainer     *string                                `json:"mainContainer,omitempty"`
+	PreflightCheck    *bool                                  `json:"preflightCheck,omitempty"`
+	HostnameSource    *v1.HostnameSource                     `json:"hostnameSource,omitempty"`
+	MPIImplementation *v1.MPIImplementation                  `json:"mpiImplementation,omitempty"`
+	BindGPUsPerRank   *bool                                  `json:"bindGPUsPerRank,omitempty"`
+	LauncherAsJob     *bool                                  `json:"launcherAsJob,omitempty"`
+	ElasticPolicy     *MPIElasticPolicyApplyConfiguration    `json:"elasticPolicy,omitempty"`
+	HostfileTemplate  *MPIHostfileTemplateApplyConfiguration `json:"hostfileTemplate,omitempty"`
+	RunPolicy         *RunPolicyApplyConfiguration           `json:"runPolicy,omitempty"`
+	JobClassName      *string                                `json:"jobClassName,omitempty"`
+	DependsOn         []JobReferenceApplyConfiguration       `json:"dependsOn,omitempty"`
+	TensorBoard       *TensorBoardSpecApplyConfiguration     `json:"tensorboard,omitempty"`
+	Outputs           []OutputSpecApplyConfiguration         `json:"outputs,omitempty"`
+	Datasets          []DatasetSpecApplyConfiguration        `json:"datasets,omitempty"`
 }
 
 // MPIJobSpecApplyConfiguration constructs an declarative configuration of the MPIJobSpec type for use with
@@ -39,7 +55,7 @@ func MPIJobSpec() *MPIJobSpecApplyConfiguration {
 // WithSlotsPerWorker sets the SlotsPerWorker field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the SlotsPerWorker field is set to the value of the last call.
-func (b *MPIJobSpecApplyConfiguration) WithSlotsPerWorker(value int32) *MPIJobSpecApplyConfiguration {
+func (b *MPIJobSpecApplyConfiguration) WithSlotsPerWorker(value intstr.IntOrString) *MPIJobSpecApplyConfiguration {
 	b.SlotsPerWorker = &value
 	return b
 }
@@ -66,6 +82,26 @@ func (b *MPIJobSpecApplyConfiguration) WithMPIReplicaSpecs(entries map[v1.Replic
 	return b
 }
 
+// WithCommonEnv adds the given value to the CommonEnv field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the CommonEnv field.
+func (b *MPIJobSpecApplyConfiguration) WithCommonEnv(values ...corev1.EnvVar) *MPIJobSpecApplyConfiguration {
+	for i := range values {
+		b.CommonEnv = append(b.CommonEnv, values[i])
+	}
+	return b
+}
+
+// WithCommonEnvFrom adds the given value to the CommonEnvFrom field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the CommonEnvFrom field.
+func (b *MPIJobSpecApplyConfiguration) WithCommonEnvFrom(values ...corev1.EnvFromSource) *MPIJobSpecApplyConfiguration {
+	for i := range values {
+		b.CommonEnvFrom = append(b.CommonEnvFrom, values[i])
+	}
+	return b
+}
+
 // WithMainContainer sets the MainContainer field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the MainContainer field is set to the value of the last call.
@@ -74,6 +110,62 @@ func (b *MPIJobSpecApplyConfiguration) WithMainContainer(value string) *MPIJobSp
 	return b
 }
 
+// WithPreflightCheck sets the PreflightCheck field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PreflightCheck field is set to the value of the last call.
+func (b *MPIJobSpecApplyConfiguration) WithPreflightCheck(value bool) *MPIJobSpecApplyConfiguration {
+	b.PreflightCheck = &value
+	return b
+}
+
+// WithHostnameSource sets the HostnameSource field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the HostnameSource field is set to the value of the last call.
+func (b *MPIJobSpecApplyConfiguration) WithHostnameSource(value v1.HostnameSource) *MPIJobSpecApplyConfiguration {
+	b.HostnameSource = &value
+	return b
+}
+
+// WithMPIImplementation sets the MPIImplementation field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MPIImplementation field is set to the value of the last call.
+func (b *MPIJobSpecApplyConfiguration) WithMPIImplementation(value v1.MPIImplementation) *MPIJobSpecApplyConfiguration {
+	b.MPIImplementation = &value
+	return b
+}
+
+// WithBindGPUsPerRank sets the BindGPUsPerRank field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the BindGPUsPerRank field is set to the value of the last call.
+func (b *MPIJobSpecApplyConfiguration) WithBindGPUsPerRank(value bool) *MPIJobSpecApplyConfiguration {
+	b.BindGPUsPerRank = &value
+	return b
+}
+
+// WithLauncherAsJob sets the LauncherAsJob field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LauncherAsJob field is set to the value of the last call.
+func (b *MPIJobSpecApplyConfiguration) WithLauncherAsJob(value bool) *MPIJobSpecApplyConfiguration {
+	b.LauncherAsJob = &value
+	return b
+}
+
+// WithElasticPolicy sets the ElasticPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ElasticPolicy field is set to the value of the last call.
+func (b *MPIJobSpecApplyConfiguration) WithElasticPolicy(value *MPIElasticPolicyApplyConfiguration) *MPIJobSpecApplyConfiguration {
+	b.ElasticPolicy = value
+	return b
+}
+
+// WithHostfileTemplate sets the HostfileTemplate field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the HostfileTemplate field is set to the value of the last call.
+func (b *MPIJobSpecApplyConfiguration) WithHostfileTemplate(value *MPIHostfileTemplateApplyConfiguration) *MPIJobSpecApplyConfiguration {
+	b.HostfileTemplate = value
+	return b
+}
+
 // WithRunPolicy sets the RunPolicy field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the RunPolicy field is set to the value of the last call.
@@ -81,3 +173,58 @@ func (b *MPIJobSpecApplyConfiguration) WithRunPolicy(value *RunPolicyApplyConfig
 	b.RunPolicy = value
 	return b
 }
+
+// WithJobClassName sets the JobClassName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the JobClassName field is set to the value of the last call.
+func (b *MPIJobSpecApplyConfiguration) WithJobClassName(value string) *MPIJobSpecApplyConfiguration {
+	b.JobClassName = &value
+	return b
+}
+
+// WithDependsOn adds the given value to the DependsOn field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the DependsOn field.
+func (b *MPIJobSpecApplyConfiguration) WithDependsOn(values ...*JobReferenceApplyConfiguration) *MPIJobSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithDependsOn")
+		}
+		b.DependsOn = append(b.DependsOn, *values[i])
+	}
+	return b
+}
+
+// WithTensorBoard sets the TensorBoard field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TensorBoard field is set to the value of the last call.
+func (b *MPIJobSpecApplyConfiguration) WithTensorBoard(value *TensorBoardSpecApplyConfiguration) *MPIJobSpecApplyConfiguration {
+	b.TensorBoard = value
+	return b
+}
+
+// WithOutputs adds the given value to the Outputs field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Outputs field.
+func (b *MPIJobSpecApplyConfiguration) WithOutputs(values ...*OutputSpecApplyConfiguration) *MPIJobSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithOutputs")
+		}
+		b.Outputs = append(b.Outputs, *values[i])
+	}
+	return b
+}
+
+// WithDatasets adds the given value to the Datasets field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Datasets field.
+func (b *MPIJobSpecApplyConfiguration) WithDatasets(values ...*DatasetSpecApplyConfiguration) *MPIJobSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithDatasets")
+		}
+		b.Datasets = append(b.Datasets, *values[i])
+	}
+	return b
+}
@@ -0,0 +1,46 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+// OutputSpecApplyConfiguration represents an declarative configuration of the OutputSpec type for use
+// with apply.
+type OutputSpecApplyConfiguration struct {
+	Name *string `json:"name,omitempty"`
+	URI  *string `json:"uri,omitempty"`
+}
+
+// OutputSpecApplyConfiguration constructs an declarative configuration of the OutputSpec type for use with
+// apply.
+func OutputSpec() *OutputSpecApplyConfiguration {
+	return &OutputSpecApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *OutputSpecApplyConfiguration) WithName(value string) *OutputSpecApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithURI sets the URI field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the URI field is set to the value of the last call.
+func (b *OutputSpecApplyConfiguration) WithURI(value string) *OutputSpecApplyConfiguration {
+	b.URI = &value
+	return b
+}
@@ -18,14 +18,22 @@ package v1
 
 import (
 	kubefloworgv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	corev1 "k8s.io/api/core/v1"
 )
 
 // PaddleJobSpecApplyConfiguration represents an declarative configuration of the PaddleJobSpec type for use
 // with apply.
 type PaddleJobSpecApplyConfiguration struct {
 	RunPolicy          *RunPolicyApplyConfiguration                             `json:"runPolicy,omitempty"`
+	JobClassName       *string                                                  `json:"jobClassName,omitempty"`
+	DependsOn          []JobReferenceApplyConfiguration                         `json:"dependsOn,omitempty"`
+	TensorBoard        *TensorBoardSpecApplyConfiguration                       `json:"tensorboard,omitempty"`
+	Outputs            []OutputSpecApplyConfiguration                           `json:"outputs,omitempty"`
+	Datasets           []DatasetSpecApplyConfiguration                          `json:"datasets,omitempty"`
 	ElasticPolicy      *PaddleElasticPolicyApplyConfiguration                   `json:"elasticPolicy,omitempty"`
 	PaddleReplicaSpecs map[kubefloworgv1.ReplicaType]*kubefloworgv1.ReplicaSpec `json:"paddleReplicaSpecs,omitempty"`
+	CommonEnv          []corev1.EnvVar                                          `json:"commonEnv,omitempty"`
+	CommonEnvFrom      []corev1.EnvFromSource                                   `json:"commonEnvFrom,omitempty"`
 }
 
 // PaddleJobSpecApplyConfiguration constructs an declarative configuration of the PaddleJobSpec type for use with
@@ -42,6 +50,61 @@ func (b *PaddleJobSpecApplyConfiguration) WithRunPolicy(value *RunPolicyApplyCon
 	return b
 }
 
+// WithJobClassName sets the JobClassName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the JobClassName field is set to the value of the last call.
+func (b *PaddleJobSpecApplyConfiguration) WithJobClassName(value string) *PaddleJobSpecApplyConfiguration {
+	b.JobClassName = &value
+	return b
+}
+
+// WithDependsOn adds the given value to the DependsOn field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the DependsOn field.
+func (b *PaddleJobSpecApplyConfiguration) WithDependsOn(values ...*JobReferenceApplyConfiguration) *PaddleJobSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithDependsOn")
+		}
+		b.DependsOn = append(b.DependsOn, *values[i])
+	}
+	return b
+}
+
+// WithTensorBoard sets the TensorBoard field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TensorBoard field is set to the value of the last call.
+func (b *PaddleJobSpecApplyConfiguration) WithTensorBoard(value *TensorBoardSpecApplyConfiguration) *PaddleJobSpecApplyConfiguration {
+	b.TensorBoard = value
+	return b
+}
+
+// WithOutputs adds the given value to the Outputs field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Outputs field.
+func (b *PaddleJobSpecApplyConfiguration) WithOutputs(values ...*OutputSpecApplyConfiguration) *PaddleJobSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithOutputs")
+		}
+		b.Outputs = append(b.Outputs, *values[i])
+	}
+	return b
+}
+
+// WithDatasets adds the given value to the Datasets field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Datasets field.
+func (b *PaddleJobSpecApplyConfiguration) WithDatasets(values ...*DatasetSpecApplyConfiguration) *PaddleJobSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithDatasets")
+		}
+		b.Datasets = append(b.Datasets, *values[i])
+	}
+	return b
+}
+
 // WithElasticPolicy sets the ElasticPolicy field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the ElasticPolicy field is set to the value of the last call.
@@ -63,3 +126,23 @@ func (b *PaddleJobSpecApplyConfiguration) WithPaddleReplicaSpecs(entries map[kub
 	}
 	return b
 }
+
+// WithCommonEnv adds the given value to the CommonEnv field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the CommonEnv field.
+func (b *PaddleJobSpecApplyConfiguration) WithCommonEnv(values ...corev1.EnvVar) *PaddleJobSpecApplyConfiguration {
+	for i := range values {
+		b.CommonEnv = append(b.CommonEnv, values[i])
+	}
+	return b
+}
+
+// WithCommonEnvFrom adds the given value to the CommonEnvFrom field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the CommonEnvFrom field.
+func (b *PaddleJobSpecApplyConfiguration) WithCommonEnvFrom(values ...corev1.EnvFromSource) *PaddleJobSpecApplyConfiguration {
+	for i := range values {
+		b.CommonEnvFrom = append(b.CommonEnvFrom, values[i])
+	}
+	return b
+}
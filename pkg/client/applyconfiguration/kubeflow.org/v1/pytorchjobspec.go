@@ -18,14 +18,23 @@ package v1
 
 import (
 	kubefloworgv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	corev1 "k8s.io/api/core/v1"
 )
 
 // PyTorchJobSpecApplyConfiguration represents an declarative configuration of the PyTorchJobSpec type for use
 // with apply.
 type PyTorchJobSpecApplyConfiguration struct {
 	RunPolicy           *RunPolicyApplyConfiguration                             `json:"runPolicy,omitempty"`
+	JobClassName        *string                                                  `json:"jobClassName,omitempty"`
+	DependsOn           []JobReferenceApplyConfiguration                         `json:"dependsOn,omitempty"`
+	TensorBoard         *TensorBoardSpecApplyConfiguration                       `json:"tensorboard,omitempty"`
+	Outputs             []OutputSpecApplyConfiguration                           `json:"outputs,omitempty"`
+	Datasets            []DatasetSpecApplyConfiguration                          `json:"datasets,omitempty"`
 	ElasticPolicy       *ElasticPolicyApplyConfiguration                         `json:"elasticPolicy,omitempty"`
+	SuccessPolicy       *kubefloworgv1.SuccessPolicy                             `json:"successPolicy,omitempty"`
 	PyTorchReplicaSpecs map[kubefloworgv1.ReplicaType]*kubefloworgv1.ReplicaSpec `json:"pytorchReplicaSpecs,omitempty"`
+	CommonEnv           []corev1.EnvVar                                          `json:"commonEnv,omitempty"`
+	CommonEnvFrom       []corev1.EnvFromSource                                   `json:"commonEnvFrom,omitempty"`
 	NprocPerNode        *string                                                  `json:"nprocPerNode,omitempty"`
 }
 
@@ -43,6 +52,61 @@ func (b *PyTorchJobSpecApplyConfiguration) WithRunPolicy(value *RunPolicyApplyCo
 	return b
 }
 
+// WithJobClassName sets the JobClassName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the JobClassName field is set to the value of the last call.
+func (b *PyTorchJobSpecApplyConfiguration) WithJobClassName(value string) *PyTorchJobSpecApplyConfiguration {
+	b.JobClassName = &value
+	return b
+}
+
+// WithDependsOn adds the given value to the DependsOn field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the DependsOn field.
+func (b *PyTorchJobSpecApplyConfiguration) WithDependsOn(values ...*JobReferenceApplyConfiguration) *PyTorchJobSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithDependsOn")
+		}
+		b.DependsOn = append(b.DependsOn, *values[i])
+	}
+	return b
+}
+
+// WithTensorBoard sets the TensorBoard field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TensorBoard field is set to the value of the last call.
+func (b *PyTorchJobSpecApplyConfiguration) WithTensorBoard(value *TensorBoardSpecApplyConfiguration) *PyTorchJobSpecApplyConfiguration {
+	b.TensorBoard = value
+	return b
+}
+
+// WithOutputs adds the given value to the Outputs field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Outputs field.
+func (b *PyTorchJobSpecApplyConfiguration) WithOutputs(values ...*OutputSpecApplyConfiguration) *PyTorchJobSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithOutputs")
+		}
+		b.Outputs = append(b.Outputs, *values[i])
+	}
+	return b
+}
+
+// WithDatasets adds the given value to the Datasets field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Datasets field.
+func (b *PyTorchJobSpecApplyConfiguration) WithDatasets(values ...*DatasetSpecApplyConfiguration) *PyTorchJobSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithDatasets")
+		}
+		b.Datasets = append(b.Datasets, *values[i])
+	}
+	return b
+}
+
 // WithElasticPolicy sets the ElasticPolicy field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the ElasticPolicy field is set to the value of the last call.
@@ -51,6 +115,14 @@ func (b *PyTorchJobSpecApplyConfiguration) WithElasticPolicy(value *ElasticPolic
 	return b
 }
 
+// WithSuccessPolicy sets the SuccessPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SuccessPolicy field is set to the value of the last call.
+func (b *PyTorchJobSpecApplyConfiguration) WithSuccessPolicy(value kubefloworgv1.SuccessPolicy) *PyTorchJobSpecApplyConfiguration {
+	b.SuccessPolicy = &value
+	return b
+}
+
 // WithPyTorchReplicaSpecs puts the entries into the PyTorchReplicaSpecs field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, the entries provided by each call will be put on the PyTorchReplicaSpecs field,
@@ -65,6 +137,26 @@ func (b *PyTorchJobSpecApplyConfiguration) WithPyTorchReplicaSpecs(entries map[k
 	return b
 }
 
+// WithCommonEnv adds the given value to the CommonEnv field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the CommonEnv field.
+func (b *PyTorchJobSpecApplyConfiguration) WithCommonEnv(values ...corev1.EnvVar) *PyTorchJobSpecApplyConfiguration {
+	for i := range values {
+		b.CommonEnv = append(b.CommonEnv, values[i])
+	}
+	return b
+}
+
+// WithCommonEnvFrom adds the given value to the CommonEnvFrom field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the CommonEnvFrom field.
+func (b *PyTorchJobSpecApplyConfiguration) WithCommonEnvFrom(values ...corev1.EnvFromSource) *PyTorchJobSpecApplyConfiguration {
+	for i := range values {
+		b.CommonEnvFrom = append(b.CommonEnvFrom, values[i])
+	}
+	return b
+}
+
 // WithNprocPerNode sets the NprocPerNode field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the NprocPerNode field is set to the value of the last call.
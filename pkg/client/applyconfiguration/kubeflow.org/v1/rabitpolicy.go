@@ -0,0 +1,61 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// RabitPolicyApplyConfiguration represents an declarative configuration of the RabitPolicy type for use
+// with apply.
+type RabitPolicyApplyConfiguration struct {
+	TrackerPort *int32          `json:"trackerPort,omitempty"`
+	NumWorkers  *int32          `json:"numWorkers,omitempty"`
+	Env         []corev1.EnvVar `json:"env,omitempty"`
+}
+
+// RabitPolicyApplyConfiguration constructs an declarative configuration of the RabitPolicy type for use with
+// apply.
+func RabitPolicy() *RabitPolicyApplyConfiguration {
+	return &RabitPolicyApplyConfiguration{}
+}
+
+// WithTrackerPort sets the TrackerPort field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TrackerPort field is set to the value of the last call.
+func (b *RabitPolicyApplyConfiguration) WithTrackerPort(value int32) *RabitPolicyApplyConfiguration {
+	b.TrackerPort = &value
+	return b
+}
+
+// WithNumWorkers sets the NumWorkers field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NumWorkers field is set to the value of the last call.
+func (b *RabitPolicyApplyConfiguration) WithNumWorkers(value int32) *RabitPolicyApplyConfiguration {
+	b.NumWorkers = &value
+	return b
+}
+
+// WithEnv adds the given value to the Env field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Env field.
+func (b *RabitPolicyApplyConfiguration) WithEnv(values ...corev1.EnvVar) *RabitPolicyApplyConfiguration {
+	for i := range values {
+		b.Env = append(b.Env, values[i])
+	}
+	return b
+}
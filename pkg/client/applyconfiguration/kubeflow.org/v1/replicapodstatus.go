@@ -0,0 +1,68 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "k8s.io/api/core/v1"
+)
+
+// ReplicaPodStatusApplyConfiguration represents an declarative configuration of the ReplicaPodStatus type for use
+// with apply.
+type ReplicaPodStatusApplyConfiguration struct {
+	Name   *string      `json:"name,omitempty"`
+	Phase  *v1.PodPhase `json:"phase,omitempty"`
+	HostIP *string      `json:"hostIP,omitempty"`
+	PodIP  *string      `json:"podIP,omitempty"`
+}
+
+// ReplicaPodStatusApplyConfiguration constructs an declarative configuration of the ReplicaPodStatus type for use with
+// apply.
+func ReplicaPodStatus() *ReplicaPodStatusApplyConfiguration {
+	return &ReplicaPodStatusApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *ReplicaPodStatusApplyConfiguration) WithName(value string) *ReplicaPodStatusApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithPhase sets the Phase field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Phase field is set to the value of the last call.
+func (b *ReplicaPodStatusApplyConfiguration) WithPhase(value v1.PodPhase) *ReplicaPodStatusApplyConfiguration {
+	b.Phase = &value
+	return b
+}
+
+// WithHostIP sets the HostIP field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the HostIP field is set to the value of the last call.
+func (b *ReplicaPodStatusApplyConfiguration) WithHostIP(value string) *ReplicaPodStatusApplyConfiguration {
+	b.HostIP = &value
+	return b
+}
+
+// WithPodIP sets the PodIP field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PodIP field is set to the value of the last call.
+func (b *ReplicaPodStatusApplyConfiguration) WithPodIP(value string) *ReplicaPodStatusApplyConfiguration {
+	b.PodIP = &value
+	return b
+}
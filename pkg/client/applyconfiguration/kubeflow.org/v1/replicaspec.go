@@ -24,9 +24,12 @@ import (
 // ReplicaSpecApplyConfiguration represents an declarative configuration of the ReplicaSpec type for use
 // with apply.
 type ReplicaSpecApplyConfiguration struct {
-	Replicas      *int32                       `json:"replicas,omitempty"`
-	Template      *v1.PodTemplateSpec          `json:"template,omitempty"`
-	RestartPolicy *kubefloworgv1.RestartPolicy `json:"restartPolicy,omitempty"`
+	Replicas          *int32                       `json:"replicas,omitempty"`
+	Template          *v1.PodTemplateSpec          `json:"template,omitempty"`
+	RestartPolicy     *kubefloworgv1.RestartPolicy `json:"restartPolicy,omitempty"`
+	ToleratedFailures *int32                       `json:"toleratedFailures,omitempty"`
+	CapacityType      *kubefloworgv1.CapacityType  `json:"capacityType,omitempty"`
+	ResourceProfile   *string                      `json:"resourceProfile,omitempty"`
 }
 
 // ReplicaSpecApplyConfiguration constructs an declarative configuration of the ReplicaSpec type for use with
@@ -58,3 +61,27 @@ func (b *ReplicaSpecApplyConfiguration) WithRestartPolicy(value kubefloworgv1.Re
 	b.RestartPolicy = &value
 	return b
 }
+
+// WithToleratedFailures sets the ToleratedFailures field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ToleratedFailures field is set to the value of the last call.
+func (b *ReplicaSpecApplyConfiguration) WithToleratedFailures(value int32) *ReplicaSpecApplyConfiguration {
+	b.ToleratedFailures = &value
+	return b
+}
+
+// WithCapacityType sets the CapacityType field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CapacityType field is set to the value of the last call.
+func (b *ReplicaSpecApplyConfiguration) WithCapacityType(value kubefloworgv1.CapacityType) *ReplicaSpecApplyConfiguration {
+	b.CapacityType = &value
+	return b
+}
+
+// WithResourceProfile sets the ResourceProfile field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ResourceProfile field is set to the value of the last call.
+func (b *ReplicaSpecApplyConfiguration) WithResourceProfile(value string) *ReplicaSpecApplyConfiguration {
+	b.ResourceProfile = &value
+	return b
+}
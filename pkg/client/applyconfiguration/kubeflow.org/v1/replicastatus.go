@@ -17,17 +17,21 @@
 package v1
 
 import (
+	kubefloworgv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
 	v1 "k8s.io/client-go/applyconfigurations/meta/v1"
 )
 
 // ReplicaStatusApplyConfiguration represents an declarative configuration of the ReplicaStatus type for use
 // with apply.
 type ReplicaStatusApplyConfiguration struct {
-	Active        *int32                              `json:"active,omitempty"`
-	Succeeded     *int32                              `json:"succeeded,omitempty"`
-	Failed        *int32                              `json:"failed,omitempty"`
-	LabelSelector *v1.LabelSelectorApplyConfiguration `json:"labelSelector,omitempty"`
-	Selector      *string                             `json:"selector,omitempty"`
+	Active          *int32                               `json:"active,omitempty"`
+	Succeeded       *int32                               `json:"succeeded,omitempty"`
+	Failed          *int32                               `json:"failed,omitempty"`
+	LabelSelector   *v1.LabelSelectorApplyConfiguration  `json:"labelSelector,omitempty"`
+	Selector        *string                              `json:"selector,omitempty"`
+	ToleratedFailed *int32                               `json:"toleratedFailed,omitempty"`
+	FailureReason   *kubefloworgv1.PodFailureReason      `json:"failureReason,omitempty"`
+	Pods            []ReplicaPodStatusApplyConfiguration `json:"pods,omitempty"`
 }
 
 // ReplicaStatusApplyConfiguration constructs an declarative configuration of the ReplicaStatus type for use with
@@ -75,3 +79,32 @@ func (b *ReplicaStatusApplyConfiguration) WithSelector(value string) *ReplicaSta
 	b.Selector = &value
 	return b
 }
+
+// WithToleratedFailed sets the ToleratedFailed field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ToleratedFailed field is set to the value of the last call.
+func (b *ReplicaStatusApplyConfiguration) WithToleratedFailed(value int32) *ReplicaStatusApplyConfiguration {
+	b.ToleratedFailed = &value
+	return b
+}
+
+// WithFailureReason sets the FailureReason field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the FailureReason field is set to the value of the last call.
+func (b *ReplicaStatusApplyConfiguration) WithFailureReason(value kubefloworgv1.PodFailureReason) *ReplicaStatusApplyConfiguration {
+	b.FailureReason = &value
+	return b
+}
+
+// WithPods adds the given value to the Pods field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Pods field.
+func (b *ReplicaStatusApplyConfiguration) WithPods(values ...*ReplicaPodStatusApplyConfiguration) *ReplicaStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithPods")
+		}
+		b.Pods = append(b.Pods, *values[i])
+	}
+	return b
+}
@@ -18,18 +18,29 @@ package v1
 
 import (
 	v1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
 // RunPolicyApplyConfiguration represents an declarative configuration of the RunPolicy type for use
 // with apply.
 type RunPolicyApplyConfiguration struct {
-	CleanPodPolicy          *v1.CleanPodPolicy                  `json:"cleanPodPolicy,omitempty"`
-	TTLSecondsAfterFinished *int32                              `json:"ttlSecondsAfterFinished,omitempty"`
-	ActiveDeadlineSeconds   *int64                              `json:"activeDeadlineSeconds,omitempty"`
-	BackoffLimit            *int32                              `json:"backoffLimit,omitempty"`
-	SchedulingPolicy        *SchedulingPolicyApplyConfiguration `json:"schedulingPolicy,omitempty"`
-	Suspend                 *bool                               `json:"suspend,omitempty"`
-	ManagedBy               *string                             `json:"managedBy,omitempty"`
+	CleanPodPolicy          *v1.CleanPodPolicy                    `json:"cleanPodPolicy,omitempty"`
+	TTLSecondsAfterFinished *int32                                `json:"ttlSecondsAfterFinished,omitempty"`
+	ActiveDeadlineSeconds   *int64                                `json:"activeDeadlineSeconds,omitempty"`
+	BackoffLimit            *int32                                `json:"backoffLimit,omitempty"`
+	SchedulingPolicy        *SchedulingPolicyApplyConfiguration   `json:"schedulingPolicy,omitempty"`
+	FailurePolicy           *FailurePolicyApplyConfiguration      `json:"failurePolicy,omitempty"`
+	CheckpointPolicy        *CheckpointPolicyApplyConfiguration   `json:"checkpointPolicy,omitempty"`
+	Suspend                 *bool                                 `json:"suspend,omitempty"`
+	Standalone              *bool                                 `json:"standalone,omitempty"`
+	ManagedBy               *string                               `json:"managedBy,omitempty"`
+	RayClusterSpec          *runtime.RawExtension                 `json:"rayClusterSpec,omitempty"`
+	InjectNetworkTuning     *bool                                 `json:"injectNetworkTuning,omitempty"`
+	EnvInjectionPolicy      *EnvInjectionPolicyApplyConfiguration `json:"envInjectionPolicy,omitempty"`
+	StartPolicy             *StartPolicyApplyConfiguration        `json:"startPolicy,omitempty"`
+	TopologyPolicy          *TopologyPolicyApplyConfiguration     `json:"topologyPolicy,omitempty"`
+	RetainServices          *bool                                 `json:"retainServices,omitempty"`
+	Extensions              *runtime.RawExtension                 `json:"extensions,omitempty"`
 }
 
 // RunPolicyApplyConfiguration constructs an declarative configuration of the RunPolicy type for use with
@@ -78,6 +89,22 @@ func (b *RunPolicyApplyConfiguration) WithSchedulingPolicy(value *SchedulingPoli
 	return b
 }
 
+// WithFailurePolicy sets the FailurePolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the FailurePolicy field is set to the value of the last call.
+func (b *RunPolicyApplyConfiguration) WithFailurePolicy(value *FailurePolicyApplyConfiguration) *RunPolicyApplyConfiguration {
+	b.FailurePolicy = value
+	return b
+}
+
+// WithCheckpointPolicy sets the CheckpointPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CheckpointPolicy field is set to the value of the last call.
+func (b *RunPolicyApplyConfiguration) WithCheckpointPolicy(value *CheckpointPolicyApplyConfiguration) *RunPolicyApplyConfiguration {
+	b.CheckpointPolicy = value
+	return b
+}
+
 // WithSuspend sets the Suspend field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Suspend field is set to the value of the last call.
@@ -86,6 +113,14 @@ func (b *RunPolicyApplyConfiguration) WithSuspend(value bool) *RunPolicyApplyCon
 	return b
 }
 
+// WithStandalone sets the Standalone field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Standalone field is set to the value of the last call.
+func (b *RunPolicyApplyConfiguration) WithStandalone(value bool) *RunPolicyApplyConfiguration {
+	b.Standalone = &value
+	return b
+}
+
 // WithManagedBy sets the ManagedBy field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the ManagedBy field is set to the value of the last call.
@@ -93,3 +128,59 @@ func (b *RunPolicyApplyConfiguration) WithManagedBy(value string) *RunPolicyAppl
 	b.ManagedBy = &value
 	return b
 }
+
+// WithRayClusterSpec sets the RayClusterSpec field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RayClusterSpec field is set to the value of the last call.
+func (b *RunPolicyApplyConfiguration) WithRayClusterSpec(value runtime.RawExtension) *RunPolicyApplyConfiguration {
+	b.RayClusterSpec = &value
+	return b
+}
+
+// WithInjectNetworkTuning sets the InjectNetworkTuning field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the InjectNetworkTuning field is set to the value of the last call.
+func (b *RunPolicyApplyConfiguration) WithInjectNetworkTuning(value bool) *RunPolicyApplyConfiguration {
+	b.InjectNetworkTuning = &value
+	return b
+}
+
+// WithEnvInjectionPolicy sets the EnvInjectionPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the EnvInjectionPolicy field is set to the value of the last call.
+func (b *RunPolicyApplyConfiguration) WithEnvInjectionPolicy(value *EnvInjectionPolicyApplyConfiguration) *RunPolicyApplyConfiguration {
+	b.EnvInjectionPolicy = value
+	return b
+}
+
+// WithStartPolicy sets the StartPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the StartPolicy field is set to the value of the last call.
+func (b *RunPolicyApplyConfiguration) WithStartPolicy(value *StartPolicyApplyConfiguration) *RunPolicyApplyConfiguration {
+	b.StartPolicy = value
+	return b
+}
+
+// WithTopologyPolicy sets the TopologyPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TopologyPolicy field is set to the value of the last call.
+func (b *RunPolicyApplyConfiguration) WithTopologyPolicy(value *TopologyPolicyApplyConfiguration) *RunPolicyApplyConfiguration {
+	b.TopologyPolicy = value
+	return b
+}
+
+// WithRetainServices sets the RetainServices field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RetainServices field is set to the value of the last call.
+func (b *RunPolicyApplyConfiguration) WithRetainServices(value bool) *RunPolicyApplyConfiguration {
+	b.RetainServices = &value
+	return b
+}
+
+// WithExtensions sets the Extensions field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Extensions field is set to the value of the last call.
+func (b *RunPolicyApplyConfiguration) WithExtensions(value runtime.RawExtension) *RunPolicyApplyConfiguration {
+	b.Extensions = &value
+	return b
+}
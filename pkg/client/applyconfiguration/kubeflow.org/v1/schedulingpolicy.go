@@ -29,6 +29,7 @@ type SchedulingPolicyApplyConfiguration struct {
 	MinResources           *map[v1.ResourceName]resource.Quantity `json:"minResources,omitempty"`
 	PriorityClass          *string                                `json:"priorityClass,omitempty"`
 	ScheduleTimeoutSeconds *int32                                 `json:"scheduleTimeoutSeconds,omitempty"`
+	SameTopology           *string                                `json:"sameTopology,omitempty"`
 }
 
 // SchedulingPolicyApplyConfiguration constructs an declarative configuration of the SchedulingPolicy type for use with
@@ -76,3 +77,11 @@ func (b *SchedulingPolicyApplyConfiguration) WithScheduleTimeoutSeconds(value in
 	b.ScheduleTimeoutSeconds = &value
 	return b
 }
+
+// WithSameTopology sets the SameTopology field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SameTopology field is set to the value of the last call.
+func (b *SchedulingPolicyApplyConfiguration) WithSameTopology(value string) *SchedulingPolicyApplyConfiguration {
+	b.SameTopology = &value
+	return b
+}
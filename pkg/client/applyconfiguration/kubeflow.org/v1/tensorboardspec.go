@@ -0,0 +1,61 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// TensorBoardSpecApplyConfiguration represents an declarative configuration of the TensorBoardSpec type for use
+// with apply.
+type TensorBoardSpecApplyConfiguration struct {
+	LogDir *string         `json:"logDir,omitempty"`
+	Image  *string         `json:"image,omitempty"`
+	Env    []corev1.EnvVar `json:"env,omitempty"`
+}
+
+// TensorBoardSpecApplyConfiguration constructs an declarative configuration of the TensorBoardSpec type for use with
+// apply.
+func TensorBoardSpec() *TensorBoardSpecApplyConfiguration {
+	return &TensorBoardSpecApplyConfiguration{}
+}
+
+// WithLogDir sets the LogDir field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LogDir field is set to the value of the last call.
+func (b *TensorBoardSpecApplyConfiguration) WithLogDir(value string) *TensorBoardSpecApplyConfiguration {
+	b.LogDir = &value
+	return b
+}
+
+// WithImage sets the Image field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Image field is set to the value of the last call.
+func (b *TensorBoardSpecApplyConfiguration) WithImage(value string) *TensorBoardSpecApplyConfiguration {
+	b.Image = &value
+	return b
+}
+
+// WithEnv adds the given value to the Env field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Env field.
+func (b *TensorBoardSpecApplyConfiguration) WithEnv(values ...corev1.EnvVar) *TensorBoardSpecApplyConfiguration {
+	for i := range values {
+		b.Env = append(b.Env, values[i])
+	}
+	return b
+}
@@ -18,15 +18,24 @@ package v1
 
 import (
 	kubefloworgv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	corev1 "k8s.io/api/core/v1"
 )
 
 // TFJobSpecApplyConfiguration represents an declarative configuration of the TFJobSpec type for use
 // with apply.
 type TFJobSpecApplyConfiguration struct {
 	RunPolicy           *RunPolicyApplyConfiguration                             `json:"runPolicy,omitempty"`
+	JobClassName        *string                                                  `json:"jobClassName,omitempty"`
+	DependsOn           []JobReferenceApplyConfiguration                         `json:"dependsOn,omitempty"`
+	TensorBoard         *TensorBoardSpecApplyConfiguration                       `json:"tensorboard,omitempty"`
+	Outputs             []OutputSpecApplyConfiguration                           `json:"outputs,omitempty"`
+	Datasets            []DatasetSpecApplyConfiguration                          `json:"datasets,omitempty"`
 	SuccessPolicy       *kubefloworgv1.SuccessPolicy                             `json:"successPolicy,omitempty"`
 	TFReplicaSpecs      map[kubefloworgv1.ReplicaType]*kubefloworgv1.ReplicaSpec `json:"tfReplicaSpecs,omitempty"`
+	CommonEnv           []corev1.EnvVar                                          `json:"commonEnv,omitempty"`
+	CommonEnvFrom       []corev1.EnvFromSource                                   `json:"commonEnvFrom,omitempty"`
 	EnableDynamicWorker *bool                                                    `json:"enableDynamicWorker,omitempty"`
+	TFConfigStrategy    *kubefloworgv1.TFConfigStrategy                          `json:"tfConfigStrategy,omitempty"`
 }
 
 // TFJobSpecApplyConfiguration constructs an declarative configuration of the TFJobSpec type for use with
@@ -43,6 +52,61 @@ func (b *TFJobSpecApplyConfiguration) WithRunPolicy(value *RunPolicyApplyConfigu
 	return b
 }
 
+// WithJobClassName sets the JobClassName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the JobClassName field is set to the value of the last call.
+func (b *TFJobSpecApplyConfiguration) WithJobClassName(value string) *TFJobSpecApplyConfiguration {
+	b.JobClassName = &value
+	return b
+}
+
+// WithDependsOn adds the given value to the DependsOn field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the DependsOn field.
+func (b *TFJobSpecApplyConfiguration) WithDependsOn(values ...*JobReferenceApplyConfiguration) *TFJobSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithDependsOn")
+		}
+		b.DependsOn = append(b.DependsOn, *values[i])
+	}
+	return b
+}
+
+// WithTensorBoard sets the TensorBoard field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TensorBoard field is set to the value of the last call.
+func (b *TFJobSpecApplyConfiguration) WithTensorBoard(value *TensorBoardSpecApplyConfiguration) *TFJobSpecApplyConfiguration {
+	b.TensorBoard = value
+	return b
+}
+
+// WithOutputs adds the given value to the Outputs field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Outputs field.
+func (b *TFJobSpecApplyConfiguration) WithOutputs(values ...*OutputSpecApplyConfiguration) *TFJobSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithOutputs")
+		}
+		b.Outputs = append(b.Outputs, *values[i])
+	}
+	return b
+}
+
+// WithDatasets adds the given value to the Datasets field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Datasets field.
+func (b *TFJobSpecApplyConfiguration) WithDatasets(values ...*DatasetSpecApplyConfiguration) *TFJobSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithDatasets")
+		}
+		b.Datasets = append(b.Datasets, *values[i])
+	}
+	return b
+}
+
 // WithSuccessPolicy sets the SuccessPolicy field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the SuccessPolicy field is set to the value of the last call.
@@ -65,6 +129,26 @@ func (b *TFJobSpecApplyConfiguration) WithTFReplicaSpecs(entries map[kubefloworg
 	return b
 }
 
+// WithCommonEnv adds the given value to the CommonEnv field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the CommonEnv field.
+func (b *TFJobSpecApplyConfiguration) WithCommonEnv(values ...corev1.EnvVar) *TFJobSpecApplyConfiguration {
+	for i := range values {
+		b.CommonEnv = append(b.CommonEnv, values[i])
+	}
+	return b
+}
+
+// WithCommonEnvFrom adds the given value to the CommonEnvFrom field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the CommonEnvFrom field.
+func (b *TFJobSpecApplyConfiguration) WithCommonEnvFrom(values ...corev1.EnvFromSource) *TFJobSpecApplyConfiguration {
+	for i := range values {
+		b.CommonEnvFrom = append(b.CommonEnvFrom, values[i])
+	}
+	return b
+}
+
 // WithEnableDynamicWorker sets the EnableDynamicWorker field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the EnableDynamicWorker field is set to the value of the last call.
@@ -72,3 +156,11 @@ func (b *TFJobSpecApplyConfiguration) WithEnableDynamicWorker(value bool) *TFJob
 	b.EnableDynamicWorker = &value
 	return b
 }
+
+// WithTFConfigStrategy sets the TFConfigStrategy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TFConfigStrategy field is set to the value of the last call.
+func (b *TFJobSpecApplyConfiguration) WithTFConfigStrategy(value kubefloworgv1.TFConfigStrategy) *TFJobSpecApplyConfiguration {
+	b.TFConfigStrategy = &value
+	return b
+}
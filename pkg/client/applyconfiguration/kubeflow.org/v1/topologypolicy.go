@@ -0,0 +1,50 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+)
+
+// TopologyPolicyApplyConfiguration represents an declarative configuration of the TopologyPolicy type for use
+// with apply.
+type TopologyPolicyApplyConfiguration struct {
+	Placement   *v1.TopologyPlacement `json:"placement,omitempty"`
+	TopologyKey *string               `json:"topologyKey,omitempty"`
+}
+
+// TopologyPolicyApplyConfiguration constructs an declarative configuration of the TopologyPolicy type for use with
+// apply.
+func TopologyPolicy() *TopologyPolicyApplyConfiguration {
+	return &TopologyPolicyApplyConfiguration{}
+}
+
+// WithPlacement sets the Placement field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Placement field is set to the value of the last call.
+func (b *TopologyPolicyApplyConfiguration) WithPlacement(value v1.TopologyPlacement) *TopologyPolicyApplyConfiguration {
+	b.Placement = &value
+	return b
+}
+
+// WithTopologyKey sets the TopologyKey field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TopologyKey field is set to the value of the last call.
+func (b *TopologyPolicyApplyConfiguration) WithTopologyKey(value string) *TopologyPolicyApplyConfiguration {
+	b.TopologyKey = &value
+	return b
+}
@@ -18,13 +18,22 @@ package v1
 
 import (
 	kubefloworgv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	corev1 "k8s.io/api/core/v1"
 )
 
 // XGBoostJobSpecApplyConfiguration represents an declarative configuration of the XGBoostJobSpec type for use
 // with apply.
 type XGBoostJobSpecApplyConfiguration struct {
 	RunPolicy       *RunPolicyApplyConfiguration                             `json:"runPolicy,omitempty"`
+	JobClassName    *string                                                  `json:"jobClassName,omitempty"`
+	DependsOn       []JobReferenceApplyConfiguration                         `json:"dependsOn,omitempty"`
+	TensorBoard     *TensorBoardSpecApplyConfiguration                       `json:"tensorboard,omitempty"`
+	Outputs         []OutputSpecApplyConfiguration                           `json:"outputs,omitempty"`
+	Datasets        []DatasetSpecApplyConfiguration                          `json:"datasets,omitempty"`
 	XGBReplicaSpecs map[kubefloworgv1.ReplicaType]*kubefloworgv1.ReplicaSpec `json:"xgbReplicaSpecs,omitempty"`
+	CommonEnv       []corev1.EnvVar                                          `json:"commonEnv,omitempty"`
+	CommonEnvFrom   []corev1.EnvFromSource                                   `json:"commonEnvFrom,omitempty"`
+	RabitPolicy     *RabitPolicyApplyConfiguration                           `json:"rabitPolicy,omitempty"`
 }
 
 // XGBoostJobSpecApplyConfiguration constructs an declarative configuration of the XGBoostJobSpec type for use with
@@ -41,6 +50,61 @@ func (b *XGBoostJobSpecApplyConfiguration) WithRunPolicy(value *RunPolicyApplyCo
 	return b
 }
 
+// WithJobClassName sets the JobClassName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the JobClassName field is set to the value of the last call.
+func (b *XGBoostJobSpecApplyConfiguration) WithJobClassName(value string) *XGBoostJobSpecApplyConfiguration {
+	b.JobClassName = &value
+	return b
+}
+
+// WithDependsOn adds the given value to the DependsOn field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the DependsOn field.
+func (b *XGBoostJobSpecApplyConfiguration) WithDependsOn(values ...*JobReferenceApplyConfiguration) *XGBoostJobSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithDependsOn")
+		}
+		b.DependsOn = append(b.DependsOn, *values[i])
+	}
+	return b
+}
+
+// WithTensorBoard sets the TensorBoard field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TensorBoard field is set to the value of the last call.
+func (b *XGBoostJobSpecApplyConfiguration) WithTensorBoard(value *TensorBoardSpecApplyConfiguration) *XGBoostJobSpecApplyConfiguration {
+	b.TensorBoard = value
+	return b
+}
+
+// WithOutputs adds the given value to the Outputs field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Outputs field.
+func (b *XGBoostJobSpecApplyConfiguration) WithOutputs(values ...*OutputSpecApplyConfiguration) *XGBoostJobSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithOutputs")
+		}
+		b.Outputs = append(b.Outputs, *values[i])
+	}
+	return b
+}
+
+// WithDatasets adds the given value to the Datasets field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Datasets field.
+func (b *XGBoostJobSpecApplyConfiguration) WithDatasets(values ...*DatasetSpecApplyConfiguration) *XGBoostJobSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithDatasets")
+		}
+		b.Datasets = append(b.Datasets, *values[i])
+	}
+	return b
+}
+
 // WithXGBReplicaSpecs puts the entries into the XGBReplicaSpecs field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, the entries provided by each call will be put on the XGBReplicaSpecs field,
@@ -54,3 +118,31 @@ func (b *XGBoostJobSpecApplyConfiguration) WithXGBReplicaSpecs(entries map[kubef
 	}
 	return b
 }
+
+// WithCommonEnv adds the given value to the CommonEnv field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the CommonEnv field.
+func (b *XGBoostJobSpecApplyConfiguration) WithCommonEnv(values ...corev1.EnvVar) *XGBoostJobSpecApplyConfiguration {
+	for i := range values {
+		b.CommonEnv = append(b.CommonEnv, values[i])
+	}
+	return b
+}
+
+// WithCommonEnvFrom adds the given value to the CommonEnvFrom field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the CommonEnvFrom field.
+func (b *XGBoostJobSpecApplyConfiguration) WithCommonEnvFrom(values ...corev1.EnvFromSource) *XGBoostJobSpecApplyConfiguration {
+	for i := range values {
+		b.CommonEnvFrom = append(b.CommonEnvFrom, values[i])
+	}
+	return b
+}
+
+// WithRabitPolicy sets the RabitPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RabitPolicy field is set to the value of the last call.
+func (b *XGBoostJobSpecApplyConfiguration) WithRabitPolicy(value *RabitPolicyApplyConfiguration) *XGBoostJobSpecApplyConfiguration {
+	b.RabitPolicy = value
+	return b
+}
@@ -0,0 +1,203 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v2beta1
+
+import (
+	v1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	kubefloworgv1 "github.com/kubeflow/training-operator/pkg/client/applyconfiguration/kubeflow.org/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// MPIJobSpecApplyConfiguration represents an declarative configuration of the MPIJobSpec type for use
+// with apply.
+type MPIJobSpecApplyConfiguration struct {
+	SlotsPerWorker    *int32                                               `json:"slotsPerWorker,omitempty"`
+	MPIReplicaSpecs   map[v1.ReplicaType]*v1.ReplicaSpec                   `json:"mpiReplicaSpecs,omitempty"`
+	CommonEnv         []corev1.EnvVar                                      `json:"commonEnv,omitempty"`
+	CommonEnvFrom     []corev1.EnvFromSource                               `json:"commonEnvFrom,omitempty"`
+	MainContainer     *string                                              `json:"mainContainer,omitempty"`
+	PreflightCheck    *bool                                                `json:"preflightCheck,omitempty"`
+	HostnameSource    *v1.HostnameSource                                   `json:"hostnameSource,omitempty"`
+	MPIImplementation *v1.MPIImplementation                                `json:"mpiImplementation,omitempty"`
+	ElasticPolicy     *kubefloworgv1.MPIElasticPolicyApplyConfiguration    `json:"elasticPolicy,omitempty"`
+	HostfileTemplate  *kubefloworgv1.MPIHostfileTemplateApplyConfiguration `json:"hostfileTemplate,omitempty"`
+	JobClassName      *string                                              `json:"jobClassName,omitempty"`
+	DependsOn         []kubefloworgv1.JobReferenceApplyConfiguration       `json:"dependsOn,omitempty"`
+	TensorBoard       *kubefloworgv1.TensorBoardSpecApplyConfiguration     `json:"tensorboard,omitempty"`
+	Outputs           []kubefloworgv1.OutputSpecApplyConfiguration         `json:"outputs,omitempty"`
+	Datasets          []kubefloworgv1.DatasetSpecApplyConfiguration        `json:"datasets,omitempty"`
+	RunPolicy         *kubefloworgv1.RunPolicyApplyConfiguration           `json:"runPolicy,omitempty"`
+}
+
+// MPIJobSpecApplyConfiguration constructs an declarative configuration of the MPIJobSpec type for use with
+// apply.
+func MPIJobSpec() *MPIJobSpecApplyConfiguration {
+	return &MPIJobSpecApplyConfiguration{}
+}
+
+// WithSlotsPerWorker sets the SlotsPerWorker field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SlotsPerWorker field is set to the value of the last call.
+func (b *MPIJobSpecApplyConfiguration) WithSlotsPerWorker(value int32) *MPIJobSpecApplyConfiguration {
+	b.SlotsPerWorker = &value
+	return b
+}
+
+// WithMPIReplicaSpecs puts the entries into the MPIReplicaSpecs field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the MPIReplicaSpecs field,
+// overwriting an existing map entries in MPIReplicaSpecs field with the same key.
+func (b *MPIJobSpecApplyConfiguration) WithMPIReplicaSpecs(entries map[v1.ReplicaType]*v1.ReplicaSpec) *MPIJobSpecApplyConfiguration {
+	if b.MPIReplicaSpecs == nil && len(entries) > 0 {
+		b.MPIReplicaSpecs = make(map[v1.ReplicaType]*v1.ReplicaSpec, len(entries))
+	}
+	for k, v := range entries {
+		b.MPIReplicaSpecs[k] = v
+	}
+	return b
+}
+
+// WithCommonEnv adds the given value to the CommonEnv field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the CommonEnv field.
+func (b *MPIJobSpecApplyConfiguration) WithCommonEnv(values ...corev1.EnvVar) *MPIJobSpecApplyConfiguration {
+	for i := range values {
+		b.CommonEnv = append(b.CommonEnv, values[i])
+	}
+	return b
+}
+
+// WithCommonEnvFrom adds the given value to the CommonEnvFrom field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the CommonEnvFrom field.
+func (b *MPIJobSpecApplyConfiguration) WithCommonEnvFrom(values ...corev1.EnvFromSource) *MPIJobSpecApplyConfiguration {
+	for i := range values {
+		b.CommonEnvFrom = append(b.CommonEnvFrom, values[i])
+	}
+	return b
+}
+
+// WithMainContainer sets the MainContainer field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MainContainer field is set to the value of the last call.
+func (b *MPIJobSpecApplyConfiguration) WithMainContainer(value string) *MPIJobSpecApplyConfiguration {
+	b.MainContainer = &value
+	return b
+}
+
+// WithPreflightCheck sets the PreflightCheck field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PreflightCheck field is set to the value of the last call.
+func (b *MPIJobSpecApplyConfiguration) WithPreflightCheck(value bool) *MPIJobSpecApplyConfiguration {
+	b.PreflightCheck = &value
+	return b
+}
+
+// WithHostnameSource sets the HostnameSource field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the HostnameSource field is set to the value of the last call.
+func (b *MPIJobSpecApplyConfiguration) WithHostnameSource(value v1.HostnameSource) *MPIJobSpecApplyConfiguration {
+	b.HostnameSource = &value
+	return b
+}
+
+// WithMPIImplementation sets the MPIImplementation field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MPIImplementation field is set to the value of the last call.
+func (b *MPIJobSpecApplyConfiguration) WithMPIImplementation(value v1.MPIImplementation) *MPIJobSpecApplyConfiguration {
+	b.MPIImplementation = &value
+	return b
+}
+
+// WithElasticPolicy sets the ElasticPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ElasticPolicy field is set to the value of the last call.
+func (b *MPIJobSpecApplyConfiguration) WithElasticPolicy(value *kubefloworgv1.MPIElasticPolicyApplyConfiguration) *MPIJobSpecApplyConfiguration {
+	b.ElasticPolicy = value
+	return b
+}
+
+// WithHostfileTemplate sets the HostfileTemplate field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the HostfileTemplate field is set to the value of the last call.
+func (b *MPIJobSpecApplyConfiguration) WithHostfileTemplate(value *kubefloworgv1.MPIHostfileTemplateApplyConfiguration) *MPIJobSpecApplyConfiguration {
+	b.HostfileTemplate = value
+	return b
+}
+
+// WithJobClassName sets the JobClassName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the JobClassName field is set to the value of the last call.
+func (b *MPIJobSpecApplyConfiguration) WithJobClassName(value string) *MPIJobSpecApplyConfiguration {
+	b.JobClassName = &value
+	return b
+}
+
+// WithDependsOn adds the given value to the DependsOn field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the DependsOn field.
+func (b *MPIJobSpecApplyConfiguration) WithDependsOn(values ...*kubefloworgv1.JobReferenceApplyConfiguration) *MPIJobSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithDependsOn")
+		}
+		b.DependsOn = append(b.DependsOn, *values[i])
+	}
+	return b
+}
+
+// WithTensorBoard sets the TensorBoard field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TensorBoard field is set to the value of the last call.
+func (b *MPIJobSpecApplyConfiguration) WithTensorBoard(value *kubefloworgv1.TensorBoardSpecApplyConfiguration) *MPIJobSpecApplyConfiguration {
+	b.TensorBoard = value
+	return b
+}
+
+// WithOutputs adds the given value to the Outputs field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Outputs field.
+func (b *MPIJobSpecApplyConfiguration) WithOutputs(values ...*kubefloworgv1.OutputSpecApplyConfiguration) *MPIJobSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithOutputs")
+		}
+		b.Outputs = append(b.Outputs, *values[i])
+	}
+	return b
+}
+
+// WithDatasets adds the given value to the Datasets field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Datasets field.
+func (b *MPIJobSpecApplyConfiguration) WithDatasets(values ...*kubefloworgv1.DatasetSpecApplyConfiguration) *MPIJobSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithDatasets")
+		}
+		b.Datasets = append(b.Datasets, *values[i])
+	}
+	return b
+}
+
+// WithRunPolicy sets the RunPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RunPolicy field is set to the value of the last call.
+func (b *MPIJobSpecApplyConfiguration) WithRunPolicy(value *kubefloworgv1.RunPolicyApplyConfiguration) *MPIJobSpecApplyConfiguration {
+	b.RunPolicy = value
+	return b
+}
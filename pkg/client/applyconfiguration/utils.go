@@ -19,8 +19,10 @@ package applyconfiguration
 import (
 	v1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
 	v2alpha1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v2alpha1"
+	v2beta1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v2beta1"
 	kubefloworgv1 "github.com/kubeflow/training-operator/pkg/client/applyconfiguration/kubeflow.org/v1"
 	kubefloworgv2alpha1 "github.com/kubeflow/training-operator/pkg/client/applyconfiguration/kubeflow.org/v2alpha1"
+	kubefloworgv2beta1 "github.com/kubeflow/training-operator/pkg/client/applyconfiguration/kubeflow.org/v2beta1"
 	schema "k8s.io/apimachinery/pkg/runtime/schema"
 )
 
@@ -29,20 +31,42 @@ import (
 func ForKind(kind schema.GroupVersionKind) interface{} {
 	switch kind {
 	// Group=kubeflow.org, Version=v1
+	case v1.SchemeGroupVersion.WithKind("CheckpointPolicy"):
+		return &kubefloworgv1.CheckpointPolicyApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("DatasetSpec"):
+		return &kubefloworgv1.DatasetSpecApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ElasticPolicy"):
 		return &kubefloworgv1.ElasticPolicyApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("EnvInjectionPolicy"):
+		return &kubefloworgv1.EnvInjectionPolicyApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("FailurePolicy"):
+		return &kubefloworgv1.FailurePolicyApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("FailurePolicyRule"):
+		return &kubefloworgv1.FailurePolicyRuleApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("GangSchedulingStatus"):
+		return &kubefloworgv1.GangSchedulingStatusApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("JAXJob"):
 		return &kubefloworgv1.JAXJobApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("JAXJobSpec"):
 		return &kubefloworgv1.JAXJobSpecApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("JobCondition"):
 		return &kubefloworgv1.JobConditionApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("JobReference"):
+		return &kubefloworgv1.JobReferenceApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("JobStatus"):
 		return &kubefloworgv1.JobStatusApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("MPIElasticPolicy"):
+		return &kubefloworgv1.MPIElasticPolicyApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("MPIHostfileTemplate"):
+		return &kubefloworgv1.MPIHostfileTemplateApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("MPIJob"):
 		return &kubefloworgv1.MPIJobApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("MPIJobSpec"):
 		return &kubefloworgv1.MPIJobSpecApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("OutputSpec"):
+		return &kubefloworgv1.OutputSpecApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("OutputStatus"):
+		return &kubefloworgv1.OutputStatusApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("PaddleElasticPolicy"):
 		return &kubefloworgv1.PaddleElasticPolicyApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("PaddleJob"):
@@ -55,6 +79,10 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kubefloworgv1.PyTorchJobSpecApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("RDZVConf"):
 		return &kubefloworgv1.RDZVConfApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("RabitPolicy"):
+		return &kubefloworgv1.RabitPolicyApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ReplicaPodStatus"):
+		return &kubefloworgv1.ReplicaPodStatusApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ReplicaSpec"):
 		return &kubefloworgv1.ReplicaSpecApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ReplicaStatus"):
@@ -63,10 +91,16 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kubefloworgv1.RunPolicyApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("SchedulingPolicy"):
 		return &kubefloworgv1.SchedulingPolicyApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("StartPolicy"):
+		return &kubefloworgv1.StartPolicyApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("TFJob"):
 		return &kubefloworgv1.TFJobApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("TFJobSpec"):
 		return &kubefloworgv1.TFJobSpecApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("TensorBoardSpec"):
+		return &kubefloworgv1.TensorBoardSpecApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("TopologyPolicy"):
+		return &kubefloworgv1.TopologyPolicyApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("XGBoostJob"):
 		return &kubefloworgv1.XGBoostJobApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("XGBoostJobSpec"):
@@ -124,6 +158,12 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 	case v2alpha1.SchemeGroupVersion.WithKind("TrainJobStatus"):
 		return &kubefloworgv2alpha1.TrainJobStatusApplyConfiguration{}
 
+		// Group=kubeflow.org, Version=v2beta1
+	case v2beta1.SchemeGroupVersion.WithKind("MPIJob"):
+		return &kubefloworgv2beta1.MPIJobApplyConfiguration{}
+	case v2beta1.SchemeGroupVersion.WithKind("MPIJobSpec"):
+		return &kubefloworgv2beta1.MPIJobSpecApplyConfiguration{}
+
 	}
 	return nil
 }
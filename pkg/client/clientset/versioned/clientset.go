@@ -22,6 +22,7 @@ import (
 
 	kubeflowv1 "github.com/kubeflow/training-operator/pkg/client/clientset/versioned/typed/kubeflow.org/v1"
 	kubeflowv2alpha1 "github.com/kubeflow/training-operator/pkg/client/clientset/versioned/typed/kubeflow.org/v2alpha1"
+	kubeflowv2beta1 "github.com/kubeflow/training-operator/pkg/client/clientset/versioned/typed/kubeflow.org/v2beta1"
 	discovery "k8s.io/client-go/discovery"
 	rest "k8s.io/client-go/rest"
 	flowcontrol "k8s.io/client-go/util/flowcontrol"
@@ -31,6 +32,7 @@ type Interface interface {
 	Discovery() discovery.DiscoveryInterface
 	KubeflowV1() kubeflowv1.KubeflowV1Interface
 	KubeflowV2alpha1() kubeflowv2alpha1.KubeflowV2alpha1Interface
+	KubeflowV2beta1() kubeflowv2beta1.KubeflowV2beta1Interface
 }
 
 // Clientset contains the clients for groups.
@@ -38,6 +40,7 @@ type Clientset struct {
 	*discovery.DiscoveryClient
 	kubeflowV1       *kubeflowv1.KubeflowV1Client
 	kubeflowV2alpha1 *kubeflowv2alpha1.KubeflowV2alpha1Client
+	kubeflowV2beta1  *kubeflowv2beta1.KubeflowV2beta1Client
 }
 
 // KubeflowV1 retrieves the KubeflowV1Client
@@ -50,6 +53,11 @@ func (c *Clientset) KubeflowV2alpha1() kubeflowv2alpha1.KubeflowV2alpha1Interfac
 	return c.kubeflowV2alpha1
 }
 
+// KubeflowV2beta1 retrieves the KubeflowV2beta1Client
+func (c *Clientset) KubeflowV2beta1() kubeflowv2beta1.KubeflowV2beta1Interface {
+	return c.kubeflowV2beta1
+}
+
 // Discovery retrieves the DiscoveryClient
 func (c *Clientset) Discovery() discovery.DiscoveryInterface {
 	if c == nil {
@@ -102,6 +110,10 @@ func NewForConfigAndClient(c *rest.Config, httpClient *http.Client) (*Clientset,
 	if err != nil {
 		return nil, err
 	}
+	cs.kubeflowV2beta1, err = kubeflowv2beta1.NewForConfigAndClient(&configShallowCopy, httpClient)
+	if err != nil {
+		return nil, err
+	}
 
 	cs.DiscoveryClient, err = discovery.NewDiscoveryClientForConfigAndClient(&configShallowCopy, httpClient)
 	if err != nil {
@@ -125,6 +137,7 @@ func New(c rest.Interface) *Clientset {
 	var cs Clientset
 	cs.kubeflowV1 = kubeflowv1.New(c)
 	cs.kubeflowV2alpha1 = kubeflowv2alpha1.New(c)
+	cs.kubeflowV2beta1 = kubeflowv2beta1.New(c)
 
 	cs.DiscoveryClient = discovery.NewDiscoveryClient(c)
 	return &cs
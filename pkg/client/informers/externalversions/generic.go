@@ -21,6 +21,7 @@ import (
 
 	v1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
 	v2alpha1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v2alpha1"
+	v2beta1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v2beta1"
 	schema "k8s.io/apimachinery/pkg/runtime/schema"
 	cache "k8s.io/client-go/tools/cache"
 )
@@ -73,6 +74,10 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 	case v2alpha1.SchemeGroupVersion.WithResource("trainingruntimes"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kubeflow().V2alpha1().TrainingRuntimes().Informer()}, nil
 
+		// Group=kubeflow.org, Version=v2beta1
+	case v2beta1.SchemeGroupVersion.WithResource("mpijobs"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kubeflow().V2beta1().MPIJobs().Informer()}, nil
+
 	}
 
 	return nil, fmt.Errorf("no informer found for %v", resource)
@@ -20,6 +20,7 @@ import (
 	internalinterfaces "github.com/kubeflow/training-operator/pkg/client/informers/externalversions/internalinterfaces"
 	v1 "github.com/kubeflow/training-operator/pkg/client/informers/externalversions/kubeflow.org/v1"
 	v2alpha1 "github.com/kubeflow/training-operator/pkg/client/informers/externalversions/kubeflow.org/v2alpha1"
+	v2beta1 "github.com/kubeflow/training-operator/pkg/client/informers/externalversions/kubeflow.org/v2beta1"
 )
 
 // Interface provides access to each of this group's versions.
@@ -28,6 +29,8 @@ type Interface interface {
 	V1() v1.Interface
 	// V2alpha1 provides access to shared informers for resources in V2alpha1.
 	V2alpha1() v2alpha1.Interface
+	// V2beta1 provides access to shared informers for resources in V2beta1.
+	V2beta1() v2beta1.Interface
 }
 
 type group struct {
@@ -50,3 +53,8 @@ func (g *group) V1() v1.Interface {
 func (g *group) V2alpha1() v2alpha1.Interface {
 	return v2alpha1.New(g.factory, g.namespace, g.tweakListOptions)
 }
+
+// V2beta1 returns a new v2beta1.Interface.
+func (g *group) V2beta1() v2beta1.Interface {
+	return v2beta1.New(g.factory, g.namespace, g.tweakListOptions)
+}
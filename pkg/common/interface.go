@@ -92,3 +92,57 @@ type ControllerInterface interface {
 	// GetFrameworkName returns framework name (e.g., tensorflow).
 	GetFrameworkName() string
 }
+
+// InitContainerInjector is optionally implemented by the custom operators which add init containers
+// to the pods of a replica type besides the ones of its template, e.g. the kubectl-delivery init
+// container of the MPI launcher, so that they are accounted in the minResources of the PodGroups
+// and in the resource quota checks.
+type InitContainerInjector interface {
+	// InjectedInitContainers returns the init containers added to the pods of the replica type of the job.
+	InjectedInitContainers(job interface{}, rtype apiv1.ReplicaType) []v1.Container
+}
+
+// PodSpecBuilder is optionally implemented by the custom operators which build the pods of their
+// replicas themselves, e.g. the MPI launcher and workers, so that the requests of the pods which
+// are not created yet are computed from their spec as it is created.
+type PodSpecBuilder interface {
+	// BuildPodSpec returns the spec of a pod of the replica type of the job, or nil if the pod
+	// cannot be built.
+	BuildPodSpec(job interface{}, rtype apiv1.ReplicaType) (*v1.PodSpec, error)
+}
+
+// JobDependenciesGetter is optionally implemented by the custom operators whose jobs reference
+// the jobs they depend on, so that their pods are created once these jobs succeed.
+type JobDependenciesGetter interface {
+	// GetJobDependencies returns the jobs the job depends on.
+	GetJobDependencies(job interface{}) []apiv1.JobReference
+}
+
+// TensorBoardGetter is optionally implemented by the custom operators whose jobs request a
+// TensorBoard serving their logs.
+type TensorBoardGetter interface {
+	// GetTensorBoard returns the TensorBoard requested by the job, or nil.
+	GetTensorBoard(job interface{}) *apiv1.TensorBoardSpec
+}
+
+// OutputsGetter is optionally implemented by the custom operators whose jobs declare the
+// artifacts they produce.
+type OutputsGetter interface {
+	// GetOutputs returns the outputs declared by the job.
+	GetOutputs(job interface{}) []apiv1.OutputSpec
+}
+
+// OutputPodsGetter is optionally implemented by the custom operators whose master role runs in
+// pods which are not controlled by the job, e.g. the pods of the batch/v1 Job of an MPI launcher,
+// so that the outputs reported by these pods are recorded.
+type OutputPodsGetter interface {
+	// GetOutputPods returns the pods of the master role of the job which are not controlled by it.
+	GetOutputPods(job interface{}) ([]*v1.Pod, error)
+}
+
+// DatasetsGetter is optionally implemented by the custom operators whose jobs declare the
+// datasets staged into their pods.
+type DatasetsGetter interface {
+	// GetDatasets returns the datasets declared by the job.
+	GetDatasets(job interface{}) []apiv1.DatasetSpec
+}
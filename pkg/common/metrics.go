@@ -15,9 +15,14 @@
 package common
 
 import (
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	"github.com/kubeflow/training-operator/pkg/controller.v1/registry"
 )
 
 // Define all the prometheus counters for all jobs
@@ -57,6 +62,83 @@ var (
 		},
 		[]string{"job_namespace", "framework"},
 	)
+	jobOOMKillsCount = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "training_operator_job_oom_kills_total",
+			Help: "Counts number of containers of the pods of the jobs which were OOMKilled",
+		},
+		[]string{"job_namespace", "framework"},
+	)
+)
+
+// Define the prometheus gauges of the restarts limited by the maximum number of concurrent restarts
+var (
+	jobsRestartingGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "training_operator_jobs_restarting",
+			Help: "Number of jobs of all kinds restarting at the same time",
+		},
+	)
+	jobsQueuedForRestartGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "training_operator_jobs_queued_for_restart",
+			Help: "Number of jobs of all kinds waiting for the restarts of other jobs",
+		},
+	)
+)
+
+// Define the prometheus histograms for the latencies of the reconciliations and the jobs
+var (
+	reconcileDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "training_operator_reconcile_duration_seconds",
+			Help:    "Duration of the reconciliations of the jobs",
+			Buckets: prometheus.ExponentialBuckets(0.001, 2, 15),
+		},
+		[]string{"framework"},
+	)
+	jobQueueToRunningDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "training_operator_job_queue_to_running_seconds",
+			Help:    "Duration from the creation of the jobs to their first Running condition",
+			Buckets: prometheus.ExponentialBuckets(1, 2, 16),
+		},
+		[]string{"job_namespace", "framework"},
+	)
+	jobRunDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "training_operator_job_run_duration_seconds",
+			Help:    "Duration from the start to the completion of the jobs",
+			Buckets: prometheus.ExponentialBuckets(10, 2, 16),
+		},
+		[]string{"job_namespace", "framework", "result"},
+	)
+	podCreationDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "training_operator_pod_creation_duration_seconds",
+			Help:    "Duration of the API calls creating the pods of the jobs",
+			Buckets: prometheus.ExponentialBuckets(0.005, 2, 14),
+		},
+		[]string{"framework", "replica_type"},
+	)
+	podDeletionDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "training_operator_pod_deletion_duration_seconds",
+			Help:    "Duration of the API calls deleting the pods of the jobs",
+			Buckets: prometheus.ExponentialBuckets(0.005, 2, 14),
+		},
+		[]string{"framework", "replica_type"},
+	)
+	activeReplicasDesc = prometheus.NewDesc(
+		"training_operator_active_replicas",
+		"Number of active replicas of the jobs",
+		[]string{"job_namespace", "framework"}, nil,
+	)
+	apiCallsDesc = prometheus.NewDesc(
+		"training_operator_job_api_calls_total",
+		"Counts number of API calls made by the operator on behalf of a job",
+		[]string{"job_namespace", "job_name", "framework", "verb"}, nil,
+	)
 )
 
 func init() {
@@ -65,7 +147,23 @@ func init() {
 		jobsDeletedCount,
 		jobsSuccessfulCount,
 		jobsFailedCount,
-		jobsRestartedCount)
+		jobsRestartedCount,
+		jobOOMKillsCount,
+		jobsRestartingGauge,
+		jobsQueuedForRestartGauge,
+		reconcileDuration,
+		jobQueueToRunningDuration,
+		jobRunDuration,
+		podCreationDuration,
+		podDeletionDuration)
+}
+
+// RegisterJobCollectors registers the collectors reporting the active replicas and the API calls
+// of the jobs listed by jobs, i.e. the registry shared by the controllers.
+func RegisterJobCollectors(jobs registry.Reader) {
+	metrics.Registry.MustRegister(
+		&activeReplicasCollector{jobs: jobs},
+		&apiCallsCollector{jobs: jobs})
 }
 
 func CreatedJobsCounterInc(job_namespace, framework string) {
@@ -87,3 +185,87 @@ func FailedJobsCounterInc(job_namespace, framework string) {
 func RestartedJobsCounterInc(job_namespace, framework string) {
 	jobsRestartedCount.WithLabelValues(job_namespace, framework).Inc()
 }
+
+func OOMKillsCounterAdd(job_namespace, framework string, count int) {
+	jobOOMKillsCount.WithLabelValues(job_namespace, framework).Add(float64(count))
+}
+
+// RestartingJobsGaugeSet records the number of jobs restarting and queued for restart.
+func RestartingJobsGaugeSet(restarting, queued int) {
+	jobsRestartingGauge.Set(float64(restarting))
+	jobsQueuedForRestartGauge.Set(float64(queued))
+}
+
+// ReconcileDurationObserve records the duration of a reconciliation which started at startTime.
+// It is meant to be deferred at the start of the reconciliation.
+func ReconcileDurationObserve(framework string, startTime time.Time) {
+	reconcileDuration.WithLabelValues(framework).Observe(time.Since(startTime).Seconds())
+}
+
+func JobQueueToRunningDurationObserve(job_namespace, framework string, duration time.Duration) {
+	jobQueueToRunningDuration.WithLabelValues(job_namespace, framework).Observe(duration.Seconds())
+}
+
+func JobRunDurationObserve(job_namespace, framework, result string, duration time.Duration) {
+	jobRunDuration.WithLabelValues(job_namespace, framework, result).Observe(duration.Seconds())
+}
+
+// PodCreationDurationObserve records the duration of a call creating a pod of the given replica type.
+func PodCreationDurationObserve(framework, replicaType string, duration time.Duration) {
+	podCreationDuration.WithLabelValues(framework, replicaType).Observe(duration.Seconds())
+}
+
+// PodDeletionDurationObserve records the duration of a call deleting a pod of the given replica type.
+func PodDeletionDurationObserve(framework, replicaType string, duration time.Duration) {
+	podDeletionDuration.WithLabelValues(framework, replicaType).Observe(duration.Seconds())
+}
+
+// frameworks maps the kinds of the jobs to their framework names.
+var frameworks = map[string]string{
+	kubeflowv1.TFJobKind:      kubeflowv1.TFJobFrameworkName,
+	kubeflowv1.PyTorchJobKind: kubeflowv1.PyTorchJobFrameworkName,
+	kubeflowv1.MPIJobKind:     kubeflowv1.MPIJobFrameworkName,
+	kubeflowv1.XGBoostJobKind: kubeflowv1.XGBoostJobFrameworkName,
+	kubeflowv1.PaddleJobKind:  kubeflowv1.PaddleJobFrameworkName,
+	kubeflowv1.JAXJobKind:     kubeflowv1.JAXJobFrameworkName,
+}
+
+// activeReplicasCollector reports the number of active replicas per namespace and framework
+// from the jobs of the registry, so that deleted jobs are not reported anymore.
+type activeReplicasCollector struct {
+	jobs registry.Reader
+}
+
+func (c *activeReplicasCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- activeReplicasDesc
+}
+
+func (c *activeReplicasCollector) Collect(ch chan<- prometheus.Metric) {
+	type key struct{ namespace, framework string }
+	active := map[key]int32{}
+	for _, job := range c.jobs.List() {
+		active[key{namespace: job.Namespace, framework: frameworks[job.Kind]}] += job.ActiveReplicas
+	}
+	for k, value := range active {
+		ch <- prometheus.MustNewConstMetric(activeReplicasDesc, prometheus.GaugeValue, float64(value), k.namespace, k.framework)
+	}
+}
+
+// apiCallsCollector reports the number of API calls made on behalf of each job of the registry
+// per verb, so that the load of the operator on the API server can be attributed to the jobs.
+// Deleted jobs are not reported anymore.
+type apiCallsCollector struct {
+	jobs registry.Reader
+}
+
+func (c *apiCallsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- apiCallsDesc
+}
+
+func (c *apiCallsCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, job := range c.jobs.List() {
+		for verb, count := range job.APICalls {
+			ch <- prometheus.MustNewConstMetric(apiCallsDesc, prometheus.CounterValue, float64(count), job.Namespace, job.Name, frameworks[job.Kind], verb)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	"github.com/kubeflow/training-operator/pkg/core"
+)
+
+var jobClassNamePath = field.NewPath("spec").Child("jobClassName")
+
+// ApplyJobClass merges the defaults of the TrainingJobClass className, read with reader, into the
+// replicas and the runPolicy of the job, and records the class in the JobClassAnnotation of the job.
+// The defaults are merged once, when the job is created, so that a later change of the class doesn't
+// change the templates of the running jobs. A field error is returned if the class doesn't exist.
+func ApplyJobClass(ctx context.Context, reader client.Reader, job metav1.Object, className string,
+	replicas map[v1.ReplicaType]*v1.ReplicaSpec, runPolicy *v1.RunPolicy) error {
+	if className == "" {
+		return nil
+	}
+	if _, ok := job.GetAnnotations()[v1.JobClassAnnotation]; ok {
+		return nil
+	}
+	class := &v1.TrainingJobClass{}
+	if err := reader.Get(ctx, client.ObjectKey{Name: className}, class); err != nil {
+		if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return field.NotFound(jobClassNamePath, className)
+		}
+		return fmt.Errorf("failed to get TrainingJobClass %s: %w", className, err)
+	}
+
+	for _, spec := range replicas {
+		if spec != nil {
+			applyJobClassDefaults(&class.Spec, &spec.Template)
+		}
+	}
+	if err := mergeRunPolicy(runPolicy, class.Spec.RunPolicy); err != nil {
+		return err
+	}
+	annotations := job.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[v1.JobClassAnnotation] = className
+	job.SetAnnotations(annotations)
+	return nil
+}
+
+// applyJobClassDefaults merges the defaults of a class into the podTemplate, whose own values
+// take precedence.
+func applyJobClassDefaults(spec *v1.TrainingJobClassSpec, podTemplate *corev1.PodTemplateSpec) {
+	for _, toleration := range spec.Tolerations {
+		if !hasToleration(podTemplate.Spec.Tolerations, toleration) {
+			podTemplate.Spec.Tolerations = append(podTemplate.Spec.Tolerations, toleration)
+		}
+	}
+	for key, value := range spec.NodeSelector {
+		if _, ok := podTemplate.Spec.NodeSelector[key]; ok {
+			continue
+		}
+		if podTemplate.Spec.NodeSelector == nil {
+			podTemplate.Spec.NodeSelector = map[string]string{}
+		}
+		podTemplate.Spec.NodeSelector[key] = value
+	}
+	for i := range podTemplate.Spec.Containers {
+		container := &podTemplate.Spec.Containers[i]
+		if container.Image == "" {
+			container.Image = spec.Images[container.Name]
+		}
+		core.SetCommonEnv(podTemplate, container.Name, spec.Env, nil)
+	}
+}
+
+func hasToleration(tolerations []corev1.Toleration, toleration corev1.Toleration) bool {
+	for i := range tolerations {
+		if tolerations[i].MatchToleration(&toleration) {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeRunPolicy sets the fields of runPolicy which are not set to the ones of the defaults,
+// except managedBy and suspend which only the jobs set.
+func mergeRunPolicy(runPolicy, defaults *v1.RunPolicy) error {
+	if defaults == nil {
+		return nil
+	}
+	fields := map[string]json.RawMessage{}
+	if err := unmarshalRunPolicy(defaults, &fields); err != nil {
+		return err
+	}
+	delete(fields, "managedBy")
+	delete(fields, "suspend")
+	own := map[string]json.RawMessage{}
+	if err := unmarshalRunPolicy(runPolicy, &own); err != nil {
+		return err
+	}
+	for name, value := range own {
+		fields[name] = value
+	}
+	b, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	merged := v1.RunPolicy{}
+	if err := json.Unmarshal(b, &merged); err != nil {
+		return err
+	}
+	*runPolicy = merged
+	return nil
+}
+
+func unmarshalRunPolicy(runPolicy *v1.RunPolicy, fields *map[string]json.RawMessage) error {
+	b, err := json.Marshal(runPolicy)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, fields)
+}
@@ -0,0 +1,262 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"github.com/google/go-cmp/cmp"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+)
+
+func newJobClassScheme(t *testing.T) *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	if err := kubeflowv1.AddToScheme(scheme); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return scheme
+}
+
+func newJobClassPyTorchJob(className, image string) *kubeflowv1.PyTorchJob {
+	return &kubeflowv1.PyTorchJob{
+		TypeMeta:   metav1.TypeMeta{APIVersion: kubeflowv1.GroupVersion.String(), Kind: kubeflowv1.PyTorchJobKind},
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: metav1.NamespaceDefault},
+		Spec: kubeflowv1.PyTorchJobSpec{
+			JobClassName: className,
+			RunPolicy:    kubeflowv1.RunPolicy{BackoffLimit: ptr.To[int32](1)},
+			PyTorchReplicaSpecs: map[kubeflowv1.ReplicaType]*kubeflowv1.ReplicaSpec{
+				kubeflowv1.PyTorchJobReplicaTypeMaster: {
+					Replicas: ptr.To[int32](1),
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							NodeSelector: map[string]string{"zone": "b"},
+							Containers: []corev1.Container{{
+								Name:  "pytorch",
+								Image: image,
+								Env:   []corev1.EnvVar{{Name: "HTTP_PROXY", Value: "job"}},
+							}},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestApplyJobClass(t *testing.T) {
+	class := &kubeflowv1.TrainingJobClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "gpu"},
+		Spec: kubeflowv1.TrainingJobClassSpec{
+			Tolerations:  []corev1.Toleration{{Key: "nvidia.com/gpu", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule}},
+			NodeSelector: map[string]string{"node-pool": "gpu", "zone": "a"},
+			Env:          []corev1.EnvVar{{Name: "NCCL_DEBUG", Value: "INFO"}, {Name: "HTTP_PROXY", Value: "proxy"}},
+			Images:       map[string]string{"pytorch": "pytorch:2.3"},
+			RunPolicy: &kubeflowv1.RunPolicy{
+				BackoffLimit:            ptr.To[int32](3),
+				TTLSecondsAfterFinished: ptr.To[int32](3600),
+				Suspend:                 ptr.To(true),
+			},
+		},
+	}
+	reader := fake.NewClientBuilder().WithScheme(newJobClassScheme(t)).WithObjects(class).Build()
+
+	cases := map[string]struct {
+		className       string
+		annotations     map[string]string
+		image           string
+		wantErr         bool
+		wantAnnotations map[string]string
+		wantPodTemplate corev1.PodTemplateSpec
+		wantRunPolicy   kubeflowv1.RunPolicy
+	}{
+		"the defaults of the class are merged": {
+			className:       "gpu",
+			wantAnnotations: map[string]string{kubeflowv1.JobClassAnnotation: "gpu"},
+			wantPodTemplate: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					NodeSelector: map[string]string{"node-pool": "gpu", "zone": "b"},
+					Tolerations:  []corev1.Toleration{{Key: "nvidia.com/gpu", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule}},
+					Containers: []corev1.Container{{
+						Name:  "pytorch",
+						Image: "pytorch:2.3",
+						Env:   []corev1.EnvVar{{Name: "NCCL_DEBUG", Value: "INFO"}, {Name: "HTTP_PROXY", Value: "job"}},
+					}},
+				},
+			},
+			wantRunPolicy: kubeflowv1.RunPolicy{
+				BackoffLimit:            ptr.To[int32](1),
+				TTLSecondsAfterFinished: ptr.To[int32](3600),
+			},
+		},
+		"the image of the job takes precedence": {
+			className:       "gpu",
+			image:           "pytorch:custom",
+			wantAnnotations: map[string]string{kubeflowv1.JobClassAnnotation: "gpu"},
+			wantPodTemplate: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					NodeSelector: map[string]string{"node-pool": "gpu", "zone": "b"},
+					Tolerations:  []corev1.Toleration{{Key: "nvidia.com/gpu", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule}},
+					Containers: []corev1.Container{{
+						Name:  "pytorch",
+						Image: "pytorch:custom",
+						Env:   []corev1.EnvVar{{Name: "NCCL_DEBUG", Value: "INFO"}, {Name: "HTTP_PROXY", Value: "job"}},
+					}},
+				},
+			},
+			wantRunPolicy: kubeflowv1.RunPolicy{
+				BackoffLimit:            ptr.To[int32](1),
+				TTLSecondsAfterFinished: ptr.To[int32](3600),
+			},
+		},
+		"the job without class is left as is": {
+			image: "pytorch:custom",
+			wantPodTemplate: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					NodeSelector: map[string]string{"zone": "b"},
+					Containers: []corev1.Container{{
+						Name:  "pytorch",
+						Image: "pytorch:custom",
+						Env:   []corev1.EnvVar{{Name: "HTTP_PROXY", Value: "job"}},
+					}},
+				},
+			},
+			wantRunPolicy: kubeflowv1.RunPolicy{BackoffLimit: ptr.To[int32](1)},
+		},
+		"the defaults are merged only once": {
+			className:       "gpu",
+			annotations:     map[string]string{kubeflowv1.JobClassAnnotation: "gpu"},
+			image:           "pytorch:custom",
+			wantAnnotations: map[string]string{kubeflowv1.JobClassAnnotation: "gpu"},
+			wantPodTemplate: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					NodeSelector: map[string]string{"zone": "b"},
+					Containers: []corev1.Container{{
+						Name:  "pytorch",
+						Image: "pytorch:custom",
+						Env:   []corev1.EnvVar{{Name: "HTTP_PROXY", Value: "job"}},
+					}},
+				},
+			},
+			wantRunPolicy: kubeflowv1.RunPolicy{BackoffLimit: ptr.To[int32](1)},
+		},
+		"the class doesn't exist": {
+			className: "missing",
+			wantErr:   true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			job := newJobClassPyTorchJob(tc.className, tc.image)
+			job.Annotations = tc.annotations
+			err := ApplyJobClass(context.Background(), reader, job, job.Spec.JobClassName, job.Spec.PyTorchReplicaSpecs, &job.Spec.RunPolicy)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Unexpected error, want error: %t, got: %v", tc.wantErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.wantPodTemplate, job.Spec.PyTorchReplicaSpecs[kubeflowv1.PyTorchJobReplicaTypeMaster].Template); len(diff) != 0 {
+				t.Errorf("Unexpected pod template (-want,+got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantRunPolicy, job.Spec.RunPolicy); len(diff) != 0 {
+				t.Errorf("Unexpected run policy (-want,+got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantAnnotations, job.Annotations); len(diff) != 0 {
+				t.Errorf("Unexpected annotations (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestJobDefaulterHandlerAppliesJobClass(t *testing.T) {
+	scheme := newJobClassScheme(t)
+	class := &kubeflowv1.TrainingJobClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "gpu"},
+		Spec:       kubeflowv1.TrainingJobClassSpec{Images: map[string]string{"pytorch": "pytorch:2.3"}},
+	}
+	reader := fake.NewClientBuilder().WithScheme(scheme).WithObjects(class).Build()
+	handler := NewJobDefaulterHandler(admission.NewDecoder(scheme),
+		func() runtime.Object { return &kubeflowv1.PyTorchJob{} },
+		func(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+			job := obj.(*kubeflowv1.PyTorchJob)
+			return nil, ApplyJobClass(ctx, reader, job, job.Spec.JobClassName, job.Spec.PyTorchReplicaSpecs, &job.Spec.RunPolicy)
+		})
+
+	cases := map[string]struct {
+		className   string
+		wantAllowed bool
+		wantImage   string
+	}{
+		"the image of the class is set": {
+			className:   "gpu",
+			wantAllowed: true,
+			wantImage:   "pytorch:2.3",
+		},
+		"the unknown class is rejected": {
+			className: "missing",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			raw, err := json.Marshal(newJobClassPyTorchJob(tc.className, ""))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			resp := handler.Handle(context.Background(), admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Create,
+				Object:    runtime.RawExtension{Raw: raw},
+			}})
+			if resp.Allowed != tc.wantAllowed {
+				t.Fatalf("Unexpected allowed, want: %t, got: %v", tc.wantAllowed, resp)
+			}
+			if !resp.Allowed {
+				return
+			}
+			patch, err := json.Marshal(resp.Patches)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			decoded, err := jsonpatch.DecodePatch(patch)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			patched, err := decoded.Apply(raw)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			got := &kubeflowv1.PyTorchJob{}
+			if err := json.Unmarshal(patched, got); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if image := got.Spec.PyTorchReplicaSpecs[kubeflowv1.PyTorchJobReplicaTypeMaster].Template.Spec.Containers[0].Image; image != tc.wantImage {
+				t.Errorf("Unexpected image, want: %q, got: %q", tc.wantImage, image)
+			}
+			if got.Annotations[kubeflowv1.JobClassAnnotation] != tc.className {
+				t.Errorf("Expected the %s annotation to be %s, got: %v", kubeflowv1.JobClassAnnotation, tc.className, got.Annotations)
+			}
+		})
+	}
+}
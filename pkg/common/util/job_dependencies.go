@@ -0,0 +1,108 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+)
+
+var dependsOnPath = field.NewPath("spec").Child("dependsOn")
+
+// jobKey is a job of the namespace of the validated job.
+type jobKey struct {
+	kind string
+	name string
+}
+
+// ValidateJobDependencies rejects the jobs of kind which depend on themselves, directly or through
+// the jobs they depend on, since they would wait for their dependencies forever. The jobs they
+// depend on are read with reader, and the ones which don't exist yet are not followed. A nil
+// reader only rejects the jobs which depend directly on themselves.
+func ValidateJobDependencies(ctx context.Context, reader client.Reader, job metav1.Object, kind string, dependsOn []v1.JobReference) field.ErrorList {
+	var allErrs field.ErrorList
+	self := jobKey{kind: kind, name: job.GetName()}
+	for i, ref := range dependsOn {
+		dep := jobKey{kind: ref.Kind, name: ref.Name}
+		if dep.kind == "" {
+			dep.kind = kind
+		}
+		if dep == self {
+			allErrs = append(allErrs, field.Invalid(dependsOnPath.Index(i), ref, "a job can't depend on itself"))
+			continue
+		}
+		if reader == nil {
+			continue
+		}
+		cycle, err := dependsOnJob(ctx, reader, job.GetNamespace(), dep, self, map[jobKey]bool{})
+		if err != nil {
+			return append(allErrs, field.InternalError(dependsOnPath.Index(i), err))
+		}
+		if cycle {
+			allErrs = append(allErrs, field.Invalid(dependsOnPath.Index(i), ref,
+				fmt.Sprintf("%s %s depends on %s %s", dep.kind, dep.name, self.kind, self.name)))
+		}
+	}
+	return allErrs
+}
+
+// dependsOnJob returns whether the job dep of the namespace depends on target, directly or through
+// the jobs it depends on. The jobs are read as unstructured objects, so that the jobs of all the
+// kinds are followed whether or not they are cached by the reader.
+func dependsOnJob(ctx context.Context, reader client.Reader, namespace string, dep, target jobKey, visited map[jobKey]bool) (bool, error) {
+	if visited[dep] {
+		return false, nil
+	}
+	visited[dep] = true
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(v1.GroupVersion.WithKind(dep.kind))
+	if err := reader.Get(ctx, client.ObjectKey{Namespace: namespace, Name: dep.name}, obj); err != nil {
+		if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) || runtime.IsNotRegisteredError(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get %s %s: %w", dep.kind, dep.name, err)
+	}
+	refs, _, err := unstructured.NestedSlice(obj.Object, "spec", "dependsOn")
+	if err != nil {
+		return false, err
+	}
+	for _, r := range refs {
+		ref, ok := r.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		next := jobKey{kind: fmt.Sprint(ref["kind"]), name: fmt.Sprint(ref["name"])}
+		if ref["kind"] == nil || next.kind == "" {
+			next.kind = dep.kind
+		}
+		if next == target {
+			return true, nil
+		}
+		if cycle, err := dependsOnJob(ctx, reader, namespace, next, target, visited); err != nil || cycle {
+			return cycle, err
+		}
+	}
+	return false, nil
+}
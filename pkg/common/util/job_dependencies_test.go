@@ -0,0 +1,75 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+)
+
+func TestValidateJobDependencies(t *testing.T) {
+	preprocess := &kubeflowv1.TFJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "preprocess", Namespace: metav1.NamespaceDefault},
+		Spec:       kubeflowv1.TFJobSpec{DependsOn: []kubeflowv1.JobReference{{Kind: kubeflowv1.PyTorchJobKind, Name: "train"}}},
+	}
+	download := &kubeflowv1.PyTorchJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "download", Namespace: metav1.NamespaceDefault},
+		Spec:       kubeflowv1.PyTorchJobSpec{DependsOn: []kubeflowv1.JobReference{{Name: "missing"}}},
+	}
+	reader := fake.NewClientBuilder().WithScheme(newJobClassScheme(t)).WithObjects(preprocess, download).Build()
+
+	cases := map[string]struct {
+		reader    client.Reader
+		dependsOn []kubeflowv1.JobReference
+		wantErrs  field.ErrorList
+	}{
+		"the jobs it depends on don't depend on it": {
+			reader:    reader,
+			dependsOn: []kubeflowv1.JobReference{{Name: "download"}, {Name: "missing"}},
+		},
+		"the job depends on itself": {
+			reader:    reader,
+			dependsOn: []kubeflowv1.JobReference{{Name: "download"}, {Kind: kubeflowv1.PyTorchJobKind, Name: "train"}},
+			wantErrs:  field.ErrorList{field.Invalid(dependsOnPath.Index(1), nil, "")},
+		},
+		"the job it depends on depends on it": {
+			reader:    reader,
+			dependsOn: []kubeflowv1.JobReference{{Kind: kubeflowv1.TFJobKind, Name: "preprocess"}},
+			wantErrs:  field.ErrorList{field.Invalid(dependsOnPath.Index(0), nil, "")},
+		},
+		"the job itself is checked without reader": {
+			dependsOn: []kubeflowv1.JobReference{{Kind: kubeflowv1.TFJobKind, Name: "preprocess"}, {Name: "train"}},
+			wantErrs:  field.ErrorList{field.Invalid(dependsOnPath.Index(1), nil, "")},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			job := &kubeflowv1.PyTorchJob{ObjectMeta: metav1.ObjectMeta{Name: "train", Namespace: metav1.NamespaceDefault}}
+			got := ValidateJobDependencies(context.Background(), tc.reader, job, kubeflowv1.PyTorchJobKind, tc.dependsOn)
+			if diff := cmp.Diff(tc.wantErrs, got, cmpopts.IgnoreFields(field.Error{}, "Detail", "BadValue")); len(diff) != 0 {
+				t.Errorf("Unexpected errors (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -19,13 +19,20 @@ import (
 	"reflect"
 	"strings"
 
-	log "github.com/sirupsen/logrus"
+	"github.com/go-logr/logr"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+	schedulerpluginsv1alpha1 "sigs.k8s.io/scheduler-plugins/apis/scheduling/v1alpha1"
+	volcanov1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
 
 	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
 	"github.com/kubeflow/training-operator/pkg/controller.v1/common"
@@ -37,28 +44,28 @@ func GenExpectationGenericKey(jobKey string, replicaType string, pl string) stri
 	return jobKey + "/" + strings.ToLower(replicaType) + "/" + pl
 }
 
-// LoggerForGenericKind generates log entry for generic Kubernetes resource Kind
-func LoggerForGenericKind(obj metav1.Object, kind string) *log.Entry {
+// LoggerForGenericKind generates a logger for a dependent (pod/service) of the job with the given Kind
+func LoggerForGenericKind(obj metav1.Object, kind string) logr.Logger {
 	job := ""
 	if controllerRef := metav1.GetControllerOf(obj); controllerRef != nil {
 		if controllerRef.Kind == kind {
-			job = obj.GetNamespace() + "." + controllerRef.Name
+			job = controllerRef.Name
 		}
 	}
-	return log.WithFields(log.Fields{
-		// We use job to match the key used in controller.go
-		// In controller.go we log the key used with the workqueue.
-		"job": job,
-		kind:  obj.GetNamespace() + "." + obj.GetName(),
-		"uid": obj.GetUID(),
-	})
+	return log.Log.WithValues(
+		"kind", kind,
+		"namespace", obj.GetNamespace(),
+		"name", job,
+		"object", obj.GetName(),
+		"uid", obj.GetUID(),
+	)
 }
 
 func objectKind(s *runtime.Scheme, obj client.Object) schema.GroupVersionKind {
 	gkvs, _, err := s.ObjectKinds(obj)
 	if err != nil {
 		var logger = LoggerForGenericKind(obj, "")
-		logger.Errorf("unknown kind for %v", obj)
+		logger.Error(err, "Unknown kind of the object")
 		return schema.GroupVersionKind{}
 	}
 	return gkvs[0]
@@ -117,7 +124,7 @@ func OnDependentUpdateFuncGeneric[T client.Object](_ *runtime.Scheme, jc *common
 		if controllerRefChanged && oldControllerRef != nil {
 			// The ControllerRef was changed. Sync the old controller, if any.
 			if job := resolveControllerRef(jc, oldObj.GetNamespace(), oldControllerRef); job != nil {
-				logger.Infof("%s controller ref updated: %v, %v", kind, newObj, oldObj)
+				logger.Info("Controller ref updated", "oldControllerRef", oldControllerRef.Name)
 				return true
 			}
 		}
@@ -128,7 +135,7 @@ func OnDependentUpdateFuncGeneric[T client.Object](_ *runtime.Scheme, jc *common
 			if job == nil {
 				return false
 			}
-			logger.Debugf("%s has a controller ref: %v, %v", kind, newObj, oldObj)
+			logger.V(1).Info("Object has a controller ref", "controllerRef", newControllerRef.Name)
 			return true
 		}
 		return false
@@ -196,3 +203,24 @@ func resolveControllerRef(jc *common.JobController, namespace string, controller
 	}
 	return job
 }
+
+// WatchPodGroups watches the PodGroups of the gang scheduler of the controller owned by the jobs
+// of ownerType. The PodGroups are not watched if the gang scheduling is disabled or their CRD is
+// not installed, so that the operator runs in the clusters without the gang scheduler.
+func WatchPodGroups(mgr manager.Manager, c controller.Controller, s *runtime.Scheme, ownerType client.Object, jc *common.JobController) error {
+	if !jc.Config.EnableGangScheduling() {
+		return nil
+	}
+	gvk := common.PodGroupGVK(jc.Config.GangScheduling)
+	if installed, err := common.KindInstalled(mgr.GetRESTMapper(), gvk); !installed {
+		return err
+	}
+	if gvk == common.VolcanoPodGroupGVK {
+		return c.Watch(source.Kind[*volcanov1beta1.PodGroup](mgr.GetCache(), &volcanov1beta1.PodGroup{},
+			handler.TypedEnqueueRequestForOwner[*volcanov1beta1.PodGroup](mgr.GetScheme(), mgr.GetRESTMapper(), ownerType, handler.OnlyControllerOwner()),
+			OnDependentFuncs[*volcanov1beta1.PodGroup](s, jc.Expectations, jc)))
+	}
+	return c.Watch(source.Kind[*schedulerpluginsv1alpha1.PodGroup](mgr.GetCache(), &schedulerpluginsv1alpha1.PodGroup{},
+		handler.TypedEnqueueRequestForOwner[*schedulerpluginsv1alpha1.PodGroup](mgr.GetScheme(), mgr.GetRESTMapper(), ownerType, handler.OnlyControllerOwner()),
+		OnDependentFuncs[*schedulerpluginsv1alpha1.PodGroup](s, jc.Expectations, jc)))
+}
@@ -0,0 +1,135 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License
+
+package util
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// RequeueWorkQueue implements RateLimitingInterface on top of the work queue of a controller-runtime
+// controller: the job keys added by the common job controller are requeued as reconcile requests,
+// and the number of requeues is the one tracked by the rate limiter of the controller.
+// The queue is bound to the controller by watching its Source. Until the controller is started,
+// the added keys are dropped, since the controller reconciles all the jobs when it starts.
+type RequeueWorkQueue struct {
+	mu    sync.RWMutex
+	queue workqueue.RateLimitingInterface
+}
+
+// NewRequeueWorkQueue returns a RequeueWorkQueue which is not bound to a controller yet.
+func NewRequeueWorkQueue() *RequeueWorkQueue {
+	return &RequeueWorkQueue{}
+}
+
+// Source returns the source which binds the queue to the work queue of the controller watching it.
+func (q *RequeueWorkQueue) Source() source.Source {
+	return source.Func(func(_ context.Context, queue workqueue.RateLimitingInterface) error {
+		q.mu.Lock()
+		defer q.mu.Unlock()
+		q.queue = queue
+		return nil
+	})
+}
+
+// controllerQueue returns the work queue of the controller and the request of the job key.
+// It returns false if the queue is not bound yet or the item is not a valid job key.
+func (q *RequeueWorkQueue) controllerQueue(item interface{}) (workqueue.RateLimitingInterface, reconcile.Request, bool) {
+	q.mu.RLock()
+	queue := q.queue
+	q.mu.RUnlock()
+	key, ok := item.(string)
+	if queue == nil || !ok {
+		return nil, reconcile.Request{}, false
+	}
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return nil, reconcile.Request{}, false
+	}
+	return queue, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: namespace, Name: name}}, true
+}
+
+// Add requeues the job immediately.
+func (q *RequeueWorkQueue) Add(item interface{}) {
+	if queue, req, ok := q.controllerQueue(item); ok {
+		queue.Add(req)
+	}
+}
+
+// AddAfter requeues the job after the duration.
+func (q *RequeueWorkQueue) AddAfter(item interface{}, duration time.Duration) {
+	if queue, req, ok := q.controllerQueue(item); ok {
+		queue.AddAfter(req, duration)
+	}
+}
+
+// AddRateLimited requeues the job after the backoff of the rate limiter of the controller.
+func (q *RequeueWorkQueue) AddRateLimited(item interface{}) {
+	if queue, req, ok := q.controllerQueue(item); ok {
+		queue.AddRateLimited(req)
+	}
+}
+
+// Forget resets the backoff of the job.
+func (q *RequeueWorkQueue) Forget(item interface{}) {
+	if queue, req, ok := q.controllerQueue(item); ok {
+		queue.Forget(req)
+	}
+}
+
+// NumRequeues returns the number of times the job was requeued with a backoff,
+// i.e. the number of its last reconciles which failed.
+func (q *RequeueWorkQueue) NumRequeues(item interface{}) int {
+	if queue, req, ok := q.controllerQueue(item); ok {
+		return queue.NumRequeues(req)
+	}
+	return 0
+}
+
+// Len returns the number of requests in the work queue of the controller.
+func (q *RequeueWorkQueue) Len() int {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	if q.queue == nil {
+		return 0
+	}
+	return q.queue.Len()
+}
+
+// ShuttingDown returns true if the work queue of the controller is shutting down.
+func (q *RequeueWorkQueue) ShuttingDown() bool {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	return q.queue != nil && q.queue.ShuttingDown()
+}
+
+// Get is not supported: the requests are processed by the controller.
+func (q *RequeueWorkQueue) Get() (item interface{}, shutdown bool) { return nil, true }
+
+// Done is not supported: the requests are processed by the controller.
+func (q *RequeueWorkQueue) Done(item interface{}) {}
+
+// ShutDown is a no-op: the work queue is shut down by the controller.
+func (q *RequeueWorkQueue) ShutDown() {}
+
+// ShutDownWithDrain is a no-op: the work queue is shut down by the controller.
+func (q *RequeueWorkQueue) ShutDownWithDrain() {}
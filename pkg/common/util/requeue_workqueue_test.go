@@ -0,0 +1,80 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestRequeueWorkQueue(t *testing.T) {
+	q := NewRequeueWorkQueue()
+
+	// Keys added before the queue is bound are dropped.
+	q.Add("default/job")
+	if got := q.Len(); got != 0 {
+		t.Errorf("Unexpected length of the unbound queue: %d", got)
+	}
+
+	controllerQueue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	defer controllerQueue.ShutDown()
+	if err := q.Source().Start(context.Background(), controllerQueue); err != nil {
+		t.Fatalf("Failed to start the source: %v", err)
+	}
+
+	q.Add("default/job")
+	q.Add("invalid/key/job")
+	q.Add(1)
+	if got := controllerQueue.Len(); got != 1 {
+		t.Fatalf("Unexpected length of the controller queue: %d", got)
+	}
+	req, _ := controllerQueue.Get()
+	want := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "job"}}
+	if req != want {
+		t.Errorf("Unexpected request: got %v, want %v", req, want)
+	}
+	controllerQueue.Done(req)
+
+	q.AddRateLimited("default/job")
+	q.AddRateLimited("default/job")
+	if got := q.NumRequeues("default/job"); got != 2 {
+		t.Errorf("Unexpected number of requeues: %d", got)
+	}
+	q.Forget("default/job")
+	if got := q.NumRequeues("default/job"); got != 0 {
+		t.Errorf("Unexpected number of requeues after Forget: %d", got)
+	}
+
+	q.AddAfter("default/other", 10*time.Millisecond)
+	if err := waitForLen(controllerQueue, 2, time.Second); err != nil {
+		t.Error(err)
+	}
+}
+
+func waitForLen(queue workqueue.RateLimitingInterface, want int, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for queue.Len() < want {
+		if time.Now().After(deadline) {
+			return context.DeadlineExceeded
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	"github.com/kubeflow/training-operator/pkg/features"
+	commonutil "github.com/kubeflow/training-operator/pkg/util"
+)
+
+// PatchJobStatus updates the status of the job to jobStatus with a merge patch through the
+// status subresource. The patch is computed against the job in the cache of the client, since
+// the status of the given job may already be modified in memory, and nothing is sent to the API
+// server if the job already has the given status. On conflicts, the latest version of the job
+// is read with the reader and the patch is retried. The given job is not modified.
+func PatchJobStatus[T client.Object](ctx context.Context, c client.Client, reader client.Reader, job T,
+	statusOf func(T) *kubeflowv1.JobStatus, jobStatus *kubeflowv1.JobStatus) error {
+	current := job.DeepCopyObject().(T)
+	if err := c.Get(ctx, client.ObjectKeyFromObject(job), current); err != nil {
+		return err
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if equality.Semantic.DeepEqual(statusOf(current), jobStatus) {
+			return nil
+		}
+		patched := current.DeepCopyObject().(T)
+		*statusOf(patched) = *jobStatus.DeepCopy()
+		err := c.Status().Patch(ctx, patched, client.MergeFromWithOptions(current, client.MergeFromWithOptimisticLock{}))
+		if err == nil && features.Enabled(features.StatusDiffLogging) {
+			commonutil.LoggerForJob(current).Info("Updated the status of the job", "diff", DiffJobStatus(*statusOf(current), *jobStatus))
+		}
+		if apierrors.IsConflict(err) {
+			if getErr := reader.Get(ctx, client.ObjectKeyFromObject(current), current); getErr != nil {
+				return getErr
+			}
+		}
+		return err
+	})
+}
+
+// JobStatusDiff is the difference between two statuses of a job.
+type JobStatusDiff struct {
+	ConditionsAdded   []ConditionDiff                               `json:"conditionsAdded,omitempty"`
+	ConditionsChanged []ConditionDiff                               `json:"conditionsChanged,omitempty"`
+	ConditionsRemoved []kubeflowv1.JobConditionType                 `json:"conditionsRemoved,omitempty"`
+	Replicas          map[kubeflowv1.ReplicaType]ReplicaStatusDelta `json:"replicas,omitempty"`
+	// Times are the timestamps of the status which were set or changed, e.g. the completionTime.
+	Times map[string]string `json:"times,omitempty"`
+}
+
+// ConditionDiff is a condition added to the status of a job, or the change of a condition.
+// The Status and the Reason of a changed condition are formatted as <old> -> <new> when
+// they changed.
+type ConditionDiff struct {
+	Type    kubeflowv1.JobConditionType `json:"type"`
+	Status  string                      `json:"status,omitempty"`
+	Reason  string                      `json:"reason,omitempty"`
+	Message string                      `json:"message,omitempty"`
+}
+
+// ReplicaStatusDelta is the change of the counts of the pods of a replica type.
+type ReplicaStatusDelta struct {
+	Active          int32 `json:"active,omitempty"`
+	Succeeded       int32 `json:"succeeded,omitempty"`
+	Failed          int32 `json:"failed,omitempty"`
+	ToleratedFailed int32 `json:"toleratedFailed,omitempty"`
+}
+
+// DiffJobStatus returns the difference from the old to the new status of a job.
+func DiffJobStatus(oldStatus, newStatus kubeflowv1.JobStatus) JobStatusDiff {
+	var diff JobStatusDiff
+	oldConditions := map[kubeflowv1.JobConditionType]kubeflowv1.JobCondition{}
+	for _, condition := range oldStatus.Conditions {
+		oldConditions[condition.Type] = condition
+	}
+	newConditions := map[kubeflowv1.JobConditionType]bool{}
+	for _, condition := range newStatus.Conditions {
+		newConditions[condition.Type] = true
+		old, ok := oldConditions[condition.Type]
+		if !ok {
+			diff.ConditionsAdded = append(diff.ConditionsAdded, ConditionDiff{
+				Type:    condition.Type,
+				Status:  string(condition.Status),
+				Reason:  condition.Reason,
+				Message: condition.Message,
+			})
+			continue
+		}
+		if old.Status == condition.Status && old.Reason == condition.Reason && old.Message == condition.Message {
+			continue
+		}
+		changed := ConditionDiff{Type: condition.Type, Status: string(condition.Status), Reason: condition.Reason, Message: condition.Message}
+		if old.Status != condition.Status {
+			changed.Status = fmt.Sprintf("%s -> %s", old.Status, condition.Status)
+		}
+		if old.Reason != condition.Reason {
+			changed.Reason = fmt.Sprintf("%s -> %s", old.Reason, condition.Reason)
+		}
+		diff.ConditionsChanged = append(diff.ConditionsChanged, changed)
+	}
+	for _, condition := range oldStatus.Conditions {
+		if !newConditions[condition.Type] {
+			diff.ConditionsRemoved = append(diff.ConditionsRemoved, condition.Type)
+		}
+	}
+
+	replicaTypes := map[kubeflowv1.ReplicaType]bool{}
+	for rtype := range oldStatus.ReplicaStatuses {
+		replicaTypes[rtype] = true
+	}
+	for rtype := range newStatus.ReplicaStatuses {
+		replicaTypes[rtype] = true
+	}
+	for rtype := range replicaTypes {
+		old, cur := replicaStatusOrEmpty(oldStatus, rtype), replicaStatusOrEmpty(newStatus, rtype)
+		delta := ReplicaStatusDelta{
+			Active:          cur.Active - old.Active,
+			Succeeded:       cur.Succeeded - old.Succeeded,
+			Failed:          cur.Failed - old.Failed,
+			ToleratedFailed: cur.ToleratedFailed - old.ToleratedFailed,
+		}
+		if delta != (ReplicaStatusDelta{}) {
+			if diff.Replicas == nil {
+				diff.Replicas = map[kubeflowv1.ReplicaType]ReplicaStatusDelta{}
+			}
+			diff.Replicas[rtype] = delta
+		}
+	}
+
+	for name, times := range map[string][2]*metav1.Time{
+		"startTime":         {oldStatus.StartTime, newStatus.StartTime},
+		"completionTime":    {oldStatus.CompletionTime, newStatus.CompletionTime},
+		"lastReconcileTime": {oldStatus.LastReconcileTime, newStatus.LastReconcileTime},
+	} {
+		if times[1] != nil && !times[1].Equal(times[0]) {
+			if diff.Times == nil {
+				diff.Times = map[string]string{}
+			}
+			diff.Times[name] = times[1].UTC().Format(time.RFC3339)
+		}
+	}
+	return diff
+}
+
+func replicaStatusOrEmpty(status kubeflowv1.JobStatus, rtype kubeflowv1.ReplicaType) kubeflowv1.ReplicaStatus {
+	if replicaStatus := status.ReplicaStatuses[rtype]; replicaStatus != nil {
+		return *replicaStatus
+	}
+	return kubeflowv1.ReplicaStatus{}
+}
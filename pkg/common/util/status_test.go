@@ -0,0 +1,170 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+)
+
+func tfJobStatus(job *kubeflowv1.TFJob) *kubeflowv1.JobStatus {
+	return &job.Status
+}
+
+func TestPatchJobStatus(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := kubeflowv1.AddToScheme(scheme); err != nil {
+		t.Fatalf("Failed to add the kubeflow scheme: %v", err)
+	}
+	running := kubeflowv1.JobStatus{
+		Conditions: []kubeflowv1.JobCondition{newJobCondition(kubeflowv1.JobRunning)},
+		ReplicaStatuses: map[kubeflowv1.ReplicaType]*kubeflowv1.ReplicaStatus{
+			kubeflowv1.TFJobReplicaTypeWorker: {Active: 2},
+		},
+	}
+
+	cases := map[string]struct {
+		jobStatus      kubeflowv1.JobStatus
+		inMemoryStatus bool
+		conflicts      int
+		wantPatches    int
+		wantJobStatus  kubeflowv1.JobStatus
+	}{
+		"status is patched": {
+			jobStatus:     running,
+			wantPatches:   1,
+			wantJobStatus: running,
+		},
+		"status is not patched when it is unchanged": {
+			jobStatus:   kubeflowv1.JobStatus{},
+			wantPatches: 0,
+		},
+		"status is patched when the job is already modified in memory": {
+			jobStatus:      running,
+			inMemoryStatus: true,
+			wantPatches:    1,
+			wantJobStatus:  running,
+		},
+		"status patch is retried on conflict": {
+			jobStatus:     running,
+			conflicts:     2,
+			wantPatches:   3,
+			wantJobStatus: running,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			job := &kubeflowv1.TFJob{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+			}
+			patches := 0
+			c := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(job).
+				WithStatusSubresource(job).
+				WithInterceptorFuncs(interceptor.Funcs{
+					SubResourcePatch: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+						patches++
+						if patches <= tc.conflicts {
+							return apierrors.NewConflict(schema.GroupResource{Resource: "tfjobs"}, obj.GetName(), nil)
+						}
+						return c.SubResource(subResourceName).Patch(ctx, obj, patch, opts...)
+					},
+				}).
+				Build()
+			if err := c.Get(context.Background(), client.ObjectKeyFromObject(job), job); err != nil {
+				t.Fatalf("Failed to get the job: %v", err)
+			}
+			if tc.inMemoryStatus {
+				job.Status = *tc.jobStatus.DeepCopy()
+			}
+
+			if err := PatchJobStatus(context.Background(), c, c, job, tfJobStatus, &tc.jobStatus); err != nil {
+				t.Fatalf("Unexpected error from PatchJobStatus: %v", err)
+			}
+			if patches != tc.wantPatches {
+				t.Errorf("Unexpected number of patches, want: %d, got: %d", tc.wantPatches, patches)
+			}
+			got := &kubeflowv1.TFJob{}
+			if err := c.Get(context.Background(), client.ObjectKeyFromObject(job), got); err != nil {
+				t.Fatalf("Failed to get the job: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantJobStatus, got.Status, cmpopts.EquateEmpty()); len(diff) != 0 {
+				t.Errorf("Unexpected job status (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDiffJobStatus(t *testing.T) {
+	completionTime := metav1.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	oldStatus := kubeflowv1.JobStatus{
+		Conditions: []kubeflowv1.JobCondition{
+			newJobCondition(kubeflowv1.JobCreated),
+			{Type: kubeflowv1.JobRunning, Status: corev1.ConditionTrue, Reason: "TFJobRunning"},
+			newJobCondition(kubeflowv1.JobRestarting),
+		},
+		ReplicaStatuses: map[kubeflowv1.ReplicaType]*kubeflowv1.ReplicaStatus{
+			kubeflowv1.TFJobReplicaTypeWorker: {Active: 2},
+			kubeflowv1.TFJobReplicaTypePS:     {Active: 1},
+		},
+	}
+	newStatus := kubeflowv1.JobStatus{
+		Conditions: []kubeflowv1.JobCondition{
+			newJobCondition(kubeflowv1.JobCreated),
+			{Type: kubeflowv1.JobRunning, Status: corev1.ConditionFalse, Reason: "TFJobFailed"},
+			{Type: kubeflowv1.JobFailed, Status: corev1.ConditionTrue, Reason: "TFJobFailed", Message: "failed"},
+		},
+		ReplicaStatuses: map[kubeflowv1.ReplicaType]*kubeflowv1.ReplicaStatus{
+			kubeflowv1.TFJobReplicaTypeWorker: {Active: 1, Failed: 1},
+			kubeflowv1.TFJobReplicaTypePS:     {Active: 1},
+		},
+		CompletionTime: &completionTime,
+	}
+
+	want := JobStatusDiff{
+		ConditionsAdded: []ConditionDiff{
+			{Type: kubeflowv1.JobFailed, Status: "True", Reason: "TFJobFailed", Message: "failed"},
+		},
+		ConditionsChanged: []ConditionDiff{
+			{Type: kubeflowv1.JobRunning, Status: "True -> False", Reason: "TFJobRunning -> TFJobFailed"},
+		},
+		ConditionsRemoved: []kubeflowv1.JobConditionType{kubeflowv1.JobRestarting},
+		Replicas: map[kubeflowv1.ReplicaType]ReplicaStatusDelta{
+			kubeflowv1.TFJobReplicaTypeWorker: {Active: -1, Failed: 1},
+		},
+		Times: map[string]string{"completionTime": "2024-01-01T00:00:00Z"},
+	}
+	if diff := cmp.Diff(want, DiffJobStatus(oldStatus, newStatus)); len(diff) != 0 {
+		t.Errorf("Unexpected diff of the job status (-want,+got):\n%s", diff)
+	}
+	if diff := cmp.Diff(JobStatusDiff{}, DiffJobStatus(newStatus, newStatus)); len(diff) != 0 {
+		t.Errorf("Unexpected diff of an unchanged job status (-want,+got):\n%s", diff)
+	}
+}
@@ -39,6 +39,18 @@ func ConvertServiceList(list []corev1.Service) []*corev1.Service {
 	return ret
 }
 
+// ConvertPodList convert pod list to pod point list
+func ConvertPodList(list []corev1.Pod) []*corev1.Pod {
+	if list == nil {
+		return nil
+	}
+	ret := make([]*corev1.Pod, 0, len(list))
+	for i := range list {
+		ret = append(ret, &list[i])
+	}
+	return ret
+}
+
 // JobControlledPodList filter pod list owned by the job.
 func JobControlledPodList(list []corev1.Pod, job metav1.Object) []*corev1.Pod {
 	if list == nil {
@@ -63,11 +75,10 @@ func GetReplicaTypes(specs map[kubeflowv1.ReplicaType]*kubeflowv1.ReplicaSpec) [
 }
 
 // DurationUntilExpireTime returns the duration until job needs to be cleaned up, or -1 if it's infinite.
-func DurationUntilExpireTime(runPolicy *kubeflowv1.RunPolicy, jobStatus kubeflowv1.JobStatus) (time.Duration, error) {
+func DurationUntilExpireTime(runPolicy *kubeflowv1.RunPolicy, jobStatus kubeflowv1.JobStatus, clock *commonutil.Clock) (time.Duration, error) {
 	if !commonutil.IsSucceeded(jobStatus) && !commonutil.IsFailed(jobStatus) {
 		return -1, nil
 	}
-	currentTime := time.Now()
 	ttl := runPolicy.TTLSecondsAfterFinished
 	if ttl == nil {
 		return -1, nil
@@ -76,11 +87,10 @@ func DurationUntilExpireTime(runPolicy *kubeflowv1.RunPolicy, jobStatus kubeflow
 	if jobStatus.CompletionTime == nil {
 		return -1, fmt.Errorf("job completion time is nil, cannot cleanup")
 	}
-	finishTime := jobStatus.CompletionTime
-	expireTime := finishTime.Add(duration)
-	if currentTime.After(expireTime) {
+	elapsed := clock.Since(jobStatus.CompletionTime.Time)
+	if elapsed > duration {
 		return 0, nil
 	} else {
-		return expireTime.Sub(currentTime), nil
+		return duration - elapsed, nil
 	}
 }
@@ -20,9 +20,11 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clocktesting "k8s.io/utils/clock/testing"
 	"k8s.io/utils/ptr"
 
 	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	commonutil "github.com/kubeflow/training-operator/pkg/util"
 )
 
 func TestDurationUntilExpireTime(t *testing.T) {
@@ -102,7 +104,7 @@ func TestDurationUntilExpireTime(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := DurationUntilExpireTime(tt.runPolicy, tt.jobStatus)
+			got, err := DurationUntilExpireTime(tt.runPolicy, tt.jobStatus, nil)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("DurationUntilExpireTime() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -116,6 +118,53 @@ func TestDurationUntilExpireTime(t *testing.T) {
 	}
 }
 
+func TestDurationUntilExpireTimeWithClockSkew(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := commonutil.NewClock(clocktesting.NewFakePassiveClock(now), 2*time.Second)
+	runPolicy := &kubeflowv1.RunPolicy{TTLSecondsAfterFinished: ptr.To[int32](10)}
+	tests := []struct {
+		name           string
+		completionTime time.Time
+		want           time.Duration
+	}{
+		{
+			name:           "completed in the past",
+			completionTime: now.Add(-4 * time.Second),
+			want:           6 * time.Second,
+		},
+		{
+			name:           "completed after the expire time",
+			completionTime: now.Add(-11 * time.Second),
+			want:           0,
+		},
+		{
+			name:           "completed in the future within the skew tolerance",
+			completionTime: now.Add(time.Second),
+			want:           10 * time.Second,
+		},
+		{
+			name:           "completed in the future beyond the skew tolerance",
+			completionTime: now.Add(3 * time.Second),
+			want:           13 * time.Second,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jobStatus := kubeflowv1.JobStatus{
+				Conditions:     []kubeflowv1.JobCondition{newJobCondition(kubeflowv1.JobSucceeded)},
+				CompletionTime: &metav1.Time{Time: tt.completionTime},
+			}
+			got, err := DurationUntilExpireTime(runPolicy, jobStatus, clock)
+			if err != nil {
+				t.Fatalf("DurationUntilExpireTime() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("DurationUntilExpireTime() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func newJobCondition(t kubeflowv1.JobConditionType) kubeflowv1.JobCondition {
 	return kubeflowv1.JobCondition{
 		Type:   t,
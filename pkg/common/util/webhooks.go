@@ -1,17 +1,54 @@
 package util
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
 	v1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	"github.com/kubeflow/training-operator/pkg/core"
 
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	admissionv1 "k8s.io/api/admission/v1"
 	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
 var supportedJobControllers = sets.New(
 	v1.MultiKueueController,
 	v1.KubeflowJobsController)
 
+var supportedSuccessPolicies = sets.New(
+	v1.SuccessPolicyDefault,
+	v1.SuccessPolicyChiefOrMaster,
+	v1.SuccessPolicyAllWorkers)
+
+var supportedFailurePolicyActions = sets.New(
+	v1.FailurePolicyActionRestart,
+	v1.FailurePolicyActionIgnore,
+	v1.FailurePolicyActionFailJob)
+
+var supportedPodFailureReasons = sets.New(
+	v1.PodFailureReasonOOMKilled,
+	v1.PodFailureReasonEvicted,
+	v1.PodFailureReasonImagePullBackOff,
+	v1.PodFailureReasonNodeNotReady,
+	v1.PodFailureReasonError)
+
 func ValidateRunPolicy(runPolicy *v1.RunPolicy) field.ErrorList {
 	errs := field.ErrorList{}
 	if runPolicy.ManagedBy != nil {
@@ -21,6 +58,70 @@ func ValidateRunPolicy(runPolicy *v1.RunPolicy) field.ErrorList {
 			errs = append(errs, field.NotSupported(fieldPath, manager, supportedJobControllers.UnsortedList()))
 		}
 	}
+	errs = append(errs, validateFailurePolicy(runPolicy.FailurePolicy)...)
+	if runPolicy.CheckpointPolicy != nil && len(runPolicy.CheckpointPolicy.Command) == 0 {
+		fieldPath := field.NewPath("spec", "runPolicy", "checkpointPolicy", "command")
+		errs = append(errs, field.Required(fieldPath, "must specify the checkpoint command"))
+	}
+	if runPolicy.StartPolicy != nil {
+		errs = append(errs, validateWaveSize(&runPolicy.StartPolicy.WaveSize)...)
+	}
+	if runPolicy.TopologyPolicy != nil && runPolicy.TopologyPolicy.TopologyKey != "" {
+		fieldPath := field.NewPath("spec", "runPolicy", "topologyPolicy", "topologyKey")
+		for _, msg := range validation.IsQualifiedName(runPolicy.TopologyPolicy.TopologyKey) {
+			errs = append(errs, field.Invalid(fieldPath, runPolicy.TopologyPolicy.TopologyKey, msg))
+		}
+	}
+	if runPolicy.SchedulingPolicy != nil && runPolicy.SchedulingPolicy.SameTopology != "" {
+		fieldPath := field.NewPath("spec", "runPolicy", "schedulingPolicy", "sameTopology")
+		for _, msg := range validation.IsQualifiedName(runPolicy.SchedulingPolicy.SameTopology) {
+			errs = append(errs, field.Invalid(fieldPath, runPolicy.SchedulingPolicy.SameTopology, msg))
+		}
+	}
+	return errs
+}
+
+func validateWaveSize(waveSize *intstr.IntOrString) field.ErrorList {
+	errs := field.ErrorList{}
+	fieldPath := field.NewPath("spec", "runPolicy", "startPolicy", "waveSize")
+	if waveSize.Type == intstr.Int {
+		if waveSize.IntVal < 1 {
+			errs = append(errs, field.Invalid(fieldPath, waveSize.IntVal, "must be greater than 0"))
+		}
+		return errs
+	}
+	percent, err := strconv.Atoi(strings.TrimSuffix(waveSize.StrVal, "%"))
+	if !strings.HasSuffix(waveSize.StrVal, "%") || err != nil || percent < 1 || percent > 100 {
+		errs = append(errs, field.Invalid(fieldPath, waveSize.StrVal, "must be a percentage between 1% and 100%"))
+	}
+	return errs
+}
+
+func validateFailurePolicy(failurePolicy *v1.FailurePolicy) field.ErrorList {
+	errs := field.ErrorList{}
+	if failurePolicy == nil {
+		return errs
+	}
+	rulesPath := field.NewPath("spec", "runPolicy", "failurePolicy", "rules")
+	for i, rule := range failurePolicy.Rules {
+		rulePath := rulesPath.Index(i)
+		if !supportedFailurePolicyActions.Has(rule.Action) {
+			errs = append(errs, field.NotSupported(rulePath.Child("action"), rule.Action, supportedFailurePolicyActions.UnsortedList()))
+		}
+		if len(rule.ExitCodes) == 0 && len(rule.FailureReasons) == 0 {
+			errs = append(errs, field.Required(rulePath.Child("exitCodes"), "must specify at least one exit code or failure reason"))
+		}
+		for j, exitCode := range rule.ExitCodes {
+			if exitCode == 0 {
+				errs = append(errs, field.Invalid(rulePath.Child("exitCodes").Index(j), exitCode, "must not be 0, the exit code of a succeeded container"))
+			}
+		}
+		for j, reason := range rule.FailureReasons {
+			if !supportedPodFailureReasons.Has(reason) {
+				errs = append(errs, field.NotSupported(rulePath.Child("failureReasons").Index(j), reason, supportedPodFailureReasons.UnsortedList()))
+			}
+		}
+	}
 	return errs
 }
 
@@ -30,3 +131,279 @@ func ValidateRunPolicyUpdate(oldRunPolicy, newRunPolicy *v1.RunPolicy) field.Err
 	fieldPath := field.NewPath("spec", "runPolicy", "managedBy")
 	return apivalidation.ValidateImmutableField(newManager, oldManager, fieldPath)
 }
+
+func ValidateSuccessPolicy(successPolicy *v1.SuccessPolicy) field.ErrorList {
+	errs := field.ErrorList{}
+	if successPolicy != nil && !supportedSuccessPolicies.Has(*successPolicy) {
+		fieldPath := field.NewPath("spec", "successPolicy")
+		errs = append(errs, field.NotSupported(fieldPath, *successPolicy, supportedSuccessPolicies.UnsortedList()))
+	}
+	return errs
+}
+
+// ValidateRunIDAnnotation checks that the RunIDAnnotation of a job, if any, can be set as the
+// RunIDLabel of its pods.
+func ValidateRunIDAnnotation(annotations map[string]string) field.ErrorList {
+	errs := field.ErrorList{}
+	runID, ok := annotations[v1.RunIDAnnotation]
+	if !ok {
+		return errs
+	}
+	fieldPath := field.NewPath("metadata", "annotations").Key(v1.RunIDAnnotation)
+	if runID == "" {
+		return append(errs, field.Required(fieldPath, "must not be empty"))
+	}
+	for _, msg := range validation.IsValidLabelValue(runID) {
+		errs = append(errs, field.Invalid(fieldPath, runID, msg))
+	}
+	return errs
+}
+
+// ValidateMetricsCollectorAnnotations checks that the MetricsCollectorAnnotation of a job, if any,
+// comes with the annotations required to collect and push its metrics.
+func ValidateMetricsCollectorAnnotations(job metav1.Object) field.ErrorList {
+	errs := field.ErrorList{}
+	annotations := job.GetAnnotations()
+	collector, ok := annotations[v1.MetricsCollectorAnnotation]
+	if !ok {
+		return errs
+	}
+	annotationsPath := field.NewPath("metadata", "annotations")
+	switch collector {
+	case v1.MetricsCollectorStdOut:
+	case v1.MetricsCollectorFile:
+		if file := annotations[v1.MetricsFileAnnotation]; !path.IsAbs(file) {
+			errs = append(errs, field.Invalid(annotationsPath.Key(v1.MetricsFileAnnotation), file,
+				fmt.Sprintf("must be an absolute path with the %s metrics collector", v1.MetricsCollectorFile)))
+		}
+	default:
+		errs = append(errs, field.NotSupported(annotationsPath.Key(v1.MetricsCollectorAnnotation), collector,
+			[]string{v1.MetricsCollectorStdOut, v1.MetricsCollectorFile}))
+	}
+	if strings.Trim(annotations[v1.MetricsNamesAnnotation], ", ") == "" {
+		errs = append(errs, field.Required(annotationsPath.Key(v1.MetricsNamesAnnotation), "must list the names of the collected metrics"))
+	}
+	switch destination := annotations[v1.MetricsDestinationAnnotation]; destination {
+	case v1.MetricsDestinationMLflow:
+		uri := annotations[v1.MLflowTrackingURIAnnotation]
+		if !strings.HasPrefix(uri, "http://") && !strings.HasPrefix(uri, "https://") {
+			errs = append(errs, field.Invalid(annotationsPath.Key(v1.MLflowTrackingURIAnnotation), uri, "must be an http or https URL"))
+		}
+	case v1.MetricsDestinationKatib:
+		if job.GetLabels()[v1.KatibTrialLabel] == "" {
+			errs = append(errs, field.Required(field.NewPath("metadata", "labels").Key(v1.KatibTrialLabel),
+				fmt.Sprintf("must be set to the Katib trial of the job with the %s metrics destination", v1.MetricsDestinationKatib)))
+		}
+	default:
+		errs = append(errs, field.NotSupported(annotationsPath.Key(v1.MetricsDestinationAnnotation), destination,
+			[]string{v1.MetricsDestinationMLflow, v1.MetricsDestinationKatib}))
+	}
+	return errs
+}
+
+// ValidateToleratedFailures checks that toleratedFailures is only set for the replica
+// types whose failed pods can be tolerated.
+func ValidateToleratedFailures(replicaSpecsPath *field.Path, rSpecs map[v1.ReplicaType]*v1.ReplicaSpec, toleratingTypes ...v1.ReplicaType) field.ErrorList {
+	errs := field.ErrorList{}
+	for rType, rSpec := range rSpecs {
+		if rSpec != nil && rSpec.ToleratedFailures != nil && !slices.Contains(toleratingTypes, rType) {
+			fieldPath := replicaSpecsPath.Key(string(rType)).Child("toleratedFailures")
+			errs = append(errs, field.Forbidden(fieldPath, fmt.Sprintf("must not be set for the %s replica type", rType)))
+		}
+	}
+	return errs
+}
+
+// ValidateResourceProfiles checks that the resource profiles of the replica specs are profiles
+// of the operator.
+func ValidateResourceProfiles(replicaSpecsPath *field.Path, rSpecs map[v1.ReplicaType]*v1.ReplicaSpec) field.ErrorList {
+	errs := field.ErrorList{}
+	for rType, rSpec := range rSpecs {
+		if rSpec == nil || rSpec.ResourceProfile == "" {
+			continue
+		}
+		if _, err := core.ResourceProfile(rSpec.ResourceProfile); err != nil {
+			errs = append(errs, field.Invalid(replicaSpecsPath.Key(string(rType)).Child("resourceProfile"), rSpec.ResourceProfile, err.Error()))
+		}
+	}
+	return errs
+}
+
+// ValidateDatasets checks that the names of the datasets are unique, that their mount paths
+// don't overlap, since a dataset would hide the one mounted below it, and that the sizeLimit is
+// only set for the downloaded datasets.
+func ValidateDatasets(datasets []v1.DatasetSpec) field.ErrorList {
+	errs := field.ErrorList{}
+	datasetsPath := field.NewPath("spec").Child("datasets")
+	names := sets.New[string]()
+	for i, dataset := range datasets {
+		datasetPath := datasetsPath.Index(i)
+		if names.Has(dataset.Name) {
+			errs = append(errs, field.Duplicate(datasetPath.Child("name"), dataset.Name))
+		}
+		names.Insert(dataset.Name)
+		for _, other := range datasets[:i] {
+			if mountPathsOverlap(dataset.MountPath, other.MountPath) {
+				errs = append(errs, field.Invalid(datasetPath.Child("mountPath"), dataset.MountPath,
+					fmt.Sprintf("must not overlap the mountPath %s of the dataset %s", other.MountPath, other.Name)))
+			}
+		}
+		if dataset.SizeLimit == nil {
+			continue
+		}
+		if strings.HasPrefix(dataset.Source, "pvc://") {
+			errs = append(errs, field.Forbidden(datasetPath.Child("sizeLimit"), "must not be set for a pvc:// dataset"))
+		} else if dataset.SizeLimit.Sign() <= 0 {
+			errs = append(errs, field.Invalid(datasetPath.Child("sizeLimit"), dataset.SizeLimit.String(), "must be greater than zero"))
+		}
+	}
+	return errs
+}
+
+// mountPathsOverlap returns whether one of the mount paths is the other or one of its parents.
+func mountPathsOverlap(a, b string) bool {
+	a, b = path.Clean(a), path.Clean(b)
+	return a == b || strings.HasPrefix(a, strings.TrimSuffix(b, "/")+"/") || strings.HasPrefix(b, strings.TrimSuffix(a, "/")+"/")
+}
+
+// ConvertLegacyReplicaTypes renames the legacy replica types of the replica specs to their supported
+// equivalents, and returns an admission warning for every renamed replica type. A legacy replica type
+// is left as is if its equivalent is also set, so that the validation reports the conflict.
+func ConvertLegacyReplicaTypes(replicaSpecsPath *field.Path, rSpecs map[v1.ReplicaType]*v1.ReplicaSpec, legacyTypes map[v1.ReplicaType]v1.ReplicaType) admission.Warnings {
+	var warnings admission.Warnings
+	for legacyType, rType := range legacyTypes {
+		rSpec, ok := rSpecs[legacyType]
+		if !ok {
+			continue
+		}
+		if _, ok := rSpecs[rType]; ok {
+			continue
+		}
+		rSpecs[rType] = rSpec
+		delete(rSpecs, legacyType)
+		warnings = append(warnings, fmt.Sprintf("%s: the %s replica type is deprecated, converted to %s",
+			replicaSpecsPath.Key(string(legacyType)), legacyType, rType))
+	}
+	slices.Sort(warnings)
+	return warnings
+}
+
+// jobDefaulterHandler is a mutating admission handler which defaults the jobs on creation, e.g.
+// converts their legacy replica types or merges the defaults of their TrainingJobClass.
+type jobDefaulterHandler struct {
+	decoder admission.Decoder
+	newJob  func() runtime.Object
+	mutate  func(ctx context.Context, job runtime.Object) (admission.Warnings, error)
+}
+
+// NewJobDefaulterHandler returns a mutating admission handler which decodes the created jobs with
+// newJob and defaults them with mutate. The handler returns the warnings of mutate to the client,
+// and denies the job if mutate returns a field error, e.g. for an unknown TrainingJobClass.
+func NewJobDefaulterHandler(decoder admission.Decoder, newJob func() runtime.Object,
+	mutate func(ctx context.Context, job runtime.Object) (admission.Warnings, error)) admission.Handler {
+	return &jobDefaulterHandler{decoder: decoder, newJob: newJob, mutate: mutate}
+}
+
+func (h *jobDefaulterHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	// The existing jobs are not defaulted again, e.g. their replica types are not converted as their
+	// pods and services are named after them.
+	if req.Operation != admissionv1.Create {
+		return admission.Allowed("")
+	}
+	job := h.newJob()
+	if err := h.decoder.Decode(req, job); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	original, err := json.Marshal(job)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	warnings, err := h.mutate(ctx, job)
+	if err != nil {
+		var fieldErr *field.Error
+		if errors.As(err, &fieldErr) {
+			return admission.Denied(fieldErr.Error())
+		}
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	converted, err := json.Marshal(job)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if bytes.Equal(original, converted) {
+		return admission.Allowed("").WithWarnings(warnings...)
+	}
+	patched, err := applyConversion(req.Object.Raw, original, converted)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	resp := admission.PatchResponseFromRaw(req.Object.Raw, patched)
+	resp.Warnings = warnings
+	return resp
+}
+
+// applyConversion applies the changes between original and converted, the job decoded before and
+// after the conversion, to raw, the job as it was submitted. The job is not patched with its typed
+// value, which lacks the fields unknown to the operator, e.g. the fields set by a newer SDK. The
+// converted replica specs are moved to their new replica types, so that their unknown fields are
+// also kept.
+func applyConversion(raw, original, converted []byte) ([]byte, error) {
+	diff := admission.PatchResponseFromRaw(original, converted)
+	if !diff.Allowed {
+		return nil, errors.New(diff.Result.Message)
+	}
+	var originalDoc interface{}
+	if err := json.Unmarshal(original, &originalDoc); err != nil {
+		return nil, err
+	}
+	var moves, others []map[string]interface{}
+	moved := sets.New[string]()
+	for _, op := range diff.Patches {
+		if op.Operation != "add" {
+			continue
+		}
+		for _, from := range diff.Patches {
+			if from.Operation == "remove" && !moved.Has(from.Path) &&
+				reflect.DeepEqual(valueAtPointer(originalDoc, from.Path), op.Value) {
+				moves = append(moves, map[string]interface{}{"op": "move", "from": from.Path, "path": op.Path})
+				moved.Insert(from.Path, op.Path)
+				break
+			}
+		}
+	}
+	for _, op := range diff.Patches {
+		if !moved.Has(op.Path) {
+			others = append(others, map[string]interface{}{"op": op.Operation, "path": op.Path, "value": op.Value})
+		}
+	}
+	patch, err := json.Marshal(append(moves, others...))
+	if err != nil {
+		return nil, err
+	}
+	decoded, err := jsonpatch.DecodePatch(patch)
+	if err != nil {
+		return nil, err
+	}
+	return decoded.Apply(raw)
+}
+
+// valueAtPointer returns the value of the JSON document doc at the JSON pointer, or nil if the
+// document has no value there.
+func valueAtPointer(doc interface{}, pointer string) interface{} {
+	for _, token := range strings.Split(pointer, "/")[1:] {
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+		switch value := doc.(type) {
+		case map[string]interface{}:
+			doc = value[token]
+		case []interface{}:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(value) {
+				return nil
+			}
+			doc = value[i]
+		default:
+			return nil
+		}
+	}
+	return doc
+}
@@ -0,0 +1,160 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+)
+
+func TestJobDefaulterHandlerPreservesUnknownFields(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := kubeflowv1.AddToScheme(scheme); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	handler := NewJobDefaulterHandler(admission.NewDecoder(scheme),
+		func() runtime.Object { return &kubeflowv1.TFJob{} },
+		func(_ context.Context, job runtime.Object) (admission.Warnings, error) {
+			return ConvertLegacyReplicaTypes(field.NewPath("spec", "tfReplicaSpecs"), job.(*kubeflowv1.TFJob).Spec.TFReplicaSpecs,
+				map[kubeflowv1.ReplicaType]kubeflowv1.ReplicaType{kubeflowv1.TFJobReplicaTypeMaster: kubeflowv1.TFJobReplicaTypeChief}), nil
+		})
+	// The job holds fields unknown to the operator, as if it was submitted by a newer SDK.
+	raw := []byte(`{
+		"apiVersion": "kubeflow.org/v1",
+		"kind": "TFJob",
+		"metadata": {"name": "test", "namespace": "default"},
+		"spec": {
+			"newSpecField": "kept",
+			"runPolicy": {"newRunPolicyField": 1},
+			"tfReplicaSpecs": {
+				"Master": {"replicas": 1, "newReplicaField": true, "template": {"spec": {"containers": [{"name": "tensorflow"}]}}},
+				"Worker": {"replicas": 2, "template": {"spec": {"containers": [{"name": "tensorflow"}]}}}
+			}
+		}
+	}`)
+	want := `{
+		"apiVersion": "kubeflow.org/v1",
+		"kind": "TFJob",
+		"metadata": {"name": "test", "namespace": "default"},
+		"spec": {
+			"newSpecField": "kept",
+			"runPolicy": {"newRunPolicyField": 1},
+			"tfReplicaSpecs": {
+				"Chief": {"replicas": 1, "newReplicaField": true, "template": {"spec": {"containers": [{"name": "tensorflow"}]}}},
+				"Worker": {"replicas": 2, "template": {"spec": {"containers": [{"name": "tensorflow"}]}}}
+			}
+		}
+	}`
+
+	resp := handler.Handle(context.Background(), admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+		Operation: admissionv1.Create,
+		Object:    runtime.RawExtension{Raw: raw},
+	}})
+	if !resp.Allowed || len(resp.Warnings) != 1 {
+		t.Fatalf("Expected the job to be allowed with a warning, got: %v", resp)
+	}
+	patch, err := json.Marshal(resp.Patches)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	decoded, err := jsonpatch.DecodePatch(patch)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	patched, err := decoded.Apply(raw)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var gotDoc, wantDoc interface{}
+	if err := json.Unmarshal(patched, &gotDoc); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := json.Unmarshal([]byte(want), &wantDoc); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(wantDoc, gotDoc); len(diff) != 0 {
+		t.Errorf("Unexpected patched job (-want,+got):\n%s", diff)
+	}
+}
+
+func TestValidateDatasets(t *testing.T) {
+	datasetsPath := field.NewPath("spec").Child("datasets")
+	cases := map[string]struct {
+		datasets []kubeflowv1.DatasetSpec
+		wantErrs field.ErrorList
+	}{
+		"valid datasets": {
+			datasets: []kubeflowv1.DatasetSpec{
+				{Name: "mnist", Source: "s3://datasets/mnist", MountPath: "/data/mnist", SizeLimit: ptr.To(resource.MustParse("10Gi"))},
+				{Name: "vocab", Source: "https://example.com/vocab.txt", MountPath: "/data/mnist-vocab"},
+			},
+			wantErrs: field.ErrorList{},
+		},
+		"duplicate names": {
+			datasets: []kubeflowv1.DatasetSpec{
+				{Name: "mnist", Source: "s3://datasets/mnist", MountPath: "/data/train"},
+				{Name: "mnist", Source: "s3://datasets/mnist", MountPath: "/data/test"},
+			},
+			wantErrs: field.ErrorList{field.Duplicate(datasetsPath.Index(1).Child("name"), "mnist")},
+		},
+		"same mount paths": {
+			datasets: []kubeflowv1.DatasetSpec{
+				{Name: "mnist", Source: "s3://datasets/mnist", MountPath: "/data/"},
+				{Name: "vocab", Source: "https://example.com/vocab.txt", MountPath: "/data"},
+			},
+			wantErrs: field.ErrorList{field.Invalid(datasetsPath.Index(1).Child("mountPath"), "/data", "")},
+		},
+		"nested mount paths": {
+			datasets: []kubeflowv1.DatasetSpec{
+				{Name: "mnist", Source: "s3://datasets/mnist", MountPath: "/data/mnist"},
+				{Name: "all", Source: "pvc://datasets", MountPath: "/"},
+			},
+			wantErrs: field.ErrorList{field.Invalid(datasetsPath.Index(1).Child("mountPath"), "/", "")},
+		},
+		"size limit of a pvc dataset": {
+			datasets: []kubeflowv1.DatasetSpec{
+				{Name: "imagenet", Source: "pvc://datasets/imagenet", MountPath: "/data", SizeLimit: ptr.To(resource.MustParse("10Gi"))},
+			},
+			wantErrs: field.ErrorList{field.Forbidden(datasetsPath.Index(0).Child("sizeLimit"), "")},
+		},
+		"zero size limit": {
+			datasets: []kubeflowv1.DatasetSpec{
+				{Name: "mnist", Source: "s3://datasets/mnist", MountPath: "/data", SizeLimit: ptr.To(resource.MustParse("0"))},
+			},
+			wantErrs: field.ErrorList{field.Invalid(datasetsPath.Index(0).Child("sizeLimit"), "0", "")},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ValidateDatasets(tc.datasets)
+			if diff := cmp.Diff(tc.wantErrs, got, cmpopts.IgnoreFields(field.Error{}, "Detail")); len(diff) != 0 {
+				t.Errorf("Unexpected errors (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
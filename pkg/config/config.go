@@ -14,12 +14,53 @@
 
 package config
 
+import "time"
+
 // Config is the global configuration for the training operator.
 var Config struct {
 	PyTorchInitContainerTemplateFile string
 	PyTorchInitContainerImage        string
 	MPIKubectlDeliveryImage          string
+	TensorBoardImage                 string
+	DatasetInitializerImage          string
+	MetricsCollectorImage            string
+	KatibMetricsCollectorImage       string
+	KatibDBManagerAddress            string
 	PyTorchInitContainerMaxTries     int
+	EventDeduplicationWindow         time.Duration
+	WorkQueueBaseDelay               time.Duration
+	WorkQueueMaxDelay                time.Duration
+	WorkQueueQPS                     float64
+	WorkQueueBurst                   int
+	ClockSkewTolerance               time.Duration
+	StrictOwnership                  bool
+	MaxConcurrentRestarts            int
+	InjectNetworkTuning              bool
+	NetworkTuningEnvDir              string
+	ServiceMeshMode                  string
+	AcceleratorDefaultsFile          string
+	ResourceProfilesFile             string
+	NodeFailureTimeout               time.Duration
+	DefaultImagePullSecrets          []string
+	DefaultPriorityClasses           map[string]string
+	ShutdownDrainTimeout             time.Duration
+	ControllerIdentity               string
+	SkipUnpinnedJobs                 bool
+	RestrictedSecurityDefaults       bool
+	PodSecurityConfigFile            string
+	PodSecurityUsername              string
+	DisableOrphanPodAdoption         bool
+	OrphanPodAdoptionQPS             float64
+	OrphanPodAdoptionBurst           int
+	OrphanPodAdoptionBatchSize       int
+	RunningJobResyncPeriod           time.Duration
+	StorageVersionMigrationQPS       float64
+	CreateNetworkPolicies            bool
+	NetworkPolicyAllowedCIDRs        []string
+	CreatePodDisruptionBudgets       bool
+	NodePreemptionTaints             []string
+	EnablePriorityPreemption         bool
+	JobQueuesFile                    string
 }
 
 const (
@@ -33,4 +74,61 @@ const (
 	PyTorchInitContainerMaxTriesDefault = 100
 	// MPIKubectlDeliveryImageDefault is the default image for launcher pod in MPIJob init container.
 	MPIKubectlDeliveryImageDefault = "kubeflow/kubectl-delivery:latest"
+	// TensorBoardImageDefault is the default image of the TensorBoards requested by the jobs.
+	TensorBoardImageDefault = "tensorflow/tensorflow:2.16.1"
+	// DatasetInitializerImageDefault is the default image of the init containers downloading the datasets of the jobs.
+	DatasetInitializerImageDefault = "rclone/rclone:1.67"
+	// MetricsCollectorImageDefault is the default image of the metrics collectors pushing the metrics of the jobs to MLflow.
+	MetricsCollectorImageDefault = "curlimages/curl:8.8.0"
+	// KatibMetricsCollectorImageDefault is the default image of the metrics collectors pushing the metrics of the jobs to Katib.
+	KatibMetricsCollectorImageDefault = "docker.io/kubeflowkatib/file-metrics-collector:v0.17.0"
+	// KatibDBManagerAddressDefault is the default address of the Katib DB manager the metrics of the jobs are pushed to.
+	KatibDBManagerAddressDefault = "katib-db-manager.kubeflow:6789"
+	// EventDeduplicationWindowDefault is the default window within which the identical events of a job are dropped.
+	EventDeduplicationWindowDefault = 5 * time.Minute
+	// WorkQueueBaseDelayDefault is the default delay of the first retry of a failed reconcile of a job.
+	WorkQueueBaseDelayDefault = 5 * time.Millisecond
+	// WorkQueueMaxDelayDefault is the default maximum delay of the retries of the failed reconciles of a job.
+	WorkQueueMaxDelayDefault = 1000 * time.Second
+	// WorkQueueQPSDefault is the default overall rate of the reconciles of the jobs of a kind.
+	WorkQueueQPSDefault = 10
+	// WorkQueueBurstDefault is the default overall burst of the reconciles of the jobs of a kind.
+	WorkQueueBurstDefault = 100
+	// ClockSkewToleranceDefault is the default tolerated skew between the timestamps of a job stamped
+	// by the API server and by the controller.
+	ClockSkewToleranceDefault = 5 * time.Second
+	// NetworkTuningEnvDirDefault is the default directory where the network tuning ConfigMap
+	// with the NCCL and Gloo env vars injected into the pods is mounted.
+	NetworkTuningEnvDirDefault = "/etc/network-tuning"
+	// AcceleratorDefaultsFileDefault is the default file of the node selectors and the tolerations
+	// added to the pods requesting an accelerator resource.
+	AcceleratorDefaultsFileDefault = "/etc/accelerator-defaults/accelerators.yaml"
+	// ResourceProfilesFileDefault is the default file of the resource profiles of the operator
+	// set on the replicas by their resourceProfile.
+	ResourceProfilesFileDefault = "/etc/resource-profiles/profiles.yaml"
+	// NodeFailureTimeoutDefault is the default time after which the pods of a node which is
+	// not Ready are force-deleted and recreated.
+	NodeFailureTimeoutDefault = 5 * time.Minute
+	// ShutdownDrainTimeoutDefault is the default time given to the reconciles in flight and the jobs
+	// still queued to complete their status writes when the operator shuts down.
+	ShutdownDrainTimeoutDefault = 30 * time.Second
+	// OrphanPodAdoptionQPSDefault is the default overall rate of the adoptions of the orphan pods
+	// by the jobs, per second.
+	OrphanPodAdoptionQPSDefault = 20
+	// OrphanPodAdoptionBurstDefault is the default overall burst of the adoptions of the orphan pods.
+	OrphanPodAdoptionBurstDefault = 100
+	// OrphanPodAdoptionBatchSizeDefault is the default maximum number of orphan pods adopted by a
+	// job per reconcile.
+	OrphanPodAdoptionBatchSizeDefault = 100
+	// RunningJobResyncPeriodDefault is the default period after which a running job is reconciled
+	// again without any event of its pods.
+	RunningJobResyncPeriodDefault = 5 * time.Minute
+	// StorageVersionMigrationQPSDefault is the default rate of the rewrites of the jobs stored in
+	// another version than the storage version of their CRD, per second.
+	StorageVersionMigrationQPSDefault = 10
+	// NodePreemptionTaintsDefault is the default comma-separated list of the keys of the taints set
+	// on the nodes about to be reclaimed by the termination handlers of the spot instances and by
+	// the autoscalers.
+	NodePreemptionTaintsDefault = "cloud.google.com/impending-node-termination,aws-node-termination-handler/spot-itn," +
+		"karpenter.sh/disrupted,ToBeDeletedByClusterAutoscaler"
 )
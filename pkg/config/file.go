@@ -0,0 +1,217 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License
+
+package config
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// FileAPIVersion is the apiVersion of the configuration file of the training operator.
+	FileAPIVersion = "config.kubeflow.org/v1alpha1"
+	// FileKind is the kind of the configuration file of the training operator.
+	FileKind = "TrainingOperatorConfiguration"
+)
+
+// File is the configuration file of the training operator, set with --config. Each of its fields
+// stands for a flag of the same meaning, e.g.
+//
+//	apiVersion: config.kubeflow.org/v1alpha1
+//	kind: TrainingOperatorConfiguration
+//	controller:
+//	  threads: 4
+//	  threadsPerKind:
+//	    pytorchjob: 8
+//	gangSchedulerName: volcano
+//	images:
+//	  mpiKubectlDelivery: kubeflow/kubectl-delivery:v1.8.0
+//	metrics:
+//	  bindAddress: :8080
+//	webhook:
+//	  port: 9443
+//
+// The fields left unset keep the values of the flags.
+type File struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	// ReloadOnChange restarts the operator when the file changes, so that the Deployment of the
+	// operator starts again with the new configuration.
+	ReloadOnChange bool `json:"reloadOnChange,omitempty"`
+
+	Controller        *ControllerFile     `json:"controller,omitempty"`
+	GangSchedulerName *string             `json:"gangSchedulerName,omitempty"`
+	Images            *ImagesFile         `json:"images,omitempty"`
+	Metrics           *BindAddressFile    `json:"metrics,omitempty"`
+	Health            *BindAddressFile    `json:"health,omitempty"`
+	LeaderElection    *LeaderElectionFile `json:"leaderElection,omitempty"`
+	Webhook           *WebhookFile        `json:"webhook,omitempty"`
+	FeatureGates      map[string]bool     `json:"featureGates,omitempty"`
+}
+
+// ControllerFile is the configuration of the controllers of the jobs.
+type ControllerFile struct {
+	// EnabledSchemes are the kinds of the jobs reconciled by the operator, as --enable-scheme.
+	EnabledSchemes []string `json:"enabledSchemes,omitempty"`
+	// Namespace is the namespace of the jobs reconciled by the operator, as --namespace.
+	Namespace *string `json:"namespace,omitempty"`
+	// Threads is the number of worker threads of the controllers, as --controller-threads.
+	Threads *int `json:"threads,omitempty"`
+	// ThreadsPerKind is the number of worker threads of the controllers by kind, as
+	// --controller-threads-per-kind.
+	ThreadsPerKind map[string]int `json:"threadsPerKind,omitempty"`
+}
+
+// ImagesFile is the configuration of the default images of the containers added to the jobs.
+type ImagesFile struct {
+	PyTorchInitContainer *string `json:"pytorchInitContainer,omitempty"`
+	MPIKubectlDelivery   *string `json:"mpiKubectlDelivery,omitempty"`
+}
+
+// BindAddressFile is the configuration of an endpoint of the operator.
+type BindAddressFile struct {
+	BindAddress *string `json:"bindAddress,omitempty"`
+}
+
+// LeaderElectionFile is the configuration of the leader election of the operator.
+type LeaderElectionFile struct {
+	LeaderElect  *bool   `json:"leaderElect,omitempty"`
+	ResourceName *string `json:"resourceName,omitempty"`
+}
+
+// WebhookFile is the configuration of the webhook server of the operator.
+type WebhookFile struct {
+	Port        *int    `json:"port,omitempty"`
+	ServiceName *string `json:"serviceName,omitempty"`
+	SecretName  *string `json:"secretName,omitempty"`
+}
+
+// flagValue is the value of a flag set by a field of the configuration file.
+type flagValue struct {
+	name  string
+	value string
+}
+
+// LoadFile reads the configuration file of the training operator.
+func LoadFile(file string) (*File, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	return ParseFile(b)
+}
+
+// ParseFile parses the content of the configuration file of the training operator. The unknown
+// fields are rejected, so that a typo doesn't silently leave a flag at its default.
+func ParseFile(b []byte) (*File, error) {
+	f := &File{}
+	if err := yaml.UnmarshalStrict(b, f); err != nil {
+		return nil, err
+	}
+	if f.APIVersion != FileAPIVersion || f.Kind != FileKind {
+		return nil, fmt.Errorf("unsupported configuration %s, %s: want %s, %s", f.APIVersion, f.Kind, FileAPIVersion, FileKind)
+	}
+	return f, nil
+}
+
+// flagValues returns the values of the flags set by the fields of the configuration file, in a
+// stable order.
+func (f *File) flagValues() []flagValue {
+	var values []flagValue
+	addString := func(name string, value *string) {
+		if value != nil {
+			values = append(values, flagValue{name: name, value: *value})
+		}
+	}
+	addInt := func(name string, value *int) {
+		if value != nil {
+			values = append(values, flagValue{name: name, value: strconv.Itoa(*value)})
+		}
+	}
+	if c := f.Controller; c != nil {
+		for _, scheme := range c.EnabledSchemes {
+			values = append(values, flagValue{name: "enable-scheme", value: scheme})
+		}
+		addString("namespace", c.Namespace)
+		addInt("controller-threads", c.Threads)
+		for _, kind := range sortedKeys(c.ThreadsPerKind) {
+			values = append(values, flagValue{name: "controller-threads-per-kind", value: fmt.Sprintf("%s=%d", kind, c.ThreadsPerKind[kind])})
+		}
+	}
+	addString("gang-scheduler-name", f.GangSchedulerName)
+	if i := f.Images; i != nil {
+		addString("pytorch-init-container-image", i.PyTorchInitContainer)
+		addString("mpi-kubectl-delivery-image", i.MPIKubectlDelivery)
+	}
+	if m := f.Metrics; m != nil {
+		addString("metrics-bind-address", m.BindAddress)
+	}
+	if h := f.Health; h != nil {
+		addString("health-probe-bind-address", h.BindAddress)
+	}
+	if l := f.LeaderElection; l != nil {
+		if l.LeaderElect != nil {
+			values = append(values, flagValue{name: "leader-elect", value: strconv.FormatBool(*l.LeaderElect)})
+		}
+		addString("leader-election-id", l.ResourceName)
+	}
+	if w := f.Webhook; w != nil {
+		addInt("webhook-server-port", w.Port)
+		addString("webhook-service-name", w.ServiceName)
+		addString("webhook-secret-name", w.SecretName)
+	}
+	if len(f.FeatureGates) != 0 {
+		gates := make([]string, 0, len(f.FeatureGates))
+		for _, feature := range sortedKeys(f.FeatureGates) {
+			gates = append(gates, fmt.Sprintf("%s=%t", feature, f.FeatureGates[feature]))
+		}
+		values = append(values, flagValue{name: "feature-gates", value: strings.Join(gates, ",")})
+	}
+	return values
+}
+
+// Apply sets the flags of fs from the fields of the configuration file. The flags set on the
+// command line take precedence over the file, so that a single flag can be overridden without
+// editing the file.
+func (f *File) Apply(fs *flag.FlagSet) error {
+	setOnCommandLine := map[string]bool{}
+	fs.Visit(func(fl *flag.Flag) {
+		setOnCommandLine[fl.Name] = true
+	})
+	for _, v := range f.flagValues() {
+		if setOnCommandLine[v.name] {
+			continue
+		}
+		if err := fs.Set(v.name, v.value); err != nil {
+			return fmt.Errorf("invalid configuration of --%s: %w", v.name, err)
+		}
+	}
+	return nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
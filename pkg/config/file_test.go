@@ -0,0 +1,159 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License
+
+package config
+
+import (
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testFile = `apiVersion: config.kubeflow.org/v1alpha1
+kind: TrainingOperatorConfiguration
+controller:
+  threads: 4
+  threadsPerKind:
+    tfjob: 2
+    pytorchjob: 8
+gangSchedulerName: volcano
+images:
+  mpiKubectlDelivery: kubeflow/kubectl-delivery:v1.8.0
+metrics:
+  bindAddress: :9090
+webhook:
+  port: 9444
+`
+
+func TestParseFile(t *testing.T) {
+	cases := map[string]struct {
+		content string
+		wantErr string
+	}{
+		"valid file": {
+			content: testFile,
+		},
+		"unknown field": {
+			content: testFile + "gangScheduler: volcano\n",
+			wantErr: "unknown field",
+		},
+		"unsupported version": {
+			content: strings.Replace(testFile, "v1alpha1", "v1", 1),
+			wantErr: "unsupported configuration",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseFile([]byte(tc.content))
+			if tc.wantErr == "" && err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if tc.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tc.wantErr)) {
+				t.Fatalf("Expected an error containing %q, got: %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+// threadsPerKind is a flag.Value recording the values it is set to.
+type threadsPerKind []string
+
+func (v *threadsPerKind) String() string { return strings.Join(*v, ",") }
+
+func (v *threadsPerKind) Set(value string) error {
+	*v = append(*v, value)
+	return nil
+}
+
+func TestApplyFile(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	threads := fs.Int("controller-threads", 1, "")
+	perKind := &threadsPerKind{}
+	fs.Var(perKind, "controller-threads-per-kind", "")
+	gangSchedulerName := fs.String("gang-scheduler-name", "", "")
+	kubectlDeliveryImage := fs.String("mpi-kubectl-delivery-image", MPIKubectlDeliveryImageDefault, "")
+	metricsAddr := fs.String("metrics-bind-address", ":8080", "")
+	webhookPort := fs.Int("webhook-server-port", 9443, "")
+	if err := fs.Parse([]string{"--gang-scheduler-name=scheduler-plugins"}); err != nil {
+		t.Fatal(err)
+	}
+	f, err := ParseFile([]byte(testFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Apply(fs); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if *threads != 4 {
+		t.Errorf("Unexpected controller threads, want: 4, got: %d", *threads)
+	}
+	if got := perKind.String(); got != "pytorchjob=8,tfjob=2" {
+		t.Errorf("Unexpected controller threads per kind: %s", got)
+	}
+	if *gangSchedulerName != "scheduler-plugins" {
+		t.Errorf("Expected the flag set on the command line to take precedence, got: %s", *gangSchedulerName)
+	}
+	if *kubectlDeliveryImage != "kubeflow/kubectl-delivery:v1.8.0" {
+		t.Errorf("Unexpected kubectl-delivery image: %s", *kubectlDeliveryImage)
+	}
+	if *metricsAddr != ":9090" || *webhookPort != 9444 {
+		t.Errorf("Unexpected metrics address or webhook port: %s, %d", *metricsAddr, *webhookPort)
+	}
+
+	f.Controller.Threads = nil
+	f.FeatureGates = map[string]bool{"Unknown": true}
+	if err := f.Apply(fs); err == nil || !strings.Contains(err.Error(), "--feature-gates") {
+		t.Errorf("Expected an error for the feature gates without flag, got: %v", err)
+	}
+}
+
+func TestFileWatcher(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(file, []byte(testFile), 0644); err != nil {
+		t.Fatal(err)
+	}
+	changed := make(chan struct{})
+	w, err := NewFileWatcher(file, func() { close(changed) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	if w.changed(ctx) {
+		t.Errorf("Expected the unchanged file not to be reported")
+	}
+	if err := os.WriteFile(file, []byte(testFile+"reloadOnChange: [\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if w.changed(ctx) {
+		t.Errorf("Expected the invalid file not to be reported")
+	}
+
+	w.Interval = 10 * time.Millisecond
+	go func() {
+		_ = w.Start(ctx)
+	}()
+	if err := os.WriteFile(file, []byte(testFile+"reloadOnChange: true\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-changed:
+	case <-time.After(5 * time.Second):
+		t.Errorf("Expected the change of the file to be reported")
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License
+
+package config
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// FileWatchIntervalDefault is the default interval at which the configuration file is checked
+// for changes.
+const FileWatchIntervalDefault = 10 * time.Second
+
+// FileWatcher calls OnChange once the content of the configuration file differs from the content
+// it was loaded with. The file is polled rather than watched, as the files of a mounted ConfigMap
+// are updated by swapping a symlink of their directory.
+//
+// The configuration is read by the controllers without synchronization, so that a change is
+// applied by restarting the operator rather than in place.
+type FileWatcher struct {
+	File     string
+	Interval time.Duration
+	OnChange func()
+
+	loaded []byte
+}
+
+// NewFileWatcher returns a FileWatcher of the configuration file with its current content.
+func NewFileWatcher(file string, onChange func()) (*FileWatcher, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	return &FileWatcher{File: file, Interval: FileWatchIntervalDefault, OnChange: onChange, loaded: b}, nil
+}
+
+func (w *FileWatcher) Start(ctx context.Context) error {
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if w.changed(ctx) {
+				w.OnChange()
+				return nil
+			}
+		}
+	}
+}
+
+// changed checks if the content of the file differs from the loaded content. A file which can't
+// be read, e.g. while its ConfigMap is updated, is checked again on the next tick.
+func (w *FileWatcher) changed(ctx context.Context) bool {
+	b, err := os.ReadFile(w.File)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "Unable to read the configuration file", "file", w.File)
+		return false
+	}
+	if bytes.Equal(b, w.loaded) {
+		return false
+	}
+	if _, err := ParseFile(b); err != nil {
+		// The invalid content is logged once, and the operator restarts once the file is fixed.
+		log.FromContext(ctx).Error(err, "Ignoring the invalid configuration file", "file", w.File)
+		w.loaded = b
+		return false
+	}
+	log.FromContext(ctx).Info("The configuration file changed, restarting", "file", w.File)
+	return true
+}
+
+// NeedLeaderElection returns false, so that all the replicas of the operator restart with the new
+// configuration.
+func (w *FileWatcher) NeedLeaderElection() bool {
+	return false
+}
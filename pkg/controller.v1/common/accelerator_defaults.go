@@ -0,0 +1,137 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kubeflow/training-operator/pkg/config"
+)
+
+// AcceleratorDefault is the node selector and the tolerations added to the pods requesting
+// an accelerator resource, e.g. to schedule them on the tainted nodes of a GPU node pool.
+type AcceleratorDefault struct {
+	NodeSelector map[string]string   `json:"nodeSelector,omitempty"`
+	Tolerations  []corev1.Toleration `json:"tolerations,omitempty"`
+}
+
+// AcceleratorDefaults maps the accelerator resources, e.g. nvidia.com/gpu, to their defaults.
+type AcceleratorDefaults map[corev1.ResourceName]AcceleratorDefault
+
+// LoadAcceleratorDefaults reads the accelerator defaults of the operator from file, a YAML map
+// of the resource names to their defaults, e.g.
+//
+//	nvidia.com/gpu:
+//	  nodeSelector:
+//	    node-pool: gpu
+//	  tolerations:
+//	  - key: nvidia.com/gpu
+//	    operator: Exists
+//	    effect: NoSchedule
+//
+// The file is read on each call, so that its updates apply to the pods created afterwards
+// without a restart of the operator. No defaults are returned if the file doesn't exist.
+func LoadAcceleratorDefaults(file string) (AcceleratorDefaults, error) {
+	if file == "" {
+		return nil, nil
+	}
+	b, err := os.ReadFile(file)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defaults := AcceleratorDefaults{}
+	if err := yaml.UnmarshalStrict(b, &defaults); err != nil {
+		return nil, err
+	}
+	return defaults, nil
+}
+
+// SetAcceleratorDefaults adds the node selector and the tolerations of the accelerators requested
+// by the containers of the podTemplate to it. The node selector of the podTemplate takes
+// precedence, and the tolerations it already has are not duplicated. An invalid accelerator
+// defaults file is logged and ignored, so that it doesn't block the creation of the pods.
+func SetAcceleratorDefaults(podTemplate *corev1.PodTemplateSpec) {
+	defaults, err := LoadAcceleratorDefaults(config.Config.AcceleratorDefaultsFile)
+	if err != nil {
+		log.Log.Error(err, "Ignoring the invalid accelerator defaults", "file", config.Config.AcceleratorDefaultsFile)
+		return
+	}
+	defaults.apply(podTemplate)
+}
+
+func (defaults AcceleratorDefaults) apply(podTemplate *corev1.PodTemplateSpec) {
+	for _, name := range requestedResources(&podTemplate.Spec) {
+		d, ok := defaults[name]
+		if !ok {
+			continue
+		}
+		for key, value := range d.NodeSelector {
+			if _, ok := podTemplate.Spec.NodeSelector[key]; ok {
+				continue
+			}
+			if podTemplate.Spec.NodeSelector == nil {
+				podTemplate.Spec.NodeSelector = map[string]string{}
+			}
+			podTemplate.Spec.NodeSelector[key] = value
+		}
+		for _, toleration := range d.Tolerations {
+			if !hasToleration(podTemplate.Spec.Tolerations, toleration) {
+				podTemplate.Spec.Tolerations = append(podTemplate.Spec.Tolerations, toleration)
+			}
+		}
+	}
+}
+
+// requestedResources returns the names of the resources requested or limited by the containers
+// and the init containers of the pod, sorted so that the tolerations are added in a stable order.
+func requestedResources(spec *corev1.PodSpec) []corev1.ResourceName {
+	seen := map[corev1.ResourceName]bool{}
+	var names []corev1.ResourceName
+	add := func(list corev1.ResourceList) {
+		for name, quantity := range list {
+			if !seen[name] && !quantity.IsZero() {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	for _, containers := range [][]corev1.Container{spec.InitContainers, spec.Containers} {
+		for i := range containers {
+			add(containers[i].Resources.Requests)
+			add(containers[i].Resources.Limits)
+		}
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+	return names
+}
+
+func hasToleration(tolerations []corev1.Toleration, toleration corev1.Toleration) bool {
+	for i := range tolerations {
+		if tolerations[i].MatchToleration(&toleration) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,118 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/kubeflow/training-operator/pkg/config"
+)
+
+const testAcceleratorDefaults = `
+nvidia.com/gpu:
+  nodeSelector:
+    node-pool: gpu
+  tolerations:
+  - key: nvidia.com/gpu
+    operator: Exists
+    effect: NoSchedule
+google.com/tpu:
+  tolerations:
+  - key: google.com/tpu
+    operator: Exists
+`
+
+func TestSetAcceleratorDefaults(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "accelerators.yaml")
+	if err := os.WriteFile(file, []byte(testAcceleratorDefaults), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	defer func(file string) { config.Config.AcceleratorDefaultsFile = file }(config.Config.AcceleratorDefaultsFile)
+	config.Config.AcceleratorDefaultsFile = file
+
+	gpuToleration := corev1.Toleration{Key: "nvidia.com/gpu", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule}
+	gpu := corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("1")}
+	cases := map[string]struct {
+		spec     corev1.PodSpec
+		wantSpec corev1.PodSpec
+	}{
+		"no accelerator": {
+			spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "test"}}},
+			wantSpec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "test"}},
+			},
+		},
+		"gpu limit": {
+			spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "test", Resources: corev1.ResourceRequirements{Limits: gpu}}}},
+			wantSpec: corev1.PodSpec{
+				Containers:   []corev1.Container{{Name: "test", Resources: corev1.ResourceRequirements{Limits: gpu}}},
+				NodeSelector: map[string]string{"node-pool": "gpu"},
+				Tolerations:  []corev1.Toleration{gpuToleration},
+			},
+		},
+		"node selector and tolerations of the template take precedence": {
+			spec: corev1.PodSpec{
+				Containers:   []corev1.Container{{Name: "test", Resources: corev1.ResourceRequirements{Requests: gpu}}},
+				NodeSelector: map[string]string{"node-pool": "a100"},
+				Tolerations:  []corev1.Toleration{{Operator: corev1.TolerationOpExists}},
+			},
+			wantSpec: corev1.PodSpec{
+				Containers:   []corev1.Container{{Name: "test", Resources: corev1.ResourceRequirements{Requests: gpu}}},
+				NodeSelector: map[string]string{"node-pool": "a100"},
+				Tolerations:  []corev1.Toleration{{Operator: corev1.TolerationOpExists}, gpuToleration},
+			},
+		},
+		"gpu already tolerated": {
+			spec: corev1.PodSpec{
+				Containers:  []corev1.Container{{Name: "test", Resources: corev1.ResourceRequirements{Limits: gpu}}},
+				Tolerations: []corev1.Toleration{gpuToleration},
+			},
+			wantSpec: corev1.PodSpec{
+				Containers:   []corev1.Container{{Name: "test", Resources: corev1.ResourceRequirements{Limits: gpu}}},
+				NodeSelector: map[string]string{"node-pool": "gpu"},
+				Tolerations:  []corev1.Toleration{gpuToleration},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			podTemplate := &corev1.PodTemplateSpec{Spec: tc.spec}
+			SetAcceleratorDefaults(podTemplate)
+			if diff := cmp.Diff(tc.wantSpec, podTemplate.Spec); len(diff) != 0 {
+				t.Errorf("Unexpected pod spec (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLoadAcceleratorDefaults(t *testing.T) {
+	dir := t.TempDir()
+	if defaults, err := LoadAcceleratorDefaults(filepath.Join(dir, "missing.yaml")); err != nil || len(defaults) != 0 {
+		t.Errorf("Unexpected defaults without file: %v, %v", defaults, err)
+	}
+	invalid := filepath.Join(dir, "invalid.yaml")
+	if err := os.WriteFile(invalid, []byte("nvidia.com/gpu:\n  nodeSelectors: {}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadAcceleratorDefaults(invalid); err == nil {
+		t.Errorf("Expected an error for an unknown field")
+	}
+}
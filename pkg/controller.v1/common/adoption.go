@@ -0,0 +1,164 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"golang.org/x/time/rate"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kubeflow/training-operator/pkg/config"
+	"github.com/kubeflow/training-operator/pkg/controller.v1/control"
+	commonutil "github.com/kubeflow/training-operator/pkg/util"
+)
+
+// adoptionRequeuePeriodMin is the minimum period after which a job whose orphan pods are not
+// all adopted claims them again.
+const adoptionRequeuePeriodMin = time.Second
+
+// AdoptionLimiter limits the rate of the adoptions of the orphan pods by the jobs of all kinds,
+// so that the thousands of orphan pods left by an upgrade or a migration of the operator are not
+// patched all at once. It is shared by all the job controllers and is safe for concurrent use.
+// A nil AdoptionLimiter is unlimited.
+type AdoptionLimiter struct {
+	limiter *rate.Limiter
+	// batchSize is the maximum number of orphan pods a job adopts per reconcile.
+	batchSize int
+}
+
+// NewAdoptionLimiter returns an AdoptionLimiter letting the jobs adopt qps orphan pods per second
+// with the burst, at most batchSize pods per reconcile of a job.
+func NewAdoptionLimiter(qps float64, burst, batchSize int) *AdoptionLimiter {
+	return &AdoptionLimiter{limiter: rate.NewLimiter(rate.Limit(qps), burst), batchSize: batchSize}
+}
+
+// allow returns whether a job may adopt one more orphan pod at now, given the pods it has already
+// adopted in the current reconcile.
+func (l *AdoptionLimiter) allow(adopted int, now time.Time) bool {
+	if l == nil {
+		return true
+	}
+	if l.batchSize > 0 && adopted >= l.batchSize {
+		return false
+	}
+	return l.limiter.AllowN(now, 1)
+}
+
+// requeueAfter returns the time after now after which the limiter lets a job adopt a batch of pods again.
+func (l *AdoptionLimiter) requeueAfter(now time.Time) time.Duration {
+	n := l.batchSize
+	if n <= 0 || n > l.limiter.Burst() {
+		n = l.limiter.Burst()
+	}
+	reservation := l.limiter.ReserveN(now, n)
+	defer reservation.CancelAt(now)
+	if !reservation.OK() {
+		return adoptionRequeuePeriodMin
+	}
+	return max(reservation.DelayFrom(now), adoptionRequeuePeriodMin)
+}
+
+// AdoptionDeferredError is returned by ClaimPods when some of the orphan pods of a job are left
+// to be adopted by a later reconcile, so that the job doesn't create their replacements.
+type AdoptionDeferredError struct {
+	Deferred     int
+	RequeueAfter time.Duration
+}
+
+func (e *AdoptionDeferredError) Error() string {
+	return fmt.Sprintf("the adoption of %d orphan pod(s) is deferred for %v", e.Deferred, e.RequeueAfter)
+}
+
+// OrphanPodAdoptionDisabled returns whether the jobs leave the orphan pods matching their labels
+// as is, e.g. during a migration of the operator.
+func OrphanPodAdoptionDisabled() bool {
+	return config.Config.DisableOrphanPodAdoption
+}
+
+// ClaimPods returns the pods of the job among pods, the pods matching the labels of the job: the
+// pods it controls, and the orphan pods it adopts. The adoptions are limited by the
+// AdoptionLimiter, and the orphan pods beyond its limit are left to a later reconcile of the
+// job, which is reported with an AdoptionDeferredError along with the claimed pods. Each
+// reconcile adopting pods emits an event with the progress of the adoption.
+func (jc *JobController) ClaimPods(job metav1.Object, pods []*corev1.Pod) ([]*corev1.Pod, error) {
+	selector := labels.SelectorFromSet(jc.GenLabels(job.GetName()))
+	// If any adoptions are attempted, we should first recheck for deletion
+	// with an uncached quorum read sometime after listing Pods (see #42639).
+	canAdoptFunc := RecheckDeletionTimestamp(func() (metav1.Object, error) {
+		jc.RecordAPICall(job, APICallGet)
+		fresh, err := jc.Controller.GetJobFromAPIClient(job.GetNamespace(), job.GetName())
+		if err != nil {
+			return nil, err
+		}
+		if fresh.GetUID() != job.GetUID() {
+			return nil, fmt.Errorf("original Job %v/%v is gone: got uid %v, wanted %v", job.GetNamespace(), job.GetName(), fresh.GetUID(), job.GetUID())
+		}
+		return fresh, nil
+	})
+
+	adopting, deferred := 0, 0
+	adoptionFilter := func(pod *corev1.Pod) bool {
+		if metav1.GetControllerOf(pod) != nil || pod.DeletionTimestamp != nil {
+			return true
+		}
+		if OrphanPodAdoptionDisabled() {
+			return false
+		}
+		if !jc.AdoptionLimiter.allow(adopting, jc.Clock.Now()) {
+			deferred++
+			return false
+		}
+		adopting++
+		return true
+	}
+	cm := control.NewPodControllerRefManager(jc.PodControl, job, selector, jc.Controller.GetAPIGroupVersionKind(), canAdoptFunc)
+	claimed, err := cm.ClaimPods(pods, append(PodClaimFilters(), adoptionFilter)...)
+	if adopting == 0 && deferred == 0 {
+		return claimed, err
+	}
+
+	// The claimed pods are the cached pods, which have no controller yet if they were adopted.
+	adopted := 0
+	for _, pod := range claimed {
+		if metav1.GetControllerOf(pod) == nil {
+			adopted++
+		}
+	}
+	jobKind := jc.Controller.GetAPIGroupVersionKind().Kind
+	if runtimeObject, ok := job.(runtime.Object); ok && adopted != 0 {
+		jc.Recorder.Eventf(runtimeObject, corev1.EventTypeNormal, commonutil.AdoptedOrphanPodsReason,
+			"%s %s adopted %d orphan pod(s), %d left to adopt.", jobKind, job.GetName(), adopted, adopting-adopted+deferred)
+	}
+	if err != nil || deferred == 0 {
+		return claimed, err
+	}
+	return claimed, &AdoptionDeferredError{Deferred: deferred, RequeueAfter: jc.AdoptionLimiter.requeueAfter(jc.Clock.Now())}
+}
+
+// IgnoreAdoptionDeferred returns nil if err is an AdoptionDeferredError, for the callers which
+// only read the pods of a job once they were claimed by its reconcile.
+func IgnoreAdoptionDeferred(err error) error {
+	var deferred *AdoptionDeferredError
+	if errors.As(err, &deferred) {
+		return nil
+	}
+	return err
+}
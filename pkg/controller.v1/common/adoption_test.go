@@ -0,0 +1,133 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/kubeflow/training-operator/pkg/config"
+	"github.com/kubeflow/training-operator/pkg/controller.v1/control"
+	testjobv1 "github.com/kubeflow/training-operator/test_job/apis/test_job/v1"
+)
+
+// adoptionTestController is a testJobController getting its job from the API server.
+type adoptionTestController struct {
+	testJobController
+	job *testjobv1.TestJob
+}
+
+func (c *adoptionTestController) GetJobFromAPIClient(string, string) (metav1.Object, error) {
+	return c.job, nil
+}
+
+func TestClaimPods(t *testing.T) {
+	job := &testjobv1.TestJob{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: metav1.NamespaceDefault, UID: "uid"}}
+	newPods := func(jc *JobController) []*corev1.Pod {
+		owned := newPod("test-worker-0", corev1.PodRunning)
+		owned.Labels = jc.GenLabels(job.Name)
+		owned.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(job, testjobv1.SchemeGroupVersionKind)}
+		pods := []*corev1.Pod{owned}
+		for i := 1; i <= 5; i++ {
+			orphan := newPod(fmt.Sprintf("test-worker-%d", i), corev1.PodRunning)
+			orphan.Labels = jc.GenLabels(job.Name)
+			pods = append(pods, orphan)
+		}
+		return pods
+	}
+
+	cases := map[string]struct {
+		limiter      *AdoptionLimiter
+		disabled     bool
+		wantClaimed  int
+		wantDeferred int
+		wantEvent    string
+	}{
+		"unlimited adoption": {
+			wantClaimed: 6,
+			wantEvent:   "Normal AdoptedOrphanPods TestJob test adopted 5 orphan pod(s), 0 left to adopt.",
+		},
+		"adoption in batches": {
+			limiter:      NewAdoptionLimiter(1000, 100, 2),
+			wantClaimed:  3,
+			wantDeferred: 3,
+			wantEvent:    "Normal AdoptedOrphanPods TestJob test adopted 2 orphan pod(s), 3 left to adopt.",
+		},
+		"rate limited adoption": {
+			limiter:      NewAdoptionLimiter(0.001, 1, 0),
+			wantClaimed:  2,
+			wantDeferred: 4,
+			wantEvent:    "Normal AdoptedOrphanPods TestJob test adopted 1 orphan pod(s), 4 left to adopt.",
+		},
+		"adoption disabled": {
+			limiter:     NewAdoptionLimiter(1000, 100, 2),
+			disabled:    true,
+			wantClaimed: 1,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			defer func(disabled bool) { config.Config.DisableOrphanPodAdoption = disabled }(config.Config.DisableOrphanPodAdoption)
+			config.Config.DisableOrphanPodAdoption = tc.disabled
+			podControl := &control.FakePodControl{}
+			recorder := record.NewFakeRecorder(10)
+			jc := &JobController{
+				Controller: &adoptionTestController{
+					testJobController: testJobController{frameworkController{framework: "test-framework"}},
+					job:               job,
+				},
+				PodControl:           podControl,
+				Recorder:             recorder,
+				JobControllerOptions: JobControllerOptions{AdoptionLimiter: tc.limiter},
+			}
+
+			claimed, err := jc.ClaimPods(job, newPods(jc))
+			var deferred *AdoptionDeferredError
+			if tc.wantDeferred == 0 && err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if tc.wantDeferred != 0 {
+				if !errors.As(err, &deferred) || deferred.Deferred != tc.wantDeferred {
+					t.Fatalf("Expected the adoption of %d pods to be deferred, got: %v", tc.wantDeferred, err)
+				}
+				if deferred.RequeueAfter < time.Second {
+					t.Errorf("Unexpected requeue period: %v", deferred.RequeueAfter)
+				}
+			}
+			if len(claimed) != tc.wantClaimed {
+				t.Errorf("Unexpected number of claimed pods, want: %d, got: %d", tc.wantClaimed, len(claimed))
+			}
+			if want := tc.wantClaimed - 1; len(podControl.Patches) != want {
+				t.Errorf("Unexpected number of adoption patches, want: %d, got: %d", want, len(podControl.Patches))
+			}
+			var events []string
+			for len(recorder.Events) != 0 {
+				events = append(events, <-recorder.Events)
+			}
+			if tc.wantEvent == "" && len(events) != 0 {
+				t.Errorf("Unexpected events: %v", events)
+			}
+			if tc.wantEvent != "" && (len(events) != 1 || events[0] != tc.wantEvent) {
+				t.Errorf("Unexpected events, want: %q, got: %v", tc.wantEvent, events)
+			}
+		})
+	}
+}
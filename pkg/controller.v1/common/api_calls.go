@@ -0,0 +1,64 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	commonutil "github.com/kubeflow/training-operator/pkg/util"
+)
+
+// The verbs of the API calls counted per job.
+const (
+	APICallCreate = "create"
+	APICallGet    = "get"
+	APICallList   = "list"
+	APICallUpdate = "update"
+	APICallDelete = "delete"
+)
+
+// RecordAPICall counts an API call with the verb made on behalf of the job in the job registry,
+// if it implements AddAPICalls, so that the load of the operator on the API server can be
+// attributed to the jobs. The calls are counted whether they succeed or not. The reads from
+// the informer caches don't reach the API server and must not be counted.
+func (jc *JobController) RecordAPICall(job interface{}, verb string) {
+	metaObject, ok := job.(metav1.Object)
+	if !ok {
+		return
+	}
+	if counter, ok := jc.JobRegistry.(interface {
+		AddAPICalls(kind, namespace, name, verb string, count int64)
+	}); ok {
+		counter.AddAPICalls(jc.Controller.GetAPIGroupVersionKind().Kind, metaObject.GetNamespace(), metaObject.GetName(), verb, 1)
+	}
+}
+
+// updateJobStatusInApiServer rolls up the phase of the job from its conditions and updates the
+// status of the job through the controller. The call is counted unless the status is unchanged
+// since oldStatus, since the controllers don't patch it then. The audit events of the state
+// changes since oldStatus are emitted once the status is updated.
+func (jc *JobController) updateJobStatusInApiServer(job interface{}, oldStatus, jobStatus *apiv1.JobStatus) error {
+	jobStatus.Phase = commonutil.JobPhase(*jobStatus)
+	if !equality.Semantic.DeepEqual(oldStatus, jobStatus) {
+		jc.RecordAPICall(job, APICallUpdate)
+	}
+	if err := jc.Controller.UpdateJobStatusInApiServer(job, jobStatus); err != nil {
+		return err
+	}
+	jc.RecordAuditEvents(job, oldStatus, jobStatus)
+	return nil
+}
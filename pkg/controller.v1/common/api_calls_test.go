@@ -0,0 +1,82 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/record"
+
+	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	"github.com/kubeflow/training-operator/pkg/controller.v1/registry"
+)
+
+// pytorchJobController is a frameworkController of PyTorchJobs whose status updates are dropped.
+type pytorchJobController struct {
+	frameworkController
+}
+
+func (c *pytorchJobController) GetAPIGroupVersionKind() schema.GroupVersionKind {
+	return apiv1.GroupVersion.WithKind(apiv1.PyTorchJobKind)
+}
+
+func (c *pytorchJobController) GetAPIGroupVersion() schema.GroupVersion {
+	return apiv1.GroupVersion
+}
+
+func (c *pytorchJobController) UpdateJobStatusInApiServer(interface{}, *apiv1.JobStatus) error {
+	return nil
+}
+
+func TestRecordAPICall(t *testing.T) {
+	jobs := registry.New()
+	job := &apiv1.PyTorchJob{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: metav1.NamespaceDefault}}
+	jobs.OnAdd(job)
+	jc := &JobController{
+		Controller:  &pytorchJobController{frameworkController{framework: "pytorch"}},
+		JobRegistry: jobs,
+		Recorder:    record.NewFakeRecorder(10),
+	}
+
+	jc.RecordAPICall(job, APICallCreate)
+	jc.RecordAPICall(job, APICallCreate)
+	jc.RecordAPICall(job, APICallDelete)
+	// The jobs which are not in the registry are ignored.
+	jc.RecordAPICall(&apiv1.PyTorchJob{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: metav1.NamespaceDefault}}, APICallGet)
+
+	oldStatus := &apiv1.JobStatus{}
+	// The unchanged statuses are not patched by the controllers.
+	if err := jc.updateJobStatusInApiServer(job, oldStatus, oldStatus.DeepCopy()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	jobStatus := oldStatus.DeepCopy()
+	jobStatus.Conditions = []apiv1.JobCondition{{Type: apiv1.JobCreated, Status: corev1.ConditionTrue}}
+	if err := jc.updateJobStatusInApiServer(job, oldStatus, jobStatus); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if jobStatus.Phase != apiv1.JobPhaseCreated {
+		t.Errorf("Unexpected phase, want: %q, got: %q", apiv1.JobPhaseCreated, jobStatus.Phase)
+	}
+
+	info, _ := jobs.Get(apiv1.PyTorchJobKind, metav1.NamespaceDefault, "test")
+	want := map[string]int64{APICallCreate: 2, APICallDelete: 1, APICallUpdate: 1}
+	if diff := cmp.Diff(want, info.APICalls); len(diff) != 0 {
+		t.Errorf("Unexpected API calls (-want,+got):\n%s", diff)
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	commonutil "github.com/kubeflow/training-operator/pkg/util"
+)
+
+// auditTransition is a state change of a job reported by an audit event.
+type auditTransition struct {
+	reason    string
+	eventType string
+	// condition is the condition of the new state of the job, if the state has one.
+	condition *apiv1.JobCondition
+}
+
+// auditTransitions returns the state changes of a job between the old and the new status, in the
+// order in which a job goes through them.
+func auditTransitions(oldStatus, newStatus *apiv1.JobStatus) []auditTransition {
+	// The state of a reconstructed status was audited before the status was cleared.
+	if len(oldStatus.Conditions) == 0 && isStatusReconstructed(*newStatus) {
+		return nil
+	}
+	var transitions []auditTransition
+	add := func(changed bool, reason, eventType string, conditionType apiv1.JobConditionType) {
+		if !changed {
+			return
+		}
+		transitions = append(transitions, auditTransition{
+			reason:    reason,
+			eventType: eventType,
+			condition: findCondition(newStatus, conditionType),
+		})
+	}
+	add(commonutil.IsCreated(*newStatus) && !commonutil.IsCreated(*oldStatus),
+		commonutil.AuditJobCreatedReason, corev1.EventTypeNormal, apiv1.JobCreated)
+	add(statusPodCount(newStatus) > 0 && statusPodCount(oldStatus) == 0,
+		commonutil.AuditPodsCreatedReason, corev1.EventTypeNormal, "")
+	add(isGangScheduled(newStatus) && !isGangScheduled(oldStatus),
+		commonutil.AuditGangScheduledReason, corev1.EventTypeNormal, "")
+	add(commonutil.IsRestarting(*newStatus) && !commonutil.IsRestarting(*oldStatus),
+		commonutil.AuditJobRestartingReason, corev1.EventTypeWarning, apiv1.JobRestarting)
+	add(commonutil.IsRunning(*newStatus) && !commonutil.IsRunning(*oldStatus),
+		commonutil.AuditJobRunningReason, corev1.EventTypeNormal, apiv1.JobRunning)
+	add(commonutil.IsSucceeded(*newStatus) && !commonutil.IsSucceeded(*oldStatus),
+		commonutil.AuditJobSucceededReason, corev1.EventTypeNormal, apiv1.JobSucceeded)
+	add(commonutil.IsFailed(*newStatus) && !commonutil.IsFailed(*oldStatus),
+		commonutil.AuditJobFailedReason, corev1.EventTypeWarning, apiv1.JobFailed)
+	return transitions
+}
+
+// statusPodCount returns the number of pods of all the replica types in the status of a job.
+func statusPodCount(jobStatus *apiv1.JobStatus) int {
+	count := 0
+	for _, status := range jobStatus.ReplicaStatuses {
+		if status != nil {
+			count += len(status.Pods)
+		}
+	}
+	return count
+}
+
+// isGangScheduled checks if the PodGroup of a job which is neither finished nor suspended is
+// admitted by the gang scheduler.
+func isGangScheduled(jobStatus *apiv1.JobStatus) bool {
+	return jobStatus.GangScheduling != nil && !commonutil.IsQueued(*jobStatus) &&
+		!commonutil.IsFinished(*jobStatus) && !commonutil.IsSuspended(*jobStatus)
+}
+
+// auditAnnotations returns the annotations of the audit event of a state change of a job.
+func (jc *JobController) auditAnnotations(metaObject metav1.Object, jobStatus *apiv1.JobStatus, transition auditTransition) map[string]string {
+	annotations := map[string]string{
+		commonutil.AuditJobKindAnnotation: jc.Controller.GetAPIGroupVersionKind().Kind,
+		commonutil.AuditJobUIDAnnotation:  string(metaObject.GetUID()),
+		commonutil.AuditRunIDAnnotation:   RunID(metaObject),
+	}
+	transitionTime := jc.Clock.MetaNow()
+	if transition.condition != nil {
+		transitionTime = transition.condition.LastTransitionTime
+		annotations[commonutil.AuditReasonAnnotation] = transition.condition.Reason
+	}
+	annotations[commonutil.AuditTransitionTimeAnnotation] = transitionTime.UTC().Format(time.RFC3339)
+
+	rtypes := make([]string, 0, len(jobStatus.ReplicaStatuses))
+	for rtype, status := range jobStatus.ReplicaStatuses {
+		if status != nil {
+			rtypes = append(rtypes, string(rtype))
+		}
+	}
+	sort.Strings(rtypes)
+	replicas := make([]string, 0, len(rtypes))
+	for _, rtype := range rtypes {
+		status := jobStatus.ReplicaStatuses[apiv1.ReplicaType(rtype)]
+		replicas = append(replicas, fmt.Sprintf("%s=%d", rtype, len(status.Pods)))
+		if transition.reason == commonutil.AuditJobFailedReason && status.FailureReason != "" {
+			annotations[commonutil.AuditFailureReasonAnnotation] = string(status.FailureReason)
+		}
+	}
+	if len(replicas) != 0 {
+		annotations[commonutil.AuditReplicasAnnotation] = strings.Join(replicas, ",")
+	}
+	return annotations
+}
+
+// auditMessage returns the message of an audit event, which lists the annotations of the event
+// sorted by key, since the events.k8s.io/v1 events have no annotations.
+func auditMessage(jobKind, jobName, reason string, annotations map[string]string) string {
+	keys := make([]string, 0, len(annotations))
+	for key := range annotations {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", strings.TrimPrefix(key, "training.kubeflow.org/"), annotations[key]))
+	}
+	return fmt.Sprintf("%s %s %s: %s", jobKind, jobName, reason, strings.Join(pairs, " "))
+}
+
+// RecordAuditEvents emits an audit event for every state change of the job between oldStatus and
+// newStatus: JobCreated, PodsCreated, GangScheduled, JobRestarting, JobRunning, JobSucceeded and
+// JobFailed. The events have the same reasons for all the kinds of jobs and the structured Audit
+// annotations, so that the jobs can be audited and billed from their events alone. It is called
+// when a job is created, and once the new status of a job is written.
+func (jc *JobController) RecordAuditEvents(job interface{}, oldStatus, newStatus *apiv1.JobStatus) {
+	metaObject, ok := job.(metav1.Object)
+	if !ok {
+		return
+	}
+	runtimeObject, ok := job.(runtime.Object)
+	if !ok {
+		return
+	}
+	jobKind := jc.Controller.GetAPIGroupVersionKind().Kind
+	for _, transition := range auditTransitions(oldStatus, newStatus) {
+		annotations := jc.auditAnnotations(metaObject, newStatus, transition)
+		jc.Recorder.AnnotatedEventf(runtimeObject, annotations, transition.eventType, transition.reason, "%s",
+			auditMessage(jobKind, metaObject.GetName(), transition.reason, annotations))
+	}
+}
+
+// terminalAuditEvent returns the last JobSucceeded or JobFailed audit event of the job, or nil if
+// the job has none, e.g. it never finished or its events expired.
+func (jc *JobController) terminalAuditEvent(metaObject metav1.Object) (*corev1.Event, error) {
+	if jc.KubeClientSet == nil {
+		return nil, nil
+	}
+	events, err := jc.KubeClientSet.CoreV1().Events(metaObject.GetNamespace()).List(context.Background(), metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("involvedObject.uid", string(metaObject.GetUID())).String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	var terminal *corev1.Event
+	for i := range events.Items {
+		event := &events.Items[i]
+		if event.Reason != commonutil.AuditJobSucceededReason && event.Reason != commonutil.AuditJobFailedReason {
+			continue
+		}
+		if terminal == nil || terminal.Annotations[commonutil.AuditTransitionTimeAnnotation] < event.Annotations[commonutil.AuditTransitionTimeAnnotation] {
+			terminal = event
+		}
+	}
+	return terminal, nil
+}
@@ -0,0 +1,152 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+	clocktesting "k8s.io/utils/clock/testing"
+
+	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	commonutil "github.com/kubeflow/training-operator/pkg/util"
+	testjobv1 "github.com/kubeflow/training-operator/test_job/apis/test_job/v1"
+)
+
+func TestRecordAuditEvents(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	job := &testjobv1.TestJob{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: metav1.NamespaceDefault, UID: "uid"}}
+	recorder := record.NewFakeRecorder(10)
+	jc := &JobController{
+		Controller: &testJobController{frameworkController{framework: "test-framework"}},
+		Recorder:   recorder,
+		Clock:      commonutil.NewClock(clocktesting.NewFakePassiveClock(now), 0),
+	}
+	// reasons returns the reasons of the events emitted since the last call.
+	reasons := func() []string {
+		var got []string
+		for len(recorder.Events) != 0 {
+			got = append(got, strings.Fields(<-recorder.Events)[1])
+		}
+		return got
+	}
+
+	status := &apiv1.JobStatus{}
+	created := status.DeepCopy()
+	commonutil.UpdateJobConditions(created, apiv1.JobCreated, corev1.ConditionTrue, "TestJobCreated", "", nil)
+	jc.RecordAuditEvents(job, status, created)
+	if diff := cmp.Diff([]string{commonutil.AuditJobCreatedReason}, reasons()); len(diff) != 0 {
+		t.Errorf("Unexpected audit events (-want,+got):\n%s", diff)
+	}
+
+	scheduled := created.DeepCopy()
+	scheduled.GangScheduling = &apiv1.GangSchedulingStatus{SchedulerName: "volcano", MinMember: 2}
+	scheduled.ReplicaStatuses = map[apiv1.ReplicaType]*apiv1.ReplicaStatus{
+		"Worker": {Pods: []apiv1.ReplicaPodStatus{{Name: "test-worker-0"}, {Name: "test-worker-1"}}},
+	}
+	jc.RecordAuditEvents(job, created, scheduled)
+	if diff := cmp.Diff([]string{commonutil.AuditPodsCreatedReason, commonutil.AuditGangScheduledReason}, reasons()); len(diff) != 0 {
+		t.Errorf("Unexpected audit events (-want,+got):\n%s", diff)
+	}
+
+	running := scheduled.DeepCopy()
+	commonutil.UpdateJobConditions(running, apiv1.JobRunning, corev1.ConditionTrue, "TestJobRunning", "", nil)
+	jc.RecordAuditEvents(job, scheduled, running)
+	jc.RecordAuditEvents(job, running, running)
+	if diff := cmp.Diff([]string{commonutil.AuditJobRunningReason}, reasons()); len(diff) != 0 {
+		t.Errorf("Unexpected audit events (-want,+got):\n%s", diff)
+	}
+
+	failed := running.DeepCopy()
+	failed.ReplicaStatuses["Worker"].FailureReason = apiv1.PodFailureReasonOOMKilled
+	commonutil.UpdateJobConditions(failed, apiv1.JobRunning, corev1.ConditionFalse, "TestJobFailed", "", nil)
+	commonutil.UpdateJobConditions(failed, apiv1.JobFailed, corev1.ConditionTrue, "TestJobFailed", "", nil)
+	findCondition(failed, apiv1.JobFailed).LastTransitionTime = metav1.NewTime(now.Add(-time.Second))
+	jc.RecordAuditEvents(job, running, failed)
+	want := "Warning JobFailed TestJob test JobFailed: failure-reason=OOMKilled job-kind=TestJob job-uid=uid " +
+		"reason=TestJobFailed replicas=Worker=2 run-id=uid transition-time=2023-12-31T23:59:59Z"
+	if got := strings.SplitN(<-recorder.Events, " map[", 2)[0]; got != want {
+		t.Errorf("Unexpected audit event, want: %q, got: %q", want, got)
+	}
+}
+
+func TestAuditTransitionsOfReconstructedStatus(t *testing.T) {
+	reconstructed := &apiv1.JobStatus{Conditions: []apiv1.JobCondition{{
+		Type:   apiv1.JobCreated,
+		Status: corev1.ConditionTrue,
+		Reason: commonutil.NewReason("TestJob", commonutil.JobStatusReconstructedReason),
+	}}}
+	if got := auditTransitions(&apiv1.JobStatus{}, reconstructed); len(got) != 0 {
+		t.Errorf("Expected no audit event for a reconstructed status, got: %v", got)
+	}
+}
+
+func TestTerminalAuditEvent(t *testing.T) {
+	job := &testjobv1.TestJob{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: metav1.NamespaceDefault, UID: "uid"}}
+	newEvent := func(name, reason, transitionTime string) *corev1.Event {
+		return &corev1.Event{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   metav1.NamespaceDefault,
+				Annotations: map[string]string{commonutil.AuditTransitionTimeAnnotation: transitionTime},
+			},
+			InvolvedObject: corev1.ObjectReference{UID: job.UID},
+			Reason:         reason,
+		}
+	}
+	cases := map[string]struct {
+		events []*corev1.Event
+		want   string
+	}{
+		"job never finished": {
+			events: []*corev1.Event{newEvent("running", commonutil.AuditJobRunningReason, "2024-01-01T00:00:00Z")},
+		},
+		"latest terminal event": {
+			events: []*corev1.Event{
+				newEvent("succeeded", commonutil.AuditJobSucceededReason, "2024-01-01T01:00:00Z"),
+				newEvent("failed", commonutil.AuditJobFailedReason, "2024-01-01T02:00:00Z"),
+			},
+			want: "failed",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			clientset := kubefake.NewSimpleClientset()
+			for _, event := range tc.events {
+				if err := clientset.Tracker().Add(event); err != nil {
+					t.Fatalf("Failed to add the event %s: %v", event.Name, err)
+				}
+			}
+			jc := &JobController{KubeClientSet: clientset}
+			got, err := jc.terminalAuditEvent(job)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			gotName := ""
+			if got != nil {
+				gotName = got.Name
+			}
+			if gotName != tc.want {
+				t.Errorf("Unexpected terminal event, want: %q, got: %q", tc.want, gotName)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+
+	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	"github.com/kubeflow/training-operator/pkg/core"
+	commonutil "github.com/kubeflow/training-operator/pkg/util"
+	utillabels "github.com/kubeflow/training-operator/pkg/util/labels"
+)
+
+const (
+	// defaultGracefulCheckpointSeconds is the default time to wait for the checkpoint
+	// command to complete before the pods are deleted.
+	defaultGracefulCheckpointSeconds = 30
+)
+
+// checkpointPods asks the running pods which are going to be deleted to save a checkpoint, by
+// executing the command of the CheckpointPolicy in the given container in the background, so that
+// the reconcile doesn't wait for it. The pods are annotated with the deadline of their checkpoint,
+// after which they are deleted whether it completed or not. It returns true while the deadline of
+// one of the pods hasn't passed, in which case the job is requeued at the deadline and the caller
+// must not delete the pods yet. Failures are recorded as events and do not prevent the deletion.
+func (jc *JobController) checkpointPods(runtimeObject runtime.Object, checkpointPolicy *apiv1.CheckpointPolicy, containerName string, pods []*corev1.Pod) bool {
+	if checkpointPolicy == nil || len(checkpointPolicy.Command) == 0 || jc.PodExecControl == nil {
+		return false
+	}
+
+	timeout := time.Duration(ptr.Deref(checkpointPolicy.GracefulCheckpointSeconds, defaultGracefulCheckpointSeconds)) * time.Second
+	now := jc.Clock.Now()
+	var wait time.Duration
+	for _, pod := range pods {
+		if pod.Status.Phase != corev1.PodRunning || pod.DeletionTimestamp != nil {
+			continue
+		}
+		if value, ok := pod.Annotations[apiv1.CheckpointDeadlineAnnotation]; ok {
+			// The checkpoint was triggered by a previous reconcile. A malformed deadline counts as passed.
+			if deadline, err := time.Parse(time.RFC3339, value); err == nil && deadline.After(now) {
+				wait = max(wait, deadline.Sub(now))
+			}
+			continue
+		}
+		if err := jc.annotateCheckpointDeadline(pod, now.Add(timeout)); err != nil {
+			jc.Recorder.Eventf(runtimeObject, corev1.EventTypeWarning, commonutil.CheckpointFailedReason,
+				"Failed to checkpoint pod %v before deleting it: %v", klog.KObj(pod), err)
+			continue
+		}
+		go jc.execCheckpoint(runtimeObject, checkpointPolicy.Command, containerName, pod, timeout)
+		wait = max(wait, timeout)
+	}
+	if wait <= 0 {
+		return false
+	}
+	key, err := KeyFunc(runtimeObject)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("couldn't get key for job object %#v: %v", runtimeObject, err))
+		return false
+	}
+	jc.WorkQueue.AddAfter(key, wait)
+	return true
+}
+
+// annotateCheckpointDeadline records the deadline of the checkpoint of the pod in its annotations.
+func (jc *JobController) annotateCheckpointDeadline(pod *corev1.Pod, deadline time.Time) error {
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"annotations": map[string]string{
+				apiv1.CheckpointDeadlineAnnotation: deadline.UTC().Format(time.RFC3339),
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return jc.PodControl.PatchPod(pod.Namespace, pod.Name, patch)
+}
+
+// execCheckpoint executes the checkpoint command in the container of the pod, for up to the timeout.
+// Once the command returns before the timeout, the deadline of the pod is moved to the current time,
+// so that the pod update requeues the job and the pod is deleted without waiting any longer.
+func (jc *JobController) execCheckpoint(runtimeObject runtime.Object, command []string, containerName string, pod *corev1.Pod, timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if err := jc.PodExecControl.ExecInPod(ctx, pod.Namespace, pod.Name, containerName, command); err != nil {
+		jc.Recorder.Eventf(runtimeObject, corev1.EventTypeWarning, commonutil.CheckpointFailedReason,
+			"Failed to checkpoint pod %v before deleting it: %v", klog.KObj(pod), err)
+	}
+	if ctx.Err() != nil {
+		return
+	}
+	if err := jc.annotateCheckpointDeadline(pod, jc.Clock.Now()); err != nil {
+		commonutil.LoggerForPod(pod, runtimeObject.GetObjectKind().GroupVersionKind().Kind).Error(err, "Failed to record the completion of the checkpoint")
+	}
+}
+
+// scaledDownPods returns the pods whose replica index is out of the range of their ReplicaSpec.
+// These pods are deleted by ReconcilePods.
+func scaledDownPods(pods []*corev1.Pod, replicas map[apiv1.ReplicaType]*apiv1.ReplicaSpec) []*corev1.Pod {
+	var result []*corev1.Pod
+	for rtype, spec := range replicas {
+		rPods, err := core.FilterPodsForReplicaType(pods, strings.ToLower(string(rtype)))
+		if err != nil {
+			continue
+		}
+		for _, pod := range rPods {
+			index, err := utillabels.ReplicaIndex(pod.Labels)
+			if err != nil {
+				continue
+			}
+			if index < 0 || index >= int(ptr.Deref(spec.Replicas, 0)) {
+				result = append(result, pod)
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,175 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	clocktesting "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+
+	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	"github.com/kubeflow/training-operator/pkg/controller.v1/control"
+	commonutil "github.com/kubeflow/training-operator/pkg/util"
+	testjobv1 "github.com/kubeflow/training-operator/test_job/apis/test_job/v1"
+)
+
+func TestCheckpointPods(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	deletingPod := newPod("deletingPod", corev1.PodRunning)
+	deletingPod.DeletionTimestamp = ptr.To(metav1.Now())
+	newPods := func() []*corev1.Pod {
+		return []*corev1.Pod{
+			newPod("runningPod", corev1.PodRunning),
+			newPod("failingPod", corev1.PodRunning),
+			newPod("pendingPod", corev1.PodPending),
+			newPod("succeededPod", corev1.PodSucceeded),
+			deletingPod,
+		}
+	}
+	withDeadline := func(deadline time.Time) []*corev1.Pod {
+		pods := newPods()
+		for _, pod := range pods[:2] {
+			pod.Annotations = map[string]string{apiv1.CheckpointDeadlineAnnotation: deadline.Format(time.RFC3339)}
+		}
+		return pods
+	}
+
+	cases := map[string]struct {
+		checkpointPolicy *apiv1.CheckpointPolicy
+		pods             []*corev1.Pod
+		execErrs         map[string]error
+		wantWait         bool
+		wantExecPods     []string
+		wantPatches      int
+		wantEvents       int
+	}{
+		"checkpointPolicy is nil": {
+			checkpointPolicy: nil,
+			pods:             newPods(),
+		},
+		"checkpoint running pods": {
+			checkpointPolicy: &apiv1.CheckpointPolicy{
+				Command:                   []string{"kill", "-USR1", "1"},
+				GracefulCheckpointSeconds: ptr.To[int32](5),
+			},
+			pods:         newPods(),
+			wantWait:     true,
+			wantExecPods: []string{"runningPod", "failingPod"},
+			// The deadline of each pod is set, then moved to the completion of its checkpoint.
+			wantPatches: 4,
+		},
+		"failed checkpoint is recorded": {
+			checkpointPolicy: &apiv1.CheckpointPolicy{
+				Command: []string{"/checkpoint.sh"},
+			},
+			pods: newPods(),
+			execErrs: map[string]error{
+				"failingPod": errors.New("command terminated with exit code 1"),
+			},
+			wantWait:     true,
+			wantExecPods: []string{"runningPod", "failingPod"},
+			wantPatches:  4,
+			wantEvents:   1,
+		},
+		"checkpoint in progress": {
+			checkpointPolicy: &apiv1.CheckpointPolicy{
+				Command: []string{"/checkpoint.sh"},
+			},
+			pods:     withDeadline(now.Add(10 * time.Second)),
+			wantWait: true,
+		},
+		"checkpoint deadline passed": {
+			checkpointPolicy: &apiv1.CheckpointPolicy{
+				Command: []string{"/checkpoint.sh"},
+			},
+			pods: withDeadline(now),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			podExecControl := &control.FakePodExecControl{Errs: tc.execErrs}
+			podControl := &control.FakePodControl{}
+			recorder := record.NewFakeRecorder(10)
+			queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+			defer queue.ShutDown()
+			jobController := JobController{
+				PodControl:     podControl,
+				PodExecControl: podExecControl,
+				Recorder:       recorder,
+				WorkQueue:      queue,
+				Clock:          commonutil.NewClock(clocktesting.NewFakePassiveClock(now), 0),
+			}
+			job := &testjobv1.TestJob{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: metav1.NamespaceDefault}}
+			if got := jobController.checkpointPods(job, tc.checkpointPolicy, "test", tc.pods); got != tc.wantWait {
+				t.Errorf("Unexpected wait for the checkpoint, want: %v, got: %v", tc.wantWait, got)
+			}
+			// The checkpoints run in the background.
+			err := wait.PollUntilContextTimeout(context.Background(), 10*time.Millisecond, 5*time.Second, true, func(context.Context) (bool, error) {
+				podControl.Lock()
+				defer podControl.Unlock()
+				return len(podControl.Patches) >= tc.wantPatches, nil
+			})
+			if err != nil {
+				t.Errorf("Unexpected number of pod patches, want: %d, got: %d", tc.wantPatches, len(podControl.Patches))
+			}
+			if diff := cmp.Diff(tc.wantExecPods, podExecControl.ExecPodNames, cmpopts.SortSlices(func(a, b string) bool { return a < b })); len(diff) != 0 {
+				t.Errorf("Unexpected checkpointed pods (-want,+got):\n%s", diff)
+			}
+			if got := len(recorder.Events); got != tc.wantEvents {
+				t.Errorf("Unexpected number of events, want: %d, got: %d", tc.wantEvents, got)
+			}
+		})
+	}
+}
+
+func TestScaledDownPods(t *testing.T) {
+	newIndexedPod := func(rtype string, index int) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: rtype + "-" + strconv.Itoa(index),
+				Labels: map[string]string{
+					apiv1.ReplicaTypeLabel:  rtype,
+					apiv1.ReplicaIndexLabel: strconv.Itoa(index),
+				},
+			},
+		}
+	}
+	pods := []*corev1.Pod{
+		newIndexedPod("master", 0),
+		newIndexedPod("worker", 0),
+		newIndexedPod("worker", 1),
+		newIndexedPod("worker", 2),
+	}
+	replicas := map[apiv1.ReplicaType]*apiv1.ReplicaSpec{
+		"Master": {Replicas: ptr.To[int32](1)},
+		"Worker": {Replicas: ptr.To[int32](1)},
+	}
+	got := scaledDownPods(pods, replicas)
+	if diff := cmp.Diff([]*corev1.Pod{pods[2], pods[3]}, got); len(diff) != 0 {
+		t.Errorf("Unexpected scaled down pods (-want,+got):\n%s", diff)
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	"github.com/kubeflow/training-operator/pkg/common"
+	"github.com/kubeflow/training-operator/pkg/config"
+)
+
+const (
+	// datasetPrefix prefixes the name of a dataset to name its volume and the init container
+	// downloading it.
+	datasetPrefix = "dataset-"
+	// datasetDownloadPath is where the volume of a dataset is mounted in the init container
+	// downloading it.
+	datasetDownloadPath = "/dataset"
+)
+
+// datasetsSpec returns the datasets declared by the job.
+func (jc *JobController) datasetsSpec(job interface{}) []apiv1.DatasetSpec {
+	getter, ok := jc.Controller.(common.DatasetsGetter)
+	if !ok {
+		return nil
+	}
+	return getter.GetDatasets(job)
+}
+
+// datasetVolume returns the volume of the dataset, and the subPath of the volume mounted in the
+// main container: the PersistentVolumeClaim of a pvc:// source, or an emptyDir volume into
+// which the dataset is downloaded, limited to the sizeLimit of the dataset.
+func datasetVolume(dataset apiv1.DatasetSpec) (corev1.Volume, string) {
+	volume := corev1.Volume{Name: datasetPrefix + dataset.Name}
+	scheme, location, _ := strings.Cut(dataset.Source, "://")
+	if scheme != "pvc" {
+		volume.EmptyDir = &corev1.EmptyDirVolumeSource{}
+		if dataset.SizeLimit != nil {
+			volume.EmptyDir.SizeLimit = ptr.To(dataset.SizeLimit.DeepCopy())
+		}
+		return volume, ""
+	}
+	claimName, subPath, _ := strings.Cut(location, "/")
+	volume.PersistentVolumeClaim = &corev1.PersistentVolumeClaimVolumeSource{ClaimName: claimName, ReadOnly: true}
+	return volume, strings.Trim(subPath, "/")
+}
+
+// datasetInitializer returns the init container downloading the dataset with rclone, whose
+// remotes are configured on the fly from the source and from the env vars of the dataset, e.g.
+// RCLONE_S3_ENDPOINT. The credentials are read from the environment by default, e.g.
+// AWS_ACCESS_KEY_ID or the workload identity of the pod.
+func datasetInitializer(dataset apiv1.DatasetSpec) corev1.Container {
+	scheme, location, _ := strings.Cut(dataset.Source, "://")
+	var args []string
+	var env []corev1.EnvVar
+	switch scheme {
+	case "s3":
+		args = []string{"copy", ":s3:" + location, datasetDownloadPath}
+		env = []corev1.EnvVar{{Name: "RCLONE_S3_PROVIDER", Value: "AWS"}, {Name: "RCLONE_S3_ENV_AUTH", Value: "true"}}
+	case "gs":
+		args = []string{"copy", ":gcs:" + location, datasetDownloadPath}
+		env = []corev1.EnvVar{{Name: "RCLONE_GCS_ENV_AUTH", Value: "true"}}
+	default:
+		args = []string{"copyurl", dataset.Source, datasetDownloadPath, "--auto-filename"}
+	}
+	// The env vars of the dataset take precedence over the defaults.
+	for _, envVar := range env {
+		if !hasEnvVar(dataset.Env, envVar.Name) {
+			dataset.Env = append(dataset.Env, envVar)
+		}
+	}
+	return corev1.Container{
+		Name:         datasetPrefix + dataset.Name,
+		Image:        config.Config.DatasetInitializerImage,
+		Command:      []string{"rclone"},
+		Args:         args,
+		Env:          dataset.Env,
+		VolumeMounts: []corev1.VolumeMount{{Name: datasetPrefix + dataset.Name, MountPath: datasetDownloadPath}},
+	}
+}
+
+func hasEnvVar(env []corev1.EnvVar, name string) bool {
+	for i := range env {
+		if env[i].Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// SetDatasets stages the datasets of the job into the podTemplate, and mounts them at their
+// mountPath in its main container containerName. The PersistentVolumeClaim of a pvc:// dataset
+// is mounted read-only as is, while the other datasets are downloaded by an init container into
+// an emptyDir volume, so that they are staged before the containers of the pod start.
+func SetDatasets(podTemplate *corev1.PodTemplateSpec, containerName string, datasets []apiv1.DatasetSpec) {
+	var main *corev1.Container
+	for i := range podTemplate.Spec.Containers {
+		if podTemplate.Spec.Containers[i].Name == containerName {
+			main = &podTemplate.Spec.Containers[i]
+		}
+	}
+	if main == nil {
+		return
+	}
+	for _, dataset := range datasets {
+		volume, subPath := datasetVolume(dataset)
+		exists := false
+		for i := range podTemplate.Spec.Volumes {
+			if podTemplate.Spec.Volumes[i].Name == volume.Name {
+				exists = true
+				break
+			}
+		}
+		if exists {
+			continue
+		}
+		podTemplate.Spec.Volumes = append(podTemplate.Spec.Volumes, volume)
+		main.VolumeMounts = append(main.VolumeMounts, corev1.VolumeMount{
+			Name:      volume.Name,
+			MountPath: dataset.MountPath,
+			SubPath:   subPath,
+			ReadOnly:  volume.PersistentVolumeClaim != nil,
+		})
+		if volume.EmptyDir != nil {
+			podTemplate.Spec.InitContainers = append(podTemplate.Spec.InitContainers, datasetInitializer(dataset))
+		}
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+
+	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	"github.com/kubeflow/training-operator/pkg/config"
+)
+
+func TestSetDatasets(t *testing.T) {
+	oldImage := config.Config.DatasetInitializerImage
+	config.Config.DatasetInitializerImage = "rclone/rclone:1.67"
+	defer func() { config.Config.DatasetInitializerImage = oldImage }()
+
+	cases := map[string]struct {
+		datasets []apiv1.DatasetSpec
+		want     corev1.PodSpec
+	}{
+		"pvc": {
+			datasets: []apiv1.DatasetSpec{{Name: "imagenet", Source: "pvc://datasets/imagenet/", MountPath: "/data"}},
+			want: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{
+						Name:         "pytorch",
+						VolumeMounts: []corev1.VolumeMount{{Name: "dataset-imagenet", MountPath: "/data", SubPath: "imagenet", ReadOnly: true}},
+					},
+					{Name: "sidecar"},
+				},
+				Volumes: []corev1.Volume{{
+					Name: "dataset-imagenet",
+					VolumeSource: corev1.VolumeSource{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "datasets", ReadOnly: true},
+					},
+				}},
+			},
+		},
+		"s3 and http": {
+			datasets: []apiv1.DatasetSpec{
+				{
+					Name:      "mnist",
+					Source:    "s3://datasets/mnist",
+					MountPath: "/data/mnist",
+					Env: []corev1.EnvVar{
+						{Name: "RCLONE_S3_PROVIDER", Value: "Minio"},
+						{Name: "RCLONE_S3_ENDPOINT", Value: "http://minio.kubeflow:9000"},
+					},
+				},
+				{Name: "vocab", Source: "https://example.com/vocab.txt", MountPath: "/data/vocab"},
+			},
+			want: corev1.PodSpec{
+				InitContainers: []corev1.Container{
+					{
+						Name:    "dataset-mnist",
+						Image:   "rclone/rclone:1.67",
+						Command: []string{"rclone"},
+						Args:    []string{"copy", ":s3:datasets/mnist", "/dataset"},
+						Env: []corev1.EnvVar{
+							{Name: "RCLONE_S3_PROVIDER", Value: "Minio"},
+							{Name: "RCLONE_S3_ENDPOINT", Value: "http://minio.kubeflow:9000"},
+							{Name: "RCLONE_S3_ENV_AUTH", Value: "true"},
+						},
+						VolumeMounts: []corev1.VolumeMount{{Name: "dataset-mnist", MountPath: "/dataset"}},
+					},
+					{
+						Name:         "dataset-vocab",
+						Image:        "rclone/rclone:1.67",
+						Command:      []string{"rclone"},
+						Args:         []string{"copyurl", "https://example.com/vocab.txt", "/dataset", "--auto-filename"},
+						VolumeMounts: []corev1.VolumeMount{{Name: "dataset-vocab", MountPath: "/dataset"}},
+					},
+				},
+				Containers: []corev1.Container{
+					{
+						Name: "pytorch",
+						VolumeMounts: []corev1.VolumeMount{
+							{Name: "dataset-mnist", MountPath: "/data/mnist"},
+							{Name: "dataset-vocab", MountPath: "/data/vocab"},
+						},
+					},
+					{Name: "sidecar"},
+				},
+				Volumes: []corev1.Volume{
+					{Name: "dataset-mnist", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+					{Name: "dataset-vocab", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+				},
+			},
+		},
+		"gcs": {
+			datasets: []apiv1.DatasetSpec{{Name: "c4", Source: "gs://datasets/c4", MountPath: "/data", SizeLimit: ptr.To(resource.MustParse("100Gi"))}},
+			want: corev1.PodSpec{
+				InitContainers: []corev1.Container{{
+					Name:         "dataset-c4",
+					Image:        "rclone/rclone:1.67",
+					Command:      []string{"rclone"},
+					Args:         []string{"copy", ":gcs:datasets/c4", "/dataset"},
+					Env:          []corev1.EnvVar{{Name: "RCLONE_GCS_ENV_AUTH", Value: "true"}},
+					VolumeMounts: []corev1.VolumeMount{{Name: "dataset-c4", MountPath: "/dataset"}},
+				}},
+				Containers: []corev1.Container{
+					{Name: "pytorch", VolumeMounts: []corev1.VolumeMount{{Name: "dataset-c4", MountPath: "/data"}}},
+					{Name: "sidecar"},
+				},
+				Volumes: []corev1.Volume{{
+					Name:         "dataset-c4",
+					VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{SizeLimit: ptr.To(resource.MustParse("100Gi"))}},
+				}},
+			},
+		},
+		"no datasets": {
+			want: corev1.PodSpec{Containers: []corev1.Container{{Name: "pytorch"}, {Name: "sidecar"}}},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			podTemplate := &corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "pytorch"}, {Name: "sidecar"}}},
+			}
+			SetDatasets(podTemplate, "pytorch", tc.datasets)
+			// The datasets are staged only once.
+			SetDatasets(podTemplate, "pytorch", tc.datasets)
+			if diff := cmp.Diff(tc.want, podTemplate.Spec); len(diff) != 0 {
+				t.Errorf("Unexpected pod spec (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,340 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	"github.com/kubeflow/training-operator/pkg/common"
+	"github.com/kubeflow/training-operator/pkg/controller.v1/registry"
+	commonutil "github.com/kubeflow/training-operator/pkg/util"
+)
+
+const (
+	persistentVolumeClaimKind = "PersistentVolumeClaim"
+	secretKind                = "Secret"
+	configMapKind             = "ConfigMap"
+
+	// dependenciesRequeuePeriod is the period of the checks of the dependencies of a waiting job,
+	// in case the creation of a dependency or the completion of a job was missed by the watches.
+	dependenciesRequeuePeriod = time.Minute
+)
+
+// dependency is an object of the namespace of a job referenced by the templates of its pods, or
+// a job it depends on.
+type dependency struct {
+	kind string
+	name string
+	// notFound is true for a job it depends on which doesn't exist.
+	notFound bool
+}
+
+func (d dependency) String() string {
+	if d.notFound {
+		return d.kind + " " + d.name + " (not found)"
+	}
+	return d.kind + " " + d.name
+}
+
+// podDependencies returns the PersistentVolumeClaims, Secrets and ConfigMaps which must
+// exist for the pods of the spec to start. The optional Secrets and ConfigMaps are skipped.
+func podDependencies(spec *corev1.PodSpec) []dependency {
+	var deps []dependency
+	add := func(kind, name string, optional *bool) {
+		if name != "" && (optional == nil || !*optional) {
+			deps = append(deps, dependency{kind: kind, name: name})
+		}
+	}
+	for _, volume := range spec.Volumes {
+		switch {
+		case volume.PersistentVolumeClaim != nil:
+			add(persistentVolumeClaimKind, volume.PersistentVolumeClaim.ClaimName, nil)
+		case volume.Secret != nil:
+			add(secretKind, volume.Secret.SecretName, volume.Secret.Optional)
+		case volume.ConfigMap != nil:
+			add(configMapKind, volume.ConfigMap.Name, volume.ConfigMap.Optional)
+		case volume.Projected != nil:
+			for _, source := range volume.Projected.Sources {
+				if source.Secret != nil {
+					add(secretKind, source.Secret.Name, source.Secret.Optional)
+				}
+				if source.ConfigMap != nil {
+					add(configMapKind, source.ConfigMap.Name, source.ConfigMap.Optional)
+				}
+			}
+		}
+	}
+	for _, containers := range [][]corev1.Container{spec.InitContainers, spec.Containers} {
+		for _, container := range containers {
+			for _, envFrom := range container.EnvFrom {
+				if envFrom.SecretRef != nil {
+					add(secretKind, envFrom.SecretRef.Name, envFrom.SecretRef.Optional)
+				}
+				if envFrom.ConfigMapRef != nil {
+					add(configMapKind, envFrom.ConfigMapRef.Name, envFrom.ConfigMapRef.Optional)
+				}
+			}
+			for _, env := range container.Env {
+				if env.ValueFrom == nil {
+					continue
+				}
+				if ref := env.ValueFrom.SecretKeyRef; ref != nil {
+					add(secretKind, ref.Name, ref.Optional)
+				}
+				if ref := env.ValueFrom.ConfigMapKeyRef; ref != nil {
+					add(configMapKind, ref.Name, ref.Optional)
+				}
+			}
+		}
+	}
+	return deps
+}
+
+// missingDependencies returns the dependencies of the replicas which don't exist in the namespace,
+// sorted by kind and name.
+func (jc *JobController) missingDependencies(job metav1.Object, replicas map[apiv1.ReplicaType]*apiv1.ReplicaSpec) ([]dependency, error) {
+	seen := map[dependency]bool{}
+	var missing []dependency
+	for _, spec := range replicas {
+		if spec == nil {
+			continue
+		}
+		for _, dep := range podDependencies(&spec.Template.Spec) {
+			if seen[dep] {
+				continue
+			}
+			seen[dep] = true
+			jc.RecordAPICall(job, APICallGet)
+			exists, err := jc.dependencyExists(job.GetNamespace(), dep)
+			if err != nil {
+				return nil, err
+			}
+			if !exists {
+				missing = append(missing, dep)
+			}
+		}
+	}
+	sort.Slice(missing, func(i, j int) bool {
+		if missing[i].kind != missing[j].kind {
+			return missing[i].kind < missing[j].kind
+		}
+		return missing[i].name < missing[j].name
+	})
+	return missing, nil
+}
+
+func (jc *JobController) dependencyExists(namespace string, dep dependency) (bool, error) {
+	ctx := context.Background()
+	var err error
+	switch dep.kind {
+	case persistentVolumeClaimKind:
+		_, err = jc.KubeClientSet.CoreV1().PersistentVolumeClaims(namespace).Get(ctx, dep.name, metav1.GetOptions{})
+	case secretKind:
+		_, err = jc.KubeClientSet.CoreV1().Secrets(namespace).Get(ctx, dep.name, metav1.GetOptions{})
+	case configMapKind:
+		_, err = jc.KubeClientSet.CoreV1().ConfigMaps(namespace).Get(ctx, dep.name, metav1.GetOptions{})
+	default:
+		return false, fmt.Errorf("unknown dependency kind %s", dep.kind)
+	}
+	if errors.IsNotFound(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// jobDependencies returns the jobs the job depends on, which are of the kind of the job unless
+// they set another kind.
+func (jc *JobController) jobDependencies(job metav1.Object) []dependency {
+	getter, ok := jc.Controller.(common.JobDependenciesGetter)
+	if !ok {
+		return nil
+	}
+	var deps []dependency
+	for _, ref := range getter.GetJobDependencies(job) {
+		kind := ref.Kind
+		if kind == "" {
+			kind = jc.Controller.GetAPIGroupVersionKind().Kind
+		}
+		deps = append(deps, dependency{kind: kind, name: ref.Name})
+	}
+	return deps
+}
+
+// pendingJobDependencies returns the jobs the job depends on which haven't succeeded yet, including
+// the ones which don't exist, marked as not found, and among them the ones which have failed. The
+// jobs are read from the job registry, so the jobs of the kinds which are not enabled in the
+// operator are never found.
+func (jc *JobController) pendingJobDependencies(job metav1.Object) (pending, failed []dependency) {
+	if jc.JobRegistry == nil {
+		return nil, nil
+	}
+	for _, dep := range jc.jobDependencies(job) {
+		info, ok := jc.JobRegistry.Get(dep.kind, job.GetNamespace(), dep.name)
+		if ok && info.Phase == apiv1.JobSucceeded {
+			continue
+		}
+		dep.notFound = !ok
+		pending = append(pending, dep)
+		if ok && info.Phase == apiv1.JobFailed {
+			failed = append(failed, dep)
+		}
+	}
+	return pending, failed
+}
+
+// failedJobDependencyMessage returns the failure message of an unfinished job which hasn't created
+// its pods yet, if a job it depends on has failed, since it would never start otherwise.
+func (jc *JobController) failedJobDependencyMessage(job metav1.Object, jobStatus apiv1.JobStatus, pods []*corev1.Pod) string {
+	if len(pods) > 0 || commonutil.IsFinished(jobStatus) {
+		return ""
+	}
+	_, failed := jc.pendingJobDependencies(job)
+	if len(failed) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("Job %s has failed because the %s it depends on has failed", job.GetName(), failed[0])
+}
+
+// reconcileDependencies holds the creation of the pods of a job until the PersistentVolumeClaims,
+// Secrets and ConfigMaps referenced by their templates exist, since the pods would otherwise be
+// stuck in ContainerCreating or Pending with confusing errors, and until the jobs it depends on
+// have succeeded. Meanwhile, the job is WaitingForDependencies, releases the resources of its
+// queue of the operator if it was admitted, and is requeued periodically, besides the creations
+// of such objects and the completions of such jobs observed by WatchDependencies. It returns true
+// while the pods must not be created.
+func (jc *JobController) reconcileDependencies(metaObject metav1.Object, runtimeObject runtime.Object,
+	replicas map[apiv1.ReplicaType]*apiv1.ReplicaSpec, jobStatus *apiv1.JobStatus) (bool, error) {
+	var missing []dependency
+	if jc.KubeClientSet != nil {
+		var err error
+		if missing, err = jc.missingDependencies(metaObject, replicas); err != nil {
+			return false, err
+		}
+	}
+	pendingJobs, _ := jc.pendingJobDependencies(metaObject)
+	missing = append(missing, pendingJobs...)
+	jobKind := jc.Controller.GetAPIGroupVersionKind().Kind
+	if len(missing) == 0 {
+		if commonutil.IsWaitingForDependencies(*jobStatus) {
+			msg := fmt.Sprintf("The dependencies of %s %s exist, creating its pods.", jobKind, metaObject.GetName())
+			reason := commonutil.NewReason(jobKind, commonutil.JobDependenciesReadyReason)
+			jc.Recorder.Event(runtimeObject, corev1.EventTypeNormal, reason, msg)
+			commonutil.UpdateJobConditions(jobStatus, apiv1.JobWaitingForDependencies, corev1.ConditionFalse, reason, msg, jc.Clock)
+		}
+		return false, nil
+	}
+
+	names := make([]string, 0, len(missing))
+	for _, dep := range missing {
+		names = append(names, dep.String())
+	}
+	msg := fmt.Sprintf("%s %s is waiting for its dependencies: %s.", jobKind, metaObject.GetName(), strings.Join(names, ", "))
+	if condition := findCondition(jobStatus, apiv1.JobWaitingForDependencies); condition == nil ||
+		condition.Status != corev1.ConditionTrue || condition.Message != msg {
+		reason := commonutil.NewReason(jobKind, commonutil.JobWaitingForDependenciesReason)
+		jc.Recorder.Event(runtimeObject, corev1.EventTypeWarning, reason, msg)
+		if condition != nil && condition.Status == corev1.ConditionTrue {
+			// The message lists the dependencies still missing, e.g. once a job it depends on is created.
+			condition.Message = msg
+			condition.LastUpdateTime = jc.Clock.MetaNow()
+		} else {
+			commonutil.UpdateJobConditions(jobStatus, apiv1.JobWaitingForDependencies, corev1.ConditionTrue, reason, msg, jc.Clock)
+		}
+	}
+	// The job is admitted by its queue again once its dependencies are ready.
+	removeCondition(jobStatus, apiv1.JobPending)
+	jc.JobQueues.Release(metaObject.GetUID())
+	if key, err := KeyFunc(metaObject); err == nil {
+		jc.WorkQueue.AddAfter(key, dependenciesRequeuePeriod)
+	}
+	return true, nil
+}
+
+// WatchDependencies requeues the jobs of the controller c which are WaitingForDependencies when
+// a PersistentVolumeClaim, a Secret or a ConfigMap is created in their namespace, and when a job
+// of their namespace finishes or is deleted. Only the metadata of these objects is cached, and
+// the jobs are observed through the job registry if it accepts listeners.
+func (jc *JobController) WatchDependencies(mgr manager.Manager, c controller.Controller) error {
+	createOnly := predicate.TypedFuncs[*metav1.PartialObjectMetadata]{
+		UpdateFunc:  func(event.TypedUpdateEvent[*metav1.PartialObjectMetadata]) bool { return false },
+		DeleteFunc:  func(event.TypedDeleteEvent[*metav1.PartialObjectMetadata]) bool { return false },
+		GenericFunc: func(event.TypedGenericEvent[*metav1.PartialObjectMetadata]) bool { return false },
+	}
+	for _, kind := range []string{persistentVolumeClaimKind, secretKind, configMapKind} {
+		obj := &metav1.PartialObjectMetadata{}
+		obj.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind(kind))
+		if err := c.Watch(source.Kind[*metav1.PartialObjectMetadata](mgr.GetCache(), obj,
+			handler.TypedEnqueueRequestsFromMapFunc(func(_ context.Context, obj *metav1.PartialObjectMetadata) []reconcile.Request {
+				return jc.jobsWaitingForDependencies(obj.GetNamespace())
+			}), createOnly)); err != nil {
+			return err
+		}
+	}
+	if listeners, ok := jc.JobRegistry.(interface{ AddListener(registry.Listener) }); ok {
+		listeners.AddListener(jc.onJobDependencyChange)
+	}
+	return nil
+}
+
+// onJobDependencyChange requeues the jobs of the controller which are WaitingForDependencies in the
+// namespace of a job which finished or was deleted.
+func (jc *JobController) onJobDependencyChange(old, new *registry.JobInfo) {
+	var namespace string
+	switch {
+	case new == nil:
+		namespace = old.Namespace
+	case (old == nil || old.Phase != new.Phase) && (new.Phase == apiv1.JobSucceeded || new.Phase == apiv1.JobFailed):
+		namespace = new.Namespace
+	default:
+		return
+	}
+	for _, request := range jc.jobsWaitingForDependencies(namespace) {
+		jc.WorkQueue.Add(request.String())
+	}
+}
+
+// jobsWaitingForDependencies returns the requests of the jobs of the controller in the namespace
+// whose latest condition is WaitingForDependencies.
+func (jc *JobController) jobsWaitingForDependencies(namespace string) []reconcile.Request {
+	if jc.JobRegistry == nil {
+		return nil
+	}
+	jobKind := jc.Controller.GetAPIGroupVersionKind().Kind
+	var requests []reconcile.Request
+	for _, job := range jc.JobRegistry.ListByNamespace(namespace) {
+		if job.Kind == jobKind && job.Phase == apiv1.JobWaitingForDependencies {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: job.Namespace, Name: job.Name}})
+		}
+	}
+	return requests
+}
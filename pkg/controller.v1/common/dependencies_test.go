@@ -0,0 +1,221 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/ptr"
+
+	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	"github.com/kubeflow/training-operator/pkg/controller.v1/registry"
+	commonutil "github.com/kubeflow/training-operator/pkg/util"
+	testjobv1 "github.com/kubeflow/training-operator/test_job/apis/test_job/v1"
+)
+
+func TestPodDependencies(t *testing.T) {
+	spec := &corev1.PodSpec{
+		Volumes: []corev1.Volume{
+			{Name: "data", VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "data"}}},
+			{Name: "creds", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "creds"}}},
+			{Name: "optional", VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "optional"}, Optional: ptr.To(true),
+			}}},
+			{Name: "projected", VolumeSource: corev1.VolumeSource{Projected: &corev1.ProjectedVolumeSource{Sources: []corev1.VolumeProjection{{
+				ConfigMap: &corev1.ConfigMapProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "projected"}},
+			}}}}},
+		},
+		InitContainers: []corev1.Container{{
+			Name:    "init",
+			EnvFrom: []corev1.EnvFromSource{{ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "init-env"}}}},
+		}},
+		Containers: []corev1.Container{{
+			Name: "test",
+			Env: []corev1.EnvVar{
+				{Name: "TOKEN", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "token"}, Key: "token",
+				}}},
+				{Name: "PLAIN", Value: "plain"},
+			},
+		}},
+	}
+	want := []dependency{
+		{kind: persistentVolumeClaimKind, name: "data"},
+		{kind: secretKind, name: "creds"},
+		{kind: configMapKind, name: "projected"},
+		{kind: configMapKind, name: "init-env"},
+		{kind: secretKind, name: "token"},
+	}
+	if diff := cmp.Diff(want, podDependencies(spec), cmp.AllowUnexported(dependency{})); len(diff) != 0 {
+		t.Errorf("Unexpected dependencies (-want,+got):\n%s", diff)
+	}
+}
+
+func TestReconcileDependencies(t *testing.T) {
+	job := &testjobv1.TestJob{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: metav1.NamespaceDefault}}
+	replicas := map[apiv1.ReplicaType]*apiv1.ReplicaSpec{
+		"worker": {Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+			Volumes: []corev1.Volume{
+				{Name: "data", VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "data"}}},
+				{Name: "creds", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "creds"}}},
+			},
+			Containers: []corev1.Container{{Name: "test"}},
+		}}},
+	}
+	recorder := record.NewFakeRecorder(10)
+	jc := &JobController{
+		Controller: &testJobController{frameworkController{framework: "test-framework"}},
+		KubeClientSet: kubefake.NewSimpleClientset(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "creds", Namespace: metav1.NamespaceDefault},
+		}),
+		WorkQueue: workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+		Recorder:  recorder,
+	}
+	jobStatus := &apiv1.JobStatus{}
+
+	for i := 0; i < 2; i++ {
+		if waiting, err := jc.reconcileDependencies(job, job, replicas, jobStatus); !waiting || err != nil {
+			t.Fatalf("Unexpected result while the PersistentVolumeClaim is missing: %v, %v", waiting, err)
+		}
+	}
+	if !commonutil.IsWaitingForDependencies(*jobStatus) {
+		t.Fatalf("Expected the job to be WaitingForDependencies")
+	}
+	wantMsg := "TestJob test is waiting for its dependencies: PersistentVolumeClaim data."
+	if got := jobStatus.Conditions[0].Message; got != wantMsg {
+		t.Errorf("Unexpected message, want: %q, got: %q", wantMsg, got)
+	}
+	// The job is reported once while its missing dependencies don't change.
+	if got := len(recorder.Events); got != 1 {
+		t.Errorf("Unexpected number of events, want: 1, got: %d", got)
+	}
+
+	_, err := jc.KubeClientSet.CoreV1().PersistentVolumeClaims(metav1.NamespaceDefault).Create(context.Background(),
+		&corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "data"}}, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("Failed to create the PersistentVolumeClaim: %v", err)
+	}
+	if waiting, err := jc.reconcileDependencies(job, job, replicas, jobStatus); waiting || err != nil {
+		t.Fatalf("Unexpected result once the dependencies exist: %v, %v", waiting, err)
+	}
+	if commonutil.IsWaitingForDependencies(*jobStatus) {
+		t.Errorf("Expected the job to be no longer WaitingForDependencies")
+	}
+}
+
+func (c *pytorchJobController) GetJobDependencies(job interface{}) []apiv1.JobReference {
+	return job.(*apiv1.PyTorchJob).Spec.DependsOn
+}
+
+func TestReconcileJobDependencies(t *testing.T) {
+	now := time.Now()
+	jobs := registry.New()
+	job := newPriorityPreemptionJob(metav1.NamespaceDefault, "train", "", "1", now, apiv1.JobCreated)
+	job.Spec.DependsOn = []apiv1.JobReference{{Name: "preprocess"}}
+	jc := &JobController{
+		Controller:  &pytorchJobController{frameworkController{framework: "pytorch"}},
+		JobRegistry: jobs,
+		WorkQueue:   workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+		Recorder:    record.NewFakeRecorder(10),
+	}
+	replicas := job.Spec.PyTorchReplicaSpecs
+	jobStatus := &apiv1.JobStatus{}
+
+	// The job waits for the jobs it depends on, even before they are created.
+	wantMsgs := map[apiv1.JobConditionType]string{
+		"":               "PyTorchJob train is waiting for its dependencies: PyTorchJob preprocess (not found).",
+		apiv1.JobRunning: "PyTorchJob train is waiting for its dependencies: PyTorchJob preprocess.",
+	}
+	for _, condition := range []apiv1.JobConditionType{"", apiv1.JobRunning} {
+		if condition != "" {
+			jobs.OnAdd(newPriorityPreemptionJob(metav1.NamespaceDefault, "preprocess", "", "1", now, condition))
+		}
+		if waiting, err := jc.reconcileDependencies(job, job, replicas, jobStatus); !waiting || err != nil {
+			t.Fatalf("Unexpected result while the job it depends on is %q: %v, %v", condition, waiting, err)
+		}
+		if msg := jc.failedJobDependencyMessage(job, *jobStatus, nil); msg != "" {
+			t.Errorf("Unexpected failure while the job it depends on is %q: %s", condition, msg)
+		}
+		if c := findCondition(jobStatus, apiv1.JobWaitingForDependencies); c == nil || c.Message != wantMsgs[condition] {
+			t.Errorf("Expected a WaitingForDependencies condition with the message %q, got: %v", wantMsgs[condition], jobStatus.Conditions)
+		}
+	}
+
+	// The job fails if a job it depends on fails before its pods are created.
+	jobs.OnAdd(newPriorityPreemptionJob(metav1.NamespaceDefault, "preprocess", "", "1", now, apiv1.JobFailed))
+	wantFailure := "Job train has failed because the PyTorchJob preprocess it depends on has failed"
+	if msg := jc.failedJobDependencyMessage(job, *jobStatus, nil); msg != wantFailure {
+		t.Errorf("Unexpected failure, want: %q, got: %q", wantFailure, msg)
+	}
+	pods := []*corev1.Pod{{ObjectMeta: metav1.ObjectMeta{Name: "train-master-0"}}}
+	if msg := jc.failedJobDependencyMessage(job, *jobStatus, pods); msg != "" {
+		t.Errorf("Unexpected failure of the job with pods: %s", msg)
+	}
+
+	// The pods are created once the jobs it depends on succeed.
+	jobs.OnAdd(newPriorityPreemptionJob(metav1.NamespaceDefault, "preprocess", "", "1", now, apiv1.JobSucceeded))
+	if waiting, err := jc.reconcileDependencies(job, job, replicas, jobStatus); waiting || err != nil {
+		t.Fatalf("Unexpected result once the job it depends on succeeded: %v, %v", waiting, err)
+	}
+	if commonutil.IsWaitingForDependencies(*jobStatus) {
+		t.Errorf("Expected the job to be no longer WaitingForDependencies")
+	}
+}
+
+func TestOnJobDependencyChange(t *testing.T) {
+	now := time.Now()
+	jobs := registry.New()
+	queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	jc := &JobController{
+		Controller:  &pytorchJobController{frameworkController{framework: "pytorch"}},
+		JobRegistry: jobs,
+		WorkQueue:   queue,
+	}
+	jobs.AddListener(jc.onJobDependencyChange)
+	jobs.OnAdd(newPriorityPreemptionJob(metav1.NamespaceDefault, "train", "", "1", now, apiv1.JobWaitingForDependencies))
+	jobs.OnAdd(newPriorityPreemptionJob("other", "train", "", "1", now, apiv1.JobWaitingForDependencies))
+
+	preprocess := newPriorityPreemptionJob(metav1.NamespaceDefault, "preprocess", "", "1", now, apiv1.JobRunning)
+	jobs.OnAdd(preprocess)
+	if queue.Len() != 0 {
+		t.Fatalf("Unexpected requeues while the job it depends on is running: %d", queue.Len())
+	}
+
+	// The waiting jobs of the namespace are requeued when a job finishes or is deleted.
+	succeeded := newPriorityPreemptionJob(metav1.NamespaceDefault, "preprocess", "", "1", now, apiv1.JobSucceeded)
+	for _, change := range []func(){
+		func() { jobs.OnUpdate(preprocess, succeeded) },
+		func() { jobs.OnDelete(succeeded) },
+	} {
+		change()
+		if queue.Len() != 1 {
+			t.Fatalf("Unexpected number of requeued jobs, want: 1, got: %d", queue.Len())
+		}
+		key, _ := queue.Get()
+		if key != "default/train" {
+			t.Errorf("Unexpected requeued job, want: default/train, got: %v", key)
+		}
+		queue.Done(key)
+		queue.Forget(key)
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/kubeflow/training-operator/pkg/config"
+	commonutil "github.com/kubeflow/training-operator/pkg/util"
+)
+
+// drainingReconciler runs the reconciles with a context which is not canceled when the shutdown
+// of the manager starts, but once the drain timeout expires after it.
+type drainingReconciler struct {
+	reconciler reconcile.Reconciler
+	timeout    time.Duration
+	clock      *commonutil.Clock
+
+	once     sync.Once
+	deadline time.Time
+}
+
+// NewDrainingReconciler returns the reconciler of a job controller draining its work on shutdown,
+// configured by the --shutdown-drain-timeout flag of the operator: the reconciles in flight and the
+// jobs still queued when the operator receives SIGTERM complete their status writes instead of
+// failing on the canceled context of the manager, until the timeout expires. The drain deadline
+// is read from the clock of the controller.
+// It returns the reconciler as is if the timeout is not set.
+func NewDrainingReconciler(reconciler reconcile.Reconciler, clock *commonutil.Clock) reconcile.Reconciler {
+	return newDrainingReconciler(reconciler, config.Config.ShutdownDrainTimeout, clock)
+}
+
+func newDrainingReconciler(reconciler reconcile.Reconciler, timeout time.Duration, clock *commonutil.Clock) reconcile.Reconciler {
+	if timeout <= 0 {
+		return reconciler
+	}
+	return &drainingReconciler{reconciler: reconciler, timeout: timeout, clock: clock}
+}
+
+func (r *drainingReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	drainCtx, cancel := r.drainContext(ctx)
+	defer cancel()
+	return r.reconciler.Reconcile(drainCtx, req)
+}
+
+// drainContext returns a context with the values of ctx, canceled at the drain deadline once ctx is canceled.
+func (r *drainingReconciler) drainContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	drainCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	stop := context.AfterFunc(ctx, func() {
+		timer := time.NewTimer(r.drainDeadline().Sub(r.clock.Now()))
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+			cancel()
+		case <-drainCtx.Done():
+		}
+	})
+	return drainCtx, func() {
+		stop()
+		cancel()
+	}
+}
+
+// drainDeadline returns the deadline of the drain, the drain timeout after the first reconcile
+// observed the shutdown, so that the reconciles of the jobs dequeued later share the same deadline.
+func (r *drainingReconciler) drainDeadline() time.Time {
+	r.once.Do(func() {
+		r.deadline = r.clock.Now().Add(r.timeout)
+	})
+	return r.deadline
+}
@@ -0,0 +1,64 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestDrainingReconciler(t *testing.T) {
+	release := make(chan struct{})
+	inner := reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+		select {
+		case <-release:
+		case <-ctx.Done():
+		}
+		return reconcile.Result{}, ctx.Err()
+	})
+	r := newDrainingReconciler(inner, 100*time.Millisecond, nil)
+
+	// The reconcile in flight outlives the cancellation of the context of the manager.
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		done <- err
+	}()
+	cancel()
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	if err := <-done; err != nil {
+		t.Errorf("Expected the reconcile to complete during the drain, got %v", err)
+	}
+
+	// The reconciles still running when the drain timeout expires are canceled.
+	release = make(chan struct{})
+	if _, err := r.Reconcile(ctx, reconcile.Request{}); err == nil {
+		t.Error("Expected the reconcile to be canceled after the drain timeout")
+	}
+}
+
+func TestDrainingReconcilerDisabled(t *testing.T) {
+	inner := reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+		return reconcile.Result{}, nil
+	})
+	if _, ok := newDrainingReconciler(inner, 0, nil).(reconcile.Func); !ok {
+		t.Error("Expected the reconciler to be returned as is without a drain timeout")
+	}
+}
@@ -0,0 +1,31 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+)
+
+// IsClusterSpecEnvDisabled returns true if the env vars describing the cluster of the job, e.g.
+// TF_CONFIG or MASTER_ADDR, must not be injected into its pods.
+func IsClusterSpecEnvDisabled(runPolicy *apiv1.RunPolicy) bool {
+	return runPolicy.EnvInjectionPolicy != nil && runPolicy.EnvInjectionPolicy.DisableClusterSpec
+}
+
+// IsMPIEnvDisabled returns true if the env vars of the MPI implementation, e.g.
+// OMPI_MCA_plm_rsh_agent, must not be injected into the pods of the job.
+func IsMPIEnvDisabled(runPolicy *apiv1.RunPolicy) bool {
+	return runPolicy.EnvInjectionPolicy != nil && runPolicy.EnvInjectionPolicy.DisableMPI
+}
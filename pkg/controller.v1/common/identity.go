@@ -0,0 +1,49 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	"github.com/kubeflow/training-operator/pkg/config"
+)
+
+// ReconciledByThisController returns whether the job is reconciled by this operator deployment, which
+// runs side by side with the deployments of other identities when canarying a new version: the jobs
+// pinned by the ControllerIdentityAnnotation are reconciled by the deployment of the same identity
+// only, and the other jobs by the deployments which are not started with --skip-unpinned-jobs.
+func ReconciledByThisController(job metav1.Object) bool {
+	return reconciledBy(job, config.Config.ControllerIdentity, config.Config.SkipUnpinnedJobs)
+}
+
+func reconciledBy(job metav1.Object, identity string, skipUnpinned bool) bool {
+	pinned := job.GetAnnotations()[apiv1.ControllerIdentityAnnotation]
+	if len(pinned) == 0 {
+		return !skipUnpinned
+	}
+	return pinned == identity
+}
+
+// ControllerLeaderElectionID returns the ID of the leader election of the operator deployment of the
+// identity, so that the deployments of different identities each elect their own leader.
+func ControllerLeaderElectionID(leaderElectionID, identity string) string {
+	if len(identity) == 0 {
+		return leaderElectionID
+	}
+	return fmt.Sprintf("%s.%s", identity, leaderElectionID)
+}
@@ -0,0 +1,84 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+)
+
+func TestReconciledBy(t *testing.T) {
+	unpinned := &apiv1.PyTorchJob{}
+	canary := &apiv1.PyTorchJob{ObjectMeta: metav1.ObjectMeta{
+		Annotations: map[string]string{apiv1.ControllerIdentityAnnotation: "canary"},
+	}}
+
+	testCases := map[string]struct {
+		job          metav1.Object
+		identity     string
+		skipUnpinned bool
+		want         bool
+	}{
+		"unpinned job, controller without identity": {
+			job:  unpinned,
+			want: true,
+		},
+		"unpinned job, stable controller": {
+			job:      unpinned,
+			identity: "stable",
+			want:     true,
+		},
+		"unpinned job, canary controller skipping the unpinned jobs": {
+			job:          unpinned,
+			identity:     "canary",
+			skipUnpinned: true,
+			want:         false,
+		},
+		"job pinned to the canary, stable controller": {
+			job:      canary,
+			identity: "stable",
+			want:     false,
+		},
+		"job pinned to the canary, controller without identity": {
+			job:  canary,
+			want: false,
+		},
+		"job pinned to the canary, canary controller": {
+			job:          canary,
+			identity:     "canary",
+			skipUnpinned: true,
+			want:         true,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := reconciledBy(tc.job, tc.identity, tc.skipUnpinned); got != tc.want {
+				t.Errorf("Unexpected reconciledBy(): got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestControllerLeaderElectionID(t *testing.T) {
+	if got := ControllerLeaderElectionID("training-operator.kubeflow.org", ""); got != "training-operator.kubeflow.org" {
+		t.Errorf("Unexpected leader election ID without identity: %s", got)
+	}
+	if got := ControllerLeaderElectionID("training-operator.kubeflow.org", "canary"); got != "canary.training-operator.kubeflow.org" {
+		t.Errorf("Unexpected leader election ID of the canary: %s", got)
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/kubeflow/training-operator/pkg/config"
+)
+
+// ParseImagePullSecrets parses the comma-separated names of the image pull secrets of the
+// --default-image-pull-secrets flag.
+func ParseImagePullSecrets(value string) ([]string, error) {
+	var names []string
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if errs := validation.IsDNS1123Subdomain(name); len(errs) != 0 {
+			return nil, fmt.Errorf("invalid image pull secret %q: %s", name, strings.Join(errs, ", "))
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// SetDefaultImagePullSecrets appends the default image pull secrets of the operator which the
+// pod template does not reference yet to its image pull secrets. The secrets are referenced by
+// name, so they must exist in the namespace of the job.
+func SetDefaultImagePullSecrets(podTemplate *corev1.PodTemplateSpec) {
+	for _, name := range config.Config.DefaultImagePullSecrets {
+		referenced := false
+		for _, secret := range podTemplate.Spec.ImagePullSecrets {
+			if secret.Name == name {
+				referenced = true
+				break
+			}
+		}
+		if !referenced {
+			podTemplate.Spec.ImagePullSecrets = append(podTemplate.Spec.ImagePullSecrets, corev1.LocalObjectReference{Name: name})
+		}
+	}
+}
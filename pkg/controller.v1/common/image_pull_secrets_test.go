@@ -0,0 +1,64 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/kubeflow/training-operator/pkg/config"
+)
+
+func TestParseImagePullSecrets(t *testing.T) {
+	got, err := ParseImagePullSecrets("registry-creds, ,mirror-creds")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if diff := cmp.Diff([]string{"registry-creds", "mirror-creds"}, got); len(diff) != 0 {
+		t.Errorf("Unexpected image pull secrets (-want,+got):\n%s", diff)
+	}
+	if _, err := ParseImagePullSecrets("registry_creds"); err == nil {
+		t.Error("Expected an error for an invalid secret name")
+	}
+}
+
+func TestSetDefaultImagePullSecrets(t *testing.T) {
+	defer func(secrets []string) { config.Config.DefaultImagePullSecrets = secrets }(config.Config.DefaultImagePullSecrets)
+	config.Config.DefaultImagePullSecrets = []string{"registry-creds", "mirror-creds"}
+
+	cases := map[string]struct {
+		secrets []corev1.LocalObjectReference
+		want    []corev1.LocalObjectReference
+	}{
+		"defaults are appended": {
+			want: []corev1.LocalObjectReference{{Name: "registry-creds"}, {Name: "mirror-creds"}},
+		},
+		"secrets of the template are kept": {
+			secrets: []corev1.LocalObjectReference{{Name: "team-creds"}, {Name: "mirror-creds"}},
+			want:    []corev1.LocalObjectReference{{Name: "team-creds"}, {Name: "mirror-creds"}, {Name: "registry-creds"}},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			podTemplate := &corev1.PodTemplateSpec{Spec: corev1.PodSpec{ImagePullSecrets: tc.secrets}}
+			SetDefaultImagePullSecrets(podTemplate)
+			if diff := cmp.Diff(tc.want, podTemplate.Spec.ImagePullSecrets); len(diff) != 0 {
+				t.Errorf("Unexpected image pull secrets (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	commonutil "github.com/kubeflow/training-operator/pkg/util"
+)
+
+const (
+	// containerCreatingReason is the reason of a container waiting for its image to be pulled
+	// and to be created.
+	containerCreatingReason = "ContainerCreating"
+	// errImagePullReason and imagePullBackOffReason are the reasons of a container whose image
+	// fails to be pulled.
+	errImagePullReason     = "ErrImagePull"
+	imagePullBackOffReason = "ImagePullBackOff"
+)
+
+// imagePullState is the state of the pull of the images of a pod bound to a node.
+type imagePullState int
+
+const (
+	imagesPulled imagePullState = iota
+	imagesPulling
+	imagesPullFailing
+)
+
+// podImagePullState returns the state of the pull of the images of the pod bound to a node, and
+// the message of the kubelet for the container failing to pull its image, if any.
+func podImagePullState(pod *corev1.Pod) (imagePullState, string) {
+	statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	state := imagesPulled
+	if pod.Status.Phase == corev1.PodPending && len(statuses) == 0 {
+		// The kubelet has not reported the containers of the pod yet.
+		state = imagesPulling
+	}
+	for _, status := range statuses {
+		if status.State.Waiting == nil {
+			continue
+		}
+		switch status.State.Waiting.Reason {
+		case errImagePullReason, imagePullBackOffReason:
+			return imagesPullFailing, fmt.Sprintf("%s: %s", status.Name, status.State.Waiting.Message)
+		case containerCreatingReason:
+			state = imagesPulling
+		}
+	}
+	return state, ""
+}
+
+// podScheduledTime returns the time the pod was bound to a node, or its creation time.
+func podScheduledTime(pod *corev1.Pod) time.Time {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodScheduled && condition.Status == corev1.ConditionTrue {
+			return condition.LastTransitionTime.Time
+		}
+	}
+	return pod.CreationTimestamp.Time
+}
+
+// formatImagePullWait formats the average wait of the pods in minutes, so that the message of the
+// condition, and thus the status of the job, is not updated on every reconcile.
+func formatImagePullWait(wait time.Duration) string {
+	if wait < time.Minute {
+		return "less than a minute"
+	}
+	return wait.Truncate(time.Minute).String()
+}
+
+// updateImagePullCondition sets the PullingImages condition while the job is starting and some of
+// its pods bound to a node wait for their images, with the number of pods pulling, failing to pull
+// and done pulling their images, and the average time the waiting pods have waited since they were
+// scheduled, in minutes. The condition is removed once the images of all the pods are pulled, or
+// once the job runs.
+func (jc *JobController) updateImagePullCondition(metaObject metav1.Object, runtimeObject runtime.Object, jobStatus *apiv1.JobStatus, pods []*corev1.Pod) {
+	if commonutil.IsRunning(*jobStatus) {
+		removeCondition(jobStatus, apiv1.JobPullingImages)
+		return
+	}
+	var pulling, failing, pulled int
+	var wait time.Duration
+	var failingPod, failingMessage string
+	for _, pod := range pods {
+		if pod.DeletionTimestamp != nil || pod.Spec.NodeName == "" {
+			continue
+		}
+		state, message := podImagePullState(pod)
+		switch state {
+		case imagesPulled:
+			pulled++
+			continue
+		case imagesPulling:
+			pulling++
+		case imagesPullFailing:
+			failing++
+			// The message of the first pod by name is reported, so that it doesn't
+			// change with the order of the pods.
+			if failingPod == "" || pod.Name < failingPod {
+				failingPod, failingMessage = pod.Name, message
+			}
+		}
+		wait += jc.Clock.Since(podScheduledTime(pod))
+	}
+	if pulling+failing == 0 {
+		removeCondition(jobStatus, apiv1.JobPullingImages)
+		return
+	}
+	jobKind := jc.Controller.GetAPIGroupVersionKind().Kind
+	msg := fmt.Sprintf("%d pod(s) of %s %s are pulling their images, %d pulled, waiting for %s on average.",
+		pulling+failing, jobKind, metaObject.GetName(), pulled, formatImagePullWait(wait/time.Duration(pulling+failing)))
+	if failing > 0 {
+		msg = fmt.Sprintf("%s %d pod(s) fail to pull their images. Pod %s: %s", msg, failing, failingPod, failingMessage)
+	}
+	reason := commonutil.NewReason(jobKind, commonutil.JobPullingImagesReason)
+	if jc.setTransientCondition(jobStatus, apiv1.JobPullingImages, reason, msg) {
+		jc.Recorder.Event(runtimeObject, corev1.EventTypeNormal, reason, msg)
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	clocktesting "k8s.io/utils/clock/testing"
+
+	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	commonutil "github.com/kubeflow/training-operator/pkg/util"
+	testjobv1 "github.com/kubeflow/training-operator/test_job/apis/test_job/v1"
+)
+
+func newImagePullPod(name string, scheduledAt time.Time, waiting ...corev1.ContainerStateWaiting) *corev1.Pod {
+	pod := newPod(name, corev1.PodPending)
+	pod.Spec.NodeName = "node"
+	pod.Status.Conditions = []corev1.PodCondition{{
+		Type:               corev1.PodScheduled,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.NewTime(scheduledAt),
+	}}
+	for i := range waiting {
+		pod.Status.ContainerStatuses = append(pod.Status.ContainerStatuses, corev1.ContainerStatus{
+			Name:  "main",
+			State: corev1.ContainerState{Waiting: &waiting[i]},
+		})
+	}
+	return pod
+}
+
+func TestUpdateImagePullCondition(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	job := &testjobv1.TestJob{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: metav1.NamespaceDefault}}
+	recorder := record.NewFakeRecorder(10)
+	jc := &JobController{
+		Controller: &testJobController{frameworkController{framework: "test-framework"}},
+		Recorder:   recorder,
+		Clock:      commonutil.NewClock(clocktesting.NewFakePassiveClock(now), 0),
+	}
+	jobStatus := &apiv1.JobStatus{}
+	pulled := newImagePullPod("test-worker-0", now.Add(-10*time.Minute))
+	pulled.Status.Phase = corev1.PodRunning
+	pending := newPod("test-worker-4", corev1.PodPending)
+	pods := []*corev1.Pod{
+		pulled,
+		newImagePullPod("test-worker-1", now.Add(-2*time.Minute), corev1.ContainerStateWaiting{Reason: containerCreatingReason}),
+		newImagePullPod("test-worker-2", now.Add(-6*time.Minute)),
+		newImagePullPod("test-worker-3", now.Add(-4*time.Minute), corev1.ContainerStateWaiting{
+			Reason:  imagePullBackOffReason,
+			Message: `Back-off pulling image "registry/train:latest"`,
+		}),
+		pending,
+	}
+
+	jc.updateImagePullCondition(job, job, jobStatus, pods)
+	if !commonutil.IsPullingImages(*jobStatus) {
+		t.Fatalf("Expected a PullingImages condition, got: %v", jobStatus.Conditions)
+	}
+	condition := findCondition(jobStatus, apiv1.JobPullingImages)
+	if want := "TestJobPullingImages"; condition.Reason != want {
+		t.Errorf("Unexpected reason, want: %q, got: %q", want, condition.Reason)
+	}
+	want := `3 pod(s) of TestJob test are pulling their images, 1 pulled, waiting for 4m0s on average. ` +
+		`1 pod(s) fail to pull their images. Pod test-worker-3: main: Back-off pulling image "registry/train:latest"`
+	if condition.Message != want {
+		t.Errorf("Unexpected message, want: %q, got: %q", want, condition.Message)
+	}
+
+	// The message follows the pods which are still pulling their images.
+	jc.updateImagePullCondition(job, job, jobStatus, pods[:2])
+	want = "1 pod(s) of TestJob test are pulling their images, 1 pulled, waiting for 2m0s on average."
+	if got := findCondition(jobStatus, apiv1.JobPullingImages).Message; got != want {
+		t.Errorf("Unexpected message, want: %q, got: %q", want, got)
+	}
+	if got := len(recorder.Events); got != 1 {
+		t.Errorf("Unexpected number of events, want: 1, got: %d", got)
+	}
+
+	jc.updateImagePullCondition(job, job, jobStatus, pods[:1])
+	if len(jobStatus.Conditions) != 0 {
+		t.Errorf("Expected the PullingImages condition to be removed, got: %v", jobStatus.Conditions)
+	}
+
+	// The condition is not set once the job is running.
+	commonutil.UpdateJobConditions(jobStatus, apiv1.JobRunning, corev1.ConditionTrue, "", "", nil)
+	jc.updateImagePullCondition(job, job, jobStatus, pods)
+	if commonutil.IsPullingImages(*jobStatus) {
+		t.Errorf("Expected no PullingImages condition for a running job, got: %v", jobStatus.Conditions)
+	}
+}
+
+func TestFormatImagePullWait(t *testing.T) {
+	if got := formatImagePullWait(30 * time.Second); got != "less than a minute" {
+		t.Errorf("Unexpected wait: %s", got)
+	}
+	if got := formatImagePullWait(150 * time.Second); got != "2m0s" {
+		t.Errorf("Unexpected wait: %s", got)
+	}
+}
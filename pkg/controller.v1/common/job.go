@@ -17,29 +17,34 @@ limitations under the License.
 package common
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
 	"time"
 
 	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	"github.com/kubeflow/training-operator/pkg/common"
+	"github.com/kubeflow/training-operator/pkg/config"
 	"github.com/kubeflow/training-operator/pkg/controller.v1/expectation"
 	"github.com/kubeflow/training-operator/pkg/core"
 	commonutil "github.com/kubeflow/training-operator/pkg/util"
 	"github.com/kubeflow/training-operator/pkg/util/k8sutil"
 	trainutil "github.com/kubeflow/training-operator/pkg/util/train"
 
-	log "github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
 	schedulerpluginsv1alpha1 "sigs.k8s.io/scheduler-plugins/apis/scheduling/v1alpha1"
 	volcanov1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
 )
 
 // DeletePodsAndServices deletes pods and services considering cleanPodPolicy.
 // However, if the job doesn't have Succeeded or Failed condition, it ignores cleanPodPolicy.
+// The services of a finished job are kept if the job retains its services.
 func (jc *JobController) DeletePodsAndServices(runtimeObject runtime.Object, runPolicy *apiv1.RunPolicy, jobStatus apiv1.JobStatus, pods []*corev1.Pod) error {
 	if len(pods) == 0 {
 		return nil
@@ -50,6 +55,13 @@ func (jc *JobController) DeletePodsAndServices(runtimeObject runtime.Object, run
 		return nil
 	}
 
+	// Give the running pods a chance to save a checkpoint before they are deleted.
+	// The job is requeued once their checkpoint deadline has passed.
+	if runPolicy.CheckpointPolicy != nil && jc.checkpointPods(runtimeObject, runPolicy.CheckpointPolicy, jc.Controller.GetDefaultContainerName(), pods) {
+		return nil
+	}
+
+	retainServices := commonutil.IsFinished(jobStatus) && ptr.Deref(runPolicy.RetainServices, false)
 	for _, pod := range pods {
 		// Note that pending pod will turn into running once schedulable,
 		// not cleaning it may leave orphan running pod in the future,
@@ -57,10 +69,14 @@ func (jc *JobController) DeletePodsAndServices(runtimeObject runtime.Object, run
 		if commonutil.IsFinished(jobStatus) && *runPolicy.CleanPodPolicy == apiv1.CleanPodPolicyRunning && pod.Status.Phase != corev1.PodRunning && pod.Status.Phase != corev1.PodPending {
 			continue
 		}
-		if err := jc.PodControl.DeletePod(pod.Namespace, pod.Name, runtimeObject); err != nil {
+		if err := jc.deletePod(pod, runtimeObject); err != nil {
 			return err
 		}
+		if retainServices {
+			continue
+		}
 		// Pod and service have the same name, thus the service could be deleted using pod's name.
+		jc.RecordAPICall(runtimeObject, APICallDelete)
 		if err := jc.ServiceControl.DeleteService(pod.Namespace, pod.Name, runtimeObject); err != nil {
 			return err
 		}
@@ -96,32 +112,91 @@ func (jc *JobController) ReconcileJobs(
 		return err
 	}
 	jobKind := jc.Controller.GetAPIGroupVersionKind().Kind
+	logger := commonutil.LoggerForJob(metaObject)
+	standalone := trainutil.IsJobStandalone(runPolicy)
+	if standalone {
+		collapseStandaloneReplicas(replicas, jc.Controller.IsMasterRole)
+	}
+	if runPolicy.SchedulingPolicy != nil && runPolicy.SchedulingPolicy.SameTopology != "" {
+		// The replica specs belong to the copy of the job fetched for this
+		// reconciliation, so the pod affinity is not written back to the job.
+		for _, spec := range replicas {
+			if spec != nil {
+				core.SetSameTopology(&spec.Template, runPolicy.SchedulingPolicy.SameTopology, jc.GenLabels(jobName))
+			}
+		}
+	}
+	if runPolicy.TopologyPolicy != nil {
+		for _, spec := range replicas {
+			if spec != nil {
+				core.SetTopologyPolicy(&spec.Template, runPolicy.TopologyPolicy, jc.GenLabels(jobName))
+			}
+		}
+	}
 	// Reset expectations
 	// 1. Since `ReconcileJobs` is called, we expect that previous expectations are all satisfied,
 	//    and it's safe to reset the expectations
 	// 2. Reset expectations can avoid dirty data such as `expectedDeletion = -1`
 	//    (pod or service was deleted unexpectedly)
 	if err = jc.ResetExpectations(jobKey, replicas); err != nil {
-		log.Warnf("Failed to reset expectations: %v", err)
+		logger.Error(err, "Failed to reset expectations")
 	}
 
-	log.Infof("Reconciling for job %s", metaObject.GetName())
+	logger.V(1).Info("Reconciling job")
 	pods, err := jc.Controller.GetPodsForJob(job)
+	var deferred *AdoptionDeferredError
+	if errors.As(err, &deferred) {
+		// The job doesn't create the replacements of the orphan pods it is still to adopt.
+		logger.Info("Deferring the reconcile of the job until its orphan pods are adopted", "deferred", deferred.Deferred)
+		jc.WorkQueue.AddAfter(jobKey, deferred.RequeueAfter)
+		return nil
+	}
 	if err != nil {
-		log.Warnf("GetPodsForJob error %v", err)
+		logger.Error(err, "Failed to get the pods of the job")
 		return err
 	}
 
 	services, err := jc.Controller.GetServicesForJob(job)
 	if err != nil {
-		log.Warnf("GetServicesForJob error %v", err)
+		logger.Error(err, "Failed to get the services of the job")
 		return err
 	}
+	jc.RecordOOMKills(metaObject, pods)
 
+	// jobStatus shares its maps and pointers with the status of the cached job. Work on a
+	// deep copy, so that the status of the job is left untouched and only the difference
+	// is patched in the API server.
+	jobStatus = *jobStatus.DeepCopy()
 	oldStatus := jobStatus.DeepCopy()
+	// A cleared job without pods may be a finished job whose pods were cleaned up.
+	var terminalEvent *corev1.Event
+	if len(pods) == 0 && len(jobStatus.Conditions) == 0 && jobStatus.StartTime == nil {
+		if terminalEvent, err = jc.terminalAuditEvent(metaObject); err != nil {
+			logger.Error(err, "Failed to get the events of the job")
+			return err
+		}
+	}
+	if reconstructJobStatus(&jobStatus, metaObject, jobKind, pods, terminalEvent, jc.Clock) {
+		source := reconstructionSource(pods, terminalEvent)
+		logger.Info("Reconstructed the cleared status of the job", "source", source)
+		jc.Recorder.Event(runtimeObject, corev1.EventTypeWarning, commonutil.JobStatusReconstructedReason,
+			fmt.Sprintf("The status of %s %s was cleared and is reconstructed from %s", jobKind, jobName, source))
+	}
+	jobStatus.RunID = RunID(metaObject)
+	// The pods whose training has finished complete once their service mesh sidecar quits.
+	jc.quitServiceMeshSidecars(metaObject, runtimeObject, pods)
+
 	if commonutil.IsFinished(jobStatus) {
+		jc.forgetRestart(metaObject, &jobStatus)
+		removeSchedulingConditions(&jobStatus)
+		if commonutil.IsSucceeded(jobStatus) {
+			// The outputs written by the training are read from the pods before they are deleted.
+			if err := jc.RecordOutputs(runtimeObject, metaObject, &jobStatus, pods); err != nil {
+				return err
+			}
+		}
 		// If the Job is succeeded or failed, delete all pods, services, and podGroup.
-		if err = jc.CleanUpResources(runPolicy, runtimeObject, metaObject, jobStatus, pods); err != nil {
+		if err = jc.CleanUpResources(runPolicy, runtimeObject, metaObject, &jobStatus, pods); err != nil {
 			return err
 		}
 
@@ -137,37 +212,54 @@ func (jc *JobController) ReconcileJobs(
 
 		// No need to update the job status if the status hasn't changed since last time.
 		if !reflect.DeepEqual(*oldStatus, jobStatus) {
-			return jc.Controller.UpdateJobStatusInApiServer(job, &jobStatus)
+			return jc.updateJobStatusInApiServer(job, oldStatus, &jobStatus)
 		}
 
 		return nil
 	}
 
 	if trainutil.IsJobSuspended(runPolicy) {
-		if err = jc.CleanUpResources(runPolicy, runtimeObject, metaObject, jobStatus, pods); err != nil {
+		jc.forgetRestart(metaObject, &jobStatus)
+		removeSchedulingConditions(&jobStatus)
+		// A suspended job releases the resources of its queue, and waits for them again once resumed.
+		removeCondition(&jobStatus, apiv1.JobPending)
+		if err = jc.CleanUpResources(runPolicy, runtimeObject, metaObject, &jobStatus, pods); err != nil {
 			return err
 		}
 		for rType := range jobStatus.ReplicaStatuses {
 			jobStatus.ReplicaStatuses[rType].Active = 0
 		}
 		msg := fmt.Sprintf("%s %s is suspended.", jobKind, jobName)
+		if preemptor, ok := metaObject.GetAnnotations()[apiv1.PriorityPreemptedByAnnotation]; ok {
+			var queue string
+			if runPolicy.SchedulingPolicy != nil {
+				queue = runPolicy.SchedulingPolicy.Queue
+			}
+			msg = fmt.Sprintf("%s %s is suspended for %s of higher priority, %s.", jobKind, jobName, preemptor, preemptedResumeHint(queue, preemptor, jc.JobQueues))
+		}
 		if commonutil.IsRunning(jobStatus) {
-			commonutil.UpdateJobConditions(&jobStatus, apiv1.JobRunning, corev1.ConditionFalse, commonutil.NewReason(jobKind, commonutil.JobSuspendedReason), msg)
+			commonutil.UpdateJobConditions(&jobStatus, apiv1.JobRunning, corev1.ConditionFalse, commonutil.NewReason(jobKind, commonutil.JobSuspendedReason), msg, jc.Clock)
 		}
 		// We add the suspended condition to the job only when the job doesn't have a suspended condition.
 		if !commonutil.IsSuspended(jobStatus) {
-			commonutil.UpdateJobConditions(&jobStatus, apiv1.JobSuspended, corev1.ConditionTrue, commonutil.NewReason(jobKind, commonutil.JobSuspendedReason), msg)
+			commonutil.UpdateJobConditions(&jobStatus, apiv1.JobSuspended, corev1.ConditionTrue, commonutil.NewReason(jobKind, commonutil.JobSuspendedReason), msg, jc.Clock)
 		}
 		jc.Recorder.Event(runtimeObject, corev1.EventTypeNormal, commonutil.NewReason(jobKind, commonutil.JobSuspendedReason), msg)
+		if err := jc.resumePreemptedJob(metaObject, runPolicy); err != nil {
+			return err
+		}
 		if !reflect.DeepEqual(*oldStatus, jobStatus) {
-			return jc.Controller.UpdateJobStatusInApiServer(job, &jobStatus)
+			return jc.updateJobStatusInApiServer(job, oldStatus, &jobStatus)
 		}
 		return nil
 	}
+	if err := jc.forgetPriorityPreemption(metaObject); err != nil {
+		return err
+	}
 	if commonutil.IsSuspended(jobStatus) {
 		msg := fmt.Sprintf("%s %s is resumed.", jobKind, jobName)
-		commonutil.UpdateJobConditions(&jobStatus, apiv1.JobSuspended, corev1.ConditionFalse, commonutil.NewReason(jobKind, commonutil.JobResumedReason), msg)
-		now := metav1.Now()
+		commonutil.UpdateJobConditions(&jobStatus, apiv1.JobSuspended, corev1.ConditionFalse, commonutil.NewReason(jobKind, commonutil.JobResumedReason), msg, jc.Clock)
+		now := jc.Clock.MetaNow()
 		jobStatus.StartTime = &now
 		jc.Recorder.Eventf(runtimeObject, corev1.EventTypeNormal, commonutil.NewReason(jobKind, commonutil.JobResumedReason), msg)
 	}
@@ -179,10 +271,8 @@ func (jc *JobController) ReconcileJobs(
 
 	jc.recordAbnormalPods(activePods, runtimeObject)
 
-	active := int32(len(activePods))
-	failed := k8sutil.FilterPodCount(pods, corev1.PodFailed)
+	failurePolicy := evaluateFailurePolicy(jobName, runPolicy.FailurePolicy, jc.Controller.GetDefaultContainerName(), pods)
 	totalReplicas := k8sutil.GetTotalReplicas(replicas)
-	prevReplicasFailedNum := k8sutil.GetTotalFailedReplicas(jobStatus.ReplicaStatuses)
 
 	var failureMessage string
 	jobExceedsLimit := false
@@ -190,12 +280,8 @@ func (jc *JobController) ReconcileJobs(
 	pastBackoffLimit := false
 
 	if runPolicy.BackoffLimit != nil {
-		jobHasNewFailure := failed > prevReplicasFailedNum
-		// new failures happen when status does not reflect the failures and active
-		// is different from parallelism, otherwise the previous controller loop
-		// failed updating status so even if we pick up failure it is not a new one
-		exceedsBackoffLimit = jobHasNewFailure && (active != totalReplicas) &&
-			(int32(previousRetry)+1 > *runPolicy.BackoffLimit)
+		exceedsBackoffLimit = exceedsBackoffLimitOnNewFailure(runPolicy, jobStatus, pods, failurePolicy.ignoredPods,
+			int32(len(activePods)), totalReplicas, previousRetry)
 
 		pastBackoffLimit, err = jc.PastBackoffLimit(jobName, runPolicy, replicas, pods)
 		if err != nil {
@@ -208,20 +294,30 @@ func (jc *JobController) ReconcileJobs(
 		// OR if the number of failed jobs increased since the last syncJob
 		jobExceedsLimit = true
 		failureMessage = fmt.Sprintf("Job %s has failed because it has reached the specified backoff limit", jobName)
+	} else if exceedsGangRestartLimit(runPolicy, replicas, pods, jobStatus) {
+		jobExceedsLimit = true
+		failureMessage = fmt.Sprintf("Job %s has failed because it has reached the specified backoff limit after %d gang restarts",
+			jobName, jobStatus.GangRestarts)
+	} else if failurePolicy.failJobMessage != "" {
+		failureMessage = failurePolicy.failJobMessage
+		jobExceedsLimit = true
 	} else if jc.PastActiveDeadline(runPolicy, jobStatus) {
 		failureMessage = fmt.Sprintf("Job %s has failed because it was active longer than specified deadline", jobName)
 		jobExceedsLimit = true
+	} else if msg := jc.failedJobDependencyMessage(metaObject, jobStatus, pods); msg != "" {
+		failureMessage = msg
+		jobExceedsLimit = true
 	}
 
 	if jobExceedsLimit {
 		// Set job completion time before resource cleanup
 		if jobStatus.CompletionTime == nil {
-			now := metav1.Now()
+			now := jc.Clock.MetaNow()
 			jobStatus.CompletionTime = &now
 		}
 
-		// If the Job exceeds backoff limit or is past active deadline
-		// delete all pods and services, then set the status to failed
+		// If the Job exceeds backoff limit, matches a FailJob rule of the failure policy
+		// or is past active deadline delete all pods and services, then set the status to failed
 		if err := jc.DeletePodsAndServices(runtimeObject, runPolicy, jobStatus, pods); err != nil {
 			return err
 		}
@@ -230,22 +326,170 @@ func (jc *JobController) ReconcileJobs(
 			return err
 		}
 
-		if jc.Config.EnableGangScheduling() {
-			jc.Recorder.Event(runtimeObject, corev1.EventTypeNormal, "JobTerminated", "Job has been terminated. Deleting PodGroup")
-			if err := jc.DeletePodGroup(metaObject); err != nil {
-				jc.Recorder.Eventf(runtimeObject, corev1.EventTypeWarning, "FailedDeletePodGroup", "Error deleting: %v", err)
+		if err := jc.CleanupPodGroup(runtimeObject, metaObject, &jobStatus); err != nil {
+			return err
+		}
+
+		if err := jc.DeleteRayCluster(runtimeObject, metaObject, runPolicy); err != nil {
+			return err
+		}
+
+		if err := jc.DeletePodDisruptionBudget(runtimeObject, metaObject); err != nil {
+			return err
+		}
+
+		if err := jc.DeleteTensorBoard(runtimeObject, metaObject, runPolicy, &jobStatus); err != nil {
+			return err
+		}
+
+		jc.forgetRestart(metaObject, &jobStatus)
+		jc.Recorder.Event(runtimeObject, corev1.EventTypeNormal, commonutil.NewReason(jobKind, commonutil.JobFailedReason), failureMessage)
+
+		commonutil.FinishJob(&jobStatus, apiv1.JobFailed, commonutil.NewReason(jobKind, commonutil.JobFailedReason), failureMessage, jc.Clock)
+		addOOMKilledHint(&jobStatus, oldStatus, pods)
+
+		if err := jc.updateJobStatusInApiServer(job, oldStatus, &jobStatus); err != nil {
+			return err
+		}
+		jc.recordJobCompleted(runtimeObject, jobKind, klog.KObj(metaObject).String(), *oldStatus, jobStatus, pods)
+		recordJobMetrics(metaObject, jc.Controller.GetFrameworkName(), *oldStatus, jobStatus, jc.Clock)
+		return nil
+	} else {
+		// The pods bound to a failed node are recreated on another node once their forced
+		// deletion is observed. They don't wait for the restarts of the other jobs, since
+		// they are no longer running anyway.
+		lostPods, requeueAfter, err := jc.podsOnFailedNodes(pods)
+		if err != nil {
+			return err
+		}
+		if requeueAfter > 0 {
+			jc.WorkQueue.AddAfter(jobKey, requeueAfter)
+		}
+		if len(lostPods) > 0 {
+			if err := jc.reschedulePodsFromFailedNodes(metaObject, runtimeObject, &jobStatus, lostPods); err != nil {
 				return err
+			}
+			if !reflect.DeepEqual(*oldStatus, jobStatus) {
+				return jc.updateJobStatusInApiServer(job, oldStatus, &jobStatus)
+			}
+			return nil
+		}
+
+		// All the pods of a job are restarted together once the node of one of them starts
+		// being reclaimed, e.g. a spot instance, after they are asked to save a checkpoint.
+		// The restarts wait while too many jobs are restarting, and back off when the job
+		// was restarted on a preemption shortly before, meanwhile the job is reconciled as usual.
+		preemptedPod, signal, err := jc.podOnPreemptedNode(pods)
+		if err != nil {
+			return err
+		}
+		if preemptedPod != nil {
+			if backoff := jc.preemptionRestartBackoff(metaObject); backoff > 0 {
+				logger.Info("Backing off the restart of the job on a preemption", "pod", preemptedPod.Name, "node", preemptedPod.Spec.NodeName, "backoff", backoff)
+				jc.WorkQueue.AddAfter(jobKey, backoff)
 			} else {
-				jc.Recorder.Eventf(runtimeObject, corev1.EventTypeNormal, "SuccessfulDeletePodGroup", "Deleted PodGroup: %v", jobName)
+				if jc.acquireRestart(metaObject, runtimeObject, &jobStatus) {
+					if err := jc.restartPreemptedPods(metaObject, runtimeObject, runPolicy, &jobStatus, preemptedPod, signal, pods); err != nil {
+						return err
+					}
+				}
+				if !reflect.DeepEqual(*oldStatus, jobStatus) {
+					return jc.updateJobStatusInApiServer(job, oldStatus, &jobStatus)
+				}
+				return nil
 			}
 		}
 
-		jc.Recorder.Event(runtimeObject, corev1.EventTypeNormal, commonutil.NewReason(jobKind, commonutil.JobFailedReason), failureMessage)
+		// The pods created before a restart requested through the restartedAt annotation
+		// are recreated once their deletion is observed.
+		// Both kinds of restarts wait while too many jobs are restarting.
+		if restartPods := podsToRestart(metaObject, pods); len(restartPods) > 0 {
+			if jc.acquireRestart(metaObject, runtimeObject, &jobStatus) {
+				if err := jc.restartPods(metaObject, runtimeObject, runPolicy, &jobStatus, restartPods); err != nil {
+					return err
+				}
+			}
+			if !reflect.DeepEqual(*oldStatus, jobStatus) {
+				return jc.updateJobStatusInApiServer(job, oldStatus, &jobStatus)
+			}
+			return nil
+		}
 
-		commonutil.UpdateJobConditions(&jobStatus, apiv1.JobFailed, corev1.ConditionTrue, commonutil.NewReason(jobKind, commonutil.JobFailedReason), failureMessage)
+		// Failed pods matching the failure policy are recreated once their deletion is observed.
+		if len(failurePolicy.restartPods) > 0 {
+			if jc.acquireRestart(metaObject, runtimeObject, &jobStatus) {
+				if err := jc.restartFailedPods(metaObject, runtimeObject, &jobStatus, failurePolicy.restartPods); err != nil {
+					return err
+				}
+			}
+			if !reflect.DeepEqual(*oldStatus, jobStatus) {
+				return jc.updateJobStatusInApiServer(job, oldStatus, &jobStatus)
+			}
+			return nil
+		}
+
+		// All the pods of the job are recreated together once their deletions are observed
+		// when a pod of a replica type whose RestartPolicy is GangRestart failed.
+		if failedPod, gangPods := podsToGangRestart(replicas, pods); failedPod != nil {
+			if jc.acquireRestart(metaObject, runtimeObject, &jobStatus) {
+				if err := jc.gangRestartPods(metaObject, runtimeObject, &jobStatus, failedPod, gangPods); err != nil {
+					return err
+				}
+			}
+			if !reflect.DeepEqual(*oldStatus, jobStatus) {
+				return jc.updateJobStatusInApiServer(job, oldStatus, &jobStatus)
+			}
+			return nil
+		}
+
+		// Delete the PodGroup created by another gang scheduler than the configured one, e.g. before
+		// the gang scheduling was disabled, so that its stale minMember doesn't hold the pods of the job.
+		if gs := jobStatus.GangScheduling; gs != nil && !jc.isConfiguredGangScheduler(gs.SchedulerName) {
+			if err := jc.deleteRecordedPodGroup(runtimeObject, metaObject, &jobStatus); err != nil {
+				jc.Recorder.Eventf(runtimeObject, corev1.EventTypeWarning, commonutil.FailedDeletePodGroupReason, "Error deleting: %v", err)
+				return err
+			}
+			jc.Recorder.Eventf(runtimeObject, corev1.EventTypeNormal, commonutil.SuccessfulDeletePodGroupReason,
+				"Deleted PodGroup %v of the gang scheduler %s", jobName, gs.SchedulerName)
+		}
+
+		// The missing pods are created once the objects referenced by their templates exist, before
+		// their queue admits them so that the waiting jobs don't hold its resources.
+		if int32(len(pods)) < totalReplicas || commonutil.IsWaitingForDependencies(jobStatus) {
+			waiting, err := jc.reconcileDependencies(metaObject, runtimeObject, replicas, &jobStatus)
+			if err != nil {
+				return err
+			}
+			if waiting {
+				if !reflect.DeepEqual(*oldStatus, jobStatus) {
+					return jc.updateJobStatusInApiServer(job, oldStatus, &jobStatus)
+				}
+				return nil
+			}
+		}
+
+		// The pods of the jobs of the queues of the operator are created once their queue admits them.
+		if jc.reconcileQueueAdmission(metaObject, runtimeObject, runPolicy, pods, &jobStatus) {
+			if !reflect.DeepEqual(*oldStatus, jobStatus) {
+				return jc.updateJobStatusInApiServer(job, oldStatus, &jobStatus)
+			}
+			return nil
+		}
+
+		// The missing pods are created once they all fit in the resource quotas of the namespace.
+		if int32(len(pods)) < totalReplicas || commonutil.IsQuotaExceeded(jobStatus) {
+			exceeded, err := jc.reconcileQuotas(job, metaObject, runtimeObject, replicas, pods, &jobStatus)
+			if err != nil {
+				return err
+			}
+			if exceeded {
+				if !reflect.DeepEqual(*oldStatus, jobStatus) {
+					return jc.updateJobStatusInApiServer(job, oldStatus, &jobStatus)
+				}
+				return nil
+			}
+		}
 
-		return jc.Controller.UpdateJobStatusInApiServer(job, &jobStatus)
-	} else {
 		// General cases which need to reconcile
 		if jc.Config.EnableGangScheduling() {
 			minMember := totalReplicas
@@ -271,9 +515,12 @@ func (jc *JobController) ReconcileJobs(
 					schedulerTimeout = timeout
 				}
 			}
+			if len(priorityClass) == 0 {
+				priorityClass = jc.defaultPriorityClass(metaObject.GetNamespace())
+			}
 
 			if minResources == nil {
-				minResources = jc.calcPGMinResources(minMember, replicas)
+				minResources = jc.calcPGMinResources(job, minMember, replicas)
 			}
 
 			var pgSpecFill FillPodGroupSpecFunc
@@ -315,75 +562,209 @@ func (jc *JobController) ReconcileJobs(
 			syncReplicas := true
 			pg, err := jc.SyncPodGroup(metaObject, pgSpecFill)
 			if err != nil {
-				log.Warnf("Sync PodGroup %v: %v", jobKey, err)
+				logger.Error(err, "Failed to sync the PodGroup")
 				syncReplicas = false
+			} else {
+				jobStatus.GangScheduling = &apiv1.GangSchedulingStatus{
+					SchedulerName: string(jc.Config.GangScheduling),
+					MinMember:     minMember,
+				}
 			}
 
 			// Delay pods creation until PodGroup status is Inqueue
-			if jc.PodGroupControl.DelayPodCreationDueToPodGroup(pg) {
-				log.Warnf("PodGroup %v unschedulable", jobKey)
+			delayed := jc.PodGroupControl.DelayPodCreationDueToPodGroup(pg)
+			if delayed {
+				logger.Info("PodGroup is unschedulable, delaying the creation of the pods")
 				syncReplicas = false
 			}
+			if err == nil {
+				jc.updateQueuedCondition(metaObject, runtimeObject, &jobStatus, pg, delayed)
+			}
 
 			if !syncReplicas {
-				now := metav1.Now()
+				if err := jc.preemptLowerPriorityJobs(metaObject, runtimeObject, jobStatus); err != nil {
+					logger.Error(err, "Failed to preempt the running jobs of lower priority")
+				}
+				now := jc.Clock.MetaNow()
 				jobStatus.LastReconcileTime = &now
 
 				// Update job status here to trigger a new reconciliation
-				return jc.Controller.UpdateJobStatusInApiServer(job, &jobStatus)
+				return jc.updateJobStatusInApiServer(job, oldStatus, &jobStatus)
+			}
+		} else {
+			jc.updateQueuedCondition(metaObject, runtimeObject, &jobStatus, nil, false)
+		}
+
+		// Give the pods removed by a scale-down a chance to save a checkpoint before they are deleted.
+		// The replicas are reconciled once their checkpoint deadline has passed.
+		if runPolicy.CheckpointPolicy != nil && jc.checkpointPods(runtimeObject, runPolicy.CheckpointPolicy, jc.Controller.GetDefaultContainerName(), scaledDownPods(pods, replicas)) {
+			if !reflect.DeepEqual(*oldStatus, jobStatus) {
+				return jc.updateJobStatusInApiServer(job, oldStatus, &jobStatus)
 			}
+			return nil
+		}
+
+		// failJob updates the status of a job failed by the reconciliation of its replicas.
+		failJob := func() error {
+			if err := jc.updateJobStatusInApiServer(job, oldStatus, &jobStatus); err != nil {
+				return err
+			}
+			jc.recordJobCompleted(runtimeObject, jobKind, klog.KObj(metaObject).String(), *oldStatus, jobStatus, pods)
+			recordJobMetrics(metaObject, jc.Controller.GetFrameworkName(), *oldStatus, jobStatus, jc.Clock)
+			return nil
+		}
+
+		// The RayCluster requested by the job runs alongside its pods.
+		err = jc.ReconcileRayCluster(runtimeObject, metaObject, runPolicy)
+		var collision *NameCollisionError
+		if errors.As(err, &collision) {
+			jc.FailJobForNameCollision(runtimeObject, metaObject, &jobStatus, collision)
+			return failJob()
+		}
+		if err != nil {
+			logger.Error(err, "Failed to reconcile the RayCluster")
+			return err
+		}
+
+		// The pods of the job are isolated before they are created.
+		err = jc.ReconcileNetworkPolicy(runtimeObject, metaObject)
+		if errors.As(err, &collision) {
+			jc.FailJobForNameCollision(runtimeObject, metaObject, &jobStatus, collision)
+			return failJob()
+		}
+		if err != nil {
+			logger.Error(err, "Failed to reconcile the NetworkPolicy")
+			return err
+		}
+
+		// The TensorBoard requested by the job serves its logs alongside its pods.
+		err = jc.ReconcileTensorBoard(runtimeObject, metaObject, &jobStatus)
+		if errors.As(err, &collision) {
+			jc.FailJobForNameCollision(runtimeObject, metaObject, &jobStatus, collision)
+			return failJob()
+		}
+		if err != nil {
+			logger.Error(err, "Failed to reconcile the TensorBoard")
+			return err
 		}
 
 		// Diff current active pods/services with replicas.
+		var blockedByQuota *QuotaExceededError
 		for rtype, spec := range replicas {
 			err := jc.Controller.ReconcilePods(metaObject, &jobStatus, pods, rtype, spec, replicas)
-			if err != nil {
-				log.Warnf("ReconcilePods error %v", err)
+			var violation *PodSecurityViolationError
+			var quotaExceeded *QuotaExceededError
+			if errors.As(err, &violation) {
+				// The pods would never be admitted in the namespace, fail the job instead of retrying.
+				jc.FailJobForPodSecurity(runtimeObject, metaObject, &jobStatus, violation)
+				return failJob()
+			}
+			if errors.As(err, &collision) {
+				// A child of the job collides with an object the job does not control.
+				jc.FailJobForNameCollision(runtimeObject, metaObject, &jobStatus, collision)
+				return failJob()
+			}
+			if errors.As(err, &quotaExceeded) {
+				// The creation of the pods is retried later, while the other replica types
+				// are still reconciled.
+				blockedByQuota = quotaExceeded
+			} else if err != nil {
+				logger.Error(err, "Failed to reconcile the pods", "replicaType", rtype)
 				return err
 			}
 
+			// A standalone job runs in a single pod, which needs no service.
+			if standalone {
+				continue
+			}
 			err = jc.Controller.ReconcileServices(metaObject, services, rtype, spec)
-
+			if errors.As(err, &collision) {
+				jc.FailJobForNameCollision(runtimeObject, metaObject, &jobStatus, collision)
+				return failJob()
+			}
 			if err != nil {
-				log.Warnf("ReconcileServices error %v", err)
+				logger.Error(err, "Failed to reconcile the services", "replicaType", rtype)
 				return err
 			}
 		}
+		jc.updateQuotaBlockedCondition(metaObject, runtimeObject, &jobStatus, blockedByQuota)
 	}
 
+	// The unschedulable pods and the pods pulling their images are reported before the job is
+	// updated, so that the Running condition is the last one once they are all started.
+	jc.updateSchedulingCondition(metaObject, runtimeObject, &jobStatus, pods)
+	jc.updateImagePullCondition(metaObject, runtimeObject, &jobStatus, pods)
 	err = jc.Controller.UpdateJobStatus(job, replicas, &jobStatus)
 	if err != nil {
-		log.Warnf("UpdateJobStatus error %v", err)
+		logger.Error(err, "Failed to update the job status")
 		return err
 	}
+	addOOMKilledHint(&jobStatus, oldStatus, pods)
+	// A restarting job holds its slot of the RestartLimiter until it is running again.
+	if !commonutil.IsRestarting(jobStatus) {
+		jc.RestartLimiter.Release(jobKind, metaObject.GetNamespace(), jobName)
+	}
 	// No need to update the job status if the status hasn't changed since last time.
 	if !reflect.DeepEqual(*oldStatus, jobStatus) {
-		return jc.Controller.UpdateJobStatusInApiServer(job, &jobStatus)
+		if err = jc.updateJobStatusInApiServer(job, oldStatus, &jobStatus); err != nil {
+			return err
+		}
+		jc.recordJobCompleted(runtimeObject, jobKind, klog.KObj(metaObject).String(), *oldStatus, jobStatus, pods)
+		recordJobMetrics(metaObject, jc.Controller.GetFrameworkName(), *oldStatus, jobStatus, jc.Clock)
+	}
+	// The pods of a running job are protected from the voluntary disruptions, e.g. the node drains.
+	if err = jc.ReconcilePodDisruptionBudget(runtimeObject, metaObject, jobStatus); err != nil {
+		logger.Error(err, "Failed to reconcile the PodDisruptionBudget")
+		return err
+	}
+	// The running jobs of lower priority make room for the job if it can't be scheduled.
+	if err = jc.preemptLowerPriorityJobs(metaObject, runtimeObject, jobStatus); err != nil {
+		logger.Error(err, "Failed to preempt the running jobs of lower priority")
+		return err
 	}
+	jc.requeueRunningJob(jobKey, jobStatus)
 	return nil
 }
 
+// runningJobResyncJitter is the maximum factor by which the resync period of a running job is
+// extended.
+const runningJobResyncJitter = 0.1
+
+// requeueRunningJob requeues a running job after the resync period of the running jobs, so that
+// a job whose pods stay healthy is still evaluated against its active deadline and watchdogs,
+// and its metrics are refreshed. The period is jittered, so that the jobs started together are
+// not all reconciled at once.
+func (jc *JobController) requeueRunningJob(jobKey string, jobStatus apiv1.JobStatus) {
+	period := config.Config.RunningJobResyncPeriod
+	if period <= 0 || !commonutil.IsRunning(jobStatus) || commonutil.IsFinished(jobStatus) || commonutil.IsSuspended(jobStatus) {
+		return
+	}
+	jc.WorkQueue.AddAfter(jobKey, wait.Jitter(period, runningJobResyncJitter))
+}
+
 func (jc *JobController) CleanUpResources(
 	runPolicy *apiv1.RunPolicy,
 	runtimeObject runtime.Object,
 	metaObject metav1.Object,
-	jobStatus apiv1.JobStatus,
+	jobStatus *apiv1.JobStatus,
 	pods []*corev1.Pod,
 ) error {
-	if err := jc.DeletePodsAndServices(runtimeObject, runPolicy, jobStatus, pods); err != nil {
+	if err := jc.DeletePodsAndServices(runtimeObject, runPolicy, *jobStatus, pods); err != nil {
 		return err
 	}
-	if jc.Config.EnableGangScheduling() {
-
-		jc.Recorder.Event(runtimeObject, corev1.EventTypeNormal, "JobTerminated", "Job has been terminated. Deleting PodGroup")
-		if err := jc.DeletePodGroup(metaObject); err != nil {
-			jc.Recorder.Eventf(runtimeObject, corev1.EventTypeWarning, "FailedDeletePodGroup", "Error deleting: %v", err)
-			return err
-		} else {
-			jc.Recorder.Eventf(runtimeObject, corev1.EventTypeNormal, "SuccessfulDeletePodGroup", "Deleted PodGroup: %v", metaObject.GetName())
-		}
+	if err := jc.CleanupPodGroup(runtimeObject, metaObject, jobStatus); err != nil {
+		return err
+	}
+	if err := jc.DeleteRayCluster(runtimeObject, metaObject, runPolicy); err != nil {
+		return err
 	}
-	if err := jc.CleanupJob(runPolicy, jobStatus, runtimeObject); err != nil {
+	if err := jc.DeletePodDisruptionBudget(runtimeObject, metaObject); err != nil {
+		return err
+	}
+	if err := jc.DeleteTensorBoard(runtimeObject, metaObject, runPolicy, jobStatus); err != nil {
+		return err
+	}
+	if err := jc.CleanupJob(runPolicy, *jobStatus, runtimeObject); err != nil {
 		return err
 	}
 	return nil
@@ -405,9 +786,26 @@ func (jc *JobController) ResetExpectations(jobKey string, replicas map[apiv1.Rep
 	return allErrs
 }
 
+// exceedsBackoffLimitOnNewFailure returns whether the job has failed pods which are not recorded in its
+// status yet and was already retried BackoffLimit times. The failed pods matching an Ignore rule of
+// the FailurePolicy are excluded from both the failed pods and the failures recorded in the status.
+func exceedsBackoffLimitOnNewFailure(runPolicy *apiv1.RunPolicy, jobStatus apiv1.JobStatus, pods, ignoredPods []*corev1.Pod,
+	active, totalReplicas int32, previousRetry int) bool {
+	failed := k8sutil.FilterPodCount(pods, corev1.PodFailed) - int32(len(ignoredPods))
+	// The failed pods matching an Ignore rule are counted in the replica statuses once they were recorded
+	// by a previous reconciliation, so only the recorded ones are excluded from the previous count.
+	prevReplicasFailedNum := k8sutil.GetTotalFailedReplicas(jobStatus.ReplicaStatuses) - countRecordedFailedPods(jobStatus, ignoredPods)
+	jobHasNewFailure := failed > prevReplicasFailedNum
+	// new failures happen when status does not reflect the failures and active
+	// is different from parallelism, otherwise the previous controller loop
+	// failed updating status so even if we pick up failure it is not a new one
+	return jobHasNewFailure && (active != totalReplicas) &&
+		(int32(previousRetry)+1 > *runPolicy.BackoffLimit)
+}
+
 // PastActiveDeadline checks if job has ActiveDeadlineSeconds field set and if it is exceeded.
 func (jc *JobController) PastActiveDeadline(runPolicy *apiv1.RunPolicy, jobStatus apiv1.JobStatus) bool {
-	return core.PastActiveDeadline(runPolicy, jobStatus)
+	return core.PastActiveDeadline(runPolicy, jobStatus, jc.Clock)
 }
 
 // PastBackoffLimit checks if container restartCounts sum exceeds BackoffLimit
@@ -418,7 +816,6 @@ func (jc *JobController) PastBackoffLimit(jobName string, runPolicy *apiv1.RunPo
 }
 
 func (jc *JobController) CleanupJob(runPolicy *apiv1.RunPolicy, jobStatus apiv1.JobStatus, job interface{}) error {
-	currentTime := time.Now()
 	metaObject, _ := job.(metav1.Object)
 	ttl := runPolicy.TTLSecondsAfterFinished
 	if ttl == nil || trainutil.IsJobSuspended(runPolicy) {
@@ -428,23 +825,23 @@ func (jc *JobController) CleanupJob(runPolicy *apiv1.RunPolicy, jobStatus apiv1.
 	if jobStatus.CompletionTime == nil {
 		return fmt.Errorf("job completion time is nil, cannot cleanup")
 	}
-	finishTime := jobStatus.CompletionTime
-	expireTime := finishTime.Add(duration)
-	if currentTime.After(expireTime) {
+	elapsed := jc.Clock.Since(jobStatus.CompletionTime.Time)
+	if elapsed > duration {
 		err := jc.Controller.DeleteJob(job)
 		if err != nil {
-			commonutil.LoggerForJob(metaObject).Warnf("Cleanup Job error: %v.", err)
+			commonutil.LoggerForJob(metaObject).Error(err, "Failed to clean up the job")
 			return err
 		}
 		return nil
 	} else {
-		if finishTime.After(currentTime) {
-			commonutil.LoggerForJob(metaObject).Warnf("Found Job finished in the future. This is likely due to time skew in the cluster. Job cleanup will be deferred.")
+		if elapsed < 0 {
+			commonutil.LoggerForJob(metaObject).Info("Found Job finished in the future. This is likely due to time skew in the cluster. Job cleanup will be deferred.",
+				"skew", -elapsed, "skewTolerance", jc.Clock.SkewTolerance())
 		}
-		remaining := expireTime.Sub(currentTime)
+		remaining := duration - elapsed
 		key, err := KeyFunc(job)
 		if err != nil {
-			commonutil.LoggerForJob(metaObject).Warnf("Couldn't get key for job object: %v", err)
+			commonutil.LoggerForJob(metaObject).Error(err, "Couldn't get key for job object")
 			return err
 		}
 		jc.WorkQueue.AddAfter(key, remaining)
@@ -452,8 +849,39 @@ func (jc *JobController) CleanupJob(runPolicy *apiv1.RunPolicy, jobStatus apiv1.
 	}
 }
 
-func (jc *JobController) calcPGMinResources(minMember int32, replicas map[apiv1.ReplicaType]*apiv1.ReplicaSpec) *corev1.ResourceList {
-	return CalcPGMinResources(minMember, replicas, jc.PriorityClassLister.Get)
+// calcPGMinResources returns the minResources of the PodGroup of the job, accounting for the init
+// containers injected by the controller in the pods of the replicas.
+func (jc *JobController) calcPGMinResources(job interface{}, minMember int32, replicas map[apiv1.ReplicaType]*apiv1.ReplicaSpec) *corev1.ResourceList {
+	var defaultPriorityClass string
+	if metaObject, ok := job.(metav1.Object); ok {
+		defaultPriorityClass = jc.defaultPriorityClass(metaObject.GetNamespace())
+	}
+	podReplicas := make(map[apiv1.ReplicaType]*apiv1.ReplicaSpec, len(replicas))
+	for rtype, spec := range replicas {
+		podReplicas[rtype] = spec
+		if spec == nil {
+			continue
+		}
+		// The pods are created with the default priority class of the namespace when they have none.
+		if len(spec.Template.Spec.PriorityClassName) == 0 && len(defaultPriorityClass) != 0 {
+			spec = spec.DeepCopy()
+			spec.Template.Spec.PriorityClassName = defaultPriorityClass
+			podReplicas[rtype] = spec
+		}
+		injector, ok := jc.Controller.(common.InitContainerInjector)
+		if !ok {
+			continue
+		}
+		if initContainers := injector.InjectedInitContainers(job, rtype); len(initContainers) > 0 {
+			podReplica := spec.DeepCopy()
+			podReplica.Template.Spec.InitContainers = append(podReplica.Template.Spec.InitContainers, initContainers...)
+			podReplicas[rtype] = podReplica
+		}
+	}
+	isMasterRole := func(rtype apiv1.ReplicaType) bool {
+		return jc.Controller.IsMasterRole(replicas, rtype, 0)
+	}
+	return CalcPGMinResources(minMember, podReplicas, jc.PriorityClassLister.Get, isMasterRole)
 }
 
 func (jc *JobController) ManagedByExternalController(controllerName *string) *string {
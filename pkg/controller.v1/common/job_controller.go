@@ -17,16 +17,21 @@ limitations under the License.
 package common
 
 import (
+	"fmt"
 	"strings"
+	"time"
 
 	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
 	"github.com/kubeflow/training-operator/pkg/common"
+	"github.com/kubeflow/training-operator/pkg/config"
 	"github.com/kubeflow/training-operator/pkg/controller.v1/control"
 	"github.com/kubeflow/training-operator/pkg/controller.v1/expectation"
+	"github.com/kubeflow/training-operator/pkg/controller.v1/registry"
+	commonutil "github.com/kubeflow/training-operator/pkg/util"
+	"github.com/kubeflow/training-operator/pkg/util/podsecurity"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
-	log "github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kubeinformers "k8s.io/client-go/informers"
@@ -38,7 +43,11 @@ import (
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 	volcanoclient "volcano.sh/apis/pkg/client/clientset/versioned"
 )
 
@@ -100,12 +109,22 @@ type JobController struct {
 	// ServiceControl is used to add or delete services.
 	ServiceControl control.ServiceControlInterface
 
+	// PodExecControl is used to execute commands in running pods.
+	PodExecControl control.PodExecControlInterface
+
+	// JobRegistry is the read-only view of the jobs of all kinds managed by the operator.
+	// The API calls made on behalf of the jobs are counted in it if it implements AddAPICalls.
+	JobRegistry registry.Reader
+
 	// KubeClientSet is a standard kubernetes clientset.
 	KubeClientSet kubeclientset.Interface
 
 	// PodGroupControl is used to add or delete PodGroup.
 	PodGroupControl control.PodGroupControlInterface
 
+	// JobControllerOptions are the optional dependencies set up by the operator.
+	JobControllerOptions
+
 	// PodLister can list/get pods from the shared informer's store.
 	PodLister corelisters.PodLister
 
@@ -152,6 +171,77 @@ type JobController struct {
 	// Recorder is an event recorder for recording Event resources to the
 	// Kubernetes API.
 	Recorder record.EventRecorder
+
+	// Clock is the source of the current time of the controller, used to stamp the status of
+	// the jobs and to compute their TTL and active deadline. A nil Clock is the real clock.
+	Clock *commonutil.Clock
+
+	// OOMKillTracker tracks the OOM kills of the pods of the jobs which were recorded.
+	// The OOM kills are not recorded if it is nil.
+	OOMKillTracker *OOMKillTracker
+}
+
+// JobControllerOptions are the optional dependencies of the JobController, shared by the job
+// controllers of all kinds. The features relying on a dependency are disabled if it is not set.
+type JobControllerOptions struct {
+	// PodGroupClients are used to delete the PodGroups recorded in the status of the jobs
+	// which were created by another gang scheduler than the configured one, e.g. before
+	// the gang scheduling was disabled.
+	PodGroupClients PodGroupClients
+
+	// RayClusterClient is used to create and delete the RayClusters requested by the jobs.
+	RayClusterClient client.Client
+
+	// NetworkPolicyClient is used to create the NetworkPolicies isolating the pods of the jobs.
+	// The NetworkPolicies are not created if it is nil.
+	NetworkPolicyClient client.Client
+
+	// TensorBoardClient is used to create and delete the TensorBoards requested by the jobs.
+	TensorBoardClient client.Client
+
+	// PodDisruptionBudgetClient is used to create and delete the PodDisruptionBudgets of the
+	// running jobs. The PodDisruptionBudgets are not created if it is nil.
+	PodDisruptionBudgetClient client.Client
+
+	// PreemptionClient is used to read the nodes of the pods of the jobs and to annotate the jobs
+	// restarted on the preemption of a node. The jobs are not restarted ahead of the preemptions
+	// of their nodes if it is nil.
+	PreemptionClient client.Client
+
+	// PriorityPreemptionClient is used to suspend the running jobs of lower priority than the
+	// jobs which can't be scheduled. The jobs are not preempted if it is nil.
+	PriorityPreemptionClient client.Client
+
+	// JobQueues admits the jobs of its queues once their resources fit in the budgets of the
+	// queues. The jobs are not queued by the operator if it is nil.
+	JobQueues *JobQueues
+
+	// RestartLimiter limits the number of jobs of all kinds restarting at the same time.
+	// The restarts are not limited if it is nil.
+	RestartLimiter *RestartLimiter
+
+	// AdoptionLimiter limits the rate of the adoptions of the orphan pods by the jobs of all
+	// kinds. The adoptions are not limited if it is nil.
+	AdoptionLimiter *AdoptionLimiter
+
+	// NodeLister can get the nodes of the pods from the cache of the manager. The pods of the
+	// failed nodes are not rescheduled if it is nil.
+	NodeLister corelisters.NodeLister
+
+	// PodSecurityChecker checks the pods against the PodSecurity policy of their namespace,
+	// read with NamespaceLister, before they are created. The pods are not checked if it is nil.
+	PodSecurityChecker *podsecurity.Checker
+
+	// NamespaceLister can get the namespaces of the jobs from the cache of the manager.
+	NamespaceLister corelisters.NamespaceLister
+}
+
+// PodGroupClients are the clients of the PodGroups of the gang schedulers.
+type PodGroupClients struct {
+	// Volcano is the client of the volcano PodGroups.
+	Volcano volcanoclient.Interface
+	// SchedulerPlugins is the client of the scheduler-plugins PodGroups.
+	SchedulerPlugins client.Client
 }
 
 type GangSchedulingSetupFunc func(jc *JobController)
@@ -185,9 +275,12 @@ func NewJobController(
 	kubeInformerFactory kubeinformers.SharedInformerFactory,
 	workQueueName string) JobController {
 
-	log.Debug("Creating event broadcaster")
+	logger := log.Log.WithName("events").WithValues("controller", controllerImpl.ControllerName())
+	logger.V(1).Info("Creating event broadcaster")
 	eventBroadcaster := record.NewBroadcaster()
-	eventBroadcaster.StartLogging(log.Infof)
+	eventBroadcaster.StartLogging(func(format string, args ...interface{}) {
+		logger.V(1).Info(fmt.Sprintf(format, args...))
+	})
 	eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: kubeClientSet.CoreV1().Events("")})
 	recorder := eventBroadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: controllerImpl.ControllerName()})
 
@@ -210,6 +303,8 @@ func NewJobController(
 		Expectations:   expectation.NewControllerExpectations(),
 		WorkQueue:      workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), workQueueName),
 		Recorder:       recorder,
+		Clock:          commonutil.NewClock(clock.RealClock{}, config.Config.ClockSkewTolerance),
+		OOMKillTracker: NewOOMKillTracker(time.Now()),
 	}
 
 	setupPodGroup(&jc)
@@ -218,6 +313,15 @@ func NewJobController(
 
 }
 
+// WatchWorkQueue binds the WorkQueue to the work queue of the controller c, if the WorkQueue is
+// backed by a controller-runtime source, so that the jobs added to the WorkQueue are reconciled by c.
+func (jc *JobController) WatchWorkQueue(c controller.Controller) error {
+	if q, ok := jc.WorkQueue.(interface{ Source() source.Source }); ok {
+		return c.Watch(q.Source())
+	}
+	return nil
+}
+
 func (jc *JobController) GenOwnerReference(obj metav1.Object) *metav1.OwnerReference {
 	boolPtr := func(b bool) *bool { return &b }
 	controllerRef := &metav1.OwnerReference{
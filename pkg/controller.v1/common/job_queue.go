@@ -0,0 +1,290 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/yaml"
+
+	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	"github.com/kubeflow/training-operator/pkg/controller.v1/registry"
+	commonutil "github.com/kubeflow/training-operator/pkg/util"
+)
+
+// jobQueueRequeuePeriod is the period at which the pending jobs check whether their queue
+// admits them.
+const jobQueueRequeuePeriod = 10 * time.Second
+
+// JobQueuePolicy is the order in which the pending jobs of a queue are admitted.
+type JobQueuePolicy string
+
+const (
+	// JobQueuePolicyFIFO admits the pending jobs in the order they were created.
+	JobQueuePolicyFIFO JobQueuePolicy = "FIFO"
+	// JobQueuePolicyFair admits first the pending jobs of the namespace using the smallest
+	// share of the resources of the queue, and in the order they were created among the jobs
+	// of the same share.
+	JobQueuePolicyFair JobQueuePolicy = "Fair"
+)
+
+// JobQueueConfig is the configuration of a queue of the operator.
+type JobQueueConfig struct {
+	// Policy is the order in which the pending jobs are admitted, FIFO by default.
+	Policy JobQueuePolicy `json:"policy,omitempty"`
+	// Resources is the budget of the queue, which the sum of the resources requested by the
+	// jobs it admitted never exceeds.
+	Resources corev1.ResourceList `json:"resources"`
+}
+
+// JobQueues admits the jobs of all kinds which set the queue of their SchedulingPolicy to one
+// of its queues once the resources they request fit in the budget of the queue, as a lightweight
+// alternative to the queues of a gang scheduler. It is shared by all the job controllers and is
+// safe for concurrent use. The jobs of the other queues are not queued by the operator. A nil
+// JobQueues queues no job.
+//
+// The jobs of a queue hold their resources from their admission until they are finished or
+// suspended, and the pending jobs are admitted one at a time in the order of the policy of the
+// queue, so that a large job is not starved by the smaller ones created after it.
+type JobQueues struct {
+	mu     sync.Mutex
+	queues map[string]JobQueueConfig
+	// admitted are the jobs admitted by each queue which aren't observed as admitted in the
+	// job registry yet, so that they are not admitted twice.
+	admitted map[string]sets.Set[types.UID]
+}
+
+// NewJobQueues returns the JobQueues of the configured queues.
+func NewJobQueues(queues map[string]JobQueueConfig) *JobQueues {
+	admitted := make(map[string]sets.Set[types.UID], len(queues))
+	for name := range queues {
+		admitted[name] = sets.New[types.UID]()
+	}
+	return &JobQueues{queues: queues, admitted: admitted}
+}
+
+// LoadJobQueues reads the queues of the operator from file, a YAML map of the names of the
+// queues to their configuration, e.g.
+//
+//	research:
+//	  policy: Fair
+//	  resources:
+//	    cpu: "256"
+//	    nvidia.com/gpu: "16"
+//	production:
+//	  resources:
+//	    nvidia.com/gpu: "64"
+func LoadJobQueues(file string) (*JobQueues, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	queues := map[string]JobQueueConfig{}
+	if err := yaml.UnmarshalStrict(b, &queues); err != nil {
+		return nil, err
+	}
+	for name, queue := range queues {
+		switch queue.Policy {
+		case "":
+			queue.Policy = JobQueuePolicyFIFO
+			queues[name] = queue
+		case JobQueuePolicyFIFO, JobQueuePolicyFair:
+		default:
+			return nil, fmt.Errorf("invalid policy %q of queue %s, must be %s or %s", queue.Policy, name, JobQueuePolicyFIFO, JobQueuePolicyFair)
+		}
+		if len(queue.Resources) == 0 {
+			return nil, fmt.Errorf("queue %s has no resources", name)
+		}
+		for resourceName, quantity := range queue.Resources {
+			if quantity.Sign() < 0 {
+				return nil, fmt.Errorf("invalid %s resources of queue %s: %s", resourceName, name, quantity.String())
+			}
+		}
+	}
+	return NewJobQueues(queues), nil
+}
+
+// Has returns whether queue is a queue of the operator.
+func (q *JobQueues) Has(queue string) bool {
+	if q == nil {
+		return false
+	}
+	_, ok := q.queues[queue]
+	return ok
+}
+
+// Release forgets the admission of the job uid which isn't observed in the job registry yet, e.g.
+// when the job waits for its dependencies after being admitted.
+func (q *JobQueues) Release(uid types.UID) {
+	if q == nil {
+		return
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for _, admitted := range q.admitted {
+		admitted.Delete(uid)
+	}
+}
+
+// TryAdmit returns whether the queue of the job admits it now. Otherwise it also returns why
+// the job is pending. The jobs of the queue are read from jobs.
+func (q *JobQueues) TryAdmit(job registry.JobInfo, jobs registry.Reader) (bool, string) {
+	if !q.Has(job.Queue) {
+		return true, ""
+	}
+	queue := q.queues[job.Queue]
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	admitted := q.admitted[job.Queue]
+	if admitted.Has(job.UID) {
+		return true, ""
+	}
+	if !coversResources(queue.Resources, job.Resources) {
+		return false, fmt.Sprintf("it requests more resources than the budget of queue %s", job.Queue)
+	}
+
+	usage := corev1.ResourceList{}
+	namespaceUsage := map[string]corev1.ResourceList{}
+	var pending []registry.JobInfo
+	seen := sets.New[types.UID]()
+	for _, info := range jobs.List() {
+		if info.Queue != job.Queue {
+			continue
+		}
+		seen.Insert(info.UID)
+		if info.Suspended || info.Phase == apiv1.JobSucceeded || info.Phase == apiv1.JobFailed || info.Phase == apiv1.JobSuspended {
+			admitted.Delete(info.UID)
+			continue
+		}
+		// The other jobs waiting for their dependencies are admitted again once they are ready.
+		if info.UID != job.UID && info.Phase == apiv1.JobWaitingForDependencies && info.ActiveReplicas == 0 {
+			admitted.Delete(info.UID)
+			continue
+		}
+		// The jobs with pods created before the queue was configured hold their resources too.
+		if info.Admitted || info.ActiveReplicas > 0 || admitted.Has(info.UID) {
+			if info.Admitted {
+				admitted.Delete(info.UID)
+			}
+			if namespaceUsage[info.Namespace] == nil {
+				namespaceUsage[info.Namespace] = corev1.ResourceList{}
+			}
+			addResources(usage, info.Resources)
+			addResources(namespaceUsage[info.Namespace], info.Resources)
+			continue
+		}
+		// The jobs which can never be admitted don't hold the others.
+		if coversResources(queue.Resources, info.Resources) {
+			pending = append(pending, info)
+		}
+	}
+	// The jobs which were deleted before being observed as admitted don't hold resources.
+	for uid := range admitted {
+		if !seen.Has(uid) {
+			admitted.Delete(uid)
+		}
+	}
+
+	// The jobs are listed in the order they were created.
+	if queue.Policy == JobQueuePolicyFair {
+		sort.SliceStable(pending, func(i, j int) bool {
+			return dominantShare(namespaceUsage[pending[i].Namespace], queue.Resources) <
+				dominantShare(namespaceUsage[pending[j].Namespace], queue.Resources)
+		})
+	}
+	position := len(pending)
+	for i, info := range pending {
+		if info.UID == job.UID {
+			position = i
+			break
+		}
+	}
+	if position > 0 {
+		return false, fmt.Sprintf("%d jobs are ahead of it in queue %s", position, job.Queue)
+	}
+	addResources(usage, job.Resources)
+	if !coversResources(queue.Resources, usage) {
+		return false, fmt.Sprintf("the jobs admitted by queue %s use its resources", job.Queue)
+	}
+	admitted.Insert(job.UID)
+	return true, ""
+}
+
+// dominantShare returns the largest share of the resources of budget used by usage.
+func dominantShare(usage, budget corev1.ResourceList) float64 {
+	share := 0.0
+	for name, quantity := range budget {
+		if quantity.IsZero() {
+			continue
+		}
+		if used, ok := usage[name]; ok {
+			share = max(share, used.AsApproximateFloat64()/quantity.AsApproximateFloat64())
+		}
+	}
+	return share
+}
+
+// reconcileQueueAdmission returns true while the job is pending in its queue of the operator, in
+// which case its Pending condition is set and it is requeued to check again later. The jobs
+// without pods which are not admitted yet, e.g. the new and the resumed jobs, wait for their
+// admission, while the jobs whose pods were created before their queue was configured are left
+// as is.
+func (jc *JobController) reconcileQueueAdmission(metaObject metav1.Object, runtimeObject runtime.Object,
+	runPolicy *apiv1.RunPolicy, pods []*corev1.Pod, jobStatus *apiv1.JobStatus) bool {
+	if runPolicy.SchedulingPolicy == nil || !jc.JobQueues.Has(runPolicy.SchedulingPolicy.Queue) || jc.JobRegistry == nil {
+		return false
+	}
+	condition := findCondition(jobStatus, apiv1.JobPending)
+	if condition != nil && condition.Status == corev1.ConditionFalse {
+		return false
+	}
+	if condition == nil && len(pods) > 0 {
+		return false
+	}
+	queue := runPolicy.SchedulingPolicy.Queue
+	jobKind := jc.Controller.GetAPIGroupVersionKind().Kind
+	key, keyErr := KeyFunc(metaObject)
+	// The job is requeued until it is observed in the job registry.
+	info, ok := jc.JobRegistry.Get(jobKind, metaObject.GetNamespace(), metaObject.GetName())
+	if ok {
+		admitted, why := jc.JobQueues.TryAdmit(info, jc.JobRegistry)
+		if admitted {
+			msg := fmt.Sprintf("%s %s is admitted by queue %s.", jobKind, metaObject.GetName(), queue)
+			reason := commonutil.NewReason(jobKind, commonutil.JobAdmittedReason)
+			jc.Recorder.Event(runtimeObject, corev1.EventTypeNormal, reason, msg)
+			commonutil.UpdateJobConditions(jobStatus, apiv1.JobPending, corev1.ConditionFalse, reason, msg, jc.Clock)
+			return false
+		}
+		if !commonutil.IsPending(*jobStatus) {
+			msg := fmt.Sprintf("%s %s is pending in queue %s because %s.", jobKind, metaObject.GetName(), queue, why)
+			reason := commonutil.NewReason(jobKind, commonutil.JobPendingReason)
+			jc.Recorder.Event(runtimeObject, corev1.EventTypeNormal, reason, msg)
+			commonutil.UpdateJobConditions(jobStatus, apiv1.JobPending, corev1.ConditionTrue, reason, msg, jc.Clock)
+		}
+	}
+	if keyErr == nil {
+		jc.WorkQueue.AddAfter(key, jobQueueRequeuePeriod)
+	}
+	return true
+}
@@ -0,0 +1,207 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+
+	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	"github.com/kubeflow/training-operator/pkg/controller.v1/registry"
+	commonutil "github.com/kubeflow/training-operator/pkg/util"
+)
+
+func newQueuedJob(namespace, name, cpu string, created time.Time, active int32) *apiv1.PyTorchJob {
+	job := newPriorityPreemptionJob(namespace, name, "", cpu, created, apiv1.JobCreated)
+	job.Spec.RunPolicy.SchedulingPolicy.Queue = "training"
+	job.Status.ReplicaStatuses[apiv1.PyTorchJobReplicaTypeMaster].Active = active
+	return job
+}
+
+func TestLoadJobQueues(t *testing.T) {
+	cases := map[string]struct {
+		content string
+		want    map[string]JobQueueConfig
+		wantErr bool
+	}{
+		"policies": {
+			content: "research:\n  policy: Fair\n  resources:\n    cpu: \"8\"\nproduction:\n  resources:\n    nvidia.com/gpu: \"4\"\n",
+			want: map[string]JobQueueConfig{
+				"research":   {Policy: JobQueuePolicyFair, Resources: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("8")}},
+				"production": {Policy: JobQueuePolicyFIFO, Resources: corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("4")}},
+			},
+		},
+		"unknown policy": {
+			content: "research:\n  policy: LIFO\n  resources:\n    cpu: \"8\"\n",
+			wantErr: true,
+		},
+		"no resources": {
+			content: "research:\n  policy: Fair\n",
+			wantErr: true,
+		},
+		"unknown field": {
+			content: "research:\n  resource:\n    cpu: \"8\"\n",
+			wantErr: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "queues.yaml")
+			if err := os.WriteFile(file, []byte(tc.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			got, err := LoadJobQueues(file)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Unexpected error, want error: %t, got: %v", tc.wantErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want, got.queues); len(diff) != 0 {
+				t.Errorf("Unexpected queues (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestJobQueuesTryAdmit(t *testing.T) {
+	now := time.Now()
+	cases := map[string]struct {
+		policy JobQueuePolicy
+		// waiting is the job waiting for its dependencies, if any.
+		waiting string
+		// admissions are the jobs trying to be admitted in order, with whether they are.
+		admissions []string
+		want       []bool
+	}{
+		"FIFO admits the oldest job first": {
+			policy:     JobQueuePolicyFIFO,
+			admissions: []string{"newer", "older", "newer"},
+			want:       []bool{false, true, false},
+		},
+		"Fair admits the job of the namespace using the least resources first": {
+			policy:     JobQueuePolicyFair,
+			admissions: []string{"older", "newer", "older"},
+			want:       []bool{false, true, false},
+		},
+		"a job larger than the queue is never admitted": {
+			policy:     JobQueuePolicyFIFO,
+			admissions: []string{"huge"},
+			want:       []bool{false},
+		},
+		"a job waiting for its dependencies doesn't hold the others": {
+			policy:     JobQueuePolicyFIFO,
+			waiting:    "older",
+			admissions: []string{"newer"},
+			want:       []bool{true},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			jobs := registry.New()
+			namespaces := map[string]string{}
+			for _, job := range []*apiv1.PyTorchJob{
+				newQueuedJob("team-a", "running", "4", now.Add(-3*time.Hour), 1),
+				newQueuedJob("team-a", "huge", "16", now.Add(-2*time.Hour), 0),
+				newQueuedJob("team-a", "older", "4", now.Add(-time.Hour), 0),
+				newQueuedJob("team-b", "newer", "2", now, 0),
+			} {
+				if job.Name == tc.waiting {
+					job.Status.Conditions = append(job.Status.Conditions,
+						apiv1.JobCondition{Type: apiv1.JobWaitingForDependencies, Status: corev1.ConditionTrue})
+				}
+				jobs.OnAdd(job)
+				namespaces[job.Name] = job.Namespace
+			}
+			queues := NewJobQueues(map[string]JobQueueConfig{
+				"training": {Policy: tc.policy, Resources: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("8")}},
+			})
+			var got []bool
+			for _, name := range tc.admissions {
+				info, _ := jobs.Get(apiv1.PyTorchJobKind, namespaces[name], name)
+				admitted, _ := queues.TryAdmit(info, jobs)
+				got = append(got, admitted)
+			}
+			if diff := cmp.Diff(tc.want, got); len(diff) != 0 {
+				t.Errorf("Unexpected admissions (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReconcileQueueAdmission(t *testing.T) {
+	now := time.Now()
+	jobs := registry.New()
+	running := newQueuedJob("team-a", "running", "6", now.Add(-time.Hour), 1)
+	job := newQueuedJob("team-a", "test", "4", now, 0)
+	jobs.OnAdd(running)
+	jobs.OnAdd(job)
+	jc := &JobController{
+		Controller:  &pytorchJobController{frameworkController{framework: "pytorch"}},
+		JobRegistry: jobs,
+		JobControllerOptions: JobControllerOptions{
+			JobQueues: NewJobQueues(map[string]JobQueueConfig{
+				"training": {Policy: JobQueuePolicyFIFO, Resources: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("8")}},
+			}),
+		},
+		Recorder:  record.NewFakeRecorder(10),
+		WorkQueue: workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+	}
+	jobStatus := &apiv1.JobStatus{}
+
+	// The job is pending while the running job uses the resources of the queue.
+	if pending := jc.reconcileQueueAdmission(job, job, &job.Spec.RunPolicy, nil, jobStatus); !pending {
+		t.Errorf("Expected the job to be pending")
+	}
+	if !commonutil.IsPending(*jobStatus) {
+		t.Errorf("Expected a true Pending condition, got: %v", jobStatus.Conditions)
+	}
+
+	// The job is admitted once the running job is finished.
+	running.Status.Conditions = append(running.Status.Conditions, apiv1.JobCondition{Type: apiv1.JobSucceeded, Status: corev1.ConditionTrue})
+	running.Status.ReplicaStatuses[apiv1.PyTorchJobReplicaTypeMaster].Active = 0
+	jobs.OnAdd(running)
+	if pending := jc.reconcileQueueAdmission(job, job, &job.Spec.RunPolicy, nil, jobStatus); pending {
+		t.Errorf("Expected the job to be admitted")
+	}
+	condition := findCondition(jobStatus, apiv1.JobPending)
+	wantReason := commonutil.NewReason(apiv1.PyTorchJobKind, commonutil.JobAdmittedReason)
+	if condition == nil || condition.Status != corev1.ConditionFalse || condition.Reason != wantReason {
+		t.Errorf("Expected a false Pending condition with the %s reason, got: %v", wantReason, jobStatus.Conditions)
+	}
+
+	// The admitted job is not queued again, e.g. while its pods are recreated.
+	if pending := jc.reconcileQueueAdmission(job, job, &job.Spec.RunPolicy, nil, jobStatus); pending {
+		t.Errorf("Expected the admitted job not to be pending")
+	}
+
+	// The jobs whose pods exist before their queue is configured are left as is.
+	other := newQueuedJob("team-a", "other", "8", now, 1)
+	jobs.OnAdd(other)
+	otherStatus := &apiv1.JobStatus{}
+	pods := []*corev1.Pod{{ObjectMeta: metav1.ObjectMeta{Name: "other-master-0"}}}
+	if pending := jc.reconcileQueueAdmission(other, other, &other.Spec.RunPolicy, pods, otherStatus); pending || len(otherStatus.Conditions) != 0 {
+		t.Errorf("Expected the job with pods to be left as is, got pending: %t, conditions: %v", pending, otherStatus.Conditions)
+	}
+}
@@ -23,13 +23,17 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	"github.com/kubeflow/training-operator/pkg/config"
 	"github.com/kubeflow/training-operator/pkg/controller.v1/control"
+	commonutil "github.com/kubeflow/training-operator/pkg/util"
 	testjobv1 "github.com/kubeflow/training-operator/test_job/apis/test_job/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	clocktesting "k8s.io/utils/clock/testing"
 	"k8s.io/utils/ptr"
 )
 
@@ -45,6 +49,7 @@ func TestDeletePodsAndServices(T *testing.T) {
 
 	cases := map[string]struct {
 		cleanPodPolicy apiv1.CleanPodPolicy
+		retainServices bool
 		jobCondition   apiv1.JobConditionType
 		wantPods       *corev1.PodList
 		wantService    *corev1.ServiceList
@@ -91,6 +96,25 @@ func TestDeletePodsAndServices(T *testing.T) {
 				},
 			},
 		},
+		"Finished job retaining its services and cleanPodPolicy is All": {
+			cleanPodPolicy: apiv1.CleanPodPolicyAll,
+			retainServices: true,
+			jobCondition:   apiv1.JobSucceeded,
+			wantPods:       &corev1.PodList{},
+			wantService: &corev1.ServiceList{
+				Items: []corev1.Service{
+					*services[0].(*corev1.Service),
+					*services[1].(*corev1.Service),
+				},
+			},
+		},
+		"Suspended job retaining its services": {
+			cleanPodPolicy: apiv1.CleanPodPolicyAll,
+			retainServices: true,
+			jobCondition:   apiv1.JobSuspended,
+			wantPods:       &corev1.PodList{},
+			wantService:    &corev1.ServiceList{},
+		},
 		"Suspended job and cleanPodPolicy is None": {
 			cleanPodPolicy: apiv1.CleanPodPolicyNone,
 			jobCondition:   apiv1.JobSuspended,
@@ -102,6 +126,7 @@ func TestDeletePodsAndServices(T *testing.T) {
 		T.Run(name, func(t *testing.T) {
 			fakeClient := fake.NewSimpleClientset(append(pods, services...)...)
 			jobController := JobController{
+				Controller:     &frameworkController{framework: "test-framework"},
 				PodControl:     control.RealPodControl{KubeClient: fakeClient, Recorder: &record.FakeRecorder{}},
 				ServiceControl: control.RealServiceControl{KubeClient: fakeClient, Recorder: &record.FakeRecorder{}},
 			}
@@ -112,6 +137,7 @@ func TestDeletePodsAndServices(T *testing.T) {
 			}
 			runPolicy := &apiv1.RunPolicy{
 				CleanPodPolicy: &tc.cleanPodPolicy,
+				RetainServices: &tc.retainServices,
 			}
 			jobStatus := apiv1.JobStatus{
 				Conditions: []apiv1.JobCondition{
@@ -220,6 +246,43 @@ func TestPastActiveDeadline(T *testing.T) {
 	}
 }
 
+func TestPastActiveDeadlineWithClockSkew(T *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cases := map[string]struct {
+		startTime                     time.Time
+		wantPastActiveDeadlineSeconds bool
+	}{
+		"started before the deadline": {
+			startTime:                     now.Add(-9 * time.Second),
+			wantPastActiveDeadlineSeconds: false,
+		},
+		"started at the deadline": {
+			startTime:                     now.Add(-10 * time.Second),
+			wantPastActiveDeadlineSeconds: true,
+		},
+		"started in the future within the skew tolerance": {
+			startTime:                     now.Add(time.Second),
+			wantPastActiveDeadlineSeconds: false,
+		},
+	}
+	for name, tc := range cases {
+		T.Run(name, func(t *testing.T) {
+			jobController := JobController{
+				Clock: commonutil.NewClock(clocktesting.NewFakePassiveClock(now), 2*time.Second),
+			}
+			runPolicy := &apiv1.RunPolicy{
+				ActiveDeadlineSeconds: ptr.To[int64](10),
+			}
+			jobStatus := apiv1.JobStatus{
+				StartTime: &metav1.Time{Time: tc.startTime},
+			}
+			if got := jobController.PastActiveDeadline(runPolicy, jobStatus); tc.wantPastActiveDeadlineSeconds != got {
+				t.Errorf("Unexpected PastActiveDeadline: \nwant: %v\ngot: %v\n", tc.wantPastActiveDeadlineSeconds, got)
+			}
+		})
+	}
+}
+
 func TestManagedByExternalController(T *testing.T) {
 	cases := map[string]struct {
 		managedBy          *string
@@ -284,3 +347,123 @@ func newService(name string) *corev1.Service {
 	}
 	return service
 }
+
+// addAfterQueue is a work queue recording the delays of the keys added after a delay.
+type addAfterQueue struct {
+	workqueue.RateLimitingInterface
+	delays map[string]time.Duration
+}
+
+func (q *addAfterQueue) AddAfter(item interface{}, duration time.Duration) {
+	q.delays[item.(string)] = duration
+}
+
+func TestRequeueRunningJob(t *testing.T) {
+	defer func(period time.Duration) { config.Config.RunningJobResyncPeriod = period }(config.Config.RunningJobResyncPeriod)
+	running := apiv1.JobStatus{}
+	commonutil.UpdateJobConditions(&running, apiv1.JobRunning, corev1.ConditionTrue, "", "", nil)
+	succeeded := *running.DeepCopy()
+	commonutil.UpdateJobConditions(&succeeded, apiv1.JobSucceeded, corev1.ConditionTrue, "", "", nil)
+
+	cases := map[string]struct {
+		period      time.Duration
+		jobStatus   apiv1.JobStatus
+		wantRequeue bool
+	}{
+		"running job": {
+			period:      time.Minute,
+			jobStatus:   running,
+			wantRequeue: true,
+		},
+		"resync disabled": {
+			jobStatus: running,
+		},
+		"created job": {
+			period: time.Minute,
+		},
+		"succeeded job": {
+			period:    time.Minute,
+			jobStatus: succeeded,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			config.Config.RunningJobResyncPeriod = tc.period
+			queue := &addAfterQueue{delays: map[string]time.Duration{}}
+			jc := &JobController{WorkQueue: queue}
+			jc.requeueRunningJob("default/test", tc.jobStatus)
+			delay, requeued := queue.delays["default/test"]
+			if requeued != tc.wantRequeue {
+				t.Fatalf("Unexpected requeue, want: %t, got: %t", tc.wantRequeue, requeued)
+			}
+			if requeued && (delay < tc.period || delay > tc.period+tc.period/10) {
+				t.Errorf("Unexpected delay %v of the period %v", delay, tc.period)
+			}
+		})
+	}
+}
+
+func TestExceedsBackoffLimitOnNewFailure(t *testing.T) {
+	newFailedPod := func(name string, exitCode int32) *corev1.Pod {
+		pod := newPod(name, corev1.PodFailed)
+		pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+			Name:  "test",
+			State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: exitCode}},
+		}}
+		return pod
+	}
+	newJobStatus := func(failed int32, pods ...apiv1.ReplicaPodStatus) apiv1.JobStatus {
+		return apiv1.JobStatus{ReplicaStatuses: map[apiv1.ReplicaType]*apiv1.ReplicaStatus{
+			"Test": {Failed: failed, Active: int32(2 - failed), Pods: pods},
+		}}
+	}
+	failurePolicy := &apiv1.FailurePolicy{Rules: []apiv1.FailurePolicyRule{
+		{Action: apiv1.FailurePolicyActionIgnore, ExitCodes: []int32{137}},
+	}}
+	running := newPod("pod-1", corev1.PodRunning)
+
+	cases := map[string]struct {
+		jobStatus apiv1.JobStatus
+		pods      []*corev1.Pod
+		want      bool
+	}{
+		"newly failed pod matching an Ignore rule": {
+			jobStatus: newJobStatus(0, apiv1.ReplicaPodStatus{Name: "pod-0", Phase: corev1.PodRunning}),
+			pods:      []*corev1.Pod{newFailedPod("pod-0", 137), running},
+		},
+		"recorded failed pod matching an Ignore rule": {
+			jobStatus: newJobStatus(1, apiv1.ReplicaPodStatus{Name: "pod-0", Phase: corev1.PodFailed}),
+			pods:      []*corev1.Pod{newFailedPod("pod-0", 137), running},
+		},
+		"newly failed pod": {
+			jobStatus: newJobStatus(0, apiv1.ReplicaPodStatus{Name: "pod-0", Phase: corev1.PodRunning}),
+			pods:      []*corev1.Pod{newFailedPod("pod-0", 1), running},
+			want:      true,
+		},
+		"recorded failed pod": {
+			jobStatus: newJobStatus(1, apiv1.ReplicaPodStatus{Name: "pod-0", Phase: corev1.PodFailed}),
+			pods:      []*corev1.Pod{newFailedPod("pod-0", 1), running},
+		},
+		"newly failed pod next to a recorded failed pod matching an Ignore rule": {
+			jobStatus: newJobStatus(1, apiv1.ReplicaPodStatus{Name: "pod-0", Phase: corev1.PodFailed}),
+			pods:      []*corev1.Pod{newFailedPod("pod-0", 137), newFailedPod("pod-1", 1)},
+			want:      true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			runPolicy := &apiv1.RunPolicy{BackoffLimit: ptr.To[int32](0), FailurePolicy: failurePolicy}
+			ignoredPods := evaluateFailurePolicy("test-job", failurePolicy, "test", tc.pods).ignoredPods
+			active := int32(0)
+			for _, pod := range tc.pods {
+				if pod.Status.Phase == corev1.PodRunning {
+					active++
+				}
+			}
+			got := exceedsBackoffLimitOnNewFailure(runPolicy, tc.jobStatus, tc.pods, ignoredPods, active, 2, 0)
+			if got != tc.want {
+				t.Errorf("Unexpected result, want: %v, got: %v", tc.want, got)
+			}
+		})
+	}
+}
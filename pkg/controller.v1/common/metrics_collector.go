@@ -0,0 +1,258 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+
+	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	"github.com/kubeflow/training-operator/pkg/config"
+	commonutil "github.com/kubeflow/training-operator/pkg/util"
+)
+
+const (
+	// MetricsCollectorContainerName is the name of the metrics collector injected into the pod of
+	// the master role of the jobs annotated with a MetricsCollectorAnnotation.
+	MetricsCollectorContainerName = "metrics-collector"
+	// metricsVolumeName is the name of the volume of the metrics shared by the main container and
+	// the metrics collector, unless the directory of the metrics file is already a volume.
+	metricsVolumeName = "kubeflow-metrics"
+	// MetricsLogContainerName is the name of the container which streams the log of the main
+	// container to the metrics file of the StdOut metrics collector.
+	MetricsLogContainerName = "metrics-log"
+	// metricsMountPath is where the log of the main container is written with the StdOut metrics
+	// collector.
+	metricsMountPath = "/kubeflow/metrics"
+	// metricsLogScript streams the log of the main container of the pod from the API server to
+	// the metrics file, with the token of the service account of the pod, until the container has
+	// terminated. The log is requested again while the container is waiting to start. The
+	// container always succeeds, so that the log which can't be read doesn't fail the pod.
+	metricsLogScript = `sa=/var/run/secrets/kubernetes.io/serviceaccount
+api="https://kubernetes.default.svc/api/v1/namespaces/$POD_NAMESPACE/pods/$POD_NAME/log?container=$CONTAINER_NAME&follow=true"
+while true; do
+  code=$(curl -sS -o "$METRICS_FILE" -w '%{http_code}' --cacert "$sa/ca.crt" \
+    -H "Authorization: Bearer $(cat "$sa/token")" "$api")
+  [ "$code" = 400 ] || break
+  sleep 1
+done
+if [ "$code" != 200 ]; then
+  echo "Failed to get the log of the $CONTAINER_NAME container: HTTP $code"
+  cat "$METRICS_FILE"
+fi`
+	// mlflowCollectorScript waits for the SIGTERM sent to the metrics collector once the main
+	// containers of the pod have terminated, and pushes the last value of each metric of the
+	// metrics file to a new run of the MLflow experiment, named after the job and tagged with its
+	// run ID.
+	mlflowCollectorScript = `push() {
+  ts="$(date +%s)000"
+  metrics=$(awk -v names=",$METRICS_NAMES," -v ts="$ts" '{
+    for (i = 1; i <= NF; i++) {
+      n = index($i, "=")
+      if (n == 0) continue
+      name = substr($i, 1, n - 1)
+      value = substr($i, n + 1)
+      if (index(names, "," name ",") && value ~ /^[-+]?[0-9]*\.?[0-9]+([eE][-+]?[0-9]+)?$/) last[name] = value
+    }
+  } END {
+    sep = ""
+    for (name in last) {
+      printf "%s{\"key\":\"%s\",\"value\":%.10g,\"timestamp\":%s,\"step\":0}", sep, name, last[name], ts
+      sep = ","
+    }
+  }' "$METRICS_FILE" 2>/dev/null)
+  if [ -z "$metrics" ]; then
+    echo "No metrics $METRICS_NAMES found in $METRICS_FILE"
+    return
+  fi
+  api="$MLFLOW_TRACKING_URI/api/2.0/mlflow/runs"
+  run=$(curl -sSf -X POST "$api/create" -H 'Content-Type: application/json' \
+    -d "{\"experiment_id\":\"$MLFLOW_EXPERIMENT_ID\",\"run_name\":\"$JOB_NAME\",\"start_time\":$ts,\"tags\":[{\"key\":\"kubeflow.org/run-id\",\"value\":\"$RUN_ID\"}]}") || return
+  run_id=$(echo "$run" | sed -n 's/.*"run_id": *"\([^"]*\)".*/\1/p')
+  curl -sSf -X POST "$api/log-batch" -H 'Content-Type: application/json' \
+    -d "{\"run_id\":\"$run_id\",\"metrics\":[$metrics]}" || return
+  curl -sSf -X POST "$api/update" -H 'Content-Type: application/json' \
+    -d "{\"run_id\":\"$run_id\",\"status\":\"FINISHED\",\"end_time\":$ts}" || return
+  echo "Pushed the metrics [$metrics] to the MLflow run $run_id"
+}
+trap 'push; exit 0' TERM
+while true; do sleep 1; done`
+)
+
+// metricsCollector is the configuration of the metrics collector of a job, read from its annotations.
+type metricsCollector struct {
+	kind        string
+	file        string
+	names       []string
+	destination string
+	// trial is the Katib trial of the job, with the katib destination.
+	trial string
+}
+
+// metricsCollectorOf returns the metrics collector requested by the annotations of the job, or
+// nil. The annotations are validated by the webhooks, an error is returned for the invalid ones
+// of the jobs created while the webhooks were not running.
+func metricsCollectorOf(metaObject metav1.Object) (*metricsCollector, error) {
+	annotations := metaObject.GetAnnotations()
+	kind, ok := annotations[apiv1.MetricsCollectorAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	collector := &metricsCollector{kind: kind, destination: annotations[apiv1.MetricsDestinationAnnotation]}
+	switch kind {
+	case apiv1.MetricsCollectorStdOut:
+		collector.file = path.Join(metricsMountPath, "stdout.log")
+	case apiv1.MetricsCollectorFile:
+		collector.file = annotations[apiv1.MetricsFileAnnotation]
+		if !path.IsAbs(collector.file) {
+			return nil, fmt.Errorf("%s %q is not an absolute path", apiv1.MetricsFileAnnotation, collector.file)
+		}
+	default:
+		return nil, fmt.Errorf("unknown %s %q", apiv1.MetricsCollectorAnnotation, kind)
+	}
+	for _, name := range strings.Split(annotations[apiv1.MetricsNamesAnnotation], ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			collector.names = append(collector.names, name)
+		}
+	}
+	if len(collector.names) == 0 {
+		return nil, fmt.Errorf("%s is not set", apiv1.MetricsNamesAnnotation)
+	}
+	switch collector.destination {
+	case apiv1.MetricsDestinationMLflow:
+		if annotations[apiv1.MLflowTrackingURIAnnotation] == "" {
+			return nil, fmt.Errorf("%s is not set", apiv1.MLflowTrackingURIAnnotation)
+		}
+	case apiv1.MetricsDestinationKatib:
+		if collector.trial = metaObject.GetLabels()[apiv1.KatibTrialLabel]; collector.trial == "" {
+			return nil, fmt.Errorf("the job is not a Katib trial, its %s label is not set", apiv1.KatibTrialLabel)
+		}
+	default:
+		return nil, fmt.Errorf("unknown %s %q", apiv1.MetricsDestinationAnnotation, collector.destination)
+	}
+	return collector, nil
+}
+
+// SetMetricsCollector injects the metrics collector requested by the annotations of the job into
+// the pod template of its master role, whose main container is containerName. With the StdOut
+// collector, the log of the main container is streamed to the metrics file by another container,
+// so that the main container is left as is. The MLflow collector is a sidecar init container, so
+// that it is terminated once the main containers have terminated, and pushes the final metrics at
+// that point. The Katib collector is the file metrics collector of Katib, which waits for the main
+// process of the pod in the shared process namespace of the pod, and reports the metrics to the
+// trial of the job.
+func (jc *JobController) SetMetricsCollector(podTemplate *corev1.PodTemplateSpec, containerName string, runtimeObject runtime.Object, metaObject metav1.Object) {
+	collector, err := metricsCollectorOf(metaObject)
+	if collector == nil {
+		if err != nil {
+			jc.Recorder.Eventf(runtimeObject, corev1.EventTypeWarning, commonutil.MetricsCollectorFailedReason, "The metrics collector is not injected: %v", err)
+		}
+		return
+	}
+	for _, container := range append(podTemplate.Spec.InitContainers, podTemplate.Spec.Containers...) {
+		if container.Name == MetricsCollectorContainerName {
+			return
+		}
+	}
+	var main *corev1.Container
+	for i := range podTemplate.Spec.Containers {
+		if podTemplate.Spec.Containers[i].Name == containerName {
+			main = &podTemplate.Spec.Containers[i]
+		}
+	}
+	if main == nil {
+		return
+	}
+
+	// The directory of the metrics file is shared with the collector, with the volume of the
+	// main container mounted at the directory, if any. With the StdOut collector, the main
+	// container doesn't mount the directory, which is written by the container of the log.
+	dir := path.Dir(collector.file)
+	mount := corev1.VolumeMount{Name: metricsVolumeName, MountPath: dir}
+	shared := false
+	for _, volumeMount := range main.VolumeMounts {
+		if collector.kind == apiv1.MetricsCollectorFile && path.Clean(volumeMount.MountPath) == dir {
+			mount, shared = volumeMount, true
+			break
+		}
+	}
+	if !shared {
+		if collector.kind == apiv1.MetricsCollectorFile {
+			main.VolumeMounts = append(main.VolumeMounts, mount)
+		}
+		podTemplate.Spec.Volumes = append(podTemplate.Spec.Volumes, corev1.Volume{
+			Name:         metricsVolumeName,
+			VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+		})
+	}
+	if collector.kind == apiv1.MetricsCollectorStdOut {
+		podTemplate.Spec.Containers = append(podTemplate.Spec.Containers, corev1.Container{
+			Name:    MetricsLogContainerName,
+			Image:   config.Config.MetricsCollectorImage,
+			Command: []string{"sh", "-c", metricsLogScript},
+			Env: []corev1.EnvVar{
+				{Name: "METRICS_FILE", Value: collector.file},
+				{Name: "CONTAINER_NAME", Value: containerName},
+				{Name: "POD_NAME", ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"}}},
+				{Name: "POD_NAMESPACE", ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.namespace"}}},
+			},
+			VolumeMounts: []corev1.VolumeMount{mount},
+		})
+	}
+	mount.ReadOnly = true
+
+	switch collector.destination {
+	case apiv1.MetricsDestinationMLflow:
+		annotations := metaObject.GetAnnotations()
+		experimentID := annotations[apiv1.MLflowExperimentIDAnnotation]
+		if experimentID == "" {
+			experimentID = "0"
+		}
+		podTemplate.Spec.InitContainers = append(podTemplate.Spec.InitContainers, corev1.Container{
+			Name:    MetricsCollectorContainerName,
+			Image:   config.Config.MetricsCollectorImage,
+			Command: []string{"sh", "-c", mlflowCollectorScript},
+			Env: []corev1.EnvVar{
+				{Name: "METRICS_FILE", Value: collector.file},
+				{Name: "METRICS_NAMES", Value: strings.Join(collector.names, ",")},
+				{Name: "MLFLOW_TRACKING_URI", Value: strings.TrimSuffix(annotations[apiv1.MLflowTrackingURIAnnotation], "/")},
+				{Name: "MLFLOW_EXPERIMENT_ID", Value: experimentID},
+				{Name: "JOB_NAME", Value: metaObject.GetName()},
+				{Name: "RUN_ID", Value: RunID(metaObject)},
+			},
+			VolumeMounts:  []corev1.VolumeMount{mount},
+			RestartPolicy: ptr.To(corev1.ContainerRestartPolicyAlways),
+		})
+	case apiv1.MetricsDestinationKatib:
+		podTemplate.Spec.ShareProcessNamespace = ptr.To(true)
+		podTemplate.Spec.Containers = append(podTemplate.Spec.Containers, corev1.Container{
+			Name:  MetricsCollectorContainerName,
+			Image: config.Config.KatibMetricsCollectorImage,
+			Args: []string{
+				"-t", collector.trial,
+				"-m", strings.Join(collector.names, ";"),
+				"-s-db", config.Config.KatibDBManagerAddress,
+				"-path", collector.file,
+			},
+			VolumeMounts: []corev1.VolumeMount{mount},
+		})
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+
+	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	"github.com/kubeflow/training-operator/pkg/config"
+)
+
+func TestSetMetricsCollector(t *testing.T) {
+	oldConfig := config.Config
+	config.Config.MetricsCollectorImage = "curlimages/curl:8.8.0"
+	config.Config.KatibMetricsCollectorImage = "kubeflowkatib/file-metrics-collector:v0.17.0"
+	config.Config.KatibDBManagerAddress = "katib-db-manager.kubeflow:6789"
+	defer func() { config.Config = oldConfig }()
+
+	job := newPriorityPreemptionJob(metav1.NamespaceDefault, "test", "", "1", time.Now(), apiv1.JobCreated)
+	job.UID = "job-uid"
+	cases := map[string]struct {
+		annotations map[string]string
+		labels      map[string]string
+		container   corev1.Container
+		want        corev1.PodSpec
+		wantWarning bool
+	}{
+		"stdout to mlflow": {
+			annotations: map[string]string{
+				apiv1.MetricsCollectorAnnotation:   apiv1.MetricsCollectorStdOut,
+				apiv1.MetricsNamesAnnotation:       "loss, accuracy",
+				apiv1.MetricsDestinationAnnotation: apiv1.MetricsDestinationMLflow,
+				apiv1.MLflowTrackingURIAnnotation:  "http://mlflow.mlflow:5000/",
+			},
+			container: corev1.Container{Name: "pytorch", Command: []string{"python", "train.py"}, Args: []string{"--epochs=1"}},
+			want: corev1.PodSpec{
+				InitContainers: []corev1.Container{{
+					Name:    MetricsCollectorContainerName,
+					Image:   "curlimages/curl:8.8.0",
+					Command: []string{"sh", "-c", mlflowCollectorScript},
+					Env: []corev1.EnvVar{
+						{Name: "METRICS_FILE", Value: "/kubeflow/metrics/stdout.log"},
+						{Name: "METRICS_NAMES", Value: "loss,accuracy"},
+						{Name: "MLFLOW_TRACKING_URI", Value: "http://mlflow.mlflow:5000"},
+						{Name: "MLFLOW_EXPERIMENT_ID", Value: "0"},
+						{Name: "JOB_NAME", Value: "test"},
+						{Name: "RUN_ID", Value: "job-uid"},
+					},
+					VolumeMounts:  []corev1.VolumeMount{{Name: "kubeflow-metrics", MountPath: "/kubeflow/metrics", ReadOnly: true}},
+					RestartPolicy: ptr.To(corev1.ContainerRestartPolicyAlways),
+				}},
+				Containers: []corev1.Container{
+					{Name: "pytorch", Command: []string{"python", "train.py"}, Args: []string{"--epochs=1"}},
+					{
+						Name:    MetricsLogContainerName,
+						Image:   "curlimages/curl:8.8.0",
+						Command: []string{"sh", "-c", metricsLogScript},
+						Env: []corev1.EnvVar{
+							{Name: "METRICS_FILE", Value: "/kubeflow/metrics/stdout.log"},
+							{Name: "CONTAINER_NAME", Value: "pytorch"},
+							{Name: "POD_NAME", ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"}}},
+							{Name: "POD_NAMESPACE", ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.namespace"}}},
+						},
+						VolumeMounts: []corev1.VolumeMount{{Name: "kubeflow-metrics", MountPath: "/kubeflow/metrics"}},
+					},
+				},
+				Volumes: []corev1.Volume{{
+					Name:         "kubeflow-metrics",
+					VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+				}},
+			},
+		},
+		"file in a volume to katib": {
+			annotations: map[string]string{
+				apiv1.MetricsCollectorAnnotation:   apiv1.MetricsCollectorFile,
+				apiv1.MetricsFileAnnotation:        "/output/metrics.log",
+				apiv1.MetricsNamesAnnotation:       "accuracy",
+				apiv1.MetricsDestinationAnnotation: apiv1.MetricsDestinationKatib,
+			},
+			labels:    map[string]string{apiv1.KatibTrialLabel: "trial"},
+			container: corev1.Container{Name: "pytorch", VolumeMounts: []corev1.VolumeMount{{Name: "output", MountPath: "/output/"}}},
+			want: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{Name: "pytorch", VolumeMounts: []corev1.VolumeMount{{Name: "output", MountPath: "/output/"}}},
+					{
+						Name:  MetricsCollectorContainerName,
+						Image: "kubeflowkatib/file-metrics-collector:v0.17.0",
+						Args: []string{
+							"-t", "trial",
+							"-m", "accuracy",
+							"-s-db", "katib-db-manager.kubeflow:6789",
+							"-path", "/output/metrics.log",
+						},
+						VolumeMounts: []corev1.VolumeMount{{Name: "output", MountPath: "/output/", ReadOnly: true}},
+					},
+				},
+				ShareProcessNamespace: ptr.To(true),
+			},
+		},
+		"katib without trial": {
+			annotations: map[string]string{
+				apiv1.MetricsCollectorAnnotation:   apiv1.MetricsCollectorStdOut,
+				apiv1.MetricsNamesAnnotation:       "loss",
+				apiv1.MetricsDestinationAnnotation: apiv1.MetricsDestinationKatib,
+			},
+			container:   corev1.Container{Name: "pytorch"},
+			want:        corev1.PodSpec{Containers: []corev1.Container{{Name: "pytorch"}}},
+			wantWarning: true,
+		},
+		"invalid annotations": {
+			annotations: map[string]string{
+				apiv1.MetricsCollectorAnnotation:   apiv1.MetricsCollectorFile,
+				apiv1.MetricsFileAnnotation:        "metrics.log",
+				apiv1.MetricsNamesAnnotation:       "loss",
+				apiv1.MetricsDestinationAnnotation: apiv1.MetricsDestinationKatib,
+			},
+			container:   corev1.Container{Name: "pytorch"},
+			want:        corev1.PodSpec{Containers: []corev1.Container{{Name: "pytorch"}}},
+			wantWarning: true,
+		},
+		"no metrics collector": {
+			container: corev1.Container{Name: "pytorch"},
+			want:      corev1.PodSpec{Containers: []corev1.Container{{Name: "pytorch"}}},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			job := job.DeepCopy()
+			job.Annotations = tc.annotations
+			job.Labels = tc.labels
+			recorder := record.NewFakeRecorder(10)
+			jc := &JobController{Recorder: recorder}
+			podTemplate := &corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{tc.container}}}
+			jc.SetMetricsCollector(podTemplate, "pytorch", job, job)
+			if !tc.wantWarning {
+				// The metrics collector is injected only once.
+				jc.SetMetricsCollector(podTemplate, "pytorch", job, job)
+			}
+			if diff := cmp.Diff(tc.want, podTemplate.Spec); len(diff) != 0 {
+				t.Errorf("Unexpected pod spec (-want,+got):\n%s", diff)
+			}
+			if warning := len(recorder.Events) != 0; warning != tc.wantWarning {
+				t.Errorf("Unexpected warning event, want: %t, got: %t", tc.wantWarning, warning)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/kubeflow/training-operator/pkg/config"
+	commonutil "github.com/kubeflow/training-operator/pkg/util"
+)
+
+// GenNetworkPolicyName returns the name of the NetworkPolicy of the job.
+func GenNetworkPolicyName(jobName string) string {
+	return jobName
+}
+
+// ParseNetworkPolicyCIDRs parses the comma-separated CIDRs of the --network-policy-allowed-cidrs flag.
+func ParseNetworkPolicyCIDRs(value string) ([]string, error) {
+	var cidrs []string
+	for _, cidr := range strings.Split(value, ",") {
+		cidr = strings.TrimSpace(cidr)
+		if cidr == "" {
+			continue
+		}
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return nil, fmt.Errorf("invalid CIDR %q: %w", cidr, err)
+		}
+		cidrs = append(cidrs, cidr)
+	}
+	return cidrs, nil
+}
+
+// networkPolicySpec returns the spec of the NetworkPolicy of the job, which only lets the pods
+// of the job and the allowed CIDRs of the operator reach the pods of the job.
+func (jc *JobController) networkPolicySpec(metaObject metav1.Object) networkingv1.NetworkPolicySpec {
+	selector := metav1.LabelSelector{MatchLabels: jc.GenLabels(metaObject.GetName())}
+	peers := []networkingv1.NetworkPolicyPeer{{PodSelector: &selector}}
+	for _, cidr := range config.Config.NetworkPolicyAllowedCIDRs {
+		peers = append(peers, networkingv1.NetworkPolicyPeer{IPBlock: &networkingv1.IPBlock{CIDR: cidr}})
+	}
+	return networkingv1.NetworkPolicySpec{
+		PodSelector: selector,
+		Ingress:     []networkingv1.NetworkPolicyIngressRule{{From: peers}},
+		PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+	}
+}
+
+// ReconcileNetworkPolicy creates the NetworkPolicy restricting the ingress of the pods of the job
+// to the pods of the job and the allowed CIDRs, if the operator isolates the jobs, and updates it
+// once the allowed CIDRs change. The NetworkPolicy is controlled by the job, so that it is garbage
+// collected with the job.
+func (jc *JobController) ReconcileNetworkPolicy(runtimeObject runtime.Object, metaObject metav1.Object) error {
+	if jc.NetworkPolicyClient == nil {
+		return nil
+	}
+	spec := jc.networkPolicySpec(metaObject)
+	policy := &networkingv1.NetworkPolicy{}
+	key := types.NamespacedName{Namespace: metaObject.GetNamespace(), Name: GenNetworkPolicyName(metaObject.GetName())}
+	err := jc.NetworkPolicyClient.Get(context.Background(), key, policy)
+	if err == nil {
+		if !metav1.IsControlledBy(policy, metaObject) {
+			if StrictOwnership() {
+				return &NameCollisionError{Kind: "NetworkPolicy", Name: policy.Name}
+			}
+			return fmt.Errorf("NetworkPolicy %s already exists and is not controlled by the job", policy.Name)
+		}
+		if equality.Semantic.DeepEqual(policy.Spec, spec) {
+			return nil
+		}
+		policy.Spec = spec
+		jc.RecordAPICall(metaObject, APICallUpdate)
+		if err := jc.NetworkPolicyClient.Update(context.Background(), policy); err != nil {
+			return err
+		}
+		jc.Recorder.Eventf(runtimeObject, corev1.EventTypeNormal, commonutil.SuccessfulUpdateNetworkPolicyReason, "Updated NetworkPolicy: %v", policy.Name)
+		return nil
+	}
+	if !errors.IsNotFound(err) {
+		return err
+	}
+
+	policy = &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            key.Name,
+			Namespace:       key.Namespace,
+			Labels:          jc.GenLabels(metaObject.GetName()),
+			OwnerReferences: []metav1.OwnerReference{*jc.GenOwnerReference(metaObject)},
+		},
+		Spec: spec,
+	}
+	jc.RecordAPICall(metaObject, APICallCreate)
+	if err := jc.NetworkPolicyClient.Create(context.Background(), policy); err != nil {
+		jc.Recorder.Eventf(runtimeObject, corev1.EventTypeWarning, commonutil.FailedCreateNetworkPolicyReason, "Error creating: %v", err)
+		return err
+	}
+	jc.Recorder.Eventf(runtimeObject, corev1.EventTypeNormal, commonutil.SuccessfulCreateNetworkPolicyReason, "Created NetworkPolicy: %v", policy.Name)
+	return nil
+}
@@ -0,0 +1,121 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kubeflow/training-operator/pkg/config"
+	testjobv1 "github.com/kubeflow/training-operator/test_job/apis/test_job/v1"
+)
+
+func TestParseNetworkPolicyCIDRs(t *testing.T) {
+	cidrs, err := ParseNetworkPolicyCIDRs(" 10.0.0.0/8, ,fd00::/8")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if diff := cmp.Diff([]string{"10.0.0.0/8", "fd00::/8"}, cidrs); len(diff) != 0 {
+		t.Errorf("Unexpected CIDRs (-want,+got):\n%s", diff)
+	}
+	if _, err := ParseNetworkPolicyCIDRs("10.0.0.1"); err == nil {
+		t.Errorf("Expected an error for an address without prefix length")
+	}
+}
+
+func TestReconcileNetworkPolicy(t *testing.T) {
+	defer func(cidrs []string) { config.Config.NetworkPolicyAllowedCIDRs = cidrs }(config.Config.NetworkPolicyAllowedCIDRs)
+	config.Config.NetworkPolicyAllowedCIDRs = []string{"10.0.0.0/8"}
+
+	job := &testjobv1.TestJob{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: metav1.NamespaceDefault, UID: "job-uid"}}
+	c := fake.NewClientBuilder().Build()
+	jc := &JobController{
+		Controller:           &testJobController{frameworkController{framework: "test-framework"}},
+		Recorder:             record.NewFakeRecorder(10),
+		JobControllerOptions: JobControllerOptions{NetworkPolicyClient: c},
+	}
+	key := types.NamespacedName{Namespace: job.Namespace, Name: GenNetworkPolicyName(job.Name)}
+
+	if err := jc.ReconcileNetworkPolicy(job, job); err != nil {
+		t.Fatalf("Unexpected error creating the NetworkPolicy: %v", err)
+	}
+	policy := &networkingv1.NetworkPolicy{}
+	if err := c.Get(context.Background(), key, policy); err != nil {
+		t.Fatalf("Failed to get the NetworkPolicy: %v", err)
+	}
+	if !metav1.IsControlledBy(policy, job) {
+		t.Errorf("Expected the NetworkPolicy to be controlled by the job")
+	}
+	selector := metav1.LabelSelector{MatchLabels: jc.GenLabels(job.Name)}
+	want := networkingv1.NetworkPolicySpec{
+		PodSelector: selector,
+		Ingress: []networkingv1.NetworkPolicyIngressRule{{From: []networkingv1.NetworkPolicyPeer{
+			{PodSelector: &selector},
+			{IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.0/8"}},
+		}}},
+		PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+	}
+	if diff := cmp.Diff(want, policy.Spec); len(diff) != 0 {
+		t.Errorf("Unexpected spec of the NetworkPolicy (-want,+got):\n%s", diff)
+	}
+
+	// The NetworkPolicy follows the allowed CIDRs of the operator.
+	config.Config.NetworkPolicyAllowedCIDRs = nil
+	if err := jc.ReconcileNetworkPolicy(job, job); err != nil {
+		t.Fatalf("Unexpected error updating the NetworkPolicy: %v", err)
+	}
+	if err := c.Get(context.Background(), key, policy); err != nil {
+		t.Fatalf("Failed to get the NetworkPolicy: %v", err)
+	}
+	if diff := cmp.Diff([]networkingv1.NetworkPolicyPeer{{PodSelector: &selector}}, policy.Spec.Ingress[0].From); len(diff) != 0 {
+		t.Errorf("Unexpected peers of the NetworkPolicy (-want,+got):\n%s", diff)
+	}
+}
+
+func TestReconcileNetworkPolicyCollision(t *testing.T) {
+	defer func(strict bool) { config.Config.StrictOwnership = strict }(config.Config.StrictOwnership)
+	config.Config.StrictOwnership = true
+
+	job := &testjobv1.TestJob{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: metav1.NamespaceDefault, UID: "job-uid"}}
+	existing := &networkingv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: GenNetworkPolicyName(job.Name), Namespace: job.Namespace}}
+	jc := &JobController{
+		Controller:           &testJobController{frameworkController{framework: "test-framework"}},
+		Recorder:             record.NewFakeRecorder(10),
+		JobControllerOptions: JobControllerOptions{NetworkPolicyClient: fake.NewClientBuilder().WithObjects(existing).Build()},
+	}
+
+	var collision *NameCollisionError
+	if err := jc.ReconcileNetworkPolicy(job, job); !errors.As(err, &collision) {
+		t.Errorf("Expected a NameCollisionError, got: %v", err)
+	}
+}
+
+func TestReconcileNetworkPolicyDisabled(t *testing.T) {
+	job := &testjobv1.TestJob{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: metav1.NamespaceDefault}}
+	jc := &JobController{Recorder: record.NewFakeRecorder(10)}
+
+	// No NetworkPolicy is created out of the opt-in mode.
+	if err := jc.ReconcileNetworkPolicy(job, job); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
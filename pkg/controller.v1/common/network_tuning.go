@@ -0,0 +1,83 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	"github.com/kubeflow/training-operator/pkg/config"
+	"github.com/kubeflow/training-operator/pkg/core"
+)
+
+// networkTuningEnvPrefixes are the prefixes of the keys of the network tuning ConfigMap which
+// are injected as env vars, the other keys are ignored.
+var networkTuningEnvPrefixes = []string{"NCCL_", "GLOO_"}
+
+// NetworkTuningEnv returns the NCCL and Gloo env vars of the network tuning ConfigMap mounted
+// in dir, sorted by name. Each key of the ConfigMap is a file of dir. The ConfigMap is read on
+// each call, so that its updates apply to the pods created afterwards without a restart of the
+// operator. No env var is returned if the ConfigMap is not mounted.
+func NetworkTuningEnv(dir string) []corev1.EnvVar {
+	if dir == "" {
+		return nil
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var env []corev1.EnvVar
+	for _, entry := range entries {
+		name := entry.Name()
+		if !hasNetworkTuningEnvPrefix(name) {
+			continue
+		}
+		// The keys of a mounted ConfigMap are symlinks to the files of its current version.
+		value, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			continue
+		}
+		env = append(env, corev1.EnvVar{Name: name, Value: strings.TrimSpace(string(value))})
+	}
+	return env
+}
+
+func hasNetworkTuningEnvPrefix(name string) bool {
+	for _, prefix := range networkTuningEnvPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// SetNetworkTuningEnv injects the env vars of the network tuning ConfigMap of the operator into
+// the given container of the podTemplate, unless the injection is disabled for the job. The env
+// vars already defined in the container take precedence, so it is called after the common env of
+// the job is set.
+func SetNetworkTuningEnv(podTemplate *corev1.PodTemplateSpec, containerName string, runPolicy *apiv1.RunPolicy) {
+	inject := config.Config.InjectNetworkTuning
+	if runPolicy.InjectNetworkTuning != nil {
+		inject = *runPolicy.InjectNetworkTuning
+	}
+	if !inject {
+		return
+	}
+	core.SetCommonEnv(podTemplate, containerName, NetworkTuningEnv(config.Config.NetworkTuningEnvDir), nil)
+}
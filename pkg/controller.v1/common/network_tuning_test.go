@@ -0,0 +1,97 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	"github.com/kubeflow/training-operator/pkg/config"
+)
+
+func TestSetNetworkTuningEnv(t *testing.T) {
+	dir := t.TempDir()
+	for name, value := range map[string]string{
+		"NCCL_SOCKET_IFNAME": "eth0\n",
+		"NCCL_IB_DISABLE":    "1",
+		"GLOO_SOCKET_IFNAME": "eth0",
+		"OTHER":              "ignored",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(value), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	defer func(inject bool, dir string) {
+		config.Config.InjectNetworkTuning, config.Config.NetworkTuningEnvDir = inject, dir
+	}(config.Config.InjectNetworkTuning, config.Config.NetworkTuningEnvDir)
+	config.Config.NetworkTuningEnvDir = dir
+
+	cases := map[string]struct {
+		inject    bool
+		runPolicy apiv1.RunPolicy
+		wantEnv   []corev1.EnvVar
+	}{
+		"disabled for the operator": {
+			wantEnv: []corev1.EnvVar{{Name: "NCCL_SOCKET_IFNAME", Value: "ib0"}},
+		},
+		"enabled for the operator": {
+			inject: true,
+			wantEnv: []corev1.EnvVar{
+				{Name: "GLOO_SOCKET_IFNAME", Value: "eth0"},
+				{Name: "NCCL_IB_DISABLE", Value: "1"},
+				{Name: "NCCL_SOCKET_IFNAME", Value: "ib0"},
+			},
+		},
+		"disabled for the job": {
+			inject:    true,
+			runPolicy: apiv1.RunPolicy{InjectNetworkTuning: ptr.To(false)},
+			wantEnv:   []corev1.EnvVar{{Name: "NCCL_SOCKET_IFNAME", Value: "ib0"}},
+		},
+		"enabled for the job": {
+			runPolicy: apiv1.RunPolicy{InjectNetworkTuning: ptr.To(true)},
+			wantEnv: []corev1.EnvVar{
+				{Name: "GLOO_SOCKET_IFNAME", Value: "eth0"},
+				{Name: "NCCL_IB_DISABLE", Value: "1"},
+				{Name: "NCCL_SOCKET_IFNAME", Value: "ib0"},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			config.Config.InjectNetworkTuning = tc.inject
+			podTemplate := &corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{{
+				Name: "test",
+				// The env vars of the container take precedence.
+				Env: []corev1.EnvVar{{Name: "NCCL_SOCKET_IFNAME", Value: "ib0"}},
+			}}}}
+			SetNetworkTuningEnv(podTemplate, "test", &tc.runPolicy)
+			if diff := cmp.Diff(tc.wantEnv, podTemplate.Spec.Containers[0].Env); len(diff) != 0 {
+				t.Errorf("Unexpected env (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNetworkTuningEnvWithoutConfigMap(t *testing.T) {
+	if env := NetworkTuningEnv(filepath.Join(t.TempDir(), "missing")); len(env) != 0 {
+		t.Errorf("Unexpected env: %v", env)
+	}
+}
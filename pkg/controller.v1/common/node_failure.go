@@ -0,0 +1,146 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/utils/ptr"
+
+	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	trainingoperatorcommon "github.com/kubeflow/training-operator/pkg/common"
+	"github.com/kubeflow/training-operator/pkg/config"
+	"github.com/kubeflow/training-operator/pkg/controller.v1/expectation"
+	commonutil "github.com/kubeflow/training-operator/pkg/util"
+	utillabels "github.com/kubeflow/training-operator/pkg/util/labels"
+)
+
+// podsOnFailedNodes returns the unfinished pods bound to a node which has not been Ready
+// for longer than the node failure timeout of the operator, or which no longer exists.
+// Such pods are never updated again by the kubelet, e.g. a pod stays Running in the API
+// while its node is down. When some pods are bound to a node which is not Ready yet for
+// long enough, the delay until the first of them times out is returned as well.
+//
+// Only the pods which are not Ready or are being deleted are checked, since the node
+// lifecycle controller marks the pods of a NotReady node as not Ready.
+func (jc *JobController) podsOnFailedNodes(pods []*corev1.Pod) ([]*corev1.Pod, time.Duration, error) {
+	timeout := config.Config.NodeFailureTimeout
+	if timeout <= 0 || jc.NodeLister == nil {
+		return nil, 0, nil
+	}
+	var failed []*corev1.Pod
+	var requeueAfter time.Duration
+	notReadySince := map[string]*time.Time{}
+	for _, pod := range pods {
+		if pod.Spec.NodeName == "" || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		if pod.DeletionTimestamp == nil && isPodReady(pod) {
+			continue
+		}
+		since, ok := notReadySince[pod.Spec.NodeName]
+		if !ok {
+			var err error
+			if since, err = jc.nodeNotReadySince(pod.Spec.NodeName); err != nil {
+				return nil, 0, err
+			}
+			notReadySince[pod.Spec.NodeName] = since
+		}
+		if since == nil {
+			continue
+		}
+		if elapsed := jc.Clock.Since(*since); elapsed >= timeout {
+			failed = append(failed, pod)
+		} else if remaining := timeout - elapsed; requeueAfter == 0 || remaining < requeueAfter {
+			requeueAfter = remaining
+		}
+	}
+	return failed, requeueAfter, nil
+}
+
+// nodeNotReadySince returns the time since which the node is not Ready, or nil if it is Ready.
+// A node which no longer exists is not Ready since the zero time. The node is read from the cache,
+// since the nodes of the pods of all the jobs are checked on every reconcile.
+func (jc *JobController) nodeNotReadySince(name string) (*time.Time, error) {
+	node, err := jc.NodeLister.Get(name)
+	if errors.IsNotFound(err) {
+		return &time.Time{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			if condition.Status == corev1.ConditionTrue {
+				return nil, nil
+			}
+			return &condition.LastTransitionTime.Time, nil
+		}
+	}
+	// The node has not posted its status yet.
+	return nil, nil
+}
+
+func isPodReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// reschedulePodsFromFailedNodes force-deletes the pods bound to a failed node, since their
+// graceful deletion waits for a kubelet which is gone, so that they are recreated on another
+// node once the deletions are observed.
+func (jc *JobController) reschedulePodsFromFailedNodes(metaObject metav1.Object, runtimeObject runtime.Object, jobStatus *apiv1.JobStatus, pods []*corev1.Pod) error {
+	jobKey, err := KeyFunc(metaObject)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("couldn't get key for job object %#v: %v", metaObject, err))
+		return err
+	}
+	jobKind := jc.Controller.GetAPIGroupVersionKind().Kind
+	for _, pod := range pods {
+		rType, err := utillabels.ReplicaType(pod.Labels)
+		if err != nil {
+			return err
+		}
+		rt := strings.ToLower(string(rType))
+		commonutil.LoggerForReplica(metaObject, rt).Info("Force deleting the pod of a failed node", "pod", pod.Name, "node", pod.Spec.NodeName)
+		jc.RecordAPICall(metaObject, APICallDelete)
+		err = jc.KubeClientSet.CoreV1().Pods(pod.Namespace).Delete(context.Background(), pod.Name, metav1.DeleteOptions{
+			GracePeriodSeconds: ptr.To[int64](0),
+		})
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		// Deletion is expected
+		jc.Expectations.RaiseExpectations(expectation.GenExpectationPodsKey(jobKey, rt), 0, 1)
+
+		msg := fmt.Sprintf("%s %s is restarting because pod %s of node %s was lost.", jobKind, metaObject.GetName(), pod.Name, pod.Spec.NodeName)
+		jc.Recorder.Event(runtimeObject, corev1.EventTypeWarning, commonutil.NewReason(jobKind, commonutil.JobNodeFailureReason), msg)
+		commonutil.UpdateJobConditions(jobStatus, apiv1.JobRestarting, corev1.ConditionTrue, commonutil.NewReason(jobKind, commonutil.JobNodeFailureReason), msg, jc.Clock)
+		trainingoperatorcommon.RestartedJobsCounterInc(metaObject.GetNamespace(), jc.Controller.GetFrameworkName())
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	clocktesting "k8s.io/utils/clock/testing"
+
+	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	"github.com/kubeflow/training-operator/pkg/config"
+	"github.com/kubeflow/training-operator/pkg/controller.v1/expectation"
+	commonutil "github.com/kubeflow/training-operator/pkg/util"
+	testjobv1 "github.com/kubeflow/training-operator/test_job/apis/test_job/v1"
+)
+
+func newNode(name string, ready corev1.ConditionStatus, since time.Time) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{{
+			Type:               corev1.NodeReady,
+			Status:             ready,
+			LastTransitionTime: metav1.NewTime(since),
+		}}},
+	}
+}
+
+func newBoundPod(name, nodeName string, ready corev1.ConditionStatus) *corev1.Pod {
+	pod := newPod(name, corev1.PodRunning)
+	pod.Namespace = metav1.NamespaceDefault
+	pod.Spec.NodeName = nodeName
+	pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: ready}}
+	return pod
+}
+
+func TestPodsOnFailedNodes(t *testing.T) {
+	defer func(timeout time.Duration) { config.Config.NodeFailureTimeout = timeout }(config.Config.NodeFailureTimeout)
+	config.Config.NodeFailureTimeout = 5 * time.Minute
+
+	now := time.Now()
+	nodes := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, node := range []*corev1.Node{
+		newNode("ready", corev1.ConditionTrue, now.Add(-time.Hour)),
+		newNode("failed", corev1.ConditionUnknown, now.Add(-10*time.Minute)),
+		newNode("failing", corev1.ConditionFalse, now.Add(-4*time.Minute)),
+	} {
+		if err := nodes.Add(node); err != nil {
+			t.Fatalf("Failed to add the node %s: %v", node.Name, err)
+		}
+	}
+	jc := &JobController{
+		JobControllerOptions: JobControllerOptions{NodeLister: corelisters.NewNodeLister(nodes)},
+		Clock:                commonutil.NewClock(clocktesting.NewFakePassiveClock(now), 0),
+	}
+	onFailedNode := newBoundPod("on-failed-node", "failed", corev1.ConditionFalse)
+	onDeletedNode := newBoundPod("on-deleted-node", "deleted", corev1.ConditionFalse)
+	pods := []*corev1.Pod{
+		newBoundPod("on-ready-node", "ready", corev1.ConditionFalse),
+		onFailedNode,
+		onDeletedNode,
+		newBoundPod("on-failing-node", "failing", corev1.ConditionFalse),
+		// The pods which are still Ready or finished are not checked.
+		newBoundPod("ready", "deleted", corev1.ConditionTrue),
+		func() *corev1.Pod {
+			pod := newBoundPod("failed", "failed", corev1.ConditionFalse)
+			pod.Status.Phase = corev1.PodFailed
+			return pod
+		}(),
+		newPod("unscheduled", corev1.PodPending),
+	}
+
+	got, requeueAfter, err := jc.podsOnFailedNodes(pods)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if diff := cmp.Diff([]*corev1.Pod{onFailedNode, onDeletedNode}, got); len(diff) != 0 {
+		t.Errorf("Unexpected pods on failed nodes (-want,+got):\n%s", diff)
+	}
+	if requeueAfter != time.Minute {
+		t.Errorf("Unexpected requeue delay, want: %v, got: %v", time.Minute, requeueAfter)
+	}
+
+	config.Config.NodeFailureTimeout = 0
+	if got, _, _ := jc.podsOnFailedNodes(pods); len(got) != 0 {
+		t.Errorf("Expected no pods on failed nodes with the node failure timeout disabled, got: %v", got)
+	}
+}
+
+func TestReschedulePodsFromFailedNodes(t *testing.T) {
+	job := &testjobv1.TestJob{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: metav1.NamespaceDefault}}
+	pod := newBoundPod("lost", "failed", corev1.ConditionFalse)
+	recorder := record.NewFakeRecorder(10)
+	jc := &JobController{
+		Controller:    &testJobController{frameworkController{framework: "test-framework"}},
+		KubeClientSet: kubefake.NewSimpleClientset(pod),
+		Expectations:  expectation.NewControllerExpectations(),
+		Recorder:      recorder,
+	}
+	jobStatus := &apiv1.JobStatus{}
+
+	if err := jc.reschedulePodsFromFailedNodes(job, job, jobStatus, []*corev1.Pod{pod}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	pods, err := jc.KubeClientSet.CoreV1().Pods(metav1.NamespaceDefault).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("Failed to list the pods: %v", err)
+	}
+	if len(pods.Items) != 0 {
+		t.Errorf("Expected the pod to be deleted, got: %v", pods.Items)
+	}
+	if !commonutil.IsRestarting(*jobStatus) {
+		t.Errorf("Expected a Restarting condition, got: %v", jobStatus.Conditions)
+	}
+	if got := len(recorder.Events); got != 1 {
+		t.Errorf("Unexpected number of events, want: 1, got: %d", got)
+	}
+	// Deleting the pod again doesn't fail once it is gone.
+	if err := jc.reschedulePodsFromFailedNodes(job, job, jobStatus, []*corev1.Pod{pod}); err != nil {
+		t.Errorf("Unexpected error for a deleted pod: %v", err)
+	}
+}
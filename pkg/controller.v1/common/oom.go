@@ -0,0 +1,203 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	trainingoperatorcommon "github.com/kubeflow/training-operator/pkg/common"
+	commonutil "github.com/kubeflow/training-operator/pkg/util"
+)
+
+const (
+	// oomKilledReason is the reason of the termination of a container killed by the kernel
+	// because its cgroup ran out of memory.
+	oomKilledReason = "OOMKilled"
+	// oomKilledHint is appended to the message of the conditions of a job whose containers were OOMKilled.
+	oomKilledHint = "Consider increasing the memory limit of the replicas."
+	// maxOOMKillsInMessage is the maximum number of OOM kills described in a condition message.
+	maxOOMKillsInMessage = 3
+)
+
+// oomKill is the termination of a container of a pod which ran out of memory.
+type oomKill struct {
+	pod         string
+	container   string
+	memoryLimit string
+	finishedAt  metav1.Time
+}
+
+func (k oomKill) String() string {
+	return fmt.Sprintf("Container %s of pod %s was OOMKilled with a memory limit of %s.", k.container, k.pod, k.memoryLimit)
+}
+
+// oomKills returns the OOM kills of the containers of the pod, from their current
+// and last termination states, since a container restarted in place keeps its last one.
+func oomKills(pod *corev1.Pod) []oomKill {
+	var kills []oomKill
+	statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	for _, status := range statuses {
+		for _, terminated := range []*corev1.ContainerStateTerminated{status.LastTerminationState.Terminated, status.State.Terminated} {
+			if terminated == nil || terminated.Reason != oomKilledReason {
+				continue
+			}
+			kills = append(kills, oomKill{
+				pod:         pod.Name,
+				container:   status.Name,
+				memoryLimit: memoryLimit(pod, status.Name),
+				finishedAt:  terminated.FinishedAt,
+			})
+		}
+	}
+	return kills
+}
+
+// OOMKillTracker remembers the OOM kills of the pods of the jobs which were recorded, per pod UID,
+// so that each OOM kill is recorded once although the pods are reconciled many times.
+// It is safe for concurrent use.
+type OOMKillTracker struct {
+	mu sync.Mutex
+	// since is the time the tracker was created at. The OOM kills which happened before, e.g.
+	// while the operator was down, are not recorded again.
+	since time.Time
+	// recorded are the OOM kills recorded per UID of job and of pod. Only the pods with OOM
+	// kills are tracked.
+	recorded map[types.UID]map[types.UID]sets.Set[oomKill]
+}
+
+// NewOOMKillTracker returns an OOMKillTracker recording the OOM kills which happen since the time.
+func NewOOMKillTracker(since time.Time) *OOMKillTracker {
+	return &OOMKillTracker{since: since, recorded: map[types.UID]map[types.UID]sets.Set[oomKill]{}}
+}
+
+// newOOMKills returns the OOM kills of the pods of the job which were not recorded yet, and
+// remembers them. The pods which are gone are forgotten.
+func (t *OOMKillTracker) newOOMKills(jobUID types.UID, pods []*corev1.Pod) []oomKill {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	recorded := t.recorded[jobUID]
+	current := map[types.UID]sets.Set[oomKill]{}
+	var kills []oomKill
+	for _, pod := range pods {
+		for _, kill := range oomKills(pod) {
+			if kill.finishedAt.Time.Before(t.since) {
+				continue
+			}
+			if current[pod.UID] == nil {
+				current[pod.UID] = sets.New[oomKill]()
+			}
+			if current[pod.UID].Has(kill) {
+				continue
+			}
+			current[pod.UID].Insert(kill)
+			if !recorded[pod.UID].Has(kill) {
+				kills = append(kills, kill)
+			}
+		}
+	}
+	if len(current) == 0 {
+		delete(t.recorded, jobUID)
+	} else {
+		t.recorded[jobUID] = current
+	}
+	return kills
+}
+
+// memoryLimit returns the memory limit of the container of the pod, or "none" if it has no limit.
+func memoryLimit(pod *corev1.Pod, name string) string {
+	containers := append(append([]corev1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...)
+	for _, container := range containers {
+		if container.Name != name {
+			continue
+		}
+		if limit, ok := container.Resources.Limits[corev1.ResourceMemory]; ok {
+			return limit.String()
+		}
+	}
+	return "none"
+}
+
+// RecordOOMKills records the containers of the pods of the job which were OOMKilled since they
+// were last reconciled: a warning event is emitted on the job and the OOM kills are counted in
+// the OOM kills metric of the namespace and the framework of the job.
+func (jc *JobController) RecordOOMKills(job metav1.Object, pods []*corev1.Pod) {
+	if jc.OOMKillTracker == nil {
+		return
+	}
+	kills := jc.OOMKillTracker.newOOMKills(job.GetUID(), pods)
+	if len(kills) == 0 {
+		return
+	}
+	logger := commonutil.LoggerForJob(job)
+	runtimeObject, isRuntimeObject := job.(runtime.Object)
+	for _, kill := range kills {
+		logger.Info("Container was OOMKilled", "pod", kill.pod, "container", kill.container, "memoryLimit", kill.memoryLimit)
+		if isRuntimeObject {
+			jc.Recorder.Eventf(runtimeObject, corev1.EventTypeWarning, commonutil.OOMKilledReason, "%s %s", kill, oomKilledHint)
+		}
+	}
+	trainingoperatorcommon.OOMKillsCounterAdd(job.GetNamespace(), jc.Controller.GetFrameworkName(), len(kills))
+}
+
+// addOOMKilledHint describes the OOM kills of the pods in the Restarting and Failed conditions
+// which were updated since the old status, with a hint to increase the memory limit.
+func addOOMKilledHint(jobStatus, oldStatus *apiv1.JobStatus, pods []*corev1.Pod) {
+	var kills []string
+	for _, pod := range pods {
+		for _, kill := range oomKills(pod) {
+			kills = append(kills, kill.String())
+		}
+	}
+	if len(kills) == 0 {
+		return
+	}
+	if len(kills) > maxOOMKillsInMessage {
+		kills = append(kills[:maxOOMKillsInMessage], fmt.Sprintf("%d more containers were OOMKilled.", len(kills)-maxOOMKillsInMessage))
+	}
+	hint := strings.Join(append(kills, oomKilledHint), " ")
+	for i := range jobStatus.Conditions {
+		condition := &jobStatus.Conditions[i]
+		if (condition.Type != apiv1.JobRestarting && condition.Type != apiv1.JobFailed) || condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		if strings.HasSuffix(condition.Message, oomKilledHint) {
+			continue
+		}
+		if old := findCondition(oldStatus, condition.Type); old != nil && reflect.DeepEqual(*old, *condition) {
+			continue
+		}
+		condition.Message = strings.TrimSpace(condition.Message + " " + hint)
+	}
+}
+
+func findCondition(status *apiv1.JobStatus, conditionType apiv1.JobConditionType) *apiv1.JobCondition {
+	for i := range status.Conditions {
+		if status.Conditions[i].Type == conditionType {
+			return &status.Conditions[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+)
+
+func newOOMKilledPod(lastFinishedAt, finishedAt *metav1.Time) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "job-worker-0"},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name: "pytorch",
+				Resources: corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi")},
+				},
+			}},
+		},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{{Name: "pytorch"}},
+		},
+	}
+	status := &pod.Status.ContainerStatuses[0]
+	if lastFinishedAt != nil {
+		status.LastTerminationState.Terminated = &corev1.ContainerStateTerminated{Reason: oomKilledReason, ExitCode: 137, FinishedAt: *lastFinishedAt}
+	}
+	if finishedAt != nil {
+		status.State.Terminated = &corev1.ContainerStateTerminated{Reason: oomKilledReason, ExitCode: 137, FinishedAt: *finishedAt}
+	}
+	return pod
+}
+
+func TestOOMKillTrackerNewOOMKills(t *testing.T) {
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	before := metav1.NewTime(since.Add(-time.Minute))
+	first := metav1.NewTime(since.Add(time.Minute))
+	second := metav1.NewTime(first.Add(time.Minute))
+	withUID := func(pod *corev1.Pod, uid types.UID) *corev1.Pod {
+		pod.UID = uid
+		return pod
+	}
+	kill := func(finishedAt metav1.Time) oomKill {
+		return oomKill{pod: "job-worker-0", container: "pytorch", memoryLimit: "2Gi", finishedAt: finishedAt}
+	}
+	steps := []struct {
+		name string
+		pods []*corev1.Pod
+		want []oomKill
+	}{
+		{
+			name: "container is running",
+			pods: []*corev1.Pod{withUID(newOOMKilledPod(nil, nil), "a")},
+		},
+		{
+			name: "container is OOMKilled",
+			pods: []*corev1.Pod{withUID(newOOMKilledPod(nil, &first), "a")},
+			want: []oomKill{kill(first)},
+		},
+		{
+			name: "OOMKilled container is reconciled again",
+			pods: []*corev1.Pod{withUID(newOOMKilledPod(nil, &first), "a")},
+		},
+		{
+			name: "OOMKilled container restarted in place",
+			pods: []*corev1.Pod{withUID(newOOMKilledPod(&first, nil), "a")},
+		},
+		{
+			name: "restarted container is OOMKilled again",
+			pods: []*corev1.Pod{withUID(newOOMKilledPod(&first, &second), "a")},
+			want: []oomKill{kill(second)},
+		},
+		{
+			name: "pod is recreated with the same name",
+			pods: []*corev1.Pod{withUID(newOOMKilledPod(nil, &second), "b")},
+			want: []oomKill{kill(second)},
+		},
+		{
+			name: "container was OOMKilled before the tracker was created",
+			pods: []*corev1.Pod{withUID(newOOMKilledPod(nil, &before), "c")},
+		},
+	}
+	tracker := NewOOMKillTracker(since)
+	for _, step := range steps {
+		got := tracker.newOOMKills("job", step.pods)
+		if diff := cmp.Diff(step.want, got, cmp.AllowUnexported(oomKill{})); len(diff) != 0 {
+			t.Errorf("Unexpected OOM kills when %s (-want,+got):\n%s", step.name, diff)
+		}
+	}
+	if got := tracker.newOOMKills("job", nil); len(got) != 0 {
+		t.Errorf("Unexpected OOM kills without pods: %v", got)
+	}
+	if len(tracker.recorded) != 0 {
+		t.Errorf("Expected the job without pods to be forgotten, got: %v", tracker.recorded)
+	}
+}
+
+func TestAddOOMKilledHint(t *testing.T) {
+	finishedAt := metav1.Now()
+	restarting := apiv1.JobCondition{Type: apiv1.JobRestarting, Status: corev1.ConditionTrue, Message: "PyTorchJob job is restarting because 1 Worker replica(s) failed."}
+	wantMessage := restarting.Message + " Container pytorch of pod job-worker-0 was OOMKilled with a memory limit of 2Gi. " + oomKilledHint
+	cases := map[string]struct {
+		oldConditions []apiv1.JobCondition
+		conditions    []apiv1.JobCondition
+		pods          []*corev1.Pod
+		wantMessage   string
+	}{
+		"new restarting condition": {
+			conditions:  []apiv1.JobCondition{restarting},
+			pods:        []*corev1.Pod{newOOMKilledPod(nil, &finishedAt)},
+			wantMessage: wantMessage,
+		},
+		"unchanged restarting condition": {
+			oldConditions: []apiv1.JobCondition{restarting},
+			conditions:    []apiv1.JobCondition{restarting},
+			pods:          []*corev1.Pod{newOOMKilledPod(nil, &finishedAt)},
+			wantMessage:   restarting.Message,
+		},
+		"no OOMKilled container": {
+			conditions:  []apiv1.JobCondition{restarting},
+			pods:        []*corev1.Pod{newOOMKilledPod(nil, nil)},
+			wantMessage: restarting.Message,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			status := &apiv1.JobStatus{Conditions: tc.conditions}
+			addOOMKilledHint(status, &apiv1.JobStatus{Conditions: tc.oldConditions}, tc.pods)
+			if diff := cmp.Diff(tc.wantMessage, status.Conditions[0].Message); len(diff) != 0 {
+				t.Errorf("Unexpected message (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
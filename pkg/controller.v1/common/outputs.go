@@ -0,0 +1,169 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	"github.com/kubeflow/training-operator/pkg/common"
+	"github.com/kubeflow/training-operator/pkg/core"
+	commonutil "github.com/kubeflow/training-operator/pkg/util"
+)
+
+// OutputsFileEnv is the env var of the main container of the pod of the master role set to the
+// path of its termination message, into which the training writes the URIs of the outputs of the
+// job which are not declared with their URI, as name=uri lines.
+const OutputsFileEnv = "KUBEFLOW_OUTPUTS_FILE"
+
+// outputsSpec returns the outputs declared by the job.
+func (jc *JobController) outputsSpec(job interface{}) []apiv1.OutputSpec {
+	getter, ok := jc.Controller.(common.OutputsGetter)
+	if !ok {
+		return nil
+	}
+	return getter.GetOutputs(job)
+}
+
+// SetOutputs sets the OutputsFileEnv env var of the main container containerName of the pod
+// template of the master role if the job declares outputs reported by the training. The URIs
+// are read from the termination message of the container once it has succeeded, so that
+// neither another container nor a shared volume is added to the pod.
+func SetOutputs(podTemplate *corev1.PodTemplateSpec, containerName string, outputs []apiv1.OutputSpec) {
+	if !slices.ContainsFunc(outputs, func(output apiv1.OutputSpec) bool { return output.URI == "" }) {
+		return
+	}
+	for i := range podTemplate.Spec.Containers {
+		container := &podTemplate.Spec.Containers[i]
+		if container.Name != containerName {
+			continue
+		}
+		path := container.TerminationMessagePath
+		if path == "" {
+			path = corev1.TerminationMessagePathDefault
+		}
+		core.SetCommonEnv(podTemplate, containerName, []corev1.EnvVar{{Name: OutputsFileEnv, Value: path}}, nil)
+		return
+	}
+}
+
+// reportedOutputs returns the URIs of the outputs reported in the termination message of the
+// main container of a pod of the master role which has succeeded, and whether such a container
+// was found. The main container is the one whose OutputsFileEnv env var is set.
+func reportedOutputs(pods []*corev1.Pod) (map[string]string, bool) {
+	for _, pod := range pods {
+		if pod.Labels[apiv1.JobRoleLabel] != "master" {
+			continue
+		}
+		containerName := outputsContainerName(pod)
+		if containerName == "" {
+			continue
+		}
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.Name != containerName || status.State.Terminated == nil || status.State.Terminated.ExitCode != 0 {
+				continue
+			}
+			reported := map[string]string{}
+			for _, line := range strings.Split(status.State.Terminated.Message, "\n") {
+				name, uri, ok := strings.Cut(line, "=")
+				uri = strings.TrimSpace(uri)
+				if ok && uri != "" {
+					reported[strings.TrimSpace(name)] = uri
+				}
+			}
+			return reported, true
+		}
+	}
+	return nil, false
+}
+
+// outputsContainerName returns the name of the container of the pod whose OutputsFileEnv env var
+// is set, if any.
+func outputsContainerName(pod *corev1.Pod) string {
+	for _, container := range pod.Spec.Containers {
+		for _, env := range container.Env {
+			if env.Name == OutputsFileEnv {
+				return container.Name
+			}
+		}
+	}
+	return ""
+}
+
+// outputPods returns the pods of the job and the pods of its master role which are not controlled
+// by the job, if any.
+func (jc *JobController) outputPods(job interface{}, pods []*corev1.Pod) ([]*corev1.Pod, error) {
+	getter, ok := jc.Controller.(common.OutputPodsGetter)
+	if !ok {
+		return pods, nil
+	}
+	others, err := getter.GetOutputPods(job)
+	if err != nil {
+		return nil, err
+	}
+	return append(slices.Clip(pods), others...), nil
+}
+
+// RecordOutputs records the URIs of the outputs declared by the succeeded job in its status:
+// the URIs declared as is, and the URIs reported by the training in the termination message of
+// the main container of the pod of the master role. The outputs are recorded once, while the
+// pods of the job are not deleted yet.
+func (jc *JobController) RecordOutputs(runtimeObject runtime.Object, metaObject metav1.Object, jobStatus *apiv1.JobStatus, pods []*corev1.Pod) error {
+	outputs := jc.outputsSpec(runtimeObject)
+	if len(outputs) == 0 || len(jobStatus.Outputs) != 0 {
+		return nil
+	}
+	pods, err := jc.outputPods(runtimeObject, pods)
+	if err != nil {
+		return err
+	}
+	reported, terminated := reportedOutputs(pods)
+	var recorded []apiv1.OutputStatus
+	var missing []string
+	for _, output := range outputs {
+		switch {
+		case output.URI != "":
+			recorded = append(recorded, apiv1.OutputStatus{Name: output.Name, URI: output.URI})
+		case reported[output.Name] != "":
+			recorded = append(recorded, apiv1.OutputStatus{Name: output.Name, URI: reported[output.Name]})
+		default:
+			missing = append(missing, output.Name)
+		}
+	}
+	jobKind := jc.Controller.GetAPIGroupVersionKind().Kind
+	if len(missing) != 0 && terminated {
+		msg := fmt.Sprintf("The training of %s %s didn't write the URIs of the outputs %s into %s",
+			jobKind, metaObject.GetName(), strings.Join(missing, ", "), OutputsFileEnv)
+		jc.Recorder.Event(runtimeObject, corev1.EventTypeWarning, commonutil.MissingOutputsReason, msg)
+	}
+	if len(recorded) == 0 {
+		return nil
+	}
+	jobStatus.Outputs = recorded
+	uris := make([]string, 0, len(recorded))
+	for _, output := range recorded {
+		uris = append(uris, output.Name+": "+output.URI)
+	}
+	msg := fmt.Sprintf("Recorded the outputs of %s %s: %s", jobKind, metaObject.GetName(), strings.Join(uris, ", "))
+	commonutil.LoggerForJob(metaObject).Info(msg)
+	jc.Recorder.Event(runtimeObject, corev1.EventTypeNormal, commonutil.OutputsRecordedReason, msg)
+	return nil
+}
@@ -0,0 +1,190 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+)
+
+func (c *pytorchJobController) GetOutputs(job interface{}) []apiv1.OutputSpec {
+	return job.(*apiv1.PyTorchJob).Spec.Outputs
+}
+
+func TestSetOutputs(t *testing.T) {
+	cases := map[string]struct {
+		outputs                []apiv1.OutputSpec
+		terminationMessagePath string
+		want                   corev1.PodSpec
+	}{
+		"outputs reported by the training": {
+			outputs: []apiv1.OutputSpec{
+				{Name: "model"},
+				{Name: "dataset", URI: "s3://datasets/mnist"},
+			},
+			want: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{
+						Name: "pytorch",
+						Env:  []corev1.EnvVar{{Name: OutputsFileEnv, Value: corev1.TerminationMessagePathDefault}},
+					},
+					{Name: "sidecar"},
+				},
+			},
+		},
+		"the termination message path of the container": {
+			outputs:                []apiv1.OutputSpec{{Name: "model"}},
+			terminationMessagePath: "/tmp/outputs",
+			want: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{
+						Name:                   "pytorch",
+						TerminationMessagePath: "/tmp/outputs",
+						Env:                    []corev1.EnvVar{{Name: OutputsFileEnv, Value: "/tmp/outputs"}},
+					},
+					{Name: "sidecar"},
+				},
+			},
+		},
+		"URI outputs only": {
+			outputs: []apiv1.OutputSpec{{Name: "model", URI: "s3://models/mnist"}},
+			want: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "pytorch"}, {Name: "sidecar"}},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			podTemplate := &corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{
+					{Name: "pytorch", TerminationMessagePath: tc.terminationMessagePath},
+					{Name: "sidecar"},
+				}},
+			}
+			SetOutputs(podTemplate, "pytorch", tc.outputs)
+			// The env var is set only once.
+			SetOutputs(podTemplate, "pytorch", tc.outputs)
+			if diff := cmp.Diff(tc.want, podTemplate.Spec); len(diff) != 0 {
+				t.Errorf("Unexpected pod spec (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRecordOutputs(t *testing.T) {
+	masterPod := func(message string, exitCode int32) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{apiv1.JobRoleLabel: "master"}},
+			Spec: corev1.PodSpec{Containers: []corev1.Container{
+				{Name: "sidecar"},
+				{Name: "pytorch", Env: []corev1.EnvVar{{Name: OutputsFileEnv, Value: corev1.TerminationMessagePathDefault}}},
+			}},
+		}
+		if message != "" {
+			pod.Status.ContainerStatuses = []corev1.ContainerStatus{
+				{
+					Name:  "sidecar",
+					State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Message: "model=s3://sidecar"}},
+				},
+				{
+					Name:  "pytorch",
+					State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Message: message, ExitCode: exitCode}},
+				},
+			}
+		}
+		return pod
+	}
+	outputs := []apiv1.OutputSpec{
+		{Name: "dataset", URI: "s3://datasets/mnist"},
+		{Name: "model"},
+		{Name: "metrics"},
+	}
+	cases := map[string]struct {
+		outputs     []apiv1.OutputSpec
+		status      []apiv1.OutputStatus
+		pods        []*corev1.Pod
+		want        []apiv1.OutputStatus
+		wantWarning bool
+	}{
+		"the URIs written by the training are recorded": {
+			outputs: outputs,
+			pods: []*corev1.Pod{
+				{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{}}},
+				masterPod("model=s3://models/mnist/v2\n", 1),
+				masterPod("model=s3://models/mnist/v3\nmetrics=s3://metrics/mnist/v3\n", 0),
+			},
+			want: []apiv1.OutputStatus{
+				{Name: "dataset", URI: "s3://datasets/mnist"},
+				{Name: "model", URI: "s3://models/mnist/v3"},
+				{Name: "metrics", URI: "s3://metrics/mnist/v3"},
+			},
+		},
+		"the outputs not written by the training are missing": {
+			outputs: outputs,
+			pods:    []*corev1.Pod{masterPod("model=s3://models/mnist/v3\nmetrics=\n", 0)},
+			want: []apiv1.OutputStatus{
+				{Name: "dataset", URI: "s3://datasets/mnist"},
+				{Name: "model", URI: "s3://models/mnist/v3"},
+			},
+			wantWarning: true,
+		},
+		"the pods are deleted": {
+			outputs: outputs,
+			want:    []apiv1.OutputStatus{{Name: "dataset", URI: "s3://datasets/mnist"}},
+		},
+		"the outputs are recorded once": {
+			outputs: outputs,
+			status:  []apiv1.OutputStatus{{Name: "model", URI: "s3://models/mnist/v3"}},
+			pods:    []*corev1.Pod{masterPod("model=s3://models/mnist/v4\n", 0)},
+			want:    []apiv1.OutputStatus{{Name: "model", URI: "s3://models/mnist/v3"}},
+		},
+		"no outputs": {},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			job := newPriorityPreemptionJob(metav1.NamespaceDefault, "test", "", "1", time.Now(), apiv1.JobSucceeded)
+			job.Spec.Outputs = tc.outputs
+			recorder := record.NewFakeRecorder(10)
+			jc := &JobController{
+				Controller: &pytorchJobController{frameworkController{framework: "pytorch"}},
+				Recorder:   recorder,
+			}
+			jobStatus := &apiv1.JobStatus{Outputs: tc.status}
+			if err := jc.RecordOutputs(job, job, jobStatus, tc.pods); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, jobStatus.Outputs); len(diff) != 0 {
+				t.Errorf("Unexpected outputs (-want,+got):\n%s", diff)
+			}
+			warning := false
+			for len(recorder.Events) != 0 {
+				if strings.HasPrefix(<-recorder.Events, corev1.EventTypeWarning) {
+					warning = true
+				}
+			}
+			if warning != tc.wantWarning {
+				t.Errorf("Unexpected warning event, want: %t, got: %t", tc.wantWarning, warning)
+			}
+		})
+	}
+}
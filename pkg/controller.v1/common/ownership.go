@@ -0,0 +1,92 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	trainingoperatorcommon "github.com/kubeflow/training-operator/pkg/common"
+	"github.com/kubeflow/training-operator/pkg/config"
+	commonutil "github.com/kubeflow/training-operator/pkg/util"
+)
+
+// NameCollisionError is returned in the strict ownership mode when a child of a job
+// cannot be created because an object of the same name, which is not controlled by
+// the job, already exists.
+type NameCollisionError struct {
+	Kind string
+	Name string
+}
+
+func (e *NameCollisionError) Error() string {
+	return fmt.Sprintf("%s %s already exists and is not controlled by the job", e.Kind, e.Name)
+}
+
+// StrictOwnership returns whether the children of the jobs which are not controlled by
+// them fail the jobs, instead of being adopted or retried.
+func StrictOwnership() bool {
+	return config.Config.StrictOwnership
+}
+
+// PodClaimFilters returns the filters of the pods claimed by a job. In the strict ownership
+// mode the orphan pods are not adopted, so that their names collide with the pods of the job.
+func PodClaimFilters() []func(*corev1.Pod) bool {
+	if !StrictOwnership() {
+		return nil
+	}
+	return []func(*corev1.Pod) bool{func(pod *corev1.Pod) bool { return metav1.GetControllerOf(pod) != nil }}
+}
+
+// ServiceClaimFilters returns the filters of the services claimed by a job. In the strict
+// ownership mode the orphan services are not adopted, so that their names collide with the
+// services of the job.
+func ServiceClaimFilters() []func(*corev1.Service) bool {
+	if !StrictOwnership() {
+		return nil
+	}
+	return []func(*corev1.Service) bool{func(svc *corev1.Service) bool { return metav1.GetControllerOf(svc) != nil }}
+}
+
+// checkNameCollision turns the AlreadyExists error of the creation of a child of the job
+// into a NameCollisionError, when the strict ownership mode is enabled and the existing
+// object is not controlled by the job. Any other error is returned as is.
+func checkNameCollision(err error, job metav1.Object, kind, name string, get func() (metav1.Object, error)) error {
+	if !StrictOwnership() || !errors.IsAlreadyExists(err) {
+		return err
+	}
+	existing, getErr := get()
+	if getErr != nil || metav1.IsControlledBy(existing, job) {
+		return err
+	}
+	return &NameCollisionError{Kind: kind, Name: name}
+}
+
+// FailJobForNameCollision marks the job as failed, since one of its children collides
+// with an object of the same name which it does not control.
+func (jc *JobController) FailJobForNameCollision(runtimeObject runtime.Object, metaObject metav1.Object, jobStatus *apiv1.JobStatus, collision *NameCollisionError) {
+	jobKind := jc.Controller.GetAPIGroupVersionKind().Kind
+	reason := commonutil.NewReason(jobKind, commonutil.JobNameCollisionReason)
+	msg := fmt.Sprintf("%s %s/%s is failed because %v", jobKind, metaObject.GetNamespace(), metaObject.GetName(), collision)
+	jc.Recorder.Event(runtimeObject, corev1.EventTypeWarning, reason, msg)
+	if commonutil.FinishJob(jobStatus, apiv1.JobFailed, reason, msg, jc.Clock) {
+		trainingoperatorcommon.FailedJobsCounterInc(metaObject.GetNamespace(), jc.Controller.GetFrameworkName())
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	"github.com/kubeflow/training-operator/pkg/config"
+	testjobv1 "github.com/kubeflow/training-operator/test_job/apis/test_job/v1"
+)
+
+func TestCheckNameCollision(t *testing.T) {
+	job := &testjobv1.TestJob{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: metav1.NamespaceDefault, UID: "job-uid"}}
+	alreadyExists := k8serrors.NewAlreadyExists(schema.GroupResource{Resource: "pods"}, "test-worker-0")
+	owned := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name:            "test-worker-0",
+		OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(job, apiv1.SchemeGroupVersion.WithKind("TestJob"))},
+	}}
+	orphan := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "test-worker-0"}}
+
+	cases := map[string]struct {
+		strict        bool
+		err           error
+		existing      *corev1.Pod
+		wantCollision bool
+	}{
+		"orphan out of the strict mode": {
+			err:      alreadyExists,
+			existing: orphan,
+		},
+		"orphan in the strict mode": {
+			strict:        true,
+			err:           alreadyExists,
+			existing:      orphan,
+			wantCollision: true,
+		},
+		"pod of the job in the strict mode": {
+			strict:   true,
+			err:      alreadyExists,
+			existing: owned,
+		},
+		"other error in the strict mode": {
+			strict:   true,
+			err:      fmt.Errorf("transient error"),
+			existing: orphan,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			defer func(strict bool) { config.Config.StrictOwnership = strict }(config.Config.StrictOwnership)
+			config.Config.StrictOwnership = tc.strict
+
+			err := checkNameCollision(tc.err, job, "Pod", "test-worker-0", func() (metav1.Object, error) {
+				return tc.existing, nil
+			})
+			var collision *NameCollisionError
+			if got := errors.As(err, &collision); got != tc.wantCollision {
+				t.Fatalf("Unexpected name collision %v, want %v: %v", got, tc.wantCollision, err)
+			}
+			if tc.wantCollision {
+				if diff := cmp.Diff(&NameCollisionError{Kind: "Pod", Name: "test-worker-0"}, collision); len(diff) != 0 {
+					t.Errorf("Unexpected NameCollisionError (-want,+got):\n%s", diff)
+				}
+			} else if err != tc.err {
+				t.Errorf("Unexpected error %v, want %v", err, tc.err)
+			}
+		})
+	}
+}
+
+func TestPodClaimFilters(t *testing.T) {
+	orphan := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "orphan"}}
+	defer func(strict bool) { config.Config.StrictOwnership = strict }(config.Config.StrictOwnership)
+
+	config.Config.StrictOwnership = false
+	if filters := PodClaimFilters(); len(filters) != 0 {
+		t.Errorf("Unexpected filters out of the strict mode: %d", len(filters))
+	}
+
+	config.Config.StrictOwnership = true
+	filters := PodClaimFilters()
+	if len(filters) != 1 || filters[0](orphan) {
+		t.Errorf("Expected the orphan pods to be filtered out in the strict mode")
+	}
+}
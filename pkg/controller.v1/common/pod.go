@@ -15,44 +15,35 @@
 package common
 
 import (
+	"context"
 	"fmt"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 
 	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
 	trainingoperatorcommon "github.com/kubeflow/training-operator/pkg/common"
-	"github.com/kubeflow/training-operator/pkg/controller.v1/control"
 	"github.com/kubeflow/training-operator/pkg/controller.v1/expectation"
 	"github.com/kubeflow/training-operator/pkg/core"
 	commonutil "github.com/kubeflow/training-operator/pkg/util"
 	utillabels "github.com/kubeflow/training-operator/pkg/util/labels"
 	trainutil "github.com/kubeflow/training-operator/pkg/util/train"
 
+	"github.com/go-logr/logr"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
-	log "github.com/sirupsen/logrus"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog/v2"
 )
 
-const (
-	// podTemplateRestartPolicyReason is the warning reason when the restart
-	// policy is set in pod template.
-	podTemplateRestartPolicyReason = "SetPodTemplateRestartPolicy"
-	// exitedWithCodeReason is the normal reason when the pod is exited because of the exit code.
-	exitedWithCodeReason = "ExitedWithCode"
-	// podTemplateSchedulerNameReason is the warning reason when other scheduler name is set
-	// in pod templates with gang-scheduling enabled
-	podTemplateSchedulerNameReason = "SetPodTemplateSchedulerName"
-)
-
 var (
 	// Prometheus metrics
 	createdPodsCount = promauto.NewCounter(prometheus.CounterOpts{
@@ -94,13 +85,13 @@ func (jc *JobController) AddPod(obj interface{}) {
 
 		jobKey, err := KeyFunc(job)
 		if err != nil {
-			logger.Infof("Failed to get the jobkey: %v", err)
+			logger.Error(err, "Failed to get the job key")
 			return
 		}
 
 		rType, err := utillabels.ReplicaType(pod.Labels)
 		if err != nil {
-			logger.Infof("This pod maybe not created by %v", jc.Controller.ControllerName())
+			logger.Info("This pod maybe not created by the controller", "controller", jc.Controller.ControllerName())
 			return
 		}
 
@@ -134,7 +125,7 @@ func (jc *JobController) UpdatePod(old, cur interface{}) {
 	if controllerRefChanged && oldControllerRef != nil {
 		// The ControllerRef was changed. Sync the old controller, if any.
 		if job := jc.resolveControllerRef(oldPod.Namespace, oldControllerRef); job != nil {
-			logger.Infof("pod ControllerRef updated: %v, %v", curPod, oldPod)
+			logger.Info("Pod ControllerRef updated", "oldControllerRef", oldControllerRef.Name)
 			jobKey, err := KeyFunc(job)
 			if err != nil {
 				return
@@ -150,7 +141,7 @@ func (jc *JobController) UpdatePod(old, cur interface{}) {
 		if job == nil {
 			return
 		}
-		logger.Debugf("pod has a ControllerRef: %v, %v", curPod, oldPod)
+		logger.V(1).Info("Pod has a ControllerRef", "controllerRef", curControllerRef.Name)
 		jobKey, err := KeyFunc(job)
 		if err != nil {
 			return
@@ -166,8 +157,6 @@ func (jc *JobController) UpdatePod(old, cur interface{}) {
 func (jc *JobController) DeletePod(obj interface{}) {
 	pod, ok := obj.(*v1.Pod)
 
-	logger := commonutil.LoggerForPod(pod, jc.Controller.GetAPIGroupVersionKind().Kind)
-
 	// When delete is dropped, the relist will notice a pod in the store not
 	// in the list, leading to the insertion of a tombstone object which contains
 	// the deleted key/value. Note that this value might be stale. If the pod
@@ -185,6 +174,8 @@ func (jc *JobController) DeletePod(obj interface{}) {
 		}
 	}
 
+	logger := commonutil.LoggerForPod(pod, jc.Controller.GetAPIGroupVersionKind().Kind)
+
 	controllerRef := metav1.GetControllerOf(pod)
 	if controllerRef == nil {
 		// No controller should care about orphans being deleted.
@@ -201,7 +192,7 @@ func (jc *JobController) DeletePod(obj interface{}) {
 
 	rType, err := utillabels.ReplicaType(pod.Labels)
 	if err != nil {
-		logger.Infof("This pod maybe not created by %v", jc.Controller.ControllerName())
+		logger.Info("This pod maybe not created by the controller", "controller", jc.Controller.ControllerName())
 		return
 	}
 
@@ -222,14 +213,6 @@ func (jc *JobController) GetPodsForJob(jobObject interface{}) ([]*v1.Pod, error)
 		return nil, fmt.Errorf("job is not of type metav1.Object")
 	}
 
-	// Create selector.
-	selector, err := metav1.LabelSelectorAsSelector(&metav1.LabelSelector{
-		MatchLabels: jc.GenLabels(job.GetName()),
-	})
-
-	if err != nil {
-		return nil, fmt.Errorf("couldn't convert Job selector: %v", err)
-	}
 	// List all pods to include those that don't match the selector anymore
 	// but have a ControllerRef pointing to this controller.
 	pods, err := jc.PodLister.Pods(job.GetNamespace()).List(labels.Everything())
@@ -237,20 +220,7 @@ func (jc *JobController) GetPodsForJob(jobObject interface{}) ([]*v1.Pod, error)
 		return nil, err
 	}
 
-	// If any adoptions are attempted, we should first recheck for deletion
-	// with an uncached quorum read sometime after listing Pods (see #42639).
-	canAdoptFunc := RecheckDeletionTimestamp(func() (metav1.Object, error) {
-		fresh, err := jc.Controller.GetJobFromAPIClient(job.GetNamespace(), job.GetName())
-		if err != nil {
-			return nil, err
-		}
-		if fresh.GetUID() != job.GetUID() {
-			return nil, fmt.Errorf("original Job %v/%v is gone: got uid %v, wanted %v", job.GetNamespace(), job.GetName(), fresh.GetUID(), job.GetUID())
-		}
-		return fresh, nil
-	})
-	cm := control.NewPodControllerRefManager(jc.PodControl, job, selector, jc.Controller.GetAPIGroupVersionKind(), canAdoptFunc)
-	return cm.ClaimPods(pods)
+	return jc.ClaimPods(job, pods)
 }
 
 // FilterPodsForReplicaType returns pods belong to a replicaType.
@@ -260,7 +230,7 @@ func (jc *JobController) FilterPodsForReplicaType(pods []*v1.Pod, replicaType st
 
 // getPodSlices returns a slice, which element is the slice of pod.
 // It gives enough information to caller to make decision to up/down scale resources.
-func (jc *JobController) GetPodSlices(pods []*v1.Pod, replicas int, logger *log.Entry) [][]*v1.Pod {
+func (jc *JobController) GetPodSlices(pods []*v1.Pod, replicas int, logger logr.Logger) [][]*v1.Pod {
 	return core.GetPodSlices(pods, replicas, logger)
 }
 
@@ -302,6 +272,7 @@ func (jc *JobController) ReconcilePods(
 	var masterRole bool
 
 	initializeReplicaStatuses(jobStatus, rType)
+	jobStatus.ReplicaStatuses[rType].Selector = jc.genReplicaSelector(metaObject.GetName(), rt)
 
 	// GetPodSlices will return enough information here to make decision to add/remove/update resources.
 	//
@@ -310,11 +281,18 @@ func (jc *JobController) ReconcilePods(
 	//
 	// If replica is 1, return a slice with size 3. [[0],[1],[2]], pod with replica-index 1 and 2 are out of range and will be deleted.
 	podSlices := jc.GetPodSlices(pods, numReplicas, logger)
+	// The pods of a job which starts in waves are created once the pods created before are ready.
+	creations := StartWave(runPolicyOf(job), pods, numReplicas)
 	for index, podSlice := range podSlices {
 		if len(podSlice) > 1 {
-			logger.Warningf("We have too many pods for %s %d", rt, index)
+			logger.Info("We have too many pods for the replica", "index", index)
 		} else if len(podSlice) == 0 {
-			logger.Infof("Need to create new pod: %s-%d", rt, index)
+			if creations == 0 {
+				logger.V(1).Info("Waiting for the pods of the previous wave to be ready", "index", index)
+				continue
+			}
+			creations--
+			logger.Info("Need to create new pod", "index", index)
 
 			// check if this replica is the master role
 			masterRole = jc.Controller.IsMasterRole(replicas, rType, index)
@@ -328,7 +306,7 @@ func (jc *JobController) ReconcilePods(
 
 			// check if the index is in the valid range, if not, we should kill the pod
 			if index < 0 || index >= numReplicas {
-				err = jc.PodControl.DeletePod(pod.Namespace, pod.Name, runtimeObject)
+				err = jc.deletePod(pod, runtimeObject)
 				if err != nil {
 					return err
 				}
@@ -342,8 +320,8 @@ func (jc *JobController) ReconcilePods(
 				state := status.State
 				if status.Name == jc.Controller.GetDefaultContainerName() && state.Terminated != nil {
 					exitCode = state.Terminated.ExitCode
-					logger.Infof("Pod: %v.%v exited with code %v", pod.Namespace, pod.Name, exitCode)
-					jc.Recorder.Eventf(runtimeObject, v1.EventTypeNormal, exitedWithCodeReason, "Pod: %v.%v exited with code %v", pod.Namespace, pod.Name, exitCode)
+					logger.Info("Pod exited", "pod", pod.Name, "index", index, "exitCode", exitCode)
+					jc.Recorder.Eventf(runtimeObject, v1.EventTypeNormal, commonutil.ExitedWithCodeReason, "Pod: %v.%v exited with code %v", pod.Namespace, pod.Name, exitCode)
 				}
 			}
 			// Check if the pod is retryable.
@@ -352,24 +330,30 @@ func (jc *JobController) ReconcilePods(
 				if spec.RestartPolicy == apiv1.RestartPolicyExitCode && trainutil.IsRetryableExitCode(exitCode) ||
 					spec.RestartPolicy == apiv1.RestartPolicyOnFailure ||
 					spec.RestartPolicy == apiv1.RestartPolicyAlways {
-					logger.Infof("Need to restart the pod: %v.%v", pod.Namespace, pod.Name)
-					if err := jc.PodControl.DeletePod(pod.Namespace, pod.Name, runtimeObject); err != nil {
-						return err
-					}
-					// Deletion is expected
-					jc.Expectations.RaiseExpectations(expectationPodsKey, 0, 1)
-
 					msg := fmt.Sprintf("job %s is restarting because %s replica(s) failed.",
 						metaObject.GetName(), rType)
-					jc.Recorder.Event(runtimeObject, v1.EventTypeWarning, commonutil.NewReason(jobKind, commonutil.JobRestartingReason), msg)
-					commonutil.UpdateJobConditions(jobStatus, apiv1.JobRestarting, v1.ConditionTrue, commonutil.NewReason(jobKind, commonutil.JobRestartingReason), msg)
-					trainingoperatorcommon.RestartedJobsCounterInc(metaObject.GetNamespace(), jc.Controller.GetFrameworkName())
+					if jc.acquireRestart(metaObject, runtimeObject, jobStatus) {
+						logger.Info("Need to restart the pod", "pod", pod.Name, "index", index)
+						if err := jc.deletePod(pod, runtimeObject); err != nil {
+							return err
+						}
+						// Deletion is expected
+						jc.Expectations.RaiseExpectations(expectationPodsKey, 0, 1)
+
+						jc.Recorder.Event(runtimeObject, v1.EventTypeWarning, commonutil.NewReason(jobKind, commonutil.JobRestartingReason), msg)
+						trainingoperatorcommon.RestartedJobsCounterInc(metaObject.GetNamespace(), jc.Controller.GetFrameworkName())
+					} else {
+						logger.Info("The restart of the pod is queued", "pod", pod.Name, "index", index)
+					}
+					// A job whose restart is queued is restarting too, so that its status update
+					// doesn't fail it because of the failed pod.
+					commonutil.UpdateJobConditions(jobStatus, apiv1.JobRestarting, v1.ConditionTrue, commonutil.NewReason(jobKind, commonutil.JobRestartingReason), msg, jc.Clock)
 				} else if spec.RestartPolicy == apiv1.RestartPolicyExitCode && !trainutil.IsRetryableExitCode(exitCode) {
-					logger.Infof("Pod %q has a non-retryable exit code. Failing job.", klog.KObj(pod))
+					logger.Info("Pod has a non-retryable exit code. Failing job.", "pod", pod.Name, "index", index, "exitCode", exitCode)
 					msg := fmt.Sprintf("job %q is failing because %q replica(s) failed.",
 						metaObject.GetName(), rType)
 					jc.Recorder.Event(runtimeObject, v1.EventTypeWarning, commonutil.NewReason(jobKind, commonutil.JobFailedReason), msg)
-					commonutil.UpdateJobConditions(jobStatus, apiv1.JobFailed, v1.ConditionTrue, commonutil.NewReason(jobKind, commonutil.JobFailedReason), msg)
+					commonutil.FinishJob(jobStatus, apiv1.JobFailed, commonutil.NewReason(jobKind, commonutil.JobFailedReason), msg, jc.Clock)
 				}
 			}
 
@@ -396,12 +380,13 @@ func (jc *JobController) createNewPod(job interface{}, rt string, index int, spe
 		utilruntime.HandleError(fmt.Errorf("couldn't get key for job object %#v: %v", job, err))
 		return err
 	}
-	logger := commonutil.LoggerForReplica(metaObject, rt)
+	logger := commonutil.LoggerForReplica(metaObject, rt).WithValues("index", index)
 
 	// Set type and index for the worker.
 	labels := jc.GenLabels(metaObject.GetName())
 	utillabels.SetReplicaType(labels, rt)
 	utillabels.SetReplicaIndex(labels, index)
+	utillabels.SetRunID(labels, RunID(metaObject))
 
 	if masterRole {
 		utillabels.SetJobRole(labels, "master")
@@ -413,12 +398,10 @@ func (jc *JobController) createNewPod(job interface{}, rt string, index int, spe
 	// Set name for the template.
 	podTemplate.Name = GenGeneralName(metaObject.GetName(), rt, idxStr)
 
-	if podTemplate.Labels == nil {
-		podTemplate.Labels = make(map[string]string)
-	}
-
-	for key, value := range labels {
-		podTemplate.Labels[key] = value
+	if overwritten := core.SetControllerLabels(podTemplate, labels); len(overwritten) != 0 {
+		errMsg := fmt.Sprintf("Labels %s of the pod template are reserved to the controller and will be overwritten", strings.Join(overwritten, ", "))
+		logger.Info(errMsg)
+		jc.Recorder.Event(runtimeObject, v1.EventTypeWarning, commonutil.PodTemplateLabelsReason, errMsg)
 	}
 
 	if err := jc.Controller.SetClusterSpec(job, podTemplate, rt, idxStr); err != nil {
@@ -429,10 +412,26 @@ func (jc *JobController) createNewPod(job interface{}, rt string, index int, spe
 	// the pod template. We recommend to set it from the replica level.
 	if podTemplate.Spec.RestartPolicy != v1.RestartPolicy("") {
 		errMsg := "Restart policy in pod template will be overwritten by restart policy in replica spec"
-		logger.Warning(errMsg)
-		jc.Recorder.Event(runtimeObject, v1.EventTypeWarning, podTemplateRestartPolicyReason, errMsg)
+		logger.Info(errMsg)
+		jc.Recorder.Event(runtimeObject, v1.EventTypeWarning, commonutil.PodTemplateRestartPolicyReason, errMsg)
 	}
 	core.SetRestartPolicy(podTemplate, spec)
+	core.SetCapacityType(podTemplate, spec)
+	if err := core.SetResourceProfile(podTemplate, jc.Controller.GetDefaultContainerName(), spec.ResourceProfile); err != nil {
+		jc.Recorder.Eventf(runtimeObject, v1.EventTypeWarning, commonutil.FailedCreatePodReason, "Error creating: %v", err)
+		return err
+	}
+	core.SetRestartedAt(podTemplate, metaObject)
+	SetServiceMeshAnnotations(podTemplate, metaObject)
+	SetAcceleratorDefaults(podTemplate)
+	SetDatasets(podTemplate, jc.Controller.GetDefaultContainerName(), jc.datasetsSpec(job))
+	if masterRole {
+		SetOutputs(podTemplate, jc.Controller.GetDefaultContainerName(), jc.outputsSpec(job))
+		jc.SetMetricsCollector(podTemplate, jc.Controller.GetDefaultContainerName(), runtimeObject, metaObject)
+	}
+	SetDefaultImagePullSecrets(podTemplate)
+	SetSecurityDefaults(podTemplate)
+	jc.SetDefaultPriorityClass(podTemplate, metaObject.GetNamespace())
 
 	// if gang-scheduling is enabled:
 	// 1. if user has specified other scheduler, we report a warning without overriding any fields.
@@ -440,19 +439,25 @@ func (jc *JobController) createNewPod(job interface{}, rt string, index int, spe
 	if jc.Config.EnableGangScheduling() {
 		if isCustomSchedulerSet(replicas, jc.PodGroupControl.GetSchedulerName()) {
 			errMsg := "Another scheduler is specified when gang-scheduling is enabled and it will not be overwritten"
-			logger.Warning(errMsg)
-			jc.Recorder.Event(runtimeObject, v1.EventTypeWarning, podTemplateSchedulerNameReason, errMsg)
+			logger.Info(errMsg)
+			jc.Recorder.Event(runtimeObject, v1.EventTypeWarning, commonutil.PodTemplateSchedulerNameReason, errMsg)
 		}
 		jc.PodGroupControl.DecoratePodTemplateSpec(podTemplate, metaObject, rt)
 	}
 
+	// Check the pod against the PodSecurity level of the namespace, so that the job fails
+	// with the violating fields instead of retrying a pod creation which is rejected.
+	if err := jc.CheckPodSecurity(metaObject.GetNamespace(), podTemplate); err != nil {
+		return err
+	}
+
 	// Creation is expected when there is no error returned
 	// We use `RaiseExpectations` here to accumulate expectations since `SetExpectations` has no such kind of ability
 	expectationPodsKey := expectation.GenExpectationPodsKey(jobKey, rt)
 	jc.Expectations.RaiseExpectations(expectationPodsKey, 1, 0)
 
 	controllerRef := jc.GenOwnerReference(metaObject)
-	err = jc.PodControl.CreatePodsWithControllerRef(metaObject.GetNamespace(), podTemplate, runtimeObject, controllerRef)
+	err = jc.createPod(metaObject.GetNamespace(), podTemplate, runtimeObject, controllerRef)
 	if err != nil && errors.IsTimeout(err) {
 		// Pod is created but its initialization has timed out.
 		// If the initialization is successful eventually, the
@@ -467,12 +472,130 @@ func (jc *JobController) createNewPod(job interface{}, rt string, index int, spe
 		// we decrement the expected number of creates
 		// and wait until next reconciliation
 		jc.Expectations.CreationObserved(expectationPodsKey)
-		return err
+		if quotaExceeded := QuotaExceeded(podTemplate.Name, err); quotaExceeded != nil {
+			return quotaExceeded
+		}
+		return checkNameCollision(err, metaObject, "Pod", podTemplate.Name, func() (metav1.Object, error) {
+			jc.RecordAPICall(metaObject, APICallGet)
+			return jc.KubeClientSet.CoreV1().Pods(metaObject.GetNamespace()).Get(context.Background(), podTemplate.Name, metav1.GetOptions{})
+		})
 	}
 	createdPodsCount.Inc()
 	return nil
 }
 
+// failurePolicyResult is the outcome of evaluating the FailurePolicy against the failed pods of a job.
+type failurePolicyResult struct {
+	// restartPods are the failed pods matching a Restart or Ignore rule, which need to be recreated.
+	restartPods []*v1.Pod
+	// ignoredPods are the failed pods matching an Ignore rule.
+	// They are not counted towards the BackoffLimit.
+	ignoredPods []*v1.Pod
+	// failJobMessage is set when a failed pod matches a FailJob rule.
+	failJobMessage string
+}
+
+// evaluateFailurePolicy matches the exit code of the given container and the failure reason of
+// every failed pod against the rules of the FailurePolicy. The Pending pods whose image cannot be
+// pulled are only matched against the FailJob rules, as the kubelet keeps retrying the pull.
+func evaluateFailurePolicy(jobName string, failurePolicy *apiv1.FailurePolicy, containerName string, pods []*v1.Pod) failurePolicyResult {
+	result := failurePolicyResult{}
+	if failurePolicy == nil {
+		return result
+	}
+	for _, pod := range pods {
+		reason, ok := core.ClassifyPodFailure(pod)
+		if !ok {
+			continue
+		}
+		var exitCode *int32
+		if code, ok := core.GetContainerExitCode(pod, containerName); ok {
+			exitCode = &code
+		}
+		rule := trainutil.MatchFailurePolicyRule(failurePolicy, exitCode, reason)
+		if rule == nil {
+			continue
+		}
+		if pod.Status.Phase != v1.PodFailed && rule.Action != apiv1.FailurePolicyActionFailJob {
+			continue
+		}
+		switch rule.Action {
+		case apiv1.FailurePolicyActionIgnore, apiv1.FailurePolicyActionRestart:
+			if rule.Action == apiv1.FailurePolicyActionIgnore {
+				result.ignoredPods = append(result.ignoredPods, pod)
+			}
+			// The pod has already been deleted in a previous reconciliation.
+			if pod.DeletionTimestamp == nil {
+				result.restartPods = append(result.restartPods, pod)
+			}
+		case apiv1.FailurePolicyActionFailJob:
+			if result.failJobMessage != "" {
+				continue
+			}
+			if exitCode != nil && slices.Contains(rule.ExitCodes, *exitCode) {
+				result.failJobMessage = fmt.Sprintf("Job %s has failed because pod %s exited with code %d matching the failure policy",
+					jobName, klog.KObj(pod), *exitCode)
+			} else {
+				result.failJobMessage = fmt.Sprintf("Job %s has failed because pod %s failed with reason %s matching the failure policy",
+					jobName, klog.KObj(pod), reason)
+			}
+		}
+	}
+	return result
+}
+
+// countRecordedFailedPods returns the number of the given pods which are recorded as failed in the
+// replica statuses of the job, i.e. which were already counted as failed by a previous reconciliation.
+func countRecordedFailedPods(jobStatus apiv1.JobStatus, pods []*v1.Pod) int32 {
+	recorded := sets.New[string]()
+	for _, status := range jobStatus.ReplicaStatuses {
+		for _, pod := range status.Pods {
+			if pod.Phase == v1.PodFailed {
+				recorded.Insert(pod.Name)
+			}
+		}
+	}
+	count := int32(0)
+	for _, pod := range pods {
+		if recorded.Has(pod.Name) {
+			count++
+		}
+	}
+	return count
+}
+
+// restartFailedPods deletes the failed pods matching a Restart or Ignore rule of the FailurePolicy,
+// so that they are recreated once the deletions are observed.
+func (jc *JobController) restartFailedPods(metaObject metav1.Object, runtimeObject runtime.Object, jobStatus *apiv1.JobStatus, pods []*v1.Pod) error {
+	jobKey, err := KeyFunc(metaObject)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("couldn't get key for job object %#v: %v", metaObject, err))
+		return err
+	}
+	jobKind := jc.Controller.GetAPIGroupVersionKind().Kind
+	for _, pod := range pods {
+		rType, err := utillabels.ReplicaType(pod.Labels)
+		if err != nil {
+			return err
+		}
+		rt := strings.ToLower(string(rType))
+		commonutil.LoggerForReplica(metaObject, rt).Info("Need to restart the pod according to the failure policy", "pod", pod.Name)
+		failedPodsCount.Inc()
+		if err := jc.deletePod(pod, runtimeObject); err != nil {
+			return err
+		}
+		// Deletion is expected
+		jc.Expectations.RaiseExpectations(expectation.GenExpectationPodsKey(jobKey, rt), 0, 1)
+
+		msg := fmt.Sprintf("job %s is restarting because %s replica(s) failed.",
+			metaObject.GetName(), rType)
+		jc.Recorder.Event(runtimeObject, v1.EventTypeWarning, commonutil.NewReason(jobKind, commonutil.JobRestartingReason), msg)
+		commonutil.UpdateJobConditions(jobStatus, apiv1.JobRestarting, v1.ConditionTrue, commonutil.NewReason(jobKind, commonutil.JobRestartingReason), msg, jc.Clock)
+		trainingoperatorcommon.RestartedJobsCounterInc(metaObject.GetNamespace(), jc.Controller.GetFrameworkName())
+	}
+	return nil
+}
+
 func isCustomSchedulerSet(replicas map[apiv1.ReplicaType]*apiv1.ReplicaSpec, gangSchedulerName string) bool {
 	for _, spec := range replicas {
 		if spec.Template.Spec.SchedulerName != "" && spec.Template.Spec.SchedulerName != gangSchedulerName {
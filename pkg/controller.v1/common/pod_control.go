@@ -0,0 +1,46 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	trainingoperatorcommon "github.com/kubeflow/training-operator/pkg/common"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// createPod creates a pod from the template through the PodControl and observes the
+// latency of the call, labeled by the framework and the replica type of the pod.
+func (jc *JobController) createPod(namespace string, template *v1.PodTemplateSpec, object runtime.Object, controllerRef *metav1.OwnerReference) error {
+	jc.RecordAPICall(object, APICallCreate)
+	start := jc.Clock.Now()
+	err := jc.PodControl.CreatePodsWithControllerRef(namespace, template, object, controllerRef)
+	trainingoperatorcommon.PodCreationDurationObserve(jc.Controller.GetFrameworkName(),
+		template.Labels[apiv1.ReplicaTypeLabel], jc.Clock.Since(start))
+	return err
+}
+
+// deletePod deletes the pod through the PodControl and observes the latency of the call,
+// labeled by the framework and the replica type of the pod.
+func (jc *JobController) deletePod(pod *v1.Pod, object runtime.Object) error {
+	jc.RecordAPICall(object, APICallDelete)
+	start := jc.Clock.Now()
+	err := jc.PodControl.DeletePod(pod.Namespace, pod.Name, object)
+	trainingoperatorcommon.PodDeletionDurationObserve(jc.Controller.GetFrameworkName(),
+		pod.Labels[apiv1.ReplicaTypeLabel], jc.Clock.Since(start))
+	return err
+}
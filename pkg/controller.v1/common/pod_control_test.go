@@ -0,0 +1,84 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"testing"
+
+	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	"github.com/kubeflow/training-operator/pkg/common"
+	"github.com/kubeflow/training-operator/pkg/controller.v1/control"
+	testjobv1 "github.com/kubeflow/training-operator/test_job/apis/test_job/v1"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// frameworkController is a ControllerInterface which only knows the name of its framework.
+type frameworkController struct {
+	common.ControllerInterface
+	framework string
+}
+
+func (c *frameworkController) GetFrameworkName() string {
+	return c.framework
+}
+
+func TestPodControlLatencyMetrics(t *testing.T) {
+	jc := &JobController{
+		Controller: &frameworkController{framework: "test-framework"},
+		PodControl: &control.FakePodControl{},
+	}
+	template := &corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{apiv1.ReplicaTypeLabel: "worker"}},
+	}
+	controllerRef := &metav1.OwnerReference{APIVersion: "v1", Kind: "TestJob", Name: "test", UID: "uid", Controller: ptr.To(true)}
+	assert.NoError(t, jc.createPod(metav1.NamespaceDefault, template, &testjobv1.TestJob{}, controllerRef))
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-worker-0", Labels: map[string]string{apiv1.ReplicaTypeLabel: "worker"}},
+	}
+	assert.NoError(t, jc.deletePod(pod, &testjobv1.TestJob{}))
+
+	families, err := metrics.Registry.Gather()
+	assert.NoError(t, err)
+	for _, name := range []string{"training_operator_pod_creation_duration_seconds", "training_operator_pod_deletion_duration_seconds"} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, uint64(1), sampleCount(families, name, "test-framework", "worker"))
+		})
+	}
+}
+
+// sampleCount returns the sample count of the histogram with the given name, framework and replica type.
+func sampleCount(families []*dto.MetricFamily, name, framework, replicaType string) uint64 {
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			labels := map[string]string{}
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			if labels["framework"] == framework && labels["replica_type"] == replicaType {
+				return metric.GetHistogram().GetSampleCount()
+			}
+		}
+	}
+	return 0
+}
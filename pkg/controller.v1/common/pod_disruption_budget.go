@@ -0,0 +1,108 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	commonutil "github.com/kubeflow/training-operator/pkg/util"
+)
+
+// GenPodDisruptionBudgetName returns the name of the PodDisruptionBudget of the job.
+func GenPodDisruptionBudgetName(jobName string) string {
+	return jobName
+}
+
+// ReconcilePodDisruptionBudget creates the PodDisruptionBudget of the job once it is running, so
+// that the voluntary disruptions of its pods, e.g. the evictions of the node drains, are blocked
+// rather than silently breaking the synchronous training of all its replicas. The budget covers
+// all the pods of the job with no unavailable pod allowed. It is controlled by the job, and is
+// deleted once the job is finished or suspended.
+func (jc *JobController) ReconcilePodDisruptionBudget(runtimeObject runtime.Object, metaObject metav1.Object, jobStatus apiv1.JobStatus) error {
+	if jc.PodDisruptionBudgetClient == nil || !commonutil.IsRunning(jobStatus) || commonutil.IsFinished(jobStatus) || commonutil.IsSuspended(jobStatus) {
+		return nil
+	}
+	pdb := &policyv1.PodDisruptionBudget{}
+	key := types.NamespacedName{Namespace: metaObject.GetNamespace(), Name: GenPodDisruptionBudgetName(metaObject.GetName())}
+	err := jc.PodDisruptionBudgetClient.Get(context.Background(), key, pdb)
+	if err == nil {
+		if !metav1.IsControlledBy(pdb, metaObject) {
+			return fmt.Errorf("PodDisruptionBudget %s already exists and is not controlled by the job", pdb.Name)
+		}
+		return nil
+	}
+	if !errors.IsNotFound(err) {
+		return err
+	}
+
+	labels := jc.GenLabels(metaObject.GetName())
+	maxUnavailable := intstr.FromInt32(0)
+	pdb = &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            key.Name,
+			Namespace:       key.Namespace,
+			Labels:          labels,
+			OwnerReferences: []metav1.OwnerReference{*jc.GenOwnerReference(metaObject)},
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MaxUnavailable: &maxUnavailable,
+			Selector:       &metav1.LabelSelector{MatchLabels: labels},
+		},
+	}
+	jc.RecordAPICall(metaObject, APICallCreate)
+	if err := jc.PodDisruptionBudgetClient.Create(context.Background(), pdb); err != nil {
+		jc.Recorder.Eventf(runtimeObject, corev1.EventTypeWarning, commonutil.FailedCreatePodDisruptionBudgetReason, "Error creating: %v", err)
+		return err
+	}
+	jc.Recorder.Eventf(runtimeObject, corev1.EventTypeNormal, commonutil.SuccessfulCreatePodDisruptionBudgetReason, "Created PodDisruptionBudget: %v", pdb.Name)
+	return nil
+}
+
+// DeletePodDisruptionBudget deletes the PodDisruptionBudget of the job, once the job is finished
+// or suspended, so that its remaining pods can be evicted.
+func (jc *JobController) DeletePodDisruptionBudget(runtimeObject runtime.Object, metaObject metav1.Object) error {
+	if jc.PodDisruptionBudgetClient == nil {
+		return nil
+	}
+	pdb := &policyv1.PodDisruptionBudget{}
+	key := types.NamespacedName{Namespace: metaObject.GetNamespace(), Name: GenPodDisruptionBudgetName(metaObject.GetName())}
+	err := jc.PodDisruptionBudgetClient.Get(context.Background(), key, pdb)
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !metav1.IsControlledBy(pdb, metaObject) {
+		return nil
+	}
+	jc.RecordAPICall(metaObject, APICallDelete)
+	if err := jc.PodDisruptionBudgetClient.Delete(context.Background(), pdb); err != nil && !errors.IsNotFound(err) {
+		jc.Recorder.Eventf(runtimeObject, corev1.EventTypeWarning, commonutil.FailedDeletePodDisruptionBudgetReason, "Error deleting: %v", err)
+		return err
+	}
+	jc.Recorder.Eventf(runtimeObject, corev1.EventTypeNormal, commonutil.SuccessfulDeletePodDisruptionBudgetReason, "Deleted PodDisruptionBudget: %v", pdb.Name)
+	return nil
+}
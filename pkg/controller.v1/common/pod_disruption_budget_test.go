@@ -0,0 +1,102 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	commonutil "github.com/kubeflow/training-operator/pkg/util"
+	testjobv1 "github.com/kubeflow/training-operator/test_job/apis/test_job/v1"
+)
+
+func TestPodDisruptionBudget(t *testing.T) {
+	job := &testjobv1.TestJob{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: metav1.NamespaceDefault, UID: "job-uid"}}
+	c := fake.NewClientBuilder().Build()
+	jc := &JobController{
+		Controller:           &testJobController{frameworkController{framework: "test-framework"}},
+		Recorder:             record.NewFakeRecorder(10),
+		JobControllerOptions: JobControllerOptions{PodDisruptionBudgetClient: c},
+	}
+	key := types.NamespacedName{Namespace: job.Namespace, Name: GenPodDisruptionBudgetName(job.Name)}
+	pdb := &policyv1.PodDisruptionBudget{}
+
+	// The pods of a job which is not running yet are not protected.
+	jobStatus := apiv1.JobStatus{}
+	commonutil.UpdateJobConditions(&jobStatus, apiv1.JobCreated, corev1.ConditionTrue, commonutil.JobCreatedReason, "", nil)
+	if err := jc.ReconcilePodDisruptionBudget(job, job, jobStatus); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := c.Get(context.Background(), key, pdb); !k8serrors.IsNotFound(err) {
+		t.Errorf("Expected no PodDisruptionBudget for a job which is not running, got: %v", err)
+	}
+
+	commonutil.UpdateJobConditions(&jobStatus, apiv1.JobRunning, corev1.ConditionTrue, commonutil.JobRunningReason, "", nil)
+	if err := jc.ReconcilePodDisruptionBudget(job, job, jobStatus); err != nil {
+		t.Fatalf("Unexpected error creating the PodDisruptionBudget: %v", err)
+	}
+	if err := c.Get(context.Background(), key, pdb); err != nil {
+		t.Fatalf("Failed to get the PodDisruptionBudget: %v", err)
+	}
+	if !metav1.IsControlledBy(pdb, job) {
+		t.Errorf("Expected the PodDisruptionBudget to be controlled by the job")
+	}
+	maxUnavailable := intstr.FromInt32(0)
+	want := policyv1.PodDisruptionBudgetSpec{
+		MaxUnavailable: &maxUnavailable,
+		Selector:       &metav1.LabelSelector{MatchLabels: jc.GenLabels(job.Name)},
+	}
+	if diff := cmp.Diff(want, pdb.Spec); len(diff) != 0 {
+		t.Errorf("Unexpected spec of the PodDisruptionBudget (-want,+got):\n%s", diff)
+	}
+
+	// The existing PodDisruptionBudget is left untouched.
+	if err := jc.ReconcilePodDisruptionBudget(job, job, jobStatus); err != nil {
+		t.Fatalf("Unexpected error reconciling the existing PodDisruptionBudget: %v", err)
+	}
+
+	if err := jc.DeletePodDisruptionBudget(job, job); err != nil {
+		t.Fatalf("Unexpected error deleting the PodDisruptionBudget: %v", err)
+	}
+	if err := c.Get(context.Background(), key, pdb); !k8serrors.IsNotFound(err) {
+		t.Errorf("Expected the PodDisruptionBudget to be deleted, got: %v", err)
+	}
+}
+
+func TestPodDisruptionBudgetDisabled(t *testing.T) {
+	job := &testjobv1.TestJob{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: metav1.NamespaceDefault}}
+	jc := &JobController{Recorder: record.NewFakeRecorder(10)}
+	jobStatus := apiv1.JobStatus{}
+	commonutil.UpdateJobConditions(&jobStatus, apiv1.JobRunning, corev1.ConditionTrue, commonutil.JobRunningReason, "", nil)
+
+	// No PodDisruptionBudget is created out of the opt-in mode.
+	if err := jc.ReconcilePodDisruptionBudget(job, job, jobStatus); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if err := jc.DeletePodDisruptionBudget(job, job); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
@@ -15,6 +15,7 @@
 package common
 
 import (
+	"strings"
 	"testing"
 
 	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
@@ -66,6 +67,196 @@ func TestSetRestartPolicy(t *testing.T) {
 	}
 }
 
+func TestSetCommonEnv(t *testing.T) {
+	proxy := v1.EnvVar{Name: "HTTPS_PROXY", Value: "http://proxy:3128"}
+	wandb := v1.EnvVar{Name: "WANDB_API_KEY", ValueFrom: &v1.EnvVarSource{
+		SecretKeyRef: &v1.SecretKeySelector{LocalObjectReference: v1.LocalObjectReference{Name: "wandb"}, Key: "key"},
+	}}
+	commonSource := v1.EnvFromSource{ConfigMapRef: &v1.ConfigMapEnvSource{LocalObjectReference: v1.LocalObjectReference{Name: "common"}}}
+	replicaSource := v1.EnvFromSource{SecretRef: &v1.SecretEnvSource{LocalObjectReference: v1.LocalObjectReference{Name: "replica"}}}
+
+	testCases := map[string]struct {
+		containers     []v1.Container
+		env            []v1.EnvVar
+		envFrom        []v1.EnvFromSource
+		wantContainers []v1.Container
+	}{
+		"no common env": {
+			containers:     []v1.Container{{Name: "main", Env: []v1.EnvVar{proxy}}},
+			wantContainers: []v1.Container{{Name: "main", Env: []v1.EnvVar{proxy}}},
+		},
+		"common env is set in the main container only": {
+			containers: []v1.Container{{Name: "main"}, {Name: "sidecar"}},
+			env:        []v1.EnvVar{proxy, wandb},
+			envFrom:    []v1.EnvFromSource{commonSource},
+			wantContainers: []v1.Container{
+				{Name: "main", Env: []v1.EnvVar{proxy, wandb}, EnvFrom: []v1.EnvFromSource{commonSource}},
+				{Name: "sidecar"},
+			},
+		},
+		"env of the container takes precedence": {
+			containers: []v1.Container{{
+				Name:    "main",
+				Env:     []v1.EnvVar{{Name: "HTTPS_PROXY", Value: "http://other:3128"}},
+				EnvFrom: []v1.EnvFromSource{replicaSource},
+			}},
+			env:     []v1.EnvVar{proxy, wandb},
+			envFrom: []v1.EnvFromSource{commonSource},
+			wantContainers: []v1.Container{{
+				Name:    "main",
+				Env:     []v1.EnvVar{wandb, {Name: "HTTPS_PROXY", Value: "http://other:3128"}},
+				EnvFrom: []v1.EnvFromSource{commonSource, replicaSource},
+			}},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			podTemplate := &v1.PodTemplateSpec{Spec: v1.PodSpec{Containers: tc.containers}}
+			core.SetCommonEnv(podTemplate, "main", tc.env, tc.envFrom)
+			assert.Equal(t, tc.wantContainers, podTemplate.Spec.Containers)
+		})
+	}
+}
+
+func TestSetCapacityType(t *testing.T) {
+	spotTolerations := []v1.Toleration{
+		{Key: "cloud.google.com/gke-spot", Operator: v1.TolerationOpEqual, Value: "true", Effect: v1.TaintEffectNoSchedule},
+		{Key: "kubernetes.azure.com/scalesetpriority", Operator: v1.TolerationOpEqual, Value: "spot", Effect: v1.TaintEffectNoSchedule},
+	}
+	gpuRequirement := v1.NodeSelectorRequirement{Key: "gpu", Operator: v1.NodeSelectorOpExists}
+	testCases := map[string]struct {
+		capacityType        apiv1.CapacityType
+		affinity            *v1.Affinity
+		expectedAffinity    *v1.Affinity
+		expectedTolerations []v1.Toleration
+	}{
+		"capacityType is not set": {},
+		"capacityType is spot": {
+			capacityType: apiv1.CapacityTypeSpot,
+			expectedAffinity: &v1.Affinity{
+				NodeAffinity: &v1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &v1.NodeSelector{
+						NodeSelectorTerms: []v1.NodeSelectorTerm{
+							{MatchExpressions: []v1.NodeSelectorRequirement{{Key: "eks.amazonaws.com/capacityType", Operator: v1.NodeSelectorOpIn, Values: []string{"SPOT"}}}},
+							{MatchExpressions: []v1.NodeSelectorRequirement{{Key: "karpenter.sh/capacity-type", Operator: v1.NodeSelectorOpIn, Values: []string{"spot"}}}},
+							{MatchExpressions: []v1.NodeSelectorRequirement{{Key: "cloud.google.com/gke-spot", Operator: v1.NodeSelectorOpIn, Values: []string{"true"}}}},
+							{MatchExpressions: []v1.NodeSelectorRequirement{{Key: "kubernetes.azure.com/scalesetpriority", Operator: v1.NodeSelectorOpIn, Values: []string{"spot"}}}},
+						},
+					},
+				},
+			},
+			expectedTolerations: spotTolerations,
+		},
+		"capacityType is on-demand with an existing node affinity": {
+			capacityType: apiv1.CapacityTypeOnDemand,
+			affinity: &v1.Affinity{
+				NodeAffinity: &v1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &v1.NodeSelector{
+						NodeSelectorTerms: []v1.NodeSelectorTerm{{MatchExpressions: []v1.NodeSelectorRequirement{gpuRequirement}}},
+					},
+				},
+			},
+			expectedAffinity: &v1.Affinity{
+				NodeAffinity: &v1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &v1.NodeSelector{
+						NodeSelectorTerms: []v1.NodeSelectorTerm{{
+							MatchExpressions: []v1.NodeSelectorRequirement{
+								gpuRequirement,
+								{Key: "eks.amazonaws.com/capacityType", Operator: v1.NodeSelectorOpNotIn, Values: []string{"SPOT"}},
+								{Key: "karpenter.sh/capacity-type", Operator: v1.NodeSelectorOpNotIn, Values: []string{"spot"}},
+								{Key: "cloud.google.com/gke-spot", Operator: v1.NodeSelectorOpNotIn, Values: []string{"true"}},
+								{Key: "kubernetes.azure.com/scalesetpriority", Operator: v1.NodeSelectorOpNotIn, Values: []string{"spot"}},
+							},
+						}},
+					},
+				},
+			},
+		},
+		"capacityType is prefer-spot": {
+			capacityType: apiv1.CapacityTypePreferSpot,
+			expectedAffinity: &v1.Affinity{
+				NodeAffinity: &v1.NodeAffinity{
+					PreferredDuringSchedulingIgnoredDuringExecution: []v1.PreferredSchedulingTerm{
+						{Weight: 100, Preference: v1.NodeSelectorTerm{MatchExpressions: []v1.NodeSelectorRequirement{{Key: "eks.amazonaws.com/capacityType", Operator: v1.NodeSelectorOpIn, Values: []string{"SPOT"}}}}},
+						{Weight: 100, Preference: v1.NodeSelectorTerm{MatchExpressions: []v1.NodeSelectorRequirement{{Key: "karpenter.sh/capacity-type", Operator: v1.NodeSelectorOpIn, Values: []string{"spot"}}}}},
+						{Weight: 100, Preference: v1.NodeSelectorTerm{MatchExpressions: []v1.NodeSelectorRequirement{{Key: "cloud.google.com/gke-spot", Operator: v1.NodeSelectorOpIn, Values: []string{"true"}}}}},
+						{Weight: 100, Preference: v1.NodeSelectorTerm{MatchExpressions: []v1.NodeSelectorRequirement{{Key: "kubernetes.azure.com/scalesetpriority", Operator: v1.NodeSelectorOpIn, Values: []string{"spot"}}}}},
+					},
+				},
+			},
+			expectedTolerations: spotTolerations,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			replicaSpec := &apiv1.ReplicaSpec{CapacityType: tc.capacityType}
+			podTemplate := &v1.PodTemplateSpec{Spec: v1.PodSpec{Affinity: tc.affinity}}
+			core.SetCapacityType(podTemplate, replicaSpec)
+			assert.Equal(t, tc.expectedAffinity, podTemplate.Spec.Affinity)
+			assert.Equal(t, tc.expectedTolerations, podTemplate.Spec.Tolerations)
+		})
+	}
+}
+
+func TestSetControllerLabels(t *testing.T) {
+	controllerLabels := map[string]string{
+		apiv1.OperatorNameLabel: "test-operator",
+		apiv1.JobNameLabel:      "test-job",
+		apiv1.ReplicaTypeLabel:  "worker",
+		apiv1.ReplicaIndexLabel: "0",
+	}
+	testCases := map[string]struct {
+		labels              map[string]string
+		annotations         map[string]string
+		expectedLabels      map[string]string
+		expectedOverwritten []string
+	}{
+		"pod template without labels": {
+			expectedLabels: controllerLabels,
+		},
+		"user labels and annotations are kept": {
+			labels:      map[string]string{"team": "ml", "app.kubernetes.io/name": "trainer"},
+			annotations: map[string]string{"cost-center": "1234"},
+			expectedLabels: map[string]string{
+				"team":                   "ml",
+				"app.kubernetes.io/name": "trainer",
+				apiv1.OperatorNameLabel:  "test-operator",
+				apiv1.JobNameLabel:       "test-job",
+				apiv1.ReplicaTypeLabel:   "worker",
+				apiv1.ReplicaIndexLabel:  "0",
+			},
+		},
+		"reserved labels are overwritten": {
+			labels: map[string]string{
+				"team":                  "ml",
+				apiv1.JobNameLabel:      "test-job",
+				apiv1.ReplicaIndexLabel: "3",
+				apiv1.ReplicaTypeLabel:  "launcher",
+			},
+			expectedLabels: map[string]string{
+				"team":                  "ml",
+				apiv1.OperatorNameLabel: "test-operator",
+				apiv1.JobNameLabel:      "test-job",
+				apiv1.ReplicaTypeLabel:  "worker",
+				apiv1.ReplicaIndexLabel: "0",
+			},
+			expectedOverwritten: []string{apiv1.ReplicaIndexLabel, apiv1.ReplicaTypeLabel},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			podTemplate := &v1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Labels: tc.labels, Annotations: tc.annotations}}
+			overwritten := core.SetControllerLabels(podTemplate, controllerLabels)
+			assert.Equal(t, tc.expectedLabels, podTemplate.Labels)
+			assert.Equal(t, tc.annotations, podTemplate.Annotations)
+			assert.Equal(t, tc.expectedOverwritten, overwritten)
+		})
+	}
+	for key := range controllerLabels {
+		assert.True(t, strings.HasPrefix(key, apiv1.ControllerLabelPrefix), "label %s is not under the prefix of the controller", key)
+	}
+}
+
 func TestIsCustomSchedulerSet(t *testing.T) {
 	testCases := map[string]struct {
 		replicaSpecs      map[apiv1.ReplicaType]*apiv1.ReplicaSpec
@@ -226,3 +417,139 @@ func TestFilterPodsForReplicaType(t *testing.T) {
 	want := []*v1.Pod{pods[0], pods[2], pods[4]}
 	assert.Equal(t, want, got)
 }
+
+func TestEvaluateFailurePolicy(t *testing.T) {
+	newFailedPod := func(name string, exitCode int32, deleting bool) *v1.Pod {
+		pod := &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Status: v1.PodStatus{
+				Phase: v1.PodFailed,
+				ContainerStatuses: []v1.ContainerStatus{{
+					Name: "test",
+					State: v1.ContainerState{
+						Terminated: &v1.ContainerStateTerminated{ExitCode: exitCode},
+					},
+				}},
+			},
+		}
+		if deleting {
+			now := metav1.Now()
+			pod.DeletionTimestamp = &now
+		}
+		return pod
+	}
+	failurePolicy := &apiv1.FailurePolicy{
+		Rules: []apiv1.FailurePolicyRule{
+			{Action: apiv1.FailurePolicyActionIgnore, ExitCodes: []int32{137}},
+			{Action: apiv1.FailurePolicyActionRestart, ExitCodes: []int32{143}},
+			{Action: apiv1.FailurePolicyActionFailJob, ExitCodes: []int32{1}},
+		},
+	}
+	ignored := newFailedPod("ignored", 137, false)
+	restarted := newFailedPod("restarted", 143, false)
+	deleted := newFailedPod("deleted", 137, true)
+	cases := map[string]struct {
+		failurePolicy *apiv1.FailurePolicy
+		pods          []*v1.Pod
+		want          failurePolicyResult
+	}{
+		"failurePolicy is nil": {
+			pods: []*v1.Pod{newFailedPod("a", 137, false)},
+			want: failurePolicyResult{},
+		},
+		"failed pods are restarted": {
+			failurePolicy: failurePolicy,
+			pods:          []*v1.Pod{ignored, restarted, newFailedPod("unmatched", 2, false)},
+			want: failurePolicyResult{
+				restartPods: []*v1.Pod{ignored, restarted},
+				ignoredPods: []*v1.Pod{ignored},
+			},
+		},
+		"deleted pods are not restarted again": {
+			failurePolicy: failurePolicy,
+			pods:          []*v1.Pod{deleted},
+			want:          failurePolicyResult{ignoredPods: []*v1.Pod{deleted}},
+		},
+		"failed pod fails the job": {
+			failurePolicy: failurePolicy,
+			pods:          []*v1.Pod{newFailedPod("a", 1, false)},
+			want: failurePolicyResult{
+				failJobMessage: "Job test-job has failed because pod default/a exited with code 1 matching the failure policy",
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := evaluateFailurePolicy("test-job", tc.failurePolicy, "test", tc.pods)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestEvaluateFailurePolicyForFailureReasons(t *testing.T) {
+	failurePolicy := &apiv1.FailurePolicy{
+		Rules: []apiv1.FailurePolicyRule{
+			{Action: apiv1.FailurePolicyActionIgnore, FailureReasons: []apiv1.PodFailureReason{apiv1.PodFailureReasonEvicted}},
+			{Action: apiv1.FailurePolicyActionFailJob, FailureReasons: []apiv1.PodFailureReason{apiv1.PodFailureReasonOOMKilled}},
+			{Action: apiv1.FailurePolicyActionRestart, ExitCodes: []int32{137}},
+		},
+	}
+	evicted := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "evicted", Namespace: "default"},
+		Status:     v1.PodStatus{Phase: v1.PodFailed, Reason: "Evicted"},
+	}
+	oomKilled := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "oom", Namespace: "default"},
+		Status: v1.PodStatus{Phase: v1.PodFailed, ContainerStatuses: []v1.ContainerStatus{{
+			Name:  "test",
+			State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{ExitCode: 137, Reason: "OOMKilled"}},
+		}}},
+	}
+	imagePullBackOff := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pending", Namespace: "default"},
+		Status: v1.PodStatus{Phase: v1.PodPending, ContainerStatuses: []v1.ContainerStatus{{
+			Name:  "test",
+			State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "ErrImagePull"}},
+		}}},
+	}
+	cases := map[string]struct {
+		failurePolicy *apiv1.FailurePolicy
+		pods          []*v1.Pod
+		want          failurePolicyResult
+	}{
+		"evicted pod is always retried": {
+			failurePolicy: failurePolicy,
+			pods:          []*v1.Pod{evicted},
+			want:          failurePolicyResult{restartPods: []*v1.Pod{evicted}, ignoredPods: []*v1.Pod{evicted}},
+		},
+		"OOMKilled pod is never retried": {
+			failurePolicy: failurePolicy,
+			pods:          []*v1.Pod{oomKilled},
+			want: failurePolicyResult{
+				failJobMessage: "Job test-job has failed because pod default/oom failed with reason OOMKilled matching the failure policy",
+			},
+		},
+		"pending pod is not restarted": {
+			failurePolicy: &apiv1.FailurePolicy{Rules: []apiv1.FailurePolicyRule{
+				{Action: apiv1.FailurePolicyActionRestart, FailureReasons: []apiv1.PodFailureReason{apiv1.PodFailureReasonImagePullBackOff}},
+			}},
+			pods: []*v1.Pod{imagePullBackOff},
+			want: failurePolicyResult{},
+		},
+		"pending pod fails the job": {
+			failurePolicy: &apiv1.FailurePolicy{Rules: []apiv1.FailurePolicyRule{
+				{Action: apiv1.FailurePolicyActionFailJob, FailureReasons: []apiv1.PodFailureReason{apiv1.PodFailureReasonImagePullBackOff}},
+			}},
+			pods: []*v1.Pod{imagePullBackOff},
+			want: failurePolicyResult{
+				failJobMessage: "Job test-job has failed because pod default/pending failed with reason ImagePullBackOff matching the failure policy",
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := evaluateFailurePolicy("test-job", tc.failurePolicy, "test", tc.pods)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	trainingoperatorcommon "github.com/kubeflow/training-operator/pkg/common"
+	commonutil "github.com/kubeflow/training-operator/pkg/util"
+)
+
+// PodSecurityViolationError is returned when a pod would be rejected by the
+// PodSecurity admission of its namespace.
+type PodSecurityViolationError struct {
+	PodName string
+	Errs    field.ErrorList
+}
+
+func (e *PodSecurityViolationError) Error() string {
+	return fmt.Sprintf("pod %s violates the pod security policy of the namespace: %v", e.PodName, e.Errs.ToAggregate())
+}
+
+// CheckPodSecurity checks the pod template against the PodSecurity policy of the namespace,
+// and returns a PodSecurityViolationError naming the violated checks.
+func (jc *JobController) CheckPodSecurity(namespace string, podTemplate *corev1.PodTemplateSpec) error {
+	if jc.PodSecurityChecker == nil || jc.NamespaceLister == nil {
+		return nil
+	}
+	ns, err := jc.NamespaceLister.Get(namespace)
+	if err != nil {
+		return err
+	}
+	if errs := jc.PodSecurityChecker.Check(ns, podTemplate); len(errs) != 0 {
+		return &PodSecurityViolationError{PodName: podTemplate.Name, Errs: errs}
+	}
+	return nil
+}
+
+// FailJobForPodSecurity marks the job as failed, since its pods would not be admitted
+// in the namespace. The condition names the violating fields.
+func (jc *JobController) FailJobForPodSecurity(runtimeObject runtime.Object, metaObject metav1.Object, jobStatus *apiv1.JobStatus, violation *PodSecurityViolationError) {
+	jobKind := jc.Controller.GetAPIGroupVersionKind().Kind
+	reason := commonutil.NewReason(jobKind, commonutil.JobPodSecurityViolationReason)
+	msg := fmt.Sprintf("%s %s/%s is failed because %v", jobKind, metaObject.GetNamespace(), metaObject.GetName(), violation)
+	jc.Recorder.Event(runtimeObject, corev1.EventTypeWarning, reason, msg)
+	if commonutil.FinishJob(jobStatus, apiv1.JobFailed, reason, msg, jc.Clock) {
+		trainingoperatorcommon.FailedJobsCounterInc(metaObject.GetNamespace(), jc.Controller.GetFrameworkName())
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"errors"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/ptr"
+
+	"github.com/kubeflow/training-operator/pkg/util/podsecurity"
+)
+
+func TestCheckPodSecurity(t *testing.T) {
+	namespaces := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, ns := range []*corev1.Namespace{
+		{ObjectMeta: metav1.ObjectMeta{Name: "privileged"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "baseline", Labels: map[string]string{"pod-security.kubernetes.io/enforce": "baseline"}}},
+	} {
+		if err := namespaces.Add(ns); err != nil {
+			t.Fatalf("Failed to add the namespace %s: %v", ns.Name, err)
+		}
+	}
+	checker, err := podsecurity.NewChecker("", "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	privilegedPod := &corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-worker-0"},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{{
+			Name:            "pytorch",
+			SecurityContext: &corev1.SecurityContext{Privileged: ptr.To(true)},
+		}}},
+	}
+
+	cases := map[string]struct {
+		checker       *podsecurity.Checker
+		namespace     string
+		wantViolation bool
+		wantNotFound  bool
+	}{
+		"the privileged namespace allows the pod": {
+			checker:   checker,
+			namespace: "privileged",
+		},
+		"the baseline namespace forbids the pod": {
+			checker:       checker,
+			namespace:     "baseline",
+			wantViolation: true,
+		},
+		"the namespace isn't in the cache": {
+			checker:      checker,
+			namespace:    "missing",
+			wantNotFound: true,
+		},
+		"the pods are not checked without checker": {
+			namespace: "baseline",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			jc := &JobController{JobControllerOptions: JobControllerOptions{
+				PodSecurityChecker: tc.checker,
+				NamespaceLister:    corelisters.NewNamespaceLister(namespaces),
+			}}
+			err := jc.CheckPodSecurity(tc.namespace, privilegedPod)
+			var violation *PodSecurityViolationError
+			if got := errors.As(err, &violation); got != tc.wantViolation {
+				t.Errorf("Unexpected violation, want: %t, got: %v", tc.wantViolation, err)
+			}
+			if got := apierrors.IsNotFound(err); got != tc.wantNotFound {
+				t.Errorf("Unexpected not found error, want: %t, got: %v", tc.wantNotFound, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,300 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	trainingoperatorcommon "github.com/kubeflow/training-operator/pkg/common"
+	"github.com/kubeflow/training-operator/pkg/config"
+	"github.com/kubeflow/training-operator/pkg/controller.v1/expectation"
+	commonutil "github.com/kubeflow/training-operator/pkg/util"
+	utillabels "github.com/kubeflow/training-operator/pkg/util/labels"
+)
+
+// ParseNodePreemptionTaints parses the comma-separated taint keys of the --node-preemption-taints flag.
+func ParseNodePreemptionTaints(value string) ([]string, error) {
+	var keys []string
+	for _, key := range strings.Split(value, ",") {
+		key = strings.TrimSpace(key)
+		if key == "" {
+			continue
+		}
+		if errs := validation.IsQualifiedName(key); len(errs) != 0 {
+			return nil, fmt.Errorf("invalid taint key %q: %s", key, strings.Join(errs, ", "))
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// PodNodeNameKey is the field index of the pods by the name of their node.
+const PodNodeNameKey = "spec.nodeName"
+
+// preemptionRestartBaseDelay and preemptionRestartMaxDelay bound the backoff between the restarts
+// of a job on the preemptions of its nodes, which doubles with every such restart.
+const (
+	preemptionRestartBaseDelay = 30 * time.Second
+	preemptionRestartMaxDelay  = 10 * time.Minute
+)
+
+// IndexPodNodeName indexes the pods of the cache by the name of their node, so that the jobs with
+// pods on a node being reclaimed are found without listing all the pods of the operator.
+func IndexPodNodeName(ctx context.Context, indexer client.FieldIndexer) error {
+	return indexer.IndexField(ctx, &corev1.Pod{}, PodNodeNameKey, func(obj client.Object) []string {
+		pod, ok := obj.(*corev1.Pod)
+		if !ok || pod.Spec.NodeName == "" {
+			return nil
+		}
+		return []string{pod.Spec.NodeName}
+	})
+}
+
+// preemptionTaints returns the preemption taints of the operator which the node has, set by the
+// termination handler of the spot instances or by the autoscaler. Only the taints which evict the
+// pods or keep them from being scheduled on the node are preemption signals.
+func preemptionTaints(node *corev1.Node) []corev1.Taint {
+	var taints []corev1.Taint
+	for _, taint := range node.Spec.Taints {
+		if taint.Effect != corev1.TaintEffectNoExecute && taint.Effect != corev1.TaintEffectNoSchedule {
+			continue
+		}
+		if slices.Contains(config.Config.NodePreemptionTaints, taint.Key) {
+			taints = append(taints, taint)
+		}
+	}
+	return taints
+}
+
+// nodePreemptionSignal returns the signal of the imminent reclaim of the node for the pod, i.e. the
+// deletion of the node or one of its preemption taints which the pod doesn't tolerate, or "" if the
+// node is not being reclaimed from under the pod.
+func nodePreemptionSignal(node *corev1.Node, pod *corev1.Pod) string {
+	if node.DeletionTimestamp != nil {
+		return "deletion"
+	}
+	for _, taint := range preemptionTaints(node) {
+		if !slices.ContainsFunc(pod.Spec.Tolerations, func(toleration corev1.Toleration) bool {
+			return toleration.ToleratesTaint(&taint)
+		}) {
+			return "taint " + taint.Key
+		}
+	}
+	return ""
+}
+
+// isNodePreempted returns whether the node is being deleted or has a preemption taint.
+func isNodePreempted(node *corev1.Node) bool {
+	return node.DeletionTimestamp != nil || len(preemptionTaints(node)) != 0
+}
+
+// podOnPreemptedNode returns the first unfinished pod of the job bound to a node which is being
+// reclaimed from under it, with the signal of the reclaim. The nodes which no longer exist are left
+// to the rescheduling of the pods of the failed nodes.
+func (jc *JobController) podOnPreemptedNode(pods []*corev1.Pod) (*corev1.Pod, string, error) {
+	if jc.PreemptionClient == nil {
+		return nil, "", nil
+	}
+	nodes := map[string]*corev1.Node{}
+	for _, pod := range pods {
+		if pod.Spec.NodeName == "" || pod.DeletionTimestamp != nil || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		node, ok := nodes[pod.Spec.NodeName]
+		if !ok {
+			node = &corev1.Node{}
+			err := jc.PreemptionClient.Get(context.Background(), types.NamespacedName{Name: pod.Spec.NodeName}, node)
+			if errors.IsNotFound(err) {
+				node = nil
+			} else if err != nil {
+				return nil, "", err
+			}
+			nodes[pod.Spec.NodeName] = node
+		}
+		if node == nil {
+			continue
+		}
+		if signal := nodePreemptionSignal(node, pod); signal != "" {
+			return pod, signal, nil
+		}
+	}
+	return nil, "", nil
+}
+
+// preemptionRestartBackoff returns the remaining delay before the job may restart again on the
+// preemption of a node, which doubles with every such restart of the job since the last one, so
+// that a job whose pods keep landing on the nodes being reclaimed doesn't restart in a loop.
+func (jc *JobController) preemptionRestartBackoff(metaObject metav1.Object) time.Duration {
+	annotations := metaObject.GetAnnotations()
+	count, err := strconv.Atoi(annotations[apiv1.PreemptionRestartCountAnnotation])
+	if err != nil || count <= 0 {
+		return 0
+	}
+	restartedAt, err := time.Parse(time.RFC3339, annotations[apiv1.PreemptionRestartTimeAnnotation])
+	if err != nil {
+		return 0
+	}
+	delay := preemptionRestartBaseDelay
+	for i := 1; i < count && delay < preemptionRestartMaxDelay; i++ {
+		delay *= 2
+	}
+	return max(min(delay, preemptionRestartMaxDelay)-jc.Clock.Since(restartedAt), 0)
+}
+
+// restartPreemptedPods restarts all the pods of the job because the node of the preempted pod is
+// being reclaimed, rather than waiting for the pod to be evicted and the synchronous training to
+// fail: the running pods are asked to save a checkpoint if the job has a CheckpointPolicy, then
+// they are all deleted and recreated by the next reconciliations, away from the reclaimed node.
+// The cause of the restart and the number of such restarts are recorded in the annotations of
+// the job.
+func (jc *JobController) restartPreemptedPods(metaObject metav1.Object, runtimeObject runtime.Object, runPolicy *apiv1.RunPolicy, jobStatus *apiv1.JobStatus, preempted *corev1.Pod, signal string, pods []*corev1.Pod) error {
+	jobKey, err := KeyFunc(metaObject)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("couldn't get key for job object %#v: %v", metaObject, err))
+		return err
+	}
+	jobKind := jc.Controller.GetAPIGroupVersionKind().Kind
+	logger := commonutil.LoggerForJob(metaObject)
+	logger.Info("Restarting the pods of the job because a node is being reclaimed", "pod", preempted.Name, "node", preempted.Spec.NodeName, "signal", signal)
+
+	var restartPods []*corev1.Pod
+	for _, pod := range pods {
+		if pod.DeletionTimestamp == nil {
+			restartPods = append(restartPods, pod)
+		}
+	}
+	if runPolicy.CheckpointPolicy != nil && jc.checkpointPods(runtimeObject, runPolicy.CheckpointPolicy, jc.Controller.GetDefaultContainerName(), restartPods) {
+		return nil
+	}
+	for _, pod := range restartPods {
+		if err := jc.deletePod(pod, runtimeObject); err != nil {
+			return err
+		}
+		// Deletion is expected
+		if rType, err := utillabels.ReplicaType(pod.Labels); err == nil {
+			jc.Expectations.RaiseExpectations(expectation.GenExpectationPodsKey(jobKey, string(rType)), 0, 1)
+		}
+	}
+	if jc.Config.EnableGangScheduling() {
+		if err := jc.DeletePodGroup(metaObject); err != nil {
+			jc.Recorder.Eventf(runtimeObject, corev1.EventTypeWarning, commonutil.FailedDeletePodGroupReason, "Error deleting: %v", err)
+			return err
+		}
+	}
+
+	cause := fmt.Sprintf("node %s of pod %s is being reclaimed (%s)", preempted.Spec.NodeName, preempted.Name, signal)
+	// The pods are already deleted, so a failure to annotate the job doesn't restart it again.
+	if err := jc.annotatePreemptionRestart(metaObject, runtimeObject, cause); err != nil {
+		logger.Error(err, "Failed to annotate the job restarted on a preemption")
+	}
+	msg := fmt.Sprintf("%s %s is restarting because %s.", jobKind, metaObject.GetName(), cause)
+	jc.Recorder.Event(runtimeObject, corev1.EventTypeWarning, commonutil.NewReason(jobKind, commonutil.JobPreemptedReason), msg)
+	commonutil.UpdateJobConditions(jobStatus, apiv1.JobRestarting, corev1.ConditionTrue, commonutil.NewReason(jobKind, commonutil.JobPreemptedReason), msg, jc.Clock)
+	trainingoperatorcommon.RestartedJobsCounterInc(metaObject.GetNamespace(), jc.Controller.GetFrameworkName())
+	return nil
+}
+
+// annotatePreemptionRestart records the cause and the time of the restart of the job on a preemption
+// and increments the number of such restarts in the annotations of the job, with a merge patch which
+// leaves the rest of the job untouched.
+func (jc *JobController) annotatePreemptionRestart(metaObject metav1.Object, runtimeObject runtime.Object, cause string) error {
+	job, ok := runtimeObject.(client.Object)
+	if !ok {
+		return fmt.Errorf("%s/%s is not a client.Object", metaObject.GetNamespace(), metaObject.GetName())
+	}
+	count, _ := strconv.Atoi(metaObject.GetAnnotations()[apiv1.PreemptionRestartCountAnnotation])
+	patched := job.DeepCopyObject().(client.Object)
+	annotations := patched.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[apiv1.PreemptionRestartCountAnnotation] = strconv.Itoa(count + 1)
+	annotations[apiv1.PreemptionRestartCauseAnnotation] = cause
+	annotations[apiv1.PreemptionRestartTimeAnnotation] = jc.Clock.Now().UTC().Format(time.RFC3339)
+	patched.SetAnnotations(annotations)
+	jc.RecordAPICall(metaObject, APICallUpdate)
+	return jc.PreemptionClient.Patch(context.Background(), patched, client.MergeFrom(job))
+}
+
+// WatchPreemptedNodes requeues the jobs of the controller c with pods bound to a node once the
+// node starts being reclaimed, so that the jobs are restarted before the node goes away. Nothing
+// is watched unless the jobs are restarted on the preemptions of their nodes.
+func (jc *JobController) WatchPreemptedNodes(mgr manager.Manager, c controller.Controller) error {
+	if jc.PreemptionClient == nil {
+		return nil
+	}
+	preempted := predicate.TypedFuncs[*corev1.Node]{
+		CreateFunc: func(event.TypedCreateEvent[*corev1.Node]) bool { return false },
+		UpdateFunc: func(e event.TypedUpdateEvent[*corev1.Node]) bool {
+			return !isNodePreempted(e.ObjectOld) && isNodePreempted(e.ObjectNew)
+		},
+		DeleteFunc:  func(event.TypedDeleteEvent[*corev1.Node]) bool { return false },
+		GenericFunc: func(event.TypedGenericEvent[*corev1.Node]) bool { return false },
+	}
+	return c.Watch(source.Kind[*corev1.Node](mgr.GetCache(), &corev1.Node{},
+		handler.TypedEnqueueRequestsFromMapFunc(jc.jobsOnNode), preempted))
+}
+
+// jobsOnNode returns the requests of the jobs of the controller with unfinished pods bound to the
+// node. The pods are listed with the PodNodeNameKey index of the cache.
+func (jc *JobController) jobsOnNode(ctx context.Context, node *corev1.Node) []reconcile.Request {
+	pods := &corev1.PodList{}
+	if err := jc.PreemptionClient.List(ctx, pods, client.MatchingFields{PodNodeNameKey: node.Name},
+		client.MatchingLabels{apiv1.OperatorNameLabel: jc.Controller.ControllerName()}); err != nil {
+		utilruntime.HandleError(fmt.Errorf("couldn't list the pods of node %s: %v", node.Name, err))
+		return nil
+	}
+	jobKind := jc.Controller.GetAPIGroupVersionKind().Kind
+	seen := map[types.NamespacedName]bool{}
+	var requests []reconcile.Request
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		controllerRef := metav1.GetControllerOf(pod)
+		if controllerRef == nil || controllerRef.Kind != jobKind {
+			continue
+		}
+		key := types.NamespacedName{Namespace: pod.Namespace, Name: controllerRef.Name}
+		if !seen[key] {
+			seen[key] = true
+			requests = append(requests, reconcile.Request{NamespacedName: key})
+		}
+	}
+	return requests
+}
@@ -0,0 +1,291 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	clocktesting "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	"github.com/kubeflow/training-operator/pkg/config"
+	"github.com/kubeflow/training-operator/pkg/controller.v1/control"
+	"github.com/kubeflow/training-operator/pkg/controller.v1/expectation"
+	commonutil "github.com/kubeflow/training-operator/pkg/util"
+	testjobv1 "github.com/kubeflow/training-operator/test_job/apis/test_job/v1"
+)
+
+func TestParseNodePreemptionTaints(t *testing.T) {
+	keys, err := ParseNodePreemptionTaints(config.NodePreemptionTaintsDefault + ", ,example.com/reclaim")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want := []string{"cloud.google.com/impending-node-termination", "aws-node-termination-handler/spot-itn",
+		"karpenter.sh/disrupted", "ToBeDeletedByClusterAutoscaler", "example.com/reclaim"}
+	if diff := cmp.Diff(want, keys); len(diff) != 0 {
+		t.Errorf("Unexpected taint keys (-want,+got):\n%s", diff)
+	}
+	if _, err := ParseNodePreemptionTaints("invalid key"); err == nil {
+		t.Errorf("Expected an error for an invalid taint key")
+	}
+}
+
+func newPodOnNode(name, node string, phase corev1.PodPhase) *corev1.Pod {
+	pod := newPod(name, phase)
+	pod.Namespace = metav1.NamespaceDefault
+	pod.Spec.NodeName = node
+	return pod
+}
+
+func TestPodOnPreemptedNode(t *testing.T) {
+	defer func(taints []string) { config.Config.NodePreemptionTaints = taints }(config.Config.NodePreemptionTaints)
+	config.Config.NodePreemptionTaints = []string{"karpenter.sh/disrupted"}
+
+	deletingNode := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "deleting", DeletionTimestamp: ptr.To(metav1.Now()), Finalizers: []string{"test"}}}
+	c := fake.NewClientBuilder().WithObjects(
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "healthy"}},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "other-taint"}, Spec: corev1.NodeSpec{Taints: []corev1.Taint{{Key: "example.com/maintenance", Effect: corev1.TaintEffectNoSchedule}}}},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "disrupted"}, Spec: corev1.NodeSpec{Taints: []corev1.Taint{{Key: "karpenter.sh/disrupted", Effect: corev1.TaintEffectNoSchedule}}}},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "prefer-no-schedule"}, Spec: corev1.NodeSpec{Taints: []corev1.Taint{{Key: "karpenter.sh/disrupted", Effect: corev1.TaintEffectPreferNoSchedule}}}},
+		deletingNode,
+	).Build()
+	jc := &JobController{JobControllerOptions: JobControllerOptions{PreemptionClient: c}}
+	tolerating := func(pod *corev1.Pod) *corev1.Pod {
+		pod.Spec.Tolerations = []corev1.Toleration{{Key: "karpenter.sh/disrupted", Operator: corev1.TolerationOpExists}}
+		return pod
+	}
+
+	cases := map[string]struct {
+		pods       []*corev1.Pod
+		wantPod    string
+		wantSignal string
+	}{
+		"pods on healthy nodes": {
+			pods: []*corev1.Pod{newPodOnNode("pod-0", "healthy", corev1.PodRunning), newPodOnNode("pod-1", "other-taint", corev1.PodRunning)},
+		},
+		"pod on a missing node": {
+			pods: []*corev1.Pod{newPodOnNode("pod-0", "missing", corev1.PodRunning)},
+		},
+		"finished pod on a tainted node": {
+			pods: []*corev1.Pod{newPodOnNode("pod-0", "disrupted", corev1.PodSucceeded)},
+		},
+		"pod on a node with a PreferNoSchedule taint": {
+			pods: []*corev1.Pod{newPodOnNode("pod-0", "prefer-no-schedule", corev1.PodRunning)},
+		},
+		"pod tolerating the taint": {
+			pods: []*corev1.Pod{tolerating(newPodOnNode("pod-0", "disrupted", corev1.PodRunning))},
+		},
+		"pod on a tainted node": {
+			pods:       []*corev1.Pod{newPodOnNode("pod-0", "healthy", corev1.PodRunning), newPodOnNode("pod-1", "disrupted", corev1.PodRunning)},
+			wantPod:    "pod-1",
+			wantSignal: "taint karpenter.sh/disrupted",
+		},
+		"pod on a deleted node": {
+			pods:       []*corev1.Pod{newPodOnNode("pod-0", "deleting", corev1.PodPending)},
+			wantPod:    "pod-0",
+			wantSignal: "deletion",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			pod, signal, err := jc.podOnPreemptedNode(tc.pods)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			gotPod := ""
+			if pod != nil {
+				gotPod = pod.Name
+			}
+			if gotPod != tc.wantPod || signal != tc.wantSignal {
+				t.Errorf("Unexpected preempted pod, want: %q (%q), got: %q (%q)", tc.wantPod, tc.wantSignal, gotPod, signal)
+			}
+		})
+	}
+}
+
+func TestRestartPreemptedPods(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := testjobv1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	job := &testjobv1.TestJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test",
+			Namespace:   metav1.NamespaceDefault,
+			Annotations: map[string]string{apiv1.PreemptionRestartCountAnnotation: "1"},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(job).Build()
+	if err := c.Get(context.Background(), client.ObjectKeyFromObject(job), job); err != nil {
+		t.Fatal(err)
+	}
+	deletingPod := newPodOnNode("pod-2", "healthy", corev1.PodRunning)
+	deletingPod.DeletionTimestamp = ptr.To(metav1.Now())
+	pods := []*corev1.Pod{
+		newPodOnNode("pod-0", "healthy", corev1.PodRunning),
+		newPodOnNode("pod-1", "disrupted", corev1.PodRunning),
+		deletingPod,
+	}
+	podControl := &control.FakePodControl{}
+	podExecControl := &control.FakePodExecControl{}
+	recorder := record.NewFakeRecorder(10)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	jc := &JobController{
+		Controller:           &testJobController{frameworkController{framework: "test-framework"}},
+		Clock:                commonutil.NewClock(clocktesting.NewFakeClock(now), 0),
+		PodControl:           podControl,
+		PodExecControl:       podExecControl,
+		Expectations:         expectation.NewControllerExpectations(),
+		Recorder:             recorder,
+		JobControllerOptions: JobControllerOptions{PreemptionClient: c},
+		WorkQueue:            workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+	}
+	defer jc.WorkQueue.ShutDown()
+	runPolicy := &apiv1.RunPolicy{
+		CheckpointPolicy: &apiv1.CheckpointPolicy{Command: []string{"/checkpoint.sh"}},
+	}
+	jobStatus := &apiv1.JobStatus{}
+
+	// The pods are asked to save a checkpoint, and are not deleted until its deadline.
+	if err := jc.restartPreemptedPods(job, job, runPolicy, jobStatus, pods[1], "taint karpenter.sh/disrupted", pods); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(podControl.DeletePodName) != 0 {
+		t.Errorf("Expected no pod to be deleted before the checkpoint deadline, got: %v", podControl.DeletePodName)
+	}
+	err := wait.PollUntilContextTimeout(context.Background(), 10*time.Millisecond, 5*time.Second, true, func(context.Context) (bool, error) {
+		podExecControl.Lock()
+		defer podExecControl.Unlock()
+		return len(podExecControl.ExecPodNames) == 2, nil
+	})
+	if err != nil {
+		t.Errorf("Expected the running pods to be checkpointed, got: %v", podExecControl.ExecPodNames)
+	}
+
+	// The pods are deleted once the deadline has passed.
+	for _, pod := range pods[:2] {
+		pod.Annotations = map[string]string{apiv1.CheckpointDeadlineAnnotation: now.Add(-time.Second).Format(time.RFC3339)}
+	}
+	if err := jc.restartPreemptedPods(job, job, runPolicy, jobStatus, pods[1], "taint karpenter.sh/disrupted", pods); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if diff := cmp.Diff([]string{"pod-0", "pod-1"}, podControl.DeletePodName); len(diff) != 0 {
+		t.Errorf("Unexpected deleted pods (-want,+got):\n%s", diff)
+	}
+	wantReason := commonutil.NewReason(testjobv1.Kind, commonutil.JobPreemptedReason)
+	if len(jobStatus.Conditions) != 1 || jobStatus.Conditions[0].Type != apiv1.JobRestarting || jobStatus.Conditions[0].Reason != wantReason {
+		t.Errorf("Expected a Restarting condition with the %s reason, got: %v", wantReason, jobStatus.Conditions)
+	}
+
+	got := &testjobv1.TestJob{}
+	if err := c.Get(context.Background(), client.ObjectKeyFromObject(job), got); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		apiv1.PreemptionRestartCountAnnotation: "2",
+		apiv1.PreemptionRestartCauseAnnotation: "node disrupted of pod pod-1 is being reclaimed (taint karpenter.sh/disrupted)",
+		apiv1.PreemptionRestartTimeAnnotation:  "2024-01-01T00:00:00Z",
+	}
+	if diff := cmp.Diff(want, got.Annotations); len(diff) != 0 {
+		t.Errorf("Unexpected annotations of the job (-want,+got):\n%s", diff)
+	}
+}
+
+func TestJobsOnNode(t *testing.T) {
+	newJobPod := func(name, node, kind, job string) *corev1.Pod {
+		pod := newPodOnNode(name, node, corev1.PodRunning)
+		pod.Labels[apiv1.OperatorNameLabel] = "test-operator"
+		pod.OwnerReferences = []metav1.OwnerReference{{APIVersion: "v1", Kind: kind, Name: job, UID: "uid", Controller: ptr.To(true)}}
+		return pod
+	}
+	c := fake.NewClientBuilder().WithIndex(&corev1.Pod{}, PodNodeNameKey, func(obj client.Object) []string {
+		return []string{obj.(*corev1.Pod).Spec.NodeName}
+	}).WithObjects(
+		newJobPod("job-a-0", "disrupted", testjobv1.Kind, "job-a"),
+		newJobPod("job-a-1", "disrupted", testjobv1.Kind, "job-a"),
+		newJobPod("job-b-0", "healthy", testjobv1.Kind, "job-b"),
+		newJobPod("other-0", "disrupted", "OtherJob", "other"),
+	).Build()
+	jc := &JobController{
+		Controller:           &testJobController{frameworkController{framework: "test-framework"}},
+		JobControllerOptions: JobControllerOptions{PreemptionClient: c},
+	}
+
+	var got []string
+	for _, request := range jc.jobsOnNode(context.Background(), &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "disrupted"}}) {
+		got = append(got, request.String())
+	}
+	if diff := cmp.Diff([]string{"default/job-a"}, got); len(diff) != 0 {
+		t.Errorf("Unexpected requeued jobs (-want,+got):\n%s", diff)
+	}
+}
+
+func TestPreemptionRestartBackoff(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	jc := &JobController{Clock: commonutil.NewClock(clocktesting.NewFakeClock(now), 0)}
+	cases := map[string]struct {
+		count       string
+		restartedAt time.Time
+		want        time.Duration
+	}{
+		"never restarted": {},
+		"restarted once": {
+			count:       "1",
+			restartedAt: now.Add(-10 * time.Second),
+			want:        20 * time.Second,
+		},
+		"restarted three times": {
+			count:       "3",
+			restartedAt: now.Add(-time.Minute),
+			want:        time.Minute,
+		},
+		"restarted many times": {
+			count:       "100",
+			restartedAt: now.Add(-time.Minute),
+			want:        9 * time.Minute,
+		},
+		"backoff expired": {
+			count:       "1",
+			restartedAt: now.Add(-time.Hour),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			job := &metav1.ObjectMeta{Annotations: map[string]string{apiv1.PreemptionRestartCountAnnotation: tc.count}}
+			if !tc.restartedAt.IsZero() {
+				job.Annotations[apiv1.PreemptionRestartTimeAnnotation] = tc.restartedAt.Format(time.RFC3339)
+			}
+			if got := jc.preemptionRestartBackoff(job); got != tc.want {
+				t.Errorf("Unexpected backoff, want: %v, got: %v", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kubeflow/training-operator/pkg/config"
+)
+
+// ParseDefaultPriorityClasses parses the comma-separated <namespace>=<priority class> pairs of the
+// --default-priority-classes flag.
+func ParseDefaultPriorityClasses(value string) (map[string]string, error) {
+	priorityClasses := map[string]string{}
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		namespace, priorityClass, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid default priority class %q, expected <namespace>=<priority class>", pair)
+		}
+		if errs := validation.IsDNS1123Label(namespace); len(errs) != 0 {
+			return nil, fmt.Errorf("invalid namespace %q: %s", namespace, strings.Join(errs, ", "))
+		}
+		if errs := validation.IsDNS1123Subdomain(priorityClass); len(errs) != 0 {
+			return nil, fmt.Errorf("invalid priority class %q: %s", priorityClass, strings.Join(errs, ", "))
+		}
+		priorityClasses[namespace] = priorityClass
+	}
+	return priorityClasses, nil
+}
+
+// defaultPriorityClass returns the default priority class of the pods and the PodGroups of the
+// jobs of the namespace, or "" if the namespace has none or its priority class doesn't exist,
+// so that the pods are not rejected by the API server.
+func (jc *JobController) defaultPriorityClass(namespace string) string {
+	priorityClass, ok := config.Config.DefaultPriorityClasses[namespace]
+	if !ok {
+		return ""
+	}
+	if jc.PriorityClassLister != nil {
+		if _, err := jc.PriorityClassLister.Get(priorityClass); err != nil {
+			log.Log.Info("Ignoring the default priority class of the namespace", "namespace", namespace,
+				"priorityClass", priorityClass, "error", err)
+			return ""
+		}
+	}
+	return priorityClass
+}
+
+// SetDefaultPriorityClass sets the default priority class of the namespace of the job to the pod
+// template if it doesn't set one.
+func (jc *JobController) SetDefaultPriorityClass(podTemplate *corev1.PodTemplateSpec, namespace string) {
+	if podTemplate.Spec.PriorityClassName != "" {
+		return
+	}
+	podTemplate.Spec.PriorityClassName = jc.defaultPriorityClass(namespace)
+}
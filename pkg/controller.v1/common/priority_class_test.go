@@ -0,0 +1,88 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	schedulinglisters "k8s.io/client-go/listers/scheduling/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/kubeflow/training-operator/pkg/config"
+)
+
+func TestParseDefaultPriorityClasses(t *testing.T) {
+	got, err := ParseDefaultPriorityClasses("research=low-priority, ,production=high-priority")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want := map[string]string{"research": "low-priority", "production": "high-priority"}
+	if diff := cmp.Diff(want, got); len(diff) != 0 {
+		t.Errorf("Unexpected default priority classes (-want,+got):\n%s", diff)
+	}
+	for _, value := range []string{"research", "Research=low-priority", "research=low_priority"} {
+		if _, err := ParseDefaultPriorityClasses(value); err == nil {
+			t.Errorf("Expected an error for %q", value)
+		}
+	}
+}
+
+func TestSetDefaultPriorityClass(t *testing.T) {
+	defer func(priorityClasses map[string]string) {
+		config.Config.DefaultPriorityClasses = priorityClasses
+	}(config.Config.DefaultPriorityClasses)
+	config.Config.DefaultPriorityClasses = map[string]string{"research": "low-priority", "staging": "missing-priority"}
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	if err := indexer.Add(&schedulingv1.PriorityClass{ObjectMeta: metav1.ObjectMeta{Name: "low-priority"}}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	jc := &JobController{PriorityClassLister: schedulinglisters.NewPriorityClassLister(indexer)}
+
+	cases := map[string]struct {
+		namespace     string
+		priorityClass string
+		want          string
+	}{
+		"default of the namespace is set": {
+			namespace: "research",
+			want:      "low-priority",
+		},
+		"priority class of the template is kept": {
+			namespace:     "research",
+			priorityClass: "high-priority",
+			want:          "high-priority",
+		},
+		"namespace without a default": {
+			namespace: "production",
+		},
+		"missing default is ignored": {
+			namespace: "staging",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			podTemplate := &corev1.PodTemplateSpec{Spec: corev1.PodSpec{PriorityClassName: tc.priorityClass}}
+			jc.SetDefaultPriorityClass(podTemplate, tc.namespace)
+			if podTemplate.Spec.PriorityClassName != tc.want {
+				t.Errorf("Unexpected priority class, want %q, got %q", tc.want, podTemplate.Spec.PriorityClassName)
+			}
+		})
+	}
+}
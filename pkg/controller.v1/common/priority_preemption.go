@@ -0,0 +1,294 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	"github.com/kubeflow/training-operator/pkg/controller.v1/registry"
+	commonutil "github.com/kubeflow/training-operator/pkg/util"
+)
+
+// jobPriority returns the value of the priority class of a job, or of the default priority class
+// of its namespace if it sets none. The jobs without priority class, or whose priority class
+// doesn't exist, have the priority 0.
+func (jc *JobController) jobPriority(namespace, priorityClass string) int32 {
+	if priorityClass == "" {
+		priorityClass = jc.defaultPriorityClass(namespace)
+	}
+	if priorityClass == "" || jc.PriorityClassLister == nil {
+		return 0
+	}
+	pc, err := jc.PriorityClassLister.Get(priorityClass)
+	if err != nil {
+		return 0
+	}
+	return pc.Value
+}
+
+// samePreemptionScope returns true if other competes for the resources of job: the jobs of the
+// same queue, or the jobs of the same namespace without queue if job has none.
+func samePreemptionScope(job, other registry.JobInfo) bool {
+	if job.Queue != "" {
+		return other.Queue == job.Queue
+	}
+	return other.Queue == "" && other.Namespace == job.Namespace
+}
+
+// coversResources returns true if freed covers all the resources of requests.
+func coversResources(freed, requests corev1.ResourceList) bool {
+	for name, quantity := range requests {
+		if f, ok := freed[name]; !ok || f.Cmp(quantity) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// priorityPreemptionGracePeriod is the time given to the gang scheduler to place the pods of a job on the
+// resources freed by the jobs suspended for it, before more jobs may be suspended for the job.
+const priorityPreemptionGracePeriod = time.Minute
+
+type preemptionCandidate struct {
+	registry.JobInfo
+	priority int32
+}
+
+// preemptLowerPriorityJobs suspends the running jobs of lower priority than the job, in the same
+// queue or namespace, when the job can't be scheduled, i.e. it is queued by the gang scheduler or
+// some of its pods are unschedulable. The jobs of the lowest priority are suspended first, and the
+// most recent ones first among them since they lose the least progress, until the resources they
+// request cover the resources requested by the job. No job is suspended if they can't cover them,
+// nor while the pods of previously suspended jobs still hold resources. The suspended jobs are
+// annotated with the PriorityPreemptedByAnnotation, see resumePreemptedJob, and the job with the
+// PriorityPreemptedJobsAnnotation and the PriorityPreemptionTimeAnnotation, see priorityPreemptionDelay.
+func (jc *JobController) preemptLowerPriorityJobs(metaObject metav1.Object, runtimeObject runtime.Object, jobStatus apiv1.JobStatus) error {
+	if jc.PriorityPreemptionClient == nil || jc.JobRegistry == nil {
+		return nil
+	}
+	if !commonutil.IsQueued(jobStatus) && !commonutil.IsScheduling(jobStatus) {
+		return nil
+	}
+	if commonutil.IsFinished(jobStatus) || commonutil.IsSuspended(jobStatus) {
+		return nil
+	}
+	jobKind := jc.Controller.GetAPIGroupVersionKind().Kind
+	job, ok := jc.JobRegistry.Get(jobKind, metaObject.GetNamespace(), metaObject.GetName())
+	if !ok || job.Suspended || len(job.Resources) == 0 {
+		return nil
+	}
+	if delay := jc.priorityPreemptionDelay(metaObject); delay > 0 {
+		if jobKey, err := KeyFunc(metaObject); err == nil {
+			jc.WorkQueue.AddAfter(jobKey, delay)
+		}
+		return nil
+	}
+	priority := jc.jobPriority(job.Namespace, job.PriorityClass)
+	logger := commonutil.LoggerForJob(metaObject)
+
+	freed := corev1.ResourceList{}
+	var candidates []preemptionCandidate
+	for _, other := range jc.JobRegistry.List() {
+		if other.UID == job.UID || !samePreemptionScope(job, other) {
+			continue
+		}
+		if other.Suspended {
+			// The resources of the jobs being suspended are about to be freed.
+			if other.ActiveReplicas > 0 {
+				addResources(freed, other.Resources)
+			}
+			continue
+		}
+		if other.Phase != apiv1.JobRunning {
+			continue
+		}
+		if otherPriority := jc.jobPriority(other.Namespace, other.PriorityClass); otherPriority < priority {
+			candidates = append(candidates, preemptionCandidate{JobInfo: other, priority: otherPriority})
+		}
+	}
+	if coversResources(freed, job.Resources) {
+		return nil
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].priority != candidates[j].priority {
+			return candidates[i].priority < candidates[j].priority
+		}
+		return candidates[j].CreationTimestamp.Before(&candidates[i].CreationTimestamp)
+	})
+	var victims []preemptionCandidate
+	for _, candidate := range candidates {
+		if coversResources(freed, job.Resources) {
+			break
+		}
+		victims = append(victims, candidate)
+		addResources(freed, candidate.Resources)
+	}
+	if !coversResources(freed, job.Resources) {
+		logger.Info("The running jobs of lower priority don't request enough resources to be preempted",
+			"priority", priority, "candidates", len(candidates))
+		return nil
+	}
+
+	var preempted, suspended []string
+	for _, victim := range victims {
+		if err := jc.suspendPreemptedJob(victim, jobKind, metaObject, priority); err != nil {
+			return err
+		}
+		preempted = append(preempted, fmt.Sprintf("%s %s/%s (priority %d)", victim.Kind, victim.Namespace, victim.Name, victim.priority))
+		suspended = append(suspended, fmt.Sprintf("%s %s/%s", victim.Kind, victim.Namespace, victim.Name))
+	}
+	if err := jc.annotatePriorityPreemption(metaObject, runtimeObject, suspended); err != nil {
+		return err
+	}
+	msg := fmt.Sprintf("%s %s of priority %d can't be scheduled, suspended the running jobs of lower priority %s to free their resources",
+		jobKind, metaObject.GetName(), priority, strings.Join(preempted, ", "))
+	logger.Info(msg)
+	jc.Recorder.Event(runtimeObject, corev1.EventTypeNormal, commonutil.PreemptedLowerPriorityJobsReason, msg)
+	return nil
+}
+
+// priorityPreemptionDelay returns the remaining delay before more jobs may be suspended for the job, once
+// jobs were suspended for it: the pods of these jobs must be gone and the priorityPreemptionGracePeriod
+// must have passed since their suspension, so that the pods of the job have the time to be scheduled on
+// the freed resources. Otherwise the jobs of lower priority would be suspended one after another as soon
+// as the pods of the previous ones are gone.
+func (jc *JobController) priorityPreemptionDelay(metaObject metav1.Object) time.Duration {
+	annotations := metaObject.GetAnnotations()
+	preemptedAt, err := time.Parse(time.RFC3339, annotations[apiv1.PriorityPreemptionTimeAnnotation])
+	if err != nil {
+		return 0
+	}
+	for _, preempted := range strings.Split(annotations[apiv1.PriorityPreemptedJobsAnnotation], ",") {
+		kind, key, _ := strings.Cut(preempted, " ")
+		namespace, name, err := cache.SplitMetaNamespaceKey(key)
+		if err != nil {
+			continue
+		}
+		if info, ok := jc.JobRegistry.Get(kind, namespace, name); ok && info.ActiveReplicas > 0 {
+			return jobQueueRequeuePeriod
+		}
+	}
+	return max(priorityPreemptionGracePeriod-jc.Clock.Since(preemptedAt), 0)
+}
+
+// annotatePriorityPreemption records the jobs suspended for the job and the time of their suspension in
+// the annotations of the job, with a merge patch which leaves the rest of the job untouched.
+func (jc *JobController) annotatePriorityPreemption(metaObject metav1.Object, runtimeObject runtime.Object, suspended []string) error {
+	job, ok := runtimeObject.(client.Object)
+	if !ok {
+		return fmt.Errorf("%s/%s is not a client.Object", metaObject.GetNamespace(), metaObject.GetName())
+	}
+	patched := job.DeepCopyObject().(client.Object)
+	annotations := patched.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[apiv1.PriorityPreemptedJobsAnnotation] = strings.Join(suspended, ",")
+	annotations[apiv1.PriorityPreemptionTimeAnnotation] = jc.Clock.Now().UTC().Format(time.RFC3339)
+	patched.SetAnnotations(annotations)
+	jc.RecordAPICall(metaObject, APICallUpdate)
+	return jc.PriorityPreemptionClient.Patch(context.Background(), patched, client.MergeFrom(job))
+}
+
+// suspendPreemptedJob suspends the victim through its RunPolicy, records the job it is suspended for
+// in its PriorityPreemptedByAnnotation, and explains the decision in an event of the victim.
+func (jc *JobController) suspendPreemptedJob(victim preemptionCandidate, jobKind string, metaObject metav1.Object, priority int32) error {
+	job := &unstructured.Unstructured{}
+	job.SetGroupVersionKind(apiv1.GroupVersion.WithKind(victim.Kind))
+	job.SetNamespace(victim.Namespace)
+	job.SetName(victim.Name)
+	preemptor := fmt.Sprintf("%s %s/%s", jobKind, metaObject.GetNamespace(), metaObject.GetName())
+	// The UID precondition avoids suspending a job recreated with the same name.
+	patch := fmt.Sprintf(`{"metadata":{"uid":%q,"annotations":{%q:%q}},"spec":{"runPolicy":{"suspend":true}}}`,
+		victim.UID, apiv1.PriorityPreemptedByAnnotation, preemptor)
+	err := jc.PriorityPreemptionClient.Patch(context.Background(), job, client.RawPatch(types.MergePatchType, []byte(patch)))
+	jc.RecordAPICall(metaObject, APICallUpdate)
+	if err != nil {
+		return fmt.Errorf("failed to suspend %s %s/%s: %w", victim.Kind, victim.Namespace, victim.Name, err)
+	}
+	msg := fmt.Sprintf("Suspended to free resources for %s of priority %d which can't be scheduled, this job has priority %d, %s",
+		preemptor, priority, victim.priority, preemptedResumeHint(victim.Queue, preemptor, jc.JobQueues))
+	jc.Recorder.Event(job, corev1.EventTypeWarning, commonutil.JobPriorityPreemptedReason, msg)
+	return nil
+}
+
+// preemptedResumeHint tells how a job of queue suspended for preemptor is resumed.
+func preemptedResumeHint(queue, preemptor string, queues *JobQueues) string {
+	if queues.Has(queue) {
+		return fmt.Sprintf("it waits in queue %s again once %s is running", queue, preemptor)
+	}
+	return "it stays suspended until spec.runPolicy.suspend is set to false"
+}
+
+// resumePreemptedJob resumes the job suspended for a job of higher priority once the latter is
+// running, finished, suspended or deleted, if the job is in a queue of the operator: the resumed job
+// waits in its queue for its resources again. The other jobs stay suspended until their owners
+// resume them, since nothing would keep them from taking back the resources of the job of higher
+// priority. The job is requeued while the job of higher priority is not running yet.
+func (jc *JobController) resumePreemptedJob(metaObject metav1.Object, runPolicy *apiv1.RunPolicy) error {
+	preemptor, ok := metaObject.GetAnnotations()[apiv1.PriorityPreemptedByAnnotation]
+	if !ok || jc.PriorityPreemptionClient == nil || jc.JobRegistry == nil {
+		return nil
+	}
+	if runPolicy.SchedulingPolicy == nil || !jc.JobQueues.Has(runPolicy.SchedulingPolicy.Queue) {
+		return nil
+	}
+	kind, key, _ := strings.Cut(preemptor, " ")
+	if namespace, name, err := cache.SplitMetaNamespaceKey(key); err == nil {
+		if info, ok := jc.JobRegistry.Get(kind, namespace, name); ok && !info.Suspended &&
+			info.Phase != apiv1.JobRunning && info.Phase != apiv1.JobSucceeded && info.Phase != apiv1.JobFailed {
+			if jobKey, err := KeyFunc(metaObject); err == nil {
+				jc.WorkQueue.AddAfter(jobKey, jobQueueRequeuePeriod)
+			}
+			return nil
+		}
+	}
+	commonutil.LoggerForJob(metaObject).Info("Resuming the job suspended for a job of higher priority", "preemptor", preemptor)
+	return jc.patchPriorityPreempted(metaObject, `"spec":{"runPolicy":{"suspend":false}},`)
+}
+
+// forgetPriorityPreemption removes the PriorityPreemptedByAnnotation of the job once it is resumed,
+// e.g. by its owner, so that it is not resumed by the operator if it is suspended again.
+func (jc *JobController) forgetPriorityPreemption(metaObject metav1.Object) error {
+	if _, ok := metaObject.GetAnnotations()[apiv1.PriorityPreemptedByAnnotation]; !ok || jc.PriorityPreemptionClient == nil {
+		return nil
+	}
+	return jc.patchPriorityPreempted(metaObject, "")
+}
+
+// patchPriorityPreempted removes the PriorityPreemptedByAnnotation of the job with a merge patch,
+// along with the fields of the spec to patch.
+func (jc *JobController) patchPriorityPreempted(metaObject metav1.Object, spec string) error {
+	job := &unstructured.Unstructured{}
+	job.SetGroupVersionKind(jc.Controller.GetAPIGroupVersionKind())
+	job.SetNamespace(metaObject.GetNamespace())
+	job.SetName(metaObject.GetName())
+	patch := fmt.Sprintf(`{%s"metadata":{"uid":%q,"annotations":{%q:null}}}`, spec, metaObject.GetUID(), apiv1.PriorityPreemptedByAnnotation)
+	jc.RecordAPICall(metaObject, APICallUpdate)
+	return jc.PriorityPreemptionClient.Patch(context.Background(), job, client.RawPatch(types.MergePatchType, []byte(patch)))
+}
@@ -0,0 +1,359 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	schedulinglisters "k8s.io/client-go/listers/scheduling/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	clocktesting "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	"github.com/kubeflow/training-operator/pkg/controller.v1/registry"
+	commonutil "github.com/kubeflow/training-operator/pkg/util"
+)
+
+func newPriorityPreemptionJob(namespace, name, priorityClass, cpu string, created time.Time, condition apiv1.JobConditionType) *apiv1.PyTorchJob {
+	return &apiv1.PyTorchJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         namespace,
+			Name:              name,
+			UID:               types.UID(name + "-uid"),
+			CreationTimestamp: metav1.NewTime(created),
+		},
+		Spec: apiv1.PyTorchJobSpec{
+			RunPolicy: apiv1.RunPolicy{
+				SchedulingPolicy: &apiv1.SchedulingPolicy{PriorityClass: priorityClass},
+			},
+			PyTorchReplicaSpecs: map[apiv1.ReplicaType]*apiv1.ReplicaSpec{
+				apiv1.PyTorchJobReplicaTypeMaster: {
+					Replicas: ptr.To[int32](1),
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{{
+								Name: "pytorch",
+								Resources: corev1.ResourceRequirements{
+									Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)},
+								},
+							}},
+						},
+					},
+				},
+			},
+		},
+		Status: apiv1.JobStatus{
+			Conditions: []apiv1.JobCondition{{Type: condition, Status: corev1.ConditionTrue}},
+			ReplicaStatuses: map[apiv1.ReplicaType]*apiv1.ReplicaStatus{
+				apiv1.PyTorchJobReplicaTypeMaster: {Active: 1},
+			},
+		},
+	}
+}
+
+func TestPreemptLowerPriorityJobs(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := apiv1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for name, value := range map[string]int32{"high": 1000, "low": 10} {
+		if err := indexer.Add(&schedulingv1.PriorityClass{ObjectMeta: metav1.ObjectMeta{Name: name}, Value: value}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	now := time.Now()
+	job := newPriorityPreemptionJob(metav1.NamespaceDefault, "urgent", "high", "4", now, apiv1.JobQueued)
+	jobs := []*apiv1.PyTorchJob{
+		job,
+		newPriorityPreemptionJob(metav1.NamespaceDefault, "low-old", "low", "4", now.Add(-2*time.Hour), apiv1.JobRunning),
+		newPriorityPreemptionJob(metav1.NamespaceDefault, "low-new", "low", "4", now.Add(-time.Hour), apiv1.JobRunning),
+		newPriorityPreemptionJob(metav1.NamespaceDefault, "same-priority", "high", "8", now.Add(-time.Hour), apiv1.JobRunning),
+		newPriorityPreemptionJob(metav1.NamespaceDefault, "low-created", "low", "8", now.Add(-time.Hour), apiv1.JobCreated),
+		newPriorityPreemptionJob("other", "other-namespace", "low", "8", now.Add(-time.Hour), apiv1.JobRunning),
+	}
+	builder := fake.NewClientBuilder().WithScheme(scheme)
+	jobRegistry := registry.New()
+	for _, j := range jobs {
+		builder = builder.WithObjects(j)
+		jobRegistry.OnAdd(j)
+	}
+	c := builder.Build()
+	recorder := record.NewFakeRecorder(10)
+	jc := &JobController{
+		Controller:           &pytorchJobController{frameworkController{framework: "pytorch"}},
+		JobRegistry:          jobRegistry,
+		PriorityClassLister:  schedulinglisters.NewPriorityClassLister(indexer),
+		Recorder:             recorder,
+		JobControllerOptions: JobControllerOptions{PriorityPreemptionClient: c},
+	}
+	suspended := func() []string {
+		var result []string
+		for _, j := range jobs {
+			got := &apiv1.PyTorchJob{}
+			if err := c.Get(context.Background(), client.ObjectKeyFromObject(j), got); err != nil {
+				t.Fatal(err)
+			}
+			if got.Spec.RunPolicy.Suspend != nil && *got.Spec.RunPolicy.Suspend {
+				result = append(result, got.Name)
+				// The registry is updated by the informers from the suspended jobs.
+				jobRegistry.OnAdd(got)
+			}
+		}
+		return result
+	}
+
+	// The most recent job of the lowest priority is enough to free the resources of the job.
+	if err := jc.preemptLowerPriorityJobs(job, job, job.Status); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if diff := cmp.Diff([]string{"low-new"}, suspended()); len(diff) != 0 {
+		t.Errorf("Unexpected suspended jobs (-want,+got):\n%s", diff)
+	}
+	victim := &apiv1.PyTorchJob{}
+	if err := c.Get(context.Background(), client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: "low-new"}, victim); err != nil {
+		t.Fatal(err)
+	}
+	if got := victim.Annotations[apiv1.PriorityPreemptedByAnnotation]; got != "PyTorchJob default/urgent" {
+		t.Errorf("Unexpected %s annotation, want: %q, got: %q", apiv1.PriorityPreemptedByAnnotation, "PyTorchJob default/urgent", got)
+	}
+	wantReasons := []string{commonutil.JobPriorityPreemptedReason, commonutil.PreemptedLowerPriorityJobsReason}
+	for _, reason := range wantReasons {
+		select {
+		case event := <-recorder.Events:
+			if !strings.Contains(event, reason) {
+				t.Errorf("Expected an event with the %s reason, got: %s", reason, event)
+			}
+		default:
+			t.Errorf("Expected an event with the %s reason", reason)
+		}
+	}
+
+	// No more job is suspended while the pods of the suspended job still hold the resources.
+	if err := jc.preemptLowerPriorityJobs(job, job, job.Status); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if diff := cmp.Diff([]string{"low-new"}, suspended()); len(diff) != 0 {
+		t.Errorf("Unexpected suspended jobs (-want,+got):\n%s", diff)
+	}
+
+	// No job is suspended if the jobs of lower priority can't free enough resources.
+	big := newPriorityPreemptionJob(metav1.NamespaceDefault, "big", "high", "64", now, apiv1.JobScheduling)
+	jobRegistry.OnAdd(big)
+	if err := jc.preemptLowerPriorityJobs(big, big, big.Status); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if diff := cmp.Diff([]string{"low-new"}, suspended()); len(diff) != 0 {
+		t.Errorf("Unexpected suspended jobs (-want,+got):\n%s", diff)
+	}
+
+	// The jobs which are not waiting to be scheduled don't preempt any job.
+	running := newPriorityPreemptionJob(metav1.NamespaceDefault, "running", "high", "4", now, apiv1.JobRunning)
+	jobRegistry.OnAdd(running)
+	if err := jc.preemptLowerPriorityJobs(running, running, running.Status); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if diff := cmp.Diff([]string{"low-new"}, suspended()); len(diff) != 0 {
+		t.Errorf("Unexpected suspended jobs (-want,+got):\n%s", diff)
+	}
+	select {
+	case event := <-recorder.Events:
+		t.Errorf("Unexpected event: %s", event)
+	default:
+	}
+}
+
+func TestPreemptLowerPriorityJobsOnConsecutiveReconciles(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := apiv1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for name, value := range map[string]int32{"high": 1000, "low": 10} {
+		if err := indexer.Add(&schedulingv1.PriorityClass{ObjectMeta: metav1.ObjectMeta{Name: name}, Value: value}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The time of the preemption is recorded to the second.
+	now := time.Now().Truncate(time.Second)
+	job := newPriorityPreemptionJob(metav1.NamespaceDefault, "urgent", "high", "4", now, apiv1.JobQueued)
+	jobs := []*apiv1.PyTorchJob{
+		job,
+		newPriorityPreemptionJob(metav1.NamespaceDefault, "low-old", "low", "4", now.Add(-2*time.Hour), apiv1.JobRunning),
+		newPriorityPreemptionJob(metav1.NamespaceDefault, "low-new", "low", "4", now.Add(-time.Hour), apiv1.JobRunning),
+	}
+	builder := fake.NewClientBuilder().WithScheme(scheme)
+	jobRegistry := registry.New()
+	for _, j := range jobs {
+		builder = builder.WithObjects(j)
+		jobRegistry.OnAdd(j)
+	}
+	c := builder.Build()
+	fakeClock := clocktesting.NewFakeClock(now)
+	queue := &addAfterQueue{delays: map[string]time.Duration{}}
+	jc := &JobController{
+		Controller:           &pytorchJobController{frameworkController{framework: "pytorch"}},
+		JobRegistry:          jobRegistry,
+		PriorityClassLister:  schedulinglisters.NewPriorityClassLister(indexer),
+		Recorder:             record.NewFakeRecorder(10),
+		WorkQueue:            queue,
+		Clock:                commonutil.NewClock(fakeClock, 0),
+		JobControllerOptions: JobControllerOptions{PriorityPreemptionClient: c},
+	}
+	get := func(name string) *apiv1.PyTorchJob {
+		got := &apiv1.PyTorchJob{}
+		if err := c.Get(context.Background(), client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: name}, got); err != nil {
+			t.Fatal(err)
+		}
+		return got
+	}
+	suspended := func() []string {
+		var result []string
+		for _, j := range jobs {
+			if got := get(j.Name); ptr.Deref(got.Spec.RunPolicy.Suspend, false) {
+				result = append(result, got.Name)
+			}
+		}
+		return result
+	}
+	// reconcile preempts the jobs for the job as it is stored, with the annotations of the previous preemptions.
+	reconcile := func() {
+		t.Helper()
+		clear(queue.delays)
+		preemptor := get(job.Name)
+		if err := jc.preemptLowerPriorityJobs(preemptor, preemptor, preemptor.Status); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	// The first reconcile suspends a job and records the preemption on the job.
+	reconcile()
+	if diff := cmp.Diff([]string{"low-new"}, suspended()); len(diff) != 0 {
+		t.Errorf("Unexpected suspended jobs (-want,+got):\n%s", diff)
+	}
+	wantAnnotations := map[string]string{
+		apiv1.PriorityPreemptedJobsAnnotation:  "PyTorchJob default/low-new",
+		apiv1.PriorityPreemptionTimeAnnotation: now.UTC().Format(time.RFC3339),
+	}
+	if diff := cmp.Diff(wantAnnotations, get(job.Name).Annotations); len(diff) != 0 {
+		t.Errorf("Unexpected annotations of the job (-want,+got):\n%s", diff)
+	}
+	// The registry is updated by the informers from the suspended job, whose pods are gone.
+	victim := get("low-new")
+	victim.Status.ReplicaStatuses[apiv1.PyTorchJobReplicaTypeMaster].Active = 0
+	jobRegistry.OnAdd(victim)
+
+	// The next reconcile doesn't suspend another job within the grace period, even though the pods
+	// of the suspended job are gone, and requeues the job for the end of the grace period.
+	fakeClock.Step(10 * time.Second)
+	reconcile()
+	if diff := cmp.Diff([]string{"low-new"}, suspended()); len(diff) != 0 {
+		t.Errorf("Unexpected suspended jobs within the grace period (-want,+got):\n%s", diff)
+	}
+	if diff := cmp.Diff(map[string]time.Duration{"default/urgent": priorityPreemptionGracePeriod - 10*time.Second}, queue.delays); len(diff) != 0 {
+		t.Errorf("Unexpected requeues (-want,+got):\n%s", diff)
+	}
+
+	// Another job is suspended once the grace period has passed.
+	fakeClock.Step(priorityPreemptionGracePeriod)
+	reconcile()
+	if diff := cmp.Diff([]string{"low-old", "low-new"}, suspended()); len(diff) != 0 {
+		t.Errorf("Unexpected suspended jobs after the grace period (-want,+got):\n%s", diff)
+	}
+}
+
+func TestResumePreemptedJob(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := apiv1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	cases := map[string]struct {
+		queue          string
+		preemptorPhase apiv1.JobConditionType
+		wantResumed    bool
+	}{
+		"job of a queue of the operator, the job of higher priority is running": {
+			queue:          "research",
+			preemptorPhase: apiv1.JobRunning,
+			wantResumed:    true,
+		},
+		"job of a queue of the operator, the job of higher priority is not scheduled yet": {
+			queue:          "research",
+			preemptorPhase: apiv1.JobQueued,
+		},
+		"job of a queue of the operator, the job of higher priority was deleted": {
+			queue:       "research",
+			wantResumed: true,
+		},
+		"job without queue": {
+			preemptorPhase: apiv1.JobRunning,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			job := newPriorityPreemptionJob(metav1.NamespaceDefault, "low", "low", "4", now.Add(-time.Hour), apiv1.JobSuspended)
+			job.Spec.RunPolicy.Suspend = ptr.To(true)
+			job.Spec.RunPolicy.SchedulingPolicy.Queue = tc.queue
+			job.Annotations = map[string]string{apiv1.PriorityPreemptedByAnnotation: "PyTorchJob default/urgent"}
+			jobRegistry := registry.New()
+			if tc.preemptorPhase != "" {
+				jobRegistry.OnAdd(newPriorityPreemptionJob(metav1.NamespaceDefault, "urgent", "high", "4", now, tc.preemptorPhase))
+			}
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(job).Build()
+			jc := &JobController{
+				Controller:  &pytorchJobController{frameworkController{framework: "pytorch"}},
+				JobRegistry: jobRegistry,
+				WorkQueue:   workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+				JobControllerOptions: JobControllerOptions{
+					PriorityPreemptionClient: c,
+					JobQueues:                NewJobQueues(map[string]JobQueueConfig{"research": {}}),
+				},
+			}
+			defer jc.WorkQueue.ShutDown()
+
+			if err := jc.resumePreemptedJob(job, &job.Spec.RunPolicy); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			got := &apiv1.PyTorchJob{}
+			if err := c.Get(context.Background(), client.ObjectKeyFromObject(job), got); err != nil {
+				t.Fatal(err)
+			}
+			if resumed := !ptr.Deref(got.Spec.RunPolicy.Suspend, false); resumed != tc.wantResumed {
+				t.Errorf("Unexpected resumed, want: %t, got: %t", tc.wantResumed, resumed)
+			}
+			if _, ok := got.Annotations[apiv1.PriorityPreemptedByAnnotation]; ok == tc.wantResumed {
+				t.Errorf("Unexpected annotations of the job: %v", got.Annotations)
+			}
+		})
+	}
+}
@@ -0,0 +1,300 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	"github.com/kubeflow/training-operator/pkg/common"
+	"github.com/kubeflow/training-operator/pkg/core"
+	"github.com/kubeflow/training-operator/pkg/features"
+	commonutil "github.com/kubeflow/training-operator/pkg/util"
+)
+
+const (
+	resourceQuotaKind = "ResourceQuota"
+	// resourceCountPods is the object count quota of the pods.
+	resourceCountPods corev1.ResourceName = "count/pods"
+
+	// quotaBlockedMinRequeuePeriod and quotaBlockedMaxRequeuePeriod bound the backoff of the
+	// retries of the creation of the pods of a QuotaBlocked job.
+	quotaBlockedMinRequeuePeriod = 10 * time.Second
+	quotaBlockedMaxRequeuePeriod = 5 * time.Minute
+)
+
+// QuotaExceededError is returned when a pod of a job cannot be created because it would
+// exceed a ResourceQuota of the namespace, and the QuotaBlockedRetry feature is enabled.
+type QuotaExceededError struct {
+	PodName string
+	Err     error
+}
+
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf("pod %s exceeds a resource quota of the namespace: %v", e.PodName, e.Err)
+}
+
+func (e *QuotaExceededError) Unwrap() error {
+	return e.Err
+}
+
+// QuotaExceeded returns a QuotaExceededError if err rejects the creation of the pod because
+// it would exceed a ResourceQuota of the namespace and the QuotaBlockedRetry feature is
+// enabled, or nil otherwise.
+func QuotaExceeded(podName string, err error) *QuotaExceededError {
+	if !features.Enabled(features.QuotaBlockedRetry) || !errors.IsForbidden(err) ||
+		!strings.Contains(err.Error(), "exceeded quota") {
+		return nil
+	}
+	return &QuotaExceededError{PodName: podName, Err: err}
+}
+
+// updateQuotaBlockedCondition sets the QuotaBlocked condition while the creation of some pods
+// of the job is rejected by a ResourceQuota of the namespace, and removes it once the pods are
+// created. Meanwhile, the job is requeued with a backoff, besides the changes of the
+// ResourceQuotas observed by WatchResourceQuotas.
+func (jc *JobController) updateQuotaBlockedCondition(metaObject metav1.Object, runtimeObject runtime.Object, jobStatus *apiv1.JobStatus, quotaExceeded *QuotaExceededError) {
+	if quotaExceeded == nil {
+		removeCondition(jobStatus, apiv1.JobQuotaBlocked)
+		return
+	}
+	jobKind := jc.Controller.GetAPIGroupVersionKind().Kind
+	msg := fmt.Sprintf("%s %s is blocked by the resource quotas of its namespace, the creation of its pods is retried. %v",
+		jobKind, metaObject.GetName(), quotaExceeded.Err)
+	reason := commonutil.NewReason(jobKind, commonutil.JobQuotaExceededReason)
+	if jc.setTransientCondition(jobStatus, apiv1.JobQuotaBlocked, reason, msg) {
+		jc.Recorder.Event(runtimeObject, corev1.EventTypeWarning, reason, msg)
+	}
+	blocked := time.Duration(0)
+	if condition := findCondition(jobStatus, apiv1.JobQuotaBlocked); condition != nil {
+		blocked = jc.Clock.Since(condition.LastTransitionTime.Time)
+	}
+	if key, err := KeyFunc(metaObject); err == nil {
+		jc.WorkQueue.AddAfter(key, quotaBlockedRequeuePeriod(blocked))
+	}
+}
+
+// quotaBlockedRequeuePeriod returns the period after which the creation of the pods of a job
+// blocked for the given duration is retried. Since the job is retried after waiting as long as
+// it has been blocked, the period doubles with every retry.
+func quotaBlockedRequeuePeriod(blocked time.Duration) time.Duration {
+	return min(max(blocked, quotaBlockedMinRequeuePeriod), quotaBlockedMaxRequeuePeriod)
+}
+
+// missingPodsRequests returns the aggregate requests of the pods of the replicas which are not
+// created yet, and their number.
+func (jc *JobController) missingPodsRequests(job interface{}, replicas map[apiv1.ReplicaType]*apiv1.ReplicaSpec, pods []*corev1.Pod) (corev1.ResourceList, int64, error) {
+	requests := corev1.ResourceList{}
+	missing := int64(0)
+	for rtype, spec := range replicas {
+		if spec == nil || spec.Replicas == nil {
+			continue
+		}
+		rPods, err := core.FilterPodsForReplicaType(pods, strings.ToLower(string(rtype)))
+		if err != nil {
+			return nil, 0, err
+		}
+		count := int64(*spec.Replicas) - int64(len(rPods))
+		if count <= 0 {
+			continue
+		}
+		podRequests := PodRequests(jc.replicaPodSpec(job, rtype, spec, replicas))
+		for i := int64(0); i < count; i++ {
+			addResources(requests, podRequests)
+		}
+		missing += count
+	}
+	return requests, missing, nil
+}
+
+// replicaPodSpec returns the spec of the pods of the replica type of the job as they are created:
+// the one built by the controller when it builds the pods itself, or else the template of the
+// replica with the resource profile, the datasets, the metrics collector of the master role and
+// the init containers injected by the controller. The template of the replica is returned as is
+// when the pods cannot be built, e.g. with an unknown resource profile, which fails the creation
+// of the pods and is reported then.
+func (jc *JobController) replicaPodSpec(job interface{}, rtype apiv1.ReplicaType, spec *apiv1.ReplicaSpec,
+	replicas map[apiv1.ReplicaType]*apiv1.ReplicaSpec) *corev1.PodSpec {
+	if builder, ok := jc.Controller.(common.PodSpecBuilder); ok {
+		if podSpec, err := builder.BuildPodSpec(job, rtype); err == nil && podSpec != nil {
+			return podSpec
+		}
+		return &spec.Template.Spec
+	}
+	podTemplate := spec.Template.DeepCopy()
+	containerName := jc.Controller.GetDefaultContainerName()
+	if err := core.SetResourceProfile(podTemplate, containerName, spec.ResourceProfile); err != nil {
+		return &spec.Template.Spec
+	}
+	SetDatasets(podTemplate, containerName, jc.datasetsSpec(job))
+	// The metrics collector is only injected when it is valid, the invalid ones are reported when
+	// the pod of the master role is created.
+	if metaObject, ok := job.(metav1.Object); ok {
+		if collector, _ := metricsCollectorOf(metaObject); collector != nil && jc.Controller.IsMasterRole(replicas, rtype, 0) {
+			runtimeObject, _ := job.(runtime.Object)
+			jc.SetMetricsCollector(podTemplate, containerName, runtimeObject, metaObject)
+		}
+	}
+	if injector, ok := jc.Controller.(common.InitContainerInjector); ok {
+		podTemplate.Spec.InitContainers = append(podTemplate.Spec.InitContainers, injector.InjectedInitContainers(job, rtype)...)
+	}
+	return &podTemplate.Spec
+}
+
+// quotaRequest returns the amount of the resource of a ResourceQuota requested by pods whose
+// aggregate requests are given, and whether the resource is checked: the count of the pods and
+// their requests are, but their limits are left to the admission of the pods.
+func quotaRequest(name corev1.ResourceName, requests corev1.ResourceList, pods int64) (resource.Quantity, bool) {
+	switch {
+	case name == corev1.ResourcePods || name == resourceCountPods:
+		return *resource.NewQuantity(pods, resource.DecimalSI), true
+	case name == corev1.ResourceCPU || name == corev1.ResourceMemory || name == corev1.ResourceEphemeralStorage:
+		return requests[name], true
+	case strings.HasPrefix(string(name), corev1.DefaultResourceRequestsPrefix):
+		return requests[corev1.ResourceName(strings.TrimPrefix(string(name), corev1.DefaultResourceRequestsPrefix))], true
+	}
+	return resource.Quantity{}, false
+}
+
+// exceededQuotas returns the descriptions of the resources of the ResourceQuotas of the namespace
+// of the job which the pods whose aggregate requests are given would exceed, sorted. The scoped
+// ResourceQuotas, which only apply to some of the pods, are not checked.
+func (jc *JobController) exceededQuotas(job metav1.Object, requests corev1.ResourceList, pods int64) ([]string, error) {
+	jc.RecordAPICall(job, APICallList)
+	quotas, err := jc.KubeClientSet.CoreV1().ResourceQuotas(job.GetNamespace()).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	var exceeded []string
+	for _, quota := range quotas.Items {
+		if len(quota.Spec.Scopes) != 0 || quota.Spec.ScopeSelector != nil {
+			continue
+		}
+		for name, hard := range quota.Status.Hard {
+			requested, ok := quotaRequest(name, requests, pods)
+			if !ok || requested.IsZero() {
+				continue
+			}
+			used := quota.Status.Used[name]
+			total := used.DeepCopy()
+			total.Add(requested)
+			if total.Cmp(hard) > 0 {
+				exceeded = append(exceeded, fmt.Sprintf("%s requested %s=%s, used %s, limited %s",
+					quota.Name, name, requested.String(), used.String(), hard.String()))
+			}
+		}
+	}
+	sort.Strings(exceeded)
+	return exceeded, nil
+}
+
+// reconcileQuotas holds the creation of the missing pods of a job while their aggregate requests
+// don't fit in the ResourceQuotas of the namespace, since the API server would otherwise admit
+// some pods of the gang and reject the others, leaving a partial gang holding the resources.
+// Meanwhile, the job is QuotaExceeded and is requeued with a backoff, besides the changes of the
+// ResourceQuotas observed by WatchResourceQuotas. It returns true while the pods must not be
+// created, and nothing is checked unless the QuotaPreCheck feature is enabled.
+func (jc *JobController) reconcileQuotas(job interface{}, metaObject metav1.Object, runtimeObject runtime.Object,
+	replicas map[apiv1.ReplicaType]*apiv1.ReplicaSpec, pods []*corev1.Pod, jobStatus *apiv1.JobStatus) (bool, error) {
+	if !features.Enabled(features.QuotaPreCheck) || jc.KubeClientSet == nil {
+		return false, nil
+	}
+	requests, missing, err := jc.missingPodsRequests(job, replicas, pods)
+	if err != nil {
+		return false, err
+	}
+	var exceeded []string
+	if missing > 0 {
+		if exceeded, err = jc.exceededQuotas(metaObject, requests, missing); err != nil {
+			return false, err
+		}
+	}
+	jobKind := jc.Controller.GetAPIGroupVersionKind().Kind
+	if len(exceeded) == 0 {
+		if commonutil.IsQuotaExceeded(*jobStatus) {
+			msg := fmt.Sprintf("The pods of %s %s fit in the resource quotas of its namespace, creating its pods.", jobKind, metaObject.GetName())
+			reason := commonutil.NewReason(jobKind, commonutil.JobQuotaAvailableReason)
+			jc.Recorder.Event(runtimeObject, corev1.EventTypeNormal, reason, msg)
+			commonutil.UpdateJobConditions(jobStatus, apiv1.JobQuotaExceeded, corev1.ConditionFalse, reason, msg, jc.Clock)
+		}
+		return false, nil
+	}
+
+	msg := fmt.Sprintf("The %d missing pods of %s %s exceed the resource quotas of its namespace: %s.",
+		missing, jobKind, metaObject.GetName(), strings.Join(exceeded, "; "))
+	if condition := findCondition(jobStatus, apiv1.JobQuotaExceeded); condition == nil ||
+		condition.Status != corev1.ConditionTrue || condition.Message != msg {
+		reason := commonutil.NewReason(jobKind, commonutil.JobQuotaExceededReason)
+		jc.Recorder.Event(runtimeObject, corev1.EventTypeWarning, reason, msg)
+		commonutil.UpdateJobConditions(jobStatus, apiv1.JobQuotaExceeded, corev1.ConditionTrue, reason, msg, jc.Clock)
+	}
+	blocked := jc.Clock.Since(findCondition(jobStatus, apiv1.JobQuotaExceeded).LastTransitionTime.Time)
+	if key, err := KeyFunc(metaObject); err == nil {
+		jc.WorkQueue.AddAfter(key, quotaBlockedRequeuePeriod(blocked))
+	}
+	return true, nil
+}
+
+// WatchResourceQuotas requeues the jobs of the controller c which are QuotaBlocked or
+// QuotaExceeded when a ResourceQuota of their namespace is created or updated, e.g. when its
+// usage decreases. Only the metadata of the ResourceQuotas is cached, and nothing is watched
+// unless the QuotaBlockedRetry or the QuotaPreCheck feature is enabled.
+func (jc *JobController) WatchResourceQuotas(mgr manager.Manager, c controller.Controller) error {
+	if !features.Enabled(features.QuotaBlockedRetry) && !features.Enabled(features.QuotaPreCheck) {
+		return nil
+	}
+	noDelete := predicate.TypedFuncs[*metav1.PartialObjectMetadata]{
+		DeleteFunc:  func(event.TypedDeleteEvent[*metav1.PartialObjectMetadata]) bool { return false },
+		GenericFunc: func(event.TypedGenericEvent[*metav1.PartialObjectMetadata]) bool { return false },
+	}
+	obj := &metav1.PartialObjectMetadata{}
+	obj.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind(resourceQuotaKind))
+	return c.Watch(source.Kind[*metav1.PartialObjectMetadata](mgr.GetCache(), obj,
+		handler.TypedEnqueueRequestsFromMapFunc(jc.jobsBlockedByQuota), noDelete))
+}
+
+// jobsBlockedByQuota returns the requests of the jobs of the controller in the namespace of
+// obj whose latest condition is QuotaBlocked or QuotaExceeded.
+func (jc *JobController) jobsBlockedByQuota(_ context.Context, obj *metav1.PartialObjectMetadata) []reconcile.Request {
+	if jc.JobRegistry == nil {
+		return nil
+	}
+	jobKind := jc.Controller.GetAPIGroupVersionKind().Kind
+	var requests []reconcile.Request
+	for _, job := range jc.JobRegistry.ListByNamespace(obj.GetNamespace()) {
+		if job.Kind == jobKind && (job.Phase == apiv1.JobQuotaBlocked || job.Phase == apiv1.JobQuotaExceeded) {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: job.Namespace, Name: job.Name}})
+		}
+	}
+	return requests
+}
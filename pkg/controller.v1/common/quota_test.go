@@ -0,0 +1,247 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/ptr"
+
+	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	"github.com/kubeflow/training-operator/pkg/config"
+	"github.com/kubeflow/training-operator/pkg/features"
+	commonutil "github.com/kubeflow/training-operator/pkg/util"
+	testjobv1 "github.com/kubeflow/training-operator/test_job/apis/test_job/v1"
+)
+
+func TestQuotaExceeded(t *testing.T) {
+	quotaErr := apierrors.NewForbidden(corev1.Resource("pods"), "test-worker-0",
+		errors.New("exceeded quota: compute, requested: cpu=4, used: cpu=8, limited: cpu=10"))
+	otherErr := apierrors.NewForbidden(corev1.Resource("pods"), "test-worker-0", errors.New("no service account"))
+
+	if got := QuotaExceeded("test-worker-0", quotaErr); got != nil {
+		t.Errorf("Unexpected QuotaExceededError with the QuotaBlockedRetry feature disabled: %v", got)
+	}
+	if err := features.Default.Set("QuotaBlockedRetry=true"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	t.Cleanup(func() { _ = features.Default.Set("QuotaBlockedRetry=false") })
+	if got := QuotaExceeded("test-worker-0", quotaErr); got == nil || !errors.Is(got, quotaErr) {
+		t.Errorf("Expected a QuotaExceededError wrapping the error, got: %v", got)
+	}
+	if got := QuotaExceeded("test-worker-0", otherErr); got != nil {
+		t.Errorf("Unexpected QuotaExceededError: %v", got)
+	}
+}
+
+func TestUpdateQuotaBlockedCondition(t *testing.T) {
+	job := &testjobv1.TestJob{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: metav1.NamespaceDefault}}
+	recorder := record.NewFakeRecorder(10)
+	jc := &JobController{
+		Controller: &testJobController{frameworkController{framework: "test-framework"}},
+		Recorder:   recorder,
+		WorkQueue:  workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+	}
+	jobStatus := &apiv1.JobStatus{}
+	commonutil.UpdateJobConditions(jobStatus, apiv1.JobCreated, corev1.ConditionTrue, "", "", nil)
+	quotaExceeded := &QuotaExceededError{PodName: "test-worker-0", Err: errors.New("exceeded quota: compute")}
+
+	for i := 0; i < 2; i++ {
+		jc.updateQuotaBlockedCondition(job, job, jobStatus, quotaExceeded)
+	}
+	if !commonutil.IsQuotaBlocked(*jobStatus) {
+		t.Fatalf("Expected a QuotaBlocked condition, got: %v", jobStatus.Conditions)
+	}
+	if want := "TestJobQuotaExceeded"; findCondition(jobStatus, apiv1.JobQuotaBlocked).Reason != want {
+		t.Errorf("Unexpected reason, want: %q, got: %q", want, findCondition(jobStatus, apiv1.JobQuotaBlocked).Reason)
+	}
+	// The job is reported once while it is blocked.
+	if got := len(recorder.Events); got != 1 {
+		t.Errorf("Unexpected number of events, want: 1, got: %d", got)
+	}
+
+	jc.updateQuotaBlockedCondition(job, job, jobStatus, nil)
+	if findCondition(jobStatus, apiv1.JobQuotaBlocked) != nil || len(jobStatus.Conditions) != 1 {
+		t.Errorf("Expected the QuotaBlocked condition to be removed, got: %v", jobStatus.Conditions)
+	}
+}
+
+func TestQuotaBlockedRequeuePeriod(t *testing.T) {
+	cases := []struct {
+		blocked time.Duration
+		want    time.Duration
+	}{
+		{blocked: 0, want: quotaBlockedMinRequeuePeriod},
+		{blocked: 40 * time.Second, want: 40 * time.Second},
+		{blocked: time.Hour, want: quotaBlockedMaxRequeuePeriod},
+	}
+	for _, tc := range cases {
+		if got := quotaBlockedRequeuePeriod(tc.blocked); got != tc.want {
+			t.Errorf("Unexpected requeue period after %v, want: %v, got: %v", tc.blocked, tc.want, got)
+		}
+	}
+}
+
+func TestReconcileQuotas(t *testing.T) {
+	if err := features.Default.Set("QuotaPreCheck=true"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	t.Cleanup(func() { _ = features.Default.Set("QuotaPreCheck=false") })
+
+	job := &testjobv1.TestJob{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: metav1.NamespaceDefault}}
+	replicas := map[apiv1.ReplicaType]*apiv1.ReplicaSpec{
+		"Worker": {Replicas: ptr.To[int32](3), Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "test", Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+			}}},
+		}}},
+	}
+	// The running worker is already counted in the usage of the quota.
+	pods := []*corev1.Pod{newPod("test-worker-0", corev1.PodRunning)}
+	pods[0].Labels = map[string]string{apiv1.ReplicaTypeLabel: "worker"}
+	quota := &corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "compute", Namespace: metav1.NamespaceDefault},
+		Status: corev1.ResourceQuotaStatus{
+			Hard: corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse("10"), corev1.ResourcePods: resource.MustParse("10")},
+			Used: corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse("8"), corev1.ResourcePods: resource.MustParse("1")},
+		},
+	}
+	scoped := &corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "best-effort", Namespace: metav1.NamespaceDefault},
+		Spec:       corev1.ResourceQuotaSpec{Scopes: []corev1.ResourceQuotaScope{corev1.ResourceQuotaScopeBestEffort}},
+		Status: corev1.ResourceQuotaStatus{
+			Hard: corev1.ResourceList{corev1.ResourcePods: resource.MustParse("0")},
+		},
+	}
+	recorder := record.NewFakeRecorder(10)
+	jc := &JobController{
+		Controller:    &testJobController{frameworkController{framework: "test-framework"}},
+		KubeClientSet: kubefake.NewSimpleClientset(quota, scoped),
+		WorkQueue:     workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+		Recorder:      recorder,
+	}
+	jobStatus := &apiv1.JobStatus{}
+
+	for i := 0; i < 2; i++ {
+		if exceeded, err := jc.reconcileQuotas(job, job, job, replicas, pods, jobStatus); !exceeded || err != nil {
+			t.Fatalf("Unexpected result while the pods exceed the quota: %v, %v", exceeded, err)
+		}
+	}
+	if !commonutil.IsQuotaExceeded(*jobStatus) {
+		t.Fatalf("Expected the job to be QuotaExceeded")
+	}
+	want := "The 2 missing pods of TestJob test exceed the resource quotas of its namespace: compute requested requests.cpu=4, used 8, limited 10."
+	if got := findCondition(jobStatus, apiv1.JobQuotaExceeded).Message; got != want {
+		t.Errorf("Unexpected message, want: %q, got: %q", want, got)
+	}
+	// The job is reported once while its quotas are unchanged.
+	if got := len(recorder.Events); got != 1 {
+		t.Errorf("Unexpected number of events, want: 1, got: %d", got)
+	}
+
+	quota.Status.Used[corev1.ResourceRequestsCPU] = resource.MustParse("6")
+	if _, err := jc.KubeClientSet.CoreV1().ResourceQuotas(metav1.NamespaceDefault).Update(context.Background(), quota, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if exceeded, err := jc.reconcileQuotas(job, job, job, replicas, pods, jobStatus); exceeded || err != nil {
+		t.Fatalf("Unexpected result once the pods fit in the quota: %v, %v", exceeded, err)
+	}
+	condition := findCondition(jobStatus, apiv1.JobQuotaExceeded)
+	if condition == nil || condition.Status != corev1.ConditionFalse || condition.Reason != "TestJobQuotaAvailable" {
+		t.Errorf("Expected the QuotaExceeded condition to be false, got: %v", condition)
+	}
+}
+
+// initContainerController is a testJobController injecting an init container in the pods of its jobs.
+type initContainerController struct {
+	testJobController
+	initContainer corev1.Container
+}
+
+func (c *initContainerController) InjectedInitContainers(interface{}, apiv1.ReplicaType) []corev1.Container {
+	return []corev1.Container{c.initContainer}
+}
+
+func TestMissingPodsRequests(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "profiles.yaml")
+	if err := os.WriteFile(file, []byte("large:\n  requests:\n    cpu: \"4\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	defer func(file string) { config.Config.ResourceProfilesFile = file }(config.Config.ResourceProfilesFile)
+	config.Config.ResourceProfilesFile = file
+
+	job := &testjobv1.TestJob{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: metav1.NamespaceDefault}}
+	initContainer := corev1.Container{Name: "init", Resources: corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1"), corev1.ResourceMemory: resource.MustParse("1Gi")},
+	}}
+	cases := map[string]struct {
+		profile      string
+		wantRequests corev1.ResourceList
+	}{
+		"template and injected init container": {
+			wantRequests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2"), corev1.ResourceMemory: resource.MustParse("2Gi")},
+		},
+		"resource profile": {
+			profile:      "large",
+			wantRequests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("8"), corev1.ResourceMemory: resource.MustParse("2Gi")},
+		},
+		// The pods with an unknown profile are not created, which is reported then.
+		"unknown resource profile": {
+			profile:      "huge",
+			wantRequests: corev1.ResourceList{},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			jc := &JobController{
+				Controller: &initContainerController{
+					testJobController: testJobController{frameworkController{framework: "test-framework"}},
+					initContainer:     initContainer,
+				},
+			}
+			replicas := map[apiv1.ReplicaType]*apiv1.ReplicaSpec{
+				"Worker": {Replicas: ptr.To[int32](3), ResourceProfile: tc.profile, Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "test"}},
+				}}},
+			}
+			pods := []*corev1.Pod{newPod("test-worker-0", corev1.PodRunning)}
+			pods[0].Labels = map[string]string{apiv1.ReplicaTypeLabel: "worker"}
+
+			requests, missing, err := jc.missingPodsRequests(job, replicas, pods)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if missing != 2 {
+				t.Errorf("Unexpected number of missing pods, want: 2, got: %d", missing)
+			}
+			if diff := cmp.Diff(tc.wantRequests, requests); len(diff) != 0 {
+				t.Errorf("Unexpected requests (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"fmt"
+
+	"golang.org/x/time/rate"
+	"k8s.io/client-go/util/workqueue"
+
+	"github.com/kubeflow/training-operator/pkg/config"
+)
+
+// ValidateRateLimiterConfig returns an error if one of the workqueue flags of the operator is invalid,
+// rather than silently falling back to the default rate limiter of controller-runtime.
+func ValidateRateLimiterConfig() error {
+	cfg := config.Config
+	switch {
+	case cfg.WorkQueueBaseDelay <= 0:
+		return fmt.Errorf("--workqueue-base-delay must be positive, got %s", cfg.WorkQueueBaseDelay)
+	case cfg.WorkQueueMaxDelay < cfg.WorkQueueBaseDelay:
+		return fmt.Errorf("--workqueue-max-delay must not be less than --workqueue-base-delay, got %s", cfg.WorkQueueMaxDelay)
+	case cfg.WorkQueueQPS <= 0:
+		return fmt.Errorf("--workqueue-qps must be positive, got %v", cfg.WorkQueueQPS)
+	case cfg.WorkQueueBurst <= 0:
+		return fmt.Errorf("--workqueue-burst must be positive, got %d", cfg.WorkQueueBurst)
+	}
+	return nil
+}
+
+// NewRateLimiter returns the rate limiter of the workqueue of a job controller, configured by the
+// workqueue flags of the operator: the failed reconciles of a job are retried with an exponential
+// backoff, and the reconciles of all the jobs are limited by a token bucket.
+// The flags are validated by ValidateRateLimiterConfig when the operator starts. It returns nil, i.e.
+// the default rate limiter of controller-runtime, if none of the flags is set, e.g. in the tests.
+func NewRateLimiter() workqueue.RateLimiter {
+	cfg := config.Config
+	if cfg.WorkQueueBaseDelay == 0 && cfg.WorkQueueMaxDelay == 0 && cfg.WorkQueueQPS == 0 && cfg.WorkQueueBurst == 0 {
+		return nil
+	}
+	return workqueue.NewMaxOfRateLimiter(
+		workqueue.NewItemExponentialFailureRateLimiter(cfg.WorkQueueBaseDelay, cfg.WorkQueueMaxDelay),
+		&workqueue.BucketRateLimiter{Limiter: rate.NewLimiter(rate.Limit(cfg.WorkQueueQPS), cfg.WorkQueueBurst)},
+	)
+}
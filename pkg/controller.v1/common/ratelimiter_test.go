@@ -0,0 +1,61 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kubeflow/training-operator/pkg/config"
+)
+
+func TestValidateRateLimiterConfig(t *testing.T) {
+	valid := func() {
+		config.Config.WorkQueueBaseDelay = config.WorkQueueBaseDelayDefault
+		config.Config.WorkQueueMaxDelay = config.WorkQueueMaxDelayDefault
+		config.Config.WorkQueueQPS = config.WorkQueueQPSDefault
+		config.Config.WorkQueueBurst = config.WorkQueueBurstDefault
+	}
+	t.Cleanup(func() {
+		config.Config.WorkQueueBaseDelay = 0
+		config.Config.WorkQueueMaxDelay = 0
+		config.Config.WorkQueueQPS = 0
+		config.Config.WorkQueueBurst = 0
+	})
+	cases := map[string]struct {
+		set     func()
+		wantErr bool
+	}{
+		"defaults":                   {set: func() {}},
+		"zero base delay":            {set: func() { config.Config.WorkQueueBaseDelay = 0 }, wantErr: true},
+		"max delay below base":       {set: func() { config.Config.WorkQueueMaxDelay = time.Millisecond }, wantErr: true},
+		"negative qps":               {set: func() { config.Config.WorkQueueQPS = -1 }, wantErr: true},
+		"zero burst":                 {set: func() { config.Config.WorkQueueBurst = 0 }, wantErr: true},
+		"max delay equal base delay": {set: func() { config.Config.WorkQueueMaxDelay = config.WorkQueueBaseDelayDefault }},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			valid()
+			tc.set()
+			err := ValidateRateLimiterConfig()
+			if (err != nil) != tc.wantErr {
+				t.Errorf("ValidateRateLimiterConfig() = %v, want error: %v", err, tc.wantErr)
+			}
+			if !tc.wantErr && NewRateLimiter() == nil {
+				t.Error("NewRateLimiter() = nil for valid flags")
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	commonutil "github.com/kubeflow/training-operator/pkg/util"
+	utillabels "github.com/kubeflow/training-operator/pkg/util/labels"
+)
+
+// RayClusterGVK is the kind of the KubeRay clusters requested through the RayClusterSpec
+// of the RunPolicy of the jobs.
+var RayClusterGVK = schema.GroupVersionKind{Group: "ray.io", Version: "v1", Kind: "RayCluster"}
+
+// GenRayClusterName returns the name of the RayCluster of the job.
+func GenRayClusterName(jobName string) string {
+	return jobName + "-ray"
+}
+
+// newRayCluster returns an empty RayCluster of the job, to be filled by a Get.
+func newRayCluster(metaObject metav1.Object) *unstructured.Unstructured {
+	cluster := &unstructured.Unstructured{}
+	cluster.SetGroupVersionKind(RayClusterGVK)
+	cluster.SetNamespace(metaObject.GetNamespace())
+	cluster.SetName(GenRayClusterName(metaObject.GetName()))
+	return cluster
+}
+
+// ReconcileRayCluster creates the RayCluster requested by the RunPolicy of the job, if it
+// doesn't exist yet. The cluster is controlled by the job, so that it is garbage collected
+// with the job.
+func (jc *JobController) ReconcileRayCluster(runtimeObject runtime.Object, metaObject metav1.Object, runPolicy *apiv1.RunPolicy) error {
+	if runPolicy.RayClusterSpec == nil {
+		return nil
+	}
+	if jc.RayClusterClient == nil {
+		return fmt.Errorf("the RayCluster of %s/%s can not be created without a client", metaObject.GetNamespace(), metaObject.GetName())
+	}
+	spec := map[string]interface{}{}
+	if err := json.Unmarshal(runPolicy.RayClusterSpec.Raw, &spec); err != nil {
+		return fmt.Errorf("invalid rayClusterSpec of %s/%s: %w", metaObject.GetNamespace(), metaObject.GetName(), err)
+	}
+
+	cluster := newRayCluster(metaObject)
+	err := jc.RayClusterClient.Get(context.Background(), types.NamespacedName{Namespace: cluster.GetNamespace(), Name: cluster.GetName()}, cluster)
+	if err == nil {
+		if !metav1.IsControlledBy(cluster, metaObject) {
+			if StrictOwnership() {
+				return &NameCollisionError{Kind: RayClusterGVK.Kind, Name: cluster.GetName()}
+			}
+			return fmt.Errorf("RayCluster %s already exists and is not controlled by the job", cluster.GetName())
+		}
+		return nil
+	}
+	if !errors.IsNotFound(err) {
+		return err
+	}
+
+	cluster = newRayCluster(metaObject)
+	labels := jc.GenLabels(metaObject.GetName())
+	utillabels.SetRunID(labels, RunID(metaObject))
+	cluster.SetLabels(labels)
+	cluster.SetOwnerReferences([]metav1.OwnerReference{*jc.GenOwnerReference(metaObject)})
+	if err := unstructured.SetNestedMap(cluster.Object, spec, "spec"); err != nil {
+		return err
+	}
+	if err := jc.RayClusterClient.Create(context.Background(), cluster); err != nil {
+		jc.Recorder.Eventf(runtimeObject, corev1.EventTypeWarning, commonutil.FailedCreateRayClusterReason, "Error creating: %v", err)
+		return err
+	}
+	jc.Recorder.Eventf(runtimeObject, corev1.EventTypeNormal, commonutil.SuccessfulCreateRayClusterReason, "Created RayCluster: %v", cluster.GetName())
+	return nil
+}
+
+// DeleteRayCluster deletes the RayCluster of the job, once the job is finished or suspended.
+func (jc *JobController) DeleteRayCluster(runtimeObject runtime.Object, metaObject metav1.Object, runPolicy *apiv1.RunPolicy) error {
+	if runPolicy.RayClusterSpec == nil || jc.RayClusterClient == nil {
+		return nil
+	}
+	cluster := newRayCluster(metaObject)
+	err := jc.RayClusterClient.Get(context.Background(), types.NamespacedName{Namespace: cluster.GetNamespace(), Name: cluster.GetName()}, cluster)
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !metav1.IsControlledBy(cluster, metaObject) {
+		return nil
+	}
+	if err := jc.RayClusterClient.Delete(context.Background(), cluster); err != nil && !errors.IsNotFound(err) {
+		jc.Recorder.Eventf(runtimeObject, corev1.EventTypeWarning, commonutil.FailedDeleteRayClusterReason, "Error deleting: %v", err)
+		return err
+	}
+	jc.Recorder.Eventf(runtimeObject, corev1.EventTypeNormal, commonutil.SuccessfulDeleteRayClusterReason, "Deleted RayCluster: %v", cluster.GetName())
+	return nil
+}
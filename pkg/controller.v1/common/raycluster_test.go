@@ -0,0 +1,84 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	testjobv1 "github.com/kubeflow/training-operator/test_job/apis/test_job/v1"
+)
+
+func TestRayCluster(t *testing.T) {
+	job := &testjobv1.TestJob{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: metav1.NamespaceDefault, UID: "job-uid"}}
+	runPolicy := &apiv1.RunPolicy{RayClusterSpec: &runtime.RawExtension{Raw: []byte(`{"rayVersion":"2.9.0"}`)}}
+	c := fake.NewClientBuilder().Build()
+	jc := &JobController{
+		Controller:           &testJobController{frameworkController{framework: "test-framework"}},
+		Recorder:             record.NewFakeRecorder(10),
+		JobControllerOptions: JobControllerOptions{RayClusterClient: c},
+	}
+	key := types.NamespacedName{Namespace: job.Namespace, Name: GenRayClusterName(job.Name)}
+
+	if err := jc.ReconcileRayCluster(job, job, runPolicy); err != nil {
+		t.Fatalf("Unexpected error creating the RayCluster: %v", err)
+	}
+	cluster := &unstructured.Unstructured{}
+	cluster.SetGroupVersionKind(RayClusterGVK)
+	if err := c.Get(context.Background(), key, cluster); err != nil {
+		t.Fatalf("Failed to get the RayCluster: %v", err)
+	}
+	if !metav1.IsControlledBy(cluster, job) {
+		t.Errorf("Expected the RayCluster to be controlled by the job")
+	}
+	if diff := cmp.Diff(map[string]interface{}{"rayVersion": "2.9.0"}, cluster.Object["spec"]); len(diff) != 0 {
+		t.Errorf("Unexpected spec of the RayCluster (-want,+got):\n%s", diff)
+	}
+
+	// The existing RayCluster is left untouched.
+	if err := jc.ReconcileRayCluster(job, job, runPolicy); err != nil {
+		t.Fatalf("Unexpected error reconciling the existing RayCluster: %v", err)
+	}
+
+	if err := jc.DeleteRayCluster(job, job, runPolicy); err != nil {
+		t.Fatalf("Unexpected error deleting the RayCluster: %v", err)
+	}
+	if err := c.Get(context.Background(), key, cluster); !k8serrors.IsNotFound(err) {
+		t.Errorf("Expected the RayCluster to be deleted, got: %v", err)
+	}
+}
+
+func TestReconcileRayClusterWithoutSpec(t *testing.T) {
+	job := &testjobv1.TestJob{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: metav1.NamespaceDefault}}
+	jc := &JobController{Recorder: record.NewFakeRecorder(10)}
+
+	// No client is needed by the jobs which don't request a RayCluster.
+	if err := jc.ReconcileRayCluster(job, job, &apiv1.RunPolicy{}); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if err := jc.DeleteRayCluster(job, job, &apiv1.RunPolicy{}); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+
+	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	trainingoperatorcommon "github.com/kubeflow/training-operator/pkg/common"
+	"github.com/kubeflow/training-operator/pkg/controller.v1/expectation"
+	commonutil "github.com/kubeflow/training-operator/pkg/util"
+	utillabels "github.com/kubeflow/training-operator/pkg/util/labels"
+)
+
+// podsToRestart returns the pods of the job which were created before the last restart
+// requested through the RestartedAtAnnotation of the job, i.e. whose annotation differs.
+func podsToRestart(metaObject metav1.Object, pods []*corev1.Pod) []*corev1.Pod {
+	restartedAt, ok := metaObject.GetAnnotations()[apiv1.RestartedAtAnnotation]
+	if !ok {
+		return nil
+	}
+	var result []*corev1.Pod
+	for _, pod := range pods {
+		if pod.DeletionTimestamp == nil && pod.Annotations[apiv1.RestartedAtAnnotation] != restartedAt {
+			result = append(result, pod)
+		}
+	}
+	return result
+}
+
+// restartPods performs the full restart of the job requested through its RestartedAtAnnotation,
+// like `kubectl rollout restart` does for a Deployment. The running pods are asked to save a
+// checkpoint if the job has a CheckpointPolicy, then all the pods created before the request
+// are deleted at once. They are recreated by the next reconciliations in the usual startup
+// order of the job, with the new value of the annotation.
+func (jc *JobController) restartPods(metaObject metav1.Object, runtimeObject runtime.Object, runPolicy *apiv1.RunPolicy, jobStatus *apiv1.JobStatus, pods []*corev1.Pod) error {
+	jobKey, err := KeyFunc(metaObject)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("couldn't get key for job object %#v: %v", metaObject, err))
+		return err
+	}
+	jobKind := jc.Controller.GetAPIGroupVersionKind().Kind
+	restartedAt := metaObject.GetAnnotations()[apiv1.RestartedAtAnnotation]
+	if runPolicy.CheckpointPolicy != nil && jc.checkpointPods(runtimeObject, runPolicy.CheckpointPolicy, jc.Controller.GetDefaultContainerName(), pods) {
+		return nil
+	}
+	commonutil.LoggerForJob(metaObject).Info("Restarting the pods of the job on request", "restartedAt", restartedAt, "pods", len(pods))
+
+	for _, pod := range pods {
+		if err := jc.deletePod(pod, runtimeObject); err != nil {
+			return err
+		}
+		// Deletion is expected
+		if rType, err := utillabels.ReplicaType(pod.Labels); err == nil {
+			jc.Expectations.RaiseExpectations(expectation.GenExpectationPodsKey(jobKey, string(rType)), 0, 1)
+		}
+	}
+
+	msg := fmt.Sprintf("%s %s is restarting because a restart was requested at %s.", jobKind, metaObject.GetName(), restartedAt)
+	jc.Recorder.Event(runtimeObject, corev1.EventTypeNormal, commonutil.NewReason(jobKind, commonutil.JobRestartRequestedReason), msg)
+	commonutil.UpdateJobConditions(jobStatus, apiv1.JobRestarting, corev1.ConditionTrue, commonutil.NewReason(jobKind, commonutil.JobRestartRequestedReason), msg, jc.Clock)
+	trainingoperatorcommon.RestartedJobsCounterInc(metaObject.GetNamespace(), jc.Controller.GetFrameworkName())
+	return nil
+}
+
+// podsToGangRestart returns the failed pod of a replica type whose RestartPolicy is GangRestart,
+// if any, and all the pods of the job which are not being deleted yet.
+func podsToGangRestart(replicas map[apiv1.ReplicaType]*apiv1.ReplicaSpec, pods []*corev1.Pod) (*corev1.Pod, []*corev1.Pod) {
+	gangRestart := map[string]bool{}
+	for rType, spec := range replicas {
+		if spec != nil && spec.RestartPolicy == apiv1.RestartPolicyGangRestart {
+			gangRestart[strings.ToLower(string(rType))] = true
+		}
+	}
+	if len(gangRestart) == 0 {
+		return nil, nil
+	}
+	var failed *corev1.Pod
+	var result []*corev1.Pod
+	for _, pod := range pods {
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+		if failed == nil && pod.Status.Phase == corev1.PodFailed && gangRestart[pod.Labels[apiv1.ReplicaTypeLabel]] {
+			failed = pod
+		}
+		result = append(result, pod)
+	}
+	if failed == nil {
+		return nil, nil
+	}
+	return failed, result
+}
+
+// exceedsGangRestartLimit returns whether a pod of a replica type whose RestartPolicy is GangRestart
+// failed while the pods of the job were already restarted together runPolicy.backoffLimit times.
+func exceedsGangRestartLimit(runPolicy *apiv1.RunPolicy, replicas map[apiv1.ReplicaType]*apiv1.ReplicaSpec, pods []*corev1.Pod, jobStatus apiv1.JobStatus) bool {
+	if runPolicy.BackoffLimit == nil {
+		return false
+	}
+	failed, _ := podsToGangRestart(replicas, pods)
+	return failed != nil && jobStatus.GangRestarts >= *runPolicy.BackoffLimit
+}
+
+// gangRestartPods restarts all the pods of the job together because the failed pod of a replica
+// type whose RestartPolicy is GangRestart failed, since the synchronous training can't go on with
+// a single replica restarted. The PodGroup of the job is deleted as well when the gang scheduling
+// is enabled, so that the recreated pods are scheduled together by a new PodGroup.
+func (jc *JobController) gangRestartPods(metaObject metav1.Object, runtimeObject runtime.Object, jobStatus *apiv1.JobStatus, failed *corev1.Pod, pods []*corev1.Pod) error {
+	jobKey, err := KeyFunc(metaObject)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("couldn't get key for job object %#v: %v", metaObject, err))
+		return err
+	}
+	jobKind := jc.Controller.GetAPIGroupVersionKind().Kind
+	commonutil.LoggerForJob(metaObject).Info("Restarting all the pods of the job because a pod failed", "pod", failed.Name, "pods", len(pods))
+
+	for _, pod := range pods {
+		if err := jc.deletePod(pod, runtimeObject); err != nil {
+			return err
+		}
+		// Deletion is expected
+		if rType, err := utillabels.ReplicaType(pod.Labels); err == nil {
+			jc.Expectations.RaiseExpectations(expectation.GenExpectationPodsKey(jobKey, string(rType)), 0, 1)
+		}
+	}
+	if jc.Config.EnableGangScheduling() {
+		if err := jc.DeletePodGroup(metaObject); err != nil {
+			jc.Recorder.Eventf(runtimeObject, corev1.EventTypeWarning, commonutil.FailedDeletePodGroupReason, "Error deleting: %v", err)
+			return err
+		}
+	}
+
+	failedPodsCount.Inc()
+	jobStatus.GangRestarts++
+	msg := fmt.Sprintf("%s %s is restarting all its pods because pod %s failed.", jobKind, metaObject.GetName(), failed.Name)
+	jc.Recorder.Event(runtimeObject, corev1.EventTypeWarning, commonutil.NewReason(jobKind, commonutil.JobRestartingReason), msg)
+	commonutil.UpdateJobConditions(jobStatus, apiv1.JobRestarting, corev1.ConditionTrue, commonutil.NewReason(jobKind, commonutil.JobRestartingReason), msg, jc.Clock)
+	trainingoperatorcommon.RestartedJobsCounterInc(metaObject.GetNamespace(), jc.Controller.GetFrameworkName())
+	return nil
+}
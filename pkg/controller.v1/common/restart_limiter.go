@@ -0,0 +1,182 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	trainingoperatorcommon "github.com/kubeflow/training-operator/pkg/common"
+	"github.com/kubeflow/training-operator/pkg/controller.v1/registry"
+	commonutil "github.com/kubeflow/training-operator/pkg/util"
+)
+
+// restartQueueRequeuePeriod is the period at which the jobs queued for restart check
+// whether they may restart.
+const restartQueueRequeuePeriod = 10 * time.Second
+
+type restartKey struct {
+	kind      string
+	namespace string
+	name      string
+}
+
+// RestartLimiter limits the number of jobs of all kinds restarting at the same time, so that
+// mass node failures do not overload the image registries and the schedulers by restarting
+// all the jobs at once. It is shared by all the job controllers and is safe for concurrent use.
+//
+// A job holds a slot from the deletion of its pods to restart until it is running again. The
+// jobs which need to restart while all the slots are held are queued, and restart in the order
+// they were queued as the slots are released. A nil RestartLimiter is unlimited.
+type RestartLimiter struct {
+	mu         sync.Mutex
+	max        int
+	restarting sets.Set[restartKey]
+	queue      []restartKey
+}
+
+// NewRestartLimiter returns a RestartLimiter letting at most max jobs restart at the same time.
+func NewRestartLimiter(max int) *RestartLimiter {
+	return &RestartLimiter{max: max, restarting: sets.New[restartKey]()}
+}
+
+// TryAcquire returns whether the job may restart now, in which case it holds a slot until it is
+// released. Otherwise the job is queued. The jobs which are not in jobs anymore were deleted,
+// so they are dropped first, unless jobs is nil.
+func (l *RestartLimiter) TryAcquire(kind, namespace, name string, jobs registry.Reader) bool {
+	if l == nil {
+		return true
+	}
+	key := restartKey{kind: kind, namespace: namespace, name: name}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	defer l.recordMetrics()
+
+	if l.restarting.Has(key) {
+		return true
+	}
+	if jobs != nil {
+		l.dropDeletedJobs(jobs)
+	}
+	position := slices.Index(l.queue, key)
+	if position < 0 {
+		position = len(l.queue)
+	}
+	// The jobs queued before this one restart first.
+	if l.restarting.Len()+position >= l.max {
+		if position == len(l.queue) {
+			l.queue = append(l.queue, key)
+		}
+		return false
+	}
+	l.queue = slices.DeleteFunc(l.queue, func(k restartKey) bool { return k == key })
+	l.restarting.Insert(key)
+	return true
+}
+
+// Release releases the slot held by the job, once it is running again.
+func (l *RestartLimiter) Release(kind, namespace, name string) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.restarting.Delete(restartKey{kind: kind, namespace: namespace, name: name})
+	l.recordMetrics()
+}
+
+// Forget releases the slot held by the job and removes it from the queue, once the job
+// is finished or suspended.
+func (l *RestartLimiter) Forget(kind, namespace, name string) {
+	if l == nil {
+		return
+	}
+	key := restartKey{kind: kind, namespace: namespace, name: name}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.restarting.Delete(key)
+	l.queue = slices.DeleteFunc(l.queue, func(k restartKey) bool { return k == key })
+	l.recordMetrics()
+}
+
+// Restarting returns the number of jobs restarting and the number of jobs queued for restart.
+func (l *RestartLimiter) Restarting() (restarting, queued int) {
+	if l == nil {
+		return 0, 0
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.restarting.Len(), len(l.queue)
+}
+
+func (l *RestartLimiter) dropDeletedJobs(jobs registry.Reader) {
+	deleted := func(k restartKey) bool {
+		_, ok := jobs.Get(k.kind, k.namespace, k.name)
+		return !ok
+	}
+	for key := range l.restarting {
+		if deleted(key) {
+			l.restarting.Delete(key)
+		}
+	}
+	l.queue = slices.DeleteFunc(l.queue, deleted)
+}
+
+func (l *RestartLimiter) recordMetrics() {
+	trainingoperatorcommon.RestartingJobsGaugeSet(l.restarting.Len(), len(l.queue))
+}
+
+// acquireRestart returns whether the job may restart now. Otherwise the QueuedForRestart
+// condition of the job is set and the job is requeued to check again later.
+func (jc *JobController) acquireRestart(metaObject metav1.Object, runtimeObject runtime.Object, jobStatus *apiv1.JobStatus) bool {
+	jobKind := jc.Controller.GetAPIGroupVersionKind().Kind
+	queued := commonutil.IsQueuedForRestart(*jobStatus)
+	if jc.RestartLimiter.TryAcquire(jobKind, metaObject.GetNamespace(), metaObject.GetName(), jc.JobRegistry) {
+		if queued {
+			msg := fmt.Sprintf("%s %s is no longer queued for restart.", jobKind, metaObject.GetName())
+			commonutil.UpdateJobConditions(jobStatus, apiv1.JobQueuedForRestart, corev1.ConditionFalse, commonutil.NewReason(jobKind, commonutil.JobRestartDequeuedReason), msg, jc.Clock)
+		}
+		return true
+	}
+	if !queued {
+		restarting, _ := jc.RestartLimiter.Restarting()
+		msg := fmt.Sprintf("%s %s is queued for restart because %d jobs are already restarting.", jobKind, metaObject.GetName(), restarting)
+		jc.Recorder.Event(runtimeObject, corev1.EventTypeNormal, commonutil.NewReason(jobKind, commonutil.JobQueuedForRestartReason), msg)
+		commonutil.UpdateJobConditions(jobStatus, apiv1.JobQueuedForRestart, corev1.ConditionTrue, commonutil.NewReason(jobKind, commonutil.JobQueuedForRestartReason), msg, jc.Clock)
+	}
+	if key, err := KeyFunc(metaObject); err == nil {
+		jc.WorkQueue.AddAfter(key, restartQueueRequeuePeriod)
+	}
+	return false
+}
+
+// forgetRestart removes the job from the RestartLimiter once it is finished or suspended,
+// and clears its QueuedForRestart condition.
+func (jc *JobController) forgetRestart(metaObject metav1.Object, jobStatus *apiv1.JobStatus) {
+	jobKind := jc.Controller.GetAPIGroupVersionKind().Kind
+	jc.RestartLimiter.Forget(jobKind, metaObject.GetNamespace(), metaObject.GetName())
+	if commonutil.IsQueuedForRestart(*jobStatus) {
+		msg := fmt.Sprintf("%s %s is no longer queued for restart.", jobKind, metaObject.GetName())
+		commonutil.UpdateJobConditions(jobStatus, apiv1.JobQueuedForRestart, corev1.ConditionFalse, commonutil.NewReason(jobKind, commonutil.JobRestartDequeuedReason), msg, jc.Clock)
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+
+	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	"github.com/kubeflow/training-operator/pkg/controller.v1/registry"
+	commonutil "github.com/kubeflow/training-operator/pkg/util"
+	testjobv1 "github.com/kubeflow/training-operator/test_job/apis/test_job/v1"
+)
+
+func TestRestartLimiter(t *testing.T) {
+	limiter := NewRestartLimiter(1)
+	kind := apiv1.PyTorchJobKind
+
+	if !limiter.TryAcquire(kind, "default", "a", nil) {
+		t.Fatalf("Expected job a to restart")
+	}
+	// The slot is held until it is released, and acquiring it again is a no-op.
+	if !limiter.TryAcquire(kind, "default", "a", nil) {
+		t.Errorf("Expected job a to keep its slot")
+	}
+	if limiter.TryAcquire(kind, "default", "b", nil) {
+		t.Errorf("Expected job b to be queued")
+	}
+	if limiter.TryAcquire(kind, "default", "c", nil) {
+		t.Errorf("Expected job c to be queued")
+	}
+	if restarting, queued := limiter.Restarting(); restarting != 1 || queued != 2 {
+		t.Errorf("Unexpected restarting %d and queued %d jobs, want 1 and 2", restarting, queued)
+	}
+
+	// The queued jobs restart in order.
+	limiter.Release(kind, "default", "a")
+	if limiter.TryAcquire(kind, "default", "c", nil) {
+		t.Errorf("Expected job c to wait for job b")
+	}
+	if !limiter.TryAcquire(kind, "default", "b", nil) {
+		t.Errorf("Expected job b to restart")
+	}
+
+	// A forgotten job releases its slot and leaves the queue.
+	limiter.Forget(kind, "default", "b")
+	if !limiter.TryAcquire(kind, "default", "c", nil) {
+		t.Errorf("Expected job c to restart")
+	}
+	if restarting, queued := limiter.Restarting(); restarting != 1 || queued != 0 {
+		t.Errorf("Unexpected restarting %d and queued %d jobs, want 1 and 0", restarting, queued)
+	}
+}
+
+func TestRestartLimiterDropsDeletedJobs(t *testing.T) {
+	limiter := NewRestartLimiter(1)
+	jobs := registry.New()
+	job := &apiv1.PyTorchJob{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "default"}}
+	jobs.OnAdd(job)
+
+	if !limiter.TryAcquire(apiv1.PyTorchJobKind, "default", "a", jobs) {
+		t.Fatalf("Expected job a to restart")
+	}
+	if limiter.TryAcquire(apiv1.PyTorchJobKind, "default", "b", jobs) {
+		t.Errorf("Expected job b to be queued while job a exists")
+	}
+	jobs.OnDelete(job)
+	// Job b is deleted too, since it is not in the registry.
+	if !limiter.TryAcquire(apiv1.TFJobKind, "default", "a", jobs) {
+		t.Errorf("Expected the TFJob a to restart once the PyTorchJob a is deleted")
+	}
+	if restarting, queued := limiter.Restarting(); restarting != 1 || queued != 0 {
+		t.Errorf("Unexpected restarting %d and queued %d jobs, want 1 and 0", restarting, queued)
+	}
+}
+
+func TestAcquireRestart(t *testing.T) {
+	job := &testjobv1.TestJob{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: metav1.NamespaceDefault}}
+	limiter := NewRestartLimiter(1)
+	limiter.TryAcquire(apiv1.PyTorchJobKind, metav1.NamespaceDefault, "other", nil)
+	recorder := record.NewFakeRecorder(10)
+	jc := &JobController{
+		Controller:           &testJobController{frameworkController{framework: "test-framework"}},
+		JobControllerOptions: JobControllerOptions{RestartLimiter: limiter},
+		WorkQueue:            workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+		Recorder:             recorder,
+	}
+	jobStatus := &apiv1.JobStatus{}
+
+	if jc.acquireRestart(job, job, jobStatus) {
+		t.Fatalf("Expected the restart of the job to be queued")
+	}
+	if !commonutil.IsQueuedForRestart(*jobStatus) {
+		t.Errorf("Expected a QueuedForRestart condition, got: %v", jobStatus.Conditions)
+	}
+	// The job is queued once.
+	jc.acquireRestart(job, job, jobStatus)
+	if got := len(recorder.Events); got != 1 {
+		t.Errorf("Unexpected number of events, want: 1, got: %d", got)
+	}
+
+	limiter.Release(apiv1.PyTorchJobKind, metav1.NamespaceDefault, "other")
+	if !jc.acquireRestart(job, job, jobStatus) {
+		t.Fatalf("Expected the job to restart")
+	}
+	if commonutil.IsQueuedForRestart(*jobStatus) {
+		t.Errorf("Expected the QueuedForRestart condition to be false, got: %v", jobStatus.Conditions)
+	}
+	if len(jobStatus.Conditions) != 1 || jobStatus.Conditions[0].Status != corev1.ConditionFalse {
+		t.Errorf("Unexpected conditions: %v", jobStatus.Conditions)
+	}
+}
@@ -0,0 +1,308 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/ptr"
+
+	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	"github.com/kubeflow/training-operator/pkg/controller.v1/control"
+	"github.com/kubeflow/training-operator/pkg/controller.v1/expectation"
+	commonutil "github.com/kubeflow/training-operator/pkg/util"
+	testjobv1 "github.com/kubeflow/training-operator/test_job/apis/test_job/v1"
+)
+
+// testJobController is a frameworkController of TestJobs.
+type testJobController struct {
+	frameworkController
+}
+
+func (c *testJobController) GetAPIGroupVersionKind() schema.GroupVersionKind {
+	return testjobv1.SchemeGroupVersionKind
+}
+
+func (c *testJobController) GetAPIGroupVersion() schema.GroupVersion {
+	return testjobv1.SchemeGroupVersion
+}
+
+func (c *testJobController) GetDefaultContainerName() string {
+	return "test"
+}
+
+func (c *testJobController) ControllerName() string {
+	return "test-operator"
+}
+
+func newRestartedPod(name, restartedAt string) *corev1.Pod {
+	pod := newPod(name, corev1.PodRunning)
+	if restartedAt != "" {
+		pod.Annotations = map[string]string{apiv1.RestartedAtAnnotation: restartedAt}
+	}
+	return pod
+}
+
+func TestPodsToRestart(t *testing.T) {
+	deletingPod := newRestartedPod("deletingPod", "")
+	deletingPod.DeletionTimestamp = ptr.To(metav1.Now())
+	pods := []*corev1.Pod{
+		newRestartedPod("neverRestartedPod", ""),
+		newRestartedPod("restartedPod", "2024-01-01T00:00:00Z"),
+		newRestartedPod("previouslyRestartedPod", "2023-01-01T00:00:00Z"),
+		deletingPod,
+	}
+
+	cases := map[string]struct {
+		annotations  map[string]string
+		wantPodNames []string
+	}{
+		"no restart requested": {},
+		"restart requested": {
+			annotations:  map[string]string{apiv1.RestartedAtAnnotation: "2024-01-01T00:00:00Z"},
+			wantPodNames: []string{"neverRestartedPod", "previouslyRestartedPod"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			job := &testjobv1.TestJob{ObjectMeta: metav1.ObjectMeta{Name: "test", Annotations: tc.annotations}}
+			var got []string
+			for _, pod := range podsToRestart(job, pods) {
+				got = append(got, pod.Name)
+			}
+			if diff := cmp.Diff(tc.wantPodNames, got, cmpopts.SortSlices(func(a, b string) bool { return a < b })); len(diff) != 0 {
+				t.Errorf("Unexpected pods to restart (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRestartPods(t *testing.T) {
+	job := &testjobv1.TestJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test",
+			Namespace:   metav1.NamespaceDefault,
+			Annotations: map[string]string{apiv1.RestartedAtAnnotation: "2024-01-01T00:00:00Z"},
+		},
+	}
+	pods := []*corev1.Pod{
+		newRestartedPod("pod-0", ""),
+		newRestartedPod("pod-1", "2023-01-01T00:00:00Z"),
+	}
+	podControl := &control.FakePodControl{}
+	podExecControl := &control.FakePodExecControl{}
+	recorder := record.NewFakeRecorder(10)
+	jc := &JobController{
+		Controller:     &testJobController{frameworkController{framework: "test-framework"}},
+		PodControl:     podControl,
+		PodExecControl: podExecControl,
+		Expectations:   expectation.NewControllerExpectations(),
+		Recorder:       recorder,
+		WorkQueue:      workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+	}
+	defer jc.WorkQueue.ShutDown()
+	runPolicy := &apiv1.RunPolicy{
+		CheckpointPolicy: &apiv1.CheckpointPolicy{Command: []string{"/checkpoint.sh"}},
+	}
+	jobStatus := &apiv1.JobStatus{}
+
+	// The pods are asked to save a checkpoint, and are not deleted until its deadline.
+	if err := jc.restartPods(job, job, runPolicy, jobStatus, pods); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(podControl.DeletePodName) != 0 || len(jobStatus.Conditions) != 0 {
+		t.Errorf("Expected the restart to wait for the checkpoint deadline, got deleted pods: %v", podControl.DeletePodName)
+	}
+	err := wait.PollUntilContextTimeout(context.Background(), 10*time.Millisecond, 5*time.Second, true, func(context.Context) (bool, error) {
+		podExecControl.Lock()
+		defer podExecControl.Unlock()
+		return len(podExecControl.ExecPodNames) == 2, nil
+	})
+	if err != nil {
+		t.Errorf("Expected the running pods to be checkpointed, got: %v", podExecControl.ExecPodNames)
+	}
+
+	// The pods are deleted once the deadline has passed.
+	for _, pod := range pods {
+		metav1.SetMetaDataAnnotation(&pod.ObjectMeta, apiv1.CheckpointDeadlineAnnotation, metav1.Now().Add(-time.Second).Format(time.RFC3339))
+	}
+	if err := jc.restartPods(job, job, runPolicy, jobStatus, pods); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if diff := cmp.Diff([]string{"pod-0", "pod-1"}, podControl.DeletePodName); len(diff) != 0 {
+		t.Errorf("Unexpected deleted pods (-want,+got):\n%s", diff)
+	}
+	wantReason := commonutil.NewReason(testjobv1.Kind, commonutil.JobRestartRequestedReason)
+	if len(jobStatus.Conditions) != 1 || jobStatus.Conditions[0].Type != apiv1.JobRestarting || jobStatus.Conditions[0].Reason != wantReason {
+		t.Errorf("Expected a Restarting condition with the %s reason, got: %v", wantReason, jobStatus.Conditions)
+	}
+	if got := len(recorder.Events); got != 1 {
+		t.Errorf("Unexpected number of events, want: 1, got: %d", got)
+	}
+}
+
+func TestPodsToGangRestart(t *testing.T) {
+	newReplicaPod := func(name, rt string, phase corev1.PodPhase) *corev1.Pod {
+		pod := newPod(name, phase)
+		pod.Labels[apiv1.ReplicaTypeLabel] = rt
+		return pod
+	}
+	deletingPod := newReplicaPod("deleting", "worker", corev1.PodFailed)
+	deletingPod.DeletionTimestamp = ptr.To(metav1.Now())
+	replicas := map[apiv1.ReplicaType]*apiv1.ReplicaSpec{
+		"Master": {RestartPolicy: apiv1.RestartPolicyOnFailure},
+		"Worker": {RestartPolicy: apiv1.RestartPolicyGangRestart},
+	}
+
+	cases := map[string]struct {
+		pods         []*corev1.Pod
+		wantFailed   string
+		wantPodNames []string
+	}{
+		"no failed pod": {
+			pods: []*corev1.Pod{newReplicaPod("master-0", "master", corev1.PodRunning), newReplicaPod("worker-0", "worker", corev1.PodRunning)},
+		},
+		"failed pod of a replica type without GangRestart": {
+			pods: []*corev1.Pod{newReplicaPod("master-0", "master", corev1.PodFailed), newReplicaPod("worker-0", "worker", corev1.PodRunning)},
+		},
+		"failed pod being deleted": {
+			pods: []*corev1.Pod{newReplicaPod("master-0", "master", corev1.PodRunning), deletingPod},
+		},
+		"failed worker": {
+			pods: []*corev1.Pod{
+				newReplicaPod("master-0", "master", corev1.PodRunning),
+				newReplicaPod("worker-0", "worker", corev1.PodRunning),
+				newReplicaPod("worker-1", "worker", corev1.PodFailed),
+				deletingPod,
+			},
+			wantFailed:   "worker-1",
+			wantPodNames: []string{"master-0", "worker-0", "worker-1"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			failed, pods := podsToGangRestart(replicas, tc.pods)
+			var gotFailed string
+			if failed != nil {
+				gotFailed = failed.Name
+			}
+			if gotFailed != tc.wantFailed {
+				t.Errorf("Unexpected failed pod, want: %q, got: %q", tc.wantFailed, gotFailed)
+			}
+			var got []string
+			for _, pod := range pods {
+				got = append(got, pod.Name)
+			}
+			if diff := cmp.Diff(tc.wantPodNames, got); len(diff) != 0 {
+				t.Errorf("Unexpected pods to restart (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGangRestartPods(t *testing.T) {
+	job := &testjobv1.TestJob{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: metav1.NamespaceDefault}}
+	failed := newPod("pod-1", corev1.PodFailed)
+	pods := []*corev1.Pod{newPod("pod-0", corev1.PodRunning), failed}
+	podControl := &control.FakePodControl{}
+	recorder := record.NewFakeRecorder(10)
+	jc := &JobController{
+		Controller:   &testJobController{frameworkController{framework: "test-framework"}},
+		PodControl:   podControl,
+		Expectations: expectation.NewControllerExpectations(),
+		Recorder:     recorder,
+	}
+	jobStatus := &apiv1.JobStatus{}
+
+	if err := jc.gangRestartPods(job, job, jobStatus, failed, pods); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if diff := cmp.Diff([]string{"pod-0", "pod-1"}, podControl.DeletePodName); len(diff) != 0 {
+		t.Errorf("Unexpected deleted pods (-want,+got):\n%s", diff)
+	}
+	if !commonutil.IsRestarting(*jobStatus) {
+		t.Errorf("Expected a Restarting condition, got: %v", jobStatus.Conditions)
+	}
+	if jobStatus.GangRestarts != 1 {
+		t.Errorf("Unexpected gang restarts, want: 1, got: %d", jobStatus.GangRestarts)
+	}
+	if got := len(recorder.Events); got != 1 {
+		t.Errorf("Unexpected number of events, want: 1, got: %d", got)
+	}
+}
+
+func TestExceedsGangRestartLimit(t *testing.T) {
+	newReplicaPod := func(name string, phase corev1.PodPhase) *corev1.Pod {
+		pod := newPod(name, phase)
+		pod.Labels[apiv1.ReplicaTypeLabel] = "worker"
+		return pod
+	}
+	replicas := map[apiv1.ReplicaType]*apiv1.ReplicaSpec{
+		"Worker": {RestartPolicy: apiv1.RestartPolicyGangRestart},
+	}
+	failedPods := []*corev1.Pod{newReplicaPod("worker-0", corev1.PodRunning), newReplicaPod("worker-1", corev1.PodFailed)}
+
+	cases := map[string]struct {
+		backoffLimit *int32
+		gangRestarts int32
+		pods         []*corev1.Pod
+		want         bool
+	}{
+		"no backoff limit": {
+			gangRestarts: 10,
+			pods:         failedPods,
+		},
+		"no failed pod": {
+			backoffLimit: ptr.To[int32](1),
+			gangRestarts: 1,
+			pods:         []*corev1.Pod{newReplicaPod("worker-0", corev1.PodRunning)},
+		},
+		"below the backoff limit": {
+			backoffLimit: ptr.To[int32](2),
+			gangRestarts: 1,
+			pods:         failedPods,
+		},
+		"reached the backoff limit": {
+			backoffLimit: ptr.To[int32](2),
+			gangRestarts: 2,
+			pods:         failedPods,
+			want:         true,
+		},
+		"zero backoff limit": {
+			backoffLimit: ptr.To[int32](0),
+			pods:         failedPods,
+			want:         true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			runPolicy := &apiv1.RunPolicy{BackoffLimit: tc.backoffLimit}
+			jobStatus := apiv1.JobStatus{GangRestarts: tc.gangRestarts}
+			if got := exceedsGangRestartLimit(runPolicy, replicas, tc.pods, jobStatus); got != tc.want {
+				t.Errorf("Unexpected result, want: %v, got: %v", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+)
+
+// RunID returns the run ID of the job, set as the RunIDLabel of its pods and of the other
+// resources created for it: the RunIDAnnotation of the job if it is a valid label value, or
+// the UID of the job. Since the run ID is derived from the job, it is the same for all the
+// pods of the job, including the pods recreated by the retries.
+func RunID(metaObject metav1.Object) string {
+	if runID, ok := metaObject.GetAnnotations()[apiv1.RunIDAnnotation]; ok && runID != "" &&
+		len(validation.IsValidLabelValue(runID)) == 0 {
+		return runID
+	}
+	return string(metaObject.GetUID())
+}
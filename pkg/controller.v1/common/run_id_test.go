@@ -0,0 +1,51 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	testjobv1 "github.com/kubeflow/training-operator/test_job/apis/test_job/v1"
+)
+
+func TestRunID(t *testing.T) {
+	cases := map[string]struct {
+		annotations map[string]string
+		want        string
+	}{
+		"defaults to the UID of the job": {
+			want: "1a2b3c",
+		},
+		"set by the annotation": {
+			annotations: map[string]string{apiv1.RunIDAnnotation: "mlflow-run-42"},
+			want:        "mlflow-run-42",
+		},
+		"invalid annotation is ignored": {
+			annotations: map[string]string{apiv1.RunIDAnnotation: "run 42"},
+			want:        "1a2b3c",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			job := &testjobv1.TestJob{ObjectMeta: metav1.ObjectMeta{Name: "test", UID: "1a2b3c", Annotations: tc.annotations}}
+			if got := RunID(job); got != tc.want {
+				t.Errorf("Unexpected run ID, want: %q, got: %q", tc.want, got)
+			}
+		})
+	}
+}
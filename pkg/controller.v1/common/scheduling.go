@@ -18,15 +18,52 @@ package common
 
 import (
 	"fmt"
+	"strings"
 
-	"github.com/google/go-cmp/cmp"
-	log "github.com/sirupsen/logrus"
+	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	"github.com/kubeflow/training-operator/pkg/controller.v1/control"
+	commonutil "github.com/kubeflow/training-operator/pkg/util"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	schedulerpluginsv1alpha1 "sigs.k8s.io/scheduler-plugins/apis/scheduling/v1alpha1"
+	volcanov1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+)
+
+var (
+	// VolcanoPodGroupGVK is the kind of the volcano PodGroups.
+	VolcanoPodGroupGVK = volcanov1beta1.SchemeGroupVersion.WithKind("PodGroup")
+	// SchedulerPluginsPodGroupGVK is the kind of the scheduler-plugins PodGroups.
+	SchedulerPluginsPodGroupGVK = schedulerpluginsv1alpha1.SchemeGroupVersion.WithKind("PodGroup")
 )
 
+// PodGroupGVK returns the kind of the PodGroups of the gang scheduler: the volcano PodGroups for
+// volcano, and the scheduler-plugins PodGroups for any other scheduler name.
+func PodGroupGVK(gangScheduler GangScheduler) schema.GroupVersionKind {
+	if strings.EqualFold(string(gangScheduler), string(GangSchedulerVolcano)) {
+		return VolcanoPodGroupGVK
+	}
+	return SchedulerPluginsPodGroupGVK
+}
+
+// KindInstalled returns whether the kind is served by the API server, e.g. whether the CRD of the
+// PodGroups of a gang scheduler is installed, so that the operator runs in the clusters without
+// the gang scheduler.
+func KindInstalled(mapper meta.RESTMapper, gvk schema.GroupVersionKind) (bool, error) {
+	_, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if meta.IsNoMatchError(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
 type FillPodGroupSpecFunc func(object metav1.Object) error
 
 func (jc *JobController) SyncPodGroup(job metav1.Object, specFunc FillPodGroupSpecFunc) (metav1.Object, error) {
@@ -36,12 +73,13 @@ func (jc *JobController) SyncPodGroup(job metav1.Object, specFunc FillPodGroupSp
 	podGroup, err := pgctl.GetPodGroup(job.GetNamespace(), job.GetName())
 	if err == nil {
 		// update podGroup for gang scheduling
-		oldPodGroup := &podGroup
+		oldPodGroup := podGroup.(client.Object).DeepCopyObject().(client.Object)
 		if err = specFunc(podGroup); err != nil {
 			return nil, fmt.Errorf("unable to fill the spec of PodGroup, '%v': %v", klog.KObj(podGroup), err)
 		}
-		if diff := cmp.Diff(oldPodGroup, podGroup); len(diff) != 0 {
-			return podGroup, pgctl.UpdatePodGroup(podGroup.(client.Object))
+		if !equality.Semantic.DeepEqual(oldPodGroup, podGroup) {
+			jc.RecordAPICall(job, APICallUpdate)
+			return podGroup, pgctl.PatchPodGroup(oldPodGroup, podGroup.(client.Object))
 		}
 		return podGroup, nil
 	} else if client.IgnoreNotFound(err) != nil {
@@ -52,11 +90,13 @@ func (jc *JobController) SyncPodGroup(job metav1.Object, specFunc FillPodGroupSp
 		newPodGroup.SetName(job.GetName())
 		newPodGroup.SetNamespace(job.GetNamespace())
 		newPodGroup.SetAnnotations(job.GetAnnotations())
+		newPodGroup.SetLabels(map[string]string{apiv1.RunIDLabel: RunID(job)})
 		newPodGroup.SetOwnerReferences([]metav1.OwnerReference{*jc.GenOwnerReference(job)})
 		if err = specFunc(newPodGroup); err != nil {
 			return nil, fmt.Errorf("unable to fill the spec of PodGroup, '%v': %v", klog.KObj(newPodGroup), err)
 		}
 
+		jc.RecordAPICall(job, APICallCreate)
 		err = pgctl.CreatePodGroup(newPodGroup)
 		if err != nil {
 			return podGroup, fmt.Errorf("unable to create PodGroup: %v", err)
@@ -73,15 +113,18 @@ func (jc *JobController) SyncPodGroup(job metav1.Object, specFunc FillPodGroupSp
 }
 
 func (jc *JobController) DeletePodGroup(job metav1.Object) error {
-	pgctl := jc.PodGroupControl
+	jc.RecordAPICall(job, APICallDelete)
+	return deletePodGroup(jc.PodGroupControl, job)
+}
 
+func deletePodGroup(pgctl control.PodGroupControlInterface, job metav1.Object) error {
 	// Check whether podGroup exists or not
 	_, err := pgctl.GetPodGroup(job.GetNamespace(), job.GetName())
 	if err != nil && k8serrors.IsNotFound(err) {
 		return nil
 	}
 
-	log.Infof("Deleting PodGroup %s", job.GetName())
+	commonutil.LoggerForJob(job).Info("Deleting PodGroup")
 
 	// Delete podGroup
 	err = pgctl.DeletePodGroup(job.GetNamespace(), job.GetName())
@@ -91,3 +134,67 @@ func (jc *JobController) DeletePodGroup(job metav1.Object) error {
 	deletedPodGroupsCount.Inc()
 	return nil
 }
+
+// isConfiguredGangScheduler returns true if the gang scheduling is enabled with the gang scheduler.
+func (jc *JobController) isConfiguredGangScheduler(schedulerName string) bool {
+	return jc.Config.EnableGangScheduling() && GangScheduler(schedulerName) == jc.Config.GangScheduling
+}
+
+// podGroupControlFor returns the PodGroupControl of the PodGroups of the gang scheduler,
+// or nil if the controller has no client for them.
+func (jc *JobController) podGroupControlFor(schedulerName string) control.PodGroupControlInterface {
+	if jc.isConfiguredGangScheduler(schedulerName) {
+		return jc.PodGroupControl
+	}
+	if strings.EqualFold(schedulerName, string(GangSchedulerVolcano)) {
+		if jc.PodGroupClients.Volcano == nil {
+			return nil
+		}
+		return control.NewVolcanoControl(jc.PodGroupClients.Volcano)
+	}
+	if jc.PodGroupClients.SchedulerPlugins == nil {
+		return nil
+	}
+	return control.NewSchedulerPluginsControl(jc.PodGroupClients.SchedulerPlugins, schedulerName)
+}
+
+// deleteRecordedPodGroup deletes the PodGroup recorded in the status of the job and clears the record.
+// The record is also cleared if the controller has no client for the PodGroups of its gang scheduler,
+// in which case the PodGroup is left to the user.
+func (jc *JobController) deleteRecordedPodGroup(runtimeObject runtime.Object, metaObject metav1.Object, jobStatus *apiv1.JobStatus) error {
+	schedulerName := jobStatus.GangScheduling.SchedulerName
+	if pgctl := jc.podGroupControlFor(schedulerName); pgctl == nil {
+		jc.Recorder.Eventf(runtimeObject, corev1.EventTypeWarning, commonutil.OrphanedPodGroupReason,
+			"PodGroup %v of the gang scheduler %s can not be deleted: the operator has no client for its PodGroups", metaObject.GetName(), schedulerName)
+	} else {
+		jc.RecordAPICall(metaObject, APICallDelete)
+		if err := deletePodGroup(pgctl, metaObject); err != nil {
+			return err
+		}
+	}
+	jobStatus.GangScheduling = nil
+	return nil
+}
+
+// CleanupPodGroup deletes the PodGroup of a terminated job. The PodGroup recorded in the status of
+// the job is deleted whatever the current gang scheduling configuration is, so that the PodGroups
+// created before the gang scheduling was disabled are not orphaned.
+func (jc *JobController) CleanupPodGroup(runtimeObject runtime.Object, metaObject metav1.Object, jobStatus *apiv1.JobStatus) error {
+	if jobStatus.GangScheduling == nil && !jc.Config.EnableGangScheduling() {
+		return nil
+	}
+
+	jc.Recorder.Event(runtimeObject, corev1.EventTypeNormal, commonutil.JobTerminatedReason, "Job has been terminated. Deleting PodGroup")
+	var err error
+	if jobStatus.GangScheduling != nil {
+		err = jc.deleteRecordedPodGroup(runtimeObject, metaObject, jobStatus)
+	} else {
+		err = jc.DeletePodGroup(metaObject)
+	}
+	if err != nil {
+		jc.Recorder.Eventf(runtimeObject, corev1.EventTypeWarning, commonutil.FailedDeletePodGroupReason, "Error deleting: %v", err)
+		return err
+	}
+	jc.Recorder.Eventf(runtimeObject, corev1.EventTypeNormal, commonutil.SuccessfulDeletePodGroupReason, "Deleted PodGroup: %v", metaObject.GetName())
+	return nil
+}
@@ -0,0 +1,135 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	volcanov1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+
+	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	commonutil "github.com/kubeflow/training-operator/pkg/util"
+)
+
+// updateQueuedCondition sets the Queued condition while the creation of the pods of the job is
+// delayed because its PodGroup pg is not admitted by the gang scheduler, and removes it once
+// the PodGroup is admitted.
+func (jc *JobController) updateQueuedCondition(metaObject metav1.Object, runtimeObject runtime.Object, jobStatus *apiv1.JobStatus, pg metav1.Object, queued bool) {
+	if !queued {
+		removeCondition(jobStatus, apiv1.JobQueued)
+		return
+	}
+	jobKind := jc.Controller.GetAPIGroupVersionKind().Kind
+	msg := fmt.Sprintf("%s %s is queued until its PodGroup is admitted by the gang scheduler.", jobKind, metaObject.GetName())
+	if pgMsg := podGroupUnschedulableMessage(pg); pgMsg != "" {
+		msg = fmt.Sprintf("%s %s", msg, pgMsg)
+	}
+	reason := commonutil.NewReason(jobKind, commonutil.JobQueuedReason)
+	if jc.setTransientCondition(jobStatus, apiv1.JobQueued, reason, msg) {
+		jc.Recorder.Event(runtimeObject, corev1.EventTypeNormal, reason, msg)
+	}
+}
+
+// podGroupUnschedulableMessage returns the message of the Unschedulable condition of a Volcano
+// PodGroup, e.g. when the quota of its queue is exhausted.
+func podGroupUnschedulableMessage(pg metav1.Object) string {
+	volcanoPodGroup, ok := pg.(*volcanov1beta1.PodGroup)
+	if !ok {
+		return ""
+	}
+	for _, condition := range volcanoPodGroup.Status.Conditions {
+		if condition.Type == volcanov1beta1.PodGroupUnschedulableType && condition.Status == corev1.ConditionTrue {
+			return condition.Message
+		}
+	}
+	return ""
+}
+
+// updateSchedulingCondition sets the Scheduling condition while some pods of the job are
+// reported as unschedulable by the scheduler, with the message of the scheduler for the first
+// of them, and removes it once none of them is unschedulable.
+func (jc *JobController) updateSchedulingCondition(metaObject metav1.Object, runtimeObject runtime.Object, jobStatus *apiv1.JobStatus, pods []*corev1.Pod) {
+	var first *corev1.Pod
+	var schedulerMessage string
+	unschedulable := 0
+	for _, pod := range pods {
+		if pod.DeletionTimestamp != nil || pod.Spec.NodeName != "" {
+			continue
+		}
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == corev1.PodScheduled && condition.Status == corev1.ConditionFalse &&
+				condition.Reason == corev1.PodReasonUnschedulable {
+				unschedulable++
+				// The message of the first pod by name is reported, so that it doesn't
+				// change with the order of the pods.
+				if first == nil || pod.Name < first.Name {
+					first, schedulerMessage = pod, condition.Message
+				}
+			}
+		}
+	}
+	if unschedulable == 0 {
+		removeCondition(jobStatus, apiv1.JobScheduling)
+		return
+	}
+	jobKind := jc.Controller.GetAPIGroupVersionKind().Kind
+	msg := fmt.Sprintf("%d pod(s) of %s %s are unschedulable. Pod %s: %s", unschedulable, jobKind, metaObject.GetName(), first.Name, schedulerMessage)
+	reason := commonutil.NewReason(jobKind, commonutil.JobUnschedulableReason)
+	if jc.setTransientCondition(jobStatus, apiv1.JobScheduling, reason, msg) {
+		jc.Recorder.Event(runtimeObject, corev1.EventTypeWarning, reason, msg)
+	}
+}
+
+// setTransientCondition sets a condition which is removed instead of being set to False, so
+// that it does not remain the last condition reported as the state of the job. The message of
+// the condition is updated in place, and true is returned if the condition was not set yet.
+func (jc *JobController) setTransientCondition(jobStatus *apiv1.JobStatus, conditionType apiv1.JobConditionType, reason, msg string) bool {
+	if condition := findCondition(jobStatus, conditionType); condition != nil && condition.Status == corev1.ConditionTrue {
+		if condition.Reason != reason || condition.Message != msg {
+			condition.Reason = reason
+			condition.Message = msg
+			condition.LastUpdateTime = jc.Clock.MetaNow()
+		}
+		return false
+	}
+	commonutil.UpdateJobConditions(jobStatus, conditionType, corev1.ConditionTrue, reason, msg, jc.Clock)
+	return true
+}
+
+// removeSchedulingConditions removes the Queued, Scheduling, QuotaBlocked and PullingImages
+// conditions of a job which no longer has pods to schedule.
+func removeSchedulingConditions(jobStatus *apiv1.JobStatus) {
+	removeCondition(jobStatus, apiv1.JobQueued)
+	removeCondition(jobStatus, apiv1.JobScheduling)
+	removeCondition(jobStatus, apiv1.JobQuotaBlocked)
+	removeCondition(jobStatus, apiv1.JobPullingImages)
+}
+
+// removeCondition removes the conditions of the type from the status.
+func removeCondition(jobStatus *apiv1.JobStatus, conditionType apiv1.JobConditionType) {
+	if findCondition(jobStatus, conditionType) == nil {
+		return
+	}
+	conditions := make([]apiv1.JobCondition, 0, len(jobStatus.Conditions)-1)
+	for _, condition := range jobStatus.Conditions {
+		if condition.Type != conditionType {
+			conditions = append(conditions, condition)
+		}
+	}
+	jobStatus.Conditions = conditions
+}
@@ -0,0 +1,123 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	volcanov1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+
+	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	commonutil "github.com/kubeflow/training-operator/pkg/util"
+	testjobv1 "github.com/kubeflow/training-operator/test_job/apis/test_job/v1"
+)
+
+func newUnschedulablePod(name, message string) *corev1.Pod {
+	pod := newPod(name, corev1.PodPending)
+	pod.Status.Conditions = []corev1.PodCondition{{
+		Type:    corev1.PodScheduled,
+		Status:  corev1.ConditionFalse,
+		Reason:  corev1.PodReasonUnschedulable,
+		Message: message,
+	}}
+	return pod
+}
+
+func TestUpdateQueuedCondition(t *testing.T) {
+	job := &testjobv1.TestJob{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: metav1.NamespaceDefault}}
+	recorder := record.NewFakeRecorder(10)
+	jc := &JobController{
+		Controller: &testJobController{frameworkController{framework: "test-framework"}},
+		Recorder:   recorder,
+	}
+	jobStatus := &apiv1.JobStatus{}
+	commonutil.UpdateJobConditions(jobStatus, apiv1.JobCreated, corev1.ConditionTrue, "", "", nil)
+	pg := &volcanov1beta1.PodGroup{Status: volcanov1beta1.PodGroupStatus{
+		Phase: volcanov1beta1.PodGroupPending,
+		Conditions: []volcanov1beta1.PodGroupCondition{{
+			Type:    volcanov1beta1.PodGroupUnschedulableType,
+			Status:  corev1.ConditionTrue,
+			Message: "queue resource quota insufficient",
+		}},
+	}}
+
+	for i := 0; i < 2; i++ {
+		jc.updateQueuedCondition(job, job, jobStatus, pg, true)
+	}
+	if !commonutil.IsQueued(*jobStatus) {
+		t.Fatalf("Expected a Queued condition, got: %v", jobStatus.Conditions)
+	}
+	want := "TestJob test is queued until its PodGroup is admitted by the gang scheduler. queue resource quota insufficient"
+	if got := findCondition(jobStatus, apiv1.JobQueued).Message; got != want {
+		t.Errorf("Unexpected message, want: %q, got: %q", want, got)
+	}
+	// The job is reported once while it is queued.
+	if got := len(recorder.Events); got != 1 {
+		t.Errorf("Unexpected number of events, want: 1, got: %d", got)
+	}
+
+	jc.updateQueuedCondition(job, job, jobStatus, pg, false)
+	if findCondition(jobStatus, apiv1.JobQueued) != nil || len(jobStatus.Conditions) != 1 {
+		t.Errorf("Expected the Queued condition to be removed, got: %v", jobStatus.Conditions)
+	}
+}
+
+func TestUpdateSchedulingCondition(t *testing.T) {
+	job := &testjobv1.TestJob{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: metav1.NamespaceDefault}}
+	recorder := record.NewFakeRecorder(10)
+	jc := &JobController{
+		Controller: &testJobController{frameworkController{framework: "test-framework"}},
+		Recorder:   recorder,
+	}
+	jobStatus := &apiv1.JobStatus{}
+	scheduled := newPod("test-worker-0", corev1.PodRunning)
+	scheduled.Spec.NodeName = "node"
+	pods := []*corev1.Pod{
+		scheduled,
+		newUnschedulablePod("test-worker-2", "0/3 nodes are available: 3 Insufficient cpu."),
+		newUnschedulablePod("test-worker-1", "0/3 nodes are available: 3 Insufficient memory."),
+	}
+
+	jc.updateSchedulingCondition(job, job, jobStatus, pods)
+	if !commonutil.IsScheduling(*jobStatus) {
+		t.Fatalf("Expected a Scheduling condition, got: %v", jobStatus.Conditions)
+	}
+	condition := findCondition(jobStatus, apiv1.JobScheduling)
+	if want := "TestJobUnschedulable"; condition.Reason != want {
+		t.Errorf("Unexpected reason, want: %q, got: %q", want, condition.Reason)
+	}
+	want := "2 pod(s) of TestJob test are unschedulable. Pod test-worker-1: 0/3 nodes are available: 3 Insufficient memory."
+	if condition.Message != want {
+		t.Errorf("Unexpected message, want: %q, got: %q", want, condition.Message)
+	}
+
+	// The message follows the pods which are still unschedulable.
+	jc.updateSchedulingCondition(job, job, jobStatus, pods[:2])
+	want = "1 pod(s) of TestJob test are unschedulable. Pod test-worker-2: 0/3 nodes are available: 3 Insufficient cpu."
+	if got := findCondition(jobStatus, apiv1.JobScheduling).Message; got != want {
+		t.Errorf("Unexpected message, want: %q, got: %q", want, got)
+	}
+	if got := len(recorder.Events); got != 1 {
+		t.Errorf("Unexpected number of events, want: 1, got: %d", got)
+	}
+
+	jc.updateSchedulingCondition(job, job, jobStatus, pods[:1])
+	if len(jobStatus.Conditions) != 0 {
+		t.Errorf("Expected the Scheduling condition to be removed, got: %v", jobStatus.Conditions)
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	volcanov1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	volcanofake "volcano.sh/apis/pkg/client/clientset/versioned/fake"
+
+	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	"github.com/kubeflow/training-operator/pkg/controller.v1/control"
+	testjobv1 "github.com/kubeflow/training-operator/test_job/apis/test_job/v1"
+)
+
+func TestCleanupPodGroup(t *testing.T) {
+	volcanoRecord := &apiv1.GangSchedulingStatus{SchedulerName: string(GangSchedulerVolcano), MinMember: 2}
+	cases := map[string]struct {
+		gangScheduling    GangScheduler
+		noPodGroupClients bool
+		gangStatus        *apiv1.GangSchedulingStatus
+		wantDeleted       bool
+	}{
+		"gang scheduling disabled without a recorded PodGroup": {},
+		"gang scheduling disabled with a recorded PodGroup": {
+			gangStatus:  volcanoRecord,
+			wantDeleted: true,
+		},
+		"gang scheduling disabled with a recorded PodGroup and no client": {
+			noPodGroupClients: true,
+			gangStatus:        volcanoRecord,
+		},
+		"gang scheduling enabled without a recorded PodGroup": {
+			gangScheduling: GangSchedulerVolcano,
+			wantDeleted:    true,
+		},
+		"gang scheduling enabled with a recorded PodGroup": {
+			gangScheduling: GangSchedulerVolcano,
+			gangStatus:     volcanoRecord,
+			wantDeleted:    true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			job := &testjobv1.TestJob{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: metav1.NamespaceDefault}}
+			vci := volcanofake.NewSimpleClientset(&volcanov1beta1.PodGroup{
+				ObjectMeta: metav1.ObjectMeta{Name: job.Name, Namespace: job.Namespace},
+			})
+			jc := &JobController{
+				Config:   JobControllerConfiguration{GangScheduling: tc.gangScheduling},
+				Recorder: record.NewFakeRecorder(10),
+			}
+			if tc.gangScheduling == GangSchedulerVolcano {
+				jc.PodGroupControl = control.NewVolcanoControl(vci)
+			}
+			if !tc.noPodGroupClients {
+				jc.PodGroupClients = PodGroupClients{Volcano: vci}
+			}
+			jobStatus := &apiv1.JobStatus{GangScheduling: tc.gangStatus.DeepCopy()}
+
+			if err := jc.CleanupPodGroup(job, job, jobStatus); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			_, err := vci.SchedulingV1beta1().PodGroups(job.Namespace).Get(context.Background(), job.Name, metav1.GetOptions{})
+			if deleted := k8serrors.IsNotFound(err); deleted != tc.wantDeleted {
+				t.Errorf("Unexpected deletion of the PodGroup, want: %t, got: %t", tc.wantDeleted, deleted)
+			}
+			if diff := cmp.Diff((*apiv1.GangSchedulingStatus)(nil), jobStatus.GangScheduling); len(diff) != 0 {
+				t.Errorf("Unexpected recorded PodGroup (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestKindInstalled(t *testing.T) {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(SchedulerPluginsPodGroupGVK, meta.RESTScopeNamespace)
+
+	for gangScheduler, want := range map[GangScheduler]bool{
+		GangSchedulerVolcano:          false,
+		"Volcano":                     false,
+		GangSchedulerSchedulerPlugins: true,
+		"custom-scheduler":            true,
+	} {
+		installed, err := KindInstalled(mapper, PodGroupGVK(gangScheduler))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if installed != want {
+			t.Errorf("Unexpected installation of the PodGroups of %s, want: %v, got: %v", gangScheduler, want, installed)
+		}
+	}
+}
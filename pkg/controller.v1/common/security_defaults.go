@@ -0,0 +1,73 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/kubeflow/training-operator/pkg/config"
+)
+
+// capabilityAll is the capability dropped from the containers by the restricted security defaults.
+const capabilityAll corev1.Capability = "ALL"
+
+// SetSecurityDefaults sets the security context fields required by the restricted Pod Security
+// Standard which the pod template leaves unset, if the --restricted-security-defaults flag of the
+// operator is set: runAsNonRoot and the RuntimeDefault seccomp profile in the pod security context,
+// and no privilege escalation and the ALL capabilities dropped in the security context of every
+// container. The fields set by the user, including to less restrictive values, are kept.
+// It is called once all the containers of the pod, including the ones injected by the operator,
+// are in the template.
+func SetSecurityDefaults(podTemplate *corev1.PodTemplateSpec) {
+	if !config.Config.RestrictedSecurityDefaults {
+		return
+	}
+	if podTemplate.Spec.SecurityContext == nil {
+		podTemplate.Spec.SecurityContext = &corev1.PodSecurityContext{}
+	}
+	podSecurityContext := podTemplate.Spec.SecurityContext
+	if podSecurityContext.RunAsNonRoot == nil {
+		podSecurityContext.RunAsNonRoot = ptr.To(true)
+	}
+	if podSecurityContext.SeccompProfile == nil {
+		podSecurityContext.SeccompProfile = &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault}
+	}
+	for i := range podTemplate.Spec.InitContainers {
+		setContainerSecurityDefaults(&podTemplate.Spec.InitContainers[i])
+	}
+	for i := range podTemplate.Spec.Containers {
+		setContainerSecurityDefaults(&podTemplate.Spec.Containers[i])
+	}
+}
+
+// setContainerSecurityDefaults sets the fields of the security context of the container required
+// by the restricted Pod Security Standard which the container leaves unset. The capabilities added
+// by the user are kept, and ALL are dropped unless the user drops some capabilities already.
+func setContainerSecurityDefaults(container *corev1.Container) {
+	if container.SecurityContext == nil {
+		container.SecurityContext = &corev1.SecurityContext{}
+	}
+	securityContext := container.SecurityContext
+	if securityContext.AllowPrivilegeEscalation == nil {
+		securityContext.AllowPrivilegeEscalation = ptr.To(false)
+	}
+	if securityContext.Capabilities == nil {
+		securityContext.Capabilities = &corev1.Capabilities{}
+	}
+	if len(securityContext.Capabilities.Drop) == 0 {
+		securityContext.Capabilities.Drop = []corev1.Capability{capabilityAll}
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/kubeflow/training-operator/pkg/config"
+)
+
+func TestSetSecurityDefaults(t *testing.T) {
+	defer func(enabled bool) { config.Config.RestrictedSecurityDefaults = enabled }(config.Config.RestrictedSecurityDefaults)
+
+	restricted := &corev1.SecurityContext{
+		AllowPrivilegeEscalation: ptr.To(false),
+		Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+	}
+	cases := map[string]struct {
+		enabled bool
+		spec    corev1.PodSpec
+		want    corev1.PodSpec
+	}{
+		"disabled": {
+			spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "main"}}},
+			want: corev1.PodSpec{Containers: []corev1.Container{{Name: "main"}}},
+		},
+		"defaults are set": {
+			enabled: true,
+			spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{{Name: "init"}},
+				Containers:     []corev1.Container{{Name: "main"}},
+			},
+			want: corev1.PodSpec{
+				SecurityContext: &corev1.PodSecurityContext{
+					RunAsNonRoot:   ptr.To(true),
+					SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+				},
+				InitContainers: []corev1.Container{{Name: "init", SecurityContext: restricted}},
+				Containers:     []corev1.Container{{Name: "main", SecurityContext: restricted}},
+			},
+		},
+		"fields set by the user are kept": {
+			enabled: true,
+			spec: corev1.PodSpec{
+				SecurityContext: &corev1.PodSecurityContext{
+					RunAsNonRoot:   ptr.To(false),
+					SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeUnconfined},
+				},
+				Containers: []corev1.Container{{
+					Name: "main",
+					SecurityContext: &corev1.SecurityContext{
+						AllowPrivilegeEscalation: ptr.To(true),
+						Capabilities:             &corev1.Capabilities{Add: []corev1.Capability{"NET_BIND_SERVICE"}},
+					},
+				}},
+			},
+			want: corev1.PodSpec{
+				SecurityContext: &corev1.PodSecurityContext{
+					RunAsNonRoot:   ptr.To(false),
+					SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeUnconfined},
+				},
+				Containers: []corev1.Container{{
+					Name: "main",
+					SecurityContext: &corev1.SecurityContext{
+						AllowPrivilegeEscalation: ptr.To(true),
+						Capabilities: &corev1.Capabilities{
+							Add:  []corev1.Capability{"NET_BIND_SERVICE"},
+							Drop: []corev1.Capability{"ALL"},
+						},
+					},
+				}},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			config.Config.RestrictedSecurityDefaults = tc.enabled
+			podTemplate := &corev1.PodTemplateSpec{Spec: tc.spec}
+			SetSecurityDefaults(podTemplate)
+			if diff := cmp.Diff(tc.want, podTemplate.Spec); len(diff) != 0 {
+				t.Errorf("Unexpected pod spec (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -15,7 +15,9 @@
 package common
 
 import (
+	"context"
 	"fmt"
+	"maps"
 	"strconv"
 	"strings"
 
@@ -26,9 +28,9 @@ import (
 	commonutil "github.com/kubeflow/training-operator/pkg/util"
 	utillabels "github.com/kubeflow/training-operator/pkg/util/labels"
 
+	"github.com/go-logr/logr"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
-	log "github.com/sirupsen/logrus"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -72,7 +74,7 @@ func (jc *JobController) AddService(obj interface{}) {
 
 		rType, err := utillabels.ReplicaType(service.Labels)
 		if err != nil {
-			log.Infof("This service maybe not created by %v", jc.Controller.ControllerName())
+			commonutil.LoggerForService(service, jc.Controller.GetAPIGroupVersionKind().Kind).Info("This service maybe not created by the controller", "controller", jc.Controller.ControllerName())
 			return
 		}
 
@@ -137,7 +139,7 @@ func (jc *JobController) GetServicesForJob(jobObject interface{}) ([]*v1.Service
 		return fresh, nil
 	})
 	cm := control.NewServiceControllerRefManager(jc.ServiceControl, job, selector, jc.Controller.GetAPIGroupVersionKind(), canAdoptFunc)
-	return cm.ClaimServices(services)
+	return cm.ClaimServices(services, ServiceClaimFilters()...)
 }
 
 // FilterServicesForReplicaType returns service belong to a replicaType.
@@ -148,7 +150,7 @@ func (jc *JobController) FilterServicesForReplicaType(services []*v1.Service, re
 // GetServiceSlices returns a slice, which element is the slice of service.
 // Assume the return object is serviceSlices, then serviceSlices[i] is an
 // array of pointers to services corresponding to Services for replica i.
-func (jc *JobController) GetServiceSlices(services []*v1.Service, replicas int, logger *log.Entry) [][]*v1.Service {
+func (jc *JobController) GetServiceSlices(services []*v1.Service, replicas int, logger logr.Logger) [][]*v1.Service {
 	return core.GetServiceSlices(services, replicas, logger)
 }
 
@@ -175,13 +177,14 @@ func (jc *JobController) ReconcileServices(
 	// If replica is 4, return a slice with size 4. [[0],[1],[2],[]], a svc with replica-index 3 will be created.
 	//
 	// If replica is 1, return a slice with size 3. [[0],[1],[2]], svc with replica-index 1 and 2 are out of range and will be deleted.
-	serviceSlices := jc.GetServiceSlices(services, replicas, commonutil.LoggerForReplica(job, rt))
+	logger := commonutil.LoggerForReplica(job, rt)
+	serviceSlices := jc.GetServiceSlices(services, replicas, logger)
 
 	for index, serviceSlice := range serviceSlices {
 		if len(serviceSlice) > 1 {
-			commonutil.LoggerForReplica(job, rt).Warningf("We have too many services for %s %d", rtype, index)
+			logger.Info("We have too many services for the replica", "index", index)
 		} else if len(serviceSlice) == 0 {
-			commonutil.LoggerForReplica(job, rt).Infof("need to create new service: %s-%d", rtype, index)
+			logger.Info("Need to create new service", "index", index)
 			err = jc.CreateNewService(job, rtype, spec, strconv.Itoa(index))
 			if err != nil {
 				return err
@@ -192,6 +195,7 @@ func (jc *JobController) ReconcileServices(
 
 			// check if the index is in the valid range, if not, we should kill the svc
 			if index < 0 || index >= replicas {
+				jc.RecordAPICall(job, APICallDelete)
 				err = jc.ServiceControl.DeleteService(svc.Namespace, svc.Name, job.(runtime.Object))
 				if err != nil {
 					return err
@@ -241,7 +245,10 @@ func (jc *JobController) CreateNewService(job metav1.Object, rtype apiv1.Replica
 	}
 
 	service.Name = GenGeneralName(job.GetName(), rt, index)
-	service.Labels = labels
+	// The run ID is not part of the selector, so that the services keep selecting the pods
+	// created before it was set or changed.
+	service.Labels = maps.Clone(labels)
+	utillabels.SetRunID(service.Labels, RunID(job))
 	// Create OwnerReference.
 	controllerRef := jc.GenOwnerReference(job)
 
@@ -249,6 +256,7 @@ func (jc *JobController) CreateNewService(job metav1.Object, rtype apiv1.Replica
 	expectationServicesKey := expectation.GenExpectationServicesKey(jobKey, rt)
 	jc.Expectations.RaiseExpectations(expectationServicesKey, 1, 0)
 
+	jc.RecordAPICall(job, APICallCreate)
 	err = jc.ServiceControl.CreateServicesWithControllerRef(job.GetNamespace(), service, job.(runtime.Object), controllerRef)
 	if err != nil && errors.IsTimeout(err) {
 		// Service is created but its initialization has timed out.
@@ -266,7 +274,10 @@ func (jc *JobController) CreateNewService(job metav1.Object, rtype apiv1.Replica
 		// and wait until next reconciliation
 		jc.Expectations.CreationObserved(expectationServicesKey)
 		failedServiceCreationCount.Inc()
-		return err
+		return checkNameCollision(err, job, "Service", service.Name, func() (metav1.Object, error) {
+			jc.RecordAPICall(job, APICallGet)
+			return jc.KubeClientSet.CoreV1().Services(job.GetNamespace()).Get(context.Background(), service.Name, metav1.GetOptions{})
+		})
 	}
 	succeededServiceCreationCount.Inc()
 	return nil
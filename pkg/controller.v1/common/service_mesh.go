@@ -0,0 +1,142 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog/v2"
+
+	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	"github.com/kubeflow/training-operator/pkg/config"
+	commonutil "github.com/kubeflow/training-operator/pkg/util"
+)
+
+// ServiceMeshMode defines how the pods of the jobs are made compatible with a service mesh,
+// whose sidecar would otherwise keep the pods running once the training has finished.
+type ServiceMeshMode string
+
+const (
+	// ServiceMeshModeNone leaves the pods as is.
+	ServiceMeshModeNone ServiceMeshMode = "None"
+	// ServiceMeshModeDisableSidecar disables the injection of the Istio sidecar into the pods.
+	ServiceMeshModeDisableSidecar ServiceMeshMode = "DisableSidecar"
+	// ServiceMeshModeQuitSidecar asks the Istio sidecar of a pod to quit once the other
+	// containers of the pod have terminated.
+	ServiceMeshModeQuitSidecar ServiceMeshMode = "QuitSidecar"
+)
+
+const (
+	// istioSidecarInjectAnnotation is the annotation of the pods which disables the injection
+	// of the Istio sidecar when set to false.
+	istioSidecarInjectAnnotation = "sidecar.istio.io/inject"
+	// istioProxyContainerName is the name of the sidecar container injected by Istio.
+	istioProxyContainerName = "istio-proxy"
+	// quitSidecarTimeout is the time to wait for the Istio sidecar of a pod to accept to quit.
+	quitSidecarTimeout = 5 * time.Second
+)
+
+// quitSidecarCommand asks the Envoy proxy and the agent of the Istio sidecar to exit.
+var quitSidecarCommand = []string{"pilot-agent", "request", "POST", "quitquitquit"}
+
+// ValidServiceMeshMode returns whether mode is a known ServiceMeshMode.
+func ValidServiceMeshMode(mode string) bool {
+	switch ServiceMeshMode(mode) {
+	case ServiceMeshModeNone, ServiceMeshModeDisableSidecar, ServiceMeshModeQuitSidecar:
+		return true
+	}
+	return false
+}
+
+// serviceMeshModeOf returns the ServiceMeshMode set in the ServiceMeshModeAnnotation of the
+// job, or the mode of the operator if the job doesn't set a known mode.
+func serviceMeshModeOf(metaObject metav1.Object) ServiceMeshMode {
+	if mode, ok := metaObject.GetAnnotations()[apiv1.ServiceMeshModeAnnotation]; ok && ValidServiceMeshMode(mode) {
+		return ServiceMeshMode(mode)
+	}
+	if ValidServiceMeshMode(config.Config.ServiceMeshMode) {
+		return ServiceMeshMode(config.Config.ServiceMeshMode)
+	}
+	return ServiceMeshModeNone
+}
+
+// SetServiceMeshAnnotations disables the injection of the Istio sidecar into the pods of the
+// podTemplate in the DisableSidecar mode of the job, unless the podTemplate sets it already.
+func SetServiceMeshAnnotations(podTemplate *corev1.PodTemplateSpec, metaObject metav1.Object) {
+	if serviceMeshModeOf(metaObject) != ServiceMeshModeDisableSidecar {
+		return
+	}
+	if _, ok := podTemplate.Annotations[istioSidecarInjectAnnotation]; ok {
+		return
+	}
+	if podTemplate.Annotations == nil {
+		podTemplate.Annotations = map[string]string{}
+	}
+	podTemplate.Annotations[istioSidecarInjectAnnotation] = "false"
+}
+
+// sidecarToQuit returns whether the Istio sidecar of the running pod is still running while all
+// the other containers of the pod have terminated.
+func sidecarToQuit(pod *corev1.Pod) bool {
+	if pod.Status.Phase != corev1.PodRunning || pod.DeletionTimestamp != nil {
+		return false
+	}
+	sidecarRunning := false
+	others := 0
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == istioProxyContainerName {
+			sidecarRunning = status.State.Running != nil
+			continue
+		}
+		if status.State.Terminated == nil {
+			return false
+		}
+		others++
+	}
+	return sidecarRunning && others > 0
+}
+
+// quitServiceMeshSidecars asks the Istio sidecars of the pods of the job to quit in the QuitSidecar
+// mode of the job, once the other containers of their pods have terminated, so that the pods
+// complete. Failures are recorded as events, and the sidecars are asked again on the next
+// reconciliations as long as they are running.
+func (jc *JobController) quitServiceMeshSidecars(metaObject metav1.Object, runtimeObject runtime.Object, pods []*corev1.Pod) {
+	if jc.PodExecControl == nil || serviceMeshModeOf(metaObject) != ServiceMeshModeQuitSidecar {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), quitSidecarTimeout)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for _, pod := range pods {
+		if !sidecarToQuit(pod) {
+			continue
+		}
+		wg.Add(1)
+		go func(pod *corev1.Pod) {
+			defer wg.Done()
+			if err := jc.PodExecControl.ExecInPod(ctx, pod.Namespace, pod.Name, istioProxyContainerName, quitSidecarCommand); err != nil {
+				jc.Recorder.Eventf(runtimeObject, corev1.EventTypeWarning, commonutil.QuitSidecarFailedReason,
+					"Failed to quit the %s sidecar of pod %v: %v", istioProxyContainerName, klog.KObj(pod), err)
+			}
+		}(pod)
+	}
+	wg.Wait()
+}
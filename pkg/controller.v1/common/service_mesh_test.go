@@ -0,0 +1,125 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	"github.com/kubeflow/training-operator/pkg/config"
+	"github.com/kubeflow/training-operator/pkg/controller.v1/control"
+	testjobv1 "github.com/kubeflow/training-operator/test_job/apis/test_job/v1"
+)
+
+func TestSetServiceMeshAnnotations(t *testing.T) {
+	cases := map[string]struct {
+		operatorMode    ServiceMeshMode
+		jobAnnotations  map[string]string
+		podAnnotations  map[string]string
+		wantAnnotations map[string]string
+	}{
+		"no mode": {
+			operatorMode: ServiceMeshModeNone,
+		},
+		"sidecar disabled for the operator": {
+			operatorMode:    ServiceMeshModeDisableSidecar,
+			wantAnnotations: map[string]string{istioSidecarInjectAnnotation: "false"},
+		},
+		"sidecar disabled for the job": {
+			operatorMode:    ServiceMeshModeNone,
+			jobAnnotations:  map[string]string{apiv1.ServiceMeshModeAnnotation: string(ServiceMeshModeDisableSidecar)},
+			wantAnnotations: map[string]string{istioSidecarInjectAnnotation: "false"},
+		},
+		"sidecar quit for the job": {
+			operatorMode:   ServiceMeshModeDisableSidecar,
+			jobAnnotations: map[string]string{apiv1.ServiceMeshModeAnnotation: string(ServiceMeshModeQuitSidecar)},
+		},
+		"unknown mode of the job": {
+			operatorMode:    ServiceMeshModeDisableSidecar,
+			jobAnnotations:  map[string]string{apiv1.ServiceMeshModeAnnotation: "Unknown"},
+			wantAnnotations: map[string]string{istioSidecarInjectAnnotation: "false"},
+		},
+		"sidecar injection set in the pod template": {
+			operatorMode:    ServiceMeshModeDisableSidecar,
+			podAnnotations:  map[string]string{istioSidecarInjectAnnotation: "true"},
+			wantAnnotations: map[string]string{istioSidecarInjectAnnotation: "true"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			defer func(mode string) { config.Config.ServiceMeshMode = mode }(config.Config.ServiceMeshMode)
+			config.Config.ServiceMeshMode = string(tc.operatorMode)
+			job := &testjobv1.TestJob{ObjectMeta: metav1.ObjectMeta{Name: "test", Annotations: tc.jobAnnotations}}
+			podTemplate := &corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Annotations: tc.podAnnotations}}
+
+			SetServiceMeshAnnotations(podTemplate, job)
+			if diff := cmp.Diff(tc.wantAnnotations, podTemplate.Annotations); len(diff) != 0 {
+				t.Errorf("Unexpected annotations (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func newMeshedPod(name string, trainingTerminated, sidecarRunning bool) *corev1.Pod {
+	pod := newPod(name, corev1.PodRunning)
+	training := corev1.ContainerStatus{Name: "test", State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}}
+	if trainingTerminated {
+		training.State = corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{}}
+	}
+	sidecar := corev1.ContainerStatus{Name: istioProxyContainerName, State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{}}}
+	if sidecarRunning {
+		sidecar.State = corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}
+	}
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{training, sidecar}
+	return pod
+}
+
+func TestQuitServiceMeshSidecars(t *testing.T) {
+	defer func(mode string) { config.Config.ServiceMeshMode = mode }(config.Config.ServiceMeshMode)
+	config.Config.ServiceMeshMode = string(ServiceMeshModeNone)
+	job := &testjobv1.TestJob{ObjectMeta: metav1.ObjectMeta{
+		Name:        "test",
+		Annotations: map[string]string{apiv1.ServiceMeshModeAnnotation: string(ServiceMeshModeQuitSidecar)},
+	}}
+	pods := []*corev1.Pod{
+		newMeshedPod("finished", true, true),
+		newMeshedPod("training", false, true),
+		newMeshedPod("completed", true, false),
+		newPod("unmeshed", corev1.PodRunning),
+	}
+	podExecControl := &control.FakePodExecControl{}
+	jc := &JobController{
+		Controller:     &testJobController{frameworkController{framework: "test-framework"}},
+		PodExecControl: podExecControl,
+		Recorder:       record.NewFakeRecorder(10),
+	}
+
+	jc.quitServiceMeshSidecars(job, job, pods)
+	if diff := cmp.Diff([]string{"finished"}, podExecControl.ExecPodNames); len(diff) != 0 {
+		t.Errorf("Unexpected pods whose sidecar is asked to quit (-want,+got):\n%s", diff)
+	}
+
+	// The sidecars are left running in the other modes.
+	podExecControl.ExecPodNames = nil
+	jc.quitServiceMeshSidecars(&testjobv1.TestJob{ObjectMeta: metav1.ObjectMeta{Name: "test"}}, job, pods)
+	if len(podExecControl.ExecPodNames) != 0 {
+		t.Errorf("Unexpected pods whose sidecar is asked to quit: %v", podExecControl.ExecPodNames)
+	}
+}
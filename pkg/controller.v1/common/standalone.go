@@ -0,0 +1,53 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"slices"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
+
+	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+)
+
+// workerReplicaType is the name of the Worker replica type, shared by all the frameworks.
+const workerReplicaType apiv1.ReplicaType = "Worker"
+
+// collapseStandaloneReplicas collapses the replicas of a standalone job, in place, to a single
+// replica of its primary replica type: the master role if any, otherwise the Worker.
+// The replicas are the ReplicaSpecs of the job, so that the env of the pod is generated
+// from the collapsed topology.
+func collapseStandaloneReplicas(replicas map[apiv1.ReplicaType]*apiv1.ReplicaSpec,
+	isMasterRole func(map[apiv1.ReplicaType]*apiv1.ReplicaSpec, apiv1.ReplicaType, int) bool) {
+	rtypes := sets.List(sets.KeySet(replicas))
+	if len(rtypes) == 0 {
+		return
+	}
+	primary := rtypes[0]
+	if i := slices.IndexFunc(rtypes, func(rtype apiv1.ReplicaType) bool {
+		return isMasterRole(replicas, rtype, 0)
+	}); i >= 0 {
+		primary = rtypes[i]
+	} else if _, ok := replicas[workerReplicaType]; ok {
+		primary = workerReplicaType
+	}
+	spec := replicas[primary].DeepCopy()
+	spec.Replicas = ptr.To[int32](1)
+	for _, rtype := range rtypes {
+		delete(replicas, rtype)
+	}
+	replicas[primary] = spec
+}
@@ -0,0 +1,74 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/utils/ptr"
+
+	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+)
+
+func TestCollapseStandaloneReplicas(t *testing.T) {
+	isMaster := func(_ map[apiv1.ReplicaType]*apiv1.ReplicaSpec, rtype apiv1.ReplicaType, _ int) bool {
+		return rtype == "Master"
+	}
+	cases := map[string]struct {
+		replicas map[apiv1.ReplicaType]*apiv1.ReplicaSpec
+		want     map[apiv1.ReplicaType]*apiv1.ReplicaSpec
+	}{
+		"master is kept": {
+			replicas: map[apiv1.ReplicaType]*apiv1.ReplicaSpec{
+				"Master": {Replicas: ptr.To[int32](1), RestartPolicy: apiv1.RestartPolicyNever},
+				"Worker": {Replicas: ptr.To[int32](4)},
+			},
+			want: map[apiv1.ReplicaType]*apiv1.ReplicaSpec{
+				"Master": {Replicas: ptr.To[int32](1), RestartPolicy: apiv1.RestartPolicyNever},
+			},
+		},
+		"worker is kept without master": {
+			replicas: map[apiv1.ReplicaType]*apiv1.ReplicaSpec{
+				"PS":     {Replicas: ptr.To[int32](2)},
+				"Worker": {Replicas: ptr.To[int32](4)},
+			},
+			want: map[apiv1.ReplicaType]*apiv1.ReplicaSpec{
+				"Worker": {Replicas: ptr.To[int32](1)},
+			},
+		},
+		"first replica type is kept without master and worker": {
+			replicas: map[apiv1.ReplicaType]*apiv1.ReplicaSpec{
+				"Launcher": {Replicas: ptr.To[int32](1)},
+				"Server":   {Replicas: ptr.To[int32](2)},
+			},
+			want: map[apiv1.ReplicaType]*apiv1.ReplicaSpec{
+				"Launcher": {Replicas: ptr.To[int32](1)},
+			},
+		},
+		"no replicas": {
+			replicas: map[apiv1.ReplicaType]*apiv1.ReplicaSpec{},
+			want:     map[apiv1.ReplicaType]*apiv1.ReplicaSpec{},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			collapseStandaloneReplicas(tc.replicas, isMaster)
+			if diff := cmp.Diff(tc.want, tc.replicas); len(diff) != 0 {
+				t.Errorf("Unexpected replicas (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+)
+
+// runPolicyOf returns the RunPolicy of a job of any kind supported by the operator, or nil.
+func runPolicyOf(job interface{}) *apiv1.RunPolicy {
+	switch job := job.(type) {
+	case *apiv1.TFJob:
+		return &job.Spec.RunPolicy
+	case *apiv1.PyTorchJob:
+		return &job.Spec.RunPolicy
+	case *apiv1.MPIJob:
+		return &job.Spec.RunPolicy
+	case *apiv1.XGBoostJob:
+		return &job.Spec.RunPolicy
+	case *apiv1.PaddleJob:
+		return &job.Spec.RunPolicy
+	case *apiv1.JAXJob:
+		return &job.Spec.RunPolicy
+	default:
+		return nil
+	}
+}
+
+// StartWave returns the number of the pods of a replica type of the given replicas which may be
+// created now, given its pods. Without a StartPolicy, all the pods are created at once. Otherwise,
+// the pods of the next wave are created once all the pods created before are ready.
+func StartWave(runPolicy *apiv1.RunPolicy, pods []*corev1.Pod, replicas int) int {
+	if runPolicy == nil || runPolicy.StartPolicy == nil {
+		return replicas
+	}
+	if !PodsReady(pods) {
+		return 0
+	}
+	waveSize, err := intstr.GetScaledValueFromIntOrPercent(&runPolicy.StartPolicy.WaveSize, replicas, true)
+	if err != nil || waveSize < 1 {
+		// The invalid wave sizes are rejected by the webhook, the pods are created one by one.
+		return 1
+	}
+	return waveSize
+}
+
+// PodsReady returns true if all the pods are ready, besides the finished and the deleted ones.
+func PodsReady(pods []*corev1.Pod) bool {
+	for _, pod := range pods {
+		if pod.DeletionTimestamp != nil || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		if !isPodReady(pod) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,69 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+)
+
+func TestStartWave(t *testing.T) {
+	ready := newPod("test-worker-0", corev1.PodRunning)
+	ready.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+	pending := newPod("test-worker-1", corev1.PodPending)
+	failed := newPod("test-worker-2", corev1.PodFailed)
+	waves := &apiv1.RunPolicy{StartPolicy: &apiv1.StartPolicy{WaveSize: intstr.FromString("25%")}}
+
+	cases := map[string]struct {
+		runPolicy *apiv1.RunPolicy
+		pods      []*corev1.Pod
+		want      int
+	}{
+		"all the pods without a start policy": {
+			runPolicy: &apiv1.RunPolicy{},
+			pods:      []*corev1.Pod{pending},
+			want:      10,
+		},
+		"first wave": {
+			runPolicy: waves,
+			want:      3,
+		},
+		"next wave once the pods are ready": {
+			runPolicy: waves,
+			pods:      []*corev1.Pod{ready, failed},
+			want:      3,
+		},
+		"waiting for the pods of the previous wave": {
+			runPolicy: waves,
+			pods:      []*corev1.Pod{ready, pending},
+		},
+		"absolute wave size": {
+			runPolicy: &apiv1.RunPolicy{StartPolicy: &apiv1.StartPolicy{WaveSize: intstr.FromInt32(4)}},
+			pods:      []*corev1.Pod{ready},
+			want:      4,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := StartWave(tc.runPolicy, tc.pods, 10); got != tc.want {
+				t.Errorf("Unexpected number of pods to create, want: %d, got: %d", tc.want, got)
+			}
+		})
+	}
+}
@@ -1,9 +1,18 @@
 package common
 
 import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
 	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	trainingoperatorcommon "github.com/kubeflow/training-operator/pkg/common"
 	"github.com/kubeflow/training-operator/pkg/core"
+	commonutil "github.com/kubeflow/training-operator/pkg/util"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // initializeReplicaStatuses initializes the ReplicaStatuses for replica.
@@ -11,7 +20,187 @@ func initializeReplicaStatuses(jobStatus *apiv1.JobStatus, rtype apiv1.ReplicaTy
 	core.InitializeReplicaStatuses(jobStatus, rtype)
 }
 
+// genReplicaSelector returns the label selector of the pods of the replica type rt of a job,
+// in the format of ReplicaStatus.Selector.
+func (jc *JobController) genReplicaSelector(jobName, rt string) string {
+	labels := jc.GenLabels(jobName)
+	labels[apiv1.ReplicaTypeLabel] = rt
+	return metav1.FormatLabelSelector(&metav1.LabelSelector{MatchLabels: labels})
+}
+
 // updateJobReplicaStatuses updates the JobReplicaStatuses according to the pod.
 func updateJobReplicaStatuses(jobStatus *apiv1.JobStatus, rtype apiv1.ReplicaType, pod *corev1.Pod) {
 	core.UpdateJobReplicaStatuses(jobStatus, rtype, pod)
 }
+
+// recordJobCompleted emits a single JobCompleted event with the summary statistics
+// of the job if it has just transitioned into a terminal state.
+func (jc *JobController) recordJobCompleted(object runtime.Object, jobKind, jobName string, oldStatus, newStatus apiv1.JobStatus, pods []*corev1.Pod) {
+	if commonutil.IsFinished(oldStatus) || !commonutil.IsFinished(newStatus) {
+		return
+	}
+	eventType := corev1.EventTypeNormal
+	if commonutil.IsFailed(newStatus) {
+		eventType = corev1.EventTypeWarning
+	}
+	jc.Recorder.Event(object, eventType, commonutil.JobCompletedReason, jobCompletedMessage(jobKind, jobName, newStatus, pods, jc.Clock))
+}
+
+// recordJobMetrics observes the latency from the creation of the job to its first Running condition
+// and the run duration of the job, when the job has just transitioned into these states.
+func recordJobMetrics(metaObject metav1.Object, framework string, oldStatus, newStatus apiv1.JobStatus, clock *commonutil.Clock) {
+	// The Running condition of a reconstructed status does not tell when the job started running.
+	if runningTime := firstRunningTime(oldStatus, newStatus); runningTime != nil && !isStatusReconstructed(newStatus) {
+		trainingoperatorcommon.JobQueueToRunningDurationObserve(metaObject.GetNamespace(), framework,
+			clock.Between(metaObject.GetCreationTimestamp().Time, runningTime.Time))
+	}
+	if commonutil.IsFinished(oldStatus) || !commonutil.IsFinished(newStatus) ||
+		newStatus.StartTime == nil || newStatus.CompletionTime == nil {
+		return
+	}
+	result := "succeeded"
+	if commonutil.IsFailed(newStatus) {
+		result = "failed"
+	}
+	trainingoperatorcommon.JobRunDurationObserve(metaObject.GetNamespace(), framework, result,
+		clock.Between(newStatus.StartTime.Time, newStatus.CompletionTime.Time))
+}
+
+// firstRunningTime returns the time of the Running condition of the new status if the job was never
+// running in the old status, so that the restarts of the job are not observed.
+func firstRunningTime(oldStatus, newStatus apiv1.JobStatus) *metav1.Time {
+	for _, condition := range oldStatus.Conditions {
+		if condition.Type == apiv1.JobRunning {
+			return nil
+		}
+	}
+	for _, condition := range newStatus.Conditions {
+		if condition.Type == apiv1.JobRunning && condition.Status == corev1.ConditionTrue {
+			return &condition.LastTransitionTime
+		}
+	}
+	return nil
+}
+
+// reconstructJobStatus rebuilds the status of a job whose status was cleared, for example
+// by a user or a GitOps prune of the status subresource, while the pods of the job are
+// still around. The Created condition is restored with the StatusReconstructed reason and
+// the creation time of the job, and the StartTime is set to the creation time of the
+// oldest pod, so that the active deadline keeps counting from the original start. The
+// replica statuses and the other conditions are recomputed from the pods by the rest of
+// the reconciliation.
+//
+// A job with no pods left, e.g. a finished job whose pods were cleaned up, is restored from
+// terminalEvent instead, its last JobSucceeded or JobFailed audit event, so that it is not run
+// again. It returns whether the status was reconstructed.
+func reconstructJobStatus(jobStatus *apiv1.JobStatus, metaObject metav1.Object, jobKind string, pods []*corev1.Pod, terminalEvent *corev1.Event, clock *commonutil.Clock) bool {
+	if len(jobStatus.Conditions) != 0 || jobStatus.StartTime != nil || (len(pods) == 0 && terminalEvent == nil) {
+		return false
+	}
+	now := clock.MetaNow()
+	jobStatus.Conditions = []apiv1.JobCondition{{
+		Type:               apiv1.JobCreated,
+		Status:             corev1.ConditionTrue,
+		Reason:             commonutil.NewReason(jobKind, commonutil.JobStatusReconstructedReason),
+		Message:            fmt.Sprintf("%s %s status is reconstructed from %s.", jobKind, metaObject.GetName(), reconstructionSource(pods, terminalEvent)),
+		LastUpdateTime:     now,
+		LastTransitionTime: metaObject.GetCreationTimestamp(),
+	}}
+	if len(pods) == 0 {
+		conditionType, reason := apiv1.JobSucceeded, commonutil.NewReason(jobKind, commonutil.JobSucceededReason)
+		if terminalEvent.Reason == commonutil.AuditJobFailedReason {
+			conditionType, reason = apiv1.JobFailed, commonutil.NewReason(jobKind, commonutil.JobFailedReason)
+		}
+		if eventReason := terminalEvent.Annotations[commonutil.AuditReasonAnnotation]; eventReason != "" {
+			reason = eventReason
+		}
+		completionTime := now
+		if t, err := time.Parse(time.RFC3339, terminalEvent.Annotations[commonutil.AuditTransitionTimeAnnotation]); err == nil {
+			completionTime = metav1.NewTime(t)
+		}
+		jobStatus.CompletionTime = &completionTime
+		jobStatus.Conditions = append(jobStatus.Conditions, apiv1.JobCondition{
+			Type:               conditionType,
+			Status:             corev1.ConditionTrue,
+			Reason:             reason,
+			Message:            fmt.Sprintf("%s %s is finished, its pods are gone.", jobKind, metaObject.GetName()),
+			LastUpdateTime:     now,
+			LastTransitionTime: completionTime,
+		})
+		return true
+	}
+	startTime := pods[0].CreationTimestamp
+	for _, pod := range pods[1:] {
+		if pod.CreationTimestamp.Before(&startTime) {
+			startTime = pod.CreationTimestamp
+		}
+	}
+	jobStatus.StartTime = &startTime
+	return true
+}
+
+// reconstructionSource describes what the status of a job is reconstructed from: its pods, or
+// its terminal audit event if it has no pods.
+func reconstructionSource(pods []*corev1.Pod, terminalEvent *corev1.Event) string {
+	if len(pods) == 0 && terminalEvent != nil {
+		return fmt.Sprintf("its %s event", terminalEvent.Reason)
+	}
+	return fmt.Sprintf("%d existing pods", len(pods))
+}
+
+// isStatusReconstructed checks if the status of the job was rebuilt by reconstructJobStatus.
+func isStatusReconstructed(jobStatus apiv1.JobStatus) bool {
+	for _, condition := range jobStatus.Conditions {
+		if condition.Type == apiv1.JobCreated {
+			return strings.HasSuffix(condition.Reason, commonutil.JobStatusReconstructedReason)
+		}
+	}
+	return false
+}
+
+// jobCompletedMessage summarizes the duration, restarts, final replica counts and
+// the failure, if any, of a finished job.
+func jobCompletedMessage(jobKind, jobName string, jobStatus apiv1.JobStatus, pods []*corev1.Pod, clock *commonutil.Clock) string {
+	result := apiv1.JobSucceeded
+	if commonutil.IsFailed(jobStatus) {
+		result = apiv1.JobFailed
+	}
+
+	duration := "unknown"
+	if jobStatus.StartTime != nil && jobStatus.CompletionTime != nil {
+		duration = clock.Between(jobStatus.StartTime.Time, jobStatus.CompletionTime.Time).Round(time.Second).String()
+	}
+
+	var restarts int32
+	for _, pod := range pods {
+		for _, status := range pod.Status.ContainerStatuses {
+			restarts += status.RestartCount
+		}
+	}
+
+	rtypes := make([]string, 0, len(jobStatus.ReplicaStatuses))
+	for rtype := range jobStatus.ReplicaStatuses {
+		rtypes = append(rtypes, string(rtype))
+	}
+	sort.Strings(rtypes)
+	replicas := make([]string, 0, len(rtypes))
+	for _, rtype := range rtypes {
+		status := jobStatus.ReplicaStatuses[apiv1.ReplicaType(rtype)]
+		if status == nil {
+			continue
+		}
+		replicas = append(replicas, fmt.Sprintf("%s(active=%d, succeeded=%d, failed=%d)",
+			rtype, status.Active, status.Succeeded, status.Failed))
+	}
+
+	msg := fmt.Sprintf("%s %s %s: duration=%s, restarts=%d, replicas=[%s]",
+		jobKind, jobName, strings.ToLower(string(result)), duration, restarts, strings.Join(replicas, ", "))
+	if result == apiv1.JobFailed {
+		for _, condition := range jobStatus.Conditions {
+			if condition.Type == apiv1.JobFailed {
+				msg = fmt.Sprintf("%s, failure=%q", msg, condition.Message)
+			}
+		}
+	}
+	return msg
+}
@@ -5,9 +5,11 @@ import (
 	"time"
 
 	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	commonutil "github.com/kubeflow/training-operator/pkg/util"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 )
 
 func TestUpdateJobReplicaStatuses(t *testing.T) {
@@ -47,3 +49,256 @@ func setStatusForTest(jobStatus *apiv1.JobStatus, rtype apiv1.ReplicaType, faile
 		updateJobReplicaStatuses(jobStatus, rtype, &pod)
 	}
 }
+
+func TestUpdateJobReplicaStatusesFailureReason(t *testing.T) {
+	terminated := func(reason string) []corev1.ContainerStatus {
+		return []corev1.ContainerStatus{{Name: "test", State: corev1.ContainerState{
+			Terminated: &corev1.ContainerStateTerminated{ExitCode: 137, Reason: reason},
+		}}}
+	}
+	cases := map[string]struct {
+		status     corev1.PodStatus
+		wantReason apiv1.PodFailureReason
+	}{
+		"running pod": {
+			status: corev1.PodStatus{Phase: corev1.PodRunning},
+		},
+		"OOMKilled container": {
+			status:     corev1.PodStatus{Phase: corev1.PodFailed, ContainerStatuses: terminated("OOMKilled")},
+			wantReason: apiv1.PodFailureReasonOOMKilled,
+		},
+		"pod evicted by the kubelet": {
+			status:     corev1.PodStatus{Phase: corev1.PodFailed, Reason: "Evicted"},
+			wantReason: apiv1.PodFailureReasonEvicted,
+		},
+		"pod evicted through the eviction API": {
+			status: corev1.PodStatus{Phase: corev1.PodFailed, ContainerStatuses: terminated("Error"), Conditions: []corev1.PodCondition{{
+				Type: corev1.DisruptionTarget, Status: corev1.ConditionTrue, Reason: "EvictionByEvictionAPI",
+			}}},
+			wantReason: apiv1.PodFailureReasonEvicted,
+		},
+		"pod of a lost node": {
+			status: corev1.PodStatus{Phase: corev1.PodFailed, Conditions: []corev1.PodCondition{{
+				Type: corev1.DisruptionTarget, Status: corev1.ConditionTrue, Reason: "DeletionByTaintManager",
+			}}},
+			wantReason: apiv1.PodFailureReasonNodeNotReady,
+		},
+		"pending pod whose image cannot be pulled": {
+			status: corev1.PodStatus{Phase: corev1.PodPending, ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "test", State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff"}},
+			}}},
+			wantReason: apiv1.PodFailureReasonImagePullBackOff,
+		},
+		"non-zero exit code": {
+			status:     corev1.PodStatus{Phase: corev1.PodFailed, ContainerStatuses: terminated("Error")},
+			wantReason: apiv1.PodFailureReasonError,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			jobStatus := apiv1.JobStatus{}
+			initializeReplicaStatuses(&jobStatus, "worker")
+			updateJobReplicaStatuses(&jobStatus, "worker", &corev1.Pod{Status: tc.status})
+			assert.Equal(t, tc.wantReason, jobStatus.ReplicaStatuses["worker"].FailureReason)
+		})
+	}
+}
+
+func TestUpdateJobReplicaStatusesPods(t *testing.T) {
+	jobStatus := apiv1.JobStatus{}
+	initializeReplicaStatuses(&jobStatus, "worker")
+	updateJobReplicaStatuses(&jobStatus, "worker", &corev1.Pod{
+		ObjectMeta: metaV1.ObjectMeta{Name: "test-worker-0"},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning, HostIP: "10.0.0.1", PodIP: "192.168.0.1"},
+	})
+	updateJobReplicaStatuses(&jobStatus, "worker", &corev1.Pod{
+		ObjectMeta: metaV1.ObjectMeta{Name: "test-worker-1"},
+		Status:     corev1.PodStatus{Phase: corev1.PodPending},
+	})
+	want := []apiv1.ReplicaPodStatus{
+		{Name: "test-worker-0", Phase: corev1.PodRunning, HostIP: "10.0.0.1", PodIP: "192.168.0.1"},
+		{Name: "test-worker-1", Phase: corev1.PodPending},
+	}
+	assert.Equal(t, want, jobStatus.ReplicaStatuses["worker"].Pods)
+}
+
+func TestGenReplicaSelector(t *testing.T) {
+	jc := &JobController{Controller: &testJobController{frameworkController{framework: "test-framework"}}}
+	want := "training.kubeflow.org/job-name=test,training.kubeflow.org/operator-name=test-operator,training.kubeflow.org/replica-type=worker"
+	assert.Equal(t, want, jc.genReplicaSelector("test", "worker"))
+}
+
+func TestRecordJobCompleted(t *testing.T) {
+	startTime := metaV1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	completionTime := metaV1.NewTime(startTime.Add(90 * time.Second))
+	pods := []*corev1.Pod{
+		{Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{RestartCount: 2}}}},
+		{Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{RestartCount: 1}}}},
+	}
+	replicaStatuses := map[apiv1.ReplicaType]*apiv1.ReplicaStatus{
+		"Worker": {Succeeded: 1, Failed: 1},
+		"Master": {Succeeded: 1},
+	}
+	cases := map[string]struct {
+		oldStatus apiv1.JobStatus
+		newStatus apiv1.JobStatus
+		wantEvent string
+	}{
+		"running job": {
+			newStatus: apiv1.JobStatus{
+				Conditions: []apiv1.JobCondition{{Type: apiv1.JobRunning, Status: corev1.ConditionTrue}},
+			},
+		},
+		"job has succeeded": {
+			newStatus: apiv1.JobStatus{
+				Conditions:      []apiv1.JobCondition{{Type: apiv1.JobSucceeded, Status: corev1.ConditionTrue}},
+				ReplicaStatuses: replicaStatuses,
+				StartTime:       &startTime,
+				CompletionTime:  &completionTime,
+			},
+			wantEvent: "Normal JobCompleted TFJob default/test succeeded: duration=1m30s, restarts=3, replicas=[Master(active=0, succeeded=1, failed=0), Worker(active=0, succeeded=1, failed=1)]",
+		},
+		"job has failed": {
+			newStatus: apiv1.JobStatus{
+				Conditions:      []apiv1.JobCondition{{Type: apiv1.JobFailed, Status: corev1.ConditionTrue, Message: "backoff limit"}},
+				ReplicaStatuses: replicaStatuses,
+				StartTime:       &startTime,
+			},
+			wantEvent: `Warning JobCompleted TFJob default/test failed: duration=unknown, restarts=3, replicas=[Master(active=0, succeeded=1, failed=0), Worker(active=0, succeeded=1, failed=1)], failure="backoff limit"`,
+		},
+		"job was already finished": {
+			oldStatus: apiv1.JobStatus{
+				Conditions: []apiv1.JobCondition{{Type: apiv1.JobSucceeded, Status: corev1.ConditionTrue}},
+			},
+			newStatus: apiv1.JobStatus{
+				Conditions: []apiv1.JobCondition{{Type: apiv1.JobSucceeded, Status: corev1.ConditionTrue}},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			recorder := record.NewFakeRecorder(1)
+			jc := &JobController{Recorder: recorder}
+			jc.recordJobCompleted(&apiv1.TFJob{}, apiv1.TFJobKind, "default/test", tc.oldStatus, tc.newStatus, pods)
+			var got string
+			select {
+			case got = <-recorder.Events:
+			default:
+			}
+			assert.Equal(t, tc.wantEvent, got)
+		})
+	}
+}
+
+func TestFirstRunningTime(t *testing.T) {
+	runningTime := metaV1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	cases := map[string]struct {
+		oldStatus apiv1.JobStatus
+		newStatus apiv1.JobStatus
+		want      *metaV1.Time
+	}{
+		"job is created": {
+			newStatus: apiv1.JobStatus{
+				Conditions: []apiv1.JobCondition{{Type: apiv1.JobCreated, Status: corev1.ConditionTrue}},
+			},
+		},
+		"job is running for the first time": {
+			oldStatus: apiv1.JobStatus{
+				Conditions: []apiv1.JobCondition{{Type: apiv1.JobCreated, Status: corev1.ConditionTrue}},
+			},
+			newStatus: apiv1.JobStatus{
+				Conditions: []apiv1.JobCondition{
+					{Type: apiv1.JobCreated, Status: corev1.ConditionTrue},
+					{Type: apiv1.JobRunning, Status: corev1.ConditionTrue, LastTransitionTime: runningTime},
+				},
+			},
+			want: &runningTime,
+		},
+		"job is running again after a restart": {
+			oldStatus: apiv1.JobStatus{
+				Conditions: []apiv1.JobCondition{
+					{Type: apiv1.JobRunning, Status: corev1.ConditionFalse},
+					{Type: apiv1.JobRestarting, Status: corev1.ConditionTrue},
+				},
+			},
+			newStatus: apiv1.JobStatus{
+				Conditions: []apiv1.JobCondition{
+					{Type: apiv1.JobRestarting, Status: corev1.ConditionFalse},
+					{Type: apiv1.JobRunning, Status: corev1.ConditionTrue, LastTransitionTime: runningTime},
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, firstRunningTime(tc.oldStatus, tc.newStatus))
+		})
+	}
+}
+
+func TestReconstructJobStatus(t *testing.T) {
+	creationTime := metaV1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	firstPodTime := metaV1.NewTime(creationTime.Add(10 * time.Second))
+	secondPodTime := metaV1.NewTime(creationTime.Add(20 * time.Second))
+	job := &metaV1.ObjectMeta{Name: "test", CreationTimestamp: creationTime}
+	pods := []*corev1.Pod{
+		{ObjectMeta: metaV1.ObjectMeta{CreationTimestamp: secondPodTime}},
+		{ObjectMeta: metaV1.ObjectMeta{CreationTimestamp: firstPodTime}},
+	}
+	completionTime := metaV1.NewTime(creationTime.Add(time.Hour))
+	failedEvent := &corev1.Event{
+		ObjectMeta: metaV1.ObjectMeta{Annotations: map[string]string{
+			commonutil.AuditReasonAnnotation:         "TestJobFailed",
+			commonutil.AuditTransitionTimeAnnotation: completionTime.UTC().Format(time.RFC3339),
+		}},
+		Reason: commonutil.AuditJobFailedReason,
+	}
+	cases := map[string]struct {
+		jobStatus         apiv1.JobStatus
+		pods              []*corev1.Pod
+		terminalEvent     *corev1.Event
+		wantReconstructed bool
+		wantCondition     apiv1.JobConditionType
+	}{
+		"status is cleared while pods exist": {
+			pods:              pods,
+			wantReconstructed: true,
+		},
+		"job has no pods yet": {},
+		"status is cleared once the pods of the finished job are gone": {
+			terminalEvent:     failedEvent,
+			wantReconstructed: true,
+			wantCondition:     apiv1.JobFailed,
+		},
+		"status has conditions": {
+			jobStatus: apiv1.JobStatus{
+				Conditions: []apiv1.JobCondition{{Type: apiv1.JobCreated, Status: corev1.ConditionTrue}},
+			},
+			pods: pods,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			jobStatus := tc.jobStatus.DeepCopy()
+			assert.Equal(t, tc.wantReconstructed, reconstructJobStatus(jobStatus, job, "TestJob", tc.pods, tc.terminalEvent, nil))
+			assert.Equal(t, tc.wantReconstructed, isStatusReconstructed(*jobStatus))
+			if !tc.wantReconstructed {
+				assert.Equal(t, tc.jobStatus, *jobStatus)
+				return
+			}
+			assert.Equal(t, apiv1.JobCreated, jobStatus.Conditions[0].Type)
+			assert.Equal(t, "TestJobStatusReconstructed", jobStatus.Conditions[0].Reason)
+			assert.Equal(t, creationTime, jobStatus.Conditions[0].LastTransitionTime)
+			if tc.wantCondition == "" {
+				assert.Equal(t, &firstPodTime, jobStatus.StartTime)
+				assert.Len(t, jobStatus.Conditions, 1)
+				return
+			}
+			assert.Len(t, jobStatus.Conditions, 2)
+			assert.Equal(t, tc.wantCondition, jobStatus.Conditions[1].Type)
+			assert.Equal(t, "TestJobFailed", jobStatus.Conditions[1].Reason)
+			assert.True(t, completionTime.Equal(jobStatus.CompletionTime))
+			assert.True(t, commonutil.IsFinished(*jobStatus))
+		})
+	}
+}
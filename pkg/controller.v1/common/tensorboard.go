@@ -0,0 +1,325 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	"github.com/kubeflow/training-operator/pkg/common"
+	"github.com/kubeflow/training-operator/pkg/config"
+	commonutil "github.com/kubeflow/training-operator/pkg/util"
+	trainutil "github.com/kubeflow/training-operator/pkg/util/train"
+)
+
+const (
+	// tensorBoardPort is the port TensorBoard listens on, and the port of its Service.
+	tensorBoardPort = 6006
+	// tensorBoardContainerName is the name of the TensorBoard container and of its port.
+	tensorBoardContainerName = "tensorboard"
+	// tensorBoardLogsVolumeName is the name of the volume of the PersistentVolumeClaim of the logs.
+	tensorBoardLogsVolumeName = "logs"
+	// tensorBoardLogsMountPath is where the PersistentVolumeClaim of the logs is mounted.
+	tensorBoardLogsMountPath = "/logs"
+)
+
+// GenTensorBoardName returns the name of the Deployment and of the Service of the TensorBoard
+// of the job.
+func GenTensorBoardName(jobName string) string {
+	return jobName + "-tensorboard"
+}
+
+// GenTensorBoardURL returns the in-cluster URL of the TensorBoard of the job.
+func GenTensorBoardURL(namespace, jobName string) string {
+	return fmt.Sprintf("http://%s.%s.svc:%d", GenTensorBoardName(jobName), namespace, tensorBoardPort)
+}
+
+// tensorBoardSpec returns the TensorBoard requested by the job, or nil.
+func (jc *JobController) tensorBoardSpec(job interface{}) *apiv1.TensorBoardSpec {
+	getter, ok := jc.Controller.(common.TensorBoardGetter)
+	if !ok {
+		return nil
+	}
+	return getter.GetTensorBoard(job)
+}
+
+// tensorBoardLabels returns the labels of the pods of the TensorBoard of the job, which are
+// distinct from the labels of the pods of the job, so that they are not counted as its replicas.
+func tensorBoardLabels(jobName string) map[string]string {
+	return map[string]string{apiv1.TensorBoardLabel: jobName}
+}
+
+// TensorBoardSelector selects the Deployments and the Services of the TensorBoards of the jobs, so
+// that the operator caches the Deployments of the TensorBoards rather than all the Deployments.
+func TensorBoardSelector() labels.Selector {
+	requirement, _ := labels.NewRequirement(apiv1.TensorBoardLabel, selection.Exists, nil)
+	return labels.NewSelector().Add(*requirement)
+}
+
+// tensorBoardLogDir returns the volume of the PersistentVolumeClaim of the logDir, if any, and
+// the directory of the logs read by TensorBoard.
+func tensorBoardLogDir(logDir string) (*corev1.Volume, string, error) {
+	scheme, location, _ := strings.Cut(logDir, "://")
+	switch {
+	case scheme == "s3" && location != "":
+		return nil, logDir, nil
+	case scheme == "pvc" && location != "":
+		claimName, subPath, _ := strings.Cut(location, "/")
+		if claimName == "" {
+			break
+		}
+		volume := &corev1.Volume{
+			Name: tensorBoardLogsVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: claimName, ReadOnly: true},
+			},
+		}
+		return volume, path.Join(tensorBoardLogsMountPath, subPath), nil
+	}
+	return nil, "", fmt.Errorf("invalid logDir %q of the TensorBoard, expected pvc://<claim-name>/<path> or s3://<bucket>/<path>", logDir)
+}
+
+// newTensorBoardDeployment returns the Deployment of the TensorBoard of the job.
+func (jc *JobController) newTensorBoardDeployment(metaObject metav1.Object, spec *apiv1.TensorBoardSpec) (*appsv1.Deployment, error) {
+	volume, logDir, err := tensorBoardLogDir(spec.LogDir)
+	if err != nil {
+		return nil, err
+	}
+	image := spec.Image
+	if image == "" {
+		image = config.Config.TensorBoardImage
+	}
+	container := corev1.Container{
+		Name:    tensorBoardContainerName,
+		Image:   image,
+		Command: []string{"tensorboard"},
+		Args:    []string{"--logdir=" + logDir, "--bind_all", fmt.Sprintf("--port=%d", tensorBoardPort)},
+		Env:     spec.Env,
+		Ports:   []corev1.ContainerPort{{Name: tensorBoardContainerName, ContainerPort: tensorBoardPort}},
+	}
+	podSpec := corev1.PodSpec{Containers: []corev1.Container{container}}
+	if volume != nil {
+		podSpec.Volumes = []corev1.Volume{*volume}
+		podSpec.Containers[0].VolumeMounts = []corev1.VolumeMount{{Name: volume.Name, MountPath: tensorBoardLogsMountPath, ReadOnly: true}}
+	}
+	labels := tensorBoardLabels(metaObject.GetName())
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            GenTensorBoardName(metaObject.GetName()),
+			Namespace:       metaObject.GetNamespace(),
+			Labels:          labels,
+			OwnerReferences: []metav1.OwnerReference{*jc.GenOwnerReference(metaObject)},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To[int32](1),
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec:       podSpec,
+			},
+		},
+	}, nil
+}
+
+// newTensorBoardService returns the Service of the TensorBoard of the job.
+func (jc *JobController) newTensorBoardService(metaObject metav1.Object) *corev1.Service {
+	labels := tensorBoardLabels(metaObject.GetName())
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            GenTensorBoardName(metaObject.GetName()),
+			Namespace:       metaObject.GetNamespace(),
+			Labels:          labels,
+			OwnerReferences: []metav1.OwnerReference{*jc.GenOwnerReference(metaObject)},
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: labels,
+			Ports: []corev1.ServicePort{{
+				Name:       tensorBoardContainerName,
+				Port:       tensorBoardPort,
+				TargetPort: intstr.FromInt32(tensorBoardPort),
+			}},
+		},
+	}
+}
+
+// ReconcileTensorBoard creates the Deployment and the Service of the TensorBoard requested by the
+// job, or patches them if they drifted from the spec of the job, and sets its URL in the status of
+// the job. They are controlled by the job, so that they are garbage collected with the job.
+func (jc *JobController) ReconcileTensorBoard(runtimeObject runtime.Object, metaObject metav1.Object, jobStatus *apiv1.JobStatus) error {
+	spec := jc.tensorBoardSpec(runtimeObject)
+	if spec == nil {
+		return nil
+	}
+	if jc.TensorBoardClient == nil {
+		return fmt.Errorf("the TensorBoard of %s/%s can not be created without a client", metaObject.GetNamespace(), metaObject.GetName())
+	}
+	deployment, err := jc.newTensorBoardDeployment(metaObject, spec)
+	if err != nil {
+		jc.Recorder.Eventf(runtimeObject, corev1.EventTypeWarning, commonutil.FailedCreateTensorBoardReason, "Error creating: %v", err)
+		return err
+	}
+	for _, obj := range []client.Object{deployment, jc.newTensorBoardService(metaObject)} {
+		if err := jc.reconcileTensorBoardObject(runtimeObject, metaObject, obj); err != nil {
+			return err
+		}
+	}
+	jobStatus.TensorBoardURL = GenTensorBoardURL(metaObject.GetNamespace(), metaObject.GetName())
+	return nil
+}
+
+// reconcileTensorBoardObject creates the Deployment or the Service of the TensorBoard of the job,
+// or patches it if it drifted from obj. The Deployments of the cache are only the ones of the
+// TensorBoards, so an existing Deployment of another owner is only found when it is created.
+func (jc *JobController) reconcileTensorBoardObject(runtimeObject runtime.Object, metaObject metav1.Object, obj client.Object) error {
+	kind := tensorBoardObjectKind(obj)
+	existing := obj.DeepCopyObject().(client.Object)
+	err := jc.TensorBoardClient.Get(context.Background(), client.ObjectKeyFromObject(obj), existing)
+	if err == nil {
+		if !metav1.IsControlledBy(existing, metaObject) {
+			return tensorBoardCollisionError(kind, obj.GetName())
+		}
+		return jc.patchTensorBoardObject(runtimeObject, metaObject, obj, existing)
+	}
+	if !errors.IsNotFound(err) {
+		return err
+	}
+	jc.RecordAPICall(metaObject, APICallCreate)
+	if err := jc.TensorBoardClient.Create(context.Background(), obj); err != nil {
+		if errors.IsAlreadyExists(err) {
+			return tensorBoardCollisionError(kind, obj.GetName())
+		}
+		jc.Recorder.Eventf(runtimeObject, corev1.EventTypeWarning, commonutil.FailedCreateTensorBoardReason, "Error creating %s: %v", kind, err)
+		return err
+	}
+	jc.Recorder.Eventf(runtimeObject, corev1.EventTypeNormal, commonutil.SuccessfulCreateTensorBoardReason, "Created %s: %v", kind, obj.GetName())
+	return nil
+}
+
+// patchTensorBoardObject patches the pod template of the existing Deployment, or the selector and
+// the ports of the existing Service, of the TensorBoard of the job if they drifted from the ones
+// of desired, e.g. after an edit of the spec.tensorboard of the job. The fields defaulted by the
+// API server and the other fields, e.g. the replicas of the Deployment, are kept.
+func (jc *JobController) patchTensorBoardObject(runtimeObject runtime.Object, metaObject metav1.Object, desired, existing client.Object) error {
+	patch := client.MergeFrom(existing.DeepCopyObject().(client.Object))
+	switch existing := existing.(type) {
+	case *appsv1.Deployment:
+		template := desired.(*appsv1.Deployment).Spec.Template
+		if tensorBoardTemplateMatches(template, existing.Spec.Template) {
+			return nil
+		}
+		existing.Spec.Template = template
+	case *corev1.Service:
+		spec := desired.(*corev1.Service).Spec
+		if equality.Semantic.DeepEqual(spec.Selector, existing.Spec.Selector) && equality.Semantic.DeepDerivative(spec.Ports, existing.Spec.Ports) {
+			return nil
+		}
+		existing.Spec.Selector = spec.Selector
+		existing.Spec.Ports = spec.Ports
+	}
+	kind := tensorBoardObjectKind(existing)
+	jc.RecordAPICall(metaObject, APICallUpdate)
+	if err := jc.TensorBoardClient.Patch(context.Background(), existing, patch); err != nil {
+		jc.Recorder.Eventf(runtimeObject, corev1.EventTypeWarning, commonutil.FailedUpdateTensorBoardReason, "Error updating %s: %v", kind, err)
+		return err
+	}
+	jc.Recorder.Eventf(runtimeObject, corev1.EventTypeNormal, commonutil.SuccessfulUpdateTensorBoardReason, "Updated %s: %v", kind, existing.GetName())
+	return nil
+}
+
+// tensorBoardTemplateMatches returns whether the pod template of an existing Deployment matches
+// the desired one, ignoring the fields defaulted by the API server. The environment variables and
+// the volumes are compared exactly, since the ones removed from the spec.tensorboard of the job
+// are not set in the desired template.
+func tensorBoardTemplateMatches(desired, existing corev1.PodTemplateSpec) bool {
+	if !equality.Semantic.DeepDerivative(desired, existing) ||
+		!equality.Semantic.DeepEqual(desired.Labels, existing.Labels) ||
+		len(desired.Spec.Containers) != len(existing.Spec.Containers) ||
+		len(desired.Spec.Volumes) != len(existing.Spec.Volumes) {
+		return false
+	}
+	for i := range desired.Spec.Containers {
+		d, e := desired.Spec.Containers[i], existing.Spec.Containers[i]
+		if len(d.Env) != len(e.Env) || len(d.VolumeMounts) != len(e.VolumeMounts) {
+			return false
+		}
+	}
+	return true
+}
+
+// tensorBoardCollisionError returns the error of a Deployment or a Service of the name of the
+// TensorBoard of a job which is not controlled by the job.
+func tensorBoardCollisionError(kind, name string) error {
+	if StrictOwnership() {
+		return &NameCollisionError{Kind: kind, Name: name}
+	}
+	return fmt.Errorf("%s %s already exists and is not controlled by the job", kind, name)
+}
+
+// DeleteTensorBoard deletes the Deployment and the Service of the TensorBoard of the job, once
+// the job is finished or suspended, and clears its URL in the status of the job. Like the pods
+// of the job, the TensorBoard of a finished job is kept if its CleanPodPolicy is None.
+func (jc *JobController) DeleteTensorBoard(runtimeObject runtime.Object, metaObject metav1.Object, runPolicy *apiv1.RunPolicy, jobStatus *apiv1.JobStatus) error {
+	if jc.TensorBoardClient == nil || jc.tensorBoardSpec(runtimeObject) == nil {
+		return nil
+	}
+	if !trainutil.IsJobSuspended(runPolicy) && runPolicy.CleanPodPolicy != nil && *runPolicy.CleanPodPolicy == apiv1.CleanPodPolicyNone {
+		return nil
+	}
+	for _, obj := range []client.Object{&appsv1.Deployment{}, &corev1.Service{}} {
+		kind := tensorBoardObjectKind(obj)
+		key := client.ObjectKey{Namespace: metaObject.GetNamespace(), Name: GenTensorBoardName(metaObject.GetName())}
+		err := jc.TensorBoardClient.Get(context.Background(), key, obj)
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if !metav1.IsControlledBy(obj, metaObject) {
+			continue
+		}
+		jc.RecordAPICall(metaObject, APICallDelete)
+		if err := jc.TensorBoardClient.Delete(context.Background(), obj, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !errors.IsNotFound(err) {
+			jc.Recorder.Eventf(runtimeObject, corev1.EventTypeWarning, commonutil.FailedDeleteTensorBoardReason, "Error deleting %s: %v", kind, err)
+			return err
+		}
+		jc.Recorder.Eventf(runtimeObject, corev1.EventTypeNormal, commonutil.SuccessfulDeleteTensorBoardReason, "Deleted %s: %v", kind, obj.GetName())
+	}
+	jobStatus.TensorBoardURL = ""
+	return nil
+}
+
+// tensorBoardObjectKind returns the kind of the Deployment or the Service of a TensorBoard.
+func tensorBoardObjectKind(obj client.Object) string {
+	if _, ok := obj.(*appsv1.Deployment); ok {
+		return "Deployment"
+	}
+	return "Service"
+}
@@ -0,0 +1,263 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+)
+
+func (c *pytorchJobController) GetTensorBoard(job interface{}) *apiv1.TensorBoardSpec {
+	return job.(*apiv1.PyTorchJob).Spec.TensorBoard
+}
+
+func TestTensorBoardLogDir(t *testing.T) {
+	cases := map[string]struct {
+		logDir     string
+		wantClaim  string
+		wantLogDir string
+		wantErr    bool
+	}{
+		"pvc": {
+			logDir:     "pvc://logs/runs/mnist",
+			wantClaim:  "logs",
+			wantLogDir: "/logs/runs/mnist",
+		},
+		"pvc root": {
+			logDir:     "pvc://logs",
+			wantClaim:  "logs",
+			wantLogDir: "/logs",
+		},
+		"s3": {
+			logDir:     "s3://bucket/runs/mnist",
+			wantLogDir: "s3://bucket/runs/mnist",
+		},
+		"no claim": {
+			logDir:  "pvc:///runs",
+			wantErr: true,
+		},
+		"unknown scheme": {
+			logDir:  "gs://bucket/runs",
+			wantErr: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			volume, logDir, err := tensorBoardLogDir(tc.logDir)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Unexpected error, want error: %t, got: %v", tc.wantErr, err)
+			}
+			var claim string
+			if volume != nil {
+				claim = volume.PersistentVolumeClaim.ClaimName
+			}
+			if claim != tc.wantClaim || logDir != tc.wantLogDir {
+				t.Errorf("Unexpected claim and logDir, want: %q, %q, got: %q, %q", tc.wantClaim, tc.wantLogDir, claim, logDir)
+			}
+		})
+	}
+}
+
+func TestTensorBoard(t *testing.T) {
+	job := newPriorityPreemptionJob(metav1.NamespaceDefault, "test", "", "1", time.Now(), apiv1.JobCreated)
+	job.UID = "job-uid"
+	job.Spec.TensorBoard = &apiv1.TensorBoardSpec{LogDir: "pvc://logs/mnist", Image: "tensorflow/tensorflow:2.16.1"}
+	c := fake.NewClientBuilder().Build()
+	jc := &JobController{
+		Controller:           &pytorchJobController{frameworkController{framework: "pytorch"}},
+		Recorder:             record.NewFakeRecorder(10),
+		JobControllerOptions: JobControllerOptions{TensorBoardClient: c},
+	}
+	key := client.ObjectKey{Namespace: job.Namespace, Name: GenTensorBoardName(job.Name)}
+	jobStatus := &apiv1.JobStatus{}
+
+	if err := jc.ReconcileTensorBoard(job, job, jobStatus); err != nil {
+		t.Fatalf("Unexpected error creating the TensorBoard: %v", err)
+	}
+	wantURL := "http://test-tensorboard.default.svc:6006"
+	if jobStatus.TensorBoardURL != wantURL {
+		t.Errorf("Unexpected URL of the TensorBoard, want: %q, got: %q", wantURL, jobStatus.TensorBoardURL)
+	}
+	deployment := &appsv1.Deployment{}
+	if err := c.Get(context.Background(), key, deployment); err != nil {
+		t.Fatalf("Failed to get the Deployment: %v", err)
+	}
+	if !metav1.IsControlledBy(deployment, job) {
+		t.Errorf("Expected the Deployment to be controlled by the job")
+	}
+	wantPodSpec := corev1.PodSpec{
+		Containers: []corev1.Container{{
+			Name:         "tensorboard",
+			Image:        "tensorflow/tensorflow:2.16.1",
+			Command:      []string{"tensorboard"},
+			Args:         []string{"--logdir=/logs/mnist", "--bind_all", "--port=6006"},
+			Ports:        []corev1.ContainerPort{{Name: "tensorboard", ContainerPort: 6006}},
+			VolumeMounts: []corev1.VolumeMount{{Name: "logs", MountPath: "/logs", ReadOnly: true}},
+		}},
+		Volumes: []corev1.Volume{{
+			Name: "logs",
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "logs", ReadOnly: true},
+			},
+		}},
+	}
+	if diff := cmp.Diff(wantPodSpec, deployment.Spec.Template.Spec); len(diff) != 0 {
+		t.Errorf("Unexpected pod spec of the TensorBoard (-want,+got):\n%s", diff)
+	}
+	if _, ok := deployment.Spec.Template.Labels[apiv1.JobNameLabel]; ok {
+		t.Errorf("Expected the pods of the TensorBoard not to be labeled as the pods of the job, got: %v", deployment.Spec.Template.Labels)
+	}
+	service := &corev1.Service{}
+	if err := c.Get(context.Background(), key, service); err != nil {
+		t.Fatalf("Failed to get the Service: %v", err)
+	}
+	if diff := cmp.Diff(deployment.Spec.Template.Labels, service.Spec.Selector); len(diff) != 0 {
+		t.Errorf("Unexpected selector of the Service (-want,+got):\n%s", diff)
+	}
+
+	// The existing TensorBoard is left untouched, including the fields defaulted by the API server.
+	deployment.Spec.Template.Spec.Containers[0].TerminationMessagePath = corev1.TerminationMessagePathDefault
+	deployment.Spec.Replicas = ptr.To[int32](2)
+	if err := c.Update(context.Background(), deployment); err != nil {
+		t.Fatalf("Failed to update the Deployment: %v", err)
+	}
+	if err := jc.ReconcileTensorBoard(job, job, jobStatus); err != nil {
+		t.Fatalf("Unexpected error reconciling the existing TensorBoard: %v", err)
+	}
+	if err := c.Get(context.Background(), key, deployment); err != nil {
+		t.Fatalf("Failed to get the Deployment: %v", err)
+	}
+	if path := deployment.Spec.Template.Spec.Containers[0].TerminationMessagePath; path != corev1.TerminationMessagePathDefault {
+		t.Errorf("Expected the defaulted fields of the Deployment to be kept, got: %q", path)
+	}
+
+	// The TensorBoard which drifted from the spec of the job is patched.
+	job.Spec.TensorBoard.Image = "tensorflow/tensorflow:2.17.0"
+	service.Spec.Selector = map[string]string{"app": "other"}
+	if err := c.Update(context.Background(), service); err != nil {
+		t.Fatalf("Failed to update the Service: %v", err)
+	}
+	if err := jc.ReconcileTensorBoard(job, job, jobStatus); err != nil {
+		t.Fatalf("Unexpected error reconciling the drifted TensorBoard: %v", err)
+	}
+	if err := c.Get(context.Background(), key, deployment); err != nil {
+		t.Fatalf("Failed to get the Deployment: %v", err)
+	}
+	if image := deployment.Spec.Template.Spec.Containers[0].Image; image != job.Spec.TensorBoard.Image {
+		t.Errorf("Unexpected image of the patched Deployment, want: %q, got: %q", job.Spec.TensorBoard.Image, image)
+	}
+	if replicas := ptr.Deref(deployment.Spec.Replicas, 0); replicas != 2 {
+		t.Errorf("Expected the replicas of the Deployment to be kept, got: %d", replicas)
+	}
+	if err := c.Get(context.Background(), key, service); err != nil {
+		t.Fatalf("Failed to get the Service: %v", err)
+	}
+	if diff := cmp.Diff(deployment.Spec.Template.Labels, service.Spec.Selector); len(diff) != 0 {
+		t.Errorf("Unexpected selector of the patched Service (-want,+got):\n%s", diff)
+	}
+
+	// The TensorBoard of a finished job is kept with the CleanPodPolicy None.
+	job.Spec.RunPolicy.CleanPodPolicy = ptr.To(apiv1.CleanPodPolicyNone)
+	if err := jc.DeleteTensorBoard(job, job, &job.Spec.RunPolicy, jobStatus); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := c.Get(context.Background(), key, deployment); err != nil {
+		t.Errorf("Expected the Deployment to be kept, got: %v", err)
+	}
+
+	job.Spec.RunPolicy.CleanPodPolicy = ptr.To(apiv1.CleanPodPolicyAll)
+	if err := jc.DeleteTensorBoard(job, job, &job.Spec.RunPolicy, jobStatus); err != nil {
+		t.Fatalf("Unexpected error deleting the TensorBoard: %v", err)
+	}
+	if err := c.Get(context.Background(), key, &appsv1.Deployment{}); !k8serrors.IsNotFound(err) {
+		t.Errorf("Expected the Deployment to be deleted, got: %v", err)
+	}
+	if err := c.Get(context.Background(), key, &corev1.Service{}); !k8serrors.IsNotFound(err) {
+		t.Errorf("Expected the Service to be deleted, got: %v", err)
+	}
+	if jobStatus.TensorBoardURL != "" {
+		t.Errorf("Expected the URL of the TensorBoard to be cleared, got: %q", jobStatus.TensorBoardURL)
+	}
+}
+
+func TestTensorBoardNotRequested(t *testing.T) {
+	job := newPriorityPreemptionJob(metav1.NamespaceDefault, "test", "", "1", time.Now(), apiv1.JobCreated)
+	jc := &JobController{
+		Controller: &pytorchJobController{frameworkController{framework: "pytorch"}},
+		Recorder:   record.NewFakeRecorder(10),
+	}
+	jobStatus := &apiv1.JobStatus{}
+	if err := jc.ReconcileTensorBoard(job, job, jobStatus); err != nil || jobStatus.TensorBoardURL != "" {
+		t.Errorf("Unexpected result for a job without TensorBoard, URL: %q, error: %v", jobStatus.TensorBoardURL, err)
+	}
+
+	// A TensorBoard can not be created without a client.
+	job.Spec.TensorBoard = &apiv1.TensorBoardSpec{LogDir: "s3://bucket/runs"}
+	if err := jc.ReconcileTensorBoard(job, job, jobStatus); err == nil {
+		t.Errorf("Expected an error without a client")
+	}
+}
+
+func TestTensorBoardNameCollision(t *testing.T) {
+	job := newPriorityPreemptionJob(metav1.NamespaceDefault, "test", "", "1", time.Now(), apiv1.JobCreated)
+	job.Spec.TensorBoard = &apiv1.TensorBoardSpec{LogDir: "s3://bucket/runs"}
+	// The Deployment of another owner is not labeled, so it is not in the cache of the operator.
+	existing := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: job.Namespace, Name: GenTensorBoardName(job.Name)}}
+	c := fake.NewClientBuilder().WithObjects(existing).WithInterceptorFuncs(interceptor.Funcs{
+		Get: func(ctx context.Context, cl client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			if _, ok := obj.(*appsv1.Deployment); ok {
+				return k8serrors.NewNotFound(appsv1.Resource("deployments"), key.Name)
+			}
+			return cl.Get(ctx, key, obj, opts...)
+		},
+	}).Build()
+	jc := &JobController{
+		Controller:           &pytorchJobController{frameworkController{framework: "pytorch"}},
+		Recorder:             record.NewFakeRecorder(10),
+		JobControllerOptions: JobControllerOptions{TensorBoardClient: c},
+	}
+	jobStatus := &apiv1.JobStatus{}
+	if err := jc.ReconcileTensorBoard(job, job, jobStatus); err == nil {
+		t.Errorf("Expected an error for the Deployment of another owner")
+	}
+	if jobStatus.TensorBoardURL != "" {
+		t.Errorf("Unexpected URL of the TensorBoard: %q", jobStatus.TensorBoardURL)
+	}
+}
+
+func TestTensorBoardSelector(t *testing.T) {
+	selector := TensorBoardSelector()
+	if !selector.Matches(labels.Set(tensorBoardLabels("test"))) {
+		t.Errorf("Expected the selector %v to match the TensorBoards", selector)
+	}
+	if selector.Matches(labels.Set{apiv1.JobNameLabel: "test"}) {
+		t.Errorf("Expected the selector %v not to match the pods of the jobs", selector)
+	}
+}
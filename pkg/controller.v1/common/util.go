@@ -20,17 +20,19 @@ import (
 	"strings"
 
 	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
-	log "github.com/sirupsen/logrus"
 	v1 "k8s.io/api/core/v1"
 	schedulingv1 "k8s.io/api/scheduling/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 // ReplicasPriority is a slice of ReplicaPriority.
 type ReplicasPriority []ReplicaPriority
 
 type ReplicaPriority struct {
-	priority int32
+	priority    int32
+	replicaType apiv1.ReplicaType
+	masterRole  bool
 
 	apiv1.ReplicaSpec
 }
@@ -39,8 +41,16 @@ func (p ReplicasPriority) Len() int {
 	return len(p)
 }
 
+// Less orders the replicas by decreasing priority, then the master roles first, e.g. the MPI
+// launcher, then by replica type, so that the same replicas are accounted in every reconciliation.
 func (p ReplicasPriority) Less(i, j int) bool {
-	return p[i].priority > p[j].priority
+	if p[i].priority != p[j].priority {
+		return p[i].priority > p[j].priority
+	}
+	if p[i].masterRole != p[j].masterRole {
+		return p[i].masterRole
+	}
+	return p[i].replicaType < p[j].replicaType
 }
 
 func (p ReplicasPriority) Swap(i, j int) {
@@ -103,17 +113,82 @@ func AddResourceList(list, req, limit v1.ResourceList) {
 	}
 }
 
+// PodRequests returns the resources requested by a pod of the spec as accounted by the scheduler:
+// the largest of the requests of its containers and of each of its init containers, plus its
+// overhead. The restartable init containers run alongside the other containers, and the requests
+// of a container default to its limits.
+func PodRequests(spec *v1.PodSpec) v1.ResourceList {
+	requests := v1.ResourceList{}
+	initRequests := v1.ResourceList{}
+	sidecarRequests := v1.ResourceList{}
+	for i := range spec.InitContainers {
+		container := &spec.InitContainers[i]
+		if container.RestartPolicy != nil && *container.RestartPolicy == v1.ContainerRestartPolicyAlways {
+			addResources(sidecarRequests, containerRequests(container))
+			continue
+		}
+		// An init container runs with the restartable init containers started before it.
+		containerInitRequests := containerRequests(container)
+		addResources(containerInitRequests, sidecarRequests)
+		maxResources(initRequests, containerInitRequests)
+	}
+	for i := range spec.Containers {
+		addResources(requests, containerRequests(&spec.Containers[i]))
+	}
+	addResources(requests, sidecarRequests)
+	maxResources(requests, initRequests)
+	addResources(requests, spec.Overhead)
+	return requests
+}
+
+// containerRequests returns the requests of the container, defaulting to its limits.
+func containerRequests(container *v1.Container) v1.ResourceList {
+	requests := container.Resources.Requests.DeepCopy()
+	if requests == nil {
+		requests = v1.ResourceList{}
+	}
+	for name, quantity := range container.Resources.Limits {
+		if _, ok := requests[name]; !ok {
+			requests[name] = quantity.DeepCopy()
+		}
+	}
+	return requests
+}
+
+func addResources(list, resources v1.ResourceList) {
+	for name, quantity := range resources {
+		if value, ok := list[name]; ok {
+			value.Add(quantity)
+			list[name] = value
+		} else {
+			list[name] = quantity.DeepCopy()
+		}
+	}
+}
+
+func maxResources(list, resources v1.ResourceList) {
+	for name, quantity := range resources {
+		if value, ok := list[name]; !ok || value.Cmp(quantity) < 0 {
+			list[name] = quantity.DeepCopy()
+		}
+	}
+}
+
 type PriorityClassGetFunc func(string) (*schedulingv1.PriorityClass, error)
 
-func CalcPGMinResources(minMember int32, replicas map[apiv1.ReplicaType]*apiv1.ReplicaSpec, pcGetFunc PriorityClassGetFunc) *v1.ResourceList {
+// CalcPGMinResources returns the resources requested by the minMember pods of the replicas which
+// are scheduled first. isMasterRole tells the master roles among the replica types of the same
+// priority, it may be nil.
+func CalcPGMinResources(minMember int32, replicas map[apiv1.ReplicaType]*apiv1.ReplicaSpec, pcGetFunc PriorityClassGetFunc,
+	isMasterRole func(apiv1.ReplicaType) bool) *v1.ResourceList {
 	var replicasPriority ReplicasPriority
 	for t, replica := range replicas {
-		rp := ReplicaPriority{0, *replica}
+		rp := ReplicaPriority{replicaType: t, masterRole: isMasterRole != nil && isMasterRole(t), ReplicaSpec: *replica}
 		pc := replica.Template.Spec.PriorityClassName
 
 		priorityClass, err := pcGetFunc(pc)
 		if err != nil || priorityClass == nil {
-			log.Warnf("Ignore task %s priority class %s: %v", t, pc, err)
+			log.Log.Info("Ignoring the priority class of the replica", "replicaType", t, "priorityClass", pc, "error", err)
 		} else {
 			rp.priority = priorityClass.Value
 		}
@@ -130,14 +205,13 @@ func CalcPGMinResources(minMember int32, replicas map[apiv1.ReplicaType]*apiv1.R
 			continue
 		}
 
+		podRequests := PodRequests(&task.Template.Spec)
 		for i := int32(0); i < *task.Replicas; i++ {
 			if podCnt >= minMember {
 				break
 			}
 			podCnt++
-			for _, c := range task.Template.Spec.Containers {
-				AddResourceList(minAvailableTasksRes, c.Resources.Requests, c.Resources.Limits)
-			}
+			addResources(minAvailableTasksRes, podRequests)
 		}
 	}
 
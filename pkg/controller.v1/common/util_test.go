@@ -18,6 +18,10 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
 
 	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
 )
@@ -80,3 +84,63 @@ func TestMaxInt(t *testing.T) {
 		assert.Equal(t, tc.expectedMax, result)
 	}
 }
+
+func resourceList(cpu, memory string) corev1.ResourceList {
+	return corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse(cpu),
+		corev1.ResourceMemory: resource.MustParse(memory),
+	}
+}
+
+func TestPodRequests(t *testing.T) {
+	always := corev1.ContainerRestartPolicyAlways
+	spec := &corev1.PodSpec{
+		InitContainers: []corev1.Container{
+			{Name: "sidecar", RestartPolicy: &always, Resources: corev1.ResourceRequirements{Requests: resourceList("100m", "100Mi")}},
+			{Name: "init", Resources: corev1.ResourceRequirements{Limits: resourceList("2", "256Mi")}},
+		},
+		Containers: []corev1.Container{
+			{Name: "main", Resources: corev1.ResourceRequirements{Requests: resourceList("1", "1Gi")}},
+		},
+		Overhead: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("50m")},
+	}
+
+	// The init container requests more cpu than the containers, and less memory.
+	want := resourceList("2150m", "1124Mi")
+	got := PodRequests(spec)
+	for name, quantity := range want {
+		if value := got[name]; value.Cmp(quantity) != 0 {
+			t.Errorf("Unexpected %s requests, want: %s, got: %s", name, quantity.String(), value.String())
+		}
+	}
+}
+
+func TestCalcPGMinResources(t *testing.T) {
+	newReplica := func(replicas int32, cpu string) *apiv1.ReplicaSpec {
+		return &apiv1.ReplicaSpec{
+			Replicas: ptr.To(replicas),
+			Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse(cpu),
+				}}}},
+			}},
+		}
+	}
+	launcher := newReplica(1, "1")
+	launcher.Template.Spec.InitContainers = []corev1.Container{{Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{
+		corev1.ResourceCPU: resource.MustParse("2"),
+	}}}}
+	replicas := map[apiv1.ReplicaType]*apiv1.ReplicaSpec{
+		"Launcher": launcher,
+		"Driver":   newReplica(4, "4"),
+	}
+	pcGetFunc := func(string) (*schedulingv1.PriorityClass, error) { return nil, nil }
+	isMasterRole := func(rtype apiv1.ReplicaType) bool { return rtype == "Launcher" }
+
+	// The master role is accounted first, with its init container, then 2 drivers.
+	got := CalcPGMinResources(3, replicas, pcGetFunc, isMasterRole)
+	want := resource.MustParse("10")
+	if value := (*got)[corev1.ResourceCPU]; value.Cmp(want) != 0 {
+		t.Errorf("Unexpected cpu minResources, want: %s, got: %s", want.String(), value.String())
+	}
+}
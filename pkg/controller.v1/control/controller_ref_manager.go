@@ -19,7 +19,6 @@ import (
 	"sync"
 
 	commonutil "github.com/kubeflow/training-operator/pkg/util"
-	log "github.com/sirupsen/logrus"
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -223,8 +222,8 @@ func (m *PodControllerRefManager) AdoptPod(pod *v1.Pod) error {
 // ReleasePod sends a patch to free the pod from the control of the controller.
 // It returns the error if the patching fails. 404 and 422 errors are ignored.
 func (m *PodControllerRefManager) ReleasePod(pod *v1.Pod) error {
-	log.Infof("patching pod %s_%s to remove its controllerRef to %s/%s:%s",
-		pod.Namespace, pod.Name, m.controllerKind.GroupVersion(), m.controllerKind.Kind, m.Controller.GetName())
+	logger := commonutil.LoggerForPod(pod, m.controllerKind.Kind)
+	logger.Info("Patching pod to remove its controllerRef", "controllerRef", m.Controller.GetName())
 	deleteOwnerRefPatch := fmt.Sprintf(`{"metadata":{"ownerReferences":[{"$patch":"delete","uid":"%s"}],"uid":"%s"}}`, m.Controller.GetUID(), pod.UID)
 	err := m.podControl.PatchPod(pod.Namespace, pod.Name, []byte(deleteOwnerRefPatch))
 	if err != nil {
@@ -355,8 +354,7 @@ func (m *ServiceControllerRefManager) AdoptService(service *v1.Service) error {
 // It returns the error if the patching fails. 404 and 422 errors are ignored.
 func (m *ServiceControllerRefManager) ReleaseService(service *v1.Service) error {
 	logger := commonutil.LoggerForService(service, m.controllerKind.Kind)
-	logger.Infof("patching service %s_%s to remove its controllerRef to %s/%s:%s",
-		service.Namespace, service.Name, m.controllerKind.GroupVersion(), m.controllerKind.Kind, m.Controller.GetName())
+	logger.Info("Patching service to remove its controllerRef", "controllerRef", m.Controller.GetName())
 	deleteOwnerRefPatch := fmt.Sprintf(`{"metadata":{"ownerReferences":[{"$patch":"delete","uid":"%s"}],"uid":"%s"}}`, m.Controller.GetUID(), service.UID)
 	err := m.serviceControl.PatchService(service.Namespace, service.Name, []byte(deleteOwnerRefPatch))
 	if err != nil {
@@ -32,22 +32,6 @@ import (
 	"k8s.io/client-go/tools/record"
 )
 
-// Reasons for pod events
-const (
-	// FailedCreatePodReason is added in an event and in a job condition
-	// when a pod for a replica set is failed to be created.
-	FailedCreatePodReason = "FailedCreatePod"
-	// SuccessfulCreatePodReason is added in an event when a pod for a job
-	// is successfully created.
-	SuccessfulCreatePodReason = "SuccessfulCreatePod"
-	// FailedDeletePodReason is added in an event and in a job condition
-	// when a pod for a replica set is failed to be deleted.
-	FailedDeletePodReason = "FailedDeletePod"
-	// SuccessfulDeletePodReason is added in an event when a pod for a job
-	// is successfully deleted.
-	SuccessfulDeletePodReason = "SuccessfulDeletePod"
-)
-
 // PodControlInterface is an interface that knows how to add or delete pods
 // created as an interface to allow testing.
 type PodControlInterface interface {
@@ -150,16 +134,16 @@ func (r RealPodControl) createPods(nodeName, namespace string, template *v1.PodT
 	}
 	logger := commonutil.LoggerForPod(pod, object.GetObjectKind().GroupVersionKind().Kind)
 	if newPod, err := r.KubeClient.CoreV1().Pods(namespace).Create(context.TODO(), pod, metav1.CreateOptions{}); err != nil {
-		r.Recorder.Eventf(object, v1.EventTypeWarning, FailedCreatePodReason, "Error creating: %v", err)
+		commonutil.RelatedEventf(r.Recorder, object, nil, v1.EventTypeWarning, commonutil.FailedCreatePodReason, commonutil.EventActionCreate, "Error creating: %v", err)
 		return err
 	} else {
 		accessor, err := meta.Accessor(object)
 		if err != nil {
-			logger.Errorf("parentObject does not have ObjectMeta, %v", err)
+			logger.Error(err, "parentObject does not have ObjectMeta")
 			return nil
 		}
-		logger.Infof("Controller %v created pod %v", accessor.GetName(), newPod.Name)
-		r.Recorder.Eventf(object, v1.EventTypeNormal, SuccessfulCreatePodReason, "Created pod: %v", newPod.Name)
+		logger.Info("Controller created pod", "controller", accessor.GetName())
+		commonutil.RelatedEventf(r.Recorder, object, newPod, v1.EventTypeNormal, commonutil.SuccessfulCreatePodReason, commonutil.EventActionCreate, "Created pod: %v", newPod.Name)
 	}
 	return nil
 }
@@ -178,16 +162,16 @@ func (r RealPodControl) DeletePod(namespace string, podID string, object runtime
 		return err
 	}
 	if pod.DeletionTimestamp != nil {
-		logger.Infof("pod %s/%s is terminating, skip deleting", pod.Namespace, pod.Name)
+		logger.Info("Pod is terminating, skip deleting", "pod", podID)
 		return nil
 	}
-	logger.Infof("Controller %v deleting pod %v/%v", accessor.GetName(), namespace, podID)
+	logger.Info("Controller deleting pod", "pod", podID)
 	// delete options
 	if err := r.KubeClient.CoreV1().Pods(namespace).Delete(context.TODO(), podID, metav1.DeleteOptions{}); err != nil {
-		r.Recorder.Eventf(object, v1.EventTypeWarning, FailedDeletePodReason, "Error deleting: %v", err)
+		commonutil.RelatedEventf(r.Recorder, object, pod, v1.EventTypeWarning, commonutil.FailedDeletePodReason, commonutil.EventActionDelete, "Error deleting: %v", err)
 		return fmt.Errorf("unable to delete pods: %v", err)
 	} else {
-		r.Recorder.Eventf(object, v1.EventTypeNormal, SuccessfulDeletePodReason, "Deleted pod: %v", podID)
+		commonutil.RelatedEventf(r.Recorder, object, pod, v1.EventTypeNormal, commonutil.SuccessfulDeletePodReason, commonutil.EventActionDelete, "Deleted pod: %v", podID)
 	}
 	return nil
 }
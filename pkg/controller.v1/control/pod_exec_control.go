@@ -0,0 +1,87 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package control
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// PodExecControlInterface is an interface that knows how to execute commands
+// in the containers of running pods, created as an interface to allow testing.
+type PodExecControlInterface interface {
+	// ExecInPod executes the command in the container of the pod and waits for it to complete.
+	ExecInPod(ctx context.Context, namespace, podName, containerName string, command []string) error
+}
+
+// RealPodExecControl is the default implementation of PodExecControlInterface.
+// It executes commands through the pods/exec subresource, the same way as `kubectl exec`.
+type RealPodExecControl struct {
+	Config     *rest.Config
+	KubeClient clientset.Interface
+}
+
+var _ PodExecControlInterface = &RealPodExecControl{}
+
+func (r RealPodExecControl) ExecInPod(ctx context.Context, namespace, podName, containerName string, command []string) error {
+	req := r.KubeClient.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(namespace).
+		Name(podName).
+		SubResource("exec").
+		VersionedParams(&v1.PodExecOptions{
+			Container: containerName,
+			Command:   command,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+	executor, err := remotecommand.NewSPDYExecutor(r.Config, "POST", req.URL())
+	if err != nil {
+		return err
+	}
+	var stderr bytes.Buffer
+	err = executor.StreamWithContext(ctx, remotecommand.StreamOptions{
+		Stdout: &bytes.Buffer{},
+		Stderr: &stderr,
+	})
+	if err != nil && stderr.Len() > 0 {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return err
+}
+
+type FakePodExecControl struct {
+	sync.Mutex
+	ExecPodNames []string
+	Errs         map[string]error
+}
+
+var _ PodExecControlInterface = &FakePodExecControl{}
+
+func (f *FakePodExecControl) ExecInPod(_ context.Context, _, podName, _ string, _ []string) error {
+	f.Lock()
+	defer f.Unlock()
+	f.ExecPodNames = append(f.ExecPodNames, podName)
+	return f.Errs[podName]
+}
@@ -40,8 +40,9 @@ type PodGroupControlInterface interface {
 	GetPodGroup(namespace string, name string) (metav1.Object, error)
 	// DeletePodGroup deletes the PodGroup identified by namespace and name.
 	DeletePodGroup(namespace string, name string) error
-	// UpdatePodGroup updates a PodGroup.
-	UpdatePodGroup(podGroup client.Object) error
+	// PatchPodGroup patches a PodGroup with its changes from oldPodGroup, so that the fields
+	// which are not set by the operator are kept.
+	PatchPodGroup(oldPodGroup, podGroup client.Object) error
 	// CreatePodGroup creates a new PodGroup with PodGroup spec fill function.
 	CreatePodGroup(podGroup client.Object) error
 	// DelayPodCreationDueToPodGroup determines whether it should delay Pod Creation.
@@ -102,9 +103,13 @@ func (v *VolcanoControl) DeletePodGroup(namespace string, name string) error {
 	return v.Client.SchedulingV1beta1().PodGroups(namespace).Delete(context.TODO(), name, metav1.DeleteOptions{})
 }
 
-func (v *VolcanoControl) UpdatePodGroup(podGroup client.Object) error {
+func (v *VolcanoControl) PatchPodGroup(oldPodGroup, podGroup client.Object) error {
 	pg := podGroup.(*volcanov1beta1.PodGroup)
-	_, err := v.Client.SchedulingV1beta1().PodGroups(pg.GetNamespace()).Update(context.TODO(), pg, metav1.UpdateOptions{})
+	data, err := client.MergeFrom(oldPodGroup).Data(pg)
+	if err != nil {
+		return fmt.Errorf("unable to compute the patch of a PodGroup, '%v': %v", klog.KObj(pg), err)
+	}
+	_, err = v.Client.SchedulingV1beta1().PodGroups(pg.GetNamespace()).Patch(context.TODO(), pg.GetName(), types.MergePatchType, data, metav1.PatchOptions{})
 	if err != nil {
 		return fmt.Errorf("unable to update a PodGroup, '%v': %v", klog.KObj(pg), err)
 	}
@@ -178,9 +183,9 @@ func (s *SchedulerPluginsControl) DeletePodGroup(namespace, name string) error {
 	return s.Client.Delete(ctx, pg)
 }
 
-func (s *SchedulerPluginsControl) UpdatePodGroup(podGroup client.Object) error {
+func (s *SchedulerPluginsControl) PatchPodGroup(oldPodGroup, podGroup client.Object) error {
 	pg := podGroup.(*schedulerpluginsv1alpha1.PodGroup)
-	err := s.Client.Update(context.TODO(), pg, &client.UpdateOptions{})
+	err := s.Client.Patch(context.TODO(), pg, client.MergeFrom(oldPodGroup))
 	if err != nil {
 		return fmt.Errorf("unable to update a PodGroup, '%v': %v", klog.KObj(pg), err)
 	}
@@ -19,7 +19,8 @@ import (
 	"fmt"
 	"sync"
 
-	log "github.com/sirupsen/logrus"
+	commonutil "github.com/kubeflow/training-operator/pkg/util"
+
 	"k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -31,21 +32,6 @@ import (
 	"k8s.io/client-go/tools/record"
 )
 
-const (
-	// FailedCreateServiceReason is added in an event and in a job controller condition
-	// when a service for a job is failed to be created.
-	FailedCreateServiceReason = "FailedCreateService"
-	// SuccessfulCreateServiceReason is added in an event when a service for a job
-	// is successfully created.
-	SuccessfulCreateServiceReason = "SuccessfulCreateService"
-	// FailedDeleteServiceReason is added in an event and in a job condition
-	// when a service for a job is failed to be deleted.
-	FailedDeleteServiceReason = "FailedDeleteService"
-	// SuccessfulDeleteServiceReason is added in an event when a service for a job
-	// is successfully deleted.
-	SuccessfulDeleteServiceReason = "SuccessfulDeleteService"
-)
-
 // ServiceControlInterface is an interface that knows how to add or delete Services
 // created as an interface to allow testing.
 type ServiceControlInterface interface {
@@ -87,23 +73,24 @@ func (r RealServiceControl) createServices(namespace string, service *v1.Service
 	}
 	serviceWithOwner, err := GetServiceFromTemplate(service, object, controllerRef)
 	if err != nil {
-		r.Recorder.Eventf(object, v1.EventTypeWarning, FailedCreateServiceReason, "Error creating: %v", err)
-		return fmt.Errorf("unable to create services: %v", err)
+		commonutil.RelatedEventf(r.Recorder, object, nil, v1.EventTypeWarning, commonutil.FailedCreateServiceReason, commonutil.EventActionCreate, "Error creating: %v", err)
+		return fmt.Errorf("unable to create services: %w", err)
 	}
 
 	newService, err := r.KubeClient.CoreV1().Services(namespace).Create(context.TODO(), serviceWithOwner, metav1.CreateOptions{})
 	if err != nil {
-		r.Recorder.Eventf(object, v1.EventTypeWarning, FailedCreateServiceReason, "Error creating: %v", err)
-		return fmt.Errorf("unable to create services: %v", err)
+		commonutil.RelatedEventf(r.Recorder, object, nil, v1.EventTypeWarning, commonutil.FailedCreateServiceReason, commonutil.EventActionCreate, "Error creating: %v", err)
+		return fmt.Errorf("unable to create services: %w", err)
 	}
 
+	logger := commonutil.LoggerForService(newService, object.GetObjectKind().GroupVersionKind().Kind)
 	accessor, err := meta.Accessor(object)
 	if err != nil {
-		log.Errorf("parentObject does not have ObjectMeta, %v", err)
+		logger.Error(err, "parentObject does not have ObjectMeta")
 		return nil
 	}
-	log.Infof("Controller %v created service %v", accessor.GetName(), newService.Name)
-	r.Recorder.Eventf(object, v1.EventTypeNormal, SuccessfulCreateServiceReason, "Created service: %v", newService.Name)
+	logger.Info("Controller created service", "controller", accessor.GetName())
+	commonutil.RelatedEventf(r.Recorder, object, newService, v1.EventTypeNormal, commonutil.SuccessfulCreateServiceReason, commonutil.EventActionCreate, "Created service: %v", newService.Name)
 
 	return nil
 }
@@ -114,6 +101,7 @@ func (r RealServiceControl) DeleteService(namespace, serviceID string, object ru
 	if err != nil {
 		return fmt.Errorf("object does not have ObjectMeta, %v", err)
 	}
+	logger := commonutil.LoggerForJob(accessor)
 	service, err := r.KubeClient.CoreV1().Services(namespace).Get(context.TODO(), serviceID, metav1.GetOptions{})
 	if err != nil {
 		if errors.IsNotFound(err) {
@@ -122,15 +110,15 @@ func (r RealServiceControl) DeleteService(namespace, serviceID string, object ru
 		return err
 	}
 	if service.DeletionTimestamp != nil {
-		log.Infof("service %s/%s is terminating, skip deleting", service.Namespace, service.Name)
+		logger.Info("Service is terminating, skip deleting", "service", serviceID)
 		return nil
 	}
-	log.Infof("Controller %v deleting service %v/%v", accessor.GetName(), namespace, serviceID)
+	logger.Info("Controller deleting service", "service", serviceID)
 	if err := r.KubeClient.CoreV1().Services(namespace).Delete(context.TODO(), serviceID, metav1.DeleteOptions{}); err != nil {
-		r.Recorder.Eventf(object, v1.EventTypeWarning, FailedDeleteServiceReason, "Error deleting: %v", err)
+		commonutil.RelatedEventf(r.Recorder, object, service, v1.EventTypeWarning, commonutil.FailedDeleteServiceReason, commonutil.EventActionDelete, "Error deleting: %v", err)
 		return fmt.Errorf("unable to delete service: %v", err)
 	} else {
-		r.Recorder.Eventf(object, v1.EventTypeNormal, SuccessfulDeleteServiceReason, "Deleted service: %v", serviceID)
+		commonutil.RelatedEventf(r.Recorder, object, service, v1.EventTypeNormal, commonutil.SuccessfulDeleteServiceReason, commonutil.EventActionDelete, "Deleted service: %v", serviceID)
 	}
 	return nil
 }
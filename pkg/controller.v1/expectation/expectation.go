@@ -21,12 +21,13 @@ import (
 	"sync/atomic"
 	"time"
 
-	log "github.com/sirupsen/logrus"
-
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/utils/clock"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
 
+var log = logf.Log.WithName("expectations")
+
 const (
 	// If a watch drops a delete event for a pod, it'll take this long
 	// before a dormant controller waiting for those packets is woken up anyway. It is
@@ -99,7 +100,7 @@ func (r *ControllerExpectations) GetExpectations(controllerKey string) (*Control
 func (r *ControllerExpectations) DeleteExpectations(controllerKey string) {
 	if exp, exists, err := r.GetByKey(controllerKey); err == nil && exists {
 		if err := r.Delete(exp); err != nil {
-			log.Debugf("Error deleting expectations for controller %v: %v", controllerKey, err)
+			log.V(1).Info("Error deleting expectations", "key", controllerKey, "error", err)
 		}
 	}
 }
@@ -110,30 +111,36 @@ func (r *ControllerExpectations) DeleteExpectations(controllerKey string) {
 func (r *ControllerExpectations) SatisfiedExpectations(controllerKey string) bool {
 	if exp, exists, err := r.GetExpectations(controllerKey); exists {
 		if exp.Fulfilled() {
-			log.Debugf("Controller expectations fulfilled %#v", exp)
+			log.V(1).Info("Controller expectations fulfilled", exp.keysAndValues()...)
 			return true
 		} else if exp.isExpired() {
-			log.Debugf("Controller expectations expired %#v", exp)
+			log.V(1).Info("Controller expectations expired", exp.keysAndValues()...)
 			return true
 		} else {
-			log.Debugf("Controller still waiting on expectations %#v", exp)
+			log.V(1).Info("Controller still waiting on expectations", exp.keysAndValues()...)
 			return false
 		}
 	} else if err != nil {
-		log.Debugf("Error encountered while checking expectations %#v, forcing sync", err)
+		log.V(1).Info("Error encountered while checking expectations, forcing sync", "key", controllerKey, "error", err)
 	} else {
 		// When a new controller is created, it doesn't have expectations.
 		// When it doesn't see expected watch events for > TTL, the expectations expire.
 		//	- In this case it wakes up, creates/deletes controllees, and sets expectations again.
 		// When it has satisfied expectations and no controllees need to be created/destroyed > TTL, the expectations expire.
 		//	- In this case it continues without setting expectations till it needs to create/delete controllees.
-		log.Debugf("Controller %v either never recorded expectations, or the ttl expired.", controllerKey)
+		log.V(1).Info("Controller either never recorded expectations, or the ttl expired", "key", controllerKey)
 	}
 	// Trigger a sync if we either encountered and error (which shouldn't happen since we're
 	// getting from local store) or this controller hasn't established expectations.
 	return true
 }
 
+// keysAndValues returns the fields which describe the expectations in the logs.
+func (exp *ControlleeExpectations) keysAndValues() []interface{} {
+	add, del := exp.GetExpectations()
+	return []interface{}{"key", exp.key, "add", add, "del", del}
+}
+
 // TODO: Extend ExpirationCache to support explicit expiration.
 // TODO: Make this possible to disable in tests.
 // TODO: Support injection of clock.
@@ -144,7 +151,7 @@ func (exp *ControlleeExpectations) isExpired() bool {
 // SetExpectations registers new expectations for the given controller. Forgets existing expectations.
 func (r *ControllerExpectations) SetExpectations(controllerKey string, add, del int) error {
 	exp := &ControlleeExpectations{add: int64(add), del: int64(del), key: controllerKey, timestamp: clock.RealClock{}.Now()}
-	log.Debugf("Setting expectations %#v", exp)
+	log.V(1).Info("Setting expectations", exp.keysAndValues()...)
 	return r.Add(exp)
 }
 
@@ -161,7 +168,7 @@ func (r *ControllerExpectations) LowerExpectations(controllerKey string, add, de
 	if exp, exists, err := r.GetExpectations(controllerKey); err == nil && exists {
 		exp.Add(int64(-add), int64(-del))
 		// The expectations might've been modified since the update on the previous line.
-		log.Debugf("Lowered expectations %#v", exp)
+		log.V(1).Info("Lowered expectations", exp.keysAndValues()...)
 	}
 }
 
@@ -170,7 +177,7 @@ func (r *ControllerExpectations) RaiseExpectations(controllerKey string, add, de
 	if exp, exists, err := r.GetExpectations(controllerKey); err == nil && exists {
 		exp.Add(int64(add), int64(del))
 		// The expectations might've been modified since the update on the previous line.
-		log.Debugf("Raised expectations %#v", exp)
+		log.V(1).Info("Raised expectations", exp.keysAndValues()...)
 	}
 }
 
@@ -16,6 +16,7 @@ package jax
 
 import (
 	"errors"
+	"net"
 	"strconv"
 	"strings"
 
@@ -23,6 +24,7 @@ import (
 	"k8s.io/utils/ptr"
 
 	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	trainutil "github.com/kubeflow/training-operator/pkg/util/train"
 )
 
 var (
@@ -37,6 +39,9 @@ type EnvVarGenerator interface {
 func setPodEnv(jaxjob *kubeflowv1.JAXJob, podTemplateSpec *corev1.PodTemplateSpec, rtype, index string) error {
 
 	coordinatorAddr := replicaName(jaxjob.Name, kubeflowv1.JAXJobReplicaTypeWorker, 0)
+	if trainutil.IsJobStandalone(&jaxjob.Spec.RunPolicy) {
+		coordinatorAddr = trainutil.StandaloneMasterAddr
+	}
 
 	coordinatorPort, err := getPortFromJAXJob(jaxjob, kubeflowv1.JAXJobReplicaTypeWorker)
 	if err != nil {
@@ -73,6 +78,20 @@ func setPodEnv(jaxjob *kubeflowv1.JAXJob, podTemplateSpec *corev1.PodTemplateSpe
 			Name:  "PROCESS_ID",
 			Value: strconv.Itoa(rank),
 		})
+		// The same settings in the form of the parameters of jax.distributed.initialize(),
+		// where the coordinator address includes the port of the coordinator service.
+		podTemplateSpec.Spec.Containers[i].Env = append(podTemplateSpec.Spec.Containers[i].Env, corev1.EnvVar{
+			Name:  "JAX_COORDINATOR_ADDRESS",
+			Value: net.JoinHostPort(coordinatorAddr, strconv.Itoa(int(coordinatorPort))),
+		})
+		podTemplateSpec.Spec.Containers[i].Env = append(podTemplateSpec.Spec.Containers[i].Env, corev1.EnvVar{
+			Name:  "JAX_NUM_PROCESSES",
+			Value: strconv.Itoa(totalReplicas),
+		})
+		podTemplateSpec.Spec.Containers[i].Env = append(podTemplateSpec.Spec.Containers[i].Env, corev1.EnvVar{
+			Name:  "JAX_PROCESS_ID",
+			Value: strconv.Itoa(rank),
+		})
 	}
 
 	return nil
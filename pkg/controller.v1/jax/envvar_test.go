@@ -48,6 +48,9 @@ func TestSetPodEnv(t *testing.T) {
 		},
 	}
 
+	standaloneJAXJob := validJAXJob.DeepCopy()
+	standaloneJAXJob.Spec.RunPolicy.Standalone = ptr.To(true)
+
 	// Define the test cases
 	cases := map[string]struct {
 		jaxJob         *kubeflowv1.JAXJob
@@ -72,9 +75,32 @@ func TestSetPodEnv(t *testing.T) {
 				{Name: "COORDINATOR_ADDRESS", Value: "test-jaxjob-worker-0"},
 				{Name: "NUM_PROCESSES", Value: "1"},
 				{Name: "PROCESS_ID", Value: validIndex},
+				{Name: "JAX_COORDINATOR_ADDRESS", Value: "test-jaxjob-worker-0:" + strconv.Itoa(int(validPort))},
+				{Name: "JAX_NUM_PROCESSES", Value: "1"},
+				{Name: "JAX_PROCESS_ID", Value: validIndex},
 			},
 			wantErr: nil,
 		},
+		"standalone job uses localhost as coordinator address": {
+			jaxJob: standaloneJAXJob,
+			podTemplate: &corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{}},
+				},
+			},
+			rtype: kubeflowv1.JAXJobReplicaTypeWorker,
+			index: validIndex,
+			wantPodEnvVars: []corev1.EnvVar{
+				{Name: "PYTHONUNBUFFERED", Value: "1"},
+				{Name: "COORDINATOR_PORT", Value: strconv.Itoa(int(validPort))},
+				{Name: "COORDINATOR_ADDRESS", Value: "localhost"},
+				{Name: "NUM_PROCESSES", Value: "1"},
+				{Name: "PROCESS_ID", Value: validIndex},
+				{Name: "JAX_COORDINATOR_ADDRESS", Value: "localhost:" + strconv.Itoa(int(validPort))},
+				{Name: "JAX_NUM_PROCESSES", Value: "1"},
+				{Name: "JAX_PROCESS_ID", Value: validIndex},
+			},
+		},
 		"invalid index for PROCESS_ID": {
 			jaxJob: validJAXJob,
 			podTemplate: &corev1.PodTemplateSpec{
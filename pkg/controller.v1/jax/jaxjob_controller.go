@@ -23,15 +23,16 @@ import (
 	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
 	trainingoperatorcommon "github.com/kubeflow/training-operator/pkg/common"
 	"github.com/kubeflow/training-operator/pkg/common/util"
+	"github.com/kubeflow/training-operator/pkg/config"
 	"github.com/kubeflow/training-operator/pkg/controller.v1/common"
 	"github.com/kubeflow/training-operator/pkg/controller.v1/control"
 	"github.com/kubeflow/training-operator/pkg/controller.v1/expectation"
+	"github.com/kubeflow/training-operator/pkg/controller.v1/registry"
+	"github.com/kubeflow/training-operator/pkg/core"
 	commonutil "github.com/kubeflow/training-operator/pkg/util"
 
 	"github.com/go-logr/logr"
-	"github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -42,6 +43,7 @@ import (
 	"k8s.io/client-go/informers"
 	kubeclientset "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
@@ -50,8 +52,6 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/source"
-	schedulerpluginsv1alpha1 "sigs.k8s.io/scheduler-plugins/apis/scheduling/v1alpha1"
-	"volcano.sh/apis/pkg/apis/scheduling/v1beta1"
 )
 
 const (
@@ -59,13 +59,13 @@ const (
 )
 
 // NewReconciler creates a JAXJob Reconciler
-func NewReconciler(mgr manager.Manager, gangSchedulingSetupFunc common.GangSchedulingSetupFunc) *JAXJobReconciler {
+func NewReconciler(mgr manager.Manager, gangSchedulingSetupFunc common.GangSchedulingSetupFunc, options common.JobControllerOptions) *JAXJobReconciler {
 	r := &JAXJobReconciler{
 		client:    mgr.GetClient(),
 		scheme:    mgr.GetScheme(),
-		recorder:  mgr.GetEventRecorderFor(controllerName),
+		recorder:  commonutil.NewDeduplicatingRecorder(commonutil.NewEventRecorderFor(mgr, controllerName), config.Config.EventDeduplicationWindow),
 		apiReader: mgr.GetAPIReader(),
-		log:       ctrl.Log.WithName(controllerName),
+		log:       ctrl.Log.WithName("controllers").WithName(kubeflowv1.JAXJobKind),
 	}
 
 	// Create clients
@@ -78,13 +78,18 @@ func NewReconciler(mgr manager.Manager, gangSchedulingSetupFunc common.GangSched
 	r.JobController = common.JobController{
 		Controller:                  r,
 		Expectations:                expectation.NewControllerExpectations(),
-		WorkQueue:                   &util.FakeWorkQueue{},
+		WorkQueue:                   util.NewRequeueWorkQueue(),
 		Recorder:                    r.recorder,
 		KubeClientSet:               kubeClientSet,
 		PriorityClassLister:         priorityClassInformer.Lister(),
 		PriorityClassInformerSynced: priorityClassInformer.Informer().HasSynced,
 		PodControl:                  control.RealPodControl{KubeClient: kubeClientSet, Recorder: r.recorder},
 		ServiceControl:              control.RealServiceControl{KubeClient: kubeClientSet, Recorder: r.recorder},
+		PodExecControl:              control.RealPodExecControl{Config: cfg, KubeClient: kubeClientSet},
+		JobRegistry:                 registry.Default,
+		JobControllerOptions:        options,
+		Clock:                       commonutil.NewClock(clock.RealClock{}, config.Config.ClockSkewTolerance),
+		OOMKillTracker:              common.NewOOMKillTracker(time.Now()),
 	}
 
 	gangSchedulingSetupFunc(&r.JobController)
@@ -106,10 +111,22 @@ type JAXJobReconciler struct {
 // +kubebuilder:rbac:groups=kubeflow.org,resources=jaxjobs/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=kubeflow.org,resources=jaxjobs/finalizers,verbs=update
 // +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups="",resources=pods/exec,verbs=create
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=resourcequotas,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;patch;delete
 // +kubebuilder:rbac:groups=scheduling.volcano.sh,resources=podgroups,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=scheduling.x-k8s.io,resources=podgroups,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=ray.io,resources=rayclusters,verbs=get;create;delete
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch;create;update
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;patch;delete
 // +kubebuilder:rbac:groups="",resources=events,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;update;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -120,15 +137,20 @@ type JAXJobReconciler struct {
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.8.3/pkg/reconcile
 func (r *JAXJobReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	defer trainingoperatorcommon.ReconcileDurationObserve(r.GetFrameworkName(), time.Now())
+	logger := r.log.WithValues("kind", kubeflowv1.JAXJobKind, "namespace", req.Namespace, "name", req.Name)
+
 	jaxjob := &kubeflowv1.JAXJob{}
 	err := r.client.Get(ctx, req.NamespacedName, jaxjob)
 	if err != nil {
+		logger.Info("unable to fetch JAXJob", "error", err.Error())
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
-	// log := ctrl.LoggerFrom(ctx).WithValues("jaxjob", klog.KObj(&jaxjob))
-	// ctrl.LoggerInto(ctx, log)
-	// log.V(2).Info("Reconciling JAXJob")
+	if !common.ReconciledByThisController(jaxjob) {
+		logger.V(1).Info("Skipping JAXJob pinned to another controller", "controller-identity", jaxjob.GetAnnotations()[kubeflowv1.ControllerIdentityAnnotation])
+		return ctrl.Result{}, nil
+	}
 
 	// Check if reconciliation is needed
 	jobKey, err := common.KeyFunc(jaxjob)
@@ -140,7 +162,7 @@ func (r *JAXJobReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 	needReconcile := util.SatisfiedExpectations(r.Expectations, jobKey, replicaTypes)
 
 	if !needReconcile || jaxjob.GetDeletionTimestamp() != nil {
-		r.log.Info("reconcile cancelled, job does not need to do reconcile or has been deleted",
+		logger.Info("reconcile cancelled, job does not need to do reconcile or has been deleted",
 			"sync", needReconcile, "deleted", jaxjob.GetDeletionTimestamp() != nil)
 		return ctrl.Result{}, nil
 	}
@@ -151,12 +173,12 @@ func (r *JAXJobReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 	// Use common to reconcile the job related pod and service
 	err = r.ReconcileJobs(jaxjob, jaxjob.Spec.JAXReplicaSpecs, jaxjob.Status, &jaxjob.Spec.RunPolicy)
 	if err != nil {
-		r.log.Error(err, "Reconcile JAXJob error")
+		logger.Error(err, "Reconcile JAXJob error")
 		return ctrl.Result{}, err
 	}
-	t, err := util.DurationUntilExpireTime(&jaxjob.Spec.RunPolicy, jaxjob.Status)
+	t, err := util.DurationUntilExpireTime(&jaxjob.Spec.RunPolicy, jaxjob.Status, r.Clock)
 	if err != nil {
-		logrus.Warnf("Reconcile JAXJob error %v", err)
+		logger.Error(err, "Reconcile JAXJob error")
 		return ctrl.Result{}, err
 	}
 	if t >= 0 {
@@ -169,8 +191,9 @@ func (r *JAXJobReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 // SetupWithManager sets up the controller with the Manager.
 func (r *JAXJobReconciler) SetupWithManager(mgr ctrl.Manager, controllerThreads int) error {
 	c, err := controller.New(r.ControllerName(), mgr, controller.Options{
-		Reconciler:              r,
+		Reconciler:              common.NewDrainingReconciler(r, r.Clock),
 		MaxConcurrentReconciles: controllerThreads,
+		RateLimiter:             common.NewRateLimiter(),
 	})
 	if err != nil {
 		return err
@@ -182,6 +205,26 @@ func (r *JAXJobReconciler) SetupWithManager(mgr ctrl.Manager, controllerThreads
 	); err != nil {
 		return err
 	}
+	// keep the job registry shared by all controllers up to date
+	if err = registry.Default.Watch(context.Background(), mgr.GetCache(), &kubeflowv1.JAXJob{}); err != nil {
+		return err
+	}
+	// requeue the jobs added to the work queue of the common job controller
+	if err = r.WatchWorkQueue(c); err != nil {
+		return err
+	}
+	// requeue the jobs waiting for the objects referenced by the templates of their pods
+	if err = r.WatchDependencies(mgr, c); err != nil {
+		return err
+	}
+	// requeue the jobs blocked by the resource quotas of their namespace
+	if err = r.WatchResourceQuotas(mgr, c); err != nil {
+		return err
+	}
+	// requeue the jobs with pods bound to a node which starts being reclaimed
+	if err = r.WatchPreemptedNodes(mgr, c); err != nil {
+		return err
+	}
 	// inject watching for job related pod
 	if err = c.Watch(source.Kind[*corev1.Pod](mgr.GetCache(), &corev1.Pod{},
 		handler.TypedEnqueueRequestForOwner[*corev1.Pod](mgr.GetScheme(), mgr.GetRESTMapper(), &kubeflowv1.JAXJob{}, handler.OnlyControllerOwner()),
@@ -194,25 +237,9 @@ func (r *JAXJobReconciler) SetupWithManager(mgr ctrl.Manager, controllerThreads
 		util.OnDependentFuncs[*corev1.Service](r.scheme, r.Expectations, &r.JobController))); err != nil {
 		return err
 	}
-	// skip watching volcano PodGroup if volcano PodGroup is not installed
-	if _, err = mgr.GetRESTMapper().RESTMapping(schema.GroupKind{Group: v1beta1.GroupName, Kind: "PodGroup"},
-		v1beta1.SchemeGroupVersion.Version); err == nil {
-		// inject watching for job related volcano PodGroup
-		if err = c.Watch(source.Kind[*v1beta1.PodGroup](mgr.GetCache(), &v1beta1.PodGroup{},
-			handler.TypedEnqueueRequestForOwner[*v1beta1.PodGroup](mgr.GetScheme(), mgr.GetRESTMapper(), &kubeflowv1.JAXJob{}, handler.OnlyControllerOwner()),
-			util.OnDependentFuncs[*v1beta1.PodGroup](r.scheme, r.Expectations, &r.JobController))); err != nil {
-			return err
-		}
-	}
-	// skip watching scheduler-plugins PodGroup if scheduler-plugins PodGroup is not installed
-	if _, err = mgr.GetRESTMapper().RESTMapping(schema.GroupKind{Group: schedulerpluginsv1alpha1.SchemeGroupVersion.Group, Kind: "PodGroup"},
-		schedulerpluginsv1alpha1.SchemeGroupVersion.Version); err == nil {
-		// inject watching for job related scheduler-plugins PodGroup
-		if err = c.Watch(source.Kind[*schedulerpluginsv1alpha1.PodGroup](mgr.GetCache(), &schedulerpluginsv1alpha1.PodGroup{},
-			handler.TypedEnqueueRequestForOwner[*schedulerpluginsv1alpha1.PodGroup](mgr.GetScheme(), mgr.GetRESTMapper(), &kubeflowv1.JAXJob{}, handler.OnlyControllerOwner()),
-			util.OnDependentFuncs[*schedulerpluginsv1alpha1.PodGroup](r.scheme, r.Expectations, &r.JobController))); err != nil {
-			return err
-		}
+	// inject watching for job related PodGroup of the gang scheduler
+	if err = util.WatchPodGroups(mgr, c, r.scheme, &kubeflowv1.JAXJob{}, &r.JobController); err != nil {
+		return err
 	}
 	return nil
 }
@@ -242,9 +269,9 @@ func (r *JAXJobReconciler) GetJobFromInformerCache(namespace, name string) (meta
 	err := r.client.Get(context.Background(), types.NamespacedName{Namespace: namespace, Name: name}, job)
 	if err != nil {
 		if errors.IsNotFound(err) {
-			logrus.Error(err, "jax job not found", "namespace", namespace, "name", name)
+			r.log.Error(err, "jax job not found", "namespace", namespace, "name", name)
 		} else {
-			logrus.Error(err, "failed to get job from api-server", "namespace", namespace, "name", name)
+			r.log.Error(err, "failed to get job from api-server", "namespace", namespace, "name", name)
 		}
 		return nil, err
 	}
@@ -257,9 +284,9 @@ func (r *JAXJobReconciler) GetJobFromAPIClient(namespace, name string) (metav1.O
 	err := r.apiReader.Get(context.Background(), types.NamespacedName{Namespace: namespace, Name: name}, job)
 	if err != nil {
 		if errors.IsNotFound(err) {
-			logrus.Error(err, "jax job not found", "namespace", namespace, "name", name)
+			r.log.Error(err, "jax job not found", "namespace", namespace, "name", name)
 		} else {
-			logrus.Error(err, "failed to get job from api-server", "namespace", namespace, "name", name)
+			r.log.Error(err, "failed to get job from api-server", "namespace", namespace, "name", name)
 		}
 		return nil, err
 	}
@@ -280,7 +307,7 @@ func (r *JAXJobReconciler) GetPodsForJob(obj interface{}) ([]*corev1.Pod, error)
 		return nil, err
 	}
 
-	return util.JobControlledPodList(podlist.Items, job), nil
+	return r.ClaimPods(job, util.ConvertPodList(podlist.Items))
 }
 
 func (r *JAXJobReconciler) GetServicesForJob(obj interface{}) ([]*corev1.Service, error) {
@@ -307,12 +334,12 @@ func (r *JAXJobReconciler) DeleteJob(job interface{}) error {
 		return fmt.Errorf("%+v is not a type of JAXJob", job)
 	}
 	if err := r.client.Delete(context.Background(), jaxjob); err != nil {
-		r.recorder.Eventf(jaxjob, corev1.EventTypeWarning, control.FailedDeletePodReason, "Error deleting: %v", err)
-		logrus.Error(err, "failed to delete job", "namespace", jaxjob.Namespace, "name", jaxjob.Name)
+		r.recorder.Eventf(jaxjob, corev1.EventTypeWarning, commonutil.FailedDeletePodReason, "Error deleting: %v", err)
+		commonutil.LoggerForJob(jaxjob).Error(err, "failed to delete job")
 		return err
 	}
-	r.recorder.Eventf(jaxjob, corev1.EventTypeNormal, control.SuccessfulDeletePodReason, "Deleted job: %v", jaxjob.Name)
-	logrus.Info("job deleted", "namespace", jaxjob.Namespace, "name", jaxjob.Name)
+	r.recorder.Eventf(jaxjob, corev1.EventTypeNormal, commonutil.SuccessfulDeletePodReason, "Deleted job: %v", jaxjob.Name)
+	commonutil.LoggerForJob(jaxjob).Info("job deleted")
 	trainingoperatorcommon.DeletedJobsCounterInc(jaxjob.Namespace, r.GetFrameworkName())
 	return nil
 }
@@ -345,11 +372,11 @@ func (r *JAXJobReconciler) UpdateJobStatus(job interface{},
 
 	// Set StartTime.
 	if jobStatus.StartTime == nil {
-		now := metav1.Now()
+		now := r.Clock.MetaNow()
 		jobStatus.StartTime = &now
 		// enqueue a sync to check if job past ActiveDeadlineSeconds
 		if jaxjob.Spec.RunPolicy.ActiveDeadlineSeconds != nil {
-			logger.Infof("Job with ActiveDeadlineSeconds will sync after %d seconds", *jaxjob.Spec.RunPolicy.ActiveDeadlineSeconds)
+			logger.Info("Job with ActiveDeadlineSeconds will sync after the deadline", "activeDeadlineSeconds", *jaxjob.Spec.RunPolicy.ActiveDeadlineSeconds)
 			r.WorkQueue.AddAfter(jaxjobKey, time.Duration(*jaxjob.Spec.RunPolicy.ActiveDeadlineSeconds)*time.Second)
 		}
 	}
@@ -365,25 +392,21 @@ func (r *JAXJobReconciler) UpdateJobStatus(job interface{},
 		failed := status.Failed
 		specReplicas := *spec.Replicas
 
-		logrus.Infof("JAXJob=%s, ReplicaType=%s expected=%d, running=%d, succeeded=%d, failed=%d, Replicas=%d",
-			jaxjob.Name, rtype, expected, running, succeeded, failed, specReplicas)
+		logger.Info("Replica status", "replicaType", rtype, "expected", expected, "running", running, "succeeded", succeeded, "failed", failed, "replicas", specReplicas)
 
 		if rtype == kubeflowv1.JAXJobReplicaTypeWorker {
 			if expected == 0 {
 				msg := fmt.Sprintf("JAXJob %s/%s successfully completed.",
 					jaxjob.Namespace, jaxjob.Name)
 				r.recorder.Event(jaxjob, corev1.EventTypeNormal, commonutil.NewReason(kubeflowv1.JAXJobKind, commonutil.JobSucceededReason), msg)
-				if jobStatus.CompletionTime == nil {
-					now := metav1.Now()
-					jobStatus.CompletionTime = &now
+				if commonutil.FinishJob(jobStatus, kubeflowv1.JobSucceeded, commonutil.NewReason(kubeflowv1.JAXJobKind, commonutil.JobSucceededReason), msg, r.Clock) {
+					trainingoperatorcommon.SuccessfulJobsCounterInc(jaxjob.Namespace, r.GetFrameworkName())
 				}
-				commonutil.UpdateJobConditions(jobStatus, kubeflowv1.JobSucceeded, corev1.ConditionTrue, commonutil.NewReason(kubeflowv1.JAXJobKind, commonutil.JobSucceededReason), msg)
-				trainingoperatorcommon.SuccessfulJobsCounterInc(jaxjob.Namespace, r.GetFrameworkName())
 			} else if running > 0 {
 				// Some workers are still running, leave a running condition.
 				msg := fmt.Sprintf("JAXJob %s/%s is running.",
 					jaxjob.Namespace, jaxjob.Name)
-				commonutil.UpdateJobConditions(jobStatus, kubeflowv1.JobRunning, corev1.ConditionTrue, commonutil.NewReason(kubeflowv1.JAXJobKind, commonutil.JobRunningReason), msg)
+				commonutil.UpdateJobConditions(jobStatus, kubeflowv1.JobRunning, corev1.ConditionTrue, commonutil.NewReason(kubeflowv1.JAXJobKind, commonutil.JobRunningReason), msg, r.Clock)
 			}
 		}
 
@@ -391,17 +414,14 @@ func (r *JAXJobReconciler) UpdateJobStatus(job interface{},
 			if spec.RestartPolicy != kubeflowv1.RestartPolicyNever {
 				msg := fmt.Sprintf("JAXJob %s is restarting because %d %s replica(s) failed.", jaxjob.Name, failed, rtype)
 				r.Recorder.Event(jaxjob, corev1.EventTypeWarning, commonutil.NewReason(kubeflowv1.JAXJobKind, commonutil.JobRestartingReason), msg)
-				commonutil.UpdateJobConditions(jobStatus, kubeflowv1.JobRestarting, corev1.ConditionTrue, commonutil.NewReason(kubeflowv1.JAXJobKind, commonutil.JobRestartingReason), msg)
+				commonutil.UpdateJobConditions(jobStatus, kubeflowv1.JobRestarting, corev1.ConditionTrue, commonutil.NewReason(kubeflowv1.JAXJobKind, commonutil.JobRestartingReason), msg, r.Clock)
 				trainingoperatorcommon.RestartedJobsCounterInc(jaxjob.Namespace, r.GetFrameworkName())
 			} else {
 				msg := fmt.Sprintf("JAXJob %s is failed because %d %s replica(s) failed.", jaxjob.Name, failed, rtype)
 				r.Recorder.Event(jaxjob, corev1.EventTypeNormal, commonutil.NewReason(kubeflowv1.JAXJobKind, commonutil.JobFailedReason), msg)
-				if jobStatus.CompletionTime == nil {
-					now := metav1.Now()
-					jobStatus.CompletionTime = &now
+				if commonutil.FinishJob(jobStatus, kubeflowv1.JobFailed, commonutil.NewReason(kubeflowv1.JAXJobKind, commonutil.JobFailedReason), msg, r.Clock) {
+					trainingoperatorcommon.FailedJobsCounterInc(jaxjob.Namespace, r.GetFrameworkName())
 				}
-				commonutil.UpdateJobConditions(jobStatus, kubeflowv1.JobFailed, corev1.ConditionTrue, commonutil.NewReason(kubeflowv1.JAXJobKind, commonutil.JobFailedReason), msg)
-				trainingoperatorcommon.FailedJobsCounterInc(jaxjob.Namespace, r.GetFrameworkName())
 			}
 		}
 	}
@@ -420,19 +440,13 @@ func (r *JAXJobReconciler) UpdateJobStatusInApiServer(job interface{}, jobStatus
 		return fmt.Errorf("%+v is not a type of JAXJob", job)
 	}
 
-	// Job status passed in differs with status in job, update in basis of the passed in one.
-	if !equality.Semantic.DeepEqual(&jaxjob.Status, jobStatus) {
-		jaxjob = jaxjob.DeepCopy()
-		jaxjob.Status = *jobStatus.DeepCopy()
-	}
-
-	result := r.client.Status().Update(context.Background(), jaxjob)
+	// Patch only the difference to the status through the status subresource, so that
+	// updates do not overwrite the spec and are retried on conflicts.
+	result := util.PatchJobStatus(context.Background(), r.client, r.apiReader, jaxjob,
+		func(j *kubeflowv1.JAXJob) *kubeflowv1.JobStatus { return &j.Status }, jobStatus)
 
 	if result != nil {
-		r.log.WithValues("jaxjob", types.NamespacedName{
-			Namespace: jaxjob.GetNamespace(),
-			Name:      jaxjob.GetName(),
-		})
+		commonutil.LoggerForJob(jaxjob).Error(result, "failed to update the job status in the API server")
 		return result
 	}
 
@@ -445,6 +459,11 @@ func (r *JAXJobReconciler) SetClusterSpec(job interface{}, podTemplate *corev1.P
 	if !ok {
 		return fmt.Errorf("%+v is not a type of JAXJob", job)
 	}
+	core.SetCommonEnv(podTemplate, kubeflowv1.JAXJobDefaultContainerName, jaxjob.Spec.CommonEnv, jaxjob.Spec.CommonEnvFrom)
+	common.SetNetworkTuningEnv(podTemplate, kubeflowv1.JAXJobDefaultContainerName, &jaxjob.Spec.RunPolicy)
+	if common.IsClusterSpecEnvDisabled(&jaxjob.Spec.RunPolicy) {
+		return nil
+	}
 	if err := setPodEnv(jaxjob, podTemplate, rtype, index); err != nil {
 		return err
 	}
@@ -459,6 +478,42 @@ func (r *JAXJobReconciler) GetDefaultContainerPortName() string {
 	return kubeflowv1.JAXJobDefaultPortName
 }
 
+// GetJobDependencies returns the jobs the JAXJob depends on.
+func (r *JAXJobReconciler) GetJobDependencies(job interface{}) []kubeflowv1.JobReference {
+	jaxJob, ok := job.(*kubeflowv1.JAXJob)
+	if !ok {
+		return nil
+	}
+	return jaxJob.Spec.DependsOn
+}
+
+// GetTensorBoard returns the TensorBoard requested by the JAXJob.
+func (r *JAXJobReconciler) GetTensorBoard(job interface{}) *kubeflowv1.TensorBoardSpec {
+	jaxJob, ok := job.(*kubeflowv1.JAXJob)
+	if !ok {
+		return nil
+	}
+	return jaxJob.Spec.TensorBoard
+}
+
+// GetOutputs returns the outputs declared by the JAXJob.
+func (r *JAXJobReconciler) GetOutputs(job interface{}) []kubeflowv1.OutputSpec {
+	jaxJob, ok := job.(*kubeflowv1.JAXJob)
+	if !ok {
+		return nil
+	}
+	return jaxJob.Spec.Outputs
+}
+
+// GetDatasets returns the datasets declared by the JAXJob.
+func (r *JAXJobReconciler) GetDatasets(job interface{}) []kubeflowv1.DatasetSpec {
+	jaxJob, ok := job.(*kubeflowv1.JAXJob)
+	if !ok {
+		return nil
+	}
+	return jaxJob.Spec.Datasets
+}
+
 func (r *JAXJobReconciler) IsMasterRole(replicas map[kubeflowv1.ReplicaType]*kubeflowv1.ReplicaSpec,
 	rtype kubeflowv1.ReplicaType, index int) bool {
 	return index == 0
@@ -467,12 +522,17 @@ func (r *JAXJobReconciler) IsMasterRole(replicas map[kubeflowv1.ReplicaType]*kub
 // onOwnerCreateFunc modify creation condition.
 func (r *JAXJobReconciler) onOwnerCreateFunc() func(createEvent event.TypedCreateEvent[*kubeflowv1.JAXJob]) bool {
 	return func(e event.TypedCreateEvent[*kubeflowv1.JAXJob]) bool {
+		if !common.ReconciledByThisController(e.Object) {
+			return false
+		}
 		jaxjob := e.Object
 		r.scheme.Default(jaxjob)
 		msg := fmt.Sprintf("JAXJob %s is created.", e.Object.GetName())
-		logrus.Info(msg)
+		commonutil.LoggerForJob(jaxjob).Info(msg)
 		trainingoperatorcommon.CreatedJobsCounterInc(jaxjob.Namespace, r.GetFrameworkName())
-		commonutil.UpdateJobConditions(&jaxjob.Status, kubeflowv1.JobCreated, corev1.ConditionTrue, commonutil.NewReason(kubeflowv1.JAXJobKind, commonutil.JobCreatedReason), msg)
+		oldStatus := jaxjob.Status.DeepCopy()
+		commonutil.UpdateJobConditions(&jaxjob.Status, kubeflowv1.JobCreated, corev1.ConditionTrue, commonutil.NewReason(kubeflowv1.JAXJobKind, commonutil.JobCreatedReason), msg, r.Clock)
+		r.RecordAuditEvents(jaxjob, oldStatus, &jaxjob.Status)
 		return true
 	}
 }
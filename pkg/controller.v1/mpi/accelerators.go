@@ -0,0 +1,96 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mpi
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+)
+
+const (
+	// migResourceNamePrefix is the prefix of the MIG resources of the NVIDIA device plugin with the
+	// mixed strategy, such as nvidia.com/mig-1g.5gb. Each instance is a device of its own.
+	migResourceNamePrefix = "nvidia.com/mig-"
+	// sharedGPUResourceNameSuffix is the suffix of the GPUs shared by time-slicing or MPS when the
+	// NVIDIA device plugin renames them, such as nvidia.com/gpu.shared. Each replica is a device.
+	sharedGPUResourceNameSuffix = gpuResourceNameSuffix + ".shared"
+)
+
+// isDeviceResource returns whether each unit of the resource is an accelerator device on its own:
+// a whole GPU, a MIG instance or a replica of a shared GPU.
+func isDeviceResource(name corev1.ResourceName) bool {
+	key := string(name)
+	return strings.HasSuffix(key, gpuResourceNameSuffix) || strings.HasPrefix(key, migResourceNamePrefix) ||
+		strings.HasSuffix(key, sharedGPUResourceNameSuffix)
+}
+
+// isAcceleratorResource returns whether the resource is a GPU resource: a device resource or a
+// fractional GPU extended resource, such as the GPU memory or cores of GPU sharing schedulers.
+func isAcceleratorResource(name corev1.ResourceName) bool {
+	return isDeviceResource(name) || strings.Contains(string(name), gpuResourceNamePattern)
+}
+
+// podAccelerators returns the number of accelerators granted to the containers of the pod spec.
+// Each unit of a device resource counts as one accelerator. A container requesting only fractional
+// GPU resources holds a share of a single GPU, so it counts as one accelerator whatever the
+// quantities, which are amounts of memory or cores rather than devices.
+func podAccelerators(spec *corev1.PodSpec) int {
+	accelerators := 0
+	for _, container := range spec.Containers {
+		devices, fractional := 0, false
+		for key, quantity := range container.Resources.Limits {
+			switch {
+			case isDeviceResource(key):
+				devices += int(quantity.Value())
+			case isAcceleratorResource(key) && !quantity.IsZero():
+				fractional = true
+			}
+		}
+		if devices == 0 && fractional {
+			devices = 1
+		}
+		accelerators += devices
+	}
+	return accelerators
+}
+
+// slotsPerWorker returns the slots of a pod with the spec: the SlotsPerWorker of the MPIJob,
+// the accelerators of the pod spec if the SlotsPerWorker is auto, or 1.
+func slotsPerWorker(mpiJob *kubeflowv1.MPIJob, spec *corev1.PodSpec) int {
+	slots := mpiJob.Spec.SlotsPerWorker
+	if slots == nil {
+		return 1
+	}
+	if slots.Type == intstr.String {
+		if accelerators := podAccelerators(spec); accelerators > 0 {
+			return accelerators
+		}
+		return 1
+	}
+	return slots.IntValue()
+}
+
+// replicaSlots returns the slots of the pods of the replica type in the hostfile.
+func replicaSlots(mpiJob *kubeflowv1.MPIJob, rtype kubeflowv1.ReplicaType) int {
+	spec := &corev1.PodSpec{}
+	if replica := mpiJob.Spec.MPIReplicaSpecs[rtype]; replica != nil {
+		spec = &replica.Template.Spec
+	}
+	return slotsPerWorker(mpiJob, spec)
+}
@@ -0,0 +1,206 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mpi
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+
+	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	"github.com/kubeflow/training-operator/pkg/config"
+	"github.com/kubeflow/training-operator/pkg/controller.v1/common"
+)
+
+func TestPodAccelerators(t *testing.T) {
+	cases := map[string]struct {
+		limits []corev1.ResourceList
+		want   int
+	}{
+		"whole GPUs": {
+			limits: []corev1.ResourceList{{gpuResourceName: resource.MustParse("2")}},
+			want:   2,
+		},
+		"MIG instances": {
+			limits: []corev1.ResourceList{{"nvidia.com/mig-1g.5gb": resource.MustParse("3")}},
+			want:   3,
+		},
+		"MIG instances of several profiles": {
+			limits: []corev1.ResourceList{{"nvidia.com/mig-1g.5gb": resource.MustParse("1"), "nvidia.com/mig-2g.10gb": resource.MustParse("1")}},
+			want:   2,
+		},
+		"replicas of shared GPUs": {
+			limits: []corev1.ResourceList{{"nvidia.com/gpu.shared": resource.MustParse("2")}},
+			want:   2,
+		},
+		"fractional GPU memory": {
+			limits: []corev1.ResourceList{{"aliyun.com/gpu-mem": resource.MustParse("8")}},
+			want:   1,
+		},
+		"GPU with fractional memory and cores": {
+			limits: []corev1.ResourceList{{gpuResourceName: resource.MustParse("1"), "nvidia.com/gpumem": resource.MustParse("3000"), "nvidia.com/gpucores": resource.MustParse("30")}},
+			want:   1,
+		},
+		"fractional GPUs in several containers": {
+			limits: []corev1.ResourceList{{"aliyun.com/gpu-mem": resource.MustParse("8")}, {"aliyun.com/gpu-mem": resource.MustParse("4")}},
+			want:   2,
+		},
+		"zero fractional GPU": {
+			limits: []corev1.ResourceList{{"aliyun.com/gpu-mem": resource.MustParse("0")}},
+		},
+		"no accelerators": {
+			limits: []corev1.ResourceList{{corev1.ResourceCPU: resource.MustParse("4")}},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			spec := &corev1.PodSpec{}
+			for _, limits := range tc.limits {
+				spec.Containers = append(spec.Containers, corev1.Container{Resources: corev1.ResourceRequirements{Limits: limits}})
+			}
+			if got := podAccelerators(spec); got != tc.want {
+				t.Errorf("Unexpected accelerators: want %d, got %d", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestIsGPULauncherWithMIG(t *testing.T) {
+	mpiJob := &kubeflowv1.MPIJob{
+		Spec: kubeflowv1.MPIJobSpec{
+			MPIReplicaSpecs: map[kubeflowv1.ReplicaType]*kubeflowv1.ReplicaSpec{
+				kubeflowv1.MPIJobReplicaTypeLauncher: {
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{{
+								Resources: corev1.ResourceRequirements{
+									Limits: corev1.ResourceList{"nvidia.com/mig-1g.5gb": resource.MustParse("1")},
+								},
+							}},
+						},
+					},
+				},
+			},
+		},
+	}
+	isGPU, err := isGPULauncher(mpiJob)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !isGPU {
+		t.Error("Expected a launcher with a MIG instance to be a GPU launcher")
+	}
+}
+
+func TestIsGPULauncherWithResourceProfile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "profiles.yaml")
+	if err := os.WriteFile(file, []byte("gpu:\n  limits:\n    nvidia.com/gpu: \"1\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	defer func(file string) { config.Config.ResourceProfilesFile = file }(config.Config.ResourceProfilesFile)
+	config.Config.ResourceProfilesFile = file
+	jc := &MPIJobReconciler{JobController: common.JobController{Recorder: record.NewFakeRecorder(10)}}
+	jc.JobController.Controller = jc
+
+	cases := map[string]struct {
+		profile string
+		want    bool
+		wantErr bool
+	}{
+		"GPU profile": {
+			profile: "gpu",
+			want:    true,
+		},
+		"unknown profile": {
+			profile: "gpu-huge",
+			wantErr: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mpiJob := newDryRunMPIJob(nil)
+			mpiJob.Spec.MPIReplicaSpecs[kubeflowv1.MPIJobReplicaTypeLauncher].ResourceProfile = tc.profile
+			mpiJob.Spec.MPIReplicaSpecs[kubeflowv1.MPIJobReplicaTypeWorker].ResourceProfile = tc.profile
+			got, err := isGPULauncher(mpiJob)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Unexpected error, want error: %t, got: %v", tc.wantErr, err)
+			}
+			if got != tc.want {
+				t.Errorf("Unexpected GPU launcher, want: %t, got: %t", tc.want, got)
+			}
+			// The pods aren't created without the resources of an unknown profile.
+			if _, err := jc.newLauncher(mpiJob, "kubectl-delivery", got); (err != nil) != tc.wantErr {
+				t.Errorf("Unexpected error of the launcher, want error: %t, got: %v", tc.wantErr, err)
+			}
+			if _, err := jc.newWorker(mpiJob, "test-worker-0"); (err != nil) != tc.wantErr {
+				t.Errorf("Unexpected error of the worker, want error: %t, got: %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestReplicaSlots(t *testing.T) {
+	newJob := func(slots *intstr.IntOrString, limits corev1.ResourceList) *kubeflowv1.MPIJob {
+		return &kubeflowv1.MPIJob{
+			Spec: kubeflowv1.MPIJobSpec{
+				SlotsPerWorker: slots,
+				MPIReplicaSpecs: map[kubeflowv1.ReplicaType]*kubeflowv1.ReplicaSpec{
+					kubeflowv1.MPIJobReplicaTypeWorker: {
+						Template: corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{{Resources: corev1.ResourceRequirements{Limits: limits}}},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+	gpus := corev1.ResourceList{gpuResourceName: resource.MustParse("4")}
+	cases := map[string]struct {
+		mpiJob *kubeflowv1.MPIJob
+		want   int
+	}{
+		"default": {
+			mpiJob: newJob(nil, gpus),
+			want:   1,
+		},
+		"slots per worker": {
+			mpiJob: newJob(ptr.To(intstr.FromInt32(2)), gpus),
+			want:   2,
+		},
+		"auto": {
+			mpiJob: newJob(ptr.To(intstr.FromString(kubeflowv1.SlotsPerWorkerAuto)), gpus),
+			want:   4,
+		},
+		"auto without GPUs": {
+			mpiJob: newJob(ptr.To(intstr.FromString(kubeflowv1.SlotsPerWorkerAuto)), nil),
+			want:   1,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := replicaSlots(tc.mpiJob, kubeflowv1.MPIJobReplicaTypeWorker); got != tc.want {
+				t.Errorf("Unexpected slots: want %d, got %d", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mpi
+
+import (
+	"bytes"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+
+	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+)
+
+// configHashAnnotation is the annotation of the ConfigMap with the hash of the inputs
+// the ConfigMap was generated from.
+const configHashAnnotation = "training.kubeflow.org/config-hash"
+
+// configMapHash returns the hash of the inputs of the ConfigMap of the MPIJob: the fields of the spec
+// used by newConfigMap, including the HostfileTemplate, and the running worker pods listed by
+// updateDiscoverHostsInConfigMap.
+// The ConfigMap only needs to be rebuilt when the hash changes, which avoids generating and comparing
+// the hostfile and the discover_hosts.sh script of jobs with thousands of workers on every reconcile.
+// The IPs of the pods are part of the hash if they are the hosts of the workers, and the slots of
+// the pods are part of the hash since they follow the GPUs of the pods of elastic MPIJobs.
+func configMapHash(mpiJob *kubeflowv1.MPIJob, workerReplicas int32, isGPULauncher bool, runningPods []*corev1.Pod) string {
+	slots := replicaSlots(mpiJob, kubeflowv1.MPIJobReplicaTypeWorker)
+	launcherSlots := replicaSlots(mpiJob, kubeflowv1.MPIJobReplicaTypeLauncher)
+	hosts := workerHosts(mpiJob, runningPods)
+	podNames := make([]string, 0, len(runningPods))
+	for _, pod := range runningPods {
+		podNames = append(podNames, pod.Name)
+	}
+	sort.Strings(podNames)
+
+	hasher := fnv.New64a()
+	fmt.Fprintf(hasher, "%s\x00%s\x00%d\x00%d\x00%d\x00%t\x00%s\x00%s\x00%t\x00%d\x00", mpiJob.Name, mpiJob.Spec.MainContainer, slots, launcherSlots, workerReplicas, isGPULauncher,
+		mpiJob.Spec.HostnameSource, mpiJob.Spec.MPIImplementation, bindsGPUsPerRank(mpiJob), workerGPUs(mpiJob))
+	if template := mpiJob.Spec.HostfileTemplate; template != nil {
+		fmt.Fprintf(hasher, "%s\x00%s\x00", template.Hostfile, template.DiscoverHosts)
+	}
+	podSlots := make(map[string]int, len(runningPods))
+	for _, pod := range runningPods {
+		podSlots[pod.Name] = workerSlots(mpiJob, pod)
+	}
+	for _, name := range podNames {
+		fmt.Fprintf(hasher, "%s\x00%s\x00%d\x00", name, hosts[name], podSlots[name])
+	}
+	return strconv.FormatUint(hasher.Sum64(), 16)
+}
+
+// workerHosts returns the hosts of the running worker pods by pod name if the hosts of the workers are
+// not their pod names, or nil otherwise. The pods which have no IP yet are not part of the hosts.
+func workerHosts(mpiJob *kubeflowv1.MPIJob, runningPods []*corev1.Pod) map[string]string {
+	if mpiJob.Spec.HostnameSource != kubeflowv1.HostnameSourcePodIP {
+		return nil
+	}
+	hosts := make(map[string]string, len(runningPods))
+	for _, pod := range runningPods {
+		if pod.Status.PodIP != "" {
+			hosts[pod.Name] = pod.Status.PodIP
+		}
+	}
+	return hosts
+}
+
+// hostOf returns the host of the pod in hosts, or the pod name if the pod has no host.
+func hostOf(hosts map[string]string, podName string) string {
+	if host, ok := hosts[podName]; ok {
+		return host
+	}
+	return podName
+}
+
+// hostMap returns the content of the host map, which maps the hosts to the pod names
+// so that kubexec.sh can run `kubectl exec` in the pod of a host.
+func hostMap(hosts map[string]string) string {
+	podNames := make([]string, 0, len(hosts))
+	for podName := range hosts {
+		podNames = append(podNames, podName)
+	}
+	sort.Strings(podNames)
+	var buffer bytes.Buffer
+	for _, podName := range podNames {
+		buffer.WriteString(fmt.Sprintf("%s %s\n", hosts[podName], podName))
+	}
+	return buffer.String()
+}
+
+// hostMapKeyToPath returns the items of the config volume of the pods of the MPIJob with the host
+// map, if the hosts of the workers are their pod IPs, so that kubexec.sh can read it.
+func hostMapKeyToPath(mpiJob *kubeflowv1.MPIJob, items []corev1.KeyToPath) []corev1.KeyToPath {
+	if mpiJob.Spec.HostnameSource != kubeflowv1.HostnameSourcePodIP {
+		return items
+	}
+	return append(items, corev1.KeyToPath{Key: hostMapName, Path: hostMapName})
+}
@@ -0,0 +1,231 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mpi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	"github.com/kubeflow/training-operator/pkg/controller.v1/common"
+)
+
+func TestConfigMapHash(t *testing.T) {
+	newJob := func(slots int32) *kubeflowv1.MPIJob {
+		return &kubeflowv1.MPIJob{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+			Spec:       kubeflowv1.MPIJobSpec{SlotsPerWorker: ptr.To(intstr.FromInt32(slots))},
+		}
+	}
+	podIPJob := newJob(1)
+	podIPJob.Spec.HostnameSource = kubeflowv1.HostnameSourcePodIP
+	intelMPIJob := newJob(1)
+	intelMPIJob.Spec.MPIImplementation = kubeflowv1.MPIImplementationIntelMPI
+	templateJob := newJob(1)
+	templateJob.Spec.HostfileTemplate = &kubeflowv1.MPIHostfileTemplate{Hostfile: "{{.Host}} slots={{.Slots}}"}
+	newPods := func(names ...string) []*corev1.Pod {
+		var pods []*corev1.Pod
+		for _, name := range names {
+			pods = append(pods, &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name}})
+		}
+		return pods
+	}
+	podsWithIP := func(ip string) []*corev1.Pod {
+		pods := newPods("test-worker-0", "test-worker-1")
+		pods[0].Status.PodIP = ip
+		return pods
+	}
+	hash := configMapHash(newJob(1), 2, false, newPods("test-worker-0", "test-worker-1"))
+
+	cases := map[string]struct {
+		job            *kubeflowv1.MPIJob
+		workerReplicas int32
+		isGPULauncher  bool
+		pods           []*corev1.Pod
+		wantSame       bool
+	}{
+		"pods in another order": {
+			job:            newJob(1),
+			workerReplicas: 2,
+			pods:           newPods("test-worker-1", "test-worker-0"),
+			wantSame:       true,
+		},
+		"pod is not running anymore": {
+			job:            newJob(1),
+			workerReplicas: 2,
+			pods:           newPods("test-worker-0"),
+		},
+		"workers are scaled": {
+			job:            newJob(1),
+			workerReplicas: 3,
+			pods:           newPods("test-worker-0", "test-worker-1"),
+		},
+		"slots per worker are changed": {
+			job:            newJob(2),
+			workerReplicas: 2,
+			pods:           newPods("test-worker-0", "test-worker-1"),
+		},
+		"hosts are the pod IPs": {
+			job:            podIPJob,
+			workerReplicas: 2,
+			pods:           podsWithIP("10.0.0.1"),
+		},
+		"pod IP is ignored for the pod name hosts": {
+			job:            newJob(1),
+			workerReplicas: 2,
+			pods:           podsWithIP("10.0.0.1"),
+			wantSame:       true,
+		},
+		"MPI implementation is set": {
+			job:            intelMPIJob,
+			workerReplicas: 2,
+			pods:           newPods("test-worker-0", "test-worker-1"),
+		},
+		"hostfile template is set": {
+			job:            templateJob,
+			workerReplicas: 2,
+			pods:           newPods("test-worker-0", "test-worker-1"),
+		},
+		"launcher runs on a GPU": {
+			job:            newJob(1),
+			workerReplicas: 2,
+			isGPULauncher:  true,
+			pods:           newPods("test-worker-0", "test-worker-1"),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := configMapHash(tc.job, tc.workerReplicas, tc.isGPULauncher, tc.pods)
+			if same := got == hash; same != tc.wantSame {
+				t.Errorf("Unexpected hash %s, want same as %s: %t", got, hash, tc.wantSame)
+			}
+		})
+	}
+
+	podIPHash := configMapHash(podIPJob, 2, false, podsWithIP("10.0.0.1"))
+	if got := configMapHash(podIPJob, 2, false, podsWithIP("10.0.0.2")); got == podIPHash {
+		t.Errorf("Unexpected hash %s after the pod IP changed", got)
+	}
+}
+
+func TestConfigMapWithPodIPHosts(t *testing.T) {
+	mpiJob := &kubeflowv1.MPIJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec:       kubeflowv1.MPIJobSpec{HostnameSource: kubeflowv1.HostnameSourcePodIP},
+	}
+	runningPods := []*corev1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Name: "test-worker-1"}, Status: corev1.PodStatus{PodIP: "10.0.0.2"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "test-worker-0"}, Status: corev1.PodStatus{PodIP: "10.0.0.1"}},
+	}
+	hosts := workerHosts(mpiJob, runningPods)
+	cm := newConfigMap(mpiJob, 3, false, hosts)
+	updateDiscoverHostsInConfigMap(cm, mpiJob, runningPods, false, hosts)
+
+	want := map[string]string{
+		hostfileName:            "10.0.0.1 slots=1\n10.0.0.2 slots=1\ntest-worker-2 slots=1\n",
+		discoverHostsScriptName: "#!/bin/sh\necho 10.0.0.1:1\necho 10.0.0.2:1",
+		hostMapName:             "10.0.0.1 test-worker-0\n10.0.0.2 test-worker-1\n",
+	}
+	for key, value := range want {
+		if diff := cmp.Diff(value, cm.Data[key]); len(diff) != 0 {
+			t.Errorf("Unexpected %s (-want,+got):\n%s", key, diff)
+		}
+	}
+}
+
+func TestHostMapVolume(t *testing.T) {
+	jc := &MPIJobReconciler{JobController: common.JobController{Recorder: record.NewFakeRecorder(10)}}
+	jc.JobController.Controller = jc
+
+	cases := map[string]struct {
+		hostnameSource kubeflowv1.HostnameSource
+		wantHostMap    bool
+	}{
+		"hosts are the pod names": {},
+		"hosts are the pod IPs": {
+			hostnameSource: kubeflowv1.HostnameSourcePodIP,
+			wantHostMap:    true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mpiJob := newDryRunMPIJob(nil)
+			mpiJob.Spec.HostnameSource = tc.hostnameSource
+
+			// The host map is mounted before the pods have an IP, so it must be in the ConfigMap.
+			if _, ok := newConfigMap(mpiJob, 2, false, workerHosts(mpiJob, nil)).Data[hostMapName]; ok != tc.wantHostMap {
+				t.Errorf("Unexpected %s in the ConfigMap, want: %t, got: %t", hostMapName, tc.wantHostMap, ok)
+			}
+			worker, launcher := newTestPods(t, jc, mpiJob)
+			for _, pod := range []*corev1.Pod{worker, launcher} {
+				found := false
+				for _, volume := range pod.Spec.Volumes {
+					if volume.Name != configVolumeName {
+						continue
+					}
+					for _, item := range volume.ConfigMap.Items {
+						found = found || (item.Key == hostMapName && item.Path == hostMapName)
+					}
+				}
+				if found != tc.wantHostMap {
+					t.Errorf("Unexpected %s in the config volume of the pod %s, want: %t, got: %t", hostMapName, pod.Name, tc.wantHostMap, found)
+				}
+			}
+		})
+	}
+}
+
+func TestGetOrCreateConfigMap(t *testing.T) {
+	mpiJob := newDryRunMPIJob(nil)
+	existing := newConfigMap(mpiJob, 1, false, nil)
+	existing.Labels["example.com/owner"] = "team"
+	existing.Data = map[string]string{"stale": "data"}
+	metav1.SetMetaDataAnnotation(&existing.ObjectMeta, configHashAnnotation, "stale")
+	jc := &MPIJobReconciler{
+		JobController: common.JobController{Recorder: record.NewFakeRecorder(10)},
+		Client:        fake.NewClientBuilder().WithObjects(existing).Build(),
+	}
+	jc.JobController.Controller = jc
+
+	// Only the data and the hash annotation of the stale ConfigMap are patched.
+	if _, err := jc.getOrCreateConfigMap(mpiJob, 1, false); err != nil {
+		t.Fatalf("Failed to reconcile the ConfigMap: %v", err)
+	}
+	got := &corev1.ConfigMap{}
+	if err := jc.Get(context.Background(), client.ObjectKeyFromObject(existing), got); err != nil {
+		t.Fatalf("Failed to get the ConfigMap: %v", err)
+	}
+	if got.Labels["example.com/owner"] != "team" {
+		t.Errorf("Unexpected labels %v of the patched ConfigMap", got.Labels)
+	}
+	if got.Annotations[configHashAnnotation] != configMapHash(mpiJob, 1, false, nil) {
+		t.Errorf("Unexpected hash annotation %s of the patched ConfigMap", got.Annotations[configHashAnnotation])
+	}
+	want := newConfigMap(mpiJob, 1, false, workerHosts(mpiJob, nil))
+	updateDiscoverHostsInConfigMap(want, mpiJob, nil, false, workerHosts(mpiJob, nil))
+	if diff := cmp.Diff(want.Data, got.Data); len(diff) != 0 {
+		t.Errorf("Unexpected data of the patched ConfigMap (-want,+got):\n%s", diff)
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mpi
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	ctlrconfig "github.com/kubeflow/training-operator/pkg/config"
+	"github.com/kubeflow/training-operator/pkg/controller.v1/common"
+	commonutil "github.com/kubeflow/training-operator/pkg/util"
+)
+
+// reviewSuffix is the suffix of the ConfigMap holding the rendered pods of an MPIJob
+// in the dry-run mode.
+const reviewSuffix = "-review"
+
+// dryRunEnabled returns true if the MPIJob asks for its pods to be reviewed before
+// they are created.
+func dryRunEnabled(mpiJob *kubeflowv1.MPIJob) bool {
+	return mpiJob.Annotations[kubeflowv1.DryRunAnnotation] == "true"
+}
+
+// dryRunApproved returns true if the creation of the pods of the MPIJob is approved.
+func dryRunApproved(mpiJob *kubeflowv1.MPIJob) bool {
+	return mpiJob.Annotations[kubeflowv1.ApprovedAnnotation] == "true"
+}
+
+// reconcileDryRun holds the creation of the pods of an MPIJob in the dry-run mode until
+// the job is approved. Meanwhile, the launcher and worker pods are rendered into the review
+// ConfigMap of the job and the job is PendingApproval. It returns true while the pods must
+// not be created.
+func (jc *MPIJobReconciler) reconcileDryRun(mpiJob *kubeflowv1.MPIJob, jobStatus *kubeflowv1.JobStatus, isGPULauncher bool) (bool, error) {
+	if !dryRunEnabled(mpiJob) {
+		return false, nil
+	}
+	if dryRunApproved(mpiJob) {
+		if commonutil.IsPendingApproval(*jobStatus) {
+			msg := fmt.Sprintf("MPIJob %s/%s is approved, creating its pods.", mpiJob.Namespace, mpiJob.Name)
+			reason := commonutil.NewReason(kubeflowv1.MPIJobKind, commonutil.JobApprovedReason)
+			jc.Recorder.Event(mpiJob, corev1.EventTypeNormal, reason, msg)
+			commonutil.UpdateJobConditions(jobStatus, kubeflowv1.JobPendingApproval, corev1.ConditionFalse, reason, msg, jc.Clock)
+		}
+		return false, nil
+	}
+
+	data, err := jc.renderReviewPods(mpiJob, isGPULauncher)
+	if err != nil {
+		return true, err
+	}
+	if err := jc.applyReviewConfigMap(mpiJob, data); err != nil {
+		return true, err
+	}
+	if !commonutil.IsPendingApproval(*jobStatus) {
+		msg := fmt.Sprintf("MPIJob %s/%s is waiting for the %s annotation, its pods are rendered in ConfigMap %s.",
+			mpiJob.Namespace, mpiJob.Name, kubeflowv1.ApprovedAnnotation, mpiJob.Name+reviewSuffix)
+		reason := commonutil.NewReason(kubeflowv1.MPIJobKind, commonutil.JobPendingApprovalReason)
+		jc.Recorder.Event(mpiJob, corev1.EventTypeNormal, reason, msg)
+		commonutil.UpdateJobConditions(jobStatus, kubeflowv1.JobPendingApproval, corev1.ConditionTrue, reason, msg, jc.Clock)
+	}
+	return true, nil
+}
+
+// renderReviewPods returns the manifests of the launcher and worker pods which would be
+// created for the MPIJob, keyed by the file name of each pod.
+func (jc *MPIJobReconciler) renderReviewPods(mpiJob *kubeflowv1.MPIJob, isGPULauncher bool) (map[string]string, error) {
+	var pods []*corev1.Pod
+	if workerSpec := mpiJob.Spec.MPIReplicaSpecs[kubeflowv1.MPIJobReplicaTypeWorker]; workerSpec != nil && workerSpec.Replicas != nil {
+		for i := int32(0); i < *workerSpec.Replicas; i++ {
+			worker, err := jc.newWorker(mpiJob, fmt.Sprintf("%s%s-%d", mpiJob.Name, workerSuffix, i))
+			if err != nil {
+				return nil, err
+			}
+			if worker == nil {
+				return nil, fmt.Errorf(MessageResourceDoesNotExist, "Worker")
+			}
+			worker.Labels[kubeflowv1.ReplicaIndexLabel] = strconv.Itoa(int(i))
+			pods = append(pods, worker)
+		}
+	}
+	launcher, err := jc.newLauncher(mpiJob, ctlrconfig.Config.MPIKubectlDeliveryImage, isGPULauncher)
+	if err != nil {
+		return nil, err
+	}
+	if launcher == nil {
+		return nil, fmt.Errorf(MessageResourceDoesNotExist, "Launcher")
+	}
+	pods = append(pods, launcher)
+
+	data := make(map[string]string, len(pods))
+	for _, pod := range pods {
+		pod.TypeMeta = metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"}
+		manifest, err := yaml.Marshal(pod)
+		if err != nil {
+			return nil, err
+		}
+		data[pod.Name+".yaml"] = string(manifest)
+	}
+	return data, nil
+}
+
+// applyReviewConfigMap creates the review ConfigMap of the MPIJob, or updates it when the
+// rendered pods change.
+func (jc *MPIJobReconciler) applyReviewConfigMap(mpiJob *kubeflowv1.MPIJob, data map[string]string) error {
+	name := mpiJob.Name + reviewSuffix
+	jc.RecordAPICall(mpiJob, common.APICallGet)
+	cm, err := jc.KubeClientSet.CoreV1().ConfigMaps(mpiJob.Namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: mpiJob.Namespace,
+				Labels:    jc.GenLabels(mpiJob.Name),
+				OwnerReferences: []metav1.OwnerReference{
+					*metav1.NewControllerRef(mpiJob, kubeflowv1.MPIJobSchemeGroupVersionKind),
+				},
+			},
+			Data: data,
+		}
+		jc.RecordAPICall(mpiJob, common.APICallCreate)
+		_, err = jc.KubeClientSet.CoreV1().ConfigMaps(mpiJob.Namespace).Create(context.Background(), cm, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	if !metav1.IsControlledBy(cm, mpiJob) {
+		return jc.resourceExists(mpiJob, "ConfigMap", cm.Name)
+	}
+	if reflect.DeepEqual(cm.Data, data) {
+		return nil
+	}
+	cm = cm.DeepCopy()
+	cm.Data = data
+	jc.RecordAPICall(mpiJob, common.APICallUpdate)
+	_, err = jc.KubeClientSet.CoreV1().ConfigMaps(mpiJob.Namespace).Update(context.Background(), cm, metav1.UpdateOptions{})
+	return err
+}
@@ -0,0 +1,147 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mpi
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/yaml"
+
+	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	"github.com/kubeflow/training-operator/pkg/config"
+	"github.com/kubeflow/training-operator/pkg/controller.v1/common"
+	commonutil "github.com/kubeflow/training-operator/pkg/util"
+)
+
+func newDryRunMPIJob(annotations map[string]string) *kubeflowv1.MPIJob {
+	template := corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "mpi", Image: "mpi:latest"}}},
+	}
+	return &kubeflowv1.MPIJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", UID: "job-uid", Annotations: annotations},
+		Spec: kubeflowv1.MPIJobSpec{
+			MPIReplicaSpecs: map[kubeflowv1.ReplicaType]*kubeflowv1.ReplicaSpec{
+				kubeflowv1.MPIJobReplicaTypeLauncher: {Replicas: ptr.To[int32](1), Template: template},
+				kubeflowv1.MPIJobReplicaTypeWorker:   {Replicas: ptr.To[int32](2), Template: template},
+			},
+		},
+	}
+}
+
+// newTestPods returns the first worker and the launcher of the mpiJob.
+func newTestPods(t *testing.T, jc *MPIJobReconciler, mpiJob *kubeflowv1.MPIJob) (worker, launcher *corev1.Pod) {
+	t.Helper()
+	worker, err := jc.newWorker(mpiJob, mpiJob.Name+workerSuffix+"-0")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	launcher, err = jc.newLauncher(mpiJob, "kubectl-delivery", false)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return worker, launcher
+}
+
+func TestReconcileDryRun(t *testing.T) {
+	jc := &MPIJobReconciler{
+		JobController: common.JobController{
+			Recorder:      record.NewFakeRecorder(10),
+			KubeClientSet: kubefake.NewSimpleClientset(),
+		},
+	}
+	jc.JobController.Controller = jc
+	jobStatus := &kubeflowv1.JobStatus{}
+
+	// The pods of a job without the dry-run annotation are created right away.
+	if pending, err := jc.reconcileDryRun(newDryRunMPIJob(nil), jobStatus, false); pending || err != nil {
+		t.Fatalf("Unexpected result from reconcileDryRun() without dry-run: %v, %v", pending, err)
+	}
+
+	mpiJob := newDryRunMPIJob(map[string]string{kubeflowv1.DryRunAnnotation: "true"})
+	for i := 0; i < 2; i++ {
+		if pending, err := jc.reconcileDryRun(mpiJob, jobStatus, false); !pending || err != nil {
+			t.Fatalf("Unexpected result from reconcileDryRun() before the approval: %v, %v", pending, err)
+		}
+	}
+	if !commonutil.IsPendingApproval(*jobStatus) {
+		t.Errorf("Expected the job to be PendingApproval")
+	}
+	cm, err := jc.KubeClientSet.CoreV1().ConfigMaps("default").Get(context.Background(), "test"+reviewSuffix, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get the review ConfigMap: %v", err)
+	}
+	if !metav1.IsControlledBy(cm, mpiJob) {
+		t.Errorf("Expected the review ConfigMap to be controlled by the job")
+	}
+	var keys []string
+	for key := range cm.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	if diff := cmp.Diff([]string{"test-launcher.yaml", "test-worker-0.yaml", "test-worker-1.yaml"}, keys); len(diff) != 0 {
+		t.Errorf("Unexpected pods in the review ConfigMap (-want,+got):\n%s", diff)
+	}
+	worker := &corev1.Pod{}
+	if err := yaml.Unmarshal([]byte(cm.Data["test-worker-1.yaml"]), worker); err != nil {
+		t.Fatalf("Failed to parse the rendered worker: %v", err)
+	}
+	if worker.Kind != "Pod" || worker.Labels[kubeflowv1.ReplicaIndexLabel] != "1" {
+		t.Errorf("Unexpected rendered worker: %+v", worker)
+	}
+
+	mpiJob.Annotations[kubeflowv1.ApprovedAnnotation] = "true"
+	if pending, err := jc.reconcileDryRun(mpiJob, jobStatus, false); pending || err != nil {
+		t.Fatalf("Unexpected result from reconcileDryRun() after the approval: %v, %v", pending, err)
+	}
+	if commonutil.IsPendingApproval(*jobStatus) {
+		t.Errorf("Expected the job to be no longer PendingApproval")
+	}
+}
+
+func TestBuildPodSpec(t *testing.T) {
+	defer func(image string) { config.Config.MPIKubectlDeliveryImage = image }(config.Config.MPIKubectlDeliveryImage)
+	config.Config.MPIKubectlDeliveryImage = "kubectl-delivery"
+	jc := &MPIJobReconciler{JobController: common.JobController{Recorder: record.NewFakeRecorder(10)}}
+	jc.JobController.Controller = jc
+	mpiJob := newDryRunMPIJob(nil)
+	worker, launcher := newTestPods(t, jc, mpiJob)
+
+	// The quota checks account for the pods as they are created, with the kubectl-delivery
+	// init container of the launcher.
+	cases := map[kubeflowv1.ReplicaType]*corev1.Pod{
+		kubeflowv1.MPIJobReplicaTypeLauncher: launcher,
+		kubeflowv1.MPIJobReplicaTypeWorker:   worker,
+	}
+	for rtype, want := range cases {
+		got, err := jc.BuildPodSpec(mpiJob, rtype)
+		if err != nil {
+			t.Fatalf("Unexpected error of the %s: %v", rtype, err)
+		}
+		if diff := cmp.Diff(&want.Spec, got); len(diff) != 0 {
+			t.Errorf("Unexpected spec of the %s (-want,+got):\n%s", rtype, diff)
+		}
+	}
+	if len(launcher.Spec.InitContainers) == 0 || launcher.Spec.InitContainers[0].Name != kubectlDeliveryName {
+		t.Errorf("Expected the kubectl-delivery init container in the launcher, got: %v", launcher.Spec.InitContainers)
+	}
+}
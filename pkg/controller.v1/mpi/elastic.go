@@ -0,0 +1,83 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mpi
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	commonutil "github.com/kubeflow/training-operator/pkg/util"
+)
+
+// hostsInsufficientReason is the reason of the HostsInsufficient condition of an MPIJob.
+var hostsInsufficientReason = commonutil.NewReason(kubeflowv1.MPIJobKind, string(kubeflowv1.JobHostsInsufficient))
+
+// workerSlots returns the slots of a worker pod in discover_hosts.sh: the SlotsPerWorker of the
+// MPIJob if set, the accelerators granted to the pod for elastic MPIJobs, or 1.
+func workerSlots(mpiJob *kubeflowv1.MPIJob, pod *corev1.Pod) int {
+	if mpiJob.Spec.SlotsPerWorker != nil {
+		return slotsPerWorker(mpiJob, &pod.Spec)
+	}
+	if mpiJob.Spec.ElasticPolicy != nil {
+		if accelerators := podAccelerators(&pod.Spec); accelerators > 0 {
+			return accelerators
+		}
+	}
+	return 1
+}
+
+// discoverHostsRefreshInterval returns the interval at which an unfinished MPIJob is requeued
+// to refresh discover_hosts.sh, or 0 if the MPIJob is not refreshed periodically.
+func discoverHostsRefreshInterval(mpiJob *kubeflowv1.MPIJob) time.Duration {
+	policy := mpiJob.Spec.ElasticPolicy
+	if policy == nil || policy.RefreshIntervalSeconds == nil || commonutil.IsFinished(mpiJob.Status) {
+		return 0
+	}
+	return time.Duration(*policy.RefreshIntervalSeconds) * time.Second
+}
+
+// updateHostsInsufficientCondition sets the HostsInsufficient condition while the launcher of an
+// elastic MPIJob runs with fewer running workers than the minReplicas of its elastic policy, and
+// removes the condition once enough workers are running again or the launcher is not running.
+// The condition is removed instead of being set to False, so that it does not remain the last
+// condition reported as the state of the MPIJob.
+func (jc *MPIJobReconciler) updateHostsInsufficientCondition(mpiJob *kubeflowv1.MPIJob, jobStatus *kubeflowv1.JobStatus, launcher *corev1.Pod, running int) {
+	var minReplicas int
+	if policy := mpiJob.Spec.ElasticPolicy; policy != nil && policy.MinReplicas != nil {
+		minReplicas = int(*policy.MinReplicas)
+	}
+	if launcher == nil || !isPodRunning(launcher) || running >= minReplicas {
+		if getCondition(*jobStatus, kubeflowv1.JobHostsInsufficient) != nil {
+			jobStatus.Conditions = filterOutCondition(jobStatus.Conditions, kubeflowv1.JobHostsInsufficient)
+		}
+		return
+	}
+	msg := fmt.Sprintf("MPIJob %s/%s has %d running workers, fewer than the %d minReplicas of its elastic policy.",
+		mpiJob.Namespace, mpiJob.Name, running, minReplicas)
+	for i := range jobStatus.Conditions {
+		if condition := &jobStatus.Conditions[i]; condition.Type == kubeflowv1.JobHostsInsufficient {
+			if condition.Message != msg {
+				condition.Message = msg
+				condition.LastUpdateTime = jc.Clock.MetaNow()
+			}
+			return
+		}
+	}
+	jc.Recorder.Event(mpiJob, corev1.EventTypeWarning, hostsInsufficientReason, msg)
+	setCondition(jobStatus, newCondition(kubeflowv1.JobHostsInsufficient, hostsInsufficientReason, msg, jc.Clock.MetaNow()))
+}
@@ -0,0 +1,192 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mpi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+
+	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	"github.com/kubeflow/training-operator/pkg/controller.v1/common"
+)
+
+func newGPUWorker(name string, gpus int64) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Resources: corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{gpuResourceName: *resource.NewQuantity(gpus, resource.DecimalSI)},
+				},
+			}},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+}
+
+func TestDiscoverHostsSlotsOfElasticMPIJob(t *testing.T) {
+	cases := map[string]struct {
+		spec kubeflowv1.MPIJobSpec
+		want string
+	}{
+		"slots follow the GPUs of the workers": {
+			spec: kubeflowv1.MPIJobSpec{ElasticPolicy: &kubeflowv1.MPIElasticPolicy{}},
+			want: "#!/bin/sh\necho test-worker-0:2\necho test-worker-1:4",
+		},
+		"slotsPerWorker takes precedence": {
+			spec: kubeflowv1.MPIJobSpec{ElasticPolicy: &kubeflowv1.MPIElasticPolicy{}, SlotsPerWorker: ptr.To(intstr.FromInt32(1))},
+			want: "#!/bin/sh\necho test-worker-0:1\necho test-worker-1:1",
+		},
+		"auto slots per worker": {
+			spec: kubeflowv1.MPIJobSpec{SlotsPerWorker: ptr.To(intstr.FromString(kubeflowv1.SlotsPerWorkerAuto))},
+			want: "#!/bin/sh\necho test-worker-0:2\necho test-worker-1:4",
+		},
+		"not elastic": {
+			want: "#!/bin/sh\necho test-worker-0:1\necho test-worker-1:1",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mpiJob := &kubeflowv1.MPIJob{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+				Spec:       tc.spec,
+			}
+			runningPods := []*corev1.Pod{newGPUWorker("test-worker-1", 4), newGPUWorker("test-worker-0", 2)}
+			cm := newConfigMap(mpiJob, 2, false, nil)
+			updateDiscoverHostsInConfigMap(cm, mpiJob, runningPods, false, nil)
+			if diff := cmp.Diff(tc.want, cm.Data[discoverHostsScriptName]); len(diff) != 0 {
+				t.Errorf("Unexpected discover_hosts.sh (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestConfigMapHashOfWorkerGPUs(t *testing.T) {
+	mpiJob := &kubeflowv1.MPIJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec:       kubeflowv1.MPIJobSpec{ElasticPolicy: &kubeflowv1.MPIElasticPolicy{}},
+	}
+	hash := configMapHash(mpiJob, 1, false, []*corev1.Pod{newGPUWorker("test-worker-0", 2)})
+	if configMapHash(mpiJob, 1, false, []*corev1.Pod{newGPUWorker("test-worker-0", 4)}) == hash {
+		t.Error("Expected the hash of the ConfigMap to change with the GPUs of the workers")
+	}
+}
+
+func TestUpdateHostsInsufficientCondition(t *testing.T) {
+	runningLauncher := &corev1.Pod{Status: corev1.PodStatus{Phase: corev1.PodRunning}}
+	insufficient := kubeflowv1.JobCondition{
+		Type:    kubeflowv1.JobHostsInsufficient,
+		Status:  corev1.ConditionTrue,
+		Reason:  hostsInsufficientReason,
+		Message: "MPIJob default/test has 1 running workers, fewer than the 2 minReplicas of its elastic policy.",
+	}
+	running := kubeflowv1.JobCondition{Type: kubeflowv1.JobRunning, Status: corev1.ConditionTrue}
+	cases := map[string]struct {
+		launcher       *corev1.Pod
+		running        int
+		conditions     []kubeflowv1.JobCondition
+		wantConditions []kubeflowv1.JobCondition
+		wantEvent      bool
+	}{
+		"fewer running workers than minReplicas": {
+			launcher:       runningLauncher,
+			running:        1,
+			conditions:     []kubeflowv1.JobCondition{running},
+			wantConditions: []kubeflowv1.JobCondition{running, insufficient},
+			wantEvent:      true,
+		},
+		"condition is already set": {
+			launcher:       runningLauncher,
+			running:        1,
+			conditions:     []kubeflowv1.JobCondition{running, insufficient},
+			wantConditions: []kubeflowv1.JobCondition{running, insufficient},
+		},
+		"enough running workers": {
+			launcher:       runningLauncher,
+			running:        2,
+			conditions:     []kubeflowv1.JobCondition{running, insufficient},
+			wantConditions: []kubeflowv1.JobCondition{running},
+		},
+		"launcher is not created": {
+			running:        1,
+			conditions:     []kubeflowv1.JobCondition{running},
+			wantConditions: []kubeflowv1.JobCondition{running},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			recorder := record.NewFakeRecorder(10)
+			jc := &MPIJobReconciler{JobController: common.JobController{Recorder: recorder}}
+			mpiJob := &kubeflowv1.MPIJob{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+				Spec:       kubeflowv1.MPIJobSpec{ElasticPolicy: &kubeflowv1.MPIElasticPolicy{MinReplicas: ptr.To[int32](2)}},
+			}
+			jobStatus := &kubeflowv1.JobStatus{Conditions: tc.conditions}
+			jc.updateHostsInsufficientCondition(mpiJob, jobStatus, tc.launcher, tc.running)
+			ignoreTimes := cmp.FilterPath(func(p cmp.Path) bool {
+				return p.Last().String() == ".LastUpdateTime" || p.Last().String() == ".LastTransitionTime"
+			}, cmp.Ignore())
+			if diff := cmp.Diff(tc.wantConditions, jobStatus.Conditions, ignoreTimes); len(diff) != 0 {
+				t.Errorf("Unexpected conditions (-want,+got):\n%s", diff)
+			}
+			if gotEvent := len(recorder.Events) != 0; gotEvent != tc.wantEvent {
+				t.Errorf("Unexpected event emitted: %v", gotEvent)
+			}
+		})
+	}
+}
+
+func TestDiscoverHostsRefreshInterval(t *testing.T) {
+	finished := kubeflowv1.JobStatus{
+		Conditions: []kubeflowv1.JobCondition{{Type: kubeflowv1.JobSucceeded, Status: corev1.ConditionTrue}},
+	}
+	cases := map[string]struct {
+		policy *kubeflowv1.MPIElasticPolicy
+		status kubeflowv1.JobStatus
+		want   time.Duration
+	}{
+		"not elastic": {},
+		"refresh interval is unset": {
+			policy: &kubeflowv1.MPIElasticPolicy{},
+		},
+		"refresh interval is set": {
+			policy: &kubeflowv1.MPIElasticPolicy{RefreshIntervalSeconds: ptr.To[int32](30)},
+			want:   30 * time.Second,
+		},
+		"job is finished": {
+			policy: &kubeflowv1.MPIElasticPolicy{RefreshIntervalSeconds: ptr.To[int32](30)},
+			status: finished,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mpiJob := &kubeflowv1.MPIJob{
+				Spec:   kubeflowv1.MPIJobSpec{ElasticPolicy: tc.policy},
+				Status: tc.status,
+			}
+			if got := discoverHostsRefreshInterval(mpiJob); got != tc.want {
+				t.Errorf("Unexpected refresh interval: want %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mpi
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+)
+
+// gpuWrapperScriptName is the name of the script of the ConfigMap setting the GPUs of the local
+// rank of an MPI process.
+const gpuWrapperScriptName = "gpu_wrapper.sh"
+
+// bindsGPUsPerRank returns whether the MPIJob gets the gpu_wrapper.sh script.
+func bindsGPUsPerRank(mpiJob *kubeflowv1.MPIJob) bool {
+	return ptr.Deref(mpiJob.Spec.BindGPUsPerRank, false)
+}
+
+// gpuWrapperScript returns the gpu_wrapper.sh script of the workers with the GPUs and the slots.
+// The local rank of the process is read from the variables of Open MPI, then of Intel MPI and
+// MPICH. Each of the slots gets GPUs/slots GPUs, or shares a GPU with the next slots if the worker
+// has more slots than GPUs, so that the GPUs of the rank are [rank*GPUs/slots, (rank+1)*GPUs/slots).
+// The ranks beyond the slots of an oversubscribed worker wrap around. CUDA_VISIBLE_DEVICES is left
+// as is if the workers have no GPUs.
+func gpuWrapperScript(gpus, slots int) string {
+	if slots < 1 {
+		slots = 1
+	}
+	return fmt.Sprintf(`#!/bin/sh
+GPUS=%d
+SLOTS=%d
+if [ "${GPUS}" -gt 0 ]; then
+  LOCAL_RANK=${OMPI_COMM_WORLD_LOCAL_RANK:-${MPI_LOCALRANKID:-${PMI_LOCAL_RANK:-0}}}
+  LOCAL_RANK=$((LOCAL_RANK %% SLOTS))
+  FIRST=$((LOCAL_RANK * GPUS / SLOTS))
+  LAST=$(((LOCAL_RANK + 1) * GPUS / SLOTS - 1))
+  if [ "${LAST}" -lt "${FIRST}" ]; then
+    LAST=${FIRST}
+  fi
+  DEVICES=${FIRST}
+  GPU=$((FIRST + 1))
+  while [ "${GPU}" -le "${LAST}" ]; do
+    DEVICES="${DEVICES},${GPU}"
+    GPU=$((GPU + 1))
+  done
+  export CUDA_VISIBLE_DEVICES=${DEVICES}
+fi
+exec "$@"
+`, gpus, slots)
+}
+
+// setGPUWrapperConfig adds the gpu_wrapper.sh script in the ConfigMap of the MPIJob, with the GPUs
+// and the slots of the workers.
+func setGPUWrapperConfig(configMap *corev1.ConfigMap, mpiJob *kubeflowv1.MPIJob) {
+	if !bindsGPUsPerRank(mpiJob) {
+		return
+	}
+	configMap.Data[gpuWrapperScriptName] = gpuWrapperScript(workerGPUs(mpiJob), replicaSlots(mpiJob, kubeflowv1.MPIJobReplicaTypeWorker))
+}
+
+// workerGPUs returns the GPUs of the workers of the MPIJob which binds the GPUs per rank, or 0.
+func workerGPUs(mpiJob *kubeflowv1.MPIJob) int {
+	worker := mpiJob.Spec.MPIReplicaSpecs[kubeflowv1.MPIJobReplicaTypeWorker]
+	if !bindsGPUsPerRank(mpiJob) || worker == nil {
+		return 0
+	}
+	return podAccelerators(&worker.Template.Spec)
+}
+
+// gpuWrapperKeyToPath returns the items of the config volume of the pods of the MPIJob with the
+// gpu_wrapper.sh script, if the MPIJob binds the GPUs per rank.
+func gpuWrapperKeyToPath(mpiJob *kubeflowv1.MPIJob, items []corev1.KeyToPath) []corev1.KeyToPath {
+	if !bindsGPUsPerRank(mpiJob) {
+		return items
+	}
+	return append(items, corev1.KeyToPath{
+		Key:  gpuWrapperScriptName,
+		Path: gpuWrapperScriptName,
+		Mode: ptr.To[int32](0555),
+	})
+}
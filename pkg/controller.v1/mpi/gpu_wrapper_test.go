@@ -0,0 +1,109 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mpi
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+
+	"github.com/kubeflow/training-operator/pkg/controller.v1/common"
+)
+
+func TestGPUWrapperScript(t *testing.T) {
+	sh, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("sh is not available")
+	}
+	cases := map[string]struct {
+		gpus, slots int
+		env         string
+		want        string
+	}{
+		"two GPUs per rank with Open MPI": {
+			gpus: 4, slots: 2, env: "OMPI_COMM_WORLD_LOCAL_RANK=1", want: "2,3",
+		},
+		"one GPU per rank with Intel MPI": {
+			gpus: 8, slots: 8, env: "MPI_LOCALRANKID=5", want: "5",
+		},
+		"two ranks per GPU": {
+			gpus: 2, slots: 4, env: "OMPI_COMM_WORLD_LOCAL_RANK=3", want: "1",
+		},
+		"rank of an oversubscribed worker": {
+			gpus: 2, slots: 2, env: "OMPI_COMM_WORLD_LOCAL_RANK=3", want: "1",
+		},
+		"no GPU": {
+			gpus: 0, slots: 2, env: "OMPI_COMM_WORLD_LOCAL_RANK=1", want: "unset",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cmd := exec.Command(sh, "-c", gpuWrapperScript(tc.gpus, tc.slots), "gpu_wrapper.sh",
+				"sh", "-c", `echo "${CUDA_VISIBLE_DEVICES:-unset}"`)
+			cmd.Env = []string{tc.env}
+			out, err := cmd.Output()
+			if err != nil {
+				t.Fatalf("Failed to run the script: %v", err)
+			}
+			if got := strings.TrimSpace(string(out)); got != tc.want {
+				t.Errorf("Unexpected CUDA_VISIBLE_DEVICES, want: %q, got: %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestGPUWrapperConfig(t *testing.T) {
+	jc := &MPIJobReconciler{JobController: common.JobController{Recorder: record.NewFakeRecorder(10)}}
+	jc.JobController.Controller = jc
+	mpiJob := newDryRunMPIJob(nil)
+	mpiJob.Spec.SlotsPerWorker = ptr.To(intstr.FromInt32(2))
+	template := mpiJob.Spec.MPIReplicaSpecs["Worker"].Template.DeepCopy()
+	template.Spec.Containers[0].Resources.Limits = corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("4")}
+	mpiJob.Spec.MPIReplicaSpecs["Worker"].Template = *template
+
+	if _, ok := newConfigMap(mpiJob, 2, false, nil).Data[gpuWrapperScriptName]; ok {
+		t.Errorf("Unexpected %s in the ConfigMap of a job which does not bind the GPUs per rank", gpuWrapperScriptName)
+	}
+	hash := configMapHash(mpiJob, 2, false, nil)
+
+	mpiJob.Spec.BindGPUsPerRank = ptr.To(true)
+	if got := newConfigMap(mpiJob, 2, false, nil).Data[gpuWrapperScriptName]; got != gpuWrapperScript(4, 2) {
+		t.Errorf("Unexpected %s in the ConfigMap:\n%s", gpuWrapperScriptName, got)
+	}
+	if configMapHash(mpiJob, 2, false, nil) == hash {
+		t.Errorf("Expected the hash of the ConfigMap to change with bindGPUsPerRank")
+	}
+	worker, launcher := newTestPods(t, jc, mpiJob)
+	for _, pod := range []*corev1.Pod{worker, launcher} {
+		found := false
+		for _, volume := range pod.Spec.Volumes {
+			if volume.Name != configVolumeName {
+				continue
+			}
+			for _, item := range volume.ConfigMap.Items {
+				found = found || item.Key == gpuWrapperScriptName
+			}
+		}
+		if !found {
+			t.Errorf("Expected %s in the config volume of the pod %s", gpuWrapperScriptName, pod.Name)
+		}
+	}
+}
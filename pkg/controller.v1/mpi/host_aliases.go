@@ -0,0 +1,42 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mpi
+
+import (
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// workerHostAliases returns the host aliases mapping the names of the worker pods to their IPs,
+// added to the launcher pod of the MPIJobs whose HostnameSource is HostAliases, and whether all
+// the workerReplicas workers are running with an IP. Since the host aliases of a pod cannot be
+// updated, the launcher is only created once the membership of the workers is complete.
+func workerHostAliases(workers []*corev1.Pod, workerReplicas int32) ([]corev1.HostAlias, bool) {
+	hostAliases := make([]corev1.HostAlias, 0, len(workers))
+	for _, pod := range workers {
+		if pod.DeletionTimestamp != nil || !isPodRunning(pod) || pod.Status.PodIP == "" {
+			continue
+		}
+		hostAliases = append(hostAliases, corev1.HostAlias{IP: pod.Status.PodIP, Hostnames: []string{pod.Name}})
+	}
+	if len(hostAliases) < int(workerReplicas) {
+		return nil, false
+	}
+	sort.Slice(hostAliases, func(i, j int) bool {
+		return hostAliases[i].Hostnames[0] < hostAliases[j].Hostnames[0]
+	})
+	return hostAliases, true
+}
@@ -0,0 +1,73 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mpi
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestWorkerHostAliases(t *testing.T) {
+	newWorker := func(name string, phase corev1.PodPhase, ip string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status:     corev1.PodStatus{Phase: phase, PodIP: ip},
+		}
+	}
+	cases := map[string]struct {
+		workers   []*corev1.Pod
+		want      []corev1.HostAlias
+		wantReady bool
+	}{
+		"all workers are running": {
+			workers: []*corev1.Pod{
+				newWorker("test-worker-1", corev1.PodRunning, "10.0.0.2"),
+				newWorker("test-worker-0", corev1.PodRunning, "10.0.0.1"),
+			},
+			want: []corev1.HostAlias{
+				{IP: "10.0.0.1", Hostnames: []string{"test-worker-0"}},
+				{IP: "10.0.0.2", Hostnames: []string{"test-worker-1"}},
+			},
+			wantReady: true,
+		},
+		"a worker is pending": {
+			workers: []*corev1.Pod{
+				newWorker("test-worker-0", corev1.PodRunning, "10.0.0.1"),
+				newWorker("test-worker-1", corev1.PodPending, ""),
+			},
+		},
+		"a worker has no IP yet": {
+			workers: []*corev1.Pod{
+				newWorker("test-worker-0", corev1.PodRunning, "10.0.0.1"),
+				newWorker("test-worker-1", corev1.PodRunning, ""),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, ready := workerHostAliases(tc.workers, 2)
+			if ready != tc.wantReady {
+				t.Errorf("Unexpected readiness, want: %t, got: %t", tc.wantReady, ready)
+			}
+			if diff := cmp.Diff(tc.want, got); len(diff) != 0 {
+				t.Errorf("Unexpected host aliases (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
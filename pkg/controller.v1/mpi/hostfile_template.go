@@ -0,0 +1,74 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mpi
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+)
+
+// hostTemplate renders the entries of the hosts of an MPIJob with a template of its
+// HostfileTemplate, or in the default format when the template is unset.
+type hostTemplate struct {
+	tmpl          *template.Template
+	namespace     string
+	defaultFormat func(host string, slots int) string
+}
+
+func newHostTemplate(text, namespace string, defaultFormat func(host string, slots int) string) *hostTemplate {
+	t := &hostTemplate{namespace: namespace, defaultFormat: defaultFormat}
+	if text != "" {
+		// The templates of the MPIJobs failing the validation are not rendered.
+		t.tmpl, _ = kubeflowv1.ParseMPIHostTemplate(text)
+	}
+	return t
+}
+
+// hostfileTemplate returns the template of the lines of the hosts in the hostfile.
+func hostfileTemplate(mpiJob *kubeflowv1.MPIJob) *hostTemplate {
+	text := ""
+	if mpiJob.Spec.HostfileTemplate != nil {
+		text = mpiJob.Spec.HostfileTemplate.Hostfile
+	}
+	return newHostTemplate(text, mpiJob.Namespace, func(host string, slots int) string {
+		return strings.TrimSuffix(hostfileEntry(mpiJob.Spec.MPIImplementation, host, slots), "\n")
+	})
+}
+
+// discoverHostsTemplate returns the template of the hosts echoed by discover_hosts.sh.
+func discoverHostsTemplate(mpiJob *kubeflowv1.MPIJob) *hostTemplate {
+	text := ""
+	if mpiJob.Spec.HostfileTemplate != nil {
+		text = mpiJob.Spec.HostfileTemplate.DiscoverHosts
+	}
+	return newHostTemplate(text, mpiJob.Namespace, func(host string, slots int) string {
+		return fmt.Sprintf("%s:%d", host, slots)
+	})
+}
+
+// render returns the entry of the host of the pod, without trailing newline. The entries which
+// fail to render, which the validation of the templates rules out, are in the default format.
+func (t *hostTemplate) render(host, podName string, slots int) string {
+	if t.tmpl != nil {
+		var b strings.Builder
+		if err := t.tmpl.Execute(&b, kubeflowv1.MPIHostTemplateData(host, podName, t.namespace, slots)); err == nil {
+			return strings.TrimRight(b.String(), "\n")
+		}
+	}
+	return t.defaultFormat(host, slots)
+}
@@ -0,0 +1,88 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mpi
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+)
+
+func TestHostfileTemplate(t *testing.T) {
+	cases := map[string]struct {
+		template          *kubeflowv1.MPIHostfileTemplate
+		hosts             map[string]string
+		wantHostfile      string
+		wantDiscoverHosts string
+	}{
+		"no template": {
+			wantHostfile:      "test-worker-0 slots=2\ntest-worker-1 slots=2\n",
+			wantDiscoverHosts: "#!/bin/sh\necho test-worker-0:2\necho test-worker-1:2",
+		},
+		"max slots": {
+			template: &kubeflowv1.MPIHostfileTemplate{
+				Hostfile: "{{.Host}} slots={{.Slots}} max_slots={{.Slots}}\n",
+			},
+			wantHostfile:      "test-worker-0 slots=2 max_slots=2\ntest-worker-1 slots=2 max_slots=2\n",
+			wantDiscoverHosts: "#!/bin/sh\necho test-worker-0:2\necho test-worker-1:2",
+		},
+		"custom domain suffix": {
+			template: &kubeflowv1.MPIHostfileTemplate{
+				Hostfile:      "{{.Host}}.{{.Namespace}}.example.com:{{.Slots}}",
+				DiscoverHosts: "{{.Host}}.{{.Namespace}}.example.com:{{.Slots}}",
+			},
+			wantHostfile:      "test-worker-0.default.example.com:2\ntest-worker-1.default.example.com:2\n",
+			wantDiscoverHosts: "#!/bin/sh\necho test-worker-0.default.example.com:2\necho test-worker-1.default.example.com:2",
+		},
+		"hosts of the pods": {
+			template: &kubeflowv1.MPIHostfileTemplate{
+				Hostfile:      "{{.Host}} slots={{.Slots}} # {{.PodName}}",
+				DiscoverHosts: "{{.Host}}:{{.Slots}}",
+			},
+			hosts:             map[string]string{"test-worker-0": "10.0.0.1", "test-worker-1": "10.0.0.2"},
+			wantHostfile:      "10.0.0.1 slots=2 # test-worker-0\n10.0.0.2 slots=2 # test-worker-1\n",
+			wantDiscoverHosts: "#!/bin/sh\necho 10.0.0.1:2\necho 10.0.0.2:2",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mpiJob := &kubeflowv1.MPIJob{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+				Spec: kubeflowv1.MPIJobSpec{
+					SlotsPerWorker:   ptr.To(intstr.FromInt32(2)),
+					HostfileTemplate: tc.template,
+				},
+			}
+			runningPods := []*corev1.Pod{
+				{ObjectMeta: metav1.ObjectMeta{Name: "test-worker-1", Namespace: "default"}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "test-worker-0", Namespace: "default"}},
+			}
+			cm := newConfigMap(mpiJob, 2, false, tc.hosts)
+			updateDiscoverHostsInConfigMap(cm, mpiJob, runningPods, false, tc.hosts)
+			if diff := cmp.Diff(tc.wantHostfile, cm.Data[hostfileName]); len(diff) != 0 {
+				t.Errorf("Unexpected hostfile (-want,+got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantDiscoverHosts, cm.Data[discoverHostsScriptName]); len(diff) != 0 {
+				t.Errorf("Unexpected discover_hosts.sh (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
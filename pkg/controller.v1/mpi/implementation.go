@@ -0,0 +1,98 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mpi
+
+import (
+	"fmt"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+
+	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+)
+
+// mpiPortRangeSize is the number of ports, starting from MPIJobDefaultPort, which the
+// MPI processes listen on when the MPI implementation of the MPIJob is set.
+const mpiPortRangeSize = 1000
+
+// hostfileEntry returns the line of the hostfile for a host with the given number of slots,
+// in the format of the MPI implementation.
+func hostfileEntry(implementation kubeflowv1.MPIImplementation, host string, slots int) string {
+	switch implementation {
+	case kubeflowv1.MPIImplementationIntelMPI, kubeflowv1.MPIImplementationMPICH:
+		return fmt.Sprintf("%s:%d\n", host, slots)
+	default:
+		return fmt.Sprintf("%s slots=%d\n", host, slots)
+	}
+}
+
+// implementationEnv returns the environment variables of the MPI implementation: the remote shell
+// agent and the hostfile for the launcher, and the port range for the launcher and the workers.
+func implementationEnv(implementation kubeflowv1.MPIImplementation, isLauncher bool) []corev1.EnvVar {
+	kubexec := fmt.Sprintf("%s/%s", configMountPath, kubexecScriptName)
+	hostfile := fmt.Sprintf("%s/%s", configMountPath, hostfileName)
+	portMin := kubeflowv1.MPIJobDefaultPort
+	portRange := fmt.Sprintf("%d:%d", portMin, portMin+mpiPortRangeSize-1)
+
+	var launcherEnv, env []corev1.EnvVar
+	switch implementation {
+	case kubeflowv1.MPIImplementationOpenMPI:
+		launcherEnv = []corev1.EnvVar{
+			{Name: "OMPI_MCA_plm_rsh_agent", Value: kubexec},
+			{Name: "OMPI_MCA_orte_default_hostfile", Value: hostfile},
+		}
+		env = []corev1.EnvVar{
+			{Name: "OMPI_MCA_btl_tcp_port_min_v4", Value: strconv.Itoa(portMin)},
+			{Name: "OMPI_MCA_btl_tcp_port_range_v4", Value: strconv.Itoa(mpiPortRangeSize)},
+		}
+	case kubeflowv1.MPIImplementationIntelMPI:
+		launcherEnv = []corev1.EnvVar{
+			{Name: "I_MPI_HYDRA_HOST_FILE", Value: hostfile},
+			{Name: "I_MPI_HYDRA_BOOTSTRAP", Value: iMPIDefaultBootstrap},
+			{Name: "I_MPI_HYDRA_BOOTSTRAP_EXEC", Value: kubexec},
+		}
+		env = []corev1.EnvVar{
+			{Name: "I_MPI_PORT_RANGE", Value: portRange},
+		}
+	case kubeflowv1.MPIImplementationMPICH:
+		launcherEnv = []corev1.EnvVar{
+			{Name: "HYDRA_HOST_FILE", Value: hostfile},
+			{Name: "HYDRA_LAUNCHER", Value: iMPIDefaultBootstrap},
+			{Name: "HYDRA_LAUNCHER_EXEC", Value: kubexec},
+		}
+		env = []corev1.EnvVar{
+			{Name: "MPIR_CVAR_CH3_PORT_RANGE", Value: portRange},
+		}
+	}
+	if isLauncher {
+		return append(launcherEnv, env...)
+	}
+	return env
+}
+
+// appendMissingEnv appends the environment variables which are not already set in envs,
+// so that the values set by the user are kept.
+func appendMissingEnv(envs []corev1.EnvVar, extra ...corev1.EnvVar) []corev1.EnvVar {
+	set := make(map[string]bool, len(envs))
+	for _, env := range envs {
+		set[env.Name] = true
+	}
+	for _, env := range extra {
+		if !set[env.Name] {
+			envs = append(envs, env)
+		}
+	}
+	return envs
+}
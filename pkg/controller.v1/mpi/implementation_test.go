@@ -0,0 +1,137 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mpi
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+
+	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	"github.com/kubeflow/training-operator/pkg/controller.v1/common"
+)
+
+func TestHostfileOfMPIImplementation(t *testing.T) {
+	cases := map[kubeflowv1.MPIImplementation]string{
+		"":                                   "test-worker-0 slots=2\ntest-worker-1 slots=2\n",
+		kubeflowv1.MPIImplementationOpenMPI:  "test-worker-0 slots=2\ntest-worker-1 slots=2\n",
+		kubeflowv1.MPIImplementationIntelMPI: "test-worker-0:2\ntest-worker-1:2\n",
+		kubeflowv1.MPIImplementationMPICH:    "test-worker-0:2\ntest-worker-1:2\n",
+	}
+	for implementation, want := range cases {
+		t.Run(string(implementation), func(t *testing.T) {
+			mpiJob := &kubeflowv1.MPIJob{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+				Spec: kubeflowv1.MPIJobSpec{
+					SlotsPerWorker:    ptr.To(intstr.FromInt32(2)),
+					MPIImplementation: implementation,
+				},
+			}
+			cm := newConfigMap(mpiJob, 2, false, nil)
+			if diff := cmp.Diff(want, cm.Data[hostfileName]); len(diff) != 0 {
+				t.Errorf("Unexpected hostfile (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestImplementationEnv(t *testing.T) {
+	cases := map[string]struct {
+		implementation kubeflowv1.MPIImplementation
+		isLauncher     bool
+		env            []corev1.EnvVar
+		want           map[string]string
+	}{
+		"Open MPI launcher": {
+			implementation: kubeflowv1.MPIImplementationOpenMPI,
+			isLauncher:     true,
+			want: map[string]string{
+				"OMPI_MCA_plm_rsh_agent":         "/etc/mpi/kubexec.sh",
+				"OMPI_MCA_orte_default_hostfile": "/etc/mpi/hostfile",
+				"OMPI_MCA_btl_tcp_port_min_v4":   "9999",
+				"OMPI_MCA_btl_tcp_port_range_v4": "1000",
+			},
+		},
+		"Intel MPI launcher keeps the bootstrap of the user": {
+			implementation: kubeflowv1.MPIImplementationIntelMPI,
+			isLauncher:     true,
+			env:            []corev1.EnvVar{{Name: "I_MPI_HYDRA_BOOTSTRAP", Value: "ssh"}},
+			want: map[string]string{
+				"I_MPI_HYDRA_HOST_FILE":      "/etc/mpi/hostfile",
+				"I_MPI_HYDRA_BOOTSTRAP":      "ssh",
+				"I_MPI_HYDRA_BOOTSTRAP_EXEC": "/etc/mpi/kubexec.sh",
+				"I_MPI_PORT_RANGE":           "9999:10998",
+			},
+		},
+		"MPICH launcher": {
+			implementation: kubeflowv1.MPIImplementationMPICH,
+			isLauncher:     true,
+			want: map[string]string{
+				"HYDRA_HOST_FILE":          "/etc/mpi/hostfile",
+				"HYDRA_LAUNCHER":           "rsh",
+				"HYDRA_LAUNCHER_EXEC":      "/etc/mpi/kubexec.sh",
+				"MPIR_CVAR_CH3_PORT_RANGE": "9999:10998",
+			},
+		},
+		"MPICH worker": {
+			implementation: kubeflowv1.MPIImplementationMPICH,
+			want: map[string]string{
+				"MPIR_CVAR_CH3_PORT_RANGE": "9999:10998",
+			},
+		},
+		"implementation is not set": {
+			want: map[string]string{},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			env := appendMissingEnv(tc.env, implementationEnv(tc.implementation, tc.isLauncher)...)
+			got := make(map[string]string, len(env))
+			for _, e := range env {
+				got[e.Name] = e.Value
+			}
+			if diff := cmp.Diff(tc.want, got); len(diff) != 0 {
+				t.Errorf("Unexpected env (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMPIEnvInjectionDisabled(t *testing.T) {
+	jc := &MPIJobReconciler{JobController: common.JobController{Recorder: record.NewFakeRecorder(10)}}
+	jc.JobController.Controller = jc
+	mpiJob := newDryRunMPIJob(nil)
+	mpiJob.Spec.MPIImplementation = kubeflowv1.MPIImplementationIntelMPI
+	mpiJob.Spec.RunPolicy.EnvInjectionPolicy = &kubeflowv1.EnvInjectionPolicy{DisableMPI: true}
+
+	worker, launcher := newTestPods(t, jc, mpiJob)
+	for _, pod := range []*corev1.Pod{launcher, worker} {
+		for _, env := range pod.Spec.Containers[0].Env {
+			if strings.HasPrefix(env.Name, "I_MPI_") || strings.HasPrefix(env.Name, "OMPI_") {
+				t.Errorf("Unexpected MPI env var %s in pod %s", env.Name, pod.Name)
+			}
+		}
+	}
+	// The hostfile and the kubexec script are still mounted.
+	if got := len(launcher.Spec.Containers[0].VolumeMounts); got == 0 {
+		t.Errorf("Expected the volumes of the launcher to be mounted")
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mpi
+
+import (
+	"context"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	"github.com/kubeflow/training-operator/pkg/controller.v1/common"
+	commonutil "github.com/kubeflow/training-operator/pkg/util"
+	trainutil "github.com/kubeflow/training-operator/pkg/util/train"
+)
+
+// isLauncherAsJob returns true if the launcher of the MPIJob runs in a batch/v1 Job.
+func isLauncherAsJob(mpiJob *kubeflowv1.MPIJob) bool {
+	return ptr.Deref(mpiJob.Spec.LauncherAsJob, false)
+}
+
+// getLauncher gets the launcher of the MPIJob. When the launcher runs in a
+// batch/v1 Job, the returned pod reflects the state of the Job.
+func (jc *MPIJobReconciler) getLauncher(mpiJob *kubeflowv1.MPIJob) (*corev1.Pod, error) {
+	if !isLauncherAsJob(mpiJob) {
+		return jc.getLauncherJob(mpiJob)
+	}
+	job, err := jc.getLauncherBatchJob(mpiJob)
+	if job == nil || err != nil {
+		return nil, err
+	}
+	return launcherPodFromJob(job), nil
+}
+
+// getLauncherBatchJob gets the batch/v1 Job of the launcher controlled by this MPIJob.
+func (jc *MPIJobReconciler) getLauncherBatchJob(mpiJob *kubeflowv1.MPIJob) (*batchv1.Job, error) {
+	job := &batchv1.Job{}
+	err := jc.Get(context.Background(), types.NamespacedName{Namespace: mpiJob.Namespace, Name: mpiJob.Name + launcherSuffix}, job)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !metav1.IsControlledBy(job, mpiJob) {
+		return nil, jc.resourceExists(mpiJob, "Job", job.Name)
+	}
+	return job, nil
+}
+
+// GetOutputPods returns the pods of the batch/v1 Job of the launcher of the MPIJob, which report
+// its outputs but are not controlled by the MPIJob.
+func (jc *MPIJobReconciler) GetOutputPods(job interface{}) ([]*corev1.Pod, error) {
+	mpiJob, ok := job.(*kubeflowv1.MPIJob)
+	if !ok || !isLauncherAsJob(mpiJob) {
+		return nil, nil
+	}
+	launcher, err := jc.getLauncherBatchJob(mpiJob)
+	if launcher == nil || err != nil {
+		return nil, err
+	}
+	podList := &corev1.PodList{}
+	if err := jc.List(context.Background(), podList, client.InNamespace(mpiJob.Namespace), client.MatchingLabels(jc.GenLabels(mpiJob.Name))); err != nil {
+		return nil, err
+	}
+	var pods []*corev1.Pod
+	for i := range podList.Items {
+		if metav1.IsControlledBy(&podList.Items[i], launcher) {
+			pods = append(pods, &podList.Items[i])
+		}
+	}
+	return pods, nil
+}
+
+// createLauncher creates the launcher pod, or the batch/v1 Job running it.
+func (jc *MPIJobReconciler) createLauncher(mpiJob *kubeflowv1.MPIJob, launcherPod *corev1.Pod) (*corev1.Pod, error) {
+	jc.RecordAPICall(mpiJob, common.APICallCreate)
+	if !isLauncherAsJob(mpiJob) {
+		return jc.KubeClientSet.CoreV1().Pods(mpiJob.Namespace).Create(context.Background(), launcherPod, metav1.CreateOptions{})
+	}
+	job, err := jc.KubeClientSet.BatchV1().Jobs(mpiJob.Namespace).Create(context.Background(), newLauncherBatchJob(mpiJob, launcherPod), metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return launcherPodFromJob(job), nil
+}
+
+// deleteLauncherBatchJob deletes the batch/v1 Job of the launcher, together
+// with its pods, when the MPIJob is suspended, or when it has finished and
+// the CleanPodPolicy asks for the pods to be deleted. The pods of the Job are
+// not controlled by the MPIJob, so the common cleanup does not reach them.
+func (jc *MPIJobReconciler) deleteLauncherBatchJob(mpiJob *kubeflowv1.MPIJob) error {
+	if !isLauncherAsJob(mpiJob) {
+		return nil
+	}
+	suspended := trainutil.IsJobSuspended(&mpiJob.Spec.RunPolicy)
+	cleanPodPolicy := ptr.Deref(mpiJob.Spec.RunPolicy.CleanPodPolicy, kubeflowv1.CleanPodPolicyNone)
+	if !suspended && (!commonutil.IsFinished(mpiJob.Status) || cleanPodPolicy == kubeflowv1.CleanPodPolicyNone) {
+		return nil
+	}
+	job, err := jc.getLauncherBatchJob(mpiJob)
+	if job == nil || err != nil {
+		return err
+	}
+	if job.DeletionTimestamp != nil {
+		return nil
+	}
+	// Like the pods, a finished launcher is kept by the Running policy.
+	if !suspended && cleanPodPolicy == kubeflowv1.CleanPodPolicyRunning && isPodFinished(launcherPodFromJob(job)) {
+		return nil
+	}
+	jc.RecordAPICall(mpiJob, common.APICallDelete)
+	err = jc.KubeClientSet.BatchV1().Jobs(job.Namespace).Delete(context.Background(), job.Name, metav1.DeleteOptions{
+		PropagationPolicy: ptr.To(metav1.DeletePropagationBackground),
+	})
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	jc.Recorder.Eventf(mpiJob, corev1.EventTypeNormal, commonutil.SuccessfulDeleteJobReason, "Deleted launcher Job: %v", job.Name)
+	return nil
+}
+
+// newLauncherBatchJob wraps the launcher pod in a batch/v1 Job, which retries
+// the launcher up to RunPolicy.BackoffLimit times.
+func newLauncherBatchJob(mpiJob *kubeflowv1.MPIJob, launcherPod *corev1.Pod) *batchv1.Job {
+	podSpec := launcherPod.Spec.DeepCopy()
+	// A Job only accepts the Never and OnFailure restart policies.
+	if podSpec.RestartPolicy != corev1.RestartPolicyOnFailure {
+		podSpec.RestartPolicy = corev1.RestartPolicyNever
+	}
+	return &batchv1.Job{
+		ObjectMeta: *launcherPod.ObjectMeta.DeepCopy(),
+		Spec: batchv1.JobSpec{
+			BackoffLimit: mpiJob.Spec.RunPolicy.BackoffLimit,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      launcherPod.Labels,
+					Annotations: launcherPod.Annotations,
+				},
+				Spec: *podSpec,
+			},
+		},
+	}
+}
+
+// launcherPodFromJob returns a pod whose phase reflects the state of the launcher Job,
+// so that the status of the MPIJob is computed the same way as for a bare launcher pod.
+func launcherPodFromJob(job *batchv1.Job) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: *job.ObjectMeta.DeepCopy(),
+		Status:     corev1.PodStatus{Phase: corev1.PodPending},
+	}
+	for _, c := range job.Status.Conditions {
+		if c.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch c.Type {
+		case batchv1.JobComplete:
+			pod.Status.Phase = corev1.PodSucceeded
+			return pod
+		case batchv1.JobFailed:
+			pod.Status.Phase = corev1.PodFailed
+			pod.Status.Reason = c.Reason
+			pod.Status.Message = c.Message
+			return pod
+		}
+	}
+	if ptr.Deref(job.Status.Ready, 0) > 0 {
+		pod.Status.Phase = corev1.PodRunning
+	}
+	return pod
+}
@@ -0,0 +1,172 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mpi
+
+import (
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	"github.com/kubeflow/training-operator/pkg/controller.v1/common"
+)
+
+func TestLauncherPodFromJob(t *testing.T) {
+	cases := map[string]struct {
+		status     batchv1.JobStatus
+		wantPhase  corev1.PodPhase
+		wantReason string
+	}{
+		"pod of the job is not ready yet": {
+			status:    batchv1.JobStatus{Active: 1, Ready: ptr.To[int32](0)},
+			wantPhase: corev1.PodPending,
+		},
+		"pod of the job is ready": {
+			status:    batchv1.JobStatus{Active: 1, Ready: ptr.To[int32](1)},
+			wantPhase: corev1.PodRunning,
+		},
+		"launcher is retried after a failure": {
+			status:    batchv1.JobStatus{Active: 1, Failed: 1, Ready: ptr.To[int32](1)},
+			wantPhase: corev1.PodRunning,
+		},
+		"job completed": {
+			status: batchv1.JobStatus{
+				Succeeded:  1,
+				Conditions: []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}},
+			},
+			wantPhase: corev1.PodSucceeded,
+		},
+		"job exceeded the backoff limit": {
+			status: batchv1.JobStatus{
+				Failed: 4,
+				Conditions: []batchv1.JobCondition{
+					{Type: batchv1.JobFailureTarget, Status: corev1.ConditionTrue, Reason: "BackoffLimitExceeded"},
+					{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Reason: "BackoffLimitExceeded"},
+				},
+			},
+			wantPhase:  corev1.PodFailed,
+			wantReason: "BackoffLimitExceeded",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			job := &batchv1.Job{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-launcher", Namespace: "default"},
+				Status:     tc.status,
+			}
+			pod := launcherPodFromJob(job)
+			if pod.Status.Phase != tc.wantPhase {
+				t.Errorf("Unexpected phase: want %q, got %q", tc.wantPhase, pod.Status.Phase)
+			}
+			if pod.Status.Reason != tc.wantReason {
+				t.Errorf("Unexpected reason: want %q, got %q", tc.wantReason, pod.Status.Reason)
+			}
+			if pod.Name != job.Name {
+				t.Errorf("Unexpected name: want %q, got %q", job.Name, pod.Name)
+			}
+		})
+	}
+}
+
+func TestNewLauncherBatchJob(t *testing.T) {
+	mpiJob := &kubeflowv1.MPIJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: kubeflowv1.MPIJobSpec{
+			LauncherAsJob: ptr.To(true),
+			RunPolicy: kubeflowv1.RunPolicy{
+				BackoffLimit: ptr.To[int32](3),
+			},
+		},
+	}
+	launcherPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-launcher",
+			Namespace: "default",
+			Labels:    map[string]string{kubeflowv1.ReplicaTypeLabel: "launcher"},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(mpiJob, kubeflowv1.MPIJobSchemeGroupVersionKind),
+			},
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy: corev1.RestartPolicyAlways,
+			Containers:    []corev1.Container{{Name: "mpi"}},
+		},
+	}
+
+	job := newLauncherBatchJob(mpiJob, launcherPod)
+	if job.Name != launcherPod.Name || !metav1.IsControlledBy(job, mpiJob) {
+		t.Errorf("Expected the Job %q to be controlled by the MPIJob, got %q with owners %v", launcherPod.Name, job.Name, job.OwnerReferences)
+	}
+	if got := ptr.Deref(job.Spec.BackoffLimit, 0); got != 3 {
+		t.Errorf("Unexpected backoff limit: want 3, got %d", got)
+	}
+	if got := job.Spec.Template.Spec.RestartPolicy; got != corev1.RestartPolicyNever {
+		t.Errorf("Unexpected restart policy: want %q, got %q", corev1.RestartPolicyNever, got)
+	}
+	if got := job.Spec.Template.Labels[kubeflowv1.ReplicaTypeLabel]; got != "launcher" {
+		t.Errorf("Unexpected replica type label of the pod template: %q", got)
+	}
+	if launcherPod.Spec.RestartPolicy != corev1.RestartPolicyAlways {
+		t.Errorf("The launcher pod must not be modified")
+	}
+}
+
+func TestGetOutputPods(t *testing.T) {
+	mpiJob := newDryRunMPIJob(nil)
+	mpiJob.Spec.LauncherAsJob = ptr.To(true)
+	launcher := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            mpiJob.Name + launcherSuffix,
+			Namespace:       mpiJob.Namespace,
+			UID:             "launcher-uid",
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(mpiJob, kubeflowv1.MPIJobSchemeGroupVersionKind)},
+		},
+	}
+	jc := &MPIJobReconciler{JobController: common.JobController{Recorder: record.NewFakeRecorder(10)}}
+	jc.JobController.Controller = jc
+	newPod := func(name string, owner metav1.Object, gvk schema.GroupVersionKind) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       mpiJob.Namespace,
+			Labels:          jc.GenLabels(mpiJob.Name),
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(owner, gvk)},
+		}}
+	}
+	jc.Client = fake.NewClientBuilder().WithObjects(
+		launcher,
+		newPod("test-launcher-abcde", launcher, batchv1.SchemeGroupVersion.WithKind("Job")),
+		newPod("test-worker-0", mpiJob, kubeflowv1.MPIJobSchemeGroupVersionKind),
+	).Build()
+
+	pods, err := jc.GetOutputPods(mpiJob)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(pods) != 1 || pods[0].Name != "test-launcher-abcde" {
+		t.Errorf("Unexpected output pods, want the pod of the launcher Job, got: %v", pods)
+	}
+
+	// The launcher pod controlled by the MPIJob is one of the pods of the job.
+	mpiJob.Spec.LauncherAsJob = nil
+	if pods, err := jc.GetOutputPods(mpiJob); len(pods) != 0 || err != nil {
+		t.Errorf("Unexpected output pods without launcher Job: %v, %v", pods, err)
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mpi
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+)
+
+const (
+	// launcherBaseCPUMillis and launcherBaseMemoryMiB are the requests of a launcher of a single rank.
+	launcherBaseCPUMillis = 250
+	launcherBaseMemoryMiB = 256
+	// launcherCPUMillisPerRank and launcherMemoryMiBPerRank are the requests added for each rank of
+	// the job, for the connections and the buffers of mpirun and of the orted daemons.
+	launcherCPUMillisPerRank = 2
+	launcherMemoryMiBPerRank = 4
+)
+
+// jobRanks returns the number of ranks of the MPIJob, the slots of all its workers.
+func jobRanks(mpiJob *kubeflowv1.MPIJob) int64 {
+	workers := int64(0)
+	if spec := mpiJob.Spec.MPIReplicaSpecs[kubeflowv1.MPIJobReplicaTypeWorker]; spec != nil && spec.Replicas != nil {
+		workers = int64(*spec.Replicas)
+	}
+	return workers * int64(replicaSlots(mpiJob, kubeflowv1.MPIJobReplicaTypeWorker))
+}
+
+// setLauncherResources defaults the CPU and memory requests of the launcher container from the
+// ranks of the MPIJob. A resource whose request or limit is set by the user is left untouched,
+// so that the requests are never above the limits.
+func setLauncherResources(container *corev1.Container, mpiJob *kubeflowv1.MPIJob) {
+	ranks := jobRanks(mpiJob)
+	requests := corev1.ResourceList{
+		corev1.ResourceCPU:    *resource.NewMilliQuantity(launcherBaseCPUMillis+launcherCPUMillisPerRank*ranks, resource.DecimalSI),
+		corev1.ResourceMemory: *resource.NewQuantity((launcherBaseMemoryMiB+launcherMemoryMiBPerRank*ranks)<<20, resource.BinarySI),
+	}
+	for name, quantity := range requests {
+		if _, ok := container.Resources.Requests[name]; ok {
+			continue
+		}
+		if _, ok := container.Resources.Limits[name]; ok {
+			continue
+		}
+		if container.Resources.Requests == nil {
+			container.Resources.Requests = corev1.ResourceList{}
+		}
+		container.Resources.Requests[name] = quantity
+	}
+}
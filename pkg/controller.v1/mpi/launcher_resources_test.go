@@ -0,0 +1,82 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mpi
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+)
+
+func TestSetLauncherResources(t *testing.T) {
+	mpiJob := &kubeflowv1.MPIJob{
+		Spec: kubeflowv1.MPIJobSpec{
+			SlotsPerWorker: ptr.To(intstr.FromInt32(8)),
+			MPIReplicaSpecs: map[kubeflowv1.ReplicaType]*kubeflowv1.ReplicaSpec{
+				kubeflowv1.MPIJobReplicaTypeWorker: {Replicas: ptr.To[int32](64)},
+			},
+		},
+	}
+	cases := map[string]struct {
+		resources corev1.ResourceRequirements
+		want      corev1.ResourceRequirements
+	}{
+		"requests scaled with the ranks": {
+			want: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("1274m"),
+					corev1.ResourceMemory: resource.MustParse("2304Mi"),
+				},
+			},
+		},
+		"requests of the user are kept": {
+			resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")},
+			},
+			want: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("4"),
+					corev1.ResourceMemory: resource.MustParse("2304Mi"),
+				},
+			},
+		},
+		"limits of the user are kept": {
+			resources: corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+			},
+			want: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1274m")},
+				Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			container := &corev1.Container{Resources: tc.resources}
+			setLauncherResources(container, mpiJob)
+			if diff := cmp.Diff(tc.want, container.Resources, cmp.Comparer(func(a, b resource.Quantity) bool {
+				return a.Cmp(b) == 0
+			})); len(diff) != 0 {
+				t.Errorf("Unexpected launcher resources (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mpi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kubeflow/training-operator/pkg/controller.v1/common"
+)
+
+func newLauncherRoleWorkers(names ...string) []*corev1.Pod {
+	var workers []*corev1.Pod
+	for _, name := range names {
+		workers = append(workers, &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}})
+	}
+	return workers
+}
+
+// launcherRoleExecNames returns the names of the pods the launcher Role grants exec into.
+func launcherRoleExecNames(role *rbacv1.Role) []string {
+	for _, rule := range role.Rules {
+		for _, resource := range rule.Resources {
+			if resource == "pods/exec" {
+				return rule.ResourceNames
+			}
+		}
+	}
+	return nil
+}
+
+func TestNewLauncherRole(t *testing.T) {
+	mpiJob := newDryRunMPIJob(nil)
+	deleting := newLauncherRoleWorkers("test-worker-2")[0]
+	deleting.DeletionTimestamp = &metav1.Time{}
+
+	testCases := map[string]struct {
+		workers   []*corev1.Pod
+		wantRules int
+		wantExec  []string
+	}{
+		"no workers": {
+			wantRules: 1,
+		},
+		"workers": {
+			workers:   newLauncherRoleWorkers("test-worker-1", "test-worker-0"),
+			wantRules: 2,
+			wantExec:  []string{"test-worker-0", "test-worker-1"},
+		},
+		"workers being deleted": {
+			workers:   append(newLauncherRoleWorkers("test-worker-0"), deleting),
+			wantRules: 2,
+			wantExec:  []string{"test-worker-0"},
+		},
+		"only workers being deleted": {
+			workers:   []*corev1.Pod{deleting},
+			wantRules: 1,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			role := newLauncherRole(mpiJob, tc.workers)
+			if len(role.Rules) != tc.wantRules {
+				t.Errorf("Unexpected number of rules: got %d, want %d", len(role.Rules), tc.wantRules)
+			}
+			if diff := cmp.Diff(tc.wantExec, launcherRoleExecNames(role)); len(diff) != 0 {
+				t.Errorf("Unexpected pods granted exec (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetOrCreateLauncherRole(t *testing.T) {
+	mpiJob := newDryRunMPIJob(nil)
+	existing := newLauncherRole(mpiJob, newLauncherRoleWorkers("test-worker-0", "test-worker-1", "test-worker-2"))
+	existing.ResourceVersion = "1"
+	kubeClient := kubefake.NewSimpleClientset(existing.DeepCopy())
+	jc := &MPIJobReconciler{
+		JobController: common.JobController{
+			Recorder:      record.NewFakeRecorder(10),
+			KubeClientSet: kubeClient,
+		},
+		Client: fake.NewClientBuilder().WithObjects(existing.DeepCopy()).Build(),
+	}
+	jc.JobController.Controller = jc
+
+	// The workers removed on scale-down are no longer granted exec.
+	role, err := jc.getOrCreateLauncherRole(mpiJob, newLauncherRoleWorkers("test-worker-0"))
+	if err != nil {
+		t.Fatalf("Failed to reconcile the launcher Role: %v", err)
+	}
+	if diff := cmp.Diff([]string{"test-worker-0"}, launcherRoleExecNames(role)); len(diff) != 0 {
+		t.Errorf("Unexpected pods granted exec after scale-down (-want,+got):\n%s", diff)
+	}
+	updated, err := kubeClient.RbacV1().Roles("default").Get(context.Background(), existing.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get the launcher Role: %v", err)
+	}
+	if diff := cmp.Diff([]string{"test-worker-0"}, launcherRoleExecNames(updated)); len(diff) != 0 {
+		t.Errorf("Unexpected pods granted exec by the updated Role (-want,+got):\n%s", diff)
+	}
+
+	// The Role is not updated while its rules match the workers.
+	kubeClient.ClearActions()
+	if _, err := jc.getOrCreateLauncherRole(mpiJob, newLauncherRoleWorkers("test-worker-0", "test-worker-1", "test-worker-2")); err != nil {
+		t.Fatalf("Failed to reconcile the launcher Role: %v", err)
+	}
+	if actions := kubeClient.Actions(); len(actions) != 0 {
+		t.Errorf("Expected no update of the Role matching the workers, got %v", actions)
+	}
+}
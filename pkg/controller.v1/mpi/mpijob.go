@@ -15,9 +15,9 @@
 package mpi
 
 import (
-	"strings"
-
 	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	"github.com/kubeflow/training-operator/pkg/core"
+	commonutil "github.com/kubeflow/training-operator/pkg/util"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -31,6 +31,7 @@ const (
 	kubexecScriptName       = "kubexec.sh"
 	hostfileName            = "hostfile"
 	discoverHostsScriptName = "discover_hosts.sh"
+	hostMapName             = "hostmap"
 	kubectlDeliveryName     = "kubectl-delivery"
 	kubectlTargetDirEnv     = "TARGET_DIR"
 	kubectlVolumeName       = "mpi-job-kubectl"
@@ -48,58 +49,29 @@ const (
 )
 
 const (
-	// ErrResourceExists is used as part of the Event 'reason' when an MPIJob
-	// fails to sync due to dependent resources of the same name already
-	// existing.
-	ErrResourceExists = "ErrResourceExists"
-
 	// MessageResourceExists is the message used for Events when a resource
 	// fails to sync due to dependent resources already existing.
 	MessageResourceExists = "Resource %q of MPIJobKind %q already exists and is not managed by MPIJob"
 
-	// ErrResourceDoesNotExist is used as part of the Event 'reason' when some
-	// resource is missing in yaml
-	ErrResourceDoesNotExist = "ErrResourceDoesNotExist"
-
 	// MessageResourceDoesNotExist is used for Events when some
 	// resource is missing in yaml
 	MessageResourceDoesNotExist = "Resource %q is missing in yaml"
-
-	// podTemplateRestartPolicyReason is the warning reason when the restart
-	// policy is set in pod template.
-	podTemplateRestartPolicyReason = "SettedPodTemplateRestartPolicy"
-
-	// podTemplateSchedulerNameReason is the warning reason when other scheduler name is set
-	// in pod templates with gang-scheduling enabled
-	podTemplateSchedulerNameReason = "SettedPodTemplateSchedulerName"
-
-	// mpiJobEvict
-	mpiJobEvict = "MPIJobEvicted"
 )
 
-// initializeMPIJobStatuses initializes the ReplicaStatuses for MPIJob.
-func initializeMPIJobStatuses(mpiJob *kubeflowv1.MPIJob, rType kubeflowv1.ReplicaType) {
-	if mpiJob.Status.ReplicaStatuses == nil {
-		mpiJob.Status.ReplicaStatuses = make(map[kubeflowv1.ReplicaType]*kubeflowv1.ReplicaStatus)
-	}
-
-	mpiJob.Status.ReplicaStatuses[rType] = &kubeflowv1.ReplicaStatus{}
-}
-
-// updateMPIJobConditions updates the conditions of the given mpiJob.
-func updateMPIJobConditions(mpiJob *kubeflowv1.MPIJob, conditionType kubeflowv1.JobConditionType, reason, message string) error {
-	condition := newCondition(conditionType, reason, message)
-	setCondition(&mpiJob.Status, condition)
+// updateMPIJobConditions updates the conditions of the given job status.
+func updateMPIJobConditions(jobStatus *kubeflowv1.JobStatus, conditionType kubeflowv1.JobConditionType, reason, message string, clock *commonutil.Clock) error {
+	condition := newCondition(conditionType, reason, message, clock.MetaNow())
+	setCondition(jobStatus, condition)
 	return nil
 }
 
-// newCondition creates a new mpiJob condition.
-func newCondition(conditionType kubeflowv1.JobConditionType, reason, message string) kubeflowv1.JobCondition {
+// newCondition creates a new mpiJob condition updated at now.
+func newCondition(conditionType kubeflowv1.JobConditionType, reason, message string, now metav1.Time) kubeflowv1.JobCondition {
 	return kubeflowv1.JobCondition{
 		Type:               conditionType,
 		Status:             corev1.ConditionTrue,
-		LastUpdateTime:     metav1.Now(),
-		LastTransitionTime: metav1.Now(),
+		LastUpdateTime:     now,
+		LastTransitionTime: now,
 		Reason:             reason,
 		Message:            message,
 	}
@@ -115,17 +87,6 @@ func getCondition(status kubeflowv1.JobStatus, condType kubeflowv1.JobConditionT
 	return nil
 }
 
-func isEvicted(status kubeflowv1.JobStatus) bool {
-	for _, condition := range status.Conditions {
-		if condition.Type == kubeflowv1.JobFailed &&
-			condition.Status == corev1.ConditionTrue &&
-			condition.Reason == mpiJobEvict {
-			return true
-		}
-	}
-	return false
-}
-
 // setCondition updates the mpiJob to include the provided condition.
 // If the condition that we are about to add already exists
 // and has the same status and reason then we are not going to update.
@@ -189,19 +150,30 @@ func isPodRunning(p *corev1.Pod) bool {
 	return p.Status.Phase == corev1.PodRunning
 }
 
-// isGPULauncher checks whether the launcher needs GPU.
-func isGPULauncher(mpiJob *kubeflowv1.MPIJob) bool {
-	for _, container := range mpiJob.Spec.MPIReplicaSpecs[kubeflowv1.MPIJobReplicaTypeLauncher].Template.Spec.Containers {
+// isGPULauncher checks whether the launcher needs GPU, including the GPUs of its resource profile.
+// An error is returned if the resource profile of the launcher can't be resolved.
+func isGPULauncher(mpiJob *kubeflowv1.MPIJob) (bool, error) {
+	launcherSpec := mpiJob.Spec.MPIReplicaSpecs[kubeflowv1.MPIJobReplicaTypeLauncher]
+	for _, container := range launcherSpec.Template.Spec.Containers {
 		for key := range container.Resources.Limits {
-			if strings.HasSuffix(string(key), gpuResourceNameSuffix) {
-				return true
-			}
-			if strings.Contains(string(key), gpuResourceNamePattern) {
-				return true
+			if isAcceleratorResource(key) {
+				return true, nil
 			}
 		}
 	}
-	return false
+	if launcherSpec.ResourceProfile == "" {
+		return false, nil
+	}
+	profile, err := core.ResourceProfile(launcherSpec.ResourceProfile)
+	if err != nil {
+		return false, err
+	}
+	for key := range profile.Limits {
+		if isAcceleratorResource(key) {
+			return true, nil
+		}
+	}
+	return false, nil
 }
 
 // hasIntelMPIBootstrapValues returns the existence of I_MPI_HYDRA_BOOTSTRAP
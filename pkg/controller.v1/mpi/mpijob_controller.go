@@ -18,16 +18,16 @@ import (
 	"bytes"
 	"context"
 	"fmt"
-	"reflect"
 	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
-	"github.com/sirupsen/logrus"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -37,18 +37,15 @@ import (
 	"k8s.io/client-go/informers"
 	kubeclientset "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/record"
-	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
-	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/source"
-	schedulerpluginsv1alpha1 "sigs.k8s.io/scheduler-plugins/apis/scheduling/v1alpha1"
-	"volcano.sh/apis/pkg/apis/scheduling/v1beta1"
 
 	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
 	trainingoperatorcommon "github.com/kubeflow/training-operator/pkg/common"
@@ -57,24 +54,24 @@ import (
 	"github.com/kubeflow/training-operator/pkg/controller.v1/common"
 	"github.com/kubeflow/training-operator/pkg/controller.v1/control"
 	"github.com/kubeflow/training-operator/pkg/controller.v1/expectation"
+	"github.com/kubeflow/training-operator/pkg/controller.v1/registry"
+	"github.com/kubeflow/training-operator/pkg/core"
+	"github.com/kubeflow/training-operator/pkg/features"
 	commonutil "github.com/kubeflow/training-operator/pkg/util"
 )
 
 const (
-	FailedDeleteJobReason     = "FailedDeleteJob"
-	SuccessfulDeleteJobReason = "SuccessfulDeleteJob"
-
 	controllerName  = "mpijob-controller"
 	labelMPIJobName = "mpi-job-name"
 )
 
-func NewReconciler(mgr manager.Manager, gangSchedulingSetupFunc common.GangSchedulingSetupFunc) *MPIJobReconciler {
+func NewReconciler(mgr manager.Manager, gangSchedulingSetupFunc common.GangSchedulingSetupFunc, options common.JobControllerOptions) *MPIJobReconciler {
 	r := &MPIJobReconciler{
 		Client:    mgr.GetClient(),
 		Scheme:    mgr.GetScheme(),
-		recorder:  mgr.GetEventRecorderFor(controllerName),
+		recorder:  commonutil.NewDeduplicatingRecorder(commonutil.NewEventRecorderFor(mgr, controllerName), ctlrconfig.Config.EventDeduplicationWindow),
 		apiReader: mgr.GetAPIReader(),
-		Log:       log.Log,
+		Log:       ctrl.Log.WithName("controllers").WithName(kubeflowv1.MPIJobKind),
 	}
 
 	cfg := mgr.GetConfig()
@@ -85,13 +82,18 @@ func NewReconciler(mgr manager.Manager, gangSchedulingSetupFunc common.GangSched
 	r.JobController = common.JobController{
 		Controller:                  r,
 		Expectations:                expectation.NewControllerExpectations(),
-		WorkQueue:                   &util.FakeWorkQueue{},
+		WorkQueue:                   util.NewRequeueWorkQueue(),
 		Recorder:                    r.recorder,
 		KubeClientSet:               kubeClientSet,
 		PriorityClassLister:         priorityClassInformer.Lister(),
 		PriorityClassInformerSynced: priorityClassInformer.Informer().HasSynced,
 		PodControl:                  control.RealPodControl{KubeClient: kubeClientSet, Recorder: r.recorder},
 		ServiceControl:              control.RealServiceControl{KubeClient: kubeClientSet, Recorder: r.recorder},
+		PodExecControl:              control.RealPodExecControl{Config: cfg, KubeClient: kubeClientSet},
+		JobRegistry:                 registry.Default,
+		JobControllerOptions:        options,
+		Clock:                       commonutil.NewClock(clock.RealClock{}, ctlrconfig.Config.ClockSkewTolerance),
+		OOMKillTracker:              common.NewOOMKillTracker(time.Now()),
 	}
 
 	gangSchedulingSetupFunc(&r.JobController)
@@ -114,27 +116,43 @@ type MPIJobReconciler struct {
 // +kubebuilder:rbac:groups=kubeflow.org,resources=mpijobs/finalizers,verbs=update
 // +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch;create
-// +kubebuilder:rbac:groups="",resources=configmaps,verbs=list;watch;create;update
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups="",resources=resourcequotas,verbs=get;list;watch
 // +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=roles,verbs=list;watch;create;update
 // +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=rolebindings,verbs=list;watch;create;update
 // +kubebuilder:rbac:groups="",resources=pods/exec,verbs=create
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
 // +kubebuilder:rbac:groups=scheduling.volcano.sh,resources=podgroups,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=scheduling.x-k8s.io,resources=podgroups,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=ray.io,resources=rayclusters,verbs=get;create;delete
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch;create;update
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;patch;delete
 // +kubebuilder:rbac:groups="",resources=events,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;update;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
 func (jc *MPIJobReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	_ = log.FromContext(ctx)
-	logger := jc.Log.WithValues(kubeflowv1.MPIJobSingular, req.NamespacedName)
+	defer trainingoperatorcommon.ReconcileDurationObserve(jc.GetFrameworkName(), time.Now())
+	logger := jc.Log.WithValues("kind", kubeflowv1.MPIJobKind, "namespace", req.Namespace, "name", req.Name)
 
 	mpijob := &kubeflowv1.MPIJob{}
 	err := jc.Get(ctx, req.NamespacedName, mpijob)
 	if err != nil {
-		logger.Info(err.Error(), "unable to fetch MPIJob", req.NamespacedName.String())
+		logger.Info("unable to fetch MPIJob", "error", err.Error())
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
+	if !common.ReconciledByThisController(mpijob) {
+		logger.V(1).Info("Skipping MPIJob pinned to another controller", "controller-identity", mpijob.GetAnnotations()[kubeflowv1.ControllerIdentityAnnotation])
+		return ctrl.Result{}, nil
+	}
+
 	if manager := jc.ManagedByExternalController(mpijob.Spec.RunPolicy.ManagedBy); manager != nil {
 		logger.Info("Skipping MPIJob managed by a custom controller", "managed-by", manager)
 		return ctrl.Result{}, nil
@@ -165,19 +183,27 @@ func (jc *MPIJobReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	mpijob.Spec.CleanPodPolicy = cleanPolicyDefined
 	mpijob.Spec.RunPolicy.CleanPodPolicy = cleanPolicyDefined
 
+	if err = jc.deleteLauncherBatchJob(mpijob); err != nil {
+		logger.Error(err, "Delete launcher Job error")
+		return ctrl.Result{}, err
+	}
+
 	// Use common to reconcile the job related pod and service
 	// MPIJob needs not service
 	err = jc.ReconcileJobs(mpijob, mpijob.Spec.MPIReplicaSpecs, mpijob.Status, &mpijob.Spec.RunPolicy)
 	if err != nil {
-		logrus.Warnf("Reconcile MPIJob error %v", err)
+		logger.Error(err, "Reconcile MPIJob error")
 		return ctrl.Result{}, err
 	}
 
-	t, err := util.DurationUntilExpireTime(&mpijob.Spec.RunPolicy, mpijob.Status)
+	t, err := util.DurationUntilExpireTime(&mpijob.Spec.RunPolicy, mpijob.Status, jc.Clock)
 	if err != nil {
-		logrus.Warnf("Reconcile MPIJob Job error %v", err)
+		logger.Error(err, "Reconcile MPIJob Job error")
 		return ctrl.Result{}, err
 	}
+	if refresh := discoverHostsRefreshInterval(mpijob); refresh > 0 && (t < 0 || refresh < t) {
+		t = refresh
+	}
 	if t >= 0 {
 		return ctrl.Result{Requeue: true, RequeueAfter: t}, nil
 	}
@@ -188,8 +214,9 @@ func (jc *MPIJobReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 // SetupWithManager sets up the controller with the Manager.
 func (jc *MPIJobReconciler) SetupWithManager(mgr ctrl.Manager, controllerThreads int) error {
 	c, err := controller.New(jc.ControllerName(), mgr, controller.Options{
-		Reconciler:              jc,
+		Reconciler:              common.NewDrainingReconciler(jc, jc.Clock),
 		MaxConcurrentReconciles: controllerThreads,
+		RateLimiter:             common.NewRateLimiter(),
 	})
 	if err != nil {
 		return err
@@ -201,12 +228,38 @@ func (jc *MPIJobReconciler) SetupWithManager(mgr ctrl.Manager, controllerThreads
 	); err != nil {
 		return err
 	}
+	// keep the job registry shared by all controllers up to date
+	if err = registry.Default.Watch(context.Background(), mgr.GetCache(), &kubeflowv1.MPIJob{}); err != nil {
+		return err
+	}
+	// requeue the jobs added to the work queue of the common job controller
+	if err = jc.WatchWorkQueue(c); err != nil {
+		return err
+	}
+	// requeue the jobs waiting for the objects referenced by the templates of their pods
+	if err = jc.WatchDependencies(mgr, c); err != nil {
+		return err
+	}
+	// requeue the jobs blocked by the resource quotas of their namespace
+	if err = jc.WatchResourceQuotas(mgr, c); err != nil {
+		return err
+	}
+	// requeue the jobs with pods bound to a node which starts being reclaimed
+	if err = jc.WatchPreemptedNodes(mgr, c); err != nil {
+		return err
+	}
 	// inject watching for job related pod
 	if err = c.Watch(source.Kind[*corev1.Pod](mgr.GetCache(), &corev1.Pod{},
 		handler.TypedEnqueueRequestForOwner[*corev1.Pod](mgr.GetScheme(), mgr.GetRESTMapper(), &kubeflowv1.MPIJob{}, handler.OnlyControllerOwner()),
 		util.OnDependentFuncs[*corev1.Pod](jc.Scheme, jc.Expectations, &jc.JobController))); err != nil {
 		return err
 	}
+	// inject watching for the batch Job running the launcher
+	if err = c.Watch(source.Kind[*batchv1.Job](mgr.GetCache(), &batchv1.Job{},
+		handler.TypedEnqueueRequestForOwner[*batchv1.Job](mgr.GetScheme(), mgr.GetRESTMapper(), &kubeflowv1.MPIJob{}, handler.OnlyControllerOwner()),
+		util.OnDependentFuncs[*batchv1.Job](jc.Scheme, jc.Expectations, &jc.JobController))); err != nil {
+		return err
+	}
 	// inject watching for job related ConfigMap
 	if err = c.Watch(source.Kind[*corev1.ConfigMap](mgr.GetCache(), &corev1.ConfigMap{},
 		handler.TypedEnqueueRequestForOwner[*corev1.ConfigMap](mgr.GetScheme(), mgr.GetRESTMapper(), &kubeflowv1.MPIJob{}, handler.OnlyControllerOwner()),
@@ -231,30 +284,10 @@ func (jc *MPIJobReconciler) SetupWithManager(mgr ctrl.Manager, controllerThreads
 		util.OnDependentFuncs[*corev1.ServiceAccount](jc.Scheme, jc.Expectations, &jc.JobController))); err != nil {
 		return err
 	}
-	// skip watching volcano PodGroup if volcano PodGroup is not installed
-	if _, err = mgr.GetRESTMapper().RESTMapping(schema.GroupKind{Group: v1beta1.GroupName, Kind: "PodGroup"},
-		v1beta1.SchemeGroupVersion.Version,
-	); err == nil {
-		// inject watching for job related volcano PodGroup
-		if err = c.Watch(source.Kind[*v1beta1.PodGroup](mgr.GetCache(), &v1beta1.PodGroup{},
-			handler.TypedEnqueueRequestForOwner[*v1beta1.PodGroup](mgr.GetScheme(), mgr.GetRESTMapper(), &kubeflowv1.MPIJob{}, handler.OnlyControllerOwner()),
-			util.OnDependentFuncs[*v1beta1.PodGroup](jc.Scheme, jc.Expectations, &jc.JobController))); err != nil {
-			return err
-		}
-	}
-	// skip watching scheduler-plugins PodGroup if scheduler-plugins PodGroup is not installed
-	if _, err = mgr.GetRESTMapper().RESTMapping(
-		schema.GroupKind{Group: schedulerpluginsv1alpha1.SchemeGroupVersion.Group, Kind: "PodGroup"},
-		schedulerpluginsv1alpha1.SchemeGroupVersion.Version,
-	); err == nil {
-		// inject watching for job related scheduler-plugins PodGroup
-		if err = c.Watch(source.Kind[*schedulerpluginsv1alpha1.PodGroup](mgr.GetCache(), &schedulerpluginsv1alpha1.PodGroup{},
-			handler.TypedEnqueueRequestForOwner[*schedulerpluginsv1alpha1.PodGroup](mgr.GetScheme(), mgr.GetRESTMapper(), &kubeflowv1.MPIJob{}, handler.OnlyControllerOwner()),
-			util.OnDependentFuncs[*schedulerpluginsv1alpha1.PodGroup](jc.Scheme, jc.Expectations, &jc.JobController))); err != nil {
-			return err
-		}
+	// inject watching for job related PodGroup of the gang scheduler
+	if err = util.WatchPodGroups(mgr, c, jc.Scheme, &kubeflowv1.MPIJob{}, &jc.JobController); err != nil {
+		return err
 	}
-
 	return nil
 }
 
@@ -300,6 +333,42 @@ func (jc *MPIJobReconciler) GetDefaultContainerPortName() string {
 	return kubeflowv1.MPIJobDefaultPortName
 }
 
+// GetJobDependencies returns the jobs the MPIJob depends on.
+func (jc *MPIJobReconciler) GetJobDependencies(job interface{}) []kubeflowv1.JobReference {
+	mpiJob, ok := job.(*kubeflowv1.MPIJob)
+	if !ok {
+		return nil
+	}
+	return mpiJob.Spec.DependsOn
+}
+
+// GetTensorBoard returns the TensorBoard requested by the MPIJob.
+func (jc *MPIJobReconciler) GetTensorBoard(job interface{}) *kubeflowv1.TensorBoardSpec {
+	mpiJob, ok := job.(*kubeflowv1.MPIJob)
+	if !ok {
+		return nil
+	}
+	return mpiJob.Spec.TensorBoard
+}
+
+// GetOutputs returns the outputs declared by the MPIJob.
+func (jc *MPIJobReconciler) GetOutputs(job interface{}) []kubeflowv1.OutputSpec {
+	mpiJob, ok := job.(*kubeflowv1.MPIJob)
+	if !ok {
+		return nil
+	}
+	return mpiJob.Spec.Outputs
+}
+
+// GetDatasets returns the datasets declared by the MPIJob.
+func (jc *MPIJobReconciler) GetDatasets(job interface{}) []kubeflowv1.DatasetSpec {
+	mpiJob, ok := job.(*kubeflowv1.MPIJob)
+	if !ok {
+		return nil
+	}
+	return mpiJob.Spec.Datasets
+}
+
 func (jc *MPIJobReconciler) IsMasterRole(replicas map[kubeflowv1.ReplicaType]*kubeflowv1.ReplicaSpec,
 	rtype kubeflowv1.ReplicaType, index int) bool {
 	return string(rtype) == string(kubeflowv1.MPIJobReplicaTypeLauncher)
@@ -316,12 +385,17 @@ func (jc *MPIJobReconciler) GetJobFromInformerCache(namespace, name string) (met
 // onOwnerCreateFunc modify creation condition.
 func (jc *MPIJobReconciler) onOwnerCreateFunc() func(createEvent event.TypedCreateEvent[*kubeflowv1.MPIJob]) bool {
 	return func(e event.TypedCreateEvent[*kubeflowv1.MPIJob]) bool {
+		if !common.ReconciledByThisController(e.Object) {
+			return false
+		}
 		mpiJob := e.Object
 		jc.Scheme.Default(mpiJob)
 		msg := fmt.Sprintf("MPIJob %s is created.", e.Object.GetName())
-		logrus.Info(msg)
+		commonutil.LoggerForJob(mpiJob).Info(msg)
 		trainingoperatorcommon.CreatedJobsCounterInc(mpiJob.Namespace, jc.GetFrameworkName())
-		commonutil.UpdateJobConditions(&mpiJob.Status, kubeflowv1.JobCreated, corev1.ConditionTrue, commonutil.NewReason(kubeflowv1.MPIJobKind, commonutil.JobCreatedReason), msg)
+		oldStatus := mpiJob.Status.DeepCopy()
+		commonutil.UpdateJobConditions(&mpiJob.Status, kubeflowv1.JobCreated, corev1.ConditionTrue, commonutil.NewReason(kubeflowv1.MPIJobKind, commonutil.JobCreatedReason), msg, jc.Clock)
+		jc.RecordAuditEvents(mpiJob, oldStatus, &mpiJob.Status)
 		return true
 	}
 }
@@ -342,14 +416,14 @@ func (jc *MPIJobReconciler) ReconcilePods(
 
 	// first set StartTime.
 	if jobStatus.StartTime == nil {
-		now := metav1.Now()
+		now := jc.Clock.MetaNow()
 		jobStatus.StartTime = &now
 	}
 
 	initializeReplicaStatuses(jobStatus, rtype)
 
 	// Get the launcher Job for this MPIJob.
-	launcher, err := jc.getLauncherJob(mpiJob)
+	launcher, err := jc.getLauncher(mpiJob)
 	if err != nil {
 		return err
 	}
@@ -364,7 +438,28 @@ func (jc *MPIJobReconciler) ReconcilePods(
 		if workerSpec != nil && workerSpec.Replicas != nil {
 			workerReplicas = *workerSpec.Replicas
 		}
-		isGPULauncher := isGPULauncher(mpiJob)
+		// The resource profiles are resolved before the pods are created, so that an unknown
+		// profile fails the reconcile rather than creating the pods without resources.
+		for rType, spec := range mpiJob.Spec.MPIReplicaSpecs {
+			if spec == nil || spec.ResourceProfile == "" {
+				continue
+			}
+			if _, err := core.ResourceProfile(spec.ResourceProfile); err != nil {
+				jc.Recorder.Eventf(mpiJob, corev1.EventTypeWarning, commonutil.FailedCreatePodReason, "Error creating %s pods: %v", strings.ToLower(string(rType)), err)
+				return err
+			}
+		}
+		isGPULauncher, err := isGPULauncher(mpiJob)
+		if err != nil {
+			return err
+		}
+
+		// Render the pods for review instead of creating them until the MPIJob is approved.
+		if launcher == nil {
+			if pending, err := jc.reconcileDryRun(mpiJob, jobStatus, isGPULauncher); pending || err != nil {
+				return err
+			}
+		}
 
 		// Get the launcher ServiceAccount for this MPIJob.
 		if sa, err := jc.getOrCreateLauncherServiceAccount(mpiJob); sa == nil || err != nil {
@@ -376,11 +471,6 @@ func (jc *MPIJobReconciler) ReconcilePods(
 			return err
 		}
 
-		// Get the launcher Role for this MPIJob.
-		if r, err := jc.getOrCreateLauncherRole(mpiJob, workerReplicas); r == nil || err != nil {
-			return err
-		}
-
 		// Get the launcher RoleBinding for this MPIJob.
 		if rb, err := jc.getLauncherRoleBinding(mpiJob); rb == nil || err != nil {
 			return err
@@ -391,43 +481,77 @@ func (jc *MPIJobReconciler) ReconcilePods(
 			return err
 		}
 
+		// Get the launcher Role for this MPIJob, granting exec on the workers which exist.
+		if r, err := jc.getOrCreateLauncherRole(mpiJob, worker); r == nil || err != nil {
+			return err
+		}
+
 		if launcher == nil {
-			launcher, err = jc.KubeClientSet.CoreV1().Pods(mpiJob.Namespace).Create(context.Background(), jc.newLauncher(mpiJob, ctlrconfig.Config.MPIKubectlDeliveryImage, isGPULauncher), metav1.CreateOptions{})
+			createLauncher, err := jc.checkPreflight(mpiJob, jobStatus, worker)
 			if err != nil {
-				jc.Recorder.Eventf(mpiJob, corev1.EventTypeWarning, commonutil.NewReason(kubeflowv1.MPIJobKind, commonutil.JobFailedReason), "launcher pod created failed: %v", err)
 				return err
-			} else {
-				jc.Recorder.Eventf(mpiJob, corev1.EventTypeNormal, commonutil.NewReason(kubeflowv1.MPIJobKind, commonutil.JobRunningReason), "launcher pod created success: %v", launcher.Name)
+			}
+			if createLauncher && mpiJob.Spec.RunPolicy.StartPolicy != nil && (len(worker) < int(workerReplicas) || !common.PodsReady(worker)) {
+				commonutil.LoggerForJob(mpiJob).V(1).Info("Waiting for all the workers to be ready to create the launcher")
+				createLauncher = false
+			}
+			var hostAliases []corev1.HostAlias
+			if createLauncher && mpiJob.Spec.HostnameSource == kubeflowv1.HostnameSourceHostAliases {
+				hostAliases, createLauncher = workerHostAliases(worker, workerReplicas)
+				if !createLauncher {
+					commonutil.LoggerForJob(mpiJob).V(1).Info("Waiting for all the workers to run to create the launcher with their host aliases")
+				}
+			}
+			if createLauncher {
+				launcherPod, err := jc.newLauncher(mpiJob, ctlrconfig.Config.MPIKubectlDeliveryImage, isGPULauncher)
+				if err != nil {
+					jc.Recorder.Eventf(mpiJob, corev1.EventTypeWarning, commonutil.FailedCreatePodReason, "Error creating launcher pods: %v", err)
+					return err
+				}
+				if launcherPod == nil {
+					return nil
+				}
+				launcherPod.Spec.HostAliases = append(launcherPod.Spec.HostAliases, hostAliases...)
+				if err := jc.CheckPodSecurity(mpiJob.Namespace, &corev1.PodTemplateSpec{ObjectMeta: launcherPod.ObjectMeta, Spec: launcherPod.Spec}); err != nil {
+					return err
+				}
+				launcher, err = jc.createLauncher(mpiJob, launcherPod)
+				if quotaExceeded := common.QuotaExceeded(launcherPod.Name, err); quotaExceeded != nil {
+					return quotaExceeded
+				}
+				if err != nil {
+					jc.Recorder.Eventf(mpiJob, corev1.EventTypeWarning, commonutil.NewReason(kubeflowv1.MPIJobKind, commonutil.JobFailedReason), "launcher pod created failed: %v", err)
+					return err
+				} else {
+					jc.Recorder.Eventf(mpiJob, corev1.EventTypeNormal, commonutil.NewReason(kubeflowv1.MPIJobKind, commonutil.JobRunningReason), "launcher pod created success: %v", launcher.Name)
+				}
 			}
 		}
 	}
 
 	// Finally, we update the status block of the MPIJob resource to reflect the
 	// current state of the world.
-	err = jc.updateMPIJobStatus(mpiJob, launcher, worker)
+	err = jc.updateMPIJobStatus(mpiJob, jobStatus, launcher, worker)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func (jc *MPIJobReconciler) updateMPIJobStatus(mpiJob *kubeflowv1.MPIJob, launcher *corev1.Pod, worker []*corev1.Pod) error {
+func (jc *MPIJobReconciler) updateMPIJobStatus(mpiJob *kubeflowv1.MPIJob, jobStatus *kubeflowv1.JobStatus, launcher *corev1.Pod, worker []*corev1.Pod) error {
+	genericLabels := jc.GenLabels(mpiJob.Name)
 	if launcher != nil {
-		initializeMPIJobStatuses(mpiJob, kubeflowv1.MPIJobReplicaTypeLauncher)
+		initializeReplicaStatuses(jobStatus, kubeflowv1.MPIJobReplicaTypeLauncher)
+		launcherStatus := jobStatus.ReplicaStatuses[kubeflowv1.MPIJobReplicaTypeLauncher]
+		launcherStatus.Selector = metav1.FormatLabelSelector(&metav1.LabelSelector{MatchLabels: defaultLauncherLabels(genericLabels)})
+		launcherStatus.Pods = []kubeflowv1.ReplicaPodStatus{core.ReplicaPodStatus(launcher)}
 		if isPodSucceeded(launcher) {
-			mpiJob.Status.ReplicaStatuses[kubeflowv1.MPIJobReplicaTypeLauncher].Succeeded = 1
+			jobStatus.ReplicaStatuses[kubeflowv1.MPIJobReplicaTypeLauncher].Succeeded = 1
 			msg := fmt.Sprintf("MPIJob %s/%s successfully completed.", mpiJob.Namespace, mpiJob.Name)
 			jc.Recorder.Event(mpiJob, corev1.EventTypeNormal, commonutil.NewReason(kubeflowv1.MPIJobPlural, commonutil.JobSucceededReason), msg)
-			if mpiJob.Status.CompletionTime == nil {
-				now := metav1.Now()
-				mpiJob.Status.CompletionTime = &now
-			}
-			err := updateMPIJobConditions(mpiJob, kubeflowv1.JobSucceeded, commonutil.NewReason(kubeflowv1.MPIJobKind, commonutil.JobSucceededReason), msg)
-			if err != nil {
-				return err
-			}
+			commonutil.FinishJob(jobStatus, kubeflowv1.JobSucceeded, commonutil.NewReason(kubeflowv1.MPIJobKind, commonutil.JobSucceededReason), msg, jc.Clock)
 		} else if isPodFailed(launcher) {
-			mpiJob.Status.ReplicaStatuses[kubeflowv1.MPIJobReplicaTypeLauncher].Failed = 1
+			jobStatus.ReplicaStatuses[kubeflowv1.MPIJobReplicaTypeLauncher].Failed = 1
 			msg := fmt.Sprintf("MPIJob %s/%s has failed", mpiJob.Namespace, mpiJob.Name)
 			reason := launcher.Status.Reason
 			if reason == "" {
@@ -435,19 +559,14 @@ func (jc *MPIJobReconciler) updateMPIJobStatus(mpiJob *kubeflowv1.MPIJob, launch
 			}
 			jc.Recorder.Event(mpiJob, corev1.EventTypeWarning, reason, msg)
 			if reason == "Evicted" {
-				reason = mpiJobEvict
-			} else if !isEvicted(mpiJob.Status) && mpiJob.Status.CompletionTime == nil {
-				now := metav1.Now()
-				mpiJob.Status.CompletionTime = &now
+				reason = commonutil.MPIJobEvictedReason
 			}
-			err := updateMPIJobConditions(mpiJob, kubeflowv1.JobFailed, reason, msg)
-			if err != nil {
-				klog.Errorf("Append mpiJob(%s/%s) condition error: %v", mpiJob.Namespace, mpiJob.Name, err)
-				return err
-			}
-
+			commonutil.FinishJob(jobStatus, kubeflowv1.JobFailed, reason, msg, jc.Clock)
 		} else if isPodRunning(launcher) {
-			mpiJob.Status.ReplicaStatuses[kubeflowv1.MPIJobReplicaTypeLauncher].Active = 1
+			jobStatus.ReplicaStatuses[kubeflowv1.MPIJobReplicaTypeLauncher].Active = 1
+		}
+		if reason, ok := core.ClassifyPodFailure(launcher); ok {
+			jobStatus.ReplicaStatuses[kubeflowv1.MPIJobReplicaTypeLauncher].FailureReason = reason
 		}
 	}
 
@@ -456,32 +575,41 @@ func (jc *MPIJobReconciler) updateMPIJobStatus(mpiJob *kubeflowv1.MPIJob, launch
 		evict   = 0
 	)
 
-	initializeMPIJobStatuses(mpiJob, kubeflowv1.MPIJobReplicaTypeWorker)
+	initializeReplicaStatuses(jobStatus, kubeflowv1.MPIJobReplicaTypeWorker)
+	jobStatus.ReplicaStatuses[kubeflowv1.MPIJobReplicaTypeWorker].Selector =
+		metav1.FormatLabelSelector(&metav1.LabelSelector{MatchLabels: defaultWorkerLabels(genericLabels)})
 	for i := 0; i < len(worker); i++ {
+		jobStatus.ReplicaStatuses[kubeflowv1.MPIJobReplicaTypeWorker].Pods = append(
+			jobStatus.ReplicaStatuses[kubeflowv1.MPIJobReplicaTypeWorker].Pods, core.ReplicaPodStatus(worker[i]))
 		switch worker[i].Status.Phase {
 		case corev1.PodFailed:
-			mpiJob.Status.ReplicaStatuses[kubeflowv1.MPIJobReplicaTypeWorker].Failed += 1
+			jobStatus.ReplicaStatuses[kubeflowv1.MPIJobReplicaTypeWorker].Failed += 1
 			if worker[i].Status.Reason == "Evicted" {
 				evict += 1
 			}
 		case corev1.PodSucceeded:
-			mpiJob.Status.ReplicaStatuses[kubeflowv1.MPIJobReplicaTypeWorker].Succeeded += 1
+			jobStatus.ReplicaStatuses[kubeflowv1.MPIJobReplicaTypeWorker].Succeeded += 1
 		case corev1.PodRunning:
 			running += 1
-			mpiJob.Status.ReplicaStatuses[kubeflowv1.MPIJobReplicaTypeWorker].Active += 1
+			jobStatus.ReplicaStatuses[kubeflowv1.MPIJobReplicaTypeWorker].Active += 1
+		}
+		if reason, ok := core.ClassifyPodFailure(worker[i]); ok {
+			jobStatus.ReplicaStatuses[kubeflowv1.MPIJobReplicaTypeWorker].FailureReason = reason
 		}
 	}
 	if evict > 0 {
 		msg := fmt.Sprintf("%d/%d workers are evicted", evict, len(worker))
-		if err := updateMPIJobConditions(mpiJob, kubeflowv1.JobFailed, mpiJobEvict, msg); err != nil {
-			return err
-		}
-		jc.Recorder.Event(mpiJob, corev1.EventTypeWarning, mpiJobEvict, msg)
+		commonutil.FinishJob(jobStatus, kubeflowv1.JobFailed, commonutil.MPIJobEvictedReason, msg, jc.Clock)
+		jc.Recorder.Event(mpiJob, corev1.EventTypeWarning, commonutil.MPIJobEvictedReason, msg)
+	}
+
+	if mpiJob.Spec.ElasticPolicy != nil {
+		jc.updateHostsInsufficientCondition(mpiJob, jobStatus, launcher, running)
 	}
 
 	if launcher != nil && launcher.Status.Phase == corev1.PodRunning && running == len(worker) {
 		msg := fmt.Sprintf("MPIJob %s/%s is running.", mpiJob.Namespace, mpiJob.Name)
-		err := updateMPIJobConditions(mpiJob, kubeflowv1.JobRunning, commonutil.NewReason(kubeflowv1.MPIJobKind, commonutil.JobRunningReason), msg)
+		err := updateMPIJobConditions(jobStatus, kubeflowv1.JobRunning, commonutil.NewReason(kubeflowv1.MPIJobKind, commonutil.JobRunningReason), msg, jc.Clock)
 		if err != nil {
 			return err
 		}
@@ -496,9 +624,9 @@ func (jc *MPIJobReconciler) GetJobFromAPIClient(namespace, name string) (metav1.
 	err := jc.apiReader.Get(context.Background(), types.NamespacedName{Namespace: namespace, Name: name}, job)
 	if err != nil {
 		if errors.IsNotFound(err) {
-			logrus.Error(err, "MPIJob not found", "namespace", namespace, "name", name)
+			jc.Log.Error(err, "MPIJob not found", "namespace", namespace, "name", name)
 		} else {
-			logrus.Error(err, "failed to get job from api-server", "namespace", namespace, "name", name)
+			jc.Log.Error(err, "failed to get job from api-server", "namespace", namespace, "name", name)
 		}
 		return nil, err
 	}
@@ -531,7 +659,7 @@ func (jc *MPIJobReconciler) GetPodsForJob(jobObject interface{}) ([]*corev1.Pod,
 		return nil, err
 	}
 
-	return util.JobControlledPodList(podlist.Items, job), nil
+	return jc.ClaimPods(job, util.ConvertPodList(podlist.Items))
 }
 
 func (jc *MPIJobReconciler) DeleteJob(job interface{}) error {
@@ -542,13 +670,13 @@ func (jc *MPIJobReconciler) DeleteJob(job interface{}) error {
 
 	log := commonutil.LoggerForJob(mpiJob)
 	if err := jc.Delete(context.Background(), mpiJob); err != nil {
-		jc.Recorder.Eventf(mpiJob, corev1.EventTypeWarning, FailedDeleteJobReason, "Error deleting: %v", err)
-		log.Errorf("failed to delete job %s/%s, %v", mpiJob.Namespace, mpiJob.Name, err)
+		jc.Recorder.Eventf(mpiJob, corev1.EventTypeWarning, commonutil.FailedDeleteJobReason, "Error deleting: %v", err)
+		log.Error(err, "failed to delete job")
 		return err
 	}
 
-	jc.Recorder.Eventf(mpiJob, corev1.EventTypeNormal, SuccessfulDeleteJobReason, "Deleted job: %v", mpiJob.Name)
-	log.Infof("job %s/%s has been deleted", mpiJob.Namespace, mpiJob.Name)
+	jc.Recorder.Eventf(mpiJob, corev1.EventTypeNormal, commonutil.SuccessfulDeleteJobReason, "Deleted job: %v", mpiJob.Name)
+	log.Info("job has been deleted")
 	trainingoperatorcommon.DeletedJobsCounterInc(mpiJob.Namespace, jc.GetFrameworkName())
 	return nil
 }
@@ -565,6 +693,7 @@ func (jc *MPIJobReconciler) UpdateJobStatus(job interface{}, replicas map[kubefl
 	if !ok {
 		return fmt.Errorf("%+v is not a type of MPIJob", job)
 	}
+	logger := commonutil.LoggerForJob(mpiJob)
 
 	for rtype, spec := range replicas {
 		status := jobStatus.ReplicaStatuses[rtype]
@@ -574,25 +703,21 @@ func (jc *MPIJobReconciler) UpdateJobStatus(job interface{}, replicas map[kubefl
 		running := status.Active
 		failed := status.Failed
 
-		logrus.Infof("MPIJob=%s, ReplicaType=%s expected=%d, running=%d, succeeded=%d , failed=%d",
-			mpiJob.Name, rtype, expected, running, succeeded, failed)
+		logger.Info("Replica status", "replicaType", rtype, "expected", expected, "running", running, "succeeded", succeeded, "failed", failed)
 
 		if rtype == kubeflowv1.MPIJobReplicaTypeLauncher {
 			if running > 0 {
 				msg := fmt.Sprintf("MPIJob %s is running.", mpiJob.Name)
-				commonutil.UpdateJobConditions(jobStatus, kubeflowv1.JobRunning, corev1.ConditionTrue, commonutil.NewReason(kubeflowv1.MPIJobKind, commonutil.JobRunningReason), msg)
+				commonutil.UpdateJobConditions(jobStatus, kubeflowv1.JobRunning, corev1.ConditionTrue, commonutil.NewReason(kubeflowv1.MPIJobKind, commonutil.JobRunningReason), msg, jc.Clock)
 			}
 			// when launcher is succeed, the job is finished.
 			if expected == 0 {
 				msg := fmt.Sprintf("MPIJob %s is successfully completed.", mpiJob.Name)
-				logrus.Info(msg)
+				logger.Info(msg)
 				jc.Recorder.Event(mpiJob, corev1.EventTypeNormal, commonutil.NewReason(kubeflowv1.MPIJobKind, commonutil.JobSucceededReason), msg)
-				if jobStatus.CompletionTime == nil {
-					now := metav1.Now()
-					jobStatus.CompletionTime = &now
+				if commonutil.FinishJob(jobStatus, kubeflowv1.JobSucceeded, commonutil.NewReason(kubeflowv1.MPIJobKind, commonutil.JobSucceededReason), msg, jc.Clock) {
+					trainingoperatorcommon.SuccessfulJobsCounterInc(mpiJob.Namespace, jc.GetFrameworkName())
 				}
-				commonutil.UpdateJobConditions(jobStatus, kubeflowv1.JobSucceeded, corev1.ConditionTrue, commonutil.NewReason(kubeflowv1.MPIJobKind, commonutil.JobSucceededReason), msg)
-				trainingoperatorcommon.SuccessfulJobsCounterInc(mpiJob.Namespace, jc.GetFrameworkName())
 				return nil
 			}
 		}
@@ -600,21 +725,17 @@ func (jc *MPIJobReconciler) UpdateJobStatus(job interface{}, replicas map[kubefl
 			if spec.RestartPolicy == kubeflowv1.RestartPolicyExitCode {
 				msg := fmt.Sprintf("MPIJob %s is restarting because %d %s replica(s) failed.", mpiJob.Name, failed, rtype)
 				jc.Recorder.Event(mpiJob, corev1.EventTypeWarning, commonutil.NewReason(kubeflowv1.MPIJobKind, commonutil.JobRestartingReason), msg)
-				commonutil.UpdateJobConditions(jobStatus, kubeflowv1.JobRestarting, corev1.ConditionTrue, commonutil.NewReason(kubeflowv1.MPIJobKind, commonutil.JobRestartingReason), msg)
+				commonutil.UpdateJobConditions(jobStatus, kubeflowv1.JobRestarting, corev1.ConditionTrue, commonutil.NewReason(kubeflowv1.MPIJobKind, commonutil.JobRestartingReason), msg, jc.Clock)
 				trainingoperatorcommon.RestartedJobsCounterInc(mpiJob.Namespace, jc.GetFrameworkName())
 			} else {
 				msg := fmt.Sprintf("MPIJob %s is failed because %d %s replica(s) failed.", mpiJob.Name, failed, rtype)
 				jc.Recorder.Event(mpiJob, corev1.EventTypeNormal, commonutil.NewReason(kubeflowv1.MPIJobKind, commonutil.JobFailedReason), msg)
-				if jobStatus.CompletionTime == nil {
-					now := metav1.Now()
-					jobStatus.CompletionTime = &now
+				if commonutil.FinishJob(jobStatus, kubeflowv1.JobFailed, commonutil.NewReason(kubeflowv1.MPIJobKind, commonutil.NewReason(kubeflowv1.MPIJobKind, commonutil.JobFailedReason)), msg, jc.Clock) {
+					trainingoperatorcommon.FailedJobsCounterInc(mpiJob.Namespace, jc.GetFrameworkName())
 				}
-				commonutil.UpdateJobConditions(jobStatus, kubeflowv1.JobFailed, corev1.ConditionTrue, commonutil.NewReason(kubeflowv1.MPIJobKind, commonutil.NewReason(kubeflowv1.MPIJobKind, commonutil.JobFailedReason)), msg)
-				trainingoperatorcommon.FailedJobsCounterInc(mpiJob.Namespace, jc.GetFrameworkName())
 			}
 		}
 	}
-	mpiJob.Status = *jobStatus.DeepCopy()
 	return nil
 }
 
@@ -629,23 +750,19 @@ func (jc *MPIJobReconciler) UpdateJobStatusInApiServer(job interface{}, jobStatu
 		return fmt.Errorf("%v is not a type of MpiJob", mpiJob)
 	}
 
-	startTime := time.Now()
+	startTime := jc.Clock.Now()
 	logger := commonutil.LoggerForJob(mpiJob)
 	defer func() {
-		logger.Infof("Finished updating MpiJobs Status %q (%v)",
-			mpiJob.Name, time.Since(startTime))
+		logger.V(1).Info("Finished updating MPIJob status", "duration", jc.Clock.Since(startTime))
 	}()
 
-	mpiJob = mpiJob.DeepCopy()
-	mpiJob.Status = *jobStatus.DeepCopy()
-
-	result := jc.Status().Update(context.Background(), mpiJob)
+	// Patch only the difference to the status through the status subresource, so that
+	// updates do not overwrite the spec and are retried on conflicts.
+	result := util.PatchJobStatus(context.Background(), jc.Client, jc.apiReader, mpiJob,
+		func(j *kubeflowv1.MPIJob) *kubeflowv1.JobStatus { return &j.Status }, jobStatus)
 
 	if result != nil {
-		jc.Log.WithValues("mpijob", types.NamespacedName{
-			Namespace: mpiJob.GetNamespace(),
-			Name:      mpiJob.GetName(),
-		})
+		commonutil.LoggerForJob(mpiJob).Error(result, "failed to update the job status in the API server")
 		return result
 	}
 
@@ -670,9 +787,7 @@ func (jc *MPIJobReconciler) getLauncherJob(mpiJob *kubeflowv1.MPIJob) (*corev1.P
 	// If the launcher is not controlled by this MPIJob resource, we should log
 	// a warning to the event recorder and return.
 	if !metav1.IsControlledBy(launcher, mpiJob) {
-		msg := fmt.Sprintf(MessageResourceExists, launcher.Name, launcher.Kind)
-		jc.Recorder.Event(mpiJob, corev1.EventTypeWarning, ErrResourceExists, msg)
-		return launcher, fmt.Errorf(msg)
+		return launcher, jc.resourceExists(mpiJob, "Pod", launcher.Name)
 	}
 	return launcher, nil
 }
@@ -680,12 +795,18 @@ func (jc *MPIJobReconciler) getLauncherJob(mpiJob *kubeflowv1.MPIJob) (*corev1.P
 // getOrCreateConfigMap gets the ConfigMap controlled by this MPIJob, or creates
 // one if it doesn't exist.
 func (jc *MPIJobReconciler) getOrCreateConfigMap(mpiJob *kubeflowv1.MPIJob, workerReplicas int32, isGPULauncher bool) (*corev1.ConfigMap, error) {
-	newCM := newConfigMap(mpiJob, workerReplicas, isGPULauncher)
 	podList, err := jc.getRunningWorkerPods(mpiJob)
 	if err != nil {
 		return nil, err
 	}
-	updateDiscoverHostsInConfigMap(newCM, mpiJob, podList, isGPULauncher)
+	hash := configMapHash(mpiJob, workerReplicas, isGPULauncher, podList)
+	newCM := func() *corev1.ConfigMap {
+		hosts := workerHosts(mpiJob, podList)
+		cm := newConfigMap(mpiJob, workerReplicas, isGPULauncher, hosts)
+		updateDiscoverHostsInConfigMap(cm, mpiJob, podList, isGPULauncher, hosts)
+		metav1.SetMetaDataAnnotation(&cm.ObjectMeta, configHashAnnotation, hash)
+		return cm
+	}
 
 	cm := &corev1.ConfigMap{}
 	NamespacedName := types.NamespacedName{Namespace: mpiJob.Namespace, Name: mpiJob.Name + configSuffix}
@@ -693,7 +814,8 @@ func (jc *MPIJobReconciler) getOrCreateConfigMap(mpiJob *kubeflowv1.MPIJob, work
 
 	// If the ConfigMap doesn't exist, we'll create it.
 	if errors.IsNotFound(err) {
-		cm, err = jc.KubeClientSet.CoreV1().ConfigMaps(mpiJob.Namespace).Create(context.Background(), newCM, metav1.CreateOptions{})
+		jc.RecordAPICall(mpiJob, common.APICallCreate)
+		cm, err = jc.KubeClientSet.CoreV1().ConfigMaps(mpiJob.Namespace).Create(context.Background(), newCM(), metav1.CreateOptions{})
 	}
 	// If an error occurs during Get/Create, we'll requeue the item so we
 	// can attempt processing again later. This could have been caused by a
@@ -705,15 +827,18 @@ func (jc *MPIJobReconciler) getOrCreateConfigMap(mpiJob *kubeflowv1.MPIJob, work
 	// If the ConfigMap is not controlled by this MPIJob resource, we
 	// should log a warning to the event recorder and return.
 	if !metav1.IsControlledBy(cm, mpiJob) {
-		msg := fmt.Sprintf(MessageResourceExists, cm.Name, cm.Kind)
-		jc.Recorder.Event(mpiJob, corev1.EventTypeWarning, ErrResourceExists, msg)
-		return nil, fmt.Errorf(msg)
-	}
-
-	// If the ConfigMap is changed, update it
-	if !reflect.DeepEqual(cm.Data, newCM.Data) {
-		cm, err = jc.KubeClientSet.CoreV1().ConfigMaps(mpiJob.Namespace).Update(context.Background(), newCM, metav1.UpdateOptions{})
-		if err != nil {
+		return nil, jc.resourceExists(mpiJob, "ConfigMap", cm.Name)
+	}
+
+	// If the inputs of the ConfigMap are changed, rebuild its data and patch it. Only the data and the
+	// hash annotation are patched, so that the labels, the annotations and the fields set on the
+	// ConfigMap by others are kept.
+	if cm.Annotations[configHashAnnotation] != hash {
+		patch := client.MergeFrom(cm.DeepCopy())
+		cm.Data = newCM().Data
+		metav1.SetMetaDataAnnotation(&cm.ObjectMeta, configHashAnnotation, hash)
+		jc.RecordAPICall(mpiJob, common.APICallUpdate)
+		if err := jc.Patch(context.Background(), cm, patch); err != nil {
 			return nil, err
 		}
 	}
@@ -726,7 +851,8 @@ func (jc *MPIJobReconciler) getOrCreateConfigMap(mpiJob *kubeflowv1.MPIJob, work
 func (jc *MPIJobReconciler) getOrCreateLauncherServiceAccount(mpiJob *kubeflowv1.MPIJob) (*corev1.ServiceAccount, error) {
 	saName := mpiJob.Spec.MPIReplicaSpecs[kubeflowv1.MPIJobReplicaTypeLauncher].Template.Spec.ServiceAccountName
 
-	if len(saName) == 0 {
+	defaultName := len(saName) == 0
+	if defaultName {
 		saName = mpiJob.Name + launcherSuffix
 	}
 
@@ -734,12 +860,12 @@ func (jc *MPIJobReconciler) getOrCreateLauncherServiceAccount(mpiJob *kubeflowv1
 	NamespacedName := types.NamespacedName{Namespace: mpiJob.Namespace, Name: saName}
 	err := jc.Get(context.Background(), NamespacedName, sa)
 
-	if err == nil {
-		jc.Recorder.Eventf(mpiJob, corev1.EventTypeNormal, "ServiceAccount is exist", "ServiceAccount: %v", sa.Name)
-	}
-
 	if errors.IsNotFound(err) {
+		jc.RecordAPICall(mpiJob, common.APICallCreate)
 		sa, err = jc.KubeClientSet.CoreV1().ServiceAccounts(mpiJob.Namespace).Create(context.Background(), newLauncherServiceAccount(mpiJob), metav1.CreateOptions{})
+		if err == nil {
+			jc.Recorder.Eventf(mpiJob, corev1.EventTypeNormal, commonutil.SuccessfulCreateServiceAccountReason, "Created ServiceAccount: %v", sa.Name)
+		}
 	}
 	// If an error occurs during Get/Create, we'll requeue the item so we
 	// can attempt processing again later. This could have been caused by a
@@ -747,24 +873,44 @@ func (jc *MPIJobReconciler) getOrCreateLauncherServiceAccount(mpiJob *kubeflowv1
 	if err != nil {
 		return nil, err
 	}
+	// The ServiceAccount named by the user is expected to exist, while the default one
+	// is a child of the MPIJob, which is adopted only out of the strict ownership mode.
+	if defaultName && !metav1.IsControlledBy(sa, mpiJob) && common.StrictOwnership() {
+		return nil, jc.resourceExists(mpiJob, "ServiceAccount", sa.Name)
+	}
 
 	return sa, nil
 }
 
-// getOrCreateLauncherRole gets the launcher Role controlled by this MPIJob.
-func (jc *MPIJobReconciler) getOrCreateLauncherRole(mpiJob *kubeflowv1.MPIJob, workerReplicas int32) (*rbacv1.Role, error) {
+// resourceExists reports that the named child of the MPIJob exists but is not controlled
+// by it. In the strict ownership mode the returned NameCollisionError fails the job, instead
+// of retrying the reconciliation forever.
+func (jc *MPIJobReconciler) resourceExists(mpiJob *kubeflowv1.MPIJob, kind, name string) error {
+	msg := fmt.Sprintf(MessageResourceExists, name, kind)
+	jc.Recorder.Event(mpiJob, corev1.EventTypeWarning, commonutil.ResourceExistsReason, msg)
+	if common.StrictOwnership() {
+		return &common.NameCollisionError{Kind: kind, Name: name}
+	}
+	return fmt.Errorf(msg)
+}
+
+// getOrCreateLauncherRole gets the launcher Role controlled by this MPIJob,
+// or creates one if it doesn't exist. The rules of the Role are updated to
+// follow the worker pods, so that the launcher can exec into the workers
+// created on scale-up and no longer into the workers removed on scale-down.
+func (jc *MPIJobReconciler) getOrCreateLauncherRole(mpiJob *kubeflowv1.MPIJob, workers []*corev1.Pod) (*rbacv1.Role, error) {
 	role := &rbacv1.Role{}
 	NamespacedName := types.NamespacedName{Namespace: mpiJob.Namespace, Name: mpiJob.Name + launcherSuffix}
 	err := jc.Get(context.Background(), NamespacedName, role)
 
-	if err == nil {
-		jc.Recorder.Eventf(mpiJob, corev1.EventTypeNormal, "LauncherRole is exist", "LauncherRole: %v", role.Name)
-	}
-
-	launcherRole := newLauncherRole(mpiJob, workerReplicas)
+	launcherRole := newLauncherRole(mpiJob, workers)
 	// If the Role doesn't exist, we'll create it.
 	if errors.IsNotFound(err) {
+		jc.RecordAPICall(mpiJob, common.APICallCreate)
 		role, err = jc.KubeClientSet.RbacV1().Roles(mpiJob.Namespace).Create(context.Background(), launcherRole, metav1.CreateOptions{})
+		if err == nil {
+			jc.Recorder.Eventf(mpiJob, corev1.EventTypeNormal, commonutil.SuccessfulCreateRoleReason, "Created Role: %v", role.Name)
+		}
 	}
 	// If an error occurs during Get/Create, we'll requeue the item so we
 	// can attempt processing again later. This could have been caused by a
@@ -775,13 +921,16 @@ func (jc *MPIJobReconciler) getOrCreateLauncherRole(mpiJob *kubeflowv1.MPIJob, w
 	// If the launcher Role is not controlled by this MPIJob resource, we
 	// should log a warning to the event recorder and return.
 	if !metav1.IsControlledBy(role, mpiJob) {
-		msg := fmt.Sprintf(MessageResourceExists, role.Name, role.Kind)
-		jc.Recorder.Event(mpiJob, corev1.EventTypeWarning, ErrResourceExists, msg)
-		return nil, fmt.Errorf(msg)
+		return nil, jc.resourceExists(mpiJob, "Role", role.Name)
 	}
 
-	if !reflect.DeepEqual(role.Rules, launcherRole.Rules) {
-		role, err = jc.KubeClientSet.RbacV1().Roles(mpiJob.Namespace).Update(context.Background(), launcherRole, metav1.UpdateOptions{})
+	if !equality.Semantic.DeepEqual(role.Rules, launcherRole.Rules) {
+		// Update the Role read with its resourceVersion, so that a concurrent
+		// change of the Role fails with a conflict and is retried.
+		role = role.DeepCopy()
+		role.Rules = launcherRole.Rules
+		jc.RecordAPICall(mpiJob, common.APICallUpdate)
+		role, err = jc.KubeClientSet.RbacV1().Roles(mpiJob.Namespace).Update(context.Background(), role, metav1.UpdateOptions{})
 		if err != nil {
 			return nil, err
 		}
@@ -798,12 +947,12 @@ func (jc *MPIJobReconciler) getLauncherRoleBinding(mpiJob *kubeflowv1.MPIJob) (*
 	err := jc.Get(context.Background(), NamespacedName, rb)
 	// If the RoleBinding doesn't exist, we'll create it.
 
-	if err == nil {
-		jc.Recorder.Eventf(mpiJob, corev1.EventTypeNormal, "RoleBinding is exist", "RoleBinding: %v", rb.Name)
-	}
-
 	if errors.IsNotFound(err) {
+		jc.RecordAPICall(mpiJob, common.APICallCreate)
 		rb, err = jc.KubeClientSet.RbacV1().RoleBindings(mpiJob.Namespace).Create(context.Background(), newLauncherRoleBinding(mpiJob), metav1.CreateOptions{})
+		if err == nil {
+			jc.Recorder.Eventf(mpiJob, corev1.EventTypeNormal, commonutil.SuccessfulCreateRoleBindingReason, "Created RoleBinding: %v", rb.Name)
+		}
 	}
 	// If an error occurs during Get/Create, we'll requeue the item so we
 	// can attempt processing again later. This could have been caused by a
@@ -814,9 +963,7 @@ func (jc *MPIJobReconciler) getLauncherRoleBinding(mpiJob *kubeflowv1.MPIJob) (*
 	// If the launcher RoleBinding is not controlled by this MPIJob resource, we
 	// should log a warning to the event recorder and return.
 	if !metav1.IsControlledBy(rb, mpiJob) {
-		msg := fmt.Sprintf(MessageResourceExists, rb.Name, rb.Kind)
-		jc.Recorder.Event(mpiJob, corev1.EventTypeWarning, ErrResourceExists, msg)
-		return nil, fmt.Errorf(msg)
+		return nil, jc.resourceExists(mpiJob, "RoleBinding", rb.Name)
 	}
 
 	return rb, nil
@@ -859,6 +1006,7 @@ func (jc *MPIJobReconciler) getOrCreateWorker(mpiJob *kubeflowv1.MPIJob) ([]*cor
 			index, err := strconv.Atoi(indexStr)
 			if err == nil {
 				if index >= int(*workerReplicas) {
+					jc.RecordAPICall(mpiJob, common.APICallDelete)
 					err = jc.KubeClientSet.CoreV1().Pods(pod.Namespace).Delete(context.Background(), pod.Name, metav1.DeleteOptions{})
 					if err != nil {
 						return nil, err
@@ -868,6 +1016,13 @@ func (jc *MPIJobReconciler) getOrCreateWorker(mpiJob *kubeflowv1.MPIJob) ([]*cor
 		}
 	}
 
+	// The workers of an MPIJob which starts in waves are created once the workers created before are ready.
+	existingPods := make([]*corev1.Pod, 0, len(podlist.Items))
+	for j := range podlist.Items {
+		existingPods = append(existingPods, &podlist.Items[j])
+	}
+	creations := common.StartWave(&mpiJob.Spec.RunPolicy, existingPods, int(*workerReplicas))
+
 	for ; i < *workerReplicas; i++ {
 		name := fmt.Sprintf("%s-%d", workerPrefix, i)
 
@@ -877,20 +1032,36 @@ func (jc *MPIJobReconciler) getOrCreateWorker(mpiJob *kubeflowv1.MPIJob) ([]*cor
 
 		// If the worker Pod doesn't exist, we'll create it.
 		if errors.IsNotFound(err) {
-			worker := jc.newWorker(mpiJob, name)
+			if creations == 0 {
+				continue
+			}
+			creations--
+			var worker *corev1.Pod
+			worker, err = jc.newWorker(mpiJob, name)
+			if err != nil {
+				jc.Recorder.Eventf(mpiJob, corev1.EventTypeWarning, commonutil.FailedCreatePodReason, "Error creating worker pods: %v", err)
+				return nil, err
+			}
 			if worker == nil {
 				msg := fmt.Sprintf(MessageResourceDoesNotExist, "Worker")
-				jc.Recorder.Event(mpiJob, corev1.EventTypeWarning, ErrResourceDoesNotExist, msg)
+				jc.Recorder.Event(mpiJob, corev1.EventTypeWarning, commonutil.ResourceDoesNotExistReason, msg)
 				err = fmt.Errorf(msg)
 				return nil, err
 			}
 			// Insert ReplicaIndexLabel
 			worker.Labels[kubeflowv1.ReplicaIndexLabel] = strconv.Itoa(int(i))
+			if err := jc.CheckPodSecurity(mpiJob.Namespace, &corev1.PodTemplateSpec{ObjectMeta: worker.ObjectMeta, Spec: worker.Spec}); err != nil {
+				return nil, err
+			}
+			jc.RecordAPICall(mpiJob, common.APICallCreate)
 			pod, err = jc.KubeClientSet.CoreV1().Pods(mpiJob.Namespace).Create(context.Background(), worker, metav1.CreateOptions{})
 			if err == nil {
-				jc.Recorder.Eventf(mpiJob, corev1.EventTypeNormal, "SuccessfulCreatePod", "Created worker pod: %v", pod.Name)
+				jc.Recorder.Eventf(mpiJob, corev1.EventTypeNormal, commonutil.SuccessfulCreatePodReason, "Created worker pod: %v", pod.Name)
 			} else {
-				jc.Recorder.Eventf(mpiJob, corev1.EventTypeWarning, "FailedCreatePod", "Created worker pod: %v", pod.Name)
+				jc.Recorder.Eventf(mpiJob, corev1.EventTypeWarning, commonutil.FailedCreatePodReason, "Error creating worker pod %v: %v", name, err)
+				if quotaExceeded := common.QuotaExceeded(name, err); quotaExceeded != nil {
+					return nil, quotaExceeded
+				}
 			}
 		}
 
@@ -905,9 +1076,7 @@ func (jc *MPIJobReconciler) getOrCreateWorker(mpiJob *kubeflowv1.MPIJob) ([]*cor
 		// If the worker is not controlled by this MPIJob resource, we should log
 		// a warning to the event recorder and return.
 		if pod != nil && !metav1.IsControlledBy(pod, mpiJob) {
-			msg := fmt.Sprintf(MessageResourceExists, pod.Name, pod.Kind)
-			jc.Recorder.Event(mpiJob, corev1.EventTypeWarning, ErrResourceExists, msg)
-			return nil, fmt.Errorf(msg)
+			return nil, jc.resourceExists(mpiJob, "Pod", pod.Name)
 		}
 		workerPods = append(workerPods, pod)
 	}
@@ -918,32 +1087,42 @@ func (jc *MPIJobReconciler) getOrCreateWorker(mpiJob *kubeflowv1.MPIJob) ([]*cor
 // newWorker creates a new worker Pod for an MPIJob resource. It also
 // sets the appropriate OwnerReferences on the resource so handleObject can
 // discover the MPIJob resource that 'owns' it.
-func (jc *MPIJobReconciler) newWorker(mpiJob *kubeflowv1.MPIJob, name string) *corev1.Pod {
+func (jc *MPIJobReconciler) newWorker(mpiJob *kubeflowv1.MPIJob, name string) (*corev1.Pod, error) {
 	genericLabels := jc.GenLabels(mpiJob.GetName())
 	labels := defaultWorkerLabels(genericLabels)
 
-	podSpec := mpiJob.Spec.MPIReplicaSpecs[kubeflowv1.MPIJobReplicaTypeWorker].Template.DeepCopy()
-
-	// keep the labels which are set in PodTemplate
-	if len(podSpec.Labels) == 0 {
-		podSpec.Labels = make(map[string]string)
-	}
+	labels[kubeflowv1.RunIDLabel] = common.RunID(mpiJob)
 
-	for key, value := range labels {
-		podSpec.Labels[key] = value
-	}
+	podSpec := mpiJob.Spec.MPIReplicaSpecs[kubeflowv1.MPIJobReplicaTypeWorker].Template.DeepCopy()
+	logger := commonutil.LoggerForReplica(mpiJob, strings.ToLower(string(kubeflowv1.MPIJobReplicaTypeWorker)))
+	jc.setControllerLabels(mpiJob, podSpec, labels, logger)
 	setRestartPolicy(podSpec, mpiJob.Spec.MPIReplicaSpecs[kubeflowv1.MPIJobReplicaTypeWorker])
-	logger := commonutil.LoggerForReplica(mpiJob, strings.ToLower(string(kubeflowv1.MPIJobReplicaTypeLauncher)))
+	core.SetCapacityType(podSpec, mpiJob.Spec.MPIReplicaSpecs[kubeflowv1.MPIJobReplicaTypeWorker])
+	core.SetRestartedAt(podSpec, mpiJob)
 	if len(podSpec.Spec.Containers) == 0 {
-		klog.Errorln("Worker pod does not have any containers in its spec")
-		return nil
+		logger.Info("Worker pod does not have any containers in its spec")
+		return nil, nil
 	}
+	core.SetCommonEnv(podSpec, podSpec.Spec.Containers[0].Name, mpiJob.Spec.CommonEnv, mpiJob.Spec.CommonEnvFrom)
+	common.SetNetworkTuningEnv(podSpec, podSpec.Spec.Containers[0].Name, &mpiJob.Spec.RunPolicy)
+	common.SetServiceMeshAnnotations(podSpec, mpiJob)
+	if err := core.SetResourceProfile(podSpec, podSpec.Spec.Containers[0].Name, mpiJob.Spec.MPIReplicaSpecs[kubeflowv1.MPIJobReplicaTypeWorker].ResourceProfile); err != nil {
+		return nil, err
+	}
+	common.SetAcceleratorDefaults(podSpec)
+	common.SetDatasets(podSpec, podSpec.Spec.Containers[0].Name, mpiJob.Spec.Datasets)
+	common.SetDefaultImagePullSecrets(podSpec)
+	jc.SetDefaultPriorityClass(podSpec, mpiJob.Namespace)
 	container := podSpec.Spec.Containers[0]
 	if len(container.Command) == 0 {
 		container.Command = []string{"sleep"}
 		container.Args = []string{"365d"}
 	}
 
+	if !common.IsMPIEnvDisabled(&mpiJob.Spec.RunPolicy) {
+		container.Env = appendMissingEnv(container.Env, implementationEnv(mpiJob.Spec.MPIImplementation, false)...)
+	}
+
 	// We need the kubexec.sh script here because Open MPI checks for the path
 	// in every rank.
 	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
@@ -960,16 +1139,17 @@ func (jc *MPIJobReconciler) newWorker(mpiJob *kubeflowv1.MPIJob, name string) *c
 				LocalObjectReference: corev1.LocalObjectReference{
 					Name: mpiJob.Name + configSuffix,
 				},
-				Items: []corev1.KeyToPath{
+				Items: gpuWrapperKeyToPath(mpiJob, hostMapKeyToPath(mpiJob, []corev1.KeyToPath{
 					{
 						Key:  kubexecScriptName,
 						Path: kubexecScriptName,
 						Mode: &scriptMode,
 					},
-				},
+				})),
 			},
 		},
 	})
+	common.SetSecurityDefaults(podSpec)
 
 	// if gang-scheduling is enabled:
 	// 1. if user has specified other scheduler, we report a warning without overriding any fields.
@@ -977,8 +1157,8 @@ func (jc *MPIJobReconciler) newWorker(mpiJob *kubeflowv1.MPIJob, name string) *c
 	if jc.Config.EnableGangScheduling() {
 		if !util.IsGangSchedulerSet(mpiJob.Spec.MPIReplicaSpecs, jc.PodGroupControl.GetSchedulerName()) {
 			errMsg := "Another scheduler is specified when gang-scheduling is enabled and it will not be overwritten"
-			logger.Warning(errMsg)
-			jc.Recorder.Event(mpiJob, corev1.EventTypeWarning, podTemplateSchedulerNameReason, errMsg)
+			logger.Info(errMsg)
+			jc.Recorder.Event(mpiJob, corev1.EventTypeWarning, commonutil.PodTemplateSchedulerNameReason, errMsg)
 		}
 
 		rtWorker := strings.ToLower(string(kubeflowv1.MPIJobReplicaTypeWorker))
@@ -996,13 +1176,23 @@ func (jc *MPIJobReconciler) newWorker(mpiJob *kubeflowv1.MPIJob, name string) *c
 			},
 		},
 		Spec: podSpec.Spec,
+	}, nil
+}
+
+// setControllerLabels merges the labels of the controller into the labels of the pod template, and
+// warns about the labels of the template which are reserved to the controller.
+func (jc *MPIJobReconciler) setControllerLabels(mpiJob *kubeflowv1.MPIJob, podSpec *corev1.PodTemplateSpec, labels map[string]string, logger logr.Logger) {
+	if overwritten := core.SetControllerLabels(podSpec, labels); len(overwritten) != 0 {
+		errMsg := fmt.Sprintf("Labels %s of the pod template are reserved to the controller and will be overwritten", strings.Join(overwritten, ", "))
+		logger.Info(errMsg)
+		jc.Recorder.Event(mpiJob, corev1.EventTypeWarning, commonutil.PodTemplateLabelsReason, errMsg)
 	}
 }
 
 // newLauncher creates a new launcher Job for an MPIJob resource. It also sets
 // the appropriate OwnerReferences on the resource so handleObject can discover
 // the MPIJob resource that 'owns' it.
-func (jc *MPIJobReconciler) newLauncher(mpiJob *kubeflowv1.MPIJob, kubectlDeliveryImage string, isGPULauncher bool) *corev1.Pod {
+func (jc *MPIJobReconciler) newLauncher(mpiJob *kubeflowv1.MPIJob, kubectlDeliveryImage string, isGPULauncher bool) (*corev1.Pod, error) {
 	launcherName := mpiJob.Name + launcherSuffix
 
 	genericLabels := jc.GenLabels(mpiJob.GetName())
@@ -1012,22 +1202,17 @@ func (jc *MPIJobReconciler) newLauncher(mpiJob *kubeflowv1.MPIJob, kubectlDelive
 	if masterRole {
 		labels[kubeflowv1.JobRoleLabel] = "master"
 	}
-	podSpec := mpiJob.Spec.MPIReplicaSpecs[kubeflowv1.MPIJobReplicaTypeLauncher].Template.DeepCopy()
-	// copy the labels and annotations to pod from PodTemplate
-	if len(podSpec.Labels) == 0 {
-		podSpec.Labels = make(map[string]string)
-	}
-	for key, value := range labels {
-		podSpec.Labels[key] = value
-	}
+	labels[kubeflowv1.RunIDLabel] = common.RunID(mpiJob)
 
+	podSpec := mpiJob.Spec.MPIReplicaSpecs[kubeflowv1.MPIJobReplicaTypeLauncher].Template.DeepCopy()
 	logger := commonutil.LoggerForReplica(mpiJob, strings.ToLower(string(kubeflowv1.MPIJobReplicaTypeLauncher)))
+	jc.setControllerLabels(mpiJob, podSpec, labels, logger)
 	// add SchedulerName to podSpec
 	if jc.Config.EnableGangScheduling() {
 		if !util.IsGangSchedulerSet(mpiJob.Spec.MPIReplicaSpecs, jc.PodGroupControl.GetSchedulerName()) {
 			errMsg := "Another scheduler is specified when gang-scheduling is enabled and it will not be overwritten"
-			logger.Warning(errMsg)
-			jc.Recorder.Event(mpiJob, corev1.EventTypeWarning, podTemplateSchedulerNameReason, errMsg)
+			logger.Info(errMsg)
+			jc.Recorder.Event(mpiJob, corev1.EventTypeWarning, commonutil.PodTemplateSchedulerNameReason, errMsg)
 		}
 
 		rt := strings.ToLower(string(kubeflowv1.MPIJobReplicaTypeLauncher))
@@ -1038,60 +1223,68 @@ func (jc *MPIJobReconciler) newLauncher(mpiJob *kubeflowv1.MPIJob, kubectlDelive
 		podSpec.Spec.ServiceAccountName = launcherName
 	}
 
-	podSpec.Spec.InitContainers = append(podSpec.Spec.InitContainers, corev1.Container{
-		Name:            kubectlDeliveryName,
-		Image:           kubectlDeliveryImage,
-		ImagePullPolicy: corev1.PullIfNotPresent,
-		Env: []corev1.EnvVar{
-			{
-				Name:  kubectlTargetDirEnv,
-				Value: kubectlMountPath,
-			},
-			{
-				Name:  "NAMESPACE",
-				Value: mpiJob.Namespace,
-			},
-		},
-		VolumeMounts: []corev1.VolumeMount{
-			{
-				Name:      kubectlVolumeName,
-				MountPath: kubectlMountPath,
-			},
-			{
-				Name:      configVolumeName,
-				MountPath: configMountPath,
-			},
-		},
-		Resources: corev1.ResourceRequirements{
-			Limits: corev1.ResourceList{
-				corev1.ResourceCPU:              resource.MustParse(initContainerCpu),
-				corev1.ResourceMemory:           resource.MustParse(initContainerMem),
-				corev1.ResourceEphemeralStorage: resource.MustParse(initContainerEphStorage),
-			},
-			Requests: corev1.ResourceList{
-				corev1.ResourceCPU:              resource.MustParse(initContainerCpu),
-				corev1.ResourceMemory:           resource.MustParse(initContainerMem),
-				corev1.ResourceEphemeralStorage: resource.MustParse(initContainerEphStorage),
-			},
-		},
-	})
+	podSpec.Spec.InitContainers = append(podSpec.Spec.InitContainers, kubectlDeliveryContainer(mpiJob, kubectlDeliveryImage))
 	if len(podSpec.Spec.Containers) == 0 {
-		klog.Errorln("Launcher pod does not have any containers in its spec")
+		logger.Info("Launcher pod does not have any containers in its spec")
 		msg := fmt.Sprintf(MessageResourceDoesNotExist, "Launcher")
-		jc.Recorder.Event(mpiJob, corev1.EventTypeWarning, ErrResourceDoesNotExist, msg)
-		return nil
+		jc.Recorder.Event(mpiJob, corev1.EventTypeWarning, commonutil.ResourceDoesNotExistReason, msg)
+		return nil, nil
+	}
+	core.SetCommonEnv(podSpec, podSpec.Spec.Containers[0].Name, mpiJob.Spec.CommonEnv, mpiJob.Spec.CommonEnvFrom)
+	common.SetNetworkTuningEnv(podSpec, podSpec.Spec.Containers[0].Name, &mpiJob.Spec.RunPolicy)
+	common.SetServiceMeshAnnotations(podSpec, mpiJob)
+	if err := core.SetResourceProfile(podSpec, podSpec.Spec.Containers[0].Name, mpiJob.Spec.MPIReplicaSpecs[kubeflowv1.MPIJobReplicaTypeLauncher].ResourceProfile); err != nil {
+		return nil, err
+	}
+	common.SetAcceleratorDefaults(podSpec)
+	common.SetDatasets(podSpec, podSpec.Spec.Containers[0].Name, mpiJob.Spec.Datasets)
+	if masterRole {
+		common.SetOutputs(podSpec, podSpec.Spec.Containers[0].Name, mpiJob.Spec.Outputs)
+		jc.SetMetricsCollector(podSpec, podSpec.Spec.Containers[0].Name, mpiJob, mpiJob)
 	}
+	common.SetDefaultImagePullSecrets(podSpec)
+	common.SetSecurityDefaults(podSpec)
+	jc.SetDefaultPriorityClass(podSpec, mpiJob.Namespace)
 	container := podSpec.Spec.Containers[0]
-	container.Env = append(container.Env,
-		corev1.EnvVar{
-			Name:  "OMPI_MCA_plm_rsh_agent",
-			Value: fmt.Sprintf("%s/%s", configMountPath, kubexecScriptName),
-		},
-		corev1.EnvVar{
-			Name:  "OMPI_MCA_orte_default_hostfile",
-			Value: fmt.Sprintf("%s/%s", configMountPath, hostfileName),
-		},
-	)
+	if features.Enabled(features.LauncherAutoSizing) {
+		setLauncherResources(&container, mpiJob)
+	}
+	switch {
+	case common.IsMPIEnvDisabled(&mpiJob.Spec.RunPolicy):
+		// The MPI implementation is configured by the image of the job.
+	case mpiJob.Spec.MPIImplementation != "":
+		container.Env = appendMissingEnv(container.Env, implementationEnv(mpiJob.Spec.MPIImplementation, true)...)
+	default:
+		container.Env = append(container.Env,
+			corev1.EnvVar{
+				Name:  "OMPI_MCA_plm_rsh_agent",
+				Value: fmt.Sprintf("%s/%s", configMountPath, kubexecScriptName),
+			},
+			corev1.EnvVar{
+				Name:  "OMPI_MCA_orte_default_hostfile",
+				Value: fmt.Sprintf("%s/%s", configMountPath, hostfileName),
+			},
+		)
+
+		// Add default Intel MPI bootstrap variables if not provided by the user.
+		bootstrap, exec := hasIntelMPIBootstrapValues(container.Env)
+		if !bootstrap {
+			container.Env = append(container.Env,
+				corev1.EnvVar{
+					Name:  "I_MPI_HYDRA_BOOTSTRAP",
+					Value: iMPIDefaultBootstrap,
+				},
+			)
+		}
+		if !exec {
+			container.Env = append(container.Env,
+				corev1.EnvVar{
+					Name:  "I_MPI_HYDRA_BOOTSTRAP_EXEC",
+					Value: fmt.Sprintf("%s/%s", configMountPath, kubexecScriptName),
+				},
+			)
+		}
+	}
 
 	if !isGPULauncher {
 		container.Env = append(container.Env,
@@ -1108,25 +1301,6 @@ func (jc *MPIJobReconciler) newLauncher(mpiJob *kubeflowv1.MPIJob, kubectlDelive
 			})
 	}
 
-	// Add default Intel MPI bootstrap variables if not provided by the user.
-	bootstrap, exec := hasIntelMPIBootstrapValues(container.Env)
-	if !bootstrap {
-		container.Env = append(container.Env,
-			corev1.EnvVar{
-				Name:  "I_MPI_HYDRA_BOOTSTRAP",
-				Value: iMPIDefaultBootstrap,
-			},
-		)
-	}
-	if !exec {
-		container.Env = append(container.Env,
-			corev1.EnvVar{
-				Name:  "I_MPI_HYDRA_BOOTSTRAP_EXEC",
-				Value: fmt.Sprintf("%s/%s", configMountPath, kubexecScriptName),
-			},
-		)
-	}
-
 	container.VolumeMounts = append(container.VolumeMounts,
 		corev1.VolumeMount{
 			Name:      kubectlVolumeName,
@@ -1142,10 +1316,12 @@ func (jc *MPIJobReconciler) newLauncher(mpiJob *kubeflowv1.MPIJob, kubectlDelive
 	// the pod template. We recommend to set it from the replica level.
 	if podSpec.Spec.RestartPolicy != corev1.RestartPolicy("") {
 		errMsg := "Restart policy in pod template will be overwritten by restart policy in replica spec"
-		klog.Warning(errMsg)
-		jc.Recorder.Event(mpiJob, corev1.EventTypeWarning, podTemplateRestartPolicyReason, errMsg)
+		logger.Info(errMsg)
+		jc.Recorder.Event(mpiJob, corev1.EventTypeWarning, commonutil.PodTemplateRestartPolicyReason, errMsg)
 	}
 	setRestartPolicy(podSpec, mpiJob.Spec.MPIReplicaSpecs[kubeflowv1.MPIJobReplicaTypeLauncher])
+	core.SetCapacityType(podSpec, mpiJob.Spec.MPIReplicaSpecs[kubeflowv1.MPIJobReplicaTypeLauncher])
+	core.SetRestartedAt(podSpec, mpiJob)
 
 	scriptsMode := int32(0555)
 	hostfileMode := int32(0444)
@@ -1163,7 +1339,7 @@ func (jc *MPIJobReconciler) newLauncher(mpiJob *kubeflowv1.MPIJob, kubectlDelive
 					LocalObjectReference: corev1.LocalObjectReference{
 						Name: mpiJob.Name + configSuffix,
 					},
-					Items: []corev1.KeyToPath{
+					Items: gpuWrapperKeyToPath(mpiJob, hostMapKeyToPath(mpiJob, []corev1.KeyToPath{
 						{
 							Key:  kubexecScriptName,
 							Path: kubexecScriptName,
@@ -1179,7 +1355,7 @@ func (jc *MPIJobReconciler) newLauncher(mpiJob *kubeflowv1.MPIJob, kubectlDelive
 							Path: discoverHostsScriptName,
 							Mode: &scriptsMode,
 						},
-					},
+					})),
 				},
 			},
 		})
@@ -1194,7 +1370,84 @@ func (jc *MPIJobReconciler) newLauncher(mpiJob *kubeflowv1.MPIJob, kubectlDelive
 			},
 		},
 		Spec: podSpec.Spec,
+	}, nil
+}
+
+// kubectlDeliveryContainer returns the init container of the launcher delivering kubectl.
+func kubectlDeliveryContainer(mpiJob *kubeflowv1.MPIJob, kubectlDeliveryImage string) corev1.Container {
+	return corev1.Container{
+		Name:            kubectlDeliveryName,
+		Image:           kubectlDeliveryImage,
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Env: []corev1.EnvVar{
+			{
+				Name:  kubectlTargetDirEnv,
+				Value: kubectlMountPath,
+			},
+			{
+				Name:  "NAMESPACE",
+				Value: mpiJob.Namespace,
+			},
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      kubectlVolumeName,
+				MountPath: kubectlMountPath,
+			},
+			{
+				Name:      configVolumeName,
+				MountPath: configMountPath,
+			},
+		},
+		Resources: corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{
+				corev1.ResourceCPU:              resource.MustParse(initContainerCpu),
+				corev1.ResourceMemory:           resource.MustParse(initContainerMem),
+				corev1.ResourceEphemeralStorage: resource.MustParse(initContainerEphStorage),
+			},
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:              resource.MustParse(initContainerCpu),
+				corev1.ResourceMemory:           resource.MustParse(initContainerMem),
+				corev1.ResourceEphemeralStorage: resource.MustParse(initContainerEphStorage),
+			},
+		},
+	}
+}
+
+// InjectedInitContainers returns the kubectl-delivery init container of the launcher, so that
+// its resources are accounted in the minResources of the PodGroup of the MPIJob.
+func (jc *MPIJobReconciler) InjectedInitContainers(job interface{}, rtype kubeflowv1.ReplicaType) []corev1.Container {
+	mpiJob, ok := job.(*kubeflowv1.MPIJob)
+	if !ok || rtype != kubeflowv1.MPIJobReplicaTypeLauncher {
+		return nil
 	}
+	return []corev1.Container{kubectlDeliveryContainer(mpiJob, ctlrconfig.Config.MPIKubectlDeliveryImage)}
+}
+
+// BuildPodSpec returns the spec of the launcher or of a worker of the MPIJob as it is created, so
+// that the requests of its injected containers and resources are accounted in the resource quota
+// checks of the MPIJob.
+func (jc *MPIJobReconciler) BuildPodSpec(job interface{}, rtype kubeflowv1.ReplicaType) (*corev1.PodSpec, error) {
+	mpiJob, ok := job.(*kubeflowv1.MPIJob)
+	if !ok {
+		return nil, fmt.Errorf("%v is not a type of MPIJob", job)
+	}
+	var pod *corev1.Pod
+	var err error
+	switch rtype {
+	case kubeflowv1.MPIJobReplicaTypeLauncher:
+		var gpuLauncher bool
+		if gpuLauncher, err = isGPULauncher(mpiJob); err != nil {
+			return nil, err
+		}
+		pod, err = jc.newLauncher(mpiJob, ctlrconfig.Config.MPIKubectlDeliveryImage, gpuLauncher)
+	case kubeflowv1.MPIJobReplicaTypeWorker:
+		pod, err = jc.newWorker(mpiJob, fmt.Sprintf("%s%s-0", mpiJob.Name, workerSuffix))
+	}
+	if err != nil || pod == nil {
+		return nil, err
+	}
+	return &pod.Spec, nil
 }
 
 // getRunningWorkerPods get all worker Pods with Running phase controlled by this MPIJob.
@@ -1224,36 +1477,48 @@ func (jc *MPIJobReconciler) getRunningWorkerPods(mpiJob *kubeflowv1.MPIJob) ([]*
 // newConfigMap creates a new ConfigMap containing configurations for an MPIJob
 // resource. It also sets the appropriate OwnerReferences on the resource so
 // handleObject can discover the MPIJob resource that 'owns' it.
-func newConfigMap(mpiJob *kubeflowv1.MPIJob, workerReplicas int32, isGPULauncher bool) *corev1.ConfigMap {
+// The workers are written in the hostfile with their host in hosts, if any, or with their pod name.
+func newConfigMap(mpiJob *kubeflowv1.MPIJob, workerReplicas int32, isGPULauncher bool, hosts map[string]string) *corev1.ConfigMap {
+	podName := "POD_NAME=$1"
+	if mpiJob.Spec.HostnameSource == kubeflowv1.HostnameSourcePodIP {
+		// The hosts are not pod names, look up the pod name of the host in the host map.
+		podName = fmt.Sprintf(`POD_NAME=$1
+while read -r HOST NAME; do
+  if [ "${HOST}" = "$1" ]; then
+    POD_NAME=${NAME}
+  fi
+done < %s/%s`, configMountPath, hostMapName)
+	}
 	kubexec := fmt.Sprintf(`#!/bin/sh
 set -x
-POD_NAME=$1
+%s
 shift
-%s/kubectl exec ${POD_NAME}`, kubectlMountPath)
+%s/kubectl exec ${POD_NAME}`, podName, kubectlMountPath)
 	if len(mpiJob.Spec.MainContainer) > 0 {
 		kubexec = fmt.Sprintf("%s --container %s", kubexec, mpiJob.Spec.MainContainer)
 	}
 	kubexec = fmt.Sprintf("%s -- /bin/sh -c \"$*\"", kubexec)
 
 	// If no processing unit is specified, default to 1 slot.
-	slots := 1
-	if mpiJob.Spec.SlotsPerWorker != nil {
-		slots = int(*mpiJob.Spec.SlotsPerWorker)
-	}
+	slots := replicaSlots(mpiJob, kubeflowv1.MPIJobReplicaTypeWorker)
+	hostfile := hostfileTemplate(mpiJob)
 	var buffer bytes.Buffer
 	if isGPULauncher {
-		buffer.WriteString(fmt.Sprintf("%s%s slots=%d\n", mpiJob.Name, launcherSuffix, slots))
+		launcherName := mpiJob.Name + launcherSuffix
+		buffer.WriteString(hostfile.render(launcherName, launcherName, replicaSlots(mpiJob, kubeflowv1.MPIJobReplicaTypeLauncher)) + "\n")
 	}
 	for i := 0; i < int(workerReplicas); i++ {
-		buffer.WriteString(fmt.Sprintf("%s%s-%d slots=%d\n", mpiJob.Name, workerSuffix, i, slots))
+		podName := fmt.Sprintf("%s%s-%d", mpiJob.Name, workerSuffix, i)
+		buffer.WriteString(hostfile.render(hostOf(hosts, podName), podName, slots) + "\n")
 	}
 
-	return &corev1.ConfigMap{
+	configMap := &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      mpiJob.Name + configSuffix,
 			Namespace: mpiJob.Namespace,
 			Labels: map[string]string{
-				"app": mpiJob.Name,
+				"app":                 mpiJob.Name,
+				kubeflowv1.RunIDLabel: common.RunID(mpiJob),
 			},
 			OwnerReferences: []metav1.OwnerReference{
 				*metav1.NewControllerRef(mpiJob, kubeflowv1.MPIJobSchemeGroupVersionKind),
@@ -1264,27 +1529,35 @@ shift
 			kubexecScriptName: kubexec,
 		},
 	}
+	// The host map is written even before the pods have an IP, since it is mounted in the pods.
+	if mpiJob.Spec.HostnameSource == kubeflowv1.HostnameSourcePodIP {
+		configMap.Data[hostMapName] = hostMap(hosts)
+	}
+	setGPUWrapperConfig(configMap, mpiJob)
+	return configMap
 }
 
 // updateDiscoverHostsInConfigMap updates the ConfigMap if the content of `discover_hosts.sh` changes.
-func updateDiscoverHostsInConfigMap(configMap *corev1.ConfigMap, mpiJob *kubeflowv1.MPIJob, runningPods []*corev1.Pod, isGPULauncher bool) {
-	slots := 1
-	if mpiJob.Spec.SlotsPerWorker != nil {
-		slots = int(*mpiJob.Spec.SlotsPerWorker)
-	}
+// The running pods are written with their host in hosts, if any, or with their name, and their slots.
+func updateDiscoverHostsInConfigMap(configMap *corev1.ConfigMap, mpiJob *kubeflowv1.MPIJob, runningPods []*corev1.Pod, isGPULauncher bool, hosts map[string]string) {
+	slots := replicaSlots(mpiJob, kubeflowv1.MPIJobReplicaTypeLauncher)
 
 	// Sort the slice of Pods to make sure the order of entries in `discover_hosts.sh` is maintained.
 	sort.Slice(runningPods, func(i, j int) bool {
 		return runningPods[i].Name < runningPods[j].Name
 	})
 
-	discoverHosts := "#!/bin/sh"
+	discover := discoverHostsTemplate(mpiJob)
+	var buffer bytes.Buffer
+	buffer.WriteString("#!/bin/sh")
 	if isGPULauncher {
-		discoverHosts = fmt.Sprintf("%s\necho %s%s:%d\n", discoverHosts, mpiJob.Name, launcherSuffix, slots)
+		launcherName := mpiJob.Name + launcherSuffix
+		buffer.WriteString(fmt.Sprintf("\necho %s\n", discover.render(launcherName, launcherName, slots)))
 	}
 	for _, p := range runningPods {
-		discoverHosts = fmt.Sprintf("%s\necho %s:%d", discoverHosts, p.Name, slots)
+		buffer.WriteString(fmt.Sprintf("\necho %s", discover.render(hostOf(hosts, p.Name), p.Name, workerSlots(mpiJob, p))))
 	}
+	discoverHosts := buffer.String()
 
 	oldDiscoverHosts, exist := configMap.Data[discoverHostsScriptName]
 	if exist {
@@ -1310,7 +1583,8 @@ func newLauncherServiceAccount(mpiJob *kubeflowv1.MPIJob) *corev1.ServiceAccount
 			Name:      launcherName,
 			Namespace: mpiJob.Namespace,
 			Labels: map[string]string{
-				"app": mpiJob.Name,
+				"app":                 mpiJob.Name,
+				kubeflowv1.RunIDLabel: common.RunID(mpiJob),
 			},
 			OwnerReferences: []metav1.OwnerReference{
 				*metav1.NewControllerRef(mpiJob, kubeflowv1.MPIJobSchemeGroupVersionKind),
@@ -1322,35 +1596,45 @@ func newLauncherServiceAccount(mpiJob *kubeflowv1.MPIJob) *corev1.ServiceAccount
 // newLauncherRole creates a new launcher Role for an MPIJob resource. It also
 // sets the appropriate OwnerReferences on the resource so handleObject can
 // discover the MPIJob resource that 'owns' it.
-func newLauncherRole(mpiJob *kubeflowv1.MPIJob, workerReplicas int32) *rbacv1.Role {
+// The launcher can exec only into the worker pods which exist and are not
+// being deleted. The exec rule is left out while there are no such workers,
+// as a rule without resource names would grant exec into every pod.
+func newLauncherRole(mpiJob *kubeflowv1.MPIJob, workers []*corev1.Pod) *rbacv1.Role {
 	var podNames []string
-	for i := 0; i < int(workerReplicas); i++ {
-		podNames = append(podNames, fmt.Sprintf("%s%s-%d", mpiJob.Name, workerSuffix, i))
+	for _, pod := range workers {
+		if pod != nil && pod.DeletionTimestamp == nil {
+			podNames = append(podNames, pod.Name)
+		}
+	}
+	sort.Strings(podNames)
+	rules := []rbacv1.PolicyRule{
+		{
+			Verbs:     []string{"get", "list", "watch"},
+			APIGroups: []string{""},
+			Resources: []string{"pods"},
+		},
+	}
+	if len(podNames) > 0 {
+		rules = append(rules, rbacv1.PolicyRule{
+			Verbs:         []string{"create"},
+			APIGroups:     []string{""},
+			Resources:     []string{"pods/exec"},
+			ResourceNames: podNames,
+		})
 	}
 	return &rbacv1.Role{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      mpiJob.Name + launcherSuffix,
 			Namespace: mpiJob.Namespace,
 			Labels: map[string]string{
-				"app": mpiJob.Name,
+				"app":                 mpiJob.Name,
+				kubeflowv1.RunIDLabel: common.RunID(mpiJob),
 			},
 			OwnerReferences: []metav1.OwnerReference{
 				*metav1.NewControllerRef(mpiJob, kubeflowv1.MPIJobSchemeGroupVersionKind),
 			},
 		},
-		Rules: []rbacv1.PolicyRule{
-			{
-				Verbs:     []string{"get", "list", "watch"},
-				APIGroups: []string{""},
-				Resources: []string{"pods"},
-			},
-			{
-				Verbs:         []string{"create"},
-				APIGroups:     []string{""},
-				Resources:     []string{"pods/exec"},
-				ResourceNames: podNames,
-			},
-		},
+		Rules: rules,
 	}
 }
 
@@ -1370,7 +1654,8 @@ func newLauncherRoleBinding(mpiJob *kubeflowv1.MPIJob) *rbacv1.RoleBinding {
 			Name:      launcherName,
 			Namespace: mpiJob.Namespace,
 			Labels: map[string]string{
-				"app": mpiJob.Name,
+				"app":                 mpiJob.Name,
+				kubeflowv1.RunIDLabel: common.RunID(mpiJob),
 			},
 			OwnerReferences: []metav1.OwnerReference{
 				*metav1.NewControllerRef(mpiJob, kubeflowv1.MPIJobSchemeGroupVersionKind),
@@ -1392,7 +1677,7 @@ func newLauncherRoleBinding(mpiJob *kubeflowv1.MPIJob) *rbacv1.RoleBinding {
 }
 
 func setRestartPolicy(podTemplateSpec *corev1.PodTemplateSpec, spec *kubeflowv1.ReplicaSpec) {
-	if spec.RestartPolicy == kubeflowv1.RestartPolicyExitCode {
+	if spec.RestartPolicy == kubeflowv1.RestartPolicyExitCode || spec.RestartPolicy == kubeflowv1.RestartPolicyGangRestart {
 		podTemplateSpec.Spec.RestartPolicy = corev1.RestartPolicyNever
 	} else {
 		podTemplateSpec.Spec.RestartPolicy = corev1.RestartPolicy(spec.RestartPolicy)
@@ -159,7 +159,9 @@ var _ = Describe("MPIJob controller", func() {
 			for testName, testCase := range testCases {
 				mpiJob := newMPIJobWithLauncher("test-"+strings.ToLower(testName),
 					ptr.To[int32](64), 1, testCase.gpu, &startTime, &completionTime)
-				Expect(isGPULauncher(mpiJob) == testCase.expected).To(BeTrue())
+				isGPU, err := isGPULauncher(mpiJob)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(isGPU == testCase.expected).To(BeTrue())
 			}
 		})
 	})
@@ -176,7 +178,10 @@ var _ = Describe("MPIJob controller", func() {
 			mpiJob := newMPIJobWithLauncher(jobName, ptr.To[int32](64), 1, gpuResourceName, &startTime, &completionTime)
 			Expect(testK8sClient.Create(ctx, mpiJob)).Should(Succeed())
 
-			launcher := reconciler.newLauncher(mpiJob, "kubectl-delivery", isGPULauncher(mpiJob))
+			isGPU, err := isGPULauncher(mpiJob)
+			Expect(err).NotTo(HaveOccurred())
+			launcher, err := reconciler.newLauncher(mpiJob, "kubectl-delivery", isGPU)
+			Expect(err).NotTo(HaveOccurred())
 			launcher.Status.Phase = corev1.PodSucceeded
 
 			launcherKey := types.NamespacedName{
@@ -220,7 +225,10 @@ var _ = Describe("MPIJob controller", func() {
 			mpiJob := newMPIJobWithLauncher(jobName, ptr.To[int32](64), 1, gpuResourceName, &startTime, &completionTime)
 			Expect(testK8sClient.Create(ctx, mpiJob)).Should(Succeed())
 
-			launcher := reconciler.newLauncher(mpiJob, "kubectl-delivery", isGPULauncher(mpiJob))
+			isGPU, err := isGPULauncher(mpiJob)
+			Expect(err).NotTo(HaveOccurred())
+			launcher, err := reconciler.newLauncher(mpiJob, "kubectl-delivery", isGPU)
+			Expect(err).NotTo(HaveOccurred())
 			launcherKey := types.NamespacedName{
 				Namespace: metav1.NamespaceDefault,
 				Name:      launcher.GetName(),
@@ -262,7 +270,10 @@ var _ = Describe("MPIJob controller", func() {
 			mpiJob := newMPIJobWithLauncher(jobName, ptr.To[int32](64), 1, gpuResourceName, &startTime, &completionTime)
 			Expect(testK8sClient.Create(ctx, mpiJob)).Should(Succeed())
 
-			launcher := reconciler.newLauncher(mpiJob, "kubectl-delivery", isGPULauncher(mpiJob))
+			isGPU, err := isGPULauncher(mpiJob)
+			Expect(err).NotTo(HaveOccurred())
+			launcher, err := reconciler.newLauncher(mpiJob, "kubectl-delivery", isGPU)
+			Expect(err).NotTo(HaveOccurred())
 			launcher.Status.Phase = corev1.PodSucceeded
 
 			launcherKey := types.NamespacedName{
@@ -308,7 +319,10 @@ var _ = Describe("MPIJob controller", func() {
 			mpiJob := newMPIJobWithLauncher(jobName, &replicas, 1, gpuResourceName, &startTime, &completionTime)
 			Expect(testK8sClient.Create(ctx, mpiJob)).Should(Succeed())
 
-			launcher := reconciler.newLauncher(mpiJob, "kubectl-delivery", isGPULauncher(mpiJob))
+			isGPU, err := isGPULauncher(mpiJob)
+			Expect(err).NotTo(HaveOccurred())
+			launcher, err := reconciler.newLauncher(mpiJob, "kubectl-delivery", isGPU)
+			Expect(err).NotTo(HaveOccurred())
 			launcherKey := types.NamespacedName{
 				Namespace: metav1.NamespaceDefault,
 				Name:      launcher.GetName(),
@@ -324,7 +338,8 @@ var _ = Describe("MPIJob controller", func() {
 
 			for i := 0; i < int(replicas); i++ {
 				name := fmt.Sprintf("%s-%d", mpiJob.Name+workerSuffix, i)
-				worker := reconciler.newWorker(mpiJob, name)
+				worker, err := reconciler.newWorker(mpiJob, name)
+				Expect(err).NotTo(HaveOccurred())
 				workerKey := types.NamespacedName{
 					Namespace: metav1.NamespaceDefault,
 					Name:      worker.GetName(),
@@ -380,7 +395,10 @@ var _ = Describe("MPIJob controller", func() {
 			mpiJob := newMPIJob(jobName, &replicas, 1, gpuResourceName, &startTime, &completionTime)
 			Expect(testK8sClient.Create(ctx, mpiJob)).Should(Succeed())
 
-			launcher := reconciler.newLauncher(mpiJob, "kubectl-delivery", isGPULauncher(mpiJob))
+			isGPU, err := isGPULauncher(mpiJob)
+			Expect(err).NotTo(HaveOccurred())
+			launcher, err := reconciler.newLauncher(mpiJob, "kubectl-delivery", isGPU)
+			Expect(err).NotTo(HaveOccurred())
 			launcherKey := types.NamespacedName{
 				Namespace: metav1.NamespaceDefault,
 				Name:      launcher.GetName(),
@@ -396,7 +414,8 @@ var _ = Describe("MPIJob controller", func() {
 
 			for i := 0; i < int(replicas); i++ {
 				name := fmt.Sprintf("%s-%d", mpiJob.Name+workerSuffix, i)
-				worker := reconciler.newWorker(mpiJob, name)
+				worker, err := reconciler.newWorker(mpiJob, name)
+				Expect(err).NotTo(HaveOccurred())
 				workerKey := types.NamespacedName{
 					Namespace: metav1.NamespaceDefault,
 					Name:      worker.GetName(),
@@ -454,7 +473,8 @@ var _ = Describe("MPIJob controller", func() {
 
 			for i := 0; i < int(replicas); i++ {
 				name := fmt.Sprintf("%s-%d", mpiJob.Name+workerSuffix, i)
-				worker := reconciler.newWorker(mpiJob, name)
+				worker, err := reconciler.newWorker(mpiJob, name)
+				Expect(err).NotTo(HaveOccurred())
 				workerKey := types.NamespacedName{
 					Namespace: metav1.NamespaceDefault,
 					Name:      worker.GetName(),
@@ -585,7 +605,10 @@ var _ = Describe("MPIJob controller", func() {
 
 			mpiJob := newMPIJob(jobName, ptr.To[int32](64), 1, gpuResourceName, &startTime, &completionTime)
 
-			launcher := reconciler.newLauncher(mpiJob, "kubectl-delivery", isGPULauncher(mpiJob))
+			isGPU, err := isGPULauncher(mpiJob)
+			Expect(err).NotTo(HaveOccurred())
+			launcher, err := reconciler.newLauncher(mpiJob, "kubectl-delivery", isGPU)
+			Expect(err).NotTo(HaveOccurred())
 			launcher.OwnerReferences = nil
 			Expect(testK8sClient.Create(ctx, launcher)).Should(Succeed())
 
@@ -617,7 +640,8 @@ var _ = Describe("MPIJob controller", func() {
 
 			for i := 0; i < 1; i++ {
 				name := fmt.Sprintf("%s-%d", mpiJob.Name+workerSuffix, i)
-				worker := reconciler.newWorker(mpiJob, name)
+				worker, err := reconciler.newWorker(mpiJob, name)
+				Expect(err).NotTo(HaveOccurred())
 				worker.OwnerReferences = nil
 				Expect(testK8sClient.Create(ctx, worker)).Should(Succeed())
 			}
@@ -648,7 +672,9 @@ var _ = Describe("MPIJob controller", func() {
 
 			mpiJob := newMPIJob(jobName, ptr.To[int32](64), 1, gpuResourceName, &startTime, &completionTime)
 
-			cm := newConfigMap(mpiJob, 64, isGPULauncher(mpiJob))
+			isGPU, err := isGPULauncher(mpiJob)
+			Expect(err).NotTo(HaveOccurred())
+			cm := newConfigMap(mpiJob, 64, isGPU, nil)
 			cm.OwnerReferences = nil
 			Expect(testK8sClient.Create(ctx, cm)).Should(Succeed())
 
@@ -678,7 +704,7 @@ var _ = Describe("MPIJob controller", func() {
 
 			mpiJob := newMPIJob(jobName, ptr.To[int32](64), 1, gpuResourceName, &startTime, &completionTime)
 
-			role := newLauncherRole(mpiJob, 64)
+			role := newLauncherRole(mpiJob, nil)
 			role.OwnerReferences = nil
 			Expect(testK8sClient.Create(ctx, role)).Should(Succeed())
 
@@ -797,7 +823,8 @@ var _ = Describe("MPIJob controller", func() {
 					)
 				}
 
-				launcher := reconciler.newLauncher(mpiJob, "kubectl-delivery", false)
+				launcher, err := reconciler.newLauncher(mpiJob, "kubectl-delivery", false)
+				Expect(err).NotTo(HaveOccurred())
 
 				Expect(len(launcher.Spec.Containers) == 1).To(BeTrue())
 				for expectedKey, expectedValue := range testCase.expectedEnvVariables {
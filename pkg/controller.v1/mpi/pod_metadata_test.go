@@ -0,0 +1,73 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mpi
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	"github.com/kubeflow/training-operator/pkg/controller.v1/common"
+	commonutil "github.com/kubeflow/training-operator/pkg/util"
+)
+
+func TestPodTemplateMetadata(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	jc := &MPIJobReconciler{JobController: common.JobController{Recorder: recorder}}
+	jc.JobController.Controller = jc
+	mpiJob := newDryRunMPIJob(nil)
+	userLabels := map[string]string{"team": "ml", "app.kubernetes.io/name": "trainer"}
+	userAnnotations := map[string]string{"cost-center": "1234"}
+	for _, spec := range mpiJob.Spec.MPIReplicaSpecs {
+		spec.Template.Labels = map[string]string{kubeflowv1.ReplicaTypeLabel: "other"}
+		for key, value := range userLabels {
+			spec.Template.Labels[key] = value
+		}
+		spec.Template.Annotations = userAnnotations
+	}
+
+	worker, launcher := newTestPods(t, jc, mpiJob)
+	for _, pod := range []*corev1.Pod{worker, launcher} {
+		for key, value := range userLabels {
+			if got := pod.Labels[key]; got != value {
+				t.Errorf("Unexpected label %s of the pod %s, want: %s, got: %s", key, pod.Name, value, got)
+			}
+		}
+		for key, value := range userAnnotations {
+			if got := pod.Annotations[key]; got != value {
+				t.Errorf("Unexpected annotation %s of the pod %s, want: %s, got: %s", key, pod.Name, value, got)
+			}
+		}
+		for key := range pod.Labels {
+			if _, ok := userLabels[key]; !ok && !strings.HasPrefix(key, kubeflowv1.ControllerLabelPrefix) {
+				t.Errorf("Expected the label %s of the pod %s to be under the prefix of the controller", key, pod.Name)
+			}
+		}
+		if got := pod.Labels[kubeflowv1.ReplicaTypeLabel]; got == "other" {
+			t.Errorf("Expected the reserved label of the pod %s to be overwritten", pod.Name)
+		}
+		select {
+		case event := <-recorder.Events:
+			if !strings.Contains(event, commonutil.PodTemplateLabelsReason) {
+				t.Errorf("Unexpected event: %s", event)
+			}
+		default:
+			t.Errorf("Expected a warning about the reserved labels of the pod %s", pod.Name)
+		}
+	}
+}
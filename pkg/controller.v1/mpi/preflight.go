@@ -0,0 +1,102 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mpi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	utilexec "k8s.io/client-go/util/exec"
+
+	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	trainingoperatorcommon "github.com/kubeflow/training-operator/pkg/common"
+	commonutil "github.com/kubeflow/training-operator/pkg/util"
+)
+
+// preflightCommand is run in every worker container to verify that the
+// kubexec bootstrap is able to start MPI processes in it.
+var preflightCommand = []string{"/bin/sh", "-c", "exit 0"}
+
+// preflightCheckEnabled returns true if the MPIJob opts in to the pre-flight check.
+func preflightCheckEnabled(mpiJob *kubeflowv1.MPIJob) bool {
+	return mpiJob.Spec.PreflightCheck != nil && *mpiJob.Spec.PreflightCheck
+}
+
+// checkPreflight runs the pre-flight check if the MPIJob opts in to it and reports
+// whether the launcher can be created. The job is marked as failed if some worker
+// does not pass the check.
+func (jc *MPIJobReconciler) checkPreflight(mpiJob *kubeflowv1.MPIJob, jobStatus *kubeflowv1.JobStatus, workers []*corev1.Pod) (bool, error) {
+	if !preflightCheckEnabled(mpiJob) {
+		return true, nil
+	}
+	if commonutil.IsFailed(*jobStatus) {
+		return false, nil
+	}
+	ready, failure, err := jc.runPreflightCheck(mpiJob, workers)
+	if err != nil {
+		return false, err
+	}
+	if failure != "" {
+		jc.Recorder.Event(mpiJob, corev1.EventTypeWarning, commonutil.MPIJobPreflightCheckFailedReason, failure)
+		if commonutil.FinishJob(jobStatus, kubeflowv1.JobFailed, commonutil.MPIJobPreflightCheckFailedReason, failure, jc.Clock) {
+			trainingoperatorcommon.FailedJobsCounterInc(mpiJob.Namespace, jc.GetFrameworkName())
+		}
+		return false, nil
+	}
+	return ready, nil
+}
+
+// runPreflightCheck verifies that every worker provides the prerequisites of the
+// kubexec bootstrap. It returns ready=false while some workers are not running yet,
+// and a non-empty failure message if a worker does not pass the check.
+// Errors which are not caused by the worker container itself, e.g. an unreachable
+// API server, are returned so that the check is retried.
+func (jc *MPIJobReconciler) runPreflightCheck(mpiJob *kubeflowv1.MPIJob, workers []*corev1.Pod) (ready bool, failure string, err error) {
+	for _, pod := range workers {
+		if pod.Status.Phase != corev1.PodRunning {
+			return false, "", nil
+		}
+	}
+	for _, pod := range workers {
+		containerName := mpiJob.Spec.MainContainer
+		if containerName == "" {
+			containerName = pod.Spec.Containers[0].Name
+		}
+		err := jc.PodExecControl.ExecInPod(context.Background(), pod.Namespace, pod.Name, containerName, preflightCommand)
+		if err == nil {
+			continue
+		}
+		if !isPreflightFailure(err) {
+			return false, "", err
+		}
+		return false, fmt.Sprintf("MPIJob %s/%s failed the pre-flight check: container %q of worker %s cannot run %q: %v",
+			mpiJob.Namespace, mpiJob.Name, containerName, pod.Name, strings.Join(preflightCommand, " "), err), nil
+	}
+	return true, "", nil
+}
+
+// isPreflightFailure returns true if the error means the command could not be
+// run in the container, rather than a transient failure of the exec request.
+func isPreflightFailure(err error) bool {
+	var exitErr utilexec.ExitError
+	if errors.As(err, &exitErr) {
+		return true
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "executable file not found") || strings.Contains(msg, "no such file or directory")
+}
@@ -0,0 +1,119 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mpi
+
+import (
+	"errors"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	utilexec "k8s.io/client-go/util/exec"
+	"k8s.io/utils/ptr"
+
+	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	"github.com/kubeflow/training-operator/pkg/controller.v1/common"
+	"github.com/kubeflow/training-operator/pkg/controller.v1/control"
+	commonutil "github.com/kubeflow/training-operator/pkg/util"
+)
+
+func newPreflightWorker(name string, phase corev1.PodPhase) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "mpi"}},
+		},
+		Status: corev1.PodStatus{Phase: phase},
+	}
+}
+
+func TestCheckPreflight(t *testing.T) {
+	cases := map[string]struct {
+		preflightCheck *bool
+		workers        []*corev1.Pod
+		execErrs       map[string]error
+		wantCreate     bool
+		wantErr        bool
+		wantFailed     bool
+	}{
+		"pre-flight check is disabled": {
+			workers:    []*corev1.Pod{newPreflightWorker("test-worker-0", corev1.PodPending)},
+			wantCreate: true,
+		},
+		"workers are not running yet": {
+			preflightCheck: ptr.To(true),
+			workers: []*corev1.Pod{
+				newPreflightWorker("test-worker-0", corev1.PodRunning),
+				newPreflightWorker("test-worker-1", corev1.PodPending),
+			},
+			wantCreate: false,
+		},
+		"all workers pass the check": {
+			preflightCheck: ptr.To(true),
+			workers: []*corev1.Pod{
+				newPreflightWorker("test-worker-0", corev1.PodRunning),
+				newPreflightWorker("test-worker-1", corev1.PodRunning),
+			},
+			wantCreate: true,
+		},
+		"worker without shell fails the job": {
+			preflightCheck: ptr.To(true),
+			workers: []*corev1.Pod{
+				newPreflightWorker("test-worker-0", corev1.PodRunning),
+				newPreflightWorker("test-worker-1", corev1.PodRunning),
+			},
+			execErrs: map[string]error{
+				"test-worker-1": utilexec.CodeExitError{Err: errors.New("command terminated with exit code 127"), Code: 127},
+			},
+			wantCreate: false,
+			wantFailed: true,
+		},
+		"transient exec error is retried": {
+			preflightCheck: ptr.To(true),
+			workers:        []*corev1.Pod{newPreflightWorker("test-worker-0", corev1.PodRunning)},
+			execErrs: map[string]error{
+				"test-worker-0": errors.New("error dialing backend: connection refused"),
+			},
+			wantCreate: false,
+			wantErr:    true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			jc := &MPIJobReconciler{
+				JobController: common.JobController{
+					Recorder:       record.NewFakeRecorder(10),
+					PodExecControl: &control.FakePodExecControl{Errs: tc.execErrs},
+				},
+			}
+			mpiJob := &kubeflowv1.MPIJob{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+				Spec:       kubeflowv1.MPIJobSpec{PreflightCheck: tc.preflightCheck},
+			}
+			jobStatus := &kubeflowv1.JobStatus{}
+			gotCreate, err := jc.checkPreflight(mpiJob, jobStatus, tc.workers)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Unexpected error from checkPreflight(): %v", err)
+			}
+			if gotCreate != tc.wantCreate {
+				t.Errorf("Unexpected result from checkPreflight() \nwant: %v, got: %v\n", tc.wantCreate, gotCreate)
+			}
+			if gotFailed := commonutil.IsFailed(*jobStatus); gotFailed != tc.wantFailed {
+				t.Errorf("Unexpected failed condition \nwant: %v, got: %v\n", tc.wantFailed, gotFailed)
+			}
+		})
+	}
+}
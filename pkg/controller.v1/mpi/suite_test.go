@@ -90,7 +90,7 @@ var _ = BeforeSuite(func() {
 	Expect(err).NotTo(HaveOccurred())
 
 	gangSchedulingSetupFunc := common.GenNonGangSchedulerSetupFunc()
-	reconciler = NewReconciler(mgr, gangSchedulingSetupFunc)
+	reconciler = NewReconciler(mgr, gangSchedulingSetupFunc, common.JobControllerOptions{})
 	Expect(reconciler.SetupWithManager(mgr, 1)).NotTo(HaveOccurred())
 
 	go func() {
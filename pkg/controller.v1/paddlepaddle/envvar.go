@@ -20,8 +20,10 @@ import (
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
 
 	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	trainutil "github.com/kubeflow/training-operator/pkg/util/train"
 )
 
 const (
@@ -30,6 +32,19 @@ const (
 	EnvJobID          = "PADDLE_JOB_ID"
 	EnvServerNum      = "PADDLE_SERVER_NUM"
 	EnvTrainerNum     = "PADDLE_TRAINER_NUM"
+
+	// The env vars of the heterogeneous mode.
+	EnvPServersIPPortList = "PADDLE_PSERVERS_IP_PORT_LIST"
+	EnvTrainerEndpoints   = "PADDLE_TRAINER_ENDPOINTS"
+	EnvTrainersNum        = "PADDLE_TRAINERS_NUM"
+	EnvTrainerID          = "PADDLE_TRAINER_ID"
+	EnvCurrentEndpoint    = "PADDLE_CURRENT_ENDPOINT"
+	EnvPort               = "PADDLE_PORT"
+	EnvPodIP              = "POD_IP"
+	EnvTrainingRole       = "TRAINING_ROLE"
+
+	trainingRolePServer = "PSERVER"
+	trainingRoleTrainer = "TRAINER"
 )
 
 // EnvVarGenerator is the environment variable generator interface.
@@ -71,8 +86,13 @@ func setPodEnv(obj interface{}, podTemplateSpec *corev1.PodTemplateSpec, rtype,
 			Value: strconv.Itoa(int(totalReplicas)),
 		})
 
-		// If the master is null, run in Collective mode
-		if paddlejob.Spec.PaddleReplicaSpecs[kubeflowv1.PaddleJobReplicaTypeMaster] == nil {
+		// If the parameter servers are set, run in heterogeneous mode
+		if ContainsPServerSpec(paddlejob.Spec.PaddleReplicaSpecs) {
+			podTemplateSpec.Spec.Containers[i].Env = append(podTemplateSpec.Spec.Containers[i].Env,
+				heterogeneousEnv(paddlejob, rtype, rank)...)
+
+			// If the master is null, run in Collective mode
+		} else if paddlejob.Spec.PaddleReplicaSpecs[kubeflowv1.PaddleJobReplicaTypeMaster] == nil {
 
 			// We pick the worker 0 as the rendezvous endpoint
 			masterAddr := replicaName(paddlejob.Name, kubeflowv1.PaddleJobReplicaTypeWorker, 0)
@@ -143,6 +163,74 @@ func setPodEnv(obj interface{}, podTemplateSpec *corev1.PodTemplateSpec, rtype,
 	return nil
 }
 
+// heterogeneousEnv returns the env vars of the parameter servers and the collective trainers of
+// the heterogeneous mode, where each pod runs a single parameter server or trainer.
+func heterogeneousEnv(job *kubeflowv1.PaddleJob, rtype string, rank int) []corev1.EnvVar {
+	pserverPort := getPortFromPaddleJob(job, kubeflowv1.PaddleJobReplicaTypePServer)
+	workerPort := getPortFromPaddleJob(job, kubeflowv1.PaddleJobReplicaTypeWorker)
+	workers := job.Spec.PaddleReplicaSpecs[kubeflowv1.PaddleJobReplicaTypeWorker]
+
+	role, port := trainingRoleTrainer, workerPort
+	replicaType := kubeflowv1.PaddleJobReplicaTypeWorker
+	if rtype == strings.ToLower(string(kubeflowv1.PaddleJobReplicaTypePServer)) {
+		role, port = trainingRolePServer, pserverPort
+		replicaType = kubeflowv1.PaddleJobReplicaTypePServer
+	}
+
+	// The pod IP is set first, so that the endpoints of a standalone job can refer to it.
+	env := []corev1.EnvVar{
+		{
+			Name: EnvPodIP,
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{
+					FieldPath: "status.podIP",
+				},
+			},
+		},
+		{
+			Name:  EnvPServersIPPortList,
+			Value: endpoints(job, kubeflowv1.PaddleJobReplicaTypePServer, pserverPort),
+		},
+		{
+			Name:  EnvTrainerEndpoints,
+			Value: endpoints(job, kubeflowv1.PaddleJobReplicaTypeWorker, workerPort),
+		},
+		{
+			Name:  EnvTrainersNum,
+			Value: strconv.Itoa(int(ptr.Deref(workers.Replicas, 1))),
+		},
+		{
+			Name:  EnvTrainingRole,
+			Value: role,
+		},
+		{
+			Name:  EnvPort,
+			Value: strconv.Itoa(int(port)),
+		},
+		{
+			Name:  EnvCurrentEndpoint,
+			Value: fmt.Sprintf("%s:%d", replicaAddr(job, replicaType, rank), port),
+		},
+	}
+	if role == trainingRoleTrainer {
+		env = append(env, corev1.EnvVar{
+			Name:  EnvTrainerID,
+			Value: strconv.Itoa(rank),
+		})
+	}
+	return env
+}
+
+// endpoints returns the comma separated endpoints of the replicas of the replica type.
+func endpoints(job *kubeflowv1.PaddleJob, rtype kubeflowv1.ReplicaType, port int32) string {
+	replicas := int(ptr.Deref(job.Spec.PaddleReplicaSpecs[rtype].Replicas, 1))
+	endpoints := make([]string, 0, replicas)
+	for i := 0; i < replicas; i++ {
+		endpoints = append(endpoints, fmt.Sprintf("%s:%d", replicaAddr(job, rtype, i), port))
+	}
+	return strings.Join(endpoints, ",")
+}
+
 func getTotalReplicas(job *kubeflowv1.PaddleJob) int32 {
 	jobReplicas := int32(0)
 	for _, r := range job.Spec.PaddleReplicaSpecs {
@@ -156,6 +244,15 @@ func replicaName(jobName string, rtype kubeflowv1.ReplicaType, index int) string
 	return strings.Replace(n, "/", "-", -1)
 }
 
+// replicaAddr returns the address of a replica, i.e. the name of its service, or the IP of the
+// pod itself, from the POD_IP env var, for the single pod of a standalone job which has no service.
+func replicaAddr(job *kubeflowv1.PaddleJob, rtype kubeflowv1.ReplicaType, index int) string {
+	if trainutil.IsJobStandalone(&job.Spec.RunPolicy) {
+		return "$(" + EnvPodIP + ")"
+	}
+	return replicaName(job.Name, rtype, index)
+}
+
 func getPortFromPaddleJob(job *kubeflowv1.PaddleJob, rtype kubeflowv1.ReplicaType) int32 {
 	containers := job.Spec.PaddleReplicaSpecs[rtype].Template.Spec.Containers
 	for _, container := range containers {
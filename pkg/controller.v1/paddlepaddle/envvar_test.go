@@ -0,0 +1,126 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package paddle
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+)
+
+func TestSetPodEnvHeterogeneous(t *testing.T) {
+	replicaSpec := func(replicas, port int32) *kubeflowv1.ReplicaSpec {
+		return &kubeflowv1.ReplicaSpec{
+			Replicas: ptr.To(replicas),
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name: kubeflowv1.PaddleJobDefaultContainerName,
+						Ports: []corev1.ContainerPort{{
+							Name:          kubeflowv1.PaddleJobDefaultPortName,
+							ContainerPort: port,
+						}},
+					}},
+				},
+			},
+		}
+	}
+	job := &kubeflowv1.PaddleJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec: kubeflowv1.PaddleJobSpec{
+			PaddleReplicaSpecs: map[kubeflowv1.ReplicaType]*kubeflowv1.ReplicaSpec{
+				kubeflowv1.PaddleJobReplicaTypePServer: replicaSpec(2, 36001),
+				kubeflowv1.PaddleJobReplicaTypeWorker:  replicaSpec(2, 36002),
+			},
+		},
+	}
+	podIP := corev1.EnvVar{
+		Name:      EnvPodIP,
+		ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "status.podIP"}},
+	}
+	commonEnv := []corev1.EnvVar{
+		{Name: "PYTHONUNBUFFERED", Value: "1"},
+		{Name: EnvJobID, Value: "test"},
+		{Name: EnvNumNodes, Value: "4"},
+		podIP,
+		{Name: EnvPServersIPPortList, Value: "test-pserver-0:36001,test-pserver-1:36001"},
+		{Name: EnvTrainerEndpoints, Value: "test-worker-0:36002,test-worker-1:36002"},
+		{Name: EnvTrainersNum, Value: "2"},
+	}
+
+	cases := map[string]struct {
+		rtype      kubeflowv1.ReplicaType
+		index      string
+		standalone bool
+		wantEnv    []corev1.EnvVar
+	}{
+		"parameter server": {
+			rtype: kubeflowv1.PaddleJobReplicaTypePServer,
+			index: "1",
+			wantEnv: append(append([]corev1.EnvVar{}, commonEnv...),
+				corev1.EnvVar{Name: EnvTrainingRole, Value: "PSERVER"},
+				corev1.EnvVar{Name: EnvPort, Value: "36001"},
+				corev1.EnvVar{Name: EnvCurrentEndpoint, Value: "test-pserver-1:36001"},
+			),
+		},
+		"trainer": {
+			rtype: kubeflowv1.PaddleJobReplicaTypeWorker,
+			index: "1",
+			wantEnv: append(append([]corev1.EnvVar{}, commonEnv...),
+				corev1.EnvVar{Name: EnvTrainingRole, Value: "TRAINER"},
+				corev1.EnvVar{Name: EnvPort, Value: "36002"},
+				corev1.EnvVar{Name: EnvCurrentEndpoint, Value: "test-worker-1:36002"},
+				corev1.EnvVar{Name: EnvTrainerID, Value: "1"},
+			),
+		},
+		"standalone trainer": {
+			rtype:      kubeflowv1.PaddleJobReplicaTypeWorker,
+			index:      "0",
+			standalone: true,
+			wantEnv: []corev1.EnvVar{
+				{Name: "PYTHONUNBUFFERED", Value: "1"},
+				{Name: EnvJobID, Value: "test"},
+				{Name: EnvNumNodes, Value: "4"},
+				podIP,
+				{Name: EnvPServersIPPortList, Value: "$(POD_IP):36001,$(POD_IP):36001"},
+				{Name: EnvTrainerEndpoints, Value: "$(POD_IP):36002,$(POD_IP):36002"},
+				{Name: EnvTrainersNum, Value: "2"},
+				{Name: EnvTrainingRole, Value: "TRAINER"},
+				{Name: EnvPort, Value: "36002"},
+				{Name: EnvCurrentEndpoint, Value: "$(POD_IP):36002"},
+				{Name: EnvTrainerID, Value: "0"},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			job := job.DeepCopy()
+			job.Spec.RunPolicy.Standalone = ptr.To(tc.standalone)
+			podTemplate := job.Spec.PaddleReplicaSpecs[tc.rtype].Template.DeepCopy()
+			if err := setPodEnv(job, podTemplate, strings.ToLower(string(tc.rtype)), tc.index); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantEnv, podTemplate.Spec.Containers[0].Env); len(diff) != 0 {
+				t.Errorf("Unexpected env (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
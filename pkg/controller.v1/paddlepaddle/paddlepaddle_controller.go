@@ -23,15 +23,16 @@ import (
 	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
 	trainingoperatorcommon "github.com/kubeflow/training-operator/pkg/common"
 	"github.com/kubeflow/training-operator/pkg/common/util"
+	"github.com/kubeflow/training-operator/pkg/config"
 	"github.com/kubeflow/training-operator/pkg/controller.v1/common"
 	"github.com/kubeflow/training-operator/pkg/controller.v1/control"
 	"github.com/kubeflow/training-operator/pkg/controller.v1/expectation"
+	"github.com/kubeflow/training-operator/pkg/controller.v1/registry"
+	"github.com/kubeflow/training-operator/pkg/core"
 	commonutil "github.com/kubeflow/training-operator/pkg/util"
 
 	"github.com/go-logr/logr"
-	"github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -42,17 +43,15 @@ import (
 	"k8s.io/client-go/informers"
 	kubeclientset "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
-	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/source"
-	schedulerpluginsv1alpha1 "sigs.k8s.io/scheduler-plugins/apis/scheduling/v1alpha1"
-	"volcano.sh/apis/pkg/apis/scheduling/v1beta1"
 )
 
 const (
@@ -60,13 +59,13 @@ const (
 )
 
 // NewReconciler creates a PaddleJob Reconciler
-func NewReconciler(mgr manager.Manager, gangSchedulingSetupFunc common.GangSchedulingSetupFunc) *PaddleJobReconciler {
+func NewReconciler(mgr manager.Manager, gangSchedulingSetupFunc common.GangSchedulingSetupFunc, options common.JobControllerOptions) *PaddleJobReconciler {
 	r := &PaddleJobReconciler{
 		Client:    mgr.GetClient(),
 		Scheme:    mgr.GetScheme(),
-		recorder:  mgr.GetEventRecorderFor(controllerName),
+		recorder:  commonutil.NewDeduplicatingRecorder(commonutil.NewEventRecorderFor(mgr, controllerName), config.Config.EventDeduplicationWindow),
 		apiReader: mgr.GetAPIReader(),
-		Log:       log.Log,
+		Log:       ctrl.Log.WithName("controllers").WithName(kubeflowv1.PaddleJobKind),
 	}
 
 	// Create clients
@@ -79,13 +78,18 @@ func NewReconciler(mgr manager.Manager, gangSchedulingSetupFunc common.GangSched
 	r.JobController = common.JobController{
 		Controller:                  r,
 		Expectations:                expectation.NewControllerExpectations(),
-		WorkQueue:                   &util.FakeWorkQueue{},
+		WorkQueue:                   util.NewRequeueWorkQueue(),
 		Recorder:                    r.recorder,
 		KubeClientSet:               kubeClientSet,
 		PriorityClassLister:         priorityClassInformer.Lister(),
 		PriorityClassInformerSynced: priorityClassInformer.Informer().HasSynced,
 		PodControl:                  control.RealPodControl{KubeClient: kubeClientSet, Recorder: r.recorder},
 		ServiceControl:              control.RealServiceControl{KubeClient: kubeClientSet, Recorder: r.recorder},
+		PodExecControl:              control.RealPodExecControl{Config: cfg, KubeClient: kubeClientSet},
+		JobRegistry:                 registry.Default,
+		JobControllerOptions:        options,
+		Clock:                       commonutil.NewClock(clock.RealClock{}, config.Config.ClockSkewTolerance),
+		OOMKillTracker:              common.NewOOMKillTracker(time.Now()),
 	}
 
 	gangSchedulingSetupFunc(&r.JobController)
@@ -107,10 +111,22 @@ type PaddleJobReconciler struct {
 // +kubebuilder:rbac:groups=kubeflow.org,resources=paddlejobs/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=kubeflow.org,resources=paddlejobs/finalizers,verbs=update
 // +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups="",resources=pods/exec,verbs=create
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=resourcequotas,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;patch;delete
 // +kubebuilder:rbac:groups=scheduling.volcano.sh,resources=podgroups,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=scheduling.x-k8s.io,resources=podgroups,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=ray.io,resources=rayclusters,verbs=get;create;delete
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch;create;update
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;patch;delete
 // +kubebuilder:rbac:groups="",resources=events,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;update;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -121,16 +137,21 @@ type PaddleJobReconciler struct {
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.8.3/pkg/reconcile
 func (r *PaddleJobReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	_ = log.FromContext(ctx)
-	logger := r.Log.WithValues(kubeflowv1.PaddleJobSingular, req.NamespacedName)
+	defer trainingoperatorcommon.ReconcileDurationObserve(r.GetFrameworkName(), time.Now())
+	logger := r.Log.WithValues("kind", kubeflowv1.PaddleJobKind, "namespace", req.Namespace, "name", req.Name)
 
 	paddlejob := &kubeflowv1.PaddleJob{}
 	err := r.Get(ctx, req.NamespacedName, paddlejob)
 	if err != nil {
-		logger.Info(err.Error(), "unable to fetch PaddleJob", req.NamespacedName.String())
+		logger.Info("unable to fetch PaddleJob", "error", err.Error())
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
+	if !common.ReconciledByThisController(paddlejob) {
+		logger.V(1).Info("Skipping PaddleJob pinned to another controller", "controller-identity", paddlejob.GetAnnotations()[kubeflowv1.ControllerIdentityAnnotation])
+		return ctrl.Result{}, nil
+	}
+
 	if manager := r.ManagedByExternalController(paddlejob.Spec.RunPolicy.ManagedBy); manager != nil {
 		logger.Info("Skipping PaddleJob managed by a custom controller", "managed-by", manager)
 		return ctrl.Result{}, nil
@@ -161,9 +182,9 @@ func (r *PaddleJobReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		return ctrl.Result{}, err
 	}
 
-	t, err := util.DurationUntilExpireTime(&paddlejob.Spec.RunPolicy, paddlejob.Status)
+	t, err := util.DurationUntilExpireTime(&paddlejob.Spec.RunPolicy, paddlejob.Status, r.Clock)
 	if err != nil {
-		logrus.Warnf("Reconcile PaddleJob error %v", err)
+		logger.Error(err, "Reconcile PaddleJob error")
 		return ctrl.Result{}, err
 	}
 	if t >= 0 {
@@ -176,8 +197,9 @@ func (r *PaddleJobReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 // SetupWithManager sets up the controller with the Manager.
 func (r *PaddleJobReconciler) SetupWithManager(mgr ctrl.Manager, controllerThreads int) error {
 	c, err := controller.New(r.ControllerName(), mgr, controller.Options{
-		Reconciler:              r,
+		Reconciler:              common.NewDrainingReconciler(r, r.Clock),
 		MaxConcurrentReconciles: controllerThreads,
+		RateLimiter:             common.NewRateLimiter(),
 	})
 
 	if err != nil {
@@ -191,6 +213,26 @@ func (r *PaddleJobReconciler) SetupWithManager(mgr ctrl.Manager, controllerThrea
 	); err != nil {
 		return err
 	}
+	// keep the job registry shared by all controllers up to date
+	if err = registry.Default.Watch(context.Background(), mgr.GetCache(), &kubeflowv1.PaddleJob{}); err != nil {
+		return err
+	}
+	// requeue the jobs added to the work queue of the common job controller
+	if err = r.WatchWorkQueue(c); err != nil {
+		return err
+	}
+	// requeue the jobs waiting for the objects referenced by the templates of their pods
+	if err = r.WatchDependencies(mgr, c); err != nil {
+		return err
+	}
+	// requeue the jobs blocked by the resource quotas of their namespace
+	if err = r.WatchResourceQuotas(mgr, c); err != nil {
+		return err
+	}
+	// requeue the jobs with pods bound to a node which starts being reclaimed
+	if err = r.WatchPreemptedNodes(mgr, c); err != nil {
+		return err
+	}
 	// inject watching for job related pod
 	if err = c.Watch(source.Kind[*corev1.Pod](mgr.GetCache(), &corev1.Pod{},
 		handler.TypedEnqueueRequestForOwner[*corev1.Pod](mgr.GetScheme(), mgr.GetRESTMapper(), &kubeflowv1.PaddleJob{}, handler.OnlyControllerOwner()),
@@ -203,30 +245,10 @@ func (r *PaddleJobReconciler) SetupWithManager(mgr ctrl.Manager, controllerThrea
 		util.OnDependentFuncs[*corev1.Service](r.Scheme, r.Expectations, &r.JobController))); err != nil {
 		return err
 	}
-	// skip watching volcano PodGroup if volcano PodGroup is not installed
-	if _, err = mgr.GetRESTMapper().RESTMapping(schema.GroupKind{Group: v1beta1.GroupName, Kind: "PodGroup"},
-		v1beta1.SchemeGroupVersion.Version,
-	); err == nil {
-		// inject watching for job related volcano PodGroup
-		if err = c.Watch(source.Kind[*v1beta1.PodGroup](mgr.GetCache(), &v1beta1.PodGroup{},
-			handler.TypedEnqueueRequestForOwner[*v1beta1.PodGroup](mgr.GetScheme(), mgr.GetRESTMapper(), &kubeflowv1.PaddleJob{}, handler.OnlyControllerOwner()),
-			util.OnDependentFuncs[*v1beta1.PodGroup](r.Scheme, r.Expectations, &r.JobController))); err != nil {
-			return err
-		}
-	}
-	// skip watching scheduler-plugins PodGroup if scheduler-plugins PodGroup is not installed
-	if _, err = mgr.GetRESTMapper().RESTMapping(
-		schema.GroupKind{Group: schedulerpluginsv1alpha1.SchemeGroupVersion.Group, Kind: "PodGroup"},
-		schedulerpluginsv1alpha1.SchemeGroupVersion.Version,
-	); err == nil {
-		// inject watching for job related scheduler-plugins PodGroup
-		if err = c.Watch(source.Kind[*schedulerpluginsv1alpha1.PodGroup](mgr.GetCache(), &schedulerpluginsv1alpha1.PodGroup{},
-			handler.TypedEnqueueRequestForOwner[*schedulerpluginsv1alpha1.PodGroup](mgr.GetScheme(), mgr.GetRESTMapper(), &kubeflowv1.PaddleJob{}, handler.OnlyControllerOwner()),
-			util.OnDependentFuncs[*schedulerpluginsv1alpha1.PodGroup](r.Scheme, r.Expectations, &r.JobController))); err != nil {
-			return err
-		}
+	// inject watching for job related PodGroup of the gang scheduler
+	if err = util.WatchPodGroups(mgr, c, r.Scheme, &kubeflowv1.PaddleJob{}, &r.JobController); err != nil {
+		return err
 	}
-
 	return nil
 }
 
@@ -255,9 +277,9 @@ func (r *PaddleJobReconciler) GetJobFromInformerCache(namespace, name string) (m
 	err := r.Get(context.Background(), types.NamespacedName{Namespace: namespace, Name: name}, job)
 	if err != nil {
 		if errors.IsNotFound(err) {
-			logrus.Error(err, "paddle job not found", "namespace", namespace, "name", name)
+			r.Log.Error(err, "paddle job not found", "namespace", namespace, "name", name)
 		} else {
-			logrus.Error(err, "failed to get job from api-server", "namespace", namespace, "name", name)
+			r.Log.Error(err, "failed to get job from api-server", "namespace", namespace, "name", name)
 		}
 		return nil, err
 	}
@@ -270,9 +292,9 @@ func (r *PaddleJobReconciler) GetJobFromAPIClient(namespace, name string) (metav
 	err := r.apiReader.Get(context.Background(), types.NamespacedName{Namespace: namespace, Name: name}, job)
 	if err != nil {
 		if errors.IsNotFound(err) {
-			logrus.Error(err, "paddle job not found", "namespace", namespace, "name", name)
+			r.Log.Error(err, "paddle job not found", "namespace", namespace, "name", name)
 		} else {
-			logrus.Error(err, "failed to get job from api-server", "namespace", namespace, "name", name)
+			r.Log.Error(err, "failed to get job from api-server", "namespace", namespace, "name", name)
 		}
 		return nil, err
 	}
@@ -293,7 +315,7 @@ func (r *PaddleJobReconciler) GetPodsForJob(obj interface{}) ([]*corev1.Pod, err
 		return nil, err
 	}
 
-	return util.JobControlledPodList(podlist.Items, job), nil
+	return r.ClaimPods(job, util.ConvertPodList(podlist.Items))
 }
 
 func (r *PaddleJobReconciler) GetServicesForJob(obj interface{}) ([]*corev1.Service, error) {
@@ -320,12 +342,12 @@ func (r *PaddleJobReconciler) DeleteJob(job interface{}) error {
 		return fmt.Errorf("%+v is not a type of PaddleJob", job)
 	}
 	if err := r.Delete(context.Background(), paddlejob); err != nil {
-		r.recorder.Eventf(paddlejob, corev1.EventTypeWarning, control.FailedDeletePodReason, "Error deleting: %v", err)
-		logrus.Error(err, "failed to delete job", "namespace", paddlejob.Namespace, "name", paddlejob.Name)
+		r.recorder.Eventf(paddlejob, corev1.EventTypeWarning, commonutil.FailedDeletePodReason, "Error deleting: %v", err)
+		commonutil.LoggerForJob(paddlejob).Error(err, "failed to delete job")
 		return err
 	}
-	r.recorder.Eventf(paddlejob, corev1.EventTypeNormal, control.SuccessfulDeletePodReason, "Deleted job: %v", paddlejob.Name)
-	logrus.Info("job deleted", "namespace", paddlejob.Namespace, "name", paddlejob.Name)
+	r.recorder.Eventf(paddlejob, corev1.EventTypeNormal, commonutil.SuccessfulDeletePodReason, "Deleted job: %v", paddlejob.Name)
+	commonutil.LoggerForJob(paddlejob).Info("job deleted")
 	trainingoperatorcommon.DeletedJobsCounterInc(paddlejob.Namespace, r.GetFrameworkName())
 	return nil
 }
@@ -359,11 +381,11 @@ func (r *PaddleJobReconciler) UpdateJobStatus(job interface{},
 
 	// Set StartTime.
 	if jobStatus.StartTime == nil {
-		now := metav1.Now()
+		now := r.Clock.MetaNow()
 		jobStatus.StartTime = &now
 		// enqueue a sync to check if job past ActiveDeadlineSeconds
 		if paddlejob.Spec.RunPolicy.ActiveDeadlineSeconds != nil {
-			logger.Infof("Job with ActiveDeadlineSeconds will sync after %d seconds", *paddlejob.Spec.RunPolicy.ActiveDeadlineSeconds)
+			logger.Info("Job with ActiveDeadlineSeconds will sync after the deadline", "activeDeadlineSeconds", *paddlejob.Spec.RunPolicy.ActiveDeadlineSeconds)
 			r.WorkQueue.AddAfter(paddlejobKey, time.Duration(*paddlejob.Spec.RunPolicy.ActiveDeadlineSeconds)*time.Second)
 		}
 	}
@@ -379,26 +401,22 @@ func (r *PaddleJobReconciler) UpdateJobStatus(job interface{},
 		failed := status.Failed
 		specReplicas := *spec.Replicas
 
-		logrus.Infof("PaddleJob=%s, ReplicaType=%s expected=%d, running=%d, succeeded=%d, failed=%d, Replicas=%d",
-			paddlejob.Name, rtype, expected, running, succeeded, failed, specReplicas)
+		logger.Info("Replica status", "replicaType", rtype, "expected", expected, "running", running, "succeeded", succeeded, "failed", failed, "replicas", specReplicas)
 
 		if ContainsMasterSpec(replicas) {
 			if rtype == kubeflowv1.PaddleJobReplicaTypeMaster {
 				if running > 0 {
 					msg := fmt.Sprintf("PaddleJob %s is running.", paddlejob.Name)
-					commonutil.UpdateJobConditions(jobStatus, kubeflowv1.JobRunning, corev1.ConditionTrue, commonutil.NewReason(kubeflowv1.PaddleJobKind, commonutil.JobRunningReason), msg)
+					commonutil.UpdateJobConditions(jobStatus, kubeflowv1.JobRunning, corev1.ConditionTrue, commonutil.NewReason(kubeflowv1.PaddleJobKind, commonutil.JobRunningReason), msg, r.Clock)
 				}
 				// when master is succeed, the job is finished.
 				if expected == 0 {
 					msg := fmt.Sprintf("PaddleJob %s is successfully completed.", paddlejob.Name)
-					logrus.Info(msg)
+					logger.Info(msg)
 					r.Recorder.Event(paddlejob, corev1.EventTypeNormal, commonutil.NewReason(kubeflowv1.PaddleJobKind, commonutil.JobSucceededReason), msg)
-					if jobStatus.CompletionTime == nil {
-						now := metav1.Now()
-						jobStatus.CompletionTime = &now
+					if commonutil.FinishJob(jobStatus, kubeflowv1.JobSucceeded, commonutil.NewReason(kubeflowv1.PaddleJobKind, commonutil.JobSucceededReason), msg, r.Clock) {
+						trainingoperatorcommon.SuccessfulJobsCounterInc(paddlejob.Namespace, r.GetFrameworkName())
 					}
-					commonutil.UpdateJobConditions(jobStatus, kubeflowv1.JobSucceeded, corev1.ConditionTrue, commonutil.NewReason(kubeflowv1.PaddleJobKind, commonutil.JobSucceededReason), msg)
-					trainingoperatorcommon.SuccessfulJobsCounterInc(paddlejob.Namespace, r.GetFrameworkName())
 					return nil
 				}
 			}
@@ -409,17 +427,14 @@ func (r *PaddleJobReconciler) UpdateJobStatus(job interface{},
 					msg := fmt.Sprintf("PaddleJob %s/%s successfully completed.",
 						paddlejob.Namespace, paddlejob.Name)
 					r.recorder.Event(paddlejob, corev1.EventTypeNormal, commonutil.NewReason(kubeflowv1.PaddleJobKind, commonutil.JobSucceededReason), msg)
-					if jobStatus.CompletionTime == nil {
-						now := metav1.Now()
-						jobStatus.CompletionTime = &now
+					if commonutil.FinishJob(jobStatus, kubeflowv1.JobSucceeded, commonutil.NewReason(kubeflowv1.PaddleJobKind, commonutil.JobSucceededReason), msg, r.Clock) {
+						trainingoperatorcommon.SuccessfulJobsCounterInc(paddlejob.Namespace, r.GetFrameworkName())
 					}
-					commonutil.UpdateJobConditions(jobStatus, kubeflowv1.JobSucceeded, corev1.ConditionTrue, commonutil.NewReason(kubeflowv1.PaddleJobKind, commonutil.JobSucceededReason), msg)
-					trainingoperatorcommon.SuccessfulJobsCounterInc(paddlejob.Namespace, r.GetFrameworkName())
 				} else if running > 0 {
 					// Some workers are still running, leave a running condition.
 					msg := fmt.Sprintf("PaddleJob %s/%s is running.",
 						paddlejob.Namespace, paddlejob.Name)
-					commonutil.UpdateJobConditions(jobStatus, kubeflowv1.JobRunning, corev1.ConditionTrue, commonutil.NewReason(kubeflowv1.PaddleJobKind, commonutil.JobRunningReason), msg)
+					commonutil.UpdateJobConditions(jobStatus, kubeflowv1.JobRunning, corev1.ConditionTrue, commonutil.NewReason(kubeflowv1.PaddleJobKind, commonutil.JobRunningReason), msg, r.Clock)
 				}
 			}
 		}
@@ -428,17 +443,14 @@ func (r *PaddleJobReconciler) UpdateJobStatus(job interface{},
 			if spec.RestartPolicy != kubeflowv1.RestartPolicyNever {
 				msg := fmt.Sprintf("PaddleJob %s is restarting because %d %s replica(s) failed.", paddlejob.Name, failed, rtype)
 				r.Recorder.Event(paddlejob, corev1.EventTypeWarning, commonutil.NewReason(kubeflowv1.PaddleJobKind, commonutil.JobRestartingReason), msg)
-				commonutil.UpdateJobConditions(jobStatus, kubeflowv1.JobRestarting, corev1.ConditionTrue, commonutil.NewReason(kubeflowv1.PaddleJobKind, commonutil.JobRestartingReason), msg)
+				commonutil.UpdateJobConditions(jobStatus, kubeflowv1.JobRestarting, corev1.ConditionTrue, commonutil.NewReason(kubeflowv1.PaddleJobKind, commonutil.JobRestartingReason), msg, r.Clock)
 				trainingoperatorcommon.RestartedJobsCounterInc(paddlejob.Namespace, r.GetFrameworkName())
 			} else {
 				msg := fmt.Sprintf("PaddleJob %s is failed because %d %s replica(s) failed.", paddlejob.Name, failed, rtype)
 				r.Recorder.Event(paddlejob, corev1.EventTypeNormal, commonutil.NewReason(kubeflowv1.PaddleJobKind, commonutil.JobFailedReason), msg)
-				if jobStatus.CompletionTime == nil {
-					now := metav1.Now()
-					jobStatus.CompletionTime = &now
+				if commonutil.FinishJob(jobStatus, kubeflowv1.JobFailed, commonutil.NewReason(kubeflowv1.PaddleJobKind, commonutil.JobFailedReason), msg, r.Clock) {
+					trainingoperatorcommon.FailedJobsCounterInc(paddlejob.Namespace, r.GetFrameworkName())
 				}
-				commonutil.UpdateJobConditions(jobStatus, kubeflowv1.JobFailed, corev1.ConditionTrue, commonutil.NewReason(kubeflowv1.PaddleJobKind, commonutil.JobFailedReason), msg)
-				trainingoperatorcommon.FailedJobsCounterInc(paddlejob.Namespace, r.GetFrameworkName())
 			}
 		}
 	}
@@ -454,6 +466,13 @@ func ContainsMasterSpec(replicas map[kubeflowv1.ReplicaType]*kubeflowv1.ReplicaS
 	return false
 }
 
+// ContainsPServerSpec returns true if the paddlejob contains parameter server spec,
+// i.e. runs in heterogeneous mode.
+func ContainsPServerSpec(replicas map[kubeflowv1.ReplicaType]*kubeflowv1.ReplicaSpec) bool {
+	_, ok := replicas[kubeflowv1.PaddleJobReplicaTypePServer]
+	return ok
+}
+
 // UpdateJobStatusInApiServer updates the job status in to cluster.
 func (r *PaddleJobReconciler) UpdateJobStatusInApiServer(job interface{}, jobStatus *kubeflowv1.JobStatus) error {
 	if jobStatus.ReplicaStatuses == nil {
@@ -466,19 +485,13 @@ func (r *PaddleJobReconciler) UpdateJobStatusInApiServer(job interface{}, jobSta
 		return fmt.Errorf("%+v is not a type of PaddleJob", job)
 	}
 
-	// Job status passed in differs with status in job, update in basis of the passed in one.
-	if !equality.Semantic.DeepEqual(&paddlejob.Status, jobStatus) {
-		paddlejob = paddlejob.DeepCopy()
-		paddlejob.Status = *jobStatus.DeepCopy()
-	}
-
-	result := r.Status().Update(context.Background(), paddlejob)
+	// Patch only the difference to the status through the status subresource, so that
+	// updates do not overwrite the spec and are retried on conflicts.
+	result := util.PatchJobStatus(context.Background(), r.Client, r.apiReader, paddlejob,
+		func(j *kubeflowv1.PaddleJob) *kubeflowv1.JobStatus { return &j.Status }, jobStatus)
 
 	if result != nil {
-		r.Log.WithValues("paddlejob", types.NamespacedName{
-			Namespace: paddlejob.GetNamespace(),
-			Name:      paddlejob.GetName(),
-		})
+		commonutil.LoggerForJob(paddlejob).Error(result, "failed to update the job status in the API server")
 		return result
 	}
 
@@ -487,7 +500,15 @@ func (r *PaddleJobReconciler) UpdateJobStatusInApiServer(job interface{}, jobSta
 
 // SetClusterSpec sets the cluster spec and init container for the pod
 func (r *PaddleJobReconciler) SetClusterSpec(job interface{}, podTemplate *corev1.PodTemplateSpec, rtype, index string) error {
-	// TODO
+	paddlejob, ok := job.(*kubeflowv1.PaddleJob)
+	if !ok {
+		return fmt.Errorf("%v is not a type of PaddleJob", job)
+	}
+	core.SetCommonEnv(podTemplate, kubeflowv1.PaddleJobDefaultContainerName, paddlejob.Spec.CommonEnv, paddlejob.Spec.CommonEnvFrom)
+	common.SetNetworkTuningEnv(podTemplate, kubeflowv1.PaddleJobDefaultContainerName, &paddlejob.Spec.RunPolicy)
+	if common.IsClusterSpecEnvDisabled(&paddlejob.Spec.RunPolicy) {
+		return nil
+	}
 	if err := setPodEnv(job, podTemplate, rtype, index); err != nil {
 		return err
 	}
@@ -502,6 +523,42 @@ func (r *PaddleJobReconciler) GetDefaultContainerPortName() string {
 	return kubeflowv1.PaddleJobDefaultPortName
 }
 
+// GetJobDependencies returns the jobs the PaddleJob depends on.
+func (r *PaddleJobReconciler) GetJobDependencies(job interface{}) []kubeflowv1.JobReference {
+	paddleJob, ok := job.(*kubeflowv1.PaddleJob)
+	if !ok {
+		return nil
+	}
+	return paddleJob.Spec.DependsOn
+}
+
+// GetTensorBoard returns the TensorBoard requested by the PaddleJob.
+func (r *PaddleJobReconciler) GetTensorBoard(job interface{}) *kubeflowv1.TensorBoardSpec {
+	paddleJob, ok := job.(*kubeflowv1.PaddleJob)
+	if !ok {
+		return nil
+	}
+	return paddleJob.Spec.TensorBoard
+}
+
+// GetOutputs returns the outputs declared by the PaddleJob.
+func (r *PaddleJobReconciler) GetOutputs(job interface{}) []kubeflowv1.OutputSpec {
+	paddleJob, ok := job.(*kubeflowv1.PaddleJob)
+	if !ok {
+		return nil
+	}
+	return paddleJob.Spec.Outputs
+}
+
+// GetDatasets returns the datasets declared by the PaddleJob.
+func (r *PaddleJobReconciler) GetDatasets(job interface{}) []kubeflowv1.DatasetSpec {
+	paddleJob, ok := job.(*kubeflowv1.PaddleJob)
+	if !ok {
+		return nil
+	}
+	return paddleJob.Spec.Datasets
+}
+
 func (r *PaddleJobReconciler) IsMasterRole(replicas map[kubeflowv1.ReplicaType]*kubeflowv1.ReplicaSpec,
 	rtype kubeflowv1.ReplicaType, index int) bool {
 	return string(rtype) == string(kubeflowv1.PaddleJobReplicaTypeMaster)
@@ -510,12 +567,17 @@ func (r *PaddleJobReconciler) IsMasterRole(replicas map[kubeflowv1.ReplicaType]*
 // onOwnerCreateFunc modify creation condition.
 func (r *PaddleJobReconciler) onOwnerCreateFunc() func(createEvent event.TypedCreateEvent[*kubeflowv1.PaddleJob]) bool {
 	return func(e event.TypedCreateEvent[*kubeflowv1.PaddleJob]) bool {
+		if !common.ReconciledByThisController(e.Object) {
+			return false
+		}
 		paddlejob := e.Object
 		r.Scheme.Default(paddlejob)
 		msg := fmt.Sprintf("PaddleJob %s is created.", e.Object.GetName())
-		logrus.Info(msg)
+		commonutil.LoggerForJob(paddlejob).Info(msg)
 		trainingoperatorcommon.CreatedJobsCounterInc(paddlejob.Namespace, r.GetFrameworkName())
-		commonutil.UpdateJobConditions(&paddlejob.Status, kubeflowv1.JobCreated, corev1.ConditionTrue, commonutil.NewReason(kubeflowv1.PaddleJobKind, commonutil.JobCreatedReason), msg)
+		oldStatus := paddlejob.Status.DeepCopy()
+		commonutil.UpdateJobConditions(&paddlejob.Status, kubeflowv1.JobCreated, corev1.ConditionTrue, commonutil.NewReason(kubeflowv1.PaddleJobKind, commonutil.JobCreatedReason), msg, r.Clock)
+		r.RecordAuditEvents(paddlejob, oldStatus, &paddlejob.Status)
 		return true
 	}
 }
@@ -100,7 +100,7 @@ var _ = BeforeSuite(func() {
 	Expect(err).NotTo(HaveOccurred())
 
 	gangSchedulingSetupFunc := common.GenNonGangSchedulerSetupFunc()
-	r := NewReconciler(mgr, gangSchedulingSetupFunc)
+	r := NewReconciler(mgr, gangSchedulingSetupFunc, common.JobControllerOptions{})
 	Expect(r.SetupWithManager(mgr, 1)).NotTo(HaveOccurred())
 	Expect(paddlewebhook.SetupWebhook(mgr)).NotTo(HaveOccurred())
 
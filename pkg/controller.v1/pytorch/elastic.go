@@ -22,6 +22,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 
 	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	trainutil "github.com/kubeflow/training-operator/pkg/util/train"
 )
 
 const (
@@ -122,6 +123,10 @@ func (e ElasticEnvVarGenerator) Generate(
 }
 
 func (e ElasticEnvVarGenerator) generateEnvNnodes(job *kubeflowv1.PyTorchJob) (*corev1.EnvVar, error) {
+	// A standalone job runs a single node.
+	if trainutil.IsJobStandalone(&job.Spec.RunPolicy) {
+		return &corev1.EnvVar{Name: EnvNnodes, Value: "1"}, nil
+	}
 	// Return worker.replicas if there is no max and min replicas specified.
 	if job.Spec.ElasticPolicy.MinReplicas == nil &&
 		job.Spec.ElasticPolicy.MaxReplicas == nil {
@@ -144,28 +149,40 @@ func (e ElasticEnvVarGenerator) generateEnvNnodes(job *kubeflowv1.PyTorchJob) (*
 }
 
 func (e ElasticEnvVarGenerator) generateEnvRDZVEndpoint(job *kubeflowv1.PyTorchJob) (*corev1.EnvVar, error) {
+	host, port, err := rdzvHostPort(job)
+	if err != nil {
+		return nil, err
+	}
+	return &corev1.EnvVar{
+		Name:  EnvRDZVEndpoint,
+		Value: fmt.Sprintf("%s:%d", host, port),
+	}, nil
+}
+
+// rdzvHostPort returns the host and the port of the rendezvous endpoint of the job, which
+// default to worker-0 and the port of the worker container.
+func rdzvHostPort(job *kubeflowv1.PyTorchJob) (string, int32, error) {
 	var err error
 	host := ""
 	if job.Spec.ElasticPolicy.RDZVHost == nil {
-		host = fmt.Sprintf("%s-worker-0", job.Name)
+		host = replicaName(job.Name, kubeflowv1.PyTorchJobReplicaTypeWorker, 0)
+		if trainutil.IsJobStandalone(&job.Spec.RunPolicy) {
+			host = trainutil.StandaloneMasterAddr
+		}
 	} else {
 		host = *job.Spec.ElasticPolicy.RDZVHost
 	}
 
 	var port int32
 	if job.Spec.ElasticPolicy.RDZVPort == nil {
-		// Generate RDZV_Endpoint.
 		port, err = getPortFromPyTorchJob(job, kubeflowv1.PyTorchJobReplicaTypeWorker)
 		if err != nil {
-			return nil, err
+			return "", 0, err
 		}
 	} else {
 		port = *job.Spec.ElasticPolicy.RDZVPort
 	}
-	return &corev1.EnvVar{
-		Name:  EnvRDZVEndpoint,
-		Value: fmt.Sprintf("%s:%d", host, port),
-	}, nil
+	return host, port, nil
 }
 
 func (e ElasticEnvVarGenerator) generateEnvRDZVConf(elasticPolicy *kubeflowv1.ElasticPolicy) *corev1.EnvVar {
@@ -94,6 +94,14 @@ func setPodEnv(obj interface{}, podTemplateSpec *corev1.PodTemplateSpec, rtype,
 				Name:  EnvNodeRank,
 				Value: strconv.Itoa(rank),
 			})
+		} else if isC10DWithoutMaster(pytorchjob) {
+			// The ranks are assigned by the c10d rendezvous hosted by worker-0.
+			envVars, err := GetMasterEnvVarGenerator().Generate(pytorchjob)
+			if err != nil {
+				return err
+			}
+			podTemplateSpec.Spec.Containers[i].Env = append(
+				podTemplateSpec.Spec.Containers[i].Env, envVars...)
 		}
 
 		if pytorchjob.Spec.NprocPerNode != nil {
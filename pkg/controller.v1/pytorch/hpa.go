@@ -31,7 +31,7 @@ import (
 )
 
 func (r *PyTorchJobReconciler) ReconcileHPA(pytorchJob *kubeflowv1.PyTorchJob) error {
-	logger := r.Log.WithValues(kubeflowv1.PyTorchJobSingular, pytorchJob.Name)
+	logger := r.Log.WithValues("kind", kubeflowv1.PyTorchJobKind, "namespace", pytorchJob.Namespace, "name", pytorchJob.Name)
 
 	if pytorchJob.Spec.ElasticPolicy == nil || pytorchJob.Spec.ElasticPolicy.Metrics == nil {
 		logger.V(1).Info(
@@ -68,8 +68,11 @@ func (r *PyTorchJobReconciler) ReconcileHPA(pytorchJob *kubeflowv1.PyTorchJob) e
 
 	if !equality.Semantic.DeepEqual(expected.Spec, current.Spec) {
 		logger.V(1).Info("Updating HPA", "namespace", current.Namespace, "name", current.Name)
-		expected.ResourceVersion = current.ResourceVersion
-		err = r.Update(context.TODO(), expected)
+		// Only the spec is patched, so that the labels, the annotations and the fields unknown to
+		// the operator set on the HPA by others are kept.
+		patch := client.MergeFrom(current.DeepCopy())
+		current.Spec = expected.Spec
+		err = r.Patch(context.TODO(), current, patch)
 		if err != nil {
 			return err
 		}
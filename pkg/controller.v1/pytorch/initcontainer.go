@@ -24,7 +24,6 @@ import (
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/yaml"
 
 	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
@@ -108,10 +107,8 @@ func setInitContainer(obj interface{}, podTemplate *corev1.PodTemplateSpec,
 	if !ok {
 		return fmt.Errorf("%+v is not a type of PyTorchJob", obj)
 	}
-	logger := log.WithValues(kubeflowv1.PyTorchJobSingular, types.NamespacedName{
-		Namespace: pytorchJob.Namespace,
-		Name:      pytorchJob.Name,
-	})
+	logger := log.WithValues("kind", kubeflowv1.PyTorchJobKind, "namespace", pytorchJob.Namespace, "name", pytorchJob.Name,
+		"replicaType", rtype, "index", index)
 
 	// There is no need to set init container if no master is specified.
 	if pytorchJob.Spec.PyTorchReplicaSpecs[kubeflowv1.PyTorchJobReplicaTypeMaster] == nil {
@@ -134,3 +131,19 @@ func setInitContainer(obj interface{}, podTemplate *corev1.PodTemplateSpec,
 	}
 	return nil
 }
+
+// InjectedInitContainers returns the init container waiting for the master which is added to the
+// workers, so that its resources are accounted in the minResources of the PodGroup of the PyTorchJob.
+func (r *PyTorchJobReconciler) InjectedInitContainers(job interface{}, rtype kubeflowv1.ReplicaType) []corev1.Container {
+	pytorchJob, ok := job.(*kubeflowv1.PyTorchJob)
+	if !ok || rtype != kubeflowv1.PyTorchJobReplicaTypeWorker ||
+		pytorchJob.Spec.PyTorchReplicaSpecs[kubeflowv1.PyTorchJobReplicaTypeMaster] == nil {
+		return nil
+	}
+	initContainers, err := getInitContainerGenerator().GetInitContainer(replicaName(pytorchJob.Name,
+		kubeflowv1.PyTorchJobReplicaTypeMaster, 0))
+	if err != nil {
+		return nil
+	}
+	return initContainers
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/onsi/ginkgo/v2"
 	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/utils/ptr"
 
 	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
@@ -108,3 +109,35 @@ func TestInitContainer(t *testing.T) {
 		gomega.Expect(len(podTemplateSpec.Spec.InitContainers)).To(gomega.Equal(t.expected))
 	}
 }
+
+func TestSetClusterSpecWithClusterSpecEnvDisabled(t *testing.T) {
+	config.Config.PyTorchInitContainerImage = config.PyTorchInitContainerImageDefault
+	config.Config.PyTorchInitContainerTemplateFile = config.PyTorchInitContainerTemplateFileDefault
+	config.Config.PyTorchInitContainerMaxTries = config.PyTorchInitContainerMaxTriesDefault
+
+	job := &kubeflowv1.PyTorchJob{
+		Spec: kubeflowv1.PyTorchJobSpec{
+			RunPolicy: kubeflowv1.RunPolicy{
+				EnvInjectionPolicy: &kubeflowv1.EnvInjectionPolicy{DisableClusterSpec: true},
+			},
+			PyTorchReplicaSpecs: map[kubeflowv1.ReplicaType]*kubeflowv1.ReplicaSpec{
+				kubeflowv1.PyTorchJobReplicaTypeMaster: {Replicas: ptr.To[int32](1)},
+				kubeflowv1.PyTorchJobReplicaTypeWorker: {Replicas: ptr.To[int32](1)},
+			},
+		},
+	}
+	podTemplate := &corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: kubeflowv1.PyTorchJobDefaultContainerName}}},
+	}
+	r := &PyTorchJobReconciler{Log: logr.Discard()}
+	if err := r.SetClusterSpec(job, podTemplate, "worker", "0"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if env := podTemplate.Spec.Containers[0].Env; len(env) != 0 {
+		t.Errorf("Expected no cluster spec env vars, got: %v", env)
+	}
+	// The init container waiting for the master is still added.
+	if got := len(podTemplate.Spec.InitContainers); got != 1 {
+		t.Errorf("Unexpected number of init containers, want: 1, got: %d", got)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 
 	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	trainutil "github.com/kubeflow/training-operator/pkg/util/train"
 )
 
 var (
@@ -33,30 +34,56 @@ func GetMasterEnvVarGenerator() EnvVarGenerator {
 func (e MasterEnvVarGenerator) Generate(
 	job *kubeflowv1.PyTorchJob) ([]corev1.EnvVar, error) {
 	var envVars []corev1.EnvVar
-	if job.Spec.PyTorchReplicaSpecs[kubeflowv1.PyTorchJobReplicaTypeMaster] != nil {
-		masterPort, err := getPortFromPyTorchJob(job, kubeflowv1.PyTorchJobReplicaTypeMaster)
+	var masterAddr string
+	var masterPort int32
+	var err error
+	switch {
+	case job.Spec.PyTorchReplicaSpecs[kubeflowv1.PyTorchJobReplicaTypeMaster] != nil:
+		masterPort, err = getPortFromPyTorchJob(job, kubeflowv1.PyTorchJobReplicaTypeMaster)
 		if err != nil {
 			return nil, err
 		}
 
-		masterAddr := replicaName(job.Name, kubeflowv1.PyTorchJobReplicaTypeMaster, 0)
-
-		envVars = append(envVars, corev1.EnvVar{
-			Name:  EnvMasterPort,
-			Value: strconv.Itoa(int(masterPort)),
-		})
-		envVars = append(envVars, corev1.EnvVar{
-			Name:  PETMasterPort,
-			Value: strconv.Itoa(int(masterPort)),
-		})
-		envVars = append(envVars, corev1.EnvVar{
-			Name:  EnvMasterAddr,
-			Value: masterAddr,
-		})
-		envVars = append(envVars, corev1.EnvVar{
-			Name:  PETMasterAddr,
-			Value: masterAddr,
-		})
+		masterAddr = replicaName(job.Name, kubeflowv1.PyTorchJobReplicaTypeMaster, 0)
+		if trainutil.IsJobStandalone(&job.Spec.RunPolicy) {
+			// The master has no service, the process rendezvous with itself.
+			masterAddr = trainutil.StandaloneMasterAddr
+		}
+	case isC10DWithoutMaster(job):
+		// The c10d rendezvous is hosted by worker-0, which is reachable through its headless service.
+		masterAddr, masterPort, err = rdzvHostPort(job)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return envVars, nil
 	}
+
+	envVars = append(envVars, corev1.EnvVar{
+		Name:  EnvMasterPort,
+		Value: strconv.Itoa(int(masterPort)),
+	})
+	envVars = append(envVars, corev1.EnvVar{
+		Name:  PETMasterPort,
+		Value: strconv.Itoa(int(masterPort)),
+	})
+	envVars = append(envVars, corev1.EnvVar{
+		Name:  EnvMasterAddr,
+		Value: masterAddr,
+	})
+	envVars = append(envVars, corev1.EnvVar{
+		Name:  PETMasterAddr,
+		Value: masterAddr,
+	})
 	return envVars, nil
 }
+
+// isC10DWithoutMaster returns true if the job has no Master replica and its workers
+// rendezvous through the c10d backend, which is then hosted by worker-0.
+func isC10DWithoutMaster(job *kubeflowv1.PyTorchJob) bool {
+	if job.Spec.PyTorchReplicaSpecs[kubeflowv1.PyTorchJobReplicaTypeMaster] != nil {
+		return false
+	}
+	elasticPolicy := job.Spec.ElasticPolicy
+	return elasticPolicy != nil && elasticPolicy.RDZVBackend != nil && *elasticPolicy.RDZVBackend == kubeflowv1.BackendC10D
+}
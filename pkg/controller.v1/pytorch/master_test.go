@@ -0,0 +1,135 @@
+// Copyright 2021 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License
+
+package pytorch
+
+import (
+	"testing"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+)
+
+func TestMasterGenerate(t *testing.T) {
+	gomega.RegisterFailHandler(ginkgo.Fail)
+	defer ginkgo.GinkgoRecover()
+
+	backendC10D := kubeflowv1.BackendC10D
+	backendETCD := kubeflowv1.BackendETCD
+	newReplicaSpec := func() *kubeflowv1.ReplicaSpec {
+		return &kubeflowv1.ReplicaSpec{
+			Replicas: ptr.To[int32](1),
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name: kubeflowv1.PyTorchJobDefaultContainerName,
+						Ports: []corev1.ContainerPort{{
+							Name:          kubeflowv1.PyTorchJobDefaultPortName,
+							ContainerPort: 23456,
+						}},
+					}},
+				},
+			},
+		}
+	}
+	masterEnvVars := func(addr, port string) []corev1.EnvVar {
+		return []corev1.EnvVar{
+			{Name: EnvMasterPort, Value: port},
+			{Name: PETMasterPort, Value: port},
+			{Name: EnvMasterAddr, Value: addr},
+			{Name: PETMasterAddr, Value: addr},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		job      *kubeflowv1.PyTorchJob
+		expected []corev1.EnvVar
+	}{
+		{
+			name: "With Master",
+			job: &kubeflowv1.PyTorchJob{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: kubeflowv1.PyTorchJobSpec{
+					PyTorchReplicaSpecs: map[kubeflowv1.ReplicaType]*kubeflowv1.ReplicaSpec{
+						kubeflowv1.PyTorchJobReplicaTypeMaster: newReplicaSpec(),
+						kubeflowv1.PyTorchJobReplicaTypeWorker: newReplicaSpec(),
+					},
+				},
+			},
+			expected: masterEnvVars("test-master-0", "23456"),
+		},
+		{
+			name: "Without Master and with c10d rendezvous",
+			job: &kubeflowv1.PyTorchJob{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: kubeflowv1.PyTorchJobSpec{
+					ElasticPolicy: &kubeflowv1.ElasticPolicy{
+						RDZVBackend: &backendC10D,
+					},
+					PyTorchReplicaSpecs: map[kubeflowv1.ReplicaType]*kubeflowv1.ReplicaSpec{
+						kubeflowv1.PyTorchJobReplicaTypeWorker: newReplicaSpec(),
+					},
+				},
+			},
+			expected: masterEnvVars("test-worker-0", "23456"),
+		},
+		{
+			name: "Without Master and with c10d rendezvous on a custom port",
+			job: &kubeflowv1.PyTorchJob{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: kubeflowv1.PyTorchJobSpec{
+					ElasticPolicy: &kubeflowv1.ElasticPolicy{
+						RDZVBackend: &backendC10D,
+						RDZVPort:    ptr.To[int32](29400),
+					},
+					PyTorchReplicaSpecs: map[kubeflowv1.ReplicaType]*kubeflowv1.ReplicaSpec{
+						kubeflowv1.PyTorchJobReplicaTypeWorker: newReplicaSpec(),
+					},
+				},
+			},
+			expected: masterEnvVars("test-worker-0", "29400"),
+		},
+		{
+			name: "Without Master and with etcd rendezvous",
+			job: &kubeflowv1.PyTorchJob{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: kubeflowv1.PyTorchJobSpec{
+					ElasticPolicy: &kubeflowv1.ElasticPolicy{
+						RDZVBackend: &backendETCD,
+					},
+					PyTorchReplicaSpecs: map[kubeflowv1.ReplicaType]*kubeflowv1.ReplicaSpec{
+						kubeflowv1.PyTorchJobReplicaTypeWorker: newReplicaSpec(),
+					},
+				},
+			},
+			expected: nil,
+		},
+	}
+
+	for _, test := range tests {
+		actual, err := GetMasterEnvVarGenerator().Generate(test.job)
+		gomega.Expect(err).To(gomega.BeNil())
+		if test.expected == nil {
+			gomega.Expect(actual).To(gomega.BeEmpty())
+		} else {
+			gomega.Expect(actual).To(gomega.Equal(test.expected))
+		}
+	}
+}
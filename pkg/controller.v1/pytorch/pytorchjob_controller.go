@@ -23,15 +23,16 @@ import (
 	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
 	trainingoperatorcommon "github.com/kubeflow/training-operator/pkg/common"
 	"github.com/kubeflow/training-operator/pkg/common/util"
+	"github.com/kubeflow/training-operator/pkg/config"
 	"github.com/kubeflow/training-operator/pkg/controller.v1/common"
 	"github.com/kubeflow/training-operator/pkg/controller.v1/control"
 	"github.com/kubeflow/training-operator/pkg/controller.v1/expectation"
+	"github.com/kubeflow/training-operator/pkg/controller.v1/registry"
+	"github.com/kubeflow/training-operator/pkg/core"
 	commonutil "github.com/kubeflow/training-operator/pkg/util"
 
 	"github.com/go-logr/logr"
-	"github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -42,17 +43,15 @@ import (
 	"k8s.io/client-go/informers"
 	kubeclientset "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
-	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/source"
-	schedulerpluginsv1alpha1 "sigs.k8s.io/scheduler-plugins/apis/scheduling/v1alpha1"
-	"volcano.sh/apis/pkg/apis/scheduling/v1beta1"
 )
 
 const (
@@ -60,13 +59,13 @@ const (
 )
 
 // NewReconciler creates a PyTorchJob Reconciler
-func NewReconciler(mgr manager.Manager, gangSchedulingSetupFunc common.GangSchedulingSetupFunc) *PyTorchJobReconciler {
+func NewReconciler(mgr manager.Manager, gangSchedulingSetupFunc common.GangSchedulingSetupFunc, options common.JobControllerOptions) *PyTorchJobReconciler {
 	r := &PyTorchJobReconciler{
 		Client:    mgr.GetClient(),
 		Scheme:    mgr.GetScheme(),
-		recorder:  mgr.GetEventRecorderFor(controllerName),
+		recorder:  commonutil.NewDeduplicatingRecorder(commonutil.NewEventRecorderFor(mgr, controllerName), config.Config.EventDeduplicationWindow),
 		apiReader: mgr.GetAPIReader(),
-		Log:       log.Log,
+		Log:       ctrl.Log.WithName("controllers").WithName(kubeflowv1.PyTorchJobKind),
 	}
 
 	// Create clients
@@ -79,13 +78,18 @@ func NewReconciler(mgr manager.Manager, gangSchedulingSetupFunc common.GangSched
 	r.JobController = common.JobController{
 		Controller:                  r,
 		Expectations:                expectation.NewControllerExpectations(),
-		WorkQueue:                   &util.FakeWorkQueue{},
+		WorkQueue:                   util.NewRequeueWorkQueue(),
 		Recorder:                    r.recorder,
 		KubeClientSet:               kubeClientSet,
 		PriorityClassLister:         priorityClassInformer.Lister(),
 		PriorityClassInformerSynced: priorityClassInformer.Informer().HasSynced,
 		PodControl:                  control.RealPodControl{KubeClient: kubeClientSet, Recorder: r.recorder},
 		ServiceControl:              control.RealServiceControl{KubeClient: kubeClientSet, Recorder: r.recorder},
+		PodExecControl:              control.RealPodExecControl{Config: cfg, KubeClient: kubeClientSet},
+		JobRegistry:                 registry.Default,
+		JobControllerOptions:        options,
+		Clock:                       commonutil.NewClock(clock.RealClock{}, config.Config.ClockSkewTolerance),
+		OOMKillTracker:              common.NewOOMKillTracker(time.Now()),
 	}
 
 	gangSchedulingSetupFunc(&r.JobController)
@@ -107,11 +111,23 @@ type PyTorchJobReconciler struct {
 // +kubebuilder:rbac:groups=kubeflow.org,resources=pytorchjobs/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=kubeflow.org,resources=pytorchjobs/finalizers,verbs=update
 // +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups="",resources=pods/exec,verbs=create
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=resourcequotas,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;patch;delete
 // +kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=scheduling.volcano.sh,resources=podgroups,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=scheduling.x-k8s.io,resources=podgroups,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=ray.io,resources=rayclusters,verbs=get;create;delete
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch;create;update
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;patch;delete
 // +kubebuilder:rbac:groups="",resources=events,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;update;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -122,16 +138,21 @@ type PyTorchJobReconciler struct {
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.8.3/pkg/reconcile
 func (r *PyTorchJobReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	_ = log.FromContext(ctx)
-	logger := r.Log.WithValues(kubeflowv1.PyTorchJobSingular, req.NamespacedName)
+	defer trainingoperatorcommon.ReconcileDurationObserve(r.GetFrameworkName(), time.Now())
+	logger := r.Log.WithValues("kind", kubeflowv1.PyTorchJobKind, "namespace", req.Namespace, "name", req.Name)
 
 	pytorchjob := &kubeflowv1.PyTorchJob{}
 	err := r.Get(ctx, req.NamespacedName, pytorchjob)
 	if err != nil {
-		logger.Info(err.Error(), "unable to fetch PyTorchJob", req.NamespacedName.String())
+		logger.Info("unable to fetch PyTorchJob", "error", err.Error())
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
+	if !common.ReconciledByThisController(pytorchjob) {
+		logger.V(1).Info("Skipping PyTorchJob pinned to another controller", "controller-identity", pytorchjob.GetAnnotations()[kubeflowv1.ControllerIdentityAnnotation])
+		return ctrl.Result{}, nil
+	}
+
 	if manager := r.ManagedByExternalController(pytorchjob.Spec.RunPolicy.ManagedBy); manager != nil {
 		logger.Info("Skipping PyTorchJob managed by a custom controller", "managed-by", manager)
 		return ctrl.Result{}, nil
@@ -166,9 +187,9 @@ func (r *PyTorchJobReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		logger.Error(err, "Reconcile PyTorchJob error")
 		return ctrl.Result{}, err
 	}
-	t, err := util.DurationUntilExpireTime(&pytorchjob.Spec.RunPolicy, pytorchjob.Status)
+	t, err := util.DurationUntilExpireTime(&pytorchjob.Spec.RunPolicy, pytorchjob.Status, r.Clock)
 	if err != nil {
-		logrus.Warnf("Reconcile PyTorchJob error %v", err)
+		logger.Error(err, "Reconcile PyTorchJob error")
 		return ctrl.Result{}, err
 	}
 	if t >= 0 {
@@ -181,8 +202,9 @@ func (r *PyTorchJobReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 // SetupWithManager sets up the controller with the Manager.
 func (r *PyTorchJobReconciler) SetupWithManager(mgr ctrl.Manager, controllerThreads int) error {
 	c, err := controller.New(r.ControllerName(), mgr, controller.Options{
-		Reconciler:              r,
+		Reconciler:              common.NewDrainingReconciler(r, r.Clock),
 		MaxConcurrentReconciles: controllerThreads,
+		RateLimiter:             common.NewRateLimiter(),
 	})
 	if err != nil {
 		return err
@@ -194,6 +216,26 @@ func (r *PyTorchJobReconciler) SetupWithManager(mgr ctrl.Manager, controllerThre
 	); err != nil {
 		return err
 	}
+	// keep the job registry shared by all controllers up to date
+	if err = registry.Default.Watch(context.Background(), mgr.GetCache(), &kubeflowv1.PyTorchJob{}); err != nil {
+		return err
+	}
+	// requeue the jobs added to the work queue of the common job controller
+	if err = r.WatchWorkQueue(c); err != nil {
+		return err
+	}
+	// requeue the jobs waiting for the objects referenced by the templates of their pods
+	if err = r.WatchDependencies(mgr, c); err != nil {
+		return err
+	}
+	// requeue the jobs blocked by the resource quotas of their namespace
+	if err = r.WatchResourceQuotas(mgr, c); err != nil {
+		return err
+	}
+	// requeue the jobs with pods bound to a node which starts being reclaimed
+	if err = r.WatchPreemptedNodes(mgr, c); err != nil {
+		return err
+	}
 	// inject watching for job related pod
 	if err = c.Watch(source.Kind[*corev1.Pod](mgr.GetCache(), &corev1.Pod{},
 		handler.TypedEnqueueRequestForOwner[*corev1.Pod](mgr.GetScheme(), mgr.GetRESTMapper(), &kubeflowv1.PyTorchJob{}, handler.OnlyControllerOwner()),
@@ -206,25 +248,9 @@ func (r *PyTorchJobReconciler) SetupWithManager(mgr ctrl.Manager, controllerThre
 		util.OnDependentFuncs[*corev1.Service](r.Scheme, r.Expectations, &r.JobController))); err != nil {
 		return err
 	}
-	// skip watching volcano PodGroup if volcano PodGroup is not installed
-	if _, err = mgr.GetRESTMapper().RESTMapping(schema.GroupKind{Group: v1beta1.GroupName, Kind: "PodGroup"},
-		v1beta1.SchemeGroupVersion.Version); err == nil {
-		// inject watching for job related volcano PodGroup
-		if err = c.Watch(source.Kind[*v1beta1.PodGroup](mgr.GetCache(), &v1beta1.PodGroup{},
-			handler.TypedEnqueueRequestForOwner[*v1beta1.PodGroup](mgr.GetScheme(), mgr.GetRESTMapper(), &kubeflowv1.PyTorchJob{}, handler.OnlyControllerOwner()),
-			util.OnDependentFuncs[*v1beta1.PodGroup](r.Scheme, r.Expectations, &r.JobController))); err != nil {
-			return err
-		}
-	}
-	// skip watching scheduler-plugins PodGroup if scheduler-plugins PodGroup is not installed
-	if _, err = mgr.GetRESTMapper().RESTMapping(schema.GroupKind{Group: schedulerpluginsv1alpha1.SchemeGroupVersion.Group, Kind: "PodGroup"},
-		schedulerpluginsv1alpha1.SchemeGroupVersion.Version); err == nil {
-		// inject watching for job related scheduler-plugins PodGroup
-		if err = c.Watch(source.Kind[*schedulerpluginsv1alpha1.PodGroup](mgr.GetCache(), &schedulerpluginsv1alpha1.PodGroup{},
-			handler.TypedEnqueueRequestForOwner[*schedulerpluginsv1alpha1.PodGroup](mgr.GetScheme(), mgr.GetRESTMapper(), &kubeflowv1.PyTorchJob{}, handler.OnlyControllerOwner()),
-			util.OnDependentFuncs[*schedulerpluginsv1alpha1.PodGroup](r.Scheme, r.Expectations, &r.JobController))); err != nil {
-			return err
-		}
+	// inject watching for job related PodGroup of the gang scheduler
+	if err = util.WatchPodGroups(mgr, c, r.Scheme, &kubeflowv1.PyTorchJob{}, &r.JobController); err != nil {
+		return err
 	}
 	return nil
 }
@@ -254,9 +280,9 @@ func (r *PyTorchJobReconciler) GetJobFromInformerCache(namespace, name string) (
 	err := r.Get(context.Background(), types.NamespacedName{Namespace: namespace, Name: name}, job)
 	if err != nil {
 		if errors.IsNotFound(err) {
-			logrus.Error(err, "pytorch job not found", "namespace", namespace, "name", name)
+			r.Log.Error(err, "pytorch job not found", "namespace", namespace, "name", name)
 		} else {
-			logrus.Error(err, "failed to get job from api-server", "namespace", namespace, "name", name)
+			r.Log.Error(err, "failed to get job from api-server", "namespace", namespace, "name", name)
 		}
 		return nil, err
 	}
@@ -269,9 +295,9 @@ func (r *PyTorchJobReconciler) GetJobFromAPIClient(namespace, name string) (meta
 	err := r.apiReader.Get(context.Background(), types.NamespacedName{Namespace: namespace, Name: name}, job)
 	if err != nil {
 		if errors.IsNotFound(err) {
-			logrus.Error(err, "pytorch job not found", "namespace", namespace, "name", name)
+			r.Log.Error(err, "pytorch job not found", "namespace", namespace, "name", name)
 		} else {
-			logrus.Error(err, "failed to get job from api-server", "namespace", namespace, "name", name)
+			r.Log.Error(err, "failed to get job from api-server", "namespace", namespace, "name", name)
 		}
 		return nil, err
 	}
@@ -292,7 +318,7 @@ func (r *PyTorchJobReconciler) GetPodsForJob(obj interface{}) ([]*corev1.Pod, er
 		return nil, err
 	}
 
-	return util.JobControlledPodList(podlist.Items, job), nil
+	return r.ClaimPods(job, util.ConvertPodList(podlist.Items))
 }
 
 func (r *PyTorchJobReconciler) GetServicesForJob(obj interface{}) ([]*corev1.Service, error) {
@@ -319,12 +345,12 @@ func (r *PyTorchJobReconciler) DeleteJob(job interface{}) error {
 		return fmt.Errorf("%+v is not a type of PyTorchJob", job)
 	}
 	if err := r.Delete(context.Background(), pytorchjob); err != nil {
-		r.recorder.Eventf(pytorchjob, corev1.EventTypeWarning, control.FailedDeletePodReason, "Error deleting: %v", err)
-		logrus.Error(err, "failed to delete job", "namespace", pytorchjob.Namespace, "name", pytorchjob.Name)
+		r.recorder.Eventf(pytorchjob, corev1.EventTypeWarning, commonutil.FailedDeletePodReason, "Error deleting: %v", err)
+		commonutil.LoggerForJob(pytorchjob).Error(err, "failed to delete job")
 		return err
 	}
-	r.recorder.Eventf(pytorchjob, corev1.EventTypeNormal, control.SuccessfulDeletePodReason, "Deleted job: %v", pytorchjob.Name)
-	logrus.Info("job deleted", "namespace", pytorchjob.Namespace, "name", pytorchjob.Name)
+	r.recorder.Eventf(pytorchjob, corev1.EventTypeNormal, commonutil.SuccessfulDeletePodReason, "Deleted job: %v", pytorchjob.Name)
+	commonutil.LoggerForJob(pytorchjob).Info("job deleted")
 	trainingoperatorcommon.DeletedJobsCounterInc(pytorchjob.Namespace, r.GetFrameworkName())
 	return nil
 }
@@ -355,13 +381,26 @@ func (r *PyTorchJobReconciler) UpdateJobStatus(job interface{},
 
 	logger := commonutil.LoggerForJob(pytorchjob)
 
+	successPolicy := kubeflowv1.SuccessPolicyDefault
+	if pytorchjob.Spec.SuccessPolicy != nil {
+		successPolicy = *pytorchjob.Spec.SuccessPolicy
+	}
+	worker0Completed := false
+	if successPolicy == kubeflowv1.SuccessPolicyChiefOrMaster && !ContainsMasterSpec(replicas) {
+		worker0Completed, err = r.isWorker0Completed(pytorchjob, replicas)
+		if err != nil {
+			logger.Error(err, "check if worker 0 completed error")
+			return err
+		}
+	}
+
 	// Set StartTime.
 	if jobStatus.StartTime == nil {
-		now := metav1.Now()
+		now := r.Clock.MetaNow()
 		jobStatus.StartTime = &now
 		// enqueue a sync to check if job past ActiveDeadlineSeconds
 		if pytorchjob.Spec.RunPolicy.ActiveDeadlineSeconds != nil {
-			logger.Infof("Job with ActiveDeadlineSeconds will sync after %d seconds", *pytorchjob.Spec.RunPolicy.ActiveDeadlineSeconds)
+			logger.Info("Job with ActiveDeadlineSeconds will sync after the deadline", "activeDeadlineSeconds", *pytorchjob.Spec.RunPolicy.ActiveDeadlineSeconds)
 			r.WorkQueue.AddAfter(pytorchjobKey, time.Duration(*pytorchjob.Spec.RunPolicy.ActiveDeadlineSeconds)*time.Second)
 		}
 	}
@@ -377,50 +416,48 @@ func (r *PyTorchJobReconciler) UpdateJobStatus(job interface{},
 		failed := status.Failed
 		specReplicas := *spec.Replicas
 
-		logrus.Infof("PyTorchJob=%s, ReplicaType=%s expected=%d, running=%d, succeeded=%d, failed=%d, Replicas=%d",
-			pytorchjob.Name, rtype, expected, running, succeeded, failed, specReplicas)
+		logger.Info("Replica status", "replicaType", rtype, "expected", expected, "running", running, "succeeded", succeeded, "failed", failed, "replicas", specReplicas)
 
 		if ContainsMasterSpec(replicas) {
 			if rtype == kubeflowv1.PyTorchJobReplicaTypeMaster {
 				if running > 0 {
 					msg := fmt.Sprintf("PyTorchJob %s is running.", pytorchjob.Name)
-					commonutil.UpdateJobConditions(jobStatus, kubeflowv1.JobRunning, corev1.ConditionTrue, commonutil.NewReason(kubeflowv1.PyTorchJobKind, commonutil.JobRunningReason), msg)
+					commonutil.UpdateJobConditions(jobStatus, kubeflowv1.JobRunning, corev1.ConditionTrue, commonutil.NewReason(kubeflowv1.PyTorchJobKind, commonutil.JobRunningReason), msg, r.Clock)
 				}
-				// when master is succeed, the job is finished.
-				if expected == 0 {
-					msg := fmt.Sprintf("PyTorchJob %s is successfully completed.", pytorchjob.Name)
-					logrus.Info(msg)
+				// when master is succeed, the job is finished unless `SuccessPolicyAllWorkers`
+				// success policy is used and some workers have not succeeded yet.
+				if expected == 0 && successPolicy == kubeflowv1.SuccessPolicyAllWorkers &&
+					!commonutil.AllReplicasSucceeded(replicas, *jobStatus, kubeflowv1.PyTorchJobReplicaTypeWorker) {
+					logger.Info("Waiting for all workers to succeed", "successPolicy", kubeflowv1.SuccessPolicyAllWorkers)
+				} else if expected == 0 {
+					msg := fmt.Sprintf("PyTorchJob %s is successfully completed. %s",
+						pytorchjob.Name, commonutil.SuccessPolicyMessage(pytorchjob.Spec.SuccessPolicy))
+					logger.Info(msg)
 					r.Recorder.Event(pytorchjob, corev1.EventTypeNormal, commonutil.NewReason(kubeflowv1.PyTorchJobKind, commonutil.JobSucceededReason), msg)
-					if jobStatus.CompletionTime == nil {
-						now := metav1.Now()
-						jobStatus.CompletionTime = &now
+					if commonutil.FinishJob(jobStatus, kubeflowv1.JobSucceeded, commonutil.NewReason(kubeflowv1.PyTorchJobKind, commonutil.JobSucceededReason), msg, r.Clock) {
+						trainingoperatorcommon.SuccessfulJobsCounterInc(pytorchjob.Namespace, r.GetFrameworkName())
 					}
-					commonutil.UpdateJobConditions(jobStatus, kubeflowv1.JobSucceeded, corev1.ConditionTrue, commonutil.NewReason(kubeflowv1.PyTorchJobKind, commonutil.JobSucceededReason), msg)
-					trainingoperatorcommon.SuccessfulJobsCounterInc(pytorchjob.Namespace, r.GetFrameworkName())
 					return nil
 				}
 			}
 		} else {
 			if rtype == kubeflowv1.PyTorchJobReplicaTypeWorker {
-				// TODO(gaocegege): Support SuccessPolicy
-				// Leave a succeeded condition for the following two cases:
+				// Leave a succeeded condition for the following three cases:
 				// 1. If all workers are succeeded.
 				// 2. If `ElasticPolicy` is not nil and any worker has completed.
-				if expected == 0 || (pytorchjob.Spec.ElasticPolicy != nil && succeeded > 0) {
-					msg := fmt.Sprintf("PyTorchJob %s/%s successfully completed.",
-						pytorchjob.Namespace, pytorchjob.Name)
+				// 3. If `SuccessPolicyChiefOrMaster` success policy is used and worker 0 has completed.
+				if expected == 0 || (pytorchjob.Spec.ElasticPolicy != nil && succeeded > 0) || worker0Completed {
+					msg := fmt.Sprintf("PyTorchJob %s/%s successfully completed. %s",
+						pytorchjob.Namespace, pytorchjob.Name, commonutil.SuccessPolicyMessage(pytorchjob.Spec.SuccessPolicy))
 					r.recorder.Event(pytorchjob, corev1.EventTypeNormal, commonutil.NewReason(kubeflowv1.PyTorchJobKind, commonutil.JobSucceededReason), msg)
-					if jobStatus.CompletionTime == nil {
-						now := metav1.Now()
-						jobStatus.CompletionTime = &now
+					if commonutil.FinishJob(jobStatus, kubeflowv1.JobSucceeded, commonutil.NewReason(kubeflowv1.PyTorchJobKind, commonutil.JobSucceededReason), msg, r.Clock) {
+						trainingoperatorcommon.SuccessfulJobsCounterInc(pytorchjob.Namespace, r.GetFrameworkName())
 					}
-					commonutil.UpdateJobConditions(jobStatus, kubeflowv1.JobSucceeded, corev1.ConditionTrue, commonutil.NewReason(kubeflowv1.PyTorchJobKind, commonutil.JobSucceededReason), msg)
-					trainingoperatorcommon.SuccessfulJobsCounterInc(pytorchjob.Namespace, r.GetFrameworkName())
 				} else if running > 0 {
 					// Some workers are still running, leave a running condition.
 					msg := fmt.Sprintf("PyTorchJob %s/%s is running.",
 						pytorchjob.Namespace, pytorchjob.Name)
-					commonutil.UpdateJobConditions(jobStatus, kubeflowv1.JobRunning, corev1.ConditionTrue, commonutil.NewReason(kubeflowv1.PyTorchJobKind, commonutil.JobRunningReason), msg)
+					commonutil.UpdateJobConditions(jobStatus, kubeflowv1.JobRunning, corev1.ConditionTrue, commonutil.NewReason(kubeflowv1.PyTorchJobKind, commonutil.JobRunningReason), msg, r.Clock)
 				}
 			}
 		}
@@ -429,17 +466,14 @@ func (r *PyTorchJobReconciler) UpdateJobStatus(job interface{},
 			if spec.RestartPolicy != kubeflowv1.RestartPolicyNever {
 				msg := fmt.Sprintf("PyTorchJob %s is restarting because %d %s replica(s) failed.", pytorchjob.Name, failed, rtype)
 				r.Recorder.Event(pytorchjob, corev1.EventTypeWarning, commonutil.NewReason(kubeflowv1.PyTorchJobKind, commonutil.JobRestartingReason), msg)
-				commonutil.UpdateJobConditions(jobStatus, kubeflowv1.JobRestarting, corev1.ConditionTrue, commonutil.NewReason(kubeflowv1.PyTorchJobKind, commonutil.JobRestartingReason), msg)
+				commonutil.UpdateJobConditions(jobStatus, kubeflowv1.JobRestarting, corev1.ConditionTrue, commonutil.NewReason(kubeflowv1.PyTorchJobKind, commonutil.JobRestartingReason), msg, r.Clock)
 				trainingoperatorcommon.RestartedJobsCounterInc(pytorchjob.Namespace, r.GetFrameworkName())
 			} else {
 				msg := fmt.Sprintf("PyTorchJob %s is failed because %d %s replica(s) failed.", pytorchjob.Name, failed, rtype)
 				r.Recorder.Event(pytorchjob, corev1.EventTypeNormal, commonutil.NewReason(kubeflowv1.PyTorchJobKind, commonutil.JobFailedReason), msg)
-				if jobStatus.CompletionTime == nil {
-					now := metav1.Now()
-					jobStatus.CompletionTime = &now
+				if commonutil.FinishJob(jobStatus, kubeflowv1.JobFailed, commonutil.NewReason(kubeflowv1.PyTorchJobKind, commonutil.JobFailedReason), msg, r.Clock) {
+					trainingoperatorcommon.FailedJobsCounterInc(pytorchjob.Namespace, r.GetFrameworkName())
 				}
-				commonutil.UpdateJobConditions(jobStatus, kubeflowv1.JobFailed, corev1.ConditionTrue, commonutil.NewReason(kubeflowv1.PyTorchJobKind, commonutil.JobFailedReason), msg)
-				trainingoperatorcommon.FailedJobsCounterInc(pytorchjob.Namespace, r.GetFrameworkName())
 			}
 		}
 	}
@@ -454,6 +488,28 @@ func ContainsMasterSpec(replicas map[kubeflowv1.ReplicaType]*kubeflowv1.ReplicaS
 	return false
 }
 
+// isWorker0Completed returns true if the pod of worker 0 has succeeded.
+func (r *PyTorchJobReconciler) isWorker0Completed(pytorchjob *kubeflowv1.PyTorchJob, replicas map[kubeflowv1.ReplicaType]*kubeflowv1.ReplicaSpec) (bool, error) {
+	spec, ok := replicas[kubeflowv1.PyTorchJobReplicaTypeWorker]
+	if !ok {
+		return false, nil
+	}
+	rt := strings.ToLower(string(kubeflowv1.PyTorchJobReplicaTypeWorker))
+	pods, err := r.GetPodsForJob(pytorchjob)
+	if err = common.IgnoreAdoptionDeferred(err); err != nil {
+		return false, err
+	}
+	pods, err = r.JobController.FilterPodsForReplicaType(pods, rt)
+	if err != nil {
+		return false, err
+	}
+	podSlices := r.GetPodSlices(pods, int(*spec.Replicas), commonutil.LoggerForReplica(pytorchjob, rt))
+	if len(podSlices) == 0 || len(podSlices[0]) != 1 {
+		return false, nil
+	}
+	return podSlices[0][0].Status.Phase == corev1.PodSucceeded, nil
+}
+
 // UpdateJobStatusInApiServer updates the job status in to cluster.
 func (r *PyTorchJobReconciler) UpdateJobStatusInApiServer(job interface{}, jobStatus *kubeflowv1.JobStatus) error {
 	if jobStatus.ReplicaStatuses == nil {
@@ -466,19 +522,13 @@ func (r *PyTorchJobReconciler) UpdateJobStatusInApiServer(job interface{}, jobSt
 		return fmt.Errorf("%+v is not a type of PyTorchJob", job)
 	}
 
-	// Job status passed in differs with status in job, update in basis of the passed in one.
-	if !equality.Semantic.DeepEqual(&pytorchjob.Status, jobStatus) {
-		pytorchjob = pytorchjob.DeepCopy()
-		pytorchjob.Status = *jobStatus.DeepCopy()
-	}
-
-	result := r.Status().Update(context.Background(), pytorchjob)
+	// Patch only the difference to the status through the status subresource, so that
+	// updates do not overwrite the spec and are retried on conflicts.
+	result := util.PatchJobStatus(context.Background(), r.Client, r.apiReader, pytorchjob,
+		func(j *kubeflowv1.PyTorchJob) *kubeflowv1.JobStatus { return &j.Status }, jobStatus)
 
 	if result != nil {
-		r.Log.WithValues("pytorchjob", types.NamespacedName{
-			Namespace: pytorchjob.GetNamespace(),
-			Name:      pytorchjob.GetName(),
-		})
+		commonutil.LoggerForJob(pytorchjob).Error(result, "failed to update the job status in the API server")
 		return result
 	}
 
@@ -487,8 +537,16 @@ func (r *PyTorchJobReconciler) UpdateJobStatusInApiServer(job interface{}, jobSt
 
 // SetClusterSpec sets the cluster spec and init container for the pod
 func (r *PyTorchJobReconciler) SetClusterSpec(job interface{}, podTemplate *corev1.PodTemplateSpec, rtype, index string) error {
-	if err := setPodEnv(job, podTemplate, rtype, index); err != nil {
-		return err
+	pytorchjob, ok := job.(*kubeflowv1.PyTorchJob)
+	if !ok {
+		return fmt.Errorf("%v is not a type of PyTorchJob", job)
+	}
+	core.SetCommonEnv(podTemplate, kubeflowv1.PyTorchJobDefaultContainerName, pytorchjob.Spec.CommonEnv, pytorchjob.Spec.CommonEnvFrom)
+	common.SetNetworkTuningEnv(podTemplate, kubeflowv1.PyTorchJobDefaultContainerName, &pytorchjob.Spec.RunPolicy)
+	if !common.IsClusterSpecEnvDisabled(&pytorchjob.Spec.RunPolicy) {
+		if err := setPodEnv(job, podTemplate, rtype, index); err != nil {
+			return err
+		}
 	}
 	if err := setInitContainer(job, podTemplate, rtype, index, r.Log); err != nil {
 		return err
@@ -513,15 +571,56 @@ func (r *PyTorchJobReconciler) GetDefaultContainerPortName() string {
 	return kubeflowv1.PyTorchJobDefaultPortName
 }
 
+// GetJobDependencies returns the jobs the PyTorchJob depends on.
+func (r *PyTorchJobReconciler) GetJobDependencies(job interface{}) []kubeflowv1.JobReference {
+	pytorchJob, ok := job.(*kubeflowv1.PyTorchJob)
+	if !ok {
+		return nil
+	}
+	return pytorchJob.Spec.DependsOn
+}
+
+// GetTensorBoard returns the TensorBoard requested by the PyTorchJob.
+func (r *PyTorchJobReconciler) GetTensorBoard(job interface{}) *kubeflowv1.TensorBoardSpec {
+	pytorchJob, ok := job.(*kubeflowv1.PyTorchJob)
+	if !ok {
+		return nil
+	}
+	return pytorchJob.Spec.TensorBoard
+}
+
+// GetOutputs returns the outputs declared by the PyTorchJob.
+func (r *PyTorchJobReconciler) GetOutputs(job interface{}) []kubeflowv1.OutputSpec {
+	pytorchJob, ok := job.(*kubeflowv1.PyTorchJob)
+	if !ok {
+		return nil
+	}
+	return pytorchJob.Spec.Outputs
+}
+
+// GetDatasets returns the datasets declared by the PyTorchJob.
+func (r *PyTorchJobReconciler) GetDatasets(job interface{}) []kubeflowv1.DatasetSpec {
+	pytorchJob, ok := job.(*kubeflowv1.PyTorchJob)
+	if !ok {
+		return nil
+	}
+	return pytorchJob.Spec.Datasets
+}
+
 // onOwnerCreateFunc modify creation condition.
 func (r *PyTorchJobReconciler) onOwnerCreateFunc() func(createEvent event.TypedCreateEvent[*kubeflowv1.PyTorchJob]) bool {
 	return func(e event.TypedCreateEvent[*kubeflowv1.PyTorchJob]) bool {
+		if !common.ReconciledByThisController(e.Object) {
+			return false
+		}
 		pytorchjob := e.Object
 		r.Scheme.Default(pytorchjob)
 		msg := fmt.Sprintf("PyTorchJob %s is created.", e.Object.GetName())
-		logrus.Info(msg)
+		commonutil.LoggerForJob(pytorchjob).Info(msg)
 		trainingoperatorcommon.CreatedJobsCounterInc(pytorchjob.Namespace, r.GetFrameworkName())
-		commonutil.UpdateJobConditions(&pytorchjob.Status, kubeflowv1.JobCreated, corev1.ConditionTrue, commonutil.NewReason(kubeflowv1.PyTorchJobKind, commonutil.JobCreatedReason), msg)
+		oldStatus := pytorchjob.Status.DeepCopy()
+		commonutil.UpdateJobConditions(&pytorchjob.Status, kubeflowv1.JobCreated, corev1.ConditionTrue, commonutil.NewReason(kubeflowv1.PyTorchJobKind, commonutil.JobCreatedReason), msg, r.Clock)
+		r.RecordAuditEvents(pytorchjob, oldStatus, &pytorchjob.Status)
 		return true
 	}
 }
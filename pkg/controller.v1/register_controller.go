@@ -16,6 +16,9 @@ package controller_v1
 
 import (
 	"fmt"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 
 	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
@@ -27,31 +30,32 @@ import (
 	tensorflowcontroller "github.com/kubeflow/training-operator/pkg/controller.v1/tensorflow"
 	xgboostcontroller "github.com/kubeflow/training-operator/pkg/controller.v1/xgboost"
 
+	"k8s.io/apimachinery/pkg/api/meta"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 )
 
 const ErrTemplateSchemeNotSupported = "scheme %s is not supported yet"
 
-type ReconcilerSetupFunc func(manager manager.Manager, gangSchedulingSetupFunc common.GangSchedulingSetupFunc, controllerThreads int) error
+type ReconcilerSetupFunc func(manager manager.Manager, gangSchedulingSetupFunc common.GangSchedulingSetupFunc, options common.JobControllerOptions, controllerThreads int) error
 
 var SupportedSchemeReconciler = map[string]ReconcilerSetupFunc{
-	kubeflowv1.TFJobKind: func(mgr manager.Manager, gangSchedulingSetupFunc common.GangSchedulingSetupFunc, controllerThreads int) error {
-		return tensorflowcontroller.NewReconciler(mgr, gangSchedulingSetupFunc).SetupWithManager(mgr, controllerThreads)
+	kubeflowv1.TFJobKind: func(mgr manager.Manager, gangSchedulingSetupFunc common.GangSchedulingSetupFunc, options common.JobControllerOptions, controllerThreads int) error {
+		return tensorflowcontroller.NewReconciler(mgr, gangSchedulingSetupFunc, options).SetupWithManager(mgr, controllerThreads)
 	},
-	kubeflowv1.PyTorchJobKind: func(mgr manager.Manager, gangSchedulingSetupFunc common.GangSchedulingSetupFunc, controllerThreads int) error {
-		return pytorchcontroller.NewReconciler(mgr, gangSchedulingSetupFunc).SetupWithManager(mgr, controllerThreads)
+	kubeflowv1.PyTorchJobKind: func(mgr manager.Manager, gangSchedulingSetupFunc common.GangSchedulingSetupFunc, options common.JobControllerOptions, controllerThreads int) error {
+		return pytorchcontroller.NewReconciler(mgr, gangSchedulingSetupFunc, options).SetupWithManager(mgr, controllerThreads)
 	},
-	kubeflowv1.XGBoostJobKind: func(mgr manager.Manager, gangSchedulingSetupFunc common.GangSchedulingSetupFunc, controllerThreads int) error {
-		return xgboostcontroller.NewReconciler(mgr, gangSchedulingSetupFunc).SetupWithManager(mgr, controllerThreads)
+	kubeflowv1.XGBoostJobKind: func(mgr manager.Manager, gangSchedulingSetupFunc common.GangSchedulingSetupFunc, options common.JobControllerOptions, controllerThreads int) error {
+		return xgboostcontroller.NewReconciler(mgr, gangSchedulingSetupFunc, options).SetupWithManager(mgr, controllerThreads)
 	},
-	kubeflowv1.MPIJobKind: func(mgr manager.Manager, gangSchedulingSetupFunc common.GangSchedulingSetupFunc, controllerThreads int) error {
-		return mpicontroller.NewReconciler(mgr, gangSchedulingSetupFunc).SetupWithManager(mgr, controllerThreads)
+	kubeflowv1.MPIJobKind: func(mgr manager.Manager, gangSchedulingSetupFunc common.GangSchedulingSetupFunc, options common.JobControllerOptions, controllerThreads int) error {
+		return mpicontroller.NewReconciler(mgr, gangSchedulingSetupFunc, options).SetupWithManager(mgr, controllerThreads)
 	},
-	kubeflowv1.PaddleJobKind: func(mgr manager.Manager, gangSchedulingSetupFunc common.GangSchedulingSetupFunc, controllerThreads int) error {
-		return paddlecontroller.NewReconciler(mgr, gangSchedulingSetupFunc).SetupWithManager(mgr, controllerThreads)
+	kubeflowv1.PaddleJobKind: func(mgr manager.Manager, gangSchedulingSetupFunc common.GangSchedulingSetupFunc, options common.JobControllerOptions, controllerThreads int) error {
+		return paddlecontroller.NewReconciler(mgr, gangSchedulingSetupFunc, options).SetupWithManager(mgr, controllerThreads)
 	},
-	kubeflowv1.JAXJobKind: func(mgr manager.Manager, gangSchedulingSetupFunc common.GangSchedulingSetupFunc, controllerThreads int) error {
-		return jaxcontroller.NewReconciler(mgr, gangSchedulingSetupFunc).SetupWithManager(mgr, controllerThreads)
+	kubeflowv1.JAXJobKind: func(mgr manager.Manager, gangSchedulingSetupFunc common.GangSchedulingSetupFunc, options common.JobControllerOptions, controllerThreads int) error {
+		return jaxcontroller.NewReconciler(mgr, gangSchedulingSetupFunc, options).SetupWithManager(mgr, controllerThreads)
 	},
 }
 
@@ -61,15 +65,33 @@ func (es *EnabledSchemes) String() string {
 	return strings.Join(*es, ",")
 }
 
-func (es *EnabledSchemes) Set(kind string) error {
-	kind = strings.ToLower(kind)
-	for supportedKind := range SupportedSchemeReconciler {
-		if strings.ToLower(supportedKind) == kind {
-			*es = append(*es, supportedKind)
-			return nil
+// Set enables the schemes of value, a kind or a comma separated list of kinds, case insensitive.
+// Nothing is enabled if any of the kinds is not supported.
+func (es *EnabledSchemes) Set(value string) error {
+	var kinds []string
+	for _, kind := range strings.Split(value, ",") {
+		kind = strings.TrimSpace(kind)
+		if kind == "" {
+			continue
+		}
+		supported := false
+		for supportedKind := range SupportedSchemeReconciler {
+			if strings.EqualFold(supportedKind, kind) {
+				kinds = append(kinds, supportedKind)
+				supported = true
+				break
+			}
+		}
+		if !supported {
+			return fmt.Errorf(ErrTemplateSchemeNotSupported, strings.ToLower(kind))
 		}
 	}
-	return fmt.Errorf(ErrTemplateSchemeNotSupported, kind)
+	for _, kind := range kinds {
+		if !slices.Contains(*es, kind) {
+			*es = append(*es, kind)
+		}
+	}
+	return nil
 }
 
 func (es *EnabledSchemes) FillAll() {
@@ -81,3 +103,61 @@ func (es *EnabledSchemes) FillAll() {
 func (es *EnabledSchemes) Empty() bool {
 	return len(*es) == 0
 }
+
+// Installed splits the schemes into the ones whose CRDs are installed in the cluster, according to
+// mapper, and the missing ones, so that the operator can run without the CRDs of the kinds it
+// doesn't reconcile.
+func (es *EnabledSchemes) Installed(mapper meta.RESTMapper) (installed, missing EnabledSchemes, err error) {
+	for _, kind := range *es {
+		_, err := mapper.RESTMapping(kubeflowv1.GroupVersion.WithKind(kind).GroupKind(), kubeflowv1.GroupVersion.Version)
+		if meta.IsNoMatchError(err) {
+			missing = append(missing, kind)
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		installed = append(installed, kind)
+	}
+	return installed, missing, nil
+}
+
+// ControllerThreads is the number of worker threads of the controllers by kind,
+// set as --controller-threads-per-kind=tfjob=4 --controller-threads-per-kind=pytorchjob=8, case insensitive.
+type ControllerThreads map[string]int
+
+func (ct ControllerThreads) String() string {
+	threads := make([]string, 0, len(ct))
+	for kind, n := range ct {
+		threads = append(threads, fmt.Sprintf("%s=%d", kind, n))
+	}
+	sort.Strings(threads)
+	return strings.Join(threads, ",")
+}
+
+func (ct ControllerThreads) Set(value string) error {
+	kind, threads, found := strings.Cut(value, "=")
+	if !found {
+		return fmt.Errorf("%q must be set as <kind>=<threads>", value)
+	}
+	n, err := strconv.Atoi(threads)
+	if err != nil || n <= 0 {
+		return fmt.Errorf("the number of threads of %s must be a positive integer, got %q", kind, threads)
+	}
+	for supportedKind := range SupportedSchemeReconciler {
+		if strings.EqualFold(supportedKind, kind) {
+			ct[supportedKind] = n
+			return nil
+		}
+	}
+	return fmt.Errorf(ErrTemplateSchemeNotSupported, kind)
+}
+
+// Get returns the number of worker threads of the controller of the kind,
+// or defaultThreads if it is not set for the kind.
+func (ct ControllerThreads) Get(kind string, defaultThreads int) int {
+	if n, ok := ct[kind]; ok {
+		return n
+	}
+	return defaultThreads
+}
@@ -17,6 +17,10 @@ package controller_v1
 import (
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"k8s.io/apimachinery/pkg/api/meta"
+
 	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
 )
 
@@ -74,3 +78,61 @@ func TestEnabledSchemes(t *testing.T) {
 		t.Error("Empty method returned true for fully registered EnabledSchemes")
 	}
 }
+
+func TestEnabledSchemesList(t *testing.T) {
+	es := EnabledSchemes{}
+	if err := es.Set("pytorchjob, MPIJob,pytorchjob"); err != nil {
+		t.Fatalf("failed to set the list of schemes: %v", err)
+	}
+	if diff := cmp.Diff(EnabledSchemes{kubeflowv1.PyTorchJobKind, kubeflowv1.MPIJobKind}, es); len(diff) != 0 {
+		t.Errorf("Unexpected schemes (-want,+got):\n%s", diff)
+	}
+	if es.Set("tfjob,dummyjob") == nil {
+		t.Error("successfully registered non-supported job dummyjob")
+	}
+	if len(es) != 2 {
+		t.Errorf("Expected no scheme of an invalid list to be enabled, got: %v", es)
+	}
+}
+
+func TestEnabledSchemesInstalled(t *testing.T) {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(kubeflowv1.GroupVersion.WithKind(kubeflowv1.PyTorchJobKind), meta.RESTScopeNamespace)
+	es := EnabledSchemes{}
+	es.FillAll()
+	installed, missing, err := es.Installed(mapper)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(EnabledSchemes{kubeflowv1.PyTorchJobKind}, installed); len(diff) != 0 {
+		t.Errorf("Unexpected installed schemes (-want,+got):\n%s", diff)
+	}
+	wantMissing := EnabledSchemes{kubeflowv1.TFJobKind, kubeflowv1.XGBoostJobKind, kubeflowv1.MPIJobKind, kubeflowv1.PaddleJobKind, kubeflowv1.JAXJobKind}
+	if diff := cmp.Diff(wantMissing, missing, cmpopts.SortSlices(func(a, b string) bool { return a < b })); len(diff) != 0 {
+		t.Errorf("Unexpected missing schemes (-want,+got):\n%s", diff)
+	}
+}
+
+func TestControllerThreads(t *testing.T) {
+	ct := ControllerThreads{}
+	if err := ct.Set("pytorchjob=8"); err != nil {
+		t.Errorf("failed to set the threads of PyTorchJob: %v", err)
+	}
+	if err := ct.Set("TFJob=4"); err != nil {
+		t.Errorf("failed to set the threads of TFJob: %v", err)
+	}
+	for _, value := range []string{"dummyjob=2", "tfjob", "tfjob=0", "tfjob=many"} {
+		if ct.Set(value) == nil {
+			t.Errorf("successfully set invalid threads %s", value)
+		}
+	}
+	if got := ct.String(); got != "PyTorchJob=8,TFJob=4" {
+		t.Errorf("unexpected ControllerThreads %s", got)
+	}
+	if got := ct.Get(kubeflowv1.PyTorchJobKind, 1); got != 8 {
+		t.Errorf("unexpected threads for %s: %d", kubeflowv1.PyTorchJobKind, got)
+	}
+	if got := ct.Get(kubeflowv1.MPIJobKind, 1); got != 1 {
+		t.Errorf("unexpected threads for %s: %d", kubeflowv1.MPIJobKind, got)
+	}
+}
@@ -0,0 +1,330 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package registry provides an in-memory registry of the jobs managed by the
+// training operator, shared by all the job controllers.
+package registry
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+)
+
+// Default is the registry shared by all the job controllers of the operator.
+var Default = New()
+
+// JobInfo is a snapshot of a job tracked by the Registry.
+type JobInfo struct {
+	Kind      string
+	Namespace string
+	Name      string
+	UID       types.UID
+
+	// Phase is the type of the latest condition of the job which is true,
+	// or empty if the job has no condition yet.
+	Phase kubeflowv1.JobConditionType
+
+	// PriorityClass is the priority class of the job set in its SchedulingPolicy.
+	PriorityClass string
+
+	// Queue is the queue of the job set in its SchedulingPolicy.
+	Queue string
+
+	// Suspended is true if the RunPolicy of the job requests it to be suspended, even if its
+	// pods are not deleted yet.
+	Suspended bool
+
+	// Admitted is true if the job was admitted by its queue of the operator, i.e. its Pending
+	// condition is false.
+	Admitted bool
+
+	// Resources is the sum of the resource requests of all the replicas of the job.
+	Resources corev1.ResourceList
+
+	// ActiveReplicas is the number of active replicas of all the replica types of the job.
+	ActiveReplicas int32
+
+	// APICalls is the number of API calls made by the operator on behalf of the job per verb,
+	// since the job was added to the registry.
+	APICalls map[string]int64
+
+	CreationTimestamp metav1.Time
+}
+
+// Reader is the read-only view of the Registry exposed to the job controllers.
+type Reader interface {
+	// Get returns the job identified by its kind, namespace and name.
+	Get(kind, namespace, name string) (JobInfo, bool)
+	// List returns all the jobs, ordered by creation timestamp.
+	List() []JobInfo
+	// ListByNamespace returns the jobs of the namespace, ordered by creation timestamp.
+	ListByNamespace(namespace string) []JobInfo
+}
+
+type jobKey struct {
+	kind      string
+	namespace string
+	name      string
+}
+
+// Listener is called on the changes of the jobs of the Registry, with a nil old job for the added
+// jobs and a nil new job for the deleted ones. It is called from the informers, so it must not block.
+type Listener func(old, new *JobInfo)
+
+// Registry tracks the jobs of all kinds from informer events. It is safe for concurrent use.
+type Registry struct {
+	mu        sync.RWMutex
+	jobs      map[jobKey]JobInfo
+	listeners []Listener
+}
+
+var _ Reader = &Registry{}
+
+// New returns an empty Registry.
+func New() *Registry {
+	return &Registry{jobs: make(map[jobKey]JobInfo)}
+}
+
+// Watch keeps the registry up to date with the jobs of the same kind as obj
+// from the events of the informer of the cache.
+func (r *Registry) Watch(ctx context.Context, c cache.Cache, obj client.Object) error {
+	informer, err := c.GetInformer(ctx, obj)
+	if err != nil {
+		return err
+	}
+	_, err = informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc:    r.OnAdd,
+		UpdateFunc: r.OnUpdate,
+		DeleteFunc: r.OnDelete,
+	})
+	return err
+}
+
+// OnAdd adds the job to the registry.
+func (r *Registry) OnAdd(obj interface{}) {
+	if info, ok := NewJobInfo(obj); ok {
+		r.set(info)
+	}
+}
+
+// OnUpdate updates the job in the registry.
+func (r *Registry) OnUpdate(_, newObj interface{}) {
+	r.OnAdd(newObj)
+}
+
+// OnDelete removes the job from the registry.
+func (r *Registry) OnDelete(obj interface{}) {
+	if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	info, ok := NewJobInfo(obj)
+	if !ok {
+		return
+	}
+	key := jobKey{kind: info.Kind, namespace: info.Namespace, name: info.Name}
+	r.mu.Lock()
+	old, ok := r.jobs[key]
+	delete(r.jobs, key)
+	listeners := r.listeners
+	r.mu.Unlock()
+	if ok {
+		notify(listeners, &old, nil)
+	}
+}
+
+func (r *Registry) set(info JobInfo) {
+	key := jobKey{kind: info.Kind, namespace: info.Namespace, name: info.Name}
+	r.mu.Lock()
+	old, ok := r.jobs[key]
+	// The API calls are not part of the job object, keep them across updates of the same job.
+	if ok && old.UID == info.UID {
+		info.APICalls = old.APICalls
+	}
+	r.jobs[key] = info
+	listeners := r.listeners
+	r.mu.Unlock()
+	if ok {
+		notify(listeners, &old, &info)
+	} else {
+		notify(listeners, nil, &info)
+	}
+}
+
+// AddListener adds a Listener called on the changes of the jobs from now on.
+func (r *Registry) AddListener(listener Listener) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.listeners = append(r.listeners, listener)
+}
+
+// notify calls the listeners with copies of the jobs, which share no map with the registry.
+func notify(listeners []Listener, old, new *JobInfo) {
+	for _, listener := range listeners {
+		listener(old.DeepCopy(), new.DeepCopy())
+	}
+}
+
+// AddAPICalls adds count to the API calls of the job with the verb, if it is in the registry.
+func (r *Registry) AddAPICalls(kind, namespace, name, verb string, count int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := jobKey{kind: kind, namespace: namespace, name: name}
+	if info, ok := r.jobs[key]; ok {
+		if info.APICalls == nil {
+			info.APICalls = map[string]int64{}
+		}
+		info.APICalls[verb] += count
+		r.jobs[key] = info
+	}
+}
+
+func (r *Registry) Get(kind, namespace, name string) (JobInfo, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	info, ok := r.jobs[jobKey{kind: kind, namespace: namespace, name: name}]
+	if !ok {
+		return JobInfo{}, false
+	}
+	return *info.DeepCopy(), true
+}
+
+func (r *Registry) List() []JobInfo {
+	return r.list(func(JobInfo) bool { return true })
+}
+
+func (r *Registry) ListByNamespace(namespace string) []JobInfo {
+	return r.list(func(info JobInfo) bool { return info.Namespace == namespace })
+}
+
+func (r *Registry) list(filter func(JobInfo) bool) []JobInfo {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var result []JobInfo
+	for _, info := range r.jobs {
+		if filter(info) {
+			result = append(result, *info.DeepCopy())
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].CreationTimestamp.Equal(&result[j].CreationTimestamp) {
+			return result[i].CreationTimestamp.Before(&result[j].CreationTimestamp)
+		}
+		if result[i].Namespace != result[j].Namespace {
+			return result[i].Namespace < result[j].Namespace
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// DeepCopy returns a copy of the JobInfo which does not share the resource list and the API calls.
+func (in *JobInfo) DeepCopy() *JobInfo {
+	if in == nil {
+		return nil
+	}
+	out := *in
+	out.Resources = in.Resources.DeepCopy()
+	if in.APICalls != nil {
+		out.APICalls = make(map[string]int64, len(in.APICalls))
+		for verb, count := range in.APICalls {
+			out.APICalls[verb] = count
+		}
+	}
+	return &out
+}
+
+// NewJobInfo returns the JobInfo of a job of any kind supported by the operator.
+func NewJobInfo(obj interface{}) (JobInfo, bool) {
+	switch job := obj.(type) {
+	case *kubeflowv1.TFJob:
+		return newJobInfo(kubeflowv1.TFJobKind, job, job.Spec.TFReplicaSpecs, &job.Spec.RunPolicy, &job.Status), true
+	case *kubeflowv1.PyTorchJob:
+		return newJobInfo(kubeflowv1.PyTorchJobKind, job, job.Spec.PyTorchReplicaSpecs, &job.Spec.RunPolicy, &job.Status), true
+	case *kubeflowv1.MPIJob:
+		return newJobInfo(kubeflowv1.MPIJobKind, job, job.Spec.MPIReplicaSpecs, &job.Spec.RunPolicy, &job.Status), true
+	case *kubeflowv1.XGBoostJob:
+		return newJobInfo(kubeflowv1.XGBoostJobKind, job, job.Spec.XGBReplicaSpecs, &job.Spec.RunPolicy, &job.Status), true
+	case *kubeflowv1.PaddleJob:
+		return newJobInfo(kubeflowv1.PaddleJobKind, job, job.Spec.PaddleReplicaSpecs, &job.Spec.RunPolicy, &job.Status), true
+	case *kubeflowv1.JAXJob:
+		return newJobInfo(kubeflowv1.JAXJobKind, job, job.Spec.JAXReplicaSpecs, &job.Spec.RunPolicy, &job.Status), true
+	default:
+		return JobInfo{}, false
+	}
+}
+
+func newJobInfo(kind string, job metav1.Object, replicas map[kubeflowv1.ReplicaType]*kubeflowv1.ReplicaSpec,
+	runPolicy *kubeflowv1.RunPolicy, status *kubeflowv1.JobStatus) JobInfo {
+	info := JobInfo{
+		Kind:              kind,
+		Namespace:         job.GetNamespace(),
+		Name:              job.GetName(),
+		UID:               job.GetUID(),
+		Resources:         totalRequests(replicas),
+		CreationTimestamp: job.GetCreationTimestamp(),
+	}
+	if runPolicy.SchedulingPolicy != nil {
+		info.PriorityClass = runPolicy.SchedulingPolicy.PriorityClass
+		info.Queue = runPolicy.SchedulingPolicy.Queue
+	}
+	info.Suspended = runPolicy.Suspend != nil && *runPolicy.Suspend
+	for _, replicaStatus := range status.ReplicaStatuses {
+		if replicaStatus != nil {
+			info.ActiveReplicas += replicaStatus.Active
+		}
+	}
+	for _, condition := range status.Conditions {
+		if condition.Type == kubeflowv1.JobPending {
+			info.Admitted = condition.Status == corev1.ConditionFalse
+		}
+	}
+	for i := len(status.Conditions) - 1; i >= 0; i-- {
+		if status.Conditions[i].Status == corev1.ConditionTrue {
+			info.Phase = status.Conditions[i].Type
+			break
+		}
+	}
+	return info
+}
+
+// totalRequests returns the sum of the resource requests of the containers of all replicas.
+func totalRequests(replicas map[kubeflowv1.ReplicaType]*kubeflowv1.ReplicaSpec) corev1.ResourceList {
+	total := corev1.ResourceList{}
+	for _, spec := range replicas {
+		if spec == nil || spec.Replicas == nil {
+			continue
+		}
+		for _, container := range spec.Template.Spec.Containers {
+			for name, quantity := range container.Resources.Requests {
+				q := quantity.DeepCopy()
+				q.Mul(int64(*spec.Replicas))
+				if current, ok := total[name]; ok {
+					q.Add(current)
+				}
+				total[name] = q
+			}
+		}
+	}
+	return total
+}
@@ -0,0 +1,218 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	toolscache "k8s.io/client-go/tools/cache"
+	"k8s.io/utils/ptr"
+
+	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+)
+
+func newPyTorchJob(namespace, name string, created time.Time) *kubeflowv1.PyTorchJob {
+	return &kubeflowv1.PyTorchJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         namespace,
+			Name:              name,
+			CreationTimestamp: metav1.NewTime(created),
+		},
+		Spec: kubeflowv1.PyTorchJobSpec{
+			RunPolicy: kubeflowv1.RunPolicy{
+				SchedulingPolicy: &kubeflowv1.SchedulingPolicy{PriorityClass: "high", Queue: "training"},
+				Suspend:          ptr.To(true),
+			},
+			PyTorchReplicaSpecs: map[kubeflowv1.ReplicaType]*kubeflowv1.ReplicaSpec{
+				kubeflowv1.PyTorchJobReplicaTypeMaster: {
+					Replicas: ptr.To[int32](1),
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{{
+								Name: "pytorch",
+								Resources: corev1.ResourceRequirements{
+									Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+								},
+							}},
+						},
+					},
+				},
+				kubeflowv1.PyTorchJobReplicaTypeWorker: {
+					Replicas: ptr.To[int32](3),
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{{
+								Name: "pytorch",
+								Resources: corev1.ResourceRequirements{
+									Requests: corev1.ResourceList{
+										corev1.ResourceCPU:    resource.MustParse("500m"),
+										corev1.ResourceMemory: resource.MustParse("1Gi"),
+									},
+								},
+							}},
+						},
+					},
+				},
+			},
+		},
+		Status: kubeflowv1.JobStatus{
+			Conditions: []kubeflowv1.JobCondition{
+				{Type: kubeflowv1.JobCreated, Status: corev1.ConditionTrue},
+				{Type: kubeflowv1.JobPending, Status: corev1.ConditionFalse},
+				{Type: kubeflowv1.JobRunning, Status: corev1.ConditionTrue},
+				{Type: kubeflowv1.JobRestarting, Status: corev1.ConditionFalse},
+			},
+			ReplicaStatuses: map[kubeflowv1.ReplicaType]*kubeflowv1.ReplicaStatus{
+				kubeflowv1.PyTorchJobReplicaTypeMaster: {Active: 1},
+				kubeflowv1.PyTorchJobReplicaTypeWorker: {Active: 2, Succeeded: 1},
+			},
+		},
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	now := time.Now()
+	first := newPyTorchJob("ns-a", "first", now)
+	second := newPyTorchJob("ns-b", "second", now.Add(time.Minute))
+	r := New()
+	r.OnAdd(second)
+	r.OnAdd(first)
+	r.OnAdd(&corev1.Pod{})
+
+	got, ok := r.Get(kubeflowv1.PyTorchJobKind, "ns-a", "first")
+	if !ok {
+		t.Fatalf("Job ns-a/first is not registered")
+	}
+	want := JobInfo{
+		Kind:          kubeflowv1.PyTorchJobKind,
+		Namespace:     "ns-a",
+		Name:          "first",
+		Phase:         kubeflowv1.JobRunning,
+		PriorityClass: "high",
+		Queue:         "training",
+		Suspended:     true,
+		Admitted:      true,
+		Resources: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("2500m"),
+			corev1.ResourceMemory: resource.MustParse("3Gi"),
+		},
+		ActiveReplicas:    3,
+		CreationTimestamp: first.CreationTimestamp,
+	}
+	if diff := cmp.Diff(want, got); len(diff) != 0 {
+		t.Errorf("Unexpected job info (-want,+got):\n%s", diff)
+	}
+
+	names := func(infos []JobInfo) []string {
+		var result []string
+		for _, info := range infos {
+			result = append(result, info.Name)
+		}
+		return result
+	}
+	if diff := cmp.Diff([]string{"first", "second"}, names(r.List())); len(diff) != 0 {
+		t.Errorf("Unexpected jobs from List (-want,+got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"second"}, names(r.ListByNamespace("ns-b"))); len(diff) != 0 {
+		t.Errorf("Unexpected jobs from ListByNamespace (-want,+got):\n%s", diff)
+	}
+
+	r.AddAPICalls(kubeflowv1.PyTorchJobKind, "ns-a", "unknown", "create", 1)
+	r.AddAPICalls(kubeflowv1.PyTorchJobKind, "ns-a", "first", "create", 3)
+	r.AddAPICalls(kubeflowv1.PyTorchJobKind, "ns-a", "first", "update", 1)
+
+	succeeded := first.DeepCopy()
+	succeeded.Status.Conditions = append(succeeded.Status.Conditions,
+		kubeflowv1.JobCondition{Type: kubeflowv1.JobSucceeded, Status: corev1.ConditionTrue})
+	r.OnUpdate(first, succeeded)
+	got, _ = r.Get(kubeflowv1.PyTorchJobKind, "ns-a", "first")
+	if got.Phase != kubeflowv1.JobSucceeded {
+		t.Errorf("Unexpected phase after update, want: %v, got: %v", kubeflowv1.JobSucceeded, got.Phase)
+	}
+	if diff := cmp.Diff(map[string]int64{"create": 3, "update": 1}, got.APICalls); len(diff) != 0 {
+		t.Errorf("Unexpected API calls after update (-want,+got):\n%s", diff)
+	}
+
+	r.OnDelete(succeeded)
+	r.OnDelete(toolscache.DeletedFinalStateUnknown{Key: "ns-b/second", Obj: second})
+	if got := r.List(); len(got) != 0 {
+		t.Errorf("Unexpected jobs after delete: %v", names(got))
+	}
+}
+
+func TestRegistryConcurrentAccess(t *testing.T) {
+	r := New()
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			job := newPyTorchJob("default", fmt.Sprintf("job-%d", i), time.Now())
+			r.OnAdd(job)
+			r.OnUpdate(job, job)
+		}(i)
+		go func() {
+			defer wg.Done()
+			for _, info := range r.List() {
+				info.Resources[corev1.ResourceCPU] = resource.MustParse("0")
+			}
+		}()
+	}
+	wg.Wait()
+	for _, info := range r.List() {
+		if cpu := info.Resources[corev1.ResourceCPU]; cpu.Cmp(resource.MustParse("2500m")) != 0 {
+			t.Errorf("Resources of job %s were modified through a listed copy: %v", info.Name, cpu.String())
+		}
+	}
+}
+
+func TestRegistryListener(t *testing.T) {
+	job := newPyTorchJob("default", "train", time.Now())
+	r := New()
+	var got []string
+	r.AddListener(func(old, new *JobInfo) {
+		switch {
+		case old == nil:
+			got = append(got, "add "+string(new.Phase))
+		case new == nil:
+			got = append(got, "delete "+string(old.Phase))
+		default:
+			got = append(got, "update "+string(old.Phase)+" "+string(new.Phase))
+			new.Resources[corev1.ResourceCPU] = resource.MustParse("0")
+		}
+	})
+	r.OnAdd(job)
+	succeeded := job.DeepCopy()
+	succeeded.Status.Conditions = append(succeeded.Status.Conditions,
+		kubeflowv1.JobCondition{Type: kubeflowv1.JobSucceeded, Status: corev1.ConditionTrue})
+	r.OnUpdate(job, succeeded)
+	if info, _ := r.Get(kubeflowv1.PyTorchJobKind, "default", "train"); info.Resources.Cpu().Cmp(resource.MustParse("2500m")) != 0 {
+		t.Errorf("Resources of the job were modified by a listener: %v", info.Resources.Cpu().String())
+	}
+	r.OnDelete(succeeded)
+	r.OnDelete(succeeded)
+
+	want := []string{"add Running", "update Running Succeeded", "delete Succeeded"}
+	if diff := cmp.Diff(want, got); len(diff) != 0 {
+		t.Errorf("Unexpected notifications (-want,+got):\n%s", diff)
+	}
+}
@@ -27,6 +27,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
@@ -123,6 +124,56 @@ var _ = Describe("TFJob controller", func() {
 		})
 	})
 
+	Context("Test TFConfigStrategy", func() {
+		It("should set the cluster environment variables of the strategy", func() {
+			type tc struct {
+				strategy    *kubeflowv1.TFConfigStrategy
+				expectedEnv []string
+			}
+			testCase := []tc{
+				{
+					strategy:    nil,
+					expectedEnv: []string{"TF_CONFIG"},
+				},
+				{
+					strategy:    ptr.To(kubeflowv1.TFConfigStrategyTFConfig),
+					expectedEnv: []string{"TF_CONFIG"},
+				},
+				{
+					strategy:    ptr.To(kubeflowv1.TFConfigStrategyGRPCWorkerCache),
+					expectedEnv: []string{"TF_GRPC_WORKER_CACHE", "TF_TASK_TYPE", "TF_TASK_INDEX"},
+				},
+				{
+					strategy:    ptr.To(kubeflowv1.TFConfigStrategyNone),
+					expectedEnv: nil,
+				},
+			}
+
+			_ = os.Setenv(EnvCustomClusterDomain, "")
+			for _, c := range testCase {
+				tfJob := tftestutil.NewTFJobWithNamespace(2, 1, "ns4")
+				tfJob.SetName(tftestutil.TestTFJobName)
+				tfJob.Spec.TFConfigStrategy = c.strategy
+				podTemplate := tfJob.Spec.TFReplicaSpecs[kubeflowv1.TFJobReplicaTypeWorker].Template.DeepCopy()
+
+				Expect(reconciler.SetClusterSpec(tfJob, podTemplate, "worker", "1")).Should(Succeed())
+
+				var actualEnv []string
+				for _, env := range podTemplate.Spec.Containers[0].Env {
+					actualEnv = append(actualEnv, env.Name)
+				}
+				Expect(actualEnv).Should(Equal(c.expectedEnv))
+				if c.strategy != nil && *c.strategy == kubeflowv1.TFConfigStrategyGRPCWorkerCache {
+					Expect(podTemplate.Spec.Containers[0].Env[0].Value).Should(Equal(
+						"ps|" + tftestutil.TestTFJobName + "-ps-0.ns4.svc:2222," +
+							"worker|" + tftestutil.TestTFJobName + "-worker-0.ns4.svc:2222;" + tftestutil.TestTFJobName + "-worker-1.ns4.svc:2222"))
+					Expect(podTemplate.Spec.Containers[0].Env[1].Value).Should(Equal("worker"))
+					Expect(podTemplate.Spec.Containers[0].Env[2].Value).Should(Equal("1"))
+				}
+			}
+		})
+	})
+
 	Context("Test IsDistributed", func() {
 		It("should returns correctly", func() {
 			type tc struct {
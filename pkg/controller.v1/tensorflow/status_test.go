@@ -25,6 +25,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
@@ -63,6 +64,31 @@ var _ = Describe("TFJob controller", func() {
 			}
 			Expect(found).To(BeTrue())
 		})
+
+		It("should not fail TFJob when the failed evaluators are tolerated", func() {
+			By("creating a TFJob with tolerated evaluator failures")
+			tfJob := tftestutil.NewTFJobWithEvaluator(1, 0, 2)
+			tfJob.Spec.TFReplicaSpecs[kubeflowv1.TFJobReplicaTypeEval].ToleratedFailures = ptr.To[int32](1)
+			initializeReplicaStatuses(&tfJob.Status, kubeflowv1.TFJobReplicaTypeWorker)
+			initializeReplicaStatuses(&tfJob.Status, kubeflowv1.TFJobReplicaTypeEval)
+
+			By("prepare pod")
+			refs := []metav1.OwnerReference{
+				*reconciler.GenOwnerReference(tfJob),
+			}
+			pod := tftestutil.NewBasePod("pod", tfJob, refs)
+			pod.Status.Phase = v1.PodFailed
+
+			By("update job replica statuses")
+			updateJobReplicaStatuses(&tfJob.Status, kubeflowv1.TFJobReplicaTypeEval, pod)
+
+			By("update job status")
+			Expect(reconciler.UpdateJobStatus(tfJob, tfJob.Spec.TFReplicaSpecs, &tfJob.Status)).To(Succeed())
+
+			By("checking the failure is tolerated")
+			Expect(util.IsFailed(tfJob.Status)).To(BeFalse())
+			Expect(tfJob.Status.ReplicaStatuses[kubeflowv1.TFJobReplicaTypeEval].ToleratedFailed).Should(Equal(int32(1)))
+		})
 	})
 
 	Context("Test Status", func() {
@@ -19,11 +19,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 
 	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
 	"github.com/kubeflow/training-operator/pkg/controller.v1/common"
+	trainutil "github.com/kubeflow/training-operator/pkg/util/train"
 )
 
 const (
@@ -153,6 +155,27 @@ func genTFConfigJSONStr(tfjob *kubeflowv1.TFJob, rtype, index string) (string, e
 	return string(tfConfigJSONByteSlice), nil
 }
 
+// genGRPCWorkerCacheStr generates the cluster of the TFJob in the form of the --cluster_spec
+// flag of the TensorFlow servers, with the jobs sorted by name:
+//
+//	ps|ps1:2222;ps2:2222,worker|worker1:2222;worker2:2222;worker3:2222
+func genGRPCWorkerCacheStr(tfjob *kubeflowv1.TFJob) (string, error) {
+	cluster, err := genClusterSpec(tfjob)
+	if err != nil {
+		return "", err
+	}
+
+	jobs := make([]string, 0, len(cluster))
+	for job := range cluster {
+		jobs = append(jobs, job)
+	}
+	sort.Strings(jobs)
+	for i, job := range jobs {
+		jobs[i] = job + "|" + strings.Join(cluster[job], ";")
+	}
+	return strings.Join(jobs, ","), nil
+}
+
 // genClusterSpec will generate ClusterSpec.
 func genClusterSpec(tfjob *kubeflowv1.TFJob) (ClusterSpec, error) {
 	clusterSpec := make(ClusterSpec)
@@ -176,6 +199,11 @@ func genClusterSpec(tfjob *kubeflowv1.TFJob) (ClusterSpec, error) {
 			if len(clusterDomain) > 0 {
 				svcName += "." + clusterDomain
 			}
+			if trainutil.IsJobStandalone(&tfjob.Spec.RunPolicy) {
+				// The single pod of a standalone job has no service, its hostname
+				// resolves to its own IP.
+				svcName = hostName
+			}
 
 			endpoint := fmt.Sprintf("%s:%d", svcName, port)
 			replicaNames = append(replicaNames, endpoint)
@@ -18,6 +18,11 @@ package tensorflow
 import (
 	"reflect"
 	"testing"
+
+	"k8s.io/utils/ptr"
+
+	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	"github.com/kubeflow/training-operator/pkg/controller.v1/tensorflow/testutil"
 )
 
 func TestConvertClusterSpecToSparseClusterSpec(t *testing.T) {
@@ -43,3 +48,37 @@ func TestConvertClusterSpecToSparseClusterSpec(t *testing.T) {
 		t.Error("sparseClusterSpec for worker is not correct!")
 	}
 }
+
+func TestGenGRPCWorkerCacheStr(t *testing.T) {
+	t.Setenv(EnvCustomClusterDomain, "")
+	tfJob := testutil.NewTFJobWithEvaluatorAndNamespace(2, 1, 1, "default")
+	tfJob.SetName("test-tfjob")
+	tfJob.Spec.TFConfigStrategy = ptr.To(kubeflowv1.TFConfigStrategyGRPCWorkerCache)
+
+	got, err := genGRPCWorkerCacheStr(tfJob)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := "evaluator|test-tfjob-evaluator-0.default.svc:2222," +
+		"ps|test-tfjob-ps-0.default.svc:2222," +
+		"worker|test-tfjob-worker-0.default.svc:2222;test-tfjob-worker-1.default.svc:2222"
+	if got != expected {
+		t.Errorf("Unexpected worker cache, want: %s, got: %s", expected, got)
+	}
+}
+
+func TestGenClusterSpecStandalone(t *testing.T) {
+	t.Setenv(EnvCustomClusterDomain, "")
+	tfJob := testutil.NewTFJobWithNamespace(1, 0, "default")
+	tfJob.SetName("test-tfjob")
+	tfJob.Spec.RunPolicy.Standalone = ptr.To(true)
+
+	got, err := genClusterSpec(tfJob)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want := ClusterSpec{"worker": {"test-tfjob-worker-0:2222"}}
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("Unexpected cluster spec, want: %v, got: %v", want, got)
+	}
+}
@@ -23,13 +23,15 @@ import (
 	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
 	trainingoperatorcommon "github.com/kubeflow/training-operator/pkg/common"
 	"github.com/kubeflow/training-operator/pkg/common/util"
+	"github.com/kubeflow/training-operator/pkg/config"
 	"github.com/kubeflow/training-operator/pkg/controller.v1/common"
 	"github.com/kubeflow/training-operator/pkg/controller.v1/control"
 	"github.com/kubeflow/training-operator/pkg/controller.v1/expectation"
+	"github.com/kubeflow/training-operator/pkg/controller.v1/registry"
+	"github.com/kubeflow/training-operator/pkg/core"
 	commonutil "github.com/kubeflow/training-operator/pkg/util"
 
 	"github.com/go-logr/logr"
-	"github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -41,36 +43,39 @@ import (
 	"k8s.io/client-go/informers"
 	kubeclientset "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
-	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/source"
-	schedulerpluginsv1alpha1 "sigs.k8s.io/scheduler-plugins/apis/scheduling/v1alpha1"
-	"volcano.sh/apis/pkg/apis/scheduling/v1beta1"
 )
 
 const (
-	FailedDeleteJobReason     = "FailedDeleteJob"
-	SuccessfulDeleteJobReason = "SuccessfulDeleteJob"
-
 	controllerName = "tfjob-controller"
 
 	// tfConfig is the environment variable name of TensorFlow cluster spec.
 	tfConfig = "TF_CONFIG"
+	// tfGRPCWorkerCache is the environment variable name of TensorFlow cluster spec
+	// in the form of the --cluster_spec flag of the TensorFlow servers.
+	tfGRPCWorkerCache = "TF_GRPC_WORKER_CACHE"
+	// tfTaskType is the environment variable name of the task type of the replica, e.g. worker.
+	tfTaskType = "TF_TASK_TYPE"
+	// tfTaskIndex is the environment variable name of the task index of the replica.
+	tfTaskIndex = "TF_TASK_INDEX"
 )
 
-func NewReconciler(mgr manager.Manager, gangSchedulingSetupFunc common.GangSchedulingSetupFunc) *TFJobReconciler {
+func NewReconciler(mgr manager.Manager, gangSchedulingSetupFunc common.GangSchedulingSetupFunc, options common.JobControllerOptions) *TFJobReconciler {
 	r := &TFJobReconciler{
 		Client:    mgr.GetClient(),
 		Scheme:    mgr.GetScheme(),
-		recorder:  mgr.GetEventRecorderFor(controllerName),
+		recorder:  commonutil.NewDeduplicatingRecorder(commonutil.NewEventRecorderFor(mgr, controllerName), config.Config.EventDeduplicationWindow),
 		apiReader: mgr.GetAPIReader(),
-		Log:       log.Log,
+		Log:       ctrl.Log.WithName("controllers").WithName(kubeflowv1.TFJobKind),
 	}
 
 	cfg := mgr.GetConfig()
@@ -81,13 +86,18 @@ func NewReconciler(mgr manager.Manager, gangSchedulingSetupFunc common.GangSched
 	r.JobController = common.JobController{
 		Controller:                  r,
 		Expectations:                expectation.NewControllerExpectations(),
-		WorkQueue:                   &util.FakeWorkQueue{},
+		WorkQueue:                   util.NewRequeueWorkQueue(),
 		Recorder:                    r.recorder,
 		KubeClientSet:               kubeClientSet,
 		PriorityClassLister:         priorityClassInformer.Lister(),
 		PriorityClassInformerSynced: priorityClassInformer.Informer().HasSynced,
 		PodControl:                  control.RealPodControl{KubeClient: kubeClientSet, Recorder: r.recorder},
 		ServiceControl:              control.RealServiceControl{KubeClient: kubeClientSet, Recorder: r.recorder},
+		PodExecControl:              control.RealPodExecControl{Config: cfg, KubeClient: kubeClientSet},
+		JobRegistry:                 registry.Default,
+		JobControllerOptions:        options,
+		Clock:                       commonutil.NewClock(clock.RealClock{}, config.Config.ClockSkewTolerance),
+		OOMKillTracker:              common.NewOOMKillTracker(time.Now()),
 	}
 
 	gangSchedulingSetupFunc(&r.JobController)
@@ -109,24 +119,41 @@ type TFJobReconciler struct {
 // +kubebuilder:rbac:groups=kubeflow.org,resources=tfjobs/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=kubeflow.org,resources=tfjobs/finalizers,verbs=update
 // +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups="",resources=pods/exec,verbs=create
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=resourcequotas,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;patch;delete
 // +kubebuilder:rbac:groups=scheduling.volcano.sh,resources=podgroups,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=scheduling.x-k8s.io,resources=podgroups,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=ray.io,resources=rayclusters,verbs=get;create;delete
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch;create;update
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;patch;delete
 // +kubebuilder:rbac:groups="",resources=events,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;update;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
 func (r *TFJobReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	_ = log.FromContext(ctx)
-	logger := r.Log.WithValues(kubeflowv1.TFJobSingular, req.NamespacedName)
+	defer trainingoperatorcommon.ReconcileDurationObserve(r.GetFrameworkName(), time.Now())
+	logger := r.Log.WithValues("kind", kubeflowv1.TFJobKind, "namespace", req.Namespace, "name", req.Name)
 
 	tfjob := &kubeflowv1.TFJob{}
 	err := r.Get(ctx, req.NamespacedName, tfjob)
 	if err != nil {
-		logger.Info(err.Error(), "unable to fetch TFJob", req.NamespacedName.String())
+		logger.Info("unable to fetch TFJob", "error", err.Error())
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
+	if !common.ReconciledByThisController(tfjob) {
+		logger.V(1).Info("Skipping TFJob pinned to another controller", "controller-identity", tfjob.GetAnnotations()[kubeflowv1.ControllerIdentityAnnotation])
+		return ctrl.Result{}, nil
+	}
+
 	if manager := r.ManagedByExternalController(tfjob.Spec.RunPolicy.ManagedBy); manager != nil {
 		logger.Info("Skipping TFJob managed by a custom controller", "managed-by", manager)
 		return ctrl.Result{}, nil
@@ -153,13 +180,13 @@ func (r *TFJobReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 	// Use common to reconcile the job related pod and service
 	err = r.ReconcileJobs(tfjob, tfjob.Spec.TFReplicaSpecs, tfjob.Status, &tfjob.Spec.RunPolicy)
 	if err != nil {
-		logrus.Warnf("Reconcile Tensorflow Job error %v", err)
+		logger.Error(err, "Reconcile Tensorflow Job error")
 		return ctrl.Result{}, err
 	}
 
-	t, err := util.DurationUntilExpireTime(&tfjob.Spec.RunPolicy, tfjob.Status)
+	t, err := util.DurationUntilExpireTime(&tfjob.Spec.RunPolicy, tfjob.Status, r.Clock)
 	if err != nil {
-		logrus.Warnf("Reconcile Tensorflow Job error %v", err)
+		logger.Error(err, "Reconcile Tensorflow Job error")
 		return ctrl.Result{}, err
 	}
 	if t >= 0 {
@@ -172,8 +199,9 @@ func (r *TFJobReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 // SetupWithManager sets up the controller with the Manager.
 func (r *TFJobReconciler) SetupWithManager(mgr ctrl.Manager, controllerThreads int) error {
 	c, err := controller.New(r.ControllerName(), mgr, controller.Options{
-		Reconciler:              r,
+		Reconciler:              common.NewDrainingReconciler(r, r.Clock),
 		MaxConcurrentReconciles: controllerThreads,
+		RateLimiter:             common.NewRateLimiter(),
 	})
 	if err != nil {
 		return err
@@ -185,6 +213,26 @@ func (r *TFJobReconciler) SetupWithManager(mgr ctrl.Manager, controllerThreads i
 	); err != nil {
 		return err
 	}
+	// keep the job registry shared by all controllers up to date
+	if err = registry.Default.Watch(context.Background(), mgr.GetCache(), &kubeflowv1.TFJob{}); err != nil {
+		return err
+	}
+	// requeue the jobs added to the work queue of the common job controller
+	if err = r.WatchWorkQueue(c); err != nil {
+		return err
+	}
+	// requeue the jobs waiting for the objects referenced by the templates of their pods
+	if err = r.WatchDependencies(mgr, c); err != nil {
+		return err
+	}
+	// requeue the jobs blocked by the resource quotas of their namespace
+	if err = r.WatchResourceQuotas(mgr, c); err != nil {
+		return err
+	}
+	// requeue the jobs with pods bound to a node which starts being reclaimed
+	if err = r.WatchPreemptedNodes(mgr, c); err != nil {
+		return err
+	}
 	// inject watching for job related pod
 	if err = c.Watch(source.Kind[*corev1.Pod](mgr.GetCache(), &corev1.Pod{},
 		handler.TypedEnqueueRequestForOwner[*corev1.Pod](mgr.GetScheme(), mgr.GetRESTMapper(), &kubeflowv1.TFJob{}, handler.OnlyControllerOwner()),
@@ -197,25 +245,9 @@ func (r *TFJobReconciler) SetupWithManager(mgr ctrl.Manager, controllerThreads i
 		util.OnDependentFuncs[*corev1.Service](r.Scheme, r.Expectations, &r.JobController))); err != nil {
 		return err
 	}
-	// skip watching volcano PodGroup if volcano PodGroup is not installed
-	if _, err = mgr.GetRESTMapper().RESTMapping(schema.GroupKind{Group: v1beta1.GroupName, Kind: "PodGroup"},
-		v1beta1.SchemeGroupVersion.Version); err == nil {
-		// inject watching for job related volcano PodGroup
-		if err = c.Watch(source.Kind[*v1beta1.PodGroup](mgr.GetCache(), &v1beta1.PodGroup{},
-			handler.TypedEnqueueRequestForOwner[*v1beta1.PodGroup](mgr.GetScheme(), mgr.GetRESTMapper(), &kubeflowv1.TFJob{}, handler.OnlyControllerOwner()),
-			util.OnDependentFuncs[*v1beta1.PodGroup](r.Scheme, r.Expectations, &r.JobController))); err != nil {
-			return err
-		}
-	}
-	// skip watching scheduler-plugins PodGroup if scheduler-plugins PodGroup is not installed
-	if _, err = mgr.GetRESTMapper().RESTMapping(schema.GroupKind{Group: schedulerpluginsv1alpha1.SchemeGroupVersion.Group, Kind: "PodGroup"},
-		schedulerpluginsv1alpha1.SchemeGroupVersion.Version); err == nil {
-		// inject watching for job related scheduler-plugins PodGroup
-		if err = c.Watch(source.Kind[*schedulerpluginsv1alpha1.PodGroup](mgr.GetCache(), &schedulerpluginsv1alpha1.PodGroup{},
-			handler.TypedEnqueueRequestForOwner[*schedulerpluginsv1alpha1.PodGroup](mgr.GetScheme(), mgr.GetRESTMapper(), &kubeflowv1.TFJob{}, handler.OnlyControllerOwner()),
-			util.OnDependentFuncs[*schedulerpluginsv1alpha1.PodGroup](r.Scheme, r.Expectations, &r.JobController))); err != nil {
-			return err
-		}
+	// inject watching for job related PodGroup of the gang scheduler
+	if err = util.WatchPodGroups(mgr, c, r.Scheme, &kubeflowv1.TFJob{}, &r.JobController); err != nil {
+		return err
 	}
 	return nil
 }
@@ -254,9 +286,9 @@ func (r *TFJobReconciler) GetJobFromAPIClient(namespace, name string) (metav1.Ob
 	err := r.apiReader.Get(context.Background(), types.NamespacedName{Namespace: namespace, Name: name}, job)
 	if err != nil {
 		if errors.IsNotFound(err) {
-			logrus.Error(err, "tensorflow job not found", "namespace", namespace, "name", name)
+			r.Log.Error(err, "tensorflow job not found", "namespace", namespace, "name", name)
 		} else {
-			logrus.Error(err, "failed to get job from api-server", "namespace", namespace, "name", name)
+			r.Log.Error(err, "failed to get job from api-server", "namespace", namespace, "name", name)
 		}
 		return nil, err
 	}
@@ -289,22 +321,7 @@ func (r *TFJobReconciler) GetPodsForJob(jobObject interface{}) ([]*corev1.Pod, e
 		return nil, err
 	}
 
-	pods := util.JobControlledPodList(podlist.Items, job)
-
-	// If any adoptions are attempted, we should first recheck for deletion
-	// with an uncached quorum read sometime after listing Pods (see #42639).
-	canAdoptFunc := common.RecheckDeletionTimestamp(func() (metav1.Object, error) {
-		fresh, err := r.Controller.GetJobFromAPIClient(job.GetNamespace(), job.GetName())
-		if err != nil {
-			return nil, err
-		}
-		if fresh.GetUID() != job.GetUID() {
-			return nil, fmt.Errorf("original Job %v/%v is gone: got uid %v, wanted %v", job.GetNamespace(), job.GetName(), fresh.GetUID(), job.GetUID())
-		}
-		return fresh, nil
-	})
-	cm := control.NewPodControllerRefManager(r.PodControl, job, selector, r.Controller.GetAPIGroupVersionKind(), canAdoptFunc)
-	return cm.ClaimPods(pods)
+	return r.ClaimPods(job, util.ConvertPodList(podlist.Items))
 }
 
 // GetServicesForJob returns the set of services that this job should manage.
@@ -348,7 +365,7 @@ func (r *TFJobReconciler) GetServicesForJob(jobObject interface{}) ([]*corev1.Se
 	cm := control.NewServiceControllerRefManager(r.ServiceControl, job, selector, r.Controller.GetAPIGroupVersionKind(), canAdoptFunc)
 
 	services := util.ConvertServiceList(svclist.Items)
-	return cm.ClaimServices(services)
+	return cm.ClaimServices(services, common.ServiceClaimFilters()...)
 }
 
 func (r *TFJobReconciler) DeleteJob(job interface{}) error {
@@ -359,13 +376,13 @@ func (r *TFJobReconciler) DeleteJob(job interface{}) error {
 
 	log := commonutil.LoggerForJob(tfJob)
 	if err := r.Delete(context.Background(), tfJob); err != nil {
-		r.recorder.Eventf(tfJob, v1.EventTypeWarning, FailedDeleteJobReason, "Error deleting: %v", err)
-		log.Errorf("failed to delete job %s/%s, %v", tfJob.Namespace, tfJob.Name, err)
+		r.recorder.Eventf(tfJob, v1.EventTypeWarning, commonutil.FailedDeleteJobReason, "Error deleting: %v", err)
+		log.Error(err, "failed to delete job")
 		return err
 	}
 
-	r.recorder.Eventf(tfJob, v1.EventTypeNormal, SuccessfulDeleteJobReason, "Deleted job: %v", tfJob.Name)
-	log.Infof("job %s/%s has been deleted", tfJob.Namespace, tfJob.Name)
+	r.recorder.Eventf(tfJob, v1.EventTypeNormal, commonutil.SuccessfulDeleteJobReason, "Deleted job: %v", tfJob.Name)
+	log.Info("job has been deleted")
 	trainingoperatorcommon.DeletedJobsCounterInc(tfJob.Namespace, r.GetFrameworkName())
 	return nil
 }
@@ -386,17 +403,17 @@ func (r *TFJobReconciler) UpdateJobStatus(job interface{}, replicas map[kubeflow
 
 	worker0Completed, err := r.IsWorker0Completed(tfJob, replicas)
 	if err != nil {
-		logger.Warnf("check if worker 0 completed error %v", err)
+		logger.Error(err, "check if worker 0 completed error")
 		return err
 	}
 
 	// Set StartTime.
 	if jobStatus.StartTime == nil {
-		now := metav1.Now()
+		now := r.Clock.MetaNow()
 		jobStatus.StartTime = &now
 		// enqueue a sync to check if job past ActiveDeadlineSeconds
 		if tfJob.Spec.RunPolicy.ActiveDeadlineSeconds != nil {
-			logger.Infof("Job with ActiveDeadlineSeconds will sync after %d seconds", *tfJob.Spec.RunPolicy.ActiveDeadlineSeconds)
+			logger.Info("Job with ActiveDeadlineSeconds will sync after the deadline", "activeDeadlineSeconds", *tfJob.Spec.RunPolicy.ActiveDeadlineSeconds)
 			// TODO(Jeffwan): requeue job key in reconciler scenarios
 			r.WorkQueue.AddAfter(tfJobKey, time.Duration(*tfJob.Spec.RunPolicy.ActiveDeadlineSeconds)*time.Second)
 		}
@@ -436,8 +453,7 @@ func (r *TFJobReconciler) UpdateJobStatus(job interface{}, replicas map[kubeflow
 		running := status.Active
 		failed := status.Failed
 
-		logger.Infof("TFJob=%s/%s, ReplicaType=%s expected=%d, running=%d, failed=%d",
-			tfJob.Namespace, tfJob.Name, rtype, expected, running, failed)
+		logger.Info("Replica status", "replicaType", rtype, "expected", expected, "running", running, "failed", failed)
 
 		// If the TFJob contains Chief or Master spec, then we will update the status
 		// according to the Chief/Master spec.
@@ -445,18 +461,20 @@ func (r *TFJobReconciler) UpdateJobStatus(job interface{}, replicas map[kubeflow
 			if kubeflowv1.IsChiefOrMaster(rtype) {
 				if running > 0 {
 					msg := fmt.Sprintf("TFJob %s/%s is running.", tfJob.Namespace, tfJob.Name)
-					commonutil.UpdateJobConditions(jobStatus, kubeflowv1.JobRunning, corev1.ConditionTrue, commonutil.NewReason(kubeflowv1.TFJobKind, commonutil.JobRunningReason), msg)
+					commonutil.UpdateJobConditions(jobStatus, kubeflowv1.JobRunning, corev1.ConditionTrue, commonutil.NewReason(kubeflowv1.TFJobKind, commonutil.JobRunningReason), msg, r.Clock)
 				}
-				if expected == 0 {
-					msg := fmt.Sprintf("TFJob %s/%s successfully completed.",
-						tfJob.Namespace, tfJob.Name)
+				// If `SuccessPolicyAllWorkers` success policy is used, the TFJob is succeeded
+				// only when both the Chief/Master and all workers are succeeded.
+				if expected == 0 && tfJob.Spec.SuccessPolicy != nil && *tfJob.Spec.SuccessPolicy == kubeflowv1.SuccessPolicyAllWorkers &&
+					!commonutil.AllReplicasSucceeded(tfJob.Spec.TFReplicaSpecs, *jobStatus, kubeflowv1.TFJobReplicaTypeWorker) {
+					logger.Info("Waiting for all workers to succeed", "successPolicy", kubeflowv1.SuccessPolicyAllWorkers)
+				} else if expected == 0 {
+					msg := fmt.Sprintf("TFJob %s/%s successfully completed. %s",
+						tfJob.Namespace, tfJob.Name, commonutil.SuccessPolicyMessage(tfJob.Spec.SuccessPolicy))
 					r.recorder.Event(tfJob, corev1.EventTypeNormal, commonutil.NewReason(kubeflowv1.TFJobKind, commonutil.JobSucceededReason), msg)
-					if jobStatus.CompletionTime == nil {
-						now := metav1.Now()
-						jobStatus.CompletionTime = &now
+					if commonutil.FinishJob(jobStatus, kubeflowv1.JobSucceeded, commonutil.NewReason(kubeflowv1.TFJobKind, commonutil.JobSucceededReason), msg, r.Clock) {
+						trainingoperatorcommon.SuccessfulJobsCounterInc(tfJob.Namespace, r.GetFrameworkName())
 					}
-					commonutil.UpdateJobConditions(jobStatus, kubeflowv1.JobSucceeded, corev1.ConditionTrue, commonutil.NewReason(kubeflowv1.TFJobKind, commonutil.JobSucceededReason), msg)
-					trainingoperatorcommon.SuccessfulJobsCounterInc(tfJob.Namespace, r.GetFrameworkName())
 				}
 			}
 		} else {
@@ -464,20 +482,18 @@ func (r *TFJobReconciler) UpdateJobStatus(job interface{}, replicas map[kubeflow
 				// Leave a succeeded condition for the following two cases:
 				// 1. If default success policy is used and worker 0 has completed.
 				// 2. If `SuccessPolicyAllWorkers` success policy is used and all workers are succeeded.
+				// `SuccessPolicyChiefOrMaster` treats worker 0 as the chief, the same as the default policy.
 				if expected == 0 || (worker0Completed && *tfJob.Spec.SuccessPolicy != kubeflowv1.SuccessPolicyAllWorkers) {
-					msg := fmt.Sprintf("TFJob %s/%s successfully completed.",
-						tfJob.Namespace, tfJob.Name)
+					msg := fmt.Sprintf("TFJob %s/%s successfully completed. %s",
+						tfJob.Namespace, tfJob.Name, commonutil.SuccessPolicyMessage(tfJob.Spec.SuccessPolicy))
 					r.recorder.Event(tfJob, corev1.EventTypeNormal, commonutil.NewReason(kubeflowv1.TFJobKind, commonutil.JobSucceededReason), msg)
-					if jobStatus.CompletionTime == nil {
-						now := metav1.Now()
-						jobStatus.CompletionTime = &now
+					if commonutil.FinishJob(jobStatus, kubeflowv1.JobSucceeded, commonutil.NewReason(kubeflowv1.TFJobKind, commonutil.JobSucceededReason), msg, r.Clock) {
+						trainingoperatorcommon.SuccessfulJobsCounterInc(tfJob.Namespace, r.GetFrameworkName())
 					}
-					commonutil.UpdateJobConditions(jobStatus, kubeflowv1.JobSucceeded, corev1.ConditionTrue, commonutil.NewReason(kubeflowv1.TFJobKind, commonutil.JobSucceededReason), msg)
-					trainingoperatorcommon.SuccessfulJobsCounterInc(tfJob.Namespace, r.GetFrameworkName())
 				} else if running > 0 {
 					// Some workers are still running, leave a running condition.
 					msg := fmt.Sprintf("TFJob %s/%s is running.", tfJob.Namespace, tfJob.Name)
-					commonutil.UpdateJobConditions(jobStatus, kubeflowv1.JobRunning, corev1.ConditionTrue, commonutil.NewReason(kubeflowv1.TFJobKind, commonutil.JobRunningReason), msg)
+					commonutil.UpdateJobConditions(jobStatus, kubeflowv1.JobRunning, corev1.ConditionTrue, commonutil.NewReason(kubeflowv1.TFJobKind, commonutil.JobRunningReason), msg, r.Clock)
 				}
 			}
 		}
@@ -487,25 +503,28 @@ func (r *TFJobReconciler) UpdateJobStatus(job interface{}, replicas map[kubeflow
 			// the restarting condition will be removed from jobStatus by kubeflowv1.filterOutCondition(),
 			// so we need to append the restarting condition back to jobStatus.
 			if existingRestartingCondition != nil {
-				commonutil.UpdateJobConditions(jobStatus, kubeflowv1.JobRestarting, corev1.ConditionTrue, existingRestartingCondition.Reason, existingRestartingCondition.Message)
+				commonutil.UpdateJobConditions(jobStatus, kubeflowv1.JobRestarting, corev1.ConditionTrue, existingRestartingCondition.Reason, existingRestartingCondition.Message, r.Clock)
 				// job is restarting, no need to set it failed
 				// we know it because we update the status condition when reconciling the replicas
 				trainingoperatorcommon.RestartedJobsCounterInc(tfJob.Namespace, r.GetFrameworkName())
 			} else {
 				if tfJob.Spec.EnableDynamicWorker && rtype == kubeflowv1.TFJobReplicaTypeWorker {
-					commonutil.LoggerForJob(tfJob).Infof("TFJob %s/%s continues regardless %d Worker replica(s) failed as enableDynamicWorker is set true.",
-						tfJob.Namespace, tfJob.Name, failed)
+					logger.Info("Job continues regardless of the failed workers as enableDynamicWorker is set true", "replicaType", rtype, "failed", failed)
+					continue
+				}
+				if rtype == kubeflowv1.TFJobReplicaTypeEval && failed <= ptr.Deref(spec.ToleratedFailures, 0) {
+					// Record the tolerated failures, the job keeps running without the failed evaluators.
+					status.ToleratedFailed = failed
+					logger.Info("Job continues regardless of the failed evaluators as the failures are tolerated",
+						"replicaType", rtype, "failed", failed, "toleratedFailures", *spec.ToleratedFailures)
 					continue
 				}
 				msg := fmt.Sprintf("TFJob %s/%s has failed because %d %s replica(s) failed.",
 					tfJob.Namespace, tfJob.Name, failed, rtype)
 				r.recorder.Event(tfJob, corev1.EventTypeNormal, commonutil.NewReason(kubeflowv1.TFJobKind, commonutil.JobFailedReason), msg)
-				if jobStatus.CompletionTime == nil {
-					now := metav1.Now()
-					jobStatus.CompletionTime = &now
+				if commonutil.FinishJob(jobStatus, kubeflowv1.JobFailed, commonutil.NewReason(kubeflowv1.TFJobKind, commonutil.JobFailedReason), msg, r.Clock) {
+					trainingoperatorcommon.FailedJobsCounterInc(tfJob.Namespace, r.GetFrameworkName())
 				}
-				commonutil.UpdateJobConditions(jobStatus, kubeflowv1.JobFailed, corev1.ConditionTrue, commonutil.NewReason(kubeflowv1.TFJobKind, commonutil.JobFailedReason), msg)
-				trainingoperatorcommon.FailedJobsCounterInc(tfJob.Namespace, r.GetFrameworkName())
 			}
 		}
 	}
@@ -528,23 +547,19 @@ func (r *TFJobReconciler) UpdateJobStatusInApiServer(job interface{}, jobStatus
 		return fmt.Errorf("%v is not a type of TFJob", tfJob)
 	}
 
-	startTime := time.Now()
+	startTime := r.Clock.Now()
 	logger := commonutil.LoggerForJob(tfJob)
 	defer func() {
-		logger.Infof("Finished updating TFJobs Status %q (%v)",
-			tfJob.Name, time.Since(startTime))
+		logger.V(1).Info("Finished updating TFJob status", "duration", r.Clock.Since(startTime))
 	}()
 
-	tfJob = tfJob.DeepCopy()
-	tfJob.Status = *jobStatus.DeepCopy()
-
-	result := r.Status().Update(context.Background(), tfJob)
+	// Patch only the difference to the status through the status subresource, so that
+	// updates do not overwrite the spec and are retried on conflicts.
+	result := util.PatchJobStatus(context.Background(), r.Client, r.apiReader, tfJob,
+		func(j *kubeflowv1.TFJob) *kubeflowv1.JobStatus { return &j.Status }, jobStatus)
 
 	if result != nil {
-		r.Log.WithValues("tfjob", types.NamespacedName{
-			Namespace: tfJob.GetNamespace(),
-			Name:      tfJob.GetName(),
-		})
+		commonutil.LoggerForJob(tfJob).Error(result, "failed to update the job status in the API server")
 		return result
 	}
 
@@ -557,30 +572,48 @@ func (r *TFJobReconciler) SetClusterSpec(job interface{}, podTemplate *corev1.Po
 	if !ok {
 		return fmt.Errorf("%v is not a type of TFJob", tfjob)
 	}
+	core.SetCommonEnv(podTemplate, kubeflowv1.TFJobDefaultContainerName, tfjob.Spec.CommonEnv, tfjob.Spec.CommonEnvFrom)
+	common.SetNetworkTuningEnv(podTemplate, kubeflowv1.TFJobDefaultContainerName, &tfjob.Spec.RunPolicy)
 
-	// Do not set TF_CONFIG for local training jobs.
-	if !isDistributed(tfjob) {
+	// Do not set TF_CONFIG for local training jobs, nor for the jobs opting out of it.
+	if !isDistributed(tfjob) || common.IsClusterSpecEnvDisabled(&tfjob.Spec.RunPolicy) {
 		return nil
 	}
-	// Generate TF_CONFIG JSON string.
-	tfConfigStr, err := genTFConfigJSONStr(tfjob, rtype, index)
-	if err != nil {
-		return err
-	}
 
-	if tfConfigStr == "" {
+	var envVars []corev1.EnvVar
+	switch ptr.Deref(tfjob.Spec.TFConfigStrategy, kubeflowv1.TFConfigStrategyTFConfig) {
+	case kubeflowv1.TFConfigStrategyNone:
+		// The users build the cluster on their own.
 		return nil
+	case kubeflowv1.TFConfigStrategyGRPCWorkerCache:
+		workerCacheStr, err := genGRPCWorkerCacheStr(tfjob)
+		if err != nil {
+			return err
+		}
+		envVars = []corev1.EnvVar{
+			{Name: tfGRPCWorkerCache, Value: workerCacheStr},
+			{Name: tfTaskType, Value: strings.ToLower(rtype)},
+			{Name: tfTaskIndex, Value: index},
+		}
+	default:
+		// Generate TF_CONFIG JSON string.
+		tfConfigStr, err := genTFConfigJSONStr(tfjob, rtype, index)
+		if err != nil {
+			return err
+		}
+
+		if tfConfigStr == "" {
+			return nil
+		}
+		envVars = []corev1.EnvVar{{Name: tfConfig, Value: tfConfigStr}}
 	}
-	// Add TF_CONFIG environment variable to tensorflow container in the pod.
+	// Add the cluster environment variables to tensorflow container in the pod.
 	for i := range podTemplate.Spec.Containers {
 		if podTemplate.Spec.Containers[i].Name == kubeflowv1.TFJobDefaultContainerName {
 			if len(podTemplate.Spec.Containers[i].Env) == 0 {
 				podTemplate.Spec.Containers[i].Env = make([]corev1.EnvVar, 0)
 			}
-			podTemplate.Spec.Containers[i].Env = append(podTemplate.Spec.Containers[i].Env, corev1.EnvVar{
-				Name:  tfConfig,
-				Value: tfConfigStr,
-			})
+			podTemplate.Spec.Containers[i].Env = append(podTemplate.Spec.Containers[i].Env, envVars...)
 			break
 		}
 	}
@@ -595,6 +628,42 @@ func (r *TFJobReconciler) GetDefaultContainerPortName() string {
 	return kubeflowv1.TFJobDefaultPortName
 }
 
+// GetJobDependencies returns the jobs the TFJob depends on.
+func (r *TFJobReconciler) GetJobDependencies(job interface{}) []kubeflowv1.JobReference {
+	tfJob, ok := job.(*kubeflowv1.TFJob)
+	if !ok {
+		return nil
+	}
+	return tfJob.Spec.DependsOn
+}
+
+// GetTensorBoard returns the TensorBoard requested by the TFJob.
+func (r *TFJobReconciler) GetTensorBoard(job interface{}) *kubeflowv1.TensorBoardSpec {
+	tfJob, ok := job.(*kubeflowv1.TFJob)
+	if !ok {
+		return nil
+	}
+	return tfJob.Spec.TensorBoard
+}
+
+// GetOutputs returns the outputs declared by the TFJob.
+func (r *TFJobReconciler) GetOutputs(job interface{}) []kubeflowv1.OutputSpec {
+	tfJob, ok := job.(*kubeflowv1.TFJob)
+	if !ok {
+		return nil
+	}
+	return tfJob.Spec.Outputs
+}
+
+// GetDatasets returns the datasets declared by the TFJob.
+func (r *TFJobReconciler) GetDatasets(job interface{}) []kubeflowv1.DatasetSpec {
+	tfJob, ok := job.(*kubeflowv1.TFJob)
+	if !ok {
+		return nil
+	}
+	return tfJob.Spec.Datasets
+}
+
 func (r *TFJobReconciler) IsMasterRole(replicas map[kubeflowv1.ReplicaType]*kubeflowv1.ReplicaSpec,
 	rtype kubeflowv1.ReplicaType, index int) bool {
 	if ContainsChiefOrMasterSpec(replicas) {
@@ -633,8 +702,8 @@ func (r *TFJobReconciler) getPodSlices(tfjob *kubeflowv1.TFJob, replicasNum *int
 	logger := commonutil.LoggerForReplica(tfjob, strings.ToLower(string(kubeflowv1.TFJobReplicaTypeWorker)))
 
 	pods, err := r.GetPodsForJob(tfjob)
-	if err != nil {
-		commonutil.LoggerForJob(tfjob).Warnf("getPodsForTFJob error %v", err)
+	if err = common.IgnoreAdoptionDeferred(err); err != nil {
+		logger.Error(err, "getPodsForTFJob error")
 		return nil, err
 	}
 
@@ -651,12 +720,17 @@ func (r *TFJobReconciler) getPodSlices(tfjob *kubeflowv1.TFJob, replicasNum *int
 // onOwnerCreateFunc modify creation condition.
 func (r *TFJobReconciler) onOwnerCreateFunc() func(createEvent event.TypedCreateEvent[*kubeflowv1.TFJob]) bool {
 	return func(e event.TypedCreateEvent[*kubeflowv1.TFJob]) bool {
+		if !common.ReconciledByThisController(e.Object) {
+			return false
+		}
 		tfJob := e.Object
 		r.Scheme.Default(tfJob)
 		msg := fmt.Sprintf("TFJob %s is created.", e.Object.GetName())
-		logrus.Info(msg)
+		commonutil.LoggerForJob(tfJob).Info(msg)
 		trainingoperatorcommon.CreatedJobsCounterInc(tfJob.Namespace, r.GetFrameworkName())
-		commonutil.UpdateJobConditions(&tfJob.Status, kubeflowv1.JobCreated, corev1.ConditionTrue, commonutil.NewReason(kubeflowv1.TFJobKind, commonutil.JobCreatedReason), msg)
+		oldStatus := tfJob.Status.DeepCopy()
+		commonutil.UpdateJobConditions(&tfJob.Status, kubeflowv1.JobCreated, corev1.ConditionTrue, commonutil.NewReason(kubeflowv1.TFJobKind, commonutil.JobCreatedReason), msg, r.Clock)
+		r.RecordAuditEvents(tfJob, oldStatus, &tfJob.Status)
 		return true
 	}
 }
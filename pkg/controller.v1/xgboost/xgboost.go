@@ -23,6 +23,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	trainutil "github.com/kubeflow/training-operator/pkg/util/train"
 )
 
 // SetPodEnv sets the pod env set for:
@@ -47,6 +48,9 @@ func SetPodEnv(job interface{}, podTemplate *corev1.PodTemplateSpec, rtype, inde
 	}
 
 	masterAddr := replicaName(xgboostjob.Name, kubeflowv1.XGBoostJobReplicaTypeMaster, 0)
+	if trainutil.IsJobStandalone(&xgboostjob.Spec.RunPolicy) {
+		masterAddr = trainutil.StandaloneMasterAddr
+	}
 
 	masterPort, err := getPortFromXGBoostJob(xgboostjob, kubeflowv1.XGBoostJobReplicaTypeMaster)
 	if err != nil {
@@ -54,6 +58,18 @@ func SetPodEnv(job interface{}, podTemplate *corev1.PodTemplateSpec, rtype, inde
 	}
 
 	totalReplicas := computeTotalReplicas(xgboostjob)
+	worldSize := totalReplicas
+
+	var rabitEnv []corev1.EnvVar
+	if rabitPolicy := xgboostjob.Spec.RabitPolicy; rabitPolicy != nil {
+		if rabitPolicy.TrackerPort != nil {
+			masterPort = *rabitPolicy.TrackerPort
+		}
+		if rabitPolicy.NumWorkers != nil {
+			worldSize = *rabitPolicy.NumWorkers
+		}
+		rabitEnv = rabitPolicy.Env
+	}
 
 	var workerPort int32
 	var workerAddrs []string
@@ -84,7 +100,7 @@ func SetPodEnv(job interface{}, podTemplate *corev1.PodTemplateSpec, rtype, inde
 		})
 		podTemplate.Spec.Containers[i].Env = append(podTemplate.Spec.Containers[i].Env, corev1.EnvVar{
 			Name:  "WORLD_SIZE",
-			Value: strconv.Itoa(int(totalReplicas)),
+			Value: strconv.Itoa(int(worldSize)),
 		})
 		podTemplate.Spec.Containers[i].Env = append(podTemplate.Spec.Containers[i].Env, corev1.EnvVar{
 			Name:  "RANK",
@@ -105,6 +121,7 @@ func SetPodEnv(job interface{}, podTemplate *corev1.PodTemplateSpec, rtype, inde
 				Value: strings.Join(workerAddrs, ","),
 			})
 		}
+		podTemplate.Spec.Containers[i].Env = append(podTemplate.Spec.Containers[i].Env, rabitEnv...)
 	}
 
 	return nil
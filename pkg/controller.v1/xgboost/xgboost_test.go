@@ -0,0 +1,109 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xgboost
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+)
+
+func newXGBoostJob(workers int32, rabitPolicy *kubeflowv1.RabitPolicy) *kubeflowv1.XGBoostJob {
+	replicaSpec := func(replicas int32) *kubeflowv1.ReplicaSpec {
+		return &kubeflowv1.ReplicaSpec{
+			Replicas: ptr.To(replicas),
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name: kubeflowv1.XGBoostJobDefaultContainerName,
+						Ports: []corev1.ContainerPort{{
+							Name:          kubeflowv1.XGBoostJobDefaultPortName,
+							ContainerPort: kubeflowv1.XGBoostJobDefaultPort,
+						}},
+					}},
+				},
+			},
+		}
+	}
+	return &kubeflowv1.XGBoostJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec: kubeflowv1.XGBoostJobSpec{
+			XGBReplicaSpecs: map[kubeflowv1.ReplicaType]*kubeflowv1.ReplicaSpec{
+				kubeflowv1.XGBoostJobReplicaTypeMaster: replicaSpec(1),
+				kubeflowv1.XGBoostJobReplicaTypeWorker: replicaSpec(workers),
+			},
+			RabitPolicy: rabitPolicy,
+		},
+	}
+}
+
+func TestSetPodEnv(t *testing.T) {
+	cases := map[string]struct {
+		job     *kubeflowv1.XGBoostJob
+		rtype   kubeflowv1.ReplicaType
+		index   string
+		wantEnv []corev1.EnvVar
+	}{
+		"master without rabitPolicy": {
+			job:   newXGBoostJob(1, nil),
+			rtype: kubeflowv1.XGBoostJobReplicaTypeMaster,
+			index: "0",
+			wantEnv: []corev1.EnvVar{
+				{Name: "MASTER_PORT", Value: "9999"},
+				{Name: "MASTER_ADDR", Value: "test-master-0"},
+				{Name: "WORLD_SIZE", Value: "2"},
+				{Name: "RANK", Value: "0"},
+				{Name: "PYTHONUNBUFFERED", Value: "1"},
+				{Name: "WORKER_PORT", Value: "9999"},
+				{Name: "WORKER_ADDRS", Value: "test-worker-0"},
+			},
+		},
+		"worker with rabitPolicy": {
+			job: newXGBoostJob(2, &kubeflowv1.RabitPolicy{
+				TrackerPort: ptr.To[int32](9091),
+				NumWorkers:  ptr.To[int32](2),
+				Env:         []corev1.EnvVar{{Name: "DMLC_WORKER_CONNECT_RETRY", Value: "10"}},
+			}),
+			rtype: kubeflowv1.XGBoostJobReplicaTypeWorker,
+			index: "1",
+			wantEnv: []corev1.EnvVar{
+				{Name: "MASTER_PORT", Value: "9091"},
+				{Name: "MASTER_ADDR", Value: "test-master-0"},
+				{Name: "WORLD_SIZE", Value: "2"},
+				{Name: "RANK", Value: "2"},
+				{Name: "PYTHONUNBUFFERED", Value: "1"},
+				{Name: "WORKER_PORT", Value: "9999"},
+				{Name: "WORKER_ADDRS", Value: "test-worker-0,test-worker-1"},
+				{Name: "DMLC_WORKER_CONNECT_RETRY", Value: "10"},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			podTemplate := tc.job.Spec.XGBReplicaSpecs[tc.rtype].Template.DeepCopy()
+			if err := SetPodEnv(tc.job, podTemplate, string(tc.rtype), tc.index); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantEnv, podTemplate.Spec.Containers[0].Env); len(diff) != 0 {
+				t.Errorf("Unexpected env (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2024 The Kubeflow Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	v1 "k8s.io/api/core/v1"
+)
+
+// spotNodeLabel is a label set on the spot nodes of a cloud provider.
+type spotNodeLabel struct {
+	key   string
+	value string
+}
+
+var (
+	// spotNodeLabels are the labels of the spot nodes of EKS (managed node groups and Karpenter),
+	// GKE and AKS, with their value on spot nodes.
+	spotNodeLabels = []spotNodeLabel{
+		{key: "eks.amazonaws.com/capacityType", value: "SPOT"},
+		{key: "karpenter.sh/capacity-type", value: "spot"},
+		{key: "cloud.google.com/gke-spot", value: "true"},
+		{key: "kubernetes.azure.com/scalesetpriority", value: "spot"},
+	}
+
+	// spotNodeTolerations tolerate the taints which GKE and AKS set on spot nodes.
+	spotNodeTolerations = []v1.Toleration{
+		{Key: "cloud.google.com/gke-spot", Operator: v1.TolerationOpEqual, Value: "true", Effect: v1.TaintEffectNoSchedule},
+		{Key: "kubernetes.azure.com/scalesetpriority", Operator: v1.TolerationOpEqual, Value: "spot", Effect: v1.TaintEffectNoSchedule},
+	}
+)
+
+// SetCapacityType adds the node affinity and the tolerations matching the CapacityType of the replica to the podTemplate.
+// The node affinity is combined with the node affinity already set in the podTemplate.
+func SetCapacityType(podTemplateSpec *v1.PodTemplateSpec, spec *apiv1.ReplicaSpec) {
+	switch spec.CapacityType {
+	case apiv1.CapacityTypeSpot:
+		// A spot node of any of the cloud providers.
+		terms := make([]v1.NodeSelectorTerm, 0, len(spotNodeLabels))
+		for _, label := range spotNodeLabels {
+			terms = append(terms, v1.NodeSelectorTerm{
+				MatchExpressions: []v1.NodeSelectorRequirement{
+					{Key: label.key, Operator: v1.NodeSelectorOpIn, Values: []string{label.value}},
+				},
+			})
+		}
+		requireNodeSelectorTerms(podTemplateSpec, terms)
+		addTolerations(podTemplateSpec, spotNodeTolerations)
+	case apiv1.CapacityTypeOnDemand:
+		// A node which is not a spot node of any of the cloud providers.
+		// NotIn also matches the nodes without the label.
+		term := v1.NodeSelectorTerm{}
+		for _, label := range spotNodeLabels {
+			term.MatchExpressions = append(term.MatchExpressions, v1.NodeSelectorRequirement{
+				Key: label.key, Operator: v1.NodeSelectorOpNotIn, Values: []string{label.value},
+			})
+		}
+		requireNodeSelectorTerms(podTemplateSpec, []v1.NodeSelectorTerm{term})
+	case apiv1.CapacityTypePreferSpot:
+		nodeAffinity := ensureNodeAffinity(podTemplateSpec)
+		for _, label := range spotNodeLabels {
+			nodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(nodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution,
+				v1.PreferredSchedulingTerm{
+					Weight: 100,
+					Preference: v1.NodeSelectorTerm{
+						MatchExpressions: []v1.NodeSelectorRequirement{
+							{Key: label.key, Operator: v1.NodeSelectorOpIn, Values: []string{label.value}},
+						},
+					},
+				})
+		}
+		addTolerations(podTemplateSpec, spotNodeTolerations)
+	}
+}
+
+func ensureNodeAffinity(podTemplateSpec *v1.PodTemplateSpec) *v1.NodeAffinity {
+	if podTemplateSpec.Spec.Affinity == nil {
+		podTemplateSpec.Spec.Affinity = &v1.Affinity{}
+	}
+	if podTemplateSpec.Spec.Affinity.NodeAffinity == nil {
+		podTemplateSpec.Spec.Affinity.NodeAffinity = &v1.NodeAffinity{}
+	}
+	return podTemplateSpec.Spec.Affinity.NodeAffinity
+}
+
+// requireNodeSelectorTerms requires the nodes to match one of the terms, in addition to
+// the required node affinity of the podTemplate. As the terms of a NodeSelector are ORed,
+// every existing term is combined with every given term.
+func requireNodeSelectorTerms(podTemplateSpec *v1.PodTemplateSpec, terms []v1.NodeSelectorTerm) {
+	nodeAffinity := ensureNodeAffinity(podTemplateSpec)
+	if nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil ||
+		len(nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms) == 0 {
+		nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &v1.NodeSelector{NodeSelectorTerms: terms}
+		return
+	}
+	existingTerms := nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+	combinedTerms := make([]v1.NodeSelectorTerm, 0, len(existingTerms)*len(terms))
+	for _, existingTerm := range existingTerms {
+		for _, term := range terms {
+			combinedTerm := *existingTerm.DeepCopy()
+			combinedTerm.MatchExpressions = append(combinedTerm.MatchExpressions, term.MatchExpressions...)
+			combinedTerms = append(combinedTerms, combinedTerm)
+		}
+	}
+	nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms = combinedTerms
+}
+
+// addTolerations adds the tolerations which are not set in the podTemplate yet.
+func addTolerations(podTemplateSpec *v1.PodTemplateSpec, tolerations []v1.Toleration) {
+	for _, toleration := range tolerations {
+		found := false
+		for _, existing := range podTemplateSpec.Spec.Tolerations {
+			if existing.MatchToleration(&toleration) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			podTemplateSpec.Spec.Tolerations = append(podTemplateSpec.Spec.Tolerations, toleration)
+		}
+	}
+}
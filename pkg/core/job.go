@@ -21,14 +21,13 @@ import (
 	"strings"
 	"time"
 
-	log "github.com/sirupsen/logrus"
-
 	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"github.com/kubeflow/training-operator/pkg/util"
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 // RecordAbnormalPods records the active pod whose latest condition is not in True status.
@@ -38,14 +37,14 @@ func RecordAbnormalPods(activePods []*v1.Pod, object runtime.Object, recorder re
 		recordContainerStatus := func(status *v1.ContainerStatus) {
 			if status.State.Terminated != nil && status.State.Terminated.ExitCode != 0 {
 				terminated := status.State.Terminated
-				recorder.Eventf(object, v1.EventTypeWarning, terminated.Reason,
+				util.RelatedEventf(recorder, object, pod, v1.EventTypeWarning, terminated.Reason, util.EventActionReconcile,
 					"Error pod %s container %s exitCode: %d terminated message: %s",
 					pod.Name, status.Name, terminated.ExitCode, terminated.Message)
 			}
 			// The terminated state and waiting state don't simultaneously exists, checks them at the same time.
 			if status.State.Waiting != nil && status.State.Waiting.Message != "" {
 				wait := status.State.Waiting
-				recorder.Eventf(object, v1.EventTypeWarning, wait.Reason,
+				util.RelatedEventf(recorder, object, pod, v1.EventTypeWarning, wait.Reason, util.EventActionReconcile,
 					"Error pod %s container %s waiting message: %s", pod.Name, status.Name, wait.Message)
 			}
 		}
@@ -74,18 +73,16 @@ func RecordAbnormalPods(activePods []*v1.Pod, object runtime.Object, recorder re
 		if condition.Status == v1.ConditionTrue {
 			continue
 		}
-		recorder.Eventf(object, v1.EventTypeWarning, condition.Reason, "Error pod %s condition message: %s", pod.Name, condition.Message)
+		util.RelatedEventf(recorder, object, pod, v1.EventTypeWarning, condition.Reason, util.EventActionReconcile, "Error pod %s condition message: %s", pod.Name, condition.Message)
 	}
 }
 
 // PastActiveDeadline checks if job has ActiveDeadlineSeconds field set and if it is exceeded.
-func PastActiveDeadline(runPolicy *apiv1.RunPolicy, jobStatus apiv1.JobStatus) bool {
+func PastActiveDeadline(runPolicy *apiv1.RunPolicy, jobStatus apiv1.JobStatus, clock *util.Clock) bool {
 	if runPolicy.ActiveDeadlineSeconds == nil || jobStatus.StartTime == nil {
 		return false
 	}
-	now := metav1.Now()
-	start := jobStatus.StartTime.Time
-	duration := now.Time.Sub(start)
+	duration := clock.Since(jobStatus.StartTime.Time)
 	allowedDuration := time.Duration(*runPolicy.ActiveDeadlineSeconds) * time.Second
 	return duration >= allowedDuration
 }
@@ -101,7 +98,7 @@ func PastBackoffLimit(jobName string, runPolicy *apiv1.RunPolicy,
 	result := int32(0)
 	for rtype, spec := range replicas {
 		if spec.RestartPolicy != apiv1.RestartPolicyOnFailure && spec.RestartPolicy != apiv1.RestartPolicyAlways && spec.RestartPolicy != apiv1.RestartPolicyExitCode {
-			log.Warnf("The restart policy of replica %v of the job %v is not OnFailure, Always or ExitCode. Not counted in backoff limit.", rtype, jobName)
+			log.Log.Info("The restart policy of the replica is not OnFailure, Always or ExitCode. Not counted in backoff limit.", "name", jobName, "replicaType", rtype)
 			continue
 		}
 		// Convert ReplicaType to lower string.
@@ -17,12 +17,16 @@ limitations under the License.
 package core
 
 import (
+	"sort"
+
 	utillabels "github.com/kubeflow/training-operator/pkg/util/labels"
 
+	"github.com/go-logr/logr"
 	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
-	log "github.com/sirupsen/logrus"
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
 )
 
 // FilterPodsForReplicaType returns pods belong to a replicaType.
@@ -45,16 +49,16 @@ func FilterPodsForReplicaType(pods []*v1.Pod, replicaType string) ([]*v1.Pod, er
 
 // GetPodSlices returns a slice, which element is the slice of pod.
 // It gives enough information to caller to make decision to up/down scale resources.
-func GetPodSlices(pods []*v1.Pod, replicas int, logger *log.Entry) [][]*v1.Pod {
+func GetPodSlices(pods []*v1.Pod, replicas int, logger logr.Logger) [][]*v1.Pod {
 	podSlices := make([][]*v1.Pod, CalculatePodSliceSize(pods, replicas))
 	for _, pod := range pods {
 		index, err := utillabels.ReplicaIndex(pod.Labels)
 		if err != nil {
-			logger.Warningf("Error obtaining replica index from Pod %s/%s: %v", pod.Namespace, pod.Name, err)
+			logger.Error(err, "Error obtaining replica index", "pod", pod.Name)
 			continue
 		}
 		if index < 0 || index >= replicas {
-			logger.Warningf("The label index is not expected: %d, pod: %s/%s", index, pod.Namespace, pod.Name)
+			logger.Info("The label index is not expected", "pod", pod.Name, "index", index)
 		}
 
 		podSlices[index] = append(podSlices[index], pod)
@@ -79,10 +83,129 @@ func CalculatePodSliceSize(pods []*v1.Pod, replicas int) int {
 
 // SetRestartPolicy check the RestartPolicy defined in job spec and overwrite RestartPolicy in podTemplate if necessary
 func SetRestartPolicy(podTemplateSpec *v1.PodTemplateSpec, spec *apiv1.ReplicaSpec) {
-	// This is necessary since restartPolicyExitCode is not supported in v1.PodTemplateSpec
-	if spec.RestartPolicy == apiv1.RestartPolicyExitCode {
+	// This is necessary since restartPolicyExitCode and restartPolicyGangRestart are not supported in v1.PodTemplateSpec
+	if spec.RestartPolicy == apiv1.RestartPolicyExitCode || spec.RestartPolicy == apiv1.RestartPolicyGangRestart {
 		podTemplateSpec.Spec.RestartPolicy = v1.RestartPolicyNever
 	} else {
 		podTemplateSpec.Spec.RestartPolicy = v1.RestartPolicy(spec.RestartPolicy)
 	}
 }
+
+// SetControllerLabels merges the labels of the controller, under the ControllerLabelPrefix, into
+// the labels of the podTemplate. The other labels of the podTemplate are user labels and are kept
+// verbatim, while the labels of the podTemplate with the reserved prefix are overwritten by the
+// values of the controller. The keys of the overwritten labels are returned, sorted.
+func SetControllerLabels(podTemplateSpec *v1.PodTemplateSpec, controllerLabels map[string]string) []string {
+	if podTemplateSpec.Labels == nil {
+		podTemplateSpec.Labels = make(map[string]string, len(controllerLabels))
+	}
+	var overwritten []string
+	for key, value := range controllerLabels {
+		if old, ok := podTemplateSpec.Labels[key]; ok && old != value {
+			overwritten = append(overwritten, key)
+		}
+		podTemplateSpec.Labels[key] = value
+	}
+	sort.Strings(overwritten)
+	return overwritten
+}
+
+// SetRestartedAt copies the RestartedAtAnnotation of the job, if any, to the podTemplate, so that
+// the pods created before the last restart requested for the job can be told apart.
+func SetRestartedAt(podTemplateSpec *v1.PodTemplateSpec, job metav1.Object) {
+	restartedAt, ok := job.GetAnnotations()[apiv1.RestartedAtAnnotation]
+	if !ok {
+		return
+	}
+	if podTemplateSpec.Annotations == nil {
+		podTemplateSpec.Annotations = make(map[string]string)
+	}
+	podTemplateSpec.Annotations[apiv1.RestartedAtAnnotation] = restartedAt
+}
+
+// SetCommonEnv merges the env vars and the env sources common to all the replicas of a job
+// into the given container of the podTemplate. The env vars defined in the container take
+// precedence over the common ones of the same name, and the env sources of the container are
+// placed after the common ones, so that they take precedence as well.
+func SetCommonEnv(podTemplateSpec *v1.PodTemplateSpec, containerName string, env []v1.EnvVar, envFrom []v1.EnvFromSource) {
+	if len(env) == 0 && len(envFrom) == 0 {
+		return
+	}
+	for i := range podTemplateSpec.Spec.Containers {
+		container := &podTemplateSpec.Spec.Containers[i]
+		if container.Name != containerName {
+			continue
+		}
+		defined := sets.New[string]()
+		for _, e := range container.Env {
+			defined.Insert(e.Name)
+		}
+		var merged []v1.EnvVar
+		for _, e := range env {
+			if !defined.Has(e.Name) {
+				merged = append(merged, e)
+			}
+		}
+		container.Env = append(merged, container.Env...)
+		if len(envFrom) != 0 {
+			container.EnvFrom = append(append([]v1.EnvFromSource{}, envFrom...), container.EnvFrom...)
+		}
+	}
+}
+
+// GetContainerExitCode returns the exit code of the given container if it is terminated.
+func GetContainerExitCode(pod *v1.Pod, containerName string) (int32, bool) {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == containerName && status.State.Terminated != nil {
+			return status.State.Terminated.ExitCode, true
+		}
+	}
+	return 0, false
+}
+
+// imagePullFailureReasons are the reasons of the waiting containers whose image cannot be pulled.
+var imagePullFailureReasons = sets.New("ErrImagePull", "ImagePullBackOff", "InvalidImageName", "ErrImageNeverPull")
+
+// nodeFailureReasons are the reasons of the failed pods, and of their DisruptionTarget condition,
+// when their node was lost or not ready.
+var nodeFailureReasons = sets.New("NodeLost", "DeletionByTaintManager", "DeletionByPodGC")
+
+// ClassifyPodFailure returns the reason of the failure of a failed pod, or of a Pending pod whose
+// image cannot be pulled, and false for the other pods.
+func ClassifyPodFailure(pod *v1.Pod) (apiv1.PodFailureReason, bool) {
+	statuses := append(append([]v1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	switch pod.Status.Phase {
+	case v1.PodPending:
+		for _, status := range statuses {
+			if status.State.Waiting != nil && imagePullFailureReasons.Has(status.State.Waiting.Reason) {
+				return apiv1.PodFailureReasonImagePullBackOff, true
+			}
+		}
+		return "", false
+	case v1.PodFailed:
+	default:
+		return "", false
+	}
+	for _, status := range statuses {
+		if status.State.Terminated != nil && status.State.Terminated.Reason == "OOMKilled" {
+			return apiv1.PodFailureReasonOOMKilled, true
+		}
+	}
+	if nodeFailureReasons.Has(pod.Status.Reason) {
+		return apiv1.PodFailureReasonNodeNotReady, true
+	}
+	if pod.Status.Reason == "Evicted" {
+		return apiv1.PodFailureReasonEvicted, true
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type != v1.DisruptionTarget || condition.Status != v1.ConditionTrue {
+			continue
+		}
+		// The other disruptions are evictions, by the eviction API, a preemption or the kubelet.
+		if nodeFailureReasons.Has(condition.Reason) {
+			return apiv1.PodFailureReasonNodeNotReady, true
+		}
+		return apiv1.PodFailureReasonEvicted, true
+	}
+	return apiv1.PodFailureReasonError, true
+}
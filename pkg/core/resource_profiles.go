@@ -0,0 +1,136 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kubeflow/training-operator/pkg/config"
+)
+
+// ResourceProfiles maps the names of the resource profiles of the operator, e.g. gpu-large, to
+// the requests and the limits of the main containers of the replicas setting them.
+type ResourceProfiles map[string]corev1.ResourceRequirements
+
+// resourceProfilesCache holds the profiles last parsed from a file, with the modification time
+// and the size of the file they were parsed from.
+var resourceProfilesCache struct {
+	sync.Mutex
+	file     string
+	modTime  time.Time
+	size     int64
+	profiles ResourceProfiles
+}
+
+// LoadResourceProfiles reads the resource profiles of the operator from file, a YAML map of the
+// names of the profiles to their requests and limits, e.g.
+//
+//	gpu-large:
+//	  requests:
+//	    cpu: "16"
+//	    memory: 64Gi
+//	  limits:
+//	    nvidia.com/gpu: "4"
+//
+// The parsed profiles are cached until the modification time or the size of the file changes,
+// so that its updates apply to the pods created afterwards without a restart of the operator.
+// The returned profiles must not be modified. No profiles are returned if the file doesn't exist.
+func LoadResourceProfiles(file string) (ResourceProfiles, error) {
+	if file == "" {
+		return nil, nil
+	}
+	info, err := os.Stat(file)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	cache := &resourceProfilesCache
+	cache.Lock()
+	defer cache.Unlock()
+	if cache.profiles != nil && cache.file == file && cache.modTime.Equal(info.ModTime()) && cache.size == info.Size() {
+		return cache.profiles, nil
+	}
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	profiles := ResourceProfiles{}
+	if err := yaml.UnmarshalStrict(b, &profiles); err != nil {
+		return nil, err
+	}
+	cache.file, cache.modTime, cache.size, cache.profiles = file, info.ModTime(), info.Size(), profiles
+	return profiles, nil
+}
+
+// ResourceProfile returns the requests and the limits of the resource profile of the operator
+// named name, or an error if the profile doesn't exist.
+func ResourceProfile(name string) (corev1.ResourceRequirements, error) {
+	profiles, err := LoadResourceProfiles(config.Config.ResourceProfilesFile)
+	if err != nil {
+		return corev1.ResourceRequirements{}, fmt.Errorf("invalid resource profiles file %s: %w", config.Config.ResourceProfilesFile, err)
+	}
+	profile, ok := profiles[name]
+	if !ok {
+		return corev1.ResourceRequirements{}, fmt.Errorf("unknown resource profile %q", name)
+	}
+	return profile, nil
+}
+
+// SetResourceProfile sets the requests and the limits of the resource profile named profile on
+// the main container containerName of the podTemplate. The requests and the limits already set
+// on the container take precedence. The profiles are resolved when the pods are created, so that
+// an unknown profile fails the creation of the pods rather than creating them without resources.
+func SetResourceProfile(podTemplate *corev1.PodTemplateSpec, containerName, profile string) error {
+	if profile == "" {
+		return nil
+	}
+	requirements, err := ResourceProfile(profile)
+	if err != nil {
+		return err
+	}
+	for i := range podTemplate.Spec.Containers {
+		container := &podTemplate.Spec.Containers[i]
+		if container.Name != containerName {
+			continue
+		}
+		container.Resources.Requests = mergeResourceList(container.Resources.Requests, requirements.Requests)
+		container.Resources.Limits = mergeResourceList(container.Resources.Limits, requirements.Limits)
+	}
+	return nil
+}
+
+// mergeResourceList adds the quantities of defaults missing in list to it.
+func mergeResourceList(list, defaults corev1.ResourceList) corev1.ResourceList {
+	for name, quantity := range defaults {
+		if _, ok := list[name]; ok {
+			continue
+		}
+		if list == nil {
+			list = corev1.ResourceList{}
+		}
+		list[name] = quantity.DeepCopy()
+	}
+	return list
+}
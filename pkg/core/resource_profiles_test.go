@@ -0,0 +1,157 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/kubeflow/training-operator/pkg/config"
+)
+
+const testResourceProfiles = `
+small:
+  requests:
+    cpu: "1"
+    memory: 4Gi
+gpu-large:
+  requests:
+    cpu: "16"
+    memory: 64Gi
+  limits:
+    memory: 64Gi
+    nvidia.com/gpu: "4"
+`
+
+func TestSetResourceProfile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "profiles.yaml")
+	if err := os.WriteFile(file, []byte(testResourceProfiles), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	defer func(file string) { config.Config.ResourceProfilesFile = file }(config.Config.ResourceProfilesFile)
+	config.Config.ResourceProfilesFile = file
+
+	cases := map[string]struct {
+		profile       string
+		containers    []corev1.Container
+		wantContainer []corev1.Container
+		wantErr       bool
+	}{
+		"no profile": {
+			containers:    []corev1.Container{{Name: "pytorch"}},
+			wantContainer: []corev1.Container{{Name: "pytorch"}},
+		},
+		"profile set on the main container": {
+			profile:    "gpu-large",
+			containers: []corev1.Container{{Name: "pytorch"}, {Name: "sidecar"}},
+			wantContainer: []corev1.Container{
+				{
+					Name: "pytorch",
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("16"),
+							corev1.ResourceMemory: resource.MustParse("64Gi"),
+						},
+						Limits: corev1.ResourceList{
+							corev1.ResourceMemory: resource.MustParse("64Gi"),
+							"nvidia.com/gpu":      resource.MustParse("4"),
+						},
+					},
+				},
+				{Name: "sidecar"},
+			},
+		},
+		"resources of the container take precedence": {
+			profile: "small",
+			containers: []corev1.Container{{
+				Name: "pytorch",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("8Gi")},
+				},
+			}},
+			wantContainer: []corev1.Container{{
+				Name: "pytorch",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("1"),
+						corev1.ResourceMemory: resource.MustParse("8Gi"),
+					},
+				},
+			}},
+		},
+		"unknown profile": {
+			profile:       "gpu-huge",
+			containers:    []corev1.Container{{Name: "pytorch"}},
+			wantContainer: []corev1.Container{{Name: "pytorch"}},
+			wantErr:       true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			podTemplate := &corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: tc.containers}}
+			err := SetResourceProfile(podTemplate, "pytorch", tc.profile)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Unexpected error, want error: %t, got: %v", tc.wantErr, err)
+			}
+			if diff := cmp.Diff(tc.wantContainer, podTemplate.Spec.Containers); len(diff) != 0 {
+				t.Errorf("Unexpected containers (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLoadResourceProfiles(t *testing.T) {
+	dir := t.TempDir()
+	if profiles, err := LoadResourceProfiles(filepath.Join(dir, "missing.yaml")); err != nil || len(profiles) != 0 {
+		t.Errorf("Unexpected profiles without file: %v, %v", profiles, err)
+	}
+	invalid := filepath.Join(dir, "invalid.yaml")
+	if err := os.WriteFile(invalid, []byte("small:\n  request: {}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadResourceProfiles(invalid); err == nil {
+		t.Errorf("Expected an error for an unknown field")
+	}
+
+	file := filepath.Join(dir, "profiles.yaml")
+	if err := os.WriteFile(file, []byte(testResourceProfiles), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	profiles, err := LoadResourceProfiles(file)
+	if err != nil || len(profiles) != 2 {
+		t.Fatalf("Unexpected profiles: %v, %v", profiles, err)
+	}
+	// The profiles are parsed again once the file is updated.
+	if err := os.WriteFile(file, []byte("small:\n  requests:\n    cpu: \"2\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	modTime := time.Now().Add(time.Minute)
+	if err := os.Chtimes(file, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+	profiles, err = LoadResourceProfiles(file)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(ResourceProfiles{"small": {Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")}}}, profiles); len(diff) != 0 {
+		t.Errorf("Unexpected profiles after the update (-want,+got):\n%s", diff)
+	}
+}
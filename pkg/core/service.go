@@ -19,9 +19,9 @@ package core
 import (
 	"fmt"
 
+	"github.com/go-logr/logr"
 	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
 	utillabels "github.com/kubeflow/training-operator/pkg/util/labels"
-	log "github.com/sirupsen/logrus"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/labels"
 )
@@ -47,16 +47,16 @@ func FilterServicesForReplicaType(services []*v1.Service, replicaType string) ([
 // GetServiceSlices returns a slice, which element is the slice of service.
 // Assume the return object is serviceSlices, then serviceSlices[i] is an
 // array of pointers to services corresponding to Services for replica i.
-func GetServiceSlices(services []*v1.Service, replicas int, logger *log.Entry) [][]*v1.Service {
+func GetServiceSlices(services []*v1.Service, replicas int, logger logr.Logger) [][]*v1.Service {
 	serviceSlices := make([][]*v1.Service, CalculateServiceSliceSize(services, replicas))
 	for _, service := range services {
 		index, err := utillabels.ReplicaIndex(service.Labels)
 		if err != nil {
-			logger.Warningf("Error obtaining index for service %s/%s: %v", service.Namespace, service.Name, err)
+			logger.Error(err, "Error obtaining replica index", "service", service.Name)
 			continue
 		}
 		if index < 0 || index >= replicas {
-			logger.Warningf("The label index is not expected: %d, service: %s/%s", index, service.Namespace, service.Name)
+			logger.Info("The label index is not expected", "service", service.Name, "index", index)
 		}
 
 		serviceSlices[index] = append(serviceSlices[index], service)
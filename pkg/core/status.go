@@ -47,4 +47,18 @@ func UpdateJobReplicaStatuses(jobStatus *apiv1.JobStatus, rtype apiv1.ReplicaTyp
 	case corev1.PodFailed:
 		jobStatus.ReplicaStatuses[rtype].Failed++
 	}
+	if reason, ok := ClassifyPodFailure(pod); ok {
+		jobStatus.ReplicaStatuses[rtype].FailureReason = reason
+	}
+	jobStatus.ReplicaStatuses[rtype].Pods = append(jobStatus.ReplicaStatuses[rtype].Pods, ReplicaPodStatus(pod))
+}
+
+// ReplicaPodStatus returns the state of the pod reported in the ReplicaStatus of its replica type.
+func ReplicaPodStatus(pod *corev1.Pod) apiv1.ReplicaPodStatus {
+	return apiv1.ReplicaPodStatus{
+		Name:   pod.Name,
+		Phase:  pod.Status.Phase,
+		HostIP: pod.Status.HostIP,
+		PodIP:  pod.Status.PodIP,
+	}
 }
@@ -0,0 +1,93 @@
+/*
+Copyright 2024 The Kubeflow Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+)
+
+// SetTopologyPolicy adds the pod affinity or the topology spread constraint of the placement of
+// the topologyPolicy to the podTemplate, with the pods of the job selected by jobLabels.
+func SetTopologyPolicy(podTemplateSpec *v1.PodTemplateSpec, topologyPolicy *apiv1.TopologyPolicy, jobLabels map[string]string) {
+	topologyKey := topologyPolicy.TopologyKey
+	if topologyKey == "" {
+		topologyKey = v1.LabelTopologyZone
+	}
+	switch topologyPolicy.Placement {
+	case apiv1.TopologyPlacementPack:
+		SetPreferredSameTopology(podTemplateSpec, topologyKey, jobLabels)
+	case apiv1.TopologyPlacementSame:
+		SetSameTopology(podTemplateSpec, topologyKey, jobLabels)
+	case apiv1.TopologyPlacementSpread:
+		SetTopologySpread(podTemplateSpec, topologyKey, jobLabels)
+	}
+}
+
+// SetSameTopology adds a required pod affinity to the podTemplate, so that the pod runs in the
+// same topology domain as the other pods of the job, selected by jobLabels. The scheduler lets
+// the first pod of the job, which matches its own affinity term, run in any domain.
+func SetSameTopology(podTemplateSpec *v1.PodTemplateSpec, topologyKey string, jobLabels map[string]string) {
+	if podTemplateSpec.Spec.Affinity == nil {
+		podTemplateSpec.Spec.Affinity = &v1.Affinity{}
+	}
+	if podTemplateSpec.Spec.Affinity.PodAffinity == nil {
+		podTemplateSpec.Spec.Affinity.PodAffinity = &v1.PodAffinity{}
+	}
+	podAffinity := podTemplateSpec.Spec.Affinity.PodAffinity
+	podAffinity.RequiredDuringSchedulingIgnoredDuringExecution = append(podAffinity.RequiredDuringSchedulingIgnoredDuringExecution,
+		v1.PodAffinityTerm{
+			LabelSelector: &metav1.LabelSelector{MatchLabels: jobLabels},
+			TopologyKey:   topologyKey,
+		})
+}
+
+// SetPreferredSameTopology adds a preferred pod affinity to the podTemplate, so that the scheduler
+// runs the pod in the same topology domain as the other pods of the job, selected by jobLabels,
+// when it can.
+func SetPreferredSameTopology(podTemplateSpec *v1.PodTemplateSpec, topologyKey string, jobLabels map[string]string) {
+	if podTemplateSpec.Spec.Affinity == nil {
+		podTemplateSpec.Spec.Affinity = &v1.Affinity{}
+	}
+	if podTemplateSpec.Spec.Affinity.PodAffinity == nil {
+		podTemplateSpec.Spec.Affinity.PodAffinity = &v1.PodAffinity{}
+	}
+	podAffinity := podTemplateSpec.Spec.Affinity.PodAffinity
+	podAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(podAffinity.PreferredDuringSchedulingIgnoredDuringExecution,
+		v1.WeightedPodAffinityTerm{
+			Weight: 100,
+			PodAffinityTerm: v1.PodAffinityTerm{
+				LabelSelector: &metav1.LabelSelector{MatchLabels: jobLabels},
+				TopologyKey:   topologyKey,
+			},
+		})
+}
+
+// SetTopologySpread adds a topology spread constraint to the podTemplate, so that the scheduler
+// spreads the pods of the job, selected by jobLabels, evenly across the topology domains when
+// it can.
+func SetTopologySpread(podTemplateSpec *v1.PodTemplateSpec, topologyKey string, jobLabels map[string]string) {
+	podTemplateSpec.Spec.TopologySpreadConstraints = append(podTemplateSpec.Spec.TopologySpreadConstraints,
+		v1.TopologySpreadConstraint{
+			MaxSkew:           1,
+			TopologyKey:       topologyKey,
+			WhenUnsatisfiable: v1.ScheduleAnyway,
+			LabelSelector:     &metav1.LabelSelector{MatchLabels: jobLabels},
+		})
+}
@@ -0,0 +1,132 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package features provides the feature gates of the training operator, which turn on
+// the features not enabled by default, set as --feature-gates=<feature>=<true|false>,...
+package features
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Feature is the name of a feature gate.
+type Feature string
+
+const (
+	// StatusDiffLogging logs a structured diff of the status of a job on each update of the
+	// status: the conditions added, changed or removed, the deltas of the replica counts and
+	// the timestamps set, to debug the transitions of the jobs.
+	StatusDiffLogging Feature = "StatusDiffLogging"
+
+	// QuotaBlockedRetry retries the creation of the pods of a job which is rejected because it
+	// would exceed a ResourceQuota of the namespace: the job is QuotaBlocked, and the creation is
+	// retried with a backoff or when a ResourceQuota of the namespace changes, instead of
+	// failing the reconciliations with the Forbidden errors of the API server.
+	QuotaBlockedRetry Feature = "QuotaBlockedRetry"
+
+	// QuotaPreCheck compares the requests of the missing pods of a job with the ResourceQuotas
+	// of the namespace before creating them: the job is QuotaExceeded and none of the pods is
+	// created while they don't all fit, instead of creating a partial gang.
+	QuotaPreCheck Feature = "QuotaPreCheck"
+
+	// LauncherAutoSizing defaults the CPU and memory requests of the launchers of the MPIJobs
+	// which do not set them from the number of ranks of the jobs, as the memory of mpirun and
+	// of the orted daemons grows with the ranks and default-sized launchers are OOM killed in
+	// the large jobs.
+	LauncherAutoSizing Feature = "LauncherAutoSizing"
+)
+
+// defaultFeatures are the known feature gates with their default values.
+var defaultFeatures = map[Feature]bool{
+	StatusDiffLogging:  false,
+	QuotaBlockedRetry:  false,
+	QuotaPreCheck:      false,
+	LauncherAutoSizing: false,
+}
+
+// Gates is a set of feature gates which implements flag.Value. It is safe for concurrent use.
+type Gates struct {
+	mu      sync.RWMutex
+	enabled map[Feature]bool
+}
+
+// Default are the feature gates of the operator.
+var Default = NewGates()
+
+// NewGates returns the known feature gates with their default values.
+func NewGates() *Gates {
+	enabled := make(map[Feature]bool, len(defaultFeatures))
+	for feature, value := range defaultFeatures {
+		enabled[feature] = value
+	}
+	return &Gates{enabled: enabled}
+}
+
+func (g *Gates) String() string {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	gates := make([]string, 0, len(g.enabled))
+	for feature, value := range g.enabled {
+		gates = append(gates, fmt.Sprintf("%s=%t", feature, value))
+	}
+	sort.Strings(gates)
+	return strings.Join(gates, ",")
+}
+
+// Set sets the feature gates of value, a comma separated list of <feature>=<true|false>.
+// Nothing is set if any of the features is unknown or has an invalid value.
+func (g *Gates) Set(value string) error {
+	values := map[Feature]bool{}
+	for _, gate := range strings.Split(value, ",") {
+		gate = strings.TrimSpace(gate)
+		if gate == "" {
+			continue
+		}
+		name, enabled, found := strings.Cut(gate, "=")
+		if !found {
+			return fmt.Errorf("%q must be set as <feature>=<true|false>", gate)
+		}
+		feature := Feature(strings.TrimSpace(name))
+		if _, ok := defaultFeatures[feature]; !ok {
+			return fmt.Errorf("unknown feature gate %q", feature)
+		}
+		b, err := strconv.ParseBool(strings.TrimSpace(enabled))
+		if err != nil {
+			return fmt.Errorf("invalid value %q of the feature gate %s", enabled, feature)
+		}
+		values[feature] = b
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for feature, b := range values {
+		g.enabled[feature] = b
+	}
+	return nil
+}
+
+// Enabled returns whether the feature is enabled.
+func (g *Gates) Enabled(feature Feature) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.enabled[feature]
+}
+
+// Enabled returns whether the feature is enabled in the feature gates of the operator.
+func Enabled(feature Feature) bool {
+	return Default.Enabled(feature)
+}
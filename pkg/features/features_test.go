@@ -0,0 +1,68 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package features
+
+import "testing"
+
+func TestGatesSet(t *testing.T) {
+	cases := map[string]struct {
+		value       string
+		wantErr     bool
+		wantEnabled bool
+	}{
+		"enabled": {
+			value:       "StatusDiffLogging=true",
+			wantEnabled: true,
+		},
+		"disabled": {
+			value: "StatusDiffLogging=false",
+		},
+		"spaces and empty gates": {
+			value:       " StatusDiffLogging = true ,",
+			wantEnabled: true,
+		},
+		"unknown feature": {
+			value:   "StatusDiffLogging=true,Unknown=true",
+			wantErr: true,
+		},
+		"invalid value": {
+			value:   "StatusDiffLogging=yes",
+			wantErr: true,
+		},
+		"missing value": {
+			value:   "StatusDiffLogging",
+			wantErr: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			gates := NewGates()
+			err := gates.Set(tc.value)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got := gates.Enabled(StatusDiffLogging); got != tc.wantEnabled {
+				t.Errorf("Unexpected StatusDiffLogging %t, want %t", got, tc.wantEnabled)
+			}
+		})
+	}
+}
+
+func TestGatesString(t *testing.T) {
+	gates := NewGates()
+	if got, want := gates.String(), "LauncherAutoSizing=false,QuotaBlockedRetry=false,QuotaPreCheck=false,StatusDiffLogging=false"; got != want {
+		t.Errorf("Unexpected gates %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,199 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package storageversion migrates the objects of the CRDs of the training operator to the
+// storage version of their CRD.
+package storageversion
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// listPageSize is the number of objects listed per page during a migration.
+const listPageSize = 500
+
+var crdGVK = schema.GroupVersionKind{Group: "apiextensions.k8s.io", Version: "v1", Kind: "CustomResourceDefinition"}
+
+var (
+	migratedObjectsCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "training_operator_storage_version_migrated_objects_total",
+			Help: "Counts number of objects rewritten in the storage version of their CRD",
+		},
+		[]string{"crd"},
+	)
+	remainingObjectsGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "training_operator_storage_version_migration_remaining_objects",
+			Help: "Number of objects of a CRD still to be rewritten in its storage version",
+		},
+		[]string{"crd"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(migratedObjectsCount, remainingObjectsGauge)
+}
+
+//+kubebuilder:rbac:groups="apiextensions.k8s.io",resources=customresourcedefinitions/status,verbs=update
+
+// Migrator rewrites the objects of the CRDs stored in other versions than the storage version of
+// their CRD, e.g. after the storage version of the MPIJobs is bumped, and then drops the old
+// versions from the storedVersions of the CRD, so that they can be removed from the CRD.
+//
+// An object is rewritten by an update which leaves it unchanged: the API server writes it again
+// since its encoding in the storage version differs from the stored one. The CRDs are handled as
+// unstructured objects, so that the operator doesn't depend on the apiextensions API.
+type Migrator struct {
+	// Client updates the objects and the CRDs.
+	Client client.Client
+	// Reader lists the objects without caching them.
+	Reader client.Reader
+	// CRDNames are the names of the CRDs to migrate. The CRDs which are not installed are skipped.
+	CRDNames []string
+	// Limiter throttles the updates of the objects, so that the migration doesn't overload the
+	// API server.
+	Limiter *rate.Limiter
+}
+
+// NewMigrator returns a Migrator of the CRDs updating at most qps objects per second.
+func NewMigrator(c client.Client, reader client.Reader, crdNames []string, qps float64) *Migrator {
+	burst := max(int(qps), 1)
+	return &Migrator{Client: c, Reader: reader, CRDNames: crdNames, Limiter: rate.NewLimiter(rate.Limit(qps), burst)}
+}
+
+// Start migrates the CRDs once. A CRD whose migration fails is logged, and is migrated again
+// on the next start of the operator.
+func (m *Migrator) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("storage-version-migrator")
+	for _, name := range m.CRDNames {
+		if err := m.migrate(ctx, name); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			logger.Error(err, "Failed to migrate the stored versions of the CRD", "crd", name)
+		}
+	}
+	return nil
+}
+
+// NeedLeaderElection returns true, so that only the leader rewrites the objects.
+func (m *Migrator) NeedLeaderElection() bool {
+	return true
+}
+
+// migrate rewrites the objects of the CRD name in its storage version, if it has objects stored
+// in other versions.
+func (m *Migrator) migrate(ctx context.Context, name string) error {
+	logger := log.FromContext(ctx).WithName("storage-version-migrator").WithValues("crd", name)
+	crd := &unstructured.Unstructured{}
+	crd.SetGroupVersionKind(crdGVK)
+	if err := m.Client.Get(ctx, client.ObjectKey{Name: name}, crd); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	gvk, err := storageGVK(crd)
+	if err != nil {
+		return err
+	}
+	storedVersions, _, err := unstructured.NestedStringSlice(crd.Object, "status", "storedVersions")
+	if err != nil {
+		return err
+	}
+	if len(storedVersions) == 0 || slices.Equal(storedVersions, []string{gvk.Version}) {
+		return nil
+	}
+
+	logger.Info("Migrating the objects to the storage version", "storedVersions", storedVersions, "storageVersion", gvk.Version)
+	if err := m.rewriteObjects(ctx, name, gvk); err != nil {
+		return err
+	}
+	// Only the storage version is left once all the objects are rewritten.
+	if err := unstructured.SetNestedStringSlice(crd.Object, []string{gvk.Version}, "status", "storedVersions"); err != nil {
+		return err
+	}
+	if err := m.Client.Status().Update(ctx, crd); err != nil {
+		return fmt.Errorf("failed to update the stored versions: %w", err)
+	}
+	logger.Info("Migrated the objects to the storage version", "storageVersion", gvk.Version)
+	return nil
+}
+
+// rewriteObjects rewrites all the objects of the kind in the storage version, page by page.
+func (m *Migrator) rewriteObjects(ctx context.Context, crdName string, gvk schema.GroupVersionKind) error {
+	listGVK := gvk.GroupVersion().WithKind(gvk.Kind + "List")
+	var objects []unstructured.Unstructured
+	continueToken := ""
+	for {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(listGVK)
+		if err := m.Reader.List(ctx, list, client.Limit(listPageSize), client.Continue(continueToken)); err != nil {
+			return err
+		}
+		objects = append(objects, list.Items...)
+		if continueToken = list.GetContinue(); continueToken == "" {
+			break
+		}
+	}
+
+	remaining := remainingObjectsGauge.WithLabelValues(crdName)
+	remaining.Set(float64(len(objects)))
+	for i := range objects {
+		if err := m.Limiter.Wait(ctx); err != nil {
+			return err
+		}
+		obj := &objects[i]
+		// A conflict means that the object was written since it was listed, in the storage version.
+		if err := m.Client.Update(ctx, obj); err != nil && !errors.IsNotFound(err) && !errors.IsConflict(err) {
+			return fmt.Errorf("failed to rewrite %s %s/%s: %w", gvk.Kind, obj.GetNamespace(), obj.GetName(), err)
+		}
+		migratedObjectsCount.WithLabelValues(crdName).Inc()
+		remaining.Dec()
+	}
+	return nil
+}
+
+// storageGVK returns the kind of the CRD in its storage version.
+func storageGVK(crd *unstructured.Unstructured) (schema.GroupVersionKind, error) {
+	group, _, _ := unstructured.NestedString(crd.Object, "spec", "group")
+	kind, _, _ := unstructured.NestedString(crd.Object, "spec", "names", "kind")
+	versions, _, err := unstructured.NestedSlice(crd.Object, "spec", "versions")
+	if err != nil {
+		return schema.GroupVersionKind{}, err
+	}
+	for _, v := range versions {
+		version, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if storage, _, _ := unstructured.NestedBool(version, "storage"); storage {
+			name, _, _ := unstructured.NestedString(version, "name")
+			return schema.GroupVersionKind{Group: group, Version: name, Kind: kind}, nil
+		}
+	}
+	return schema.GroupVersionKind{}, fmt.Errorf("no storage version in the CRD %s", crd.GetName())
+}
@@ -0,0 +1,104 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storageversion
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newCRD(name, kind string, storedVersions ...interface{}) *unstructured.Unstructured {
+	crd := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"group": "kubeflow.org",
+			"names": map[string]interface{}{"kind": kind},
+			"versions": []interface{}{
+				map[string]interface{}{"name": "v1", "served": true, "storage": false},
+				map[string]interface{}{"name": "v2", "served": true, "storage": true},
+			},
+		},
+		"status": map[string]interface{}{"storedVersions": storedVersions},
+	}}
+	crd.SetGroupVersionKind(crdGVK)
+	crd.SetName(name)
+	return crd
+}
+
+func newObject(gvk schema.GroupVersionKind, name string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	obj.SetNamespace("default")
+	obj.SetName(name)
+	return obj
+}
+
+func TestMigrator(t *testing.T) {
+	jobGVK := schema.GroupVersionKind{Group: "kubeflow.org", Version: "v2", Kind: "TestJob"}
+	migratedGVK := jobGVK.GroupVersion().WithKind("MigratedJob")
+	objects := []client.Object{
+		newCRD("testjobs.kubeflow.org", jobGVK.Kind, "v1", "v2"),
+		newCRD("migratedjobs.kubeflow.org", migratedGVK.Kind, "v2"),
+		newObject(migratedGVK, "migrated"),
+	}
+	for i := 0; i < 3; i++ {
+		objects = append(objects, newObject(jobGVK, fmt.Sprintf("test-%d", i)))
+	}
+	c := fake.NewClientBuilder().WithObjects(objects...).WithStatusSubresource(objects[0]).Build()
+	m := NewMigrator(c, c, []string{"testjobs.kubeflow.org", "migratedjobs.kubeflow.org", "missingjobs.kubeflow.org"}, 1000)
+
+	if err := m.Start(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for name, want := range map[string][]string{
+		"testjobs.kubeflow.org":     {"v2"},
+		"migratedjobs.kubeflow.org": {"v2"},
+	} {
+		crd := &unstructured.Unstructured{}
+		crd.SetGroupVersionKind(crdGVK)
+		if err := c.Get(context.Background(), client.ObjectKey{Name: name}, crd); err != nil {
+			t.Fatal(err)
+		}
+		got, _, _ := unstructured.NestedStringSlice(crd.Object, "status", "storedVersions")
+		if diff := cmp.Diff(want, got); len(diff) != 0 {
+			t.Errorf("Unexpected stored versions of %s (-want,+got):\n%s", name, diff)
+		}
+	}
+	if got := testutil.ToFloat64(migratedObjectsCount.WithLabelValues("testjobs.kubeflow.org")); got != 3 {
+		t.Errorf("Unexpected number of migrated objects, want: 3, got: %v", got)
+	}
+	if got := testutil.ToFloat64(remainingObjectsGauge.WithLabelValues("testjobs.kubeflow.org")); got != 0 {
+		t.Errorf("Unexpected number of remaining objects, want: 0, got: %v", got)
+	}
+	if got := testutil.ToFloat64(migratedObjectsCount.WithLabelValues("migratedjobs.kubeflow.org")); got != 0 {
+		t.Errorf("Expected the objects of the migrated CRD not to be rewritten, got: %v", got)
+	}
+	obj := newObject(jobGVK, "test-0")
+	if err := c.Get(context.Background(), client.ObjectKeyFromObject(obj), obj); err != nil {
+		t.Fatal(err)
+	}
+	// The fake client creates the objects with the resource version 999.
+	if obj.GetResourceVersion() == "999" {
+		t.Errorf("Expected the object to be rewritten")
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/clock"
+)
+
+// Clock is the source of the current time of the job controllers and the place where the
+// durations between the timestamps of a job are computed. These timestamps are stamped
+// either by the controller, e.g. the StartTime and the conditions, or by the API server,
+// e.g. the creation timestamps of the job and of its pods, so a timestamp may be slightly
+// ahead of another one which happened before it. The durations which are negative by at
+// most the skew tolerance are counted as zero.
+//
+// A nil Clock is the real clock with no skew tolerance.
+type Clock struct {
+	clock         clock.PassiveClock
+	skewTolerance time.Duration
+}
+
+// NewClock returns a Clock which reads the time from the clock and tolerates the given
+// skew between the timestamps of a job.
+func NewClock(clock clock.PassiveClock, skewTolerance time.Duration) *Clock {
+	return &Clock{clock: clock, skewTolerance: skewTolerance}
+}
+
+// Now returns the current time.
+func (c *Clock) Now() time.Time {
+	if c == nil || c.clock == nil {
+		return time.Now()
+	}
+	return c.clock.Now()
+}
+
+// MetaNow returns the current time as a metav1.Time, to stamp the status of a job.
+func (c *Clock) MetaNow() metav1.Time {
+	return metav1.NewTime(c.Now())
+}
+
+// Between returns the duration from start to end, or zero if end is before start
+// by no more than the skew tolerance.
+func (c *Clock) Between(start, end time.Time) time.Duration {
+	d := end.Sub(start)
+	if d < 0 && -d <= c.SkewTolerance() {
+		return 0
+	}
+	return d
+}
+
+// Since returns the duration elapsed since t, or zero if t is in the future by no more
+// than the skew tolerance.
+func (c *Clock) Since(t time.Time) time.Duration {
+	return c.Between(t, c.Now())
+}
+
+// SkewTolerance returns the tolerated skew between the timestamps of a job.
+func (c *Clock) SkewTolerance() time.Duration {
+	if c == nil {
+		return 0
+	}
+	return c.skewTolerance
+}
@@ -0,0 +1,44 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+func TestClock(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewClock(clocktesting.NewFakePassiveClock(now), 2*time.Second)
+
+	assert.Equal(t, now, clock.Now())
+	assert.Equal(t, 3*time.Second, clock.Since(now.Add(-3*time.Second)))
+	assert.Equal(t, time.Duration(0), clock.Since(now.Add(2*time.Second)), "a skew within the tolerance counts as zero")
+	assert.Equal(t, -3*time.Second, clock.Since(now.Add(3*time.Second)), "a skew beyond the tolerance is kept")
+	assert.Equal(t, time.Duration(0), clock.Between(now, now.Add(-time.Second)))
+	assert.Equal(t, 2*time.Second, clock.SkewTolerance())
+}
+
+func TestNilClock(t *testing.T) {
+	var clock *Clock
+	now := time.Now()
+
+	assert.False(t, clock.Now().Before(now))
+	assert.Equal(t, time.Duration(0), clock.SkewTolerance())
+	assert.Equal(t, -time.Second, clock.Between(now, now.Add(-time.Second)))
+}
@@ -0,0 +1,162 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/tools/reference"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+const (
+	// EventActionAnnotation is the annotation of an event with the action taken by the operator,
+	// the action of an events.k8s.io/v1 event.
+	EventActionAnnotation = "training.kubeflow.org/event-action"
+	// EventRelatedAnnotation is the annotation of an event with the object related to the event,
+	// such as the pod of the job created or deleted, as <kind>/<namespace>/<name>.
+	EventRelatedAnnotation = "training.kubeflow.org/event-related"
+
+	// EventActionReconcile is the action of the events emitted while reconciling a job.
+	EventActionReconcile = "Reconcile"
+	// EventActionCreate is the action of the events of the objects created for a job.
+	EventActionCreate = "Create"
+	// EventActionDelete is the action of the events of the objects deleted for a job.
+	EventActionDelete = "Delete"
+)
+
+// The annotations of the audit events emitted on the state changes of the jobs. The events.k8s.io/v1
+// events have no annotations, so their note also lists the annotations as key=value pairs.
+const (
+	// AuditJobKindAnnotation is the kind of the job.
+	AuditJobKindAnnotation = "training.kubeflow.org/job-kind"
+	// AuditJobUIDAnnotation is the UID of the job, which tells apart the jobs recreated with
+	// the same name.
+	AuditJobUIDAnnotation = "training.kubeflow.org/job-uid"
+	// AuditRunIDAnnotation is the run ID of the job.
+	AuditRunIDAnnotation = "training.kubeflow.org/run-id"
+	// AuditReasonAnnotation is the reason of the condition of the new state of the job, if any.
+	AuditReasonAnnotation = "training.kubeflow.org/reason"
+	// AuditFailureReasonAnnotation is the failure reason of the failed pods of a failed job, if known.
+	AuditFailureReasonAnnotation = "training.kubeflow.org/failure-reason"
+	// AuditReplicasAnnotation is the number of pods of every replica type of the job, as
+	// <replica type>=<pods> pairs separated by commas.
+	AuditReplicasAnnotation = "training.kubeflow.org/replicas"
+	// AuditTransitionTimeAnnotation is the time of the state change in RFC 3339 format, which
+	// is kept when the event is aggregated with other events.
+	AuditTransitionTimeAnnotation = "training.kubeflow.org/transition-time"
+)
+
+// RelatedEventRecorder is implemented by the EventRecorders which emit events with an action
+// and an object related to the object of the event, such as the events.k8s.io/v1 events.
+type RelatedEventRecorder interface {
+	RelatedEventf(object, related runtime.Object, eventtype, reason, action, messageFmt string, args ...interface{})
+}
+
+// RelatedEventf emits an event of the object with the action taken and the related object, if any.
+// The action and the related object are the fields of the event if the recorder emits
+// events.k8s.io/v1 events, and the annotations of the event otherwise.
+func RelatedEventf(recorder record.EventRecorder, object, related runtime.Object, eventtype, reason, action, messageFmt string, args ...interface{}) {
+	if r, ok := recorder.(RelatedEventRecorder); ok {
+		r.RelatedEventf(object, related, eventtype, reason, action, messageFmt, args...)
+		return
+	}
+	recorder.AnnotatedEventf(object, eventAnnotations(related, action), eventtype, reason, messageFmt, args...)
+}
+
+// eventAnnotations returns the annotations of an event with the action and the related object.
+func eventAnnotations(related runtime.Object, action string) map[string]string {
+	annotations := map[string]string{EventActionAnnotation: action}
+	if related == nil {
+		return annotations
+	}
+	if ref, err := reference.GetReference(scheme.Scheme, related); err == nil {
+		annotations[EventRelatedAnnotation] = fmt.Sprintf("%s/%s/%s", ref.Kind, ref.Namespace, ref.Name)
+	}
+	return annotations
+}
+
+// eventsRecorder is an EventRecorder which emits events.k8s.io/v1 events. The events emitted
+// without an action get the Reconcile action, which is required by the events.k8s.io/v1 API.
+type eventsRecorder struct {
+	recorder events.EventRecorder
+}
+
+// NewEventsRecorder returns an EventRecorder emitting the events through the events.k8s.io/v1 recorder.
+func NewEventsRecorder(recorder events.EventRecorder) record.EventRecorder {
+	return &eventsRecorder{recorder: recorder}
+}
+
+func (r *eventsRecorder) Event(object runtime.Object, eventtype, reason, message string) {
+	r.recorder.Eventf(object, nil, eventtype, reason, EventActionReconcile, "%s", message)
+}
+
+func (r *eventsRecorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	r.recorder.Eventf(object, nil, eventtype, reason, EventActionReconcile, messageFmt, args...)
+}
+
+func (r *eventsRecorder) AnnotatedEventf(object runtime.Object, annotations map[string]string, eventtype, reason, messageFmt string, args ...interface{}) {
+	action := annotations[EventActionAnnotation]
+	if len(action) == 0 {
+		action = EventActionReconcile
+	}
+	r.recorder.Eventf(object, nil, eventtype, reason, action, messageFmt, args...)
+}
+
+func (r *eventsRecorder) RelatedEventf(object, related runtime.Object, eventtype, reason, action, messageFmt string, args ...interface{}) {
+	r.recorder.Eventf(object, related, eventtype, reason, action, messageFmt, args...)
+}
+
+// eventBroadcasterRunnable records the events of the broadcaster to the API server while the manager
+// runs. It does not need the leader election, so that no event of the controllers is dropped
+// before the broadcaster starts.
+type eventBroadcasterRunnable struct {
+	broadcaster events.EventBroadcaster
+}
+
+func (r *eventBroadcasterRunnable) Start(ctx context.Context) error {
+	if err := r.broadcaster.StartRecordingToSinkWithContext(ctx); err != nil {
+		return err
+	}
+	<-ctx.Done()
+	r.broadcaster.Shutdown()
+	return nil
+}
+
+func (r *eventBroadcasterRunnable) NeedLeaderElection() bool {
+	return false
+}
+
+// NewEventRecorderFor returns an EventRecorder emitting events.k8s.io/v1 events reported by the
+// named controller, recorded to the API server while the manager runs. The core/v1 EventRecorder
+// of the manager is returned if the broadcaster cannot be added to the manager.
+func NewEventRecorderFor(mgr manager.Manager, name string) record.EventRecorder {
+	client, err := kubernetes.NewForConfig(mgr.GetConfig())
+	if err == nil {
+		broadcaster := events.NewBroadcaster(&events.EventSinkImpl{Interface: client.EventsV1()})
+		if err = mgr.Add(&eventBroadcasterRunnable{broadcaster: broadcaster}); err == nil {
+			return NewEventsRecorder(broadcaster.NewRecorder(mgr.GetScheme(), name))
+		}
+	}
+	log.Log.Error(err, "Falling back to core/v1 events", "controller", name)
+	return mgr.GetEventRecorderFor(name)
+}
@@ -0,0 +1,88 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+)
+
+// capturingRecorder is an events.k8s.io/v1 EventRecorder keeping the events emitted.
+type capturingRecorder struct {
+	events []string
+}
+
+func (r *capturingRecorder) Eventf(regarding, related runtime.Object, eventtype, reason, action, note string, args ...interface{}) {
+	relatedName := ""
+	if pod, ok := related.(*corev1.Pod); ok {
+		relatedName = pod.Name
+	}
+	r.events = append(r.events, fmt.Sprintf("%s %s %s %s %s", eventtype, reason, action, relatedName, fmt.Sprintf(note, args...)))
+}
+
+// annotatingRecorder is a core/v1 EventRecorder keeping the annotations of the events emitted.
+type annotatingRecorder struct {
+	annotations []map[string]string
+}
+
+func (r *annotatingRecorder) Event(object runtime.Object, eventtype, reason, message string) {}
+
+func (r *annotatingRecorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+}
+
+func (r *annotatingRecorder) AnnotatedEventf(object runtime.Object, annotations map[string]string, eventtype, reason, messageFmt string, args ...interface{}) {
+	r.annotations = append(r.annotations, annotations)
+}
+
+func TestEventsRecorder(t *testing.T) {
+	capturing := &capturingRecorder{}
+	recorder := NewEventsRecorder(capturing)
+	job := &apiv1.PyTorchJob{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"}}
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-worker-0"}}
+
+	recorder.Eventf(job, corev1.EventTypeNormal, "Reason", "Message %d", 1)
+	recorder.AnnotatedEventf(job, map[string]string{EventActionAnnotation: EventActionDelete}, corev1.EventTypeNormal, "Reason", "Message %d", 2)
+	RelatedEventf(recorder, job, pod, corev1.EventTypeNormal, "Reason", EventActionCreate, "Message %d", 3)
+	RelatedEventf(NewDeduplicatingRecorder(recorder, time.Minute), job, pod, corev1.EventTypeNormal, "Reason", EventActionCreate, "Message %d", 4)
+
+	assert.Equal(t, []string{
+		"Normal Reason Reconcile  Message 1",
+		"Normal Reason Delete  Message 2",
+		"Normal Reason Create test-worker-0 Message 3",
+		"Normal Reason Create test-worker-0 Message 4",
+	}, capturing.events)
+}
+
+func TestRelatedEventfAnnotations(t *testing.T) {
+	annotating := &annotatingRecorder{}
+	job := &apiv1.PyTorchJob{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"}}
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-worker-0"}}
+
+	RelatedEventf(annotating, job, pod, corev1.EventTypeNormal, "Reason", EventActionDelete, "Message")
+	RelatedEventf(annotating, job, nil, corev1.EventTypeWarning, "Reason", EventActionCreate, "Message")
+
+	assert.Equal(t, []map[string]string{
+		{EventActionAnnotation: EventActionDelete, EventRelatedAnnotation: "Pod/default/test-worker-0"},
+		{EventActionAnnotation: EventActionCreate},
+	}, annotating.annotations)
+}
@@ -75,13 +75,14 @@ func (c *CRDRestClient) Update(obj *metav1unstructured.Unstructured, plural stri
 	// TODO(jlewi): Can we just call obj.GetKind() to get the kind? I think that will return the singular
 	// not plural will that work?
 	if plural == "" {
-		logger.Errorf("Could not issue update because plural not set.")
-		return fmt.Errorf("plural must be set")
+		err := fmt.Errorf("plural must be set")
+		logger.Error(err, "Could not issue update because plural not set.")
+		return err
 	}
 	r := c.restcli.Put().Resource(plural).Namespace(obj.GetNamespace()).Name(obj.GetName()).Body(obj)
 	_, err := r.DoRaw(context.TODO())
 	if err != nil {
-		logger.Errorf("Could not issue update using URL: %v; error; %v", r.URL().String(), err)
+		logger.Error(err, "Could not issue update", "url", r.URL().String())
 	}
 	return err
 }
@@ -89,13 +90,14 @@ func (c *CRDRestClient) Update(obj *metav1unstructured.Unstructured, plural stri
 func (c *CRDRestClient) UpdateStatus(obj *metav1unstructured.Unstructured, plural string) error {
 	logger := util.LoggerForUnstructured(obj, obj.GetKind())
 	if plural == "" {
-		logger.Errorf("Could not issue update because plural not set.")
-		return fmt.Errorf("plural must be set")
+		err := fmt.Errorf("plural must be set")
+		logger.Error(err, "Could not issue update because plural not set.")
+		return err
 	}
 	r := c.restcli.Put().Resource(plural).Namespace(obj.GetNamespace()).Name(obj.GetName()).SubResource("status").Body(obj)
 	_, err := r.DoRaw(context.TODO())
 	if err != nil {
-		logger.Errorf("Could not issue update using URL: %v; error; %v", r.URL().String(), err)
+		logger.Error(err, "Could not issue update", "url", r.URL().String())
 	}
 	return err
 }
@@ -19,7 +19,6 @@ import (
 	"os"
 
 	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
-	log "github.com/sirupsen/logrus"
 	v1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -27,6 +26,7 @@ import (
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp" // for gcp auth
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 // RecommendedConfigPathEnvVar is a environment variable for path configuration
@@ -36,7 +36,8 @@ const RecommendedConfigPathEnvVar = "KUBECONFIG"
 func MustNewKubeClient() kubernetes.Interface {
 	cfg, err := GetClusterConfig()
 	if err != nil {
-		log.Fatal(err)
+		log.Log.Error(err, "Failed to get the cluster config")
+		os.Exit(1)
 	}
 	return kubernetes.NewForConfigOrDie(cfg)
 }
@@ -99,8 +100,8 @@ func FilterActivePods(pods []*v1.Pod) []*v1.Pod {
 		if IsPodActive(p) {
 			result = append(result, p)
 		} else {
-			log.Infof("Ignoring inactive pod %v/%v in state %v, deletion time %v",
-				p.Namespace, p.Name, p.Status.Phase, p.DeletionTimestamp)
+			log.Log.V(1).Info("Ignoring inactive pod", "namespace", p.Namespace, "pod", p.Name,
+				"phase", p.Status.Phase, "deletionTimestamp", p.DeletionTimestamp)
 		}
 	}
 	return result
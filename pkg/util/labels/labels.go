@@ -57,3 +57,7 @@ func HasKnownLabels(labels map[string]string, groupName string) bool {
 func SetJobRole(labels map[string]string, role string) {
 	labels[v1.JobRoleLabel] = role
 }
+
+func SetRunID(labels map[string]string, runID string) {
+	labels[v1.RunIDLabel] = runID
+}
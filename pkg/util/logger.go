@@ -15,82 +15,98 @@
 package util
 
 import (
-	"strings"
+	"reflect"
 
-	log "github.com/sirupsen/logrus"
+	"github.com/go-logr/logr"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	metav1unstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
-func LoggerForReplica(job metav1.Object, rtype string) *log.Entry {
-	return log.WithFields(log.Fields{
-		// We use job to match the key used in controller.go
-		// Its more common in K8s to use a period to indicate namespace.name. So that's what we use.
-		"job":          job.GetNamespace() + "." + job.GetName(),
-		"uid":          job.GetUID(),
-		"replica-type": rtype,
-	})
+// The loggers below derive from the logger of the manager and use the same keys
+// for the same fields across controllers: kind, namespace, name, uid, replicaType and index.
+
+func LoggerForReplica(job metav1.Object, rtype string) logr.Logger {
+	return LoggerForJob(job).WithValues("replicaType", rtype)
 }
 
-func LoggerForJob(job metav1.Object) *log.Entry {
-	return log.WithFields(log.Fields{
-		// We use job to match the key used in controller.go
-		// Its more common in K8s to use a period to indicate namespace.name. So that's what we use.
-		"job": job.GetNamespace() + "." + job.GetName(),
-		"uid": job.GetUID(),
-	})
+func LoggerForJob(job metav1.Object) logr.Logger {
+	return log.Log.WithValues(
+		"kind", kindOf(job),
+		"namespace", job.GetNamespace(),
+		"name", job.GetName(),
+		"uid", job.GetUID(),
+	)
 }
 
-func LoggerForPod(pod *v1.Pod, kind string) *log.Entry {
+func LoggerForPod(pod *v1.Pod, kind string) logr.Logger {
 	job := ""
 	if controllerRef := metav1.GetControllerOf(pod); controllerRef != nil {
 		if controllerRef.Kind == kind {
-			job = pod.Namespace + "." + controllerRef.Name
+			job = controllerRef.Name
 		}
 	}
-	return log.WithFields(log.Fields{
-		// We use job to match the key used in controller.go
-		// In controller.go we log the key used with the workqueue.
-		"job": job,
-		"pod": pod.Namespace + "." + pod.Name,
-		"uid": pod.ObjectMeta.UID,
-	})
+	return log.Log.WithValues(
+		"kind", kind,
+		"namespace", pod.Namespace,
+		"name", job,
+		"pod", pod.Name,
+		"uid", pod.UID,
+	)
 }
 
-func LoggerForService(svc *v1.Service, kind string) *log.Entry {
+func LoggerForService(svc *v1.Service, kind string) logr.Logger {
 	job := ""
 	if controllerRef := metav1.GetControllerOf(svc); controllerRef != nil {
 		if controllerRef.Kind == kind {
-			job = svc.Namespace + "." + controllerRef.Name
+			job = controllerRef.Name
 		}
 	}
-	return log.WithFields(log.Fields{
-		// We use job to match the key used in controller.go
-		// In controller.go we log the key used with the workqueue.
-		"job":     job,
-		"service": svc.Namespace + "." + svc.Name,
-		"uid":     svc.ObjectMeta.UID,
-	})
+	return log.Log.WithValues(
+		"kind", kind,
+		"namespace", svc.Namespace,
+		"name", job,
+		"service", svc.Name,
+		"uid", svc.UID,
+	)
 }
 
-func LoggerForKey(key string) *log.Entry {
-	return log.WithFields(log.Fields{
-		// The key used by the workQueue should be namespace + "/" + name.
-		// Its more common in K8s to use a period to indicate namespace.name. So that's what we use.
-		"job": strings.Replace(key, "/", ".", -1),
-	})
+func LoggerForKey(key string) logr.Logger {
+	// The key used by the workQueue should be namespace + "/" + name.
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return log.Log.WithValues("key", key)
+	}
+	return log.Log.WithValues("namespace", namespace, "name", name)
 }
 
-func LoggerForUnstructured(obj *metav1unstructured.Unstructured, kind string) *log.Entry {
+func LoggerForUnstructured(obj *metav1unstructured.Unstructured, kind string) logr.Logger {
 	job := ""
 	if obj.GetKind() == kind {
-		job = obj.GetNamespace() + "." + obj.GetName()
+		job = obj.GetName()
+	}
+	return log.Log.WithValues(
+		"kind", kind,
+		"namespace", obj.GetNamespace(),
+		"name", job,
+		"uid", obj.GetUID(),
+	)
+}
+
+// kindOf returns the kind of the object. The type name is used when the
+// TypeMeta is not set, as it is for objects read from the cache.
+func kindOf(obj metav1.Object) string {
+	if runtimeObject, ok := obj.(runtime.Object); ok {
+		if kind := runtimeObject.GetObjectKind().GroupVersionKind().Kind; kind != "" {
+			return kind
+		}
+	}
+	t := reflect.TypeOf(obj)
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
 	}
-	return log.WithFields(log.Fields{
-		// We use job to match the key used in controller.go
-		// In controller.go we log the key used with the workqueue.
-		"job": job,
-		"uid": obj.GetUID(),
-	})
+	return t.Name()
 }
@@ -0,0 +1,105 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package podsecurity checks pods against the Pod Security Standards enforced in their namespace,
+// so that pods which would be rejected by the PodSecurity admission are detected before creation.
+// The pods are evaluated by the policy evaluator of the admission itself.
+package podsecurity
+
+import (
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	admissionapi "k8s.io/pod-security-admission/admission/api"
+	"k8s.io/pod-security-admission/admission/api/load"
+	"k8s.io/pod-security-admission/api"
+	"k8s.io/pod-security-admission/policy"
+)
+
+// Checker evaluates the pods against the policy enforced in their namespace, with the defaults
+// and the exemptions of the configuration of the PodSecurity admission.
+type Checker struct {
+	evaluator  policy.Evaluator
+	defaults   api.Policy
+	exemptions admissionapi.PodSecurityExemptions
+	username   string
+}
+
+// NewChecker returns a Checker for the PodSecurityConfiguration of the admission in file, which
+// is the configuration of the admission of the API server, or for the default configuration if
+// file is empty. The pods are created by username, e.g. the service account of the operator,
+// which is matched against the exempt usernames.
+func NewChecker(file, username string) (*Checker, error) {
+	cfg, err := load.LoadFromFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the PodSecurityConfiguration %s: %w", file, err)
+	}
+	return newChecker(cfg, username)
+}
+
+func newChecker(cfg *admissionapi.PodSecurityConfiguration, username string) (*Checker, error) {
+	evaluator, err := policy.NewEvaluator(policy.DefaultChecks())
+	if err != nil {
+		return nil, err
+	}
+	defaults, err := admissionapi.ToPolicy(cfg.Defaults)
+	if err != nil {
+		return nil, fmt.Errorf("invalid defaults of the PodSecurityConfiguration: %w", err)
+	}
+	return &Checker{
+		evaluator:  evaluator,
+		defaults:   defaults,
+		exemptions: cfg.Exemptions,
+		username:   username,
+	}, nil
+}
+
+// Check returns the checks of the policy enforced in the namespace ns which the pod template
+// violates. As the PodSecurity admission does, the pods of the exempt namespaces, usernames and
+// RuntimeClasses are not evaluated, the enforce and enforce-version labels of the namespace take
+// precedence over the defaults, and invalid labels are evaluated as restricted:latest.
+func (c *Checker) Check(ns *corev1.Namespace, template *corev1.PodTemplateSpec) field.ErrorList {
+	if c.exempt(ns.Name, template.Spec.RuntimeClassName) {
+		return nil
+	}
+	nsPolicy, _ := api.PolicyToEvaluate(ns.Labels, c.defaults)
+	if nsPolicy.Enforce.Level == api.LevelPrivileged {
+		return nil
+	}
+	var allErrs field.ErrorList
+	for _, result := range c.evaluator.EvaluatePod(nsPolicy.Enforce, &template.ObjectMeta, &template.Spec) {
+		if result.Allowed {
+			continue
+		}
+		violation := result.ForbiddenReason
+		if result.ForbiddenDetail != "" {
+			violation = fmt.Sprintf("%s (%s)", result.ForbiddenReason, result.ForbiddenDetail)
+		}
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec"),
+			fmt.Sprintf("%s is forbidden by PodSecurity %q", violation, nsPolicy.Enforce.String())))
+	}
+	return allErrs
+}
+
+func (c *Checker) exempt(namespace string, runtimeClassName *string) bool {
+	if c.username != "" && slices.Contains(c.exemptions.Usernames, c.username) {
+		return true
+	}
+	if slices.Contains(c.exemptions.Namespaces, namespace) {
+		return true
+	}
+	return runtimeClassName != nil && *runtimeClassName != "" && slices.Contains(c.exemptions.RuntimeClasses, *runtimeClassName)
+}
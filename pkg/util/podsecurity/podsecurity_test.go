@@ -0,0 +1,230 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package podsecurity
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/pod-security-admission/api"
+	"k8s.io/utils/ptr"
+)
+
+const testConfiguration = `apiVersion: pod-security.admission.config.k8s.io/v1
+kind: PodSecurityConfiguration
+defaults:
+  enforce: baseline
+exemptions:
+  namespaces: [kube-system]
+  runtimeClasses: [kata]
+  usernames: ["system:serviceaccount:kubeflow:training-operator"]
+`
+
+func TestNewChecker(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(file, []byte(testConfiguration), 0o644); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	invalidFile := filepath.Join(dir, "invalid.yaml")
+	if err := os.WriteFile(invalidFile, []byte("kind: PodSecurityConfiguration\n"), 0o644); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	hostNetworkPod := &corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{HostNetwork: true, Containers: []corev1.Container{{Name: "pytorch"}}},
+	}
+	defaultNamespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: metav1.NamespaceDefault}}
+
+	cases := map[string]struct {
+		file         string
+		wantErr      bool
+		wantViolated bool
+	}{
+		"the default configuration is privileged": {},
+		"the defaults of the configuration are enforced": {
+			file:         file,
+			wantViolated: true,
+		},
+		"the configuration is invalid": {
+			file:    invalidFile,
+			wantErr: true,
+		},
+		"the configuration doesn't exist": {
+			file:    filepath.Join(dir, "missing.yaml"),
+			wantErr: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			checker, err := NewChecker(tc.file, "")
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Unexpected error, want error: %t, got: %v", tc.wantErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if violated := len(checker.Check(defaultNamespace, hostNetworkPod)) != 0; violated != tc.wantViolated {
+				t.Errorf("Unexpected violation, want: %t, got: %t", tc.wantViolated, violated)
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(file, []byte(testConfiguration), 0o644); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	restrictedContainer := corev1.Container{
+		Name: "pytorch",
+		SecurityContext: &corev1.SecurityContext{
+			AllowPrivilegeEscalation: ptr.To(false),
+			Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+		},
+	}
+	restrictedPodSC := &corev1.PodSecurityContext{
+		RunAsNonRoot:   ptr.To(true),
+		SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+	}
+	privilegedPod := &corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{
+			HostIPC: true,
+			Containers: []corev1.Container{{
+				Name:            "pytorch",
+				SecurityContext: &corev1.SecurityContext{Privileged: ptr.To(true)},
+			}},
+		},
+	}
+	cases := map[string]struct {
+		namespace      string
+		labels         map[string]string
+		username       string
+		template       *corev1.PodTemplateSpec
+		wantViolations []string
+	}{
+		"privileged level allows everything": {
+			labels:   map[string]string{api.EnforceLevelLabel: "privileged"},
+			template: privilegedPod,
+		},
+		"the default level forbids privileged containers and host namespaces": {
+			template: privilegedPod,
+			wantViolations: []string{
+				`host namespaces (hostIPC=true) is forbidden by PodSecurity "baseline:latest"`,
+				`privileged (container "pytorch" must not set securityContext.privileged=true) is forbidden by PodSecurity "baseline:latest"`,
+			},
+		},
+		"baseline level forbids unconfined seccomp profiles": {
+			labels: map[string]string{api.EnforceLevelLabel: "baseline"},
+			template: &corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					SecurityContext: &corev1.PodSecurityContext{
+						SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeUnconfined},
+					},
+					Containers: []corev1.Container{{Name: "pytorch"}},
+				},
+			},
+			wantViolations: []string{
+				`seccompProfile (pod must not set securityContext.seccompProfile.type to "Unconfined") is forbidden by PodSecurity "baseline:latest"`,
+			},
+		},
+		"restricted level allows a hardened pod": {
+			labels: map[string]string{api.EnforceLevelLabel: "restricted"},
+			template: &corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					SecurityContext: restrictedPodSC,
+					Containers:      []corev1.Container{restrictedContainer},
+				},
+			},
+		},
+		"restricted level requires hardening of every container": {
+			labels: map[string]string{api.EnforceLevelLabel: "restricted"},
+			template: &corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					SecurityContext: restrictedPodSC,
+					InitContainers:  []corev1.Container{{Name: "init"}},
+					Containers:      []corev1.Container{restrictedContainer},
+				},
+			},
+			wantViolations: []string{
+				`allowPrivilegeEscalation != false (container "init" must set securityContext.allowPrivilegeEscalation=false) is forbidden by PodSecurity "restricted:latest"`,
+				`unrestricted capabilities (container "init" must set securityContext.capabilities.drop=["ALL"]) is forbidden by PodSecurity "restricted:latest"`,
+			},
+		},
+		"the enforce version is honored": {
+			labels: map[string]string{api.EnforceLevelLabel: "restricted", api.EnforceVersionLabel: "v1.21"},
+			template: &corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					SecurityContext: restrictedPodSC,
+					Containers: []corev1.Container{{
+						Name:            "pytorch",
+						SecurityContext: &corev1.SecurityContext{AllowPrivilegeEscalation: ptr.To(false)},
+					}},
+				},
+			},
+		},
+		"invalid level is evaluated as restricted": {
+			labels: map[string]string{api.EnforceLevelLabel: "strict"},
+			template: &corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{SecurityContext: restrictedPodSC, Containers: []corev1.Container{{Name: "pytorch"}}},
+			},
+			wantViolations: []string{
+				`allowPrivilegeEscalation != false (container "pytorch" must set securityContext.allowPrivilegeEscalation=false) is forbidden by PodSecurity "restricted:latest"`,
+				`unrestricted capabilities (container "pytorch" must set securityContext.capabilities.drop=["ALL"]) is forbidden by PodSecurity "restricted:latest"`,
+			},
+		},
+		"exempt namespace": {
+			namespace: "kube-system",
+			template:  privilegedPod,
+		},
+		"exempt username": {
+			username: "system:serviceaccount:kubeflow:training-operator",
+			template: privilegedPod,
+		},
+		"exempt runtime class": {
+			template: &corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RuntimeClassName: ptr.To("kata"),
+					HostNetwork:      true,
+					Containers:       []corev1.Container{{Name: "pytorch"}},
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			checker, err := NewChecker(file, tc.username)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			namespace := tc.namespace
+			if namespace == "" {
+				namespace = metav1.NamespaceDefault
+			}
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace, Labels: tc.labels}}
+			var gotViolations []string
+			for _, err := range checker.Check(ns, tc.template) {
+				gotViolations = append(gotViolations, err.Detail)
+			}
+			if diff := cmp.Diff(tc.wantViolations, gotViolations); len(diff) != 0 {
+				t.Errorf("Unexpected violations (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
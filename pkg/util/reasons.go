@@ -0,0 +1,280 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import "fmt"
+
+// The reasons of the conditions and events of the jobs are matched by alerting rules and
+// dashboards, so their values must not change between releases. The reasons of this block
+// are prefixed by the kind of the job through NewReason, e.g. PyTorchJobRunning.
+const (
+	// JobCreatedReason is added in a job when it is created.
+	JobCreatedReason = "Created"
+	// JobSucceededReason is added in a job when it is succeeded.
+	JobSucceededReason = "Succeeded"
+	// JobRunningReason is added in a job when it is running.
+	JobRunningReason = "Running"
+	// JobFailedReason is added in a job when it is failed.
+	JobFailedReason = "Failed"
+	// JobRestartingReason is added in a job when it is restarting.
+	JobRestartingReason = "Restarting"
+	// JobFailedValidationReason is added in a job when it failed validation
+	JobFailedValidationReason = "FailedValidation"
+	// JobSuspendedReason is added in a job when it is suspended.
+	JobSuspendedReason = "Suspended"
+	// JobResumedReason is added in a job when it is unsuspended.
+	JobResumedReason = "Resumed"
+	// JobPodSecurityViolationReason is added in a job when its pods would be rejected
+	// by the PodSecurity admission of the namespace.
+	JobPodSecurityViolationReason = "PodSecurityViolation"
+	// JobStatusReconstructedReason is added in a job when its cleared status is rebuilt
+	// from the pods of the job.
+	JobStatusReconstructedReason = "StatusReconstructed"
+	// JobRestartRequestedReason is added in a job when a restart is requested through
+	// its restartedAt annotation.
+	JobRestartRequestedReason = "RestartRequested"
+	// JobNameCollisionReason is added in a job in the strict ownership mode when one of
+	// its children collides with an object of the same name which it does not control.
+	JobNameCollisionReason = "NameCollision"
+	// JobQueuedForRestartReason is added in a job when its restart waits for the restarts
+	// of other jobs, because of the maximum number of concurrent restarts of the operator.
+	JobQueuedForRestartReason = "QueuedForRestart"
+	// JobRestartDequeuedReason is added in a job when its restart no longer waits.
+	JobRestartDequeuedReason = "RestartDequeued"
+	// JobPendingApprovalReason is added in a job when its pods are rendered for review
+	// instead of being created.
+	JobPendingApprovalReason = "PendingApproval"
+	// JobApprovedReason is added in a job when the creation of its pods is approved.
+	JobApprovedReason = "Approved"
+	// JobNodeFailureReason is added in a job when its pods are recreated because their
+	// node has not been Ready for longer than the node failure timeout.
+	JobNodeFailureReason = "NodeFailure"
+	// JobPreemptedReason is added in a job when its pods are restarted because the node of
+	// one of them is being reclaimed, e.g. a spot instance.
+	JobPreemptedReason = "Preempted"
+	// JobPriorityPreemptedReason is added in an event when a running job is suspended to free
+	// resources for an unschedulable job of higher priority.
+	JobPriorityPreemptedReason = "PriorityPreempted"
+	// PreemptedLowerPriorityJobsReason is added in an event when the running jobs of lower
+	// priority are suspended to free resources for an unschedulable job.
+	PreemptedLowerPriorityJobsReason = "PreemptedLowerPriorityJobs"
+	// JobWaitingForDependenciesReason is added in a job when the objects referenced by the
+	// templates of its pods don't exist yet.
+	JobWaitingForDependenciesReason = "WaitingForDependencies"
+	// JobDependenciesReadyReason is added in a job when the objects referenced by the
+	// templates of its pods exist.
+	JobDependenciesReadyReason = "DependenciesReady"
+	// JobQueuedReason is added in a job when its PodGroup waits to be admitted by the
+	// gang scheduler.
+	JobQueuedReason = "Queued"
+	// JobUnschedulableReason is added in a job when some of its pods are reported as
+	// unschedulable by the scheduler.
+	JobUnschedulableReason = "Unschedulable"
+	// JobPullingImagesReason is added in a job when some of its pods wait for their images
+	// to be pulled while the job is starting.
+	JobPullingImagesReason = "PullingImages"
+	// JobQuotaExceededReason is added in a job when the creation of some of its pods is
+	// rejected by a ResourceQuota of the namespace.
+	JobQuotaExceededReason = "QuotaExceeded"
+	// JobQuotaAvailableReason is added in a job when its missing pods fit in the
+	// ResourceQuotas of the namespace.
+	JobQuotaAvailableReason = "QuotaAvailable"
+	// JobPendingReason is added in a job when it waits in its queue of the operator until the
+	// resources of the queue admit it.
+	JobPendingReason = "Pending"
+	// JobAdmittedReason is added in a job when it is admitted by its queue of the operator.
+	JobAdmittedReason = "Admitted"
+)
+
+// The reasons of the events of the jobs, which are not prefixed by the kind of the job.
+const (
+	// JobCompletedReason is the reason of the single summary event which is emitted
+	// when a job transitions into a terminal state.
+	JobCompletedReason = "JobCompleted"
+	// JobTerminatedReason is the reason of the event emitted when the PodGroup of a
+	// terminated job is deleted.
+	JobTerminatedReason = "JobTerminated"
+	// ExitedWithCodeReason is the normal reason when the pod is exited because of the exit code.
+	ExitedWithCodeReason = "ExitedWithCode"
+	// OOMKilledReason is the warning reason when a container of a job is OOMKilled.
+	OOMKilledReason = "OOMKilled"
+	// CheckpointFailedReason is the warning reason when the checkpoint command fails in a pod.
+	CheckpointFailedReason = "CheckpointFailed"
+	// QuitSidecarFailedReason is the warning reason when the Istio sidecar of a pod fails to quit.
+	QuitSidecarFailedReason = "QuitSidecarFailed"
+	// PodTemplateRestartPolicyReason is the warning reason when the restart
+	// policy is set in pod template.
+	PodTemplateRestartPolicyReason = "SetPodTemplateRestartPolicy"
+	// PodTemplateSchedulerNameReason is the warning reason when other scheduler name is set
+	// in pod templates with gang-scheduling enabled
+	PodTemplateSchedulerNameReason = "SetPodTemplateSchedulerName"
+	// PodTemplateLabelsReason is the warning reason when labels reserved to the controller
+	// are set in pod templates.
+	PodTemplateLabelsReason = "SetPodTemplateLabels"
+	// ResourceExistsReason is the warning reason when a child of a job can not be created
+	// because an object of the same name which is not managed by the job already exists.
+	ResourceExistsReason = "ErrResourceExists"
+	// ResourceDoesNotExistReason is the warning reason when a replica is missing in the
+	// spec of a job.
+	ResourceDoesNotExistReason = "ErrResourceDoesNotExist"
+	// AdoptedOrphanPodsReason is the reason of the events reporting the progress of the
+	// adoption of the orphan pods matching the labels of a job.
+	AdoptedOrphanPodsReason = "AdoptedOrphanPods"
+	// MPIJobEvictedReason is the reason when the launcher of an MPIJob is evicted.
+	MPIJobEvictedReason = "MPIJobEvicted"
+	// MPIJobPreflightCheckFailedReason is the reason when worker containers do not
+	// pass the pre-flight check.
+	MPIJobPreflightCheckFailedReason = "MPIJobPreflightCheckFailed"
+)
+
+// The reasons of the events of the creations and deletions of the children of the jobs.
+const (
+	// FailedCreatePodReason is added in an event and in a job condition
+	// when a pod for a replica set is failed to be created.
+	FailedCreatePodReason = "FailedCreatePod"
+	// SuccessfulCreatePodReason is added in an event when a pod for a job
+	// is successfully created.
+	SuccessfulCreatePodReason = "SuccessfulCreatePod"
+	// FailedDeletePodReason is added in an event and in a job condition
+	// when a pod for a replica set is failed to be deleted.
+	FailedDeletePodReason = "FailedDeletePod"
+	// SuccessfulDeletePodReason is added in an event when a pod for a job
+	// is successfully deleted.
+	SuccessfulDeletePodReason = "SuccessfulDeletePod"
+	// FailedCreateServiceReason is added in an event and in a job controller condition
+	// when a service for a job is failed to be created.
+	FailedCreateServiceReason = "FailedCreateService"
+	// SuccessfulCreateServiceReason is added in an event when a service for a job
+	// is successfully created.
+	SuccessfulCreateServiceReason = "SuccessfulCreateService"
+	// FailedDeleteServiceReason is added in an event and in a job condition
+	// when a service for a job is failed to be deleted.
+	FailedDeleteServiceReason = "FailedDeleteService"
+	// SuccessfulDeleteServiceReason is added in an event when a service for a job
+	// is successfully deleted.
+	SuccessfulDeleteServiceReason = "SuccessfulDeleteService"
+	// FailedDeleteJobReason is added in an event when a job or the batch/v1 Job of
+	// the launcher of an MPIJob is failed to be deleted.
+	FailedDeleteJobReason = "FailedDeleteJob"
+	// SuccessfulDeleteJobReason is added in an event when a job or the batch/v1 Job
+	// of the launcher of an MPIJob is successfully deleted.
+	SuccessfulDeleteJobReason = "SuccessfulDeleteJob"
+	// FailedDeletePodGroupReason is added in an event when the PodGroup of a job
+	// is failed to be deleted.
+	FailedDeletePodGroupReason = "FailedDeletePodGroup"
+	// SuccessfulDeletePodGroupReason is added in an event when the PodGroup of a job
+	// is successfully deleted.
+	SuccessfulDeletePodGroupReason = "SuccessfulDeletePodGroup"
+	// OrphanedPodGroupReason is added in an event when the PodGroup of a job can not be
+	// deleted because the operator has no client for the PodGroups of its gang scheduler.
+	OrphanedPodGroupReason = "OrphanedPodGroup"
+	// FailedCreateRayClusterReason is added in an event when the RayCluster of a job
+	// is failed to be created.
+	FailedCreateRayClusterReason = "FailedCreateRayCluster"
+	// SuccessfulCreateRayClusterReason is added in an event when the RayCluster of a job
+	// is successfully created.
+	SuccessfulCreateRayClusterReason = "SuccessfulCreateRayCluster"
+	// FailedDeleteRayClusterReason is added in an event when the RayCluster of a job
+	// is failed to be deleted.
+	FailedDeleteRayClusterReason = "FailedDeleteRayCluster"
+	// SuccessfulDeleteRayClusterReason is added in an event when the RayCluster of a job
+	// is successfully deleted.
+	SuccessfulDeleteRayClusterReason = "SuccessfulDeleteRayCluster"
+	// SuccessfulCreateServiceAccountReason is added in an event when the ServiceAccount
+	// of the launcher of an MPIJob is successfully created.
+	SuccessfulCreateServiceAccountReason = "SuccessfulCreateServiceAccount"
+	// SuccessfulCreateRoleReason is added in an event when the Role of the launcher
+	// of an MPIJob is successfully created.
+	SuccessfulCreateRoleReason = "SuccessfulCreateRole"
+	// SuccessfulCreateRoleBindingReason is added in an event when the RoleBinding of
+	// the launcher of an MPIJob is successfully created.
+	SuccessfulCreateRoleBindingReason = "SuccessfulCreateRoleBinding"
+	// FailedCreateNetworkPolicyReason is added in an event when the NetworkPolicy of a job
+	// is failed to be created.
+	FailedCreateNetworkPolicyReason = "FailedCreateNetworkPolicy"
+	// SuccessfulCreateNetworkPolicyReason is added in an event when the NetworkPolicy of a job
+	// is successfully created.
+	SuccessfulCreateNetworkPolicyReason = "SuccessfulCreateNetworkPolicy"
+	// SuccessfulUpdateNetworkPolicyReason is added in an event when the NetworkPolicy of a job
+	// is successfully updated with the allowed CIDRs of the operator.
+	SuccessfulUpdateNetworkPolicyReason = "SuccessfulUpdateNetworkPolicy"
+	// FailedCreatePodDisruptionBudgetReason is added in an event when the PodDisruptionBudget
+	// of a running job is failed to be created.
+	FailedCreatePodDisruptionBudgetReason = "FailedCreatePodDisruptionBudget"
+	// SuccessfulCreatePodDisruptionBudgetReason is added in an event when the PodDisruptionBudget
+	// of a running job is successfully created.
+	SuccessfulCreatePodDisruptionBudgetReason = "SuccessfulCreatePodDisruptionBudget"
+	// FailedDeletePodDisruptionBudgetReason is added in an event when the PodDisruptionBudget
+	// of a job is failed to be deleted.
+	FailedDeletePodDisruptionBudgetReason = "FailedDeletePodDisruptionBudget"
+	// SuccessfulDeletePodDisruptionBudgetReason is added in an event when the PodDisruptionBudget
+	// of a job is successfully deleted.
+	SuccessfulDeletePodDisruptionBudgetReason = "SuccessfulDeletePodDisruptionBudget"
+	// FailedCreateTensorBoardReason is added in an event when the Deployment or the Service of
+	// the TensorBoard of a job is failed to be created.
+	FailedCreateTensorBoardReason = "FailedCreateTensorBoard"
+	// SuccessfulCreateTensorBoardReason is added in an event when the Deployment or the Service
+	// of the TensorBoard of a job is successfully created.
+	SuccessfulCreateTensorBoardReason = "SuccessfulCreateTensorBoard"
+	// FailedUpdateTensorBoardReason is added in an event when the Deployment or the Service of
+	// the TensorBoard of a job is failed to be patched.
+	FailedUpdateTensorBoardReason = "FailedUpdateTensorBoard"
+	// SuccessfulUpdateTensorBoardReason is added in an event when the Deployment or the Service
+	// of the TensorBoard of a job is successfully patched.
+	SuccessfulUpdateTensorBoardReason = "SuccessfulUpdateTensorBoard"
+	// FailedDeleteTensorBoardReason is added in an event when the Deployment or the Service of
+	// the TensorBoard of a job is failed to be deleted.
+	FailedDeleteTensorBoardReason = "FailedDeleteTensorBoard"
+	// SuccessfulDeleteTensorBoardReason is added in an event when the Deployment or the Service
+	// of the TensorBoard of a job is successfully deleted.
+	SuccessfulDeleteTensorBoardReason = "SuccessfulDeleteTensorBoard"
+	// OutputsRecordedReason is added in an event when the URIs of the outputs of a succeeded job
+	// are recorded in its status.
+	OutputsRecordedReason = "OutputsRecorded"
+	// MissingOutputsReason is added in an event when the training of a succeeded job didn't write
+	// the URIs of some of its outputs.
+	MissingOutputsReason = "MissingOutputs"
+	// MetricsCollectorFailedReason is added in an event when the metrics collector requested by
+	// the annotations of a job can not be injected into the pod of its master role.
+	MetricsCollectorFailedReason = "MetricsCollectorFailed"
+)
+
+// The reasons of the audit events emitted on the state changes of the jobs, which are not
+// prefixed by the kind of the job, so that the jobs of all the kinds can be audited and billed
+// from their events alone.
+const (
+	// AuditJobCreatedReason is the reason of the audit event of a job which is created.
+	AuditJobCreatedReason = "JobCreated"
+	// AuditPodsCreatedReason is the reason of the audit event of a job whose first pods
+	// are created.
+	AuditPodsCreatedReason = "PodsCreated"
+	// AuditGangScheduledReason is the reason of the audit event of a job whose PodGroup
+	// is admitted by the gang scheduler.
+	AuditGangScheduledReason = "GangScheduled"
+	// AuditJobRunningReason is the reason of the audit event of a job which starts running,
+	// including after a restart.
+	AuditJobRunningReason = "JobRunning"
+	// AuditJobRestartingReason is the reason of the audit event of a job which restarts.
+	AuditJobRestartingReason = "JobRestarting"
+	// AuditJobSucceededReason is the reason of the audit event of a job which succeeds.
+	AuditJobSucceededReason = "JobSucceeded"
+	// AuditJobFailedReason is the reason of the audit event of a job which fails.
+	AuditJobFailedReason = "JobFailed"
+)
+
+// NewReason returns the reason of a condition or an event of a job of the kind.
+func NewReason(kind, reason string) string {
+	return fmt.Sprintf("%s%s", kind, reason)
+}
@@ -0,0 +1,42 @@
+package util
+
+import "testing"
+
+// TestReasonValues pins the values of the reasons, since alerting rules and dashboards match them.
+func TestReasonValues(t *testing.T) {
+	cases := []struct{ got, want string }{
+		{JobRunningReason, "Running"},
+		{JobRestartingReason, "Restarting"},
+		{JobFailedReason, "Failed"},
+		{JobCompletedReason, "JobCompleted"},
+		{ExitedWithCodeReason, "ExitedWithCode"},
+		{JobPendingReason, "Pending"},
+		{JobAdmittedReason, "Admitted"},
+		{OOMKilledReason, "OOMKilled"},
+		{PodTemplateRestartPolicyReason, "SetPodTemplateRestartPolicy"},
+		{PodTemplateSchedulerNameReason, "SetPodTemplateSchedulerName"},
+		{PodTemplateLabelsReason, "SetPodTemplateLabels"},
+		{ResourceExistsReason, "ErrResourceExists"},
+		{MPIJobEvictedReason, "MPIJobEvicted"},
+		{FailedCreatePodReason, "FailedCreatePod"},
+		{SuccessfulDeleteServiceReason, "SuccessfulDeleteService"},
+		{FailedDeletePodGroupReason, "FailedDeletePodGroup"},
+		{SuccessfulCreateRayClusterReason, "SuccessfulCreateRayCluster"},
+		{SuccessfulCreateNetworkPolicyReason, "SuccessfulCreateNetworkPolicy"},
+		{JobPreemptedReason, "Preempted"},
+		{JobPriorityPreemptedReason, "PriorityPreempted"},
+		{PreemptedLowerPriorityJobsReason, "PreemptedLowerPriorityJobs"},
+		{SuccessfulDeletePodDisruptionBudgetReason, "SuccessfulDeletePodDisruptionBudget"},
+		{SuccessfulCreateTensorBoardReason, "SuccessfulCreateTensorBoard"},
+		{OutputsRecordedReason, "OutputsRecorded"},
+		{MetricsCollectorFailedReason, "MetricsCollectorFailed"},
+	}
+	for _, tc := range cases {
+		if tc.got != tc.want {
+			t.Errorf("Unexpected reason, want: %q, got: %q", tc.want, tc.got)
+		}
+	}
+	if got, want := NewReason("PyTorchJob", JobRunningReason), "PyTorchJobRunning"; got != want {
+		t.Errorf("Unexpected reason, want: %q, got: %q", want, got)
+	}
+}
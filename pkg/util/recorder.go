@@ -0,0 +1,120 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
+)
+
+// eventKey identifies the events which are duplicates of each other.
+type eventKey struct {
+	uid       types.UID
+	namespace string
+	name      string
+	eventType string
+	reason    string
+	message   string
+}
+
+// deduplicatingRecorder is an EventRecorder which drops the events identical to an event
+// already emitted for the same object within the window.
+type deduplicatingRecorder struct {
+	recorder record.EventRecorder
+	window   time.Duration
+	clock    clock.Clock
+
+	mu        sync.Mutex
+	lastSeen  map[eventKey]time.Time
+	lastSweep time.Time
+}
+
+// NewDeduplicatingRecorder returns an EventRecorder which emits an event through the recorder
+// only if no identical event (same object, type, reason and message) was emitted within the window,
+// so that the events emitted on every reconcile do not flood the API server.
+// The recorder is returned as is if the window is not positive.
+func NewDeduplicatingRecorder(recorder record.EventRecorder, window time.Duration) record.EventRecorder {
+	return newDeduplicatingRecorder(recorder, window, clock.RealClock{})
+}
+
+func newDeduplicatingRecorder(recorder record.EventRecorder, window time.Duration, clock clock.Clock) record.EventRecorder {
+	if window <= 0 {
+		return recorder
+	}
+	return &deduplicatingRecorder{
+		recorder:  recorder,
+		window:    window,
+		clock:     clock,
+		lastSeen:  map[eventKey]time.Time{},
+		lastSweep: clock.Now(),
+	}
+}
+
+func (r *deduplicatingRecorder) Event(object runtime.Object, eventtype, reason, message string) {
+	if r.shouldEmit(object, eventtype, reason, message) {
+		r.recorder.Event(object, eventtype, reason, message)
+	}
+}
+
+func (r *deduplicatingRecorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	r.Event(object, eventtype, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+func (r *deduplicatingRecorder) AnnotatedEventf(object runtime.Object, annotations map[string]string, eventtype, reason, messageFmt string, args ...interface{}) {
+	message := fmt.Sprintf(messageFmt, args...)
+	if r.shouldEmit(object, eventtype, reason, message) {
+		r.recorder.AnnotatedEventf(object, annotations, eventtype, reason, "%s", message)
+	}
+}
+
+func (r *deduplicatingRecorder) RelatedEventf(object, related runtime.Object, eventtype, reason, action, messageFmt string, args ...interface{}) {
+	message := fmt.Sprintf(messageFmt, args...)
+	if r.shouldEmit(object, eventtype, reason, message) {
+		RelatedEventf(r.recorder, object, related, eventtype, reason, action, "%s", message)
+	}
+}
+
+// shouldEmit returns true if the event was not emitted within the window, and records it as emitted.
+func (r *deduplicatingRecorder) shouldEmit(object runtime.Object, eventtype, reason, message string) bool {
+	key := eventKey{eventType: eventtype, reason: reason, message: message}
+	if accessor, err := meta.Accessor(object); err == nil {
+		key.uid, key.namespace, key.name = accessor.GetUID(), accessor.GetNamespace(), accessor.GetName()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := r.clock.Now()
+	// Forget the expired events from time to time, so that the events of the deleted jobs are not kept.
+	if now.Sub(r.lastSweep) >= r.window {
+		for k, seen := range r.lastSeen {
+			if now.Sub(seen) >= r.window {
+				delete(r.lastSeen, k)
+			}
+		}
+		r.lastSweep = now
+	}
+	if seen, ok := r.lastSeen[key]; ok && now.Sub(seen) < r.window {
+		return false
+	}
+	r.lastSeen[key] = now
+	return true
+}
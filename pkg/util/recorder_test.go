@@ -0,0 +1,60 @@
+// Copyright 2024 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	clocktesting "k8s.io/utils/clock/testing"
+
+	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+)
+
+func TestDeduplicatingRecorder(t *testing.T) {
+	fakeRecorder := record.NewFakeRecorder(10)
+	fakeClock := clocktesting.NewFakeClock(time.Now())
+	recorder := newDeduplicatingRecorder(fakeRecorder, time.Minute, fakeClock)
+	first := &apiv1.MPIJob{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "first", UID: "1"}}
+	second := &apiv1.MPIJob{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "second", UID: "2"}}
+
+	recorder.Eventf(first, corev1.EventTypeNormal, "Reason", "Message %d", 1)
+	recorder.Eventf(first, corev1.EventTypeNormal, "Reason", "Message %d", 1)
+	recorder.Event(first, corev1.EventTypeNormal, "Reason", "Message 2")
+	recorder.Event(second, corev1.EventTypeNormal, "Reason", "Message 1")
+	fakeClock.Step(time.Minute)
+	recorder.Event(first, corev1.EventTypeNormal, "Reason", "Message 1")
+	close(fakeRecorder.Events)
+
+	var got []string
+	for event := range fakeRecorder.Events {
+		got = append(got, event)
+	}
+	assert.Equal(t, []string{
+		"Normal Reason Message 1",
+		"Normal Reason Message 2",
+		"Normal Reason Message 1",
+		"Normal Reason Message 1",
+	}, got)
+}
+
+func TestDeduplicatingRecorderDisabled(t *testing.T) {
+	fakeRecorder := record.NewFakeRecorder(10)
+	assert.Same(t, fakeRecorder, NewDeduplicatingRecorder(fakeRecorder, 0))
+}
@@ -2,6 +2,7 @@ package util
 
 import (
 	"fmt"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -9,29 +10,6 @@ import (
 	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
 )
 
-const (
-	// JobCreatedReason is added in a job when it is created.
-	JobCreatedReason = "Created"
-	// JobSucceededReason is added in a job when it is succeeded.
-	JobSucceededReason = "Succeeded"
-	// JobRunningReason is added in a job when it is running.
-	JobRunningReason = "Running"
-	// JobFailedReason is added in a job when it is failed.
-	JobFailedReason = "Failed"
-	// JobRestartingReason is added in a job when it is restarting.
-	JobRestartingReason = "Restarting"
-	// JobFailedValidationReason is added in a job when it failed validation
-	JobFailedValidationReason = "FailedValidation"
-	// JobSuspendedReason is added in a job when it is suspended.
-	JobSuspendedReason = "Suspended"
-	// JobResumedReason is added in a job when it is unsuspended.
-	JobResumedReason = "Resumed"
-)
-
-func NewReason(kind, reason string) string {
-	return fmt.Sprintf("%s%s", kind, reason)
-}
-
 // IsFinished checks if the job is succeeded or failed
 func IsFinished(status apiv1.JobStatus) bool {
 	return IsSucceeded(status) || IsFailed(status)
@@ -47,6 +25,10 @@ func IsFailed(status apiv1.JobStatus) bool {
 	return isStatusConditionTrue(status, apiv1.JobFailed)
 }
 
+func IsCreated(status apiv1.JobStatus) bool {
+	return isStatusConditionTrue(status, apiv1.JobCreated)
+}
+
 func IsRunning(status apiv1.JobStatus) bool {
 	return isStatusConditionTrue(status, apiv1.JobRunning)
 }
@@ -55,14 +37,129 @@ func IsSuspended(status apiv1.JobStatus) bool {
 	return isStatusConditionTrue(status, apiv1.JobSuspended)
 }
 
-// UpdateJobConditions adds to the jobStatus a new condition if needed, with the conditionType, reason, and message
+func IsRestarting(status apiv1.JobStatus) bool {
+	return isStatusConditionTrue(status, apiv1.JobRestarting)
+}
+
+func IsQueuedForRestart(status apiv1.JobStatus) bool {
+	return isStatusConditionTrue(status, apiv1.JobQueuedForRestart)
+}
+
+func IsPendingApproval(status apiv1.JobStatus) bool {
+	return isStatusConditionTrue(status, apiv1.JobPendingApproval)
+}
+
+func IsWaitingForDependencies(status apiv1.JobStatus) bool {
+	return isStatusConditionTrue(status, apiv1.JobWaitingForDependencies)
+}
+
+func IsQueued(status apiv1.JobStatus) bool {
+	return isStatusConditionTrue(status, apiv1.JobQueued)
+}
+
+func IsScheduling(status apiv1.JobStatus) bool {
+	return isStatusConditionTrue(status, apiv1.JobScheduling)
+}
+
+func IsPullingImages(status apiv1.JobStatus) bool {
+	return isStatusConditionTrue(status, apiv1.JobPullingImages)
+}
+
+func IsQuotaBlocked(status apiv1.JobStatus) bool {
+	return isStatusConditionTrue(status, apiv1.JobQuotaBlocked)
+}
+
+func IsQuotaExceeded(status apiv1.JobStatus) bool {
+	return isStatusConditionTrue(status, apiv1.JobQuotaExceeded)
+}
+
+func IsPending(status apiv1.JobStatus) bool {
+	return isStatusConditionTrue(status, apiv1.JobPending)
+}
+
+// FinishJob moves the job into the terminal condition, JobSucceeded or JobFailed, with the reason
+// and the message. The completion time and the duration of the job are set once, and the first
+// terminal condition of the job is kept, so that a job is never both succeeded and failed. It
+// returns false if the job was already finished.
+func FinishJob(jobStatus *apiv1.JobStatus, conditionType apiv1.JobConditionType, reason, message string, clock *Clock) bool {
+	finished := IsFinished(*jobStatus)
+	if jobStatus.CompletionTime == nil {
+		now := clock.MetaNow()
+		jobStatus.CompletionTime = &now
+	}
+	if jobStatus.Duration == nil && jobStatus.StartTime != nil {
+		duration := clock.Between(jobStatus.StartTime.Time, jobStatus.CompletionTime.Time).Round(time.Second)
+		jobStatus.Duration = &metav1.Duration{Duration: duration}
+	}
+	if finished {
+		return false
+	}
+	UpdateJobConditions(jobStatus, conditionType, v1.ConditionTrue, reason, message, clock)
+	return true
+}
+
+// JobPhase rolls up the phase of the job from its conditions. The phase is empty until a
+// condition is reported, and Created until the job is running, suspended or finished.
+func JobPhase(status apiv1.JobStatus) apiv1.JobPhase {
+	switch {
+	case len(status.Conditions) == 0:
+		return ""
+	case IsSucceeded(status):
+		return apiv1.JobPhaseSucceeded
+	case IsFailed(status):
+		return apiv1.JobPhaseFailed
+	case IsSuspended(status):
+		return apiv1.JobPhaseSuspended
+	case IsRestarting(status):
+		return apiv1.JobPhaseRestarting
+	case IsRunning(status):
+		return apiv1.JobPhaseRunning
+	default:
+		return apiv1.JobPhaseCreated
+	}
+}
+
+// AllReplicasSucceeded checks if all replicas of the given type have succeeded.
+// It returns true if the job does not have the given replica type.
+func AllReplicasSucceeded(replicas map[apiv1.ReplicaType]*apiv1.ReplicaSpec, status apiv1.JobStatus, rtype apiv1.ReplicaType) bool {
+	spec, ok := replicas[rtype]
+	if !ok || spec == nil || spec.Replicas == nil {
+		return true
+	}
+	rStatus, ok := status.ReplicaStatuses[rtype]
+	if !ok || rStatus == nil {
+		return false
+	}
+	return rStatus.Succeeded >= *spec.Replicas
+}
+
+// SuccessPolicyMessage describes the success policy which marked the job as succeeded,
+// it is appended to the message of the Succeeded condition.
+func SuccessPolicyMessage(policy *apiv1.SuccessPolicy) string {
+	successPolicy := apiv1.SuccessPolicyDefault
+	if policy != nil {
+		successPolicy = *policy
+	}
+	switch successPolicy {
+	case apiv1.SuccessPolicyAllWorkers:
+		return fmt.Sprintf("All workers succeeded (successPolicy: %s).", successPolicy)
+	case apiv1.SuccessPolicyChiefOrMaster:
+		return fmt.Sprintf("Chief/Master replica succeeded (successPolicy: %s).", successPolicy)
+	default:
+		return "Default success policy is satisfied."
+	}
+}
+
+// UpdateJobConditions adds to the jobStatus a new condition if needed, with the conditionType, reason, and message,
+// stamped with the current time of the clock.
 func UpdateJobConditions(
 	jobStatus *apiv1.JobStatus,
 	conditionType apiv1.JobConditionType,
 	conditionStatus v1.ConditionStatus,
 	reason, message string,
+	clock *Clock,
 ) {
-	condition := newCondition(conditionType, conditionStatus, reason, message)
+	condition := newCondition(conditionType, conditionStatus, reason, message, clock.MetaNow())
 	setCondition(jobStatus, condition)
 }
 
@@ -75,13 +172,13 @@ func isStatusConditionTrue(status apiv1.JobStatus, condType apiv1.JobConditionTy
 	return false
 }
 
-// newCondition creates a new job condition.
-func newCondition(conditionType apiv1.JobConditionType, conditionStatus v1.ConditionStatus, reason, message string) apiv1.JobCondition {
+// newCondition creates a new job condition updated at now.
+func newCondition(conditionType apiv1.JobConditionType, conditionStatus v1.ConditionStatus, reason, message string, now metav1.Time) apiv1.JobCondition {
 	return apiv1.JobCondition{
 		Type:               conditionType,
 		Status:             conditionStatus,
-		LastUpdateTime:     metav1.Now(),
-		LastTransitionTime: metav1.Now(),
+		LastUpdateTime:     now,
+		LastTransitionTime: now,
 		Reason:             reason,
 		Message:            message,
 	}
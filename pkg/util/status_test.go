@@ -2,9 +2,13 @@ package util
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clocktesting "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
 
 	apiv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
 )
@@ -106,13 +110,84 @@ func TestIsSuspended(t *testing.T) {
 	assert.True(t, IsSuspended(jobStatus))
 }
 
+func TestFinishJob(t *testing.T) {
+	startTime := metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	clock := NewClock(clocktesting.NewFakePassiveClock(startTime.Add(90*time.Minute+400*time.Millisecond)), 0)
+	jobStatus := &apiv1.JobStatus{StartTime: &startTime}
+	UpdateJobConditions(jobStatus, apiv1.JobRunning, corev1.ConditionTrue, JobRunningReason, "", nil)
+
+	if !FinishJob(jobStatus, apiv1.JobSucceeded, JobSucceededReason, "succeeded", clock) {
+		t.Fatalf("Expected the job to be finished")
+	}
+	if want := clock.MetaNow(); jobStatus.CompletionTime == nil || !jobStatus.CompletionTime.Equal(&want) {
+		t.Errorf("Unexpected completion time, want: %v, got: %v", want, jobStatus.CompletionTime)
+	}
+	if want := 90 * time.Minute; jobStatus.Duration == nil || jobStatus.Duration.Duration != want {
+		t.Errorf("Unexpected duration, want: %v, got: %v", want, jobStatus.Duration)
+	}
+
+	// The first terminal condition of the job is kept.
+	if FinishJob(jobStatus, apiv1.JobFailed, JobFailedReason, "failed", clock) {
+		t.Errorf("Expected the job to be already finished")
+	}
+	if !IsSucceeded(*jobStatus) || IsFailed(*jobStatus) || IsRunning(*jobStatus) {
+		t.Errorf("Expected a single terminal condition, got: %v", jobStatus.Conditions)
+	}
+}
+
+func TestJobPhase(t *testing.T) {
+	cases := map[string]struct {
+		conditions []apiv1.JobConditionType
+		want       apiv1.JobPhase
+	}{
+		"No condition": {
+			want: "",
+		},
+		"Created job": {
+			conditions: []apiv1.JobConditionType{apiv1.JobCreated, apiv1.JobQueued},
+			want:       apiv1.JobPhaseCreated,
+		},
+		"Running job": {
+			conditions: []apiv1.JobConditionType{apiv1.JobCreated, apiv1.JobRunning},
+			want:       apiv1.JobPhaseRunning,
+		},
+		"Restarting job": {
+			conditions: []apiv1.JobConditionType{apiv1.JobCreated, apiv1.JobRestarting},
+			want:       apiv1.JobPhaseRestarting,
+		},
+		"Suspended job": {
+			conditions: []apiv1.JobConditionType{apiv1.JobCreated, apiv1.JobRunning, apiv1.JobSuspended},
+			want:       apiv1.JobPhaseSuspended,
+		},
+		"Succeeded job": {
+			conditions: []apiv1.JobConditionType{apiv1.JobCreated, apiv1.JobSuspended, apiv1.JobSucceeded},
+			want:       apiv1.JobPhaseSucceeded,
+		},
+		"Failed job": {
+			conditions: []apiv1.JobConditionType{apiv1.JobCreated, apiv1.JobRestarting, apiv1.JobFailed},
+			want:       apiv1.JobPhaseFailed,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			jobStatus := apiv1.JobStatus{}
+			for _, conditionType := range tc.conditions {
+				jobStatus.Conditions = append(jobStatus.Conditions, apiv1.JobCondition{Type: conditionType, Status: corev1.ConditionTrue})
+			}
+			if got := JobPhase(jobStatus); got != tc.want {
+				t.Errorf("Unexpected phase, want: %q, got: %q", tc.want, got)
+			}
+		})
+	}
+}
+
 func TestUpdateJobConditions(t *testing.T) {
 	jobStatus := apiv1.JobStatus{}
 	conditionType := apiv1.JobCreated
 	reason := "Job Created"
 	message := "Job Created"
 
-	UpdateJobConditions(&jobStatus, conditionType, corev1.ConditionTrue, reason, message)
+	UpdateJobConditions(&jobStatus, conditionType, corev1.ConditionTrue, reason, message, nil)
 	// Check JobCreated condition is appended
 	conditionInStatus := jobStatus.Conditions[0]
 	assert.Equal(t, conditionInStatus.Type, conditionType)
@@ -122,7 +197,7 @@ func TestUpdateJobConditions(t *testing.T) {
 	conditionType = apiv1.JobRunning
 	reason = "Job Running"
 	message = "Job Running"
-	UpdateJobConditions(&jobStatus, conditionType, corev1.ConditionTrue, reason, message)
+	UpdateJobConditions(&jobStatus, conditionType, corev1.ConditionTrue, reason, message, nil)
 	// Check JobRunning condition is appended
 	conditionInStatus = jobStatus.Conditions[1]
 	assert.Equal(t, conditionInStatus.Type, conditionType)
@@ -132,7 +207,7 @@ func TestUpdateJobConditions(t *testing.T) {
 	conditionType = apiv1.JobRestarting
 	reason = "Job Restarting"
 	message = "Job Restarting"
-	UpdateJobConditions(&jobStatus, conditionType, corev1.ConditionTrue, reason, message)
+	UpdateJobConditions(&jobStatus, conditionType, corev1.ConditionTrue, reason, message, nil)
 	// Check JobRunning condition is filtered out and JobRestarting state is appended
 	conditionInStatus = jobStatus.Conditions[1]
 	assert.Equal(t, conditionInStatus.Type, conditionType)
@@ -142,7 +217,7 @@ func TestUpdateJobConditions(t *testing.T) {
 	conditionType = apiv1.JobRunning
 	reason = "Job Running"
 	message = "Job Running"
-	UpdateJobConditions(&jobStatus, conditionType, corev1.ConditionTrue, reason, message)
+	UpdateJobConditions(&jobStatus, conditionType, corev1.ConditionTrue, reason, message, nil)
 	// Again, Check JobRestarting condition is filtered and JobRestarting is appended
 	conditionInStatus = jobStatus.Conditions[1]
 	assert.Equal(t, conditionInStatus.Type, conditionType)
@@ -152,7 +227,7 @@ func TestUpdateJobConditions(t *testing.T) {
 	conditionType = apiv1.JobFailed
 	reason = "Job Failed"
 	message = "Job Failed"
-	UpdateJobConditions(&jobStatus, conditionType, corev1.ConditionTrue, reason, message)
+	UpdateJobConditions(&jobStatus, conditionType, corev1.ConditionTrue, reason, message, nil)
 	// Check JobRunning condition is set to false
 	jobRunningCondition := jobStatus.Conditions[1]
 	assert.Equal(t, jobRunningCondition.Type, apiv1.JobRunning)
@@ -163,3 +238,58 @@ func TestUpdateJobConditions(t *testing.T) {
 	assert.Equal(t, conditionInStatus.Reason, reason)
 	assert.Equal(t, conditionInStatus.Message, message)
 }
+
+func TestAllReplicasSucceeded(t *testing.T) {
+	replicas := map[apiv1.ReplicaType]*apiv1.ReplicaSpec{
+		apiv1.TFJobReplicaTypeWorker: {Replicas: ptr.To[int32](2)},
+	}
+	cases := map[string]struct {
+		jobStatus apiv1.JobStatus
+		rtype     apiv1.ReplicaType
+		want      bool
+	}{
+		"all workers succeeded": {
+			jobStatus: apiv1.JobStatus{
+				ReplicaStatuses: map[apiv1.ReplicaType]*apiv1.ReplicaStatus{
+					apiv1.TFJobReplicaTypeWorker: {Succeeded: 2},
+				},
+			},
+			rtype: apiv1.TFJobReplicaTypeWorker,
+			want:  true,
+		},
+		"some workers are running": {
+			jobStatus: apiv1.JobStatus{
+				ReplicaStatuses: map[apiv1.ReplicaType]*apiv1.ReplicaStatus{
+					apiv1.TFJobReplicaTypeWorker: {Succeeded: 1, Active: 1},
+				},
+			},
+			rtype: apiv1.TFJobReplicaTypeWorker,
+			want:  false,
+		},
+		"replica status is missing": {
+			jobStatus: apiv1.JobStatus{},
+			rtype:     apiv1.TFJobReplicaTypeWorker,
+			want:      false,
+		},
+		"replica type is not in the job": {
+			jobStatus: apiv1.JobStatus{},
+			rtype:     apiv1.TFJobReplicaTypePS,
+			want:      true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := AllReplicasSucceeded(replicas, tc.jobStatus, tc.rtype)
+			if tc.want != got {
+				t.Errorf("Unexpected result from AllReplicasSucceeded() \nwant: %v, got: %v\n", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestSuccessPolicyMessage(t *testing.T) {
+	assert.Equal(t, "Default success policy is satisfied.", SuccessPolicyMessage(nil))
+	assert.Equal(t, "Default success policy is satisfied.", SuccessPolicyMessage(ptr.To(apiv1.SuccessPolicyDefault)))
+	assert.Equal(t, "All workers succeeded (successPolicy: AllWorkers).", SuccessPolicyMessage(ptr.To(apiv1.SuccessPolicyAllWorkers)))
+	assert.Equal(t, "Chief/Master replica succeeded (successPolicy: ChiefOrMaster).", SuccessPolicyMessage(ptr.To(apiv1.SuccessPolicyChiefOrMaster)))
+}
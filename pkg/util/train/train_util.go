@@ -16,6 +16,8 @@
 package train
 
 import (
+	"slices"
+
 	"k8s.io/utils/ptr"
 
 	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
@@ -28,3 +30,38 @@ func IsRetryableExitCode(exitCode int32) bool {
 func IsJobSuspended(runPolicy *kubeflowv1.RunPolicy) bool {
 	return runPolicy != nil && ptr.Deref(runPolicy.Suspend, false)
 }
+
+// StandaloneMasterAddr is the rendezvous address of a standalone job, whose single pod has no service.
+const StandaloneMasterAddr = "localhost"
+
+// IsJobStandalone returns true if the job runs as a single pod.
+func IsJobStandalone(runPolicy *kubeflowv1.RunPolicy) bool {
+	return runPolicy != nil && ptr.Deref(runPolicy.Standalone, false)
+}
+
+// MatchFailurePolicy returns the action of the first rule of the failure policy
+// matching the exit code, and false if no rule matches.
+func MatchFailurePolicy(failurePolicy *kubeflowv1.FailurePolicy, exitCode int32) (kubeflowv1.FailurePolicyAction, bool) {
+	if rule := MatchFailurePolicyRule(failurePolicy, &exitCode, ""); rule != nil {
+		return rule.Action, true
+	}
+	return "", false
+}
+
+// MatchFailurePolicyRule returns the first rule of the failure policy matching either the
+// exit code, if known, or the failure reason of a pod, and nil if no rule matches.
+func MatchFailurePolicyRule(failurePolicy *kubeflowv1.FailurePolicy, exitCode *int32, reason kubeflowv1.PodFailureReason) *kubeflowv1.FailurePolicyRule {
+	if failurePolicy == nil {
+		return nil
+	}
+	for i := range failurePolicy.Rules {
+		rule := &failurePolicy.Rules[i]
+		if exitCode != nil && slices.Contains(rule.ExitCodes, *exitCode) {
+			return rule
+		}
+		if reason != "" && slices.Contains(rule.FailureReasons, reason) {
+			return rule
+		}
+	}
+	return nil
+}
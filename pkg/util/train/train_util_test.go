@@ -94,3 +94,55 @@ func TestIsJobSuspended(t *testing.T) {
 		})
 	}
 }
+
+func TestMatchFailurePolicy(t *testing.T) {
+	failurePolicy := &kubeflowv1.FailurePolicy{
+		Rules: []kubeflowv1.FailurePolicyRule{
+			{
+				Action:    kubeflowv1.FailurePolicyActionIgnore,
+				ExitCodes: []int32{137, 143},
+			},
+			{
+				Action:    kubeflowv1.FailurePolicyActionFailJob,
+				ExitCodes: []int32{1, 137},
+			},
+		},
+	}
+	cases := map[string]struct {
+		failurePolicy *kubeflowv1.FailurePolicy
+		exitCode      int32
+		wantAction    kubeflowv1.FailurePolicyAction
+		wantMatched   bool
+	}{
+		"failurePolicy is nil": {
+			failurePolicy: nil,
+			exitCode:      137,
+			wantMatched:   false,
+		},
+		"exit code matches a rule": {
+			failurePolicy: failurePolicy,
+			exitCode:      1,
+			wantAction:    kubeflowv1.FailurePolicyActionFailJob,
+			wantMatched:   true,
+		},
+		"first matching rule wins": {
+			failurePolicy: failurePolicy,
+			exitCode:      137,
+			wantAction:    kubeflowv1.FailurePolicyActionIgnore,
+			wantMatched:   true,
+		},
+		"exit code does not match any rule": {
+			failurePolicy: failurePolicy,
+			exitCode:      2,
+			wantMatched:   false,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			gotAction, gotMatched := MatchFailurePolicy(tc.failurePolicy, tc.exitCode)
+			if tc.wantMatched != gotMatched || tc.wantAction != gotAction {
+				t.Errorf("Unexpected result from MatchFailurePolicy \nwant: %v, %v\n, \ngot: %v, %v\n", tc.wantAction, tc.wantMatched, gotAction, gotMatched)
+			}
+		})
+	}
+}
@@ -27,10 +27,12 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	trainingoperator "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	"github.com/kubeflow/training-operator/pkg/common/util"
 )
 
 var (
@@ -38,15 +40,28 @@ var (
 	jaxReplicaSpecPath = specPath.Child("jaxReplicaSpecs")
 )
 
-type Webhook struct{}
+type Webhook struct {
+	// client reads the jobs the validated jobs depend on.
+	client client.Reader
+}
 
 func SetupWebhook(mgr ctrl.Manager) error {
+	mgr.GetWebhookServer().Register("/mutate-kubeflow-org-v1-jaxjob", &webhook.Admission{
+		Handler: util.NewJobDefaulterHandler(admission.NewDecoder(mgr.GetScheme()),
+			func() runtime.Object { return &trainingoperator.JAXJob{} },
+			func(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+				job := obj.(*trainingoperator.JAXJob)
+				return nil, util.ApplyJobClass(ctx, mgr.GetClient(), job, job.Spec.JobClassName, job.Spec.JAXReplicaSpecs, &job.Spec.RunPolicy)
+			}),
+	})
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(&trainingoperator.JAXJob{}).
-		WithValidator(&Webhook{}).
+		WithValidator(&Webhook{client: mgr.GetClient()}).
 		Complete()
 }
 
+// +kubebuilder:webhook:path=/mutate-kubeflow-org-v1-jaxjob,mutating=true,failurePolicy=fail,sideEffects=None,groups=kubeflow.org,resources=jaxjobs,verbs=create,versions=v1,name=defaulter.jaxjob.training-operator.kubeflow.org,admissionReviewVersions=v1
+
 // +kubebuilder:webhook:path=/validate-kubeflow-org-v1-jaxjob,mutating=false,failurePolicy=fail,sideEffects=None,groups=kubeflow.org,resources=jaxjobs,verbs=create;update,versions=v1,name=validator.jaxjob.training-operator.kubeflow.org,admissionReviewVersions=v1
 
 var _ webhook.CustomValidator = &Webhook{}
@@ -55,14 +70,18 @@ func (w *Webhook) ValidateCreate(ctx context.Context, obj runtime.Object) (admis
 	job := obj.(*trainingoperator.JAXJob)
 	log := ctrl.LoggerFrom(ctx).WithName("jaxjob-webhook")
 	log.V(5).Info("Validating create", "jaxJob", klog.KObj(job))
-	return nil, validateJAXJob(job).ToAggregate()
+	allErrs := validateJAXJob(job)
+	allErrs = append(allErrs, util.ValidateJobDependencies(ctx, w.client, job, trainingoperator.JAXJobKind, job.Spec.DependsOn)...)
+	return nil, allErrs.ToAggregate()
 }
 
 func (w *Webhook) ValidateUpdate(ctx context.Context, _ runtime.Object, newObj runtime.Object) (admission.Warnings, error) {
 	job := newObj.(*trainingoperator.JAXJob)
 	log := ctrl.LoggerFrom(ctx).WithName("jaxjob-webhook")
 	log.V(5).Info("Validating update", "jaxJob", klog.KObj(job))
-	return nil, validateJAXJob(job).ToAggregate()
+	allErrs := validateJAXJob(job)
+	allErrs = append(allErrs, util.ValidateJobDependencies(ctx, w.client, job, trainingoperator.JAXJobKind, job.Spec.DependsOn)...)
+	return nil, allErrs.ToAggregate()
 }
 
 func (w *Webhook) ValidateDelete(context.Context, runtime.Object) (admission.Warnings, error) {
@@ -75,7 +94,12 @@ func validateJAXJob(job *trainingoperator.JAXJob) field.ErrorList {
 		allErrs = append(allErrs, field.Invalid(field.NewPath("metadata").Child("name"), job.Name, fmt.Sprintf("should match: %v", strings.Join(errors, ","))))
 	}
 
+	allErrs = append(allErrs, util.ValidateRunIDAnnotation(job.Annotations)...)
+	allErrs = append(allErrs, util.ValidateMetricsCollectorAnnotations(job)...)
 	allErrs = append(allErrs, validateSpec(job.Spec)...)
+	allErrs = append(allErrs, util.ValidateToleratedFailures(jaxReplicaSpecPath, job.Spec.JAXReplicaSpecs)...)
+	allErrs = append(allErrs, util.ValidateResourceProfiles(jaxReplicaSpecPath, job.Spec.JAXReplicaSpecs)...)
+	allErrs = append(allErrs, util.ValidateDatasets(job.Spec.Datasets)...)
 	return allErrs
 }
 
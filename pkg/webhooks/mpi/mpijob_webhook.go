@@ -0,0 +1,97 @@
+/*
+Copyright 2024 The Kubeflow Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mpi
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	trainingoperator "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	"github.com/kubeflow/training-operator/pkg/common/util"
+)
+
+var standalonePath = field.NewPath("spec", "runPolicy", "standalone")
+
+type Webhook struct {
+	// client reads the jobs the validated jobs depend on.
+	client client.Reader
+}
+
+// SetupWebhook registers the mutating webhook of the MPIJob, which merges the defaults of its
+// TrainingJobClass, the validating webhook, and the conversion webhook, which serves the v2beta1
+// version of the MPIJobs stored as v1.
+func SetupWebhook(mgr ctrl.Manager) error {
+	mgr.GetWebhookServer().Register("/mutate-kubeflow-org-v1-mpijob", &webhook.Admission{
+		Handler: util.NewJobDefaulterHandler(admission.NewDecoder(mgr.GetScheme()),
+			func() runtime.Object { return &trainingoperator.MPIJob{} },
+			func(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+				job := obj.(*trainingoperator.MPIJob)
+				return nil, util.ApplyJobClass(ctx, mgr.GetClient(), job, job.Spec.JobClassName, job.Spec.MPIReplicaSpecs, &job.Spec.RunPolicy)
+			}),
+	})
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&trainingoperator.MPIJob{}).
+		WithValidator(&Webhook{client: mgr.GetClient()}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-kubeflow-org-v1-mpijob,mutating=true,failurePolicy=fail,sideEffects=None,groups=kubeflow.org,resources=mpijobs,verbs=create,versions=v1,name=defaulter.mpijob.training-operator.kubeflow.org,admissionReviewVersions=v1
+
+// +kubebuilder:webhook:path=/validate-kubeflow-org-v1-mpijob,mutating=false,failurePolicy=fail,sideEffects=None,groups=kubeflow.org,resources=mpijobs,verbs=create;update,versions=v1,name=validator.mpijob.training-operator.kubeflow.org,admissionReviewVersions=v1
+
+var _ webhook.CustomValidator = &Webhook{}
+
+func (w *Webhook) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	job := obj.(*trainingoperator.MPIJob)
+	log := ctrl.LoggerFrom(ctx).WithName("mpijob-webhook")
+	log.V(5).Info("Validating create", "mpiJob", klog.KObj(job))
+	allErrs := validateMPIJob(job)
+	allErrs = append(allErrs, util.ValidateJobDependencies(ctx, w.client, job, trainingoperator.MPIJobKind, job.Spec.DependsOn)...)
+	return nil, allErrs.ToAggregate()
+}
+
+func (w *Webhook) ValidateUpdate(ctx context.Context, _ runtime.Object, newObj runtime.Object) (admission.Warnings, error) {
+	job := newObj.(*trainingoperator.MPIJob)
+	log := ctrl.LoggerFrom(ctx).WithName("mpijob-webhook")
+	log.V(5).Info("Validating update", "mpiJob", klog.KObj(job))
+	allErrs := validateMPIJob(job)
+	allErrs = append(allErrs, util.ValidateJobDependencies(ctx, w.client, job, trainingoperator.MPIJobKind, job.Spec.DependsOn)...)
+	return nil, allErrs.ToAggregate()
+}
+
+func (w *Webhook) ValidateDelete(context.Context, runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateMPIJob rejects the standalone MPIJobs, since the launcher needs the workers to run
+// mpirun, and the invalid datasets. The replicas of the MPIJobs are validated by the controller.
+func validateMPIJob(job *trainingoperator.MPIJob) field.ErrorList {
+	var allErrs field.ErrorList
+	if ptr.Deref(job.Spec.RunPolicy.Standalone, false) {
+		allErrs = append(allErrs, field.Forbidden(standalonePath, "is not supported by MPIJob"))
+	}
+	allErrs = append(allErrs, util.ValidateDatasets(job.Spec.Datasets)...)
+	return allErrs
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2024 The Kubeflow Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mpi
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+
+	trainingoperator "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+)
+
+func TestValidateV1MPIJob(t *testing.T) {
+	testCases := map[string]struct {
+		standalone *bool
+		wantErr    field.ErrorList
+	}{
+		"valid MPIJob": {},
+		"standalone is false": {
+			standalone: ptr.To(false),
+		},
+		"standalone is forbidden": {
+			standalone: ptr.To(true),
+			wantErr: field.ErrorList{
+				field.Forbidden(standalonePath, ""),
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			mpiJob := &trainingoperator.MPIJob{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: trainingoperator.MPIJobSpec{
+					RunPolicy: trainingoperator.RunPolicy{Standalone: tc.standalone},
+				},
+			}
+			got := validateMPIJob(mpiJob)
+			if diff := cmp.Diff(tc.wantErr, got, cmpopts.IgnoreFields(field.Error{}, "Detail", "BadValue")); len(diff) != 0 {
+				t.Errorf("Unexpected error (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -27,6 +27,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
@@ -39,15 +40,28 @@ var (
 	paddleReplicaSpecPath = specPath.Child("paddleReplicaSpecs")
 )
 
-type Webhook struct{}
+type Webhook struct {
+	// client reads the jobs the validated jobs depend on.
+	client client.Reader
+}
 
 func SetupWebhook(mgr ctrl.Manager) error {
+	mgr.GetWebhookServer().Register("/mutate-kubeflow-org-v1-paddlejob", &webhook.Admission{
+		Handler: util.NewJobDefaulterHandler(admission.NewDecoder(mgr.GetScheme()),
+			func() runtime.Object { return &trainingoperator.PaddleJob{} },
+			func(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+				job := obj.(*trainingoperator.PaddleJob)
+				return nil, util.ApplyJobClass(ctx, mgr.GetClient(), job, job.Spec.JobClassName, job.Spec.PaddleReplicaSpecs, &job.Spec.RunPolicy)
+			}),
+	})
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(&trainingoperator.PaddleJob{}).
-		WithValidator(&Webhook{}).
+		WithValidator(&Webhook{client: mgr.GetClient()}).
 		Complete()
 }
 
+// +kubebuilder:webhook:path=/mutate-kubeflow-org-v1-paddlejob,mutating=true,failurePolicy=fail,sideEffects=None,groups=kubeflow.org,resources=paddlejobs,verbs=create,versions=v1,name=defaulter.paddlejob.training-operator.kubeflow.org,admissionReviewVersions=v1
+
 // +kubebuilder:webhook:path=/validate-kubeflow-org-v1-paddlejob,mutating=false,failurePolicy=fail,sideEffects=None,groups=kubeflow.org,resources=paddlejobs,verbs=create;update,versions=v1,name=validator.paddlejob.training-operator.kubeflow.org,admissionReviewVersions=v1
 
 var _ webhook.CustomValidator = &Webhook{}
@@ -56,7 +70,9 @@ func (w Webhook) ValidateCreate(ctx context.Context, obj runtime.Object) (admiss
 	job := obj.(*trainingoperator.PaddleJob)
 	log := ctrl.LoggerFrom(ctx).WithName("paddlejob-webhook")
 	log.V(5).Info("Validating create", "paddleJob", klog.KObj(job))
-	return nil, validatePaddleJob(nil, job).ToAggregate()
+	allErrs := validatePaddleJob(nil, job)
+	allErrs = append(allErrs, util.ValidateJobDependencies(ctx, w.client, job, trainingoperator.PaddleJobKind, job.Spec.DependsOn)...)
+	return nil, allErrs.ToAggregate()
 }
 
 func (w Webhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
@@ -64,7 +80,9 @@ func (w Webhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Obje
 	newJob := newObj.(*trainingoperator.PaddleJob)
 	log := ctrl.LoggerFrom(ctx).WithName("paddlejob-webhook")
 	log.V(5).Info("Validating update", "paddleJob", klog.KObj(newJob))
-	return nil, validatePaddleJob(oldJob, newJob).ToAggregate()
+	allErrs := validatePaddleJob(oldJob, newJob)
+	allErrs = append(allErrs, util.ValidateJobDependencies(ctx, w.client, newJob, trainingoperator.PaddleJobKind, newJob.Spec.DependsOn)...)
+	return nil, allErrs.ToAggregate()
 }
 
 func (w Webhook) ValidateDelete(context.Context, runtime.Object) (admission.Warnings, error) {
@@ -80,7 +98,35 @@ func validatePaddleJob(oldJob, newJob *trainingoperator.PaddleJob) field.ErrorLi
 		allErrs = append(allErrs, util.ValidateRunPolicyUpdate(&oldJob.Spec.RunPolicy, &newJob.Spec.RunPolicy)...)
 	}
 	allErrs = append(allErrs, util.ValidateRunPolicy(&newJob.Spec.RunPolicy)...)
+	allErrs = append(allErrs, util.ValidateRunIDAnnotation(newJob.Annotations)...)
+	allErrs = append(allErrs, util.ValidateMetricsCollectorAnnotations(newJob)...)
+	allErrs = append(allErrs, util.ValidateToleratedFailures(paddleReplicaSpecPath, newJob.Spec.PaddleReplicaSpecs)...)
+	allErrs = append(allErrs, util.ValidateResourceProfiles(paddleReplicaSpecPath, newJob.Spec.PaddleReplicaSpecs)...)
+	allErrs = append(allErrs, util.ValidateDatasets(newJob.Spec.Datasets)...)
 	allErrs = append(allErrs, validateSpec(newJob.Spec.PaddleReplicaSpecs)...)
+	allErrs = append(allErrs, validateHeterogeneousMode(newJob.Spec)...)
+	return allErrs
+}
+
+// validateHeterogeneousMode validates the topology of a PaddleJob with parameter servers,
+// where the workers are the collective trainers served by the parameter servers.
+func validateHeterogeneousMode(spec trainingoperator.PaddleJobSpec) field.ErrorList {
+	var allErrs field.ErrorList
+	if _, ok := spec.PaddleReplicaSpecs[trainingoperator.PaddleJobReplicaTypePServer]; !ok {
+		return nil
+	}
+	if _, ok := spec.PaddleReplicaSpecs[trainingoperator.PaddleJobReplicaTypeMaster]; ok {
+		allErrs = append(allErrs, field.Forbidden(paddleReplicaSpecPath.Key(string(trainingoperator.PaddleJobReplicaTypeMaster)),
+			fmt.Sprintf("must not be set together with %s", trainingoperator.PaddleJobReplicaTypePServer)))
+	}
+	if _, ok := spec.PaddleReplicaSpecs[trainingoperator.PaddleJobReplicaTypeWorker]; !ok {
+		allErrs = append(allErrs, field.Required(paddleReplicaSpecPath.Key(string(trainingoperator.PaddleJobReplicaTypeWorker)),
+			fmt.Sprintf("must be set together with %s", trainingoperator.PaddleJobReplicaTypePServer)))
+	}
+	if spec.ElasticPolicy != nil {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("elasticPolicy"),
+			fmt.Sprintf("is not supported together with %s", trainingoperator.PaddleJobReplicaTypePServer)))
+	}
 	return allErrs
 }
 
@@ -98,6 +144,7 @@ func validateSpec(rSpecs map[trainingoperator.ReplicaType]*trainingoperator.Repl
 		validReplicaTypes := []trainingoperator.ReplicaType{
 			trainingoperator.PaddleJobReplicaTypeMaster,
 			trainingoperator.PaddleJobReplicaTypeWorker,
+			trainingoperator.PaddleJobReplicaTypePServer,
 		}
 		if !slices.Contains(validReplicaTypes, rType) {
 			allErrs = append(allErrs, field.NotSupported(rolePath, rType, validReplicaTypes))
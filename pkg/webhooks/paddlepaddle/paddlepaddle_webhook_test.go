@@ -173,6 +173,53 @@ func TestValidateV1PaddleJob(t *testing.T) {
 				field.Required(paddleReplicaSpecPath, ""),
 			},
 		},
+		"valid heterogeneous paddleJob": {
+			paddleJob: &trainingoperator.PaddleJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test",
+				},
+				Spec: trainingoperator.PaddleJobSpec{
+					PaddleReplicaSpecs: map[trainingoperator.ReplicaType]*trainingoperator.ReplicaSpec{
+						trainingoperator.PaddleJobReplicaTypePServer: validPaddleReplicaSpecs[trainingoperator.PaddleJobReplicaTypeWorker],
+						trainingoperator.PaddleJobReplicaTypeWorker:  validPaddleReplicaSpecs[trainingoperator.PaddleJobReplicaTypeWorker],
+					},
+				},
+			},
+		},
+		"heterogeneous paddleJob with a master and no worker": {
+			paddleJob: &trainingoperator.PaddleJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test",
+				},
+				Spec: trainingoperator.PaddleJobSpec{
+					PaddleReplicaSpecs: map[trainingoperator.ReplicaType]*trainingoperator.ReplicaSpec{
+						trainingoperator.PaddleJobReplicaTypePServer: validPaddleReplicaSpecs[trainingoperator.PaddleJobReplicaTypeWorker],
+						trainingoperator.PaddleJobReplicaTypeMaster:  validPaddleReplicaSpecs[trainingoperator.PaddleJobReplicaTypeWorker],
+					},
+				},
+			},
+			wantErr: field.ErrorList{
+				field.Forbidden(paddleReplicaSpecPath.Key(string(trainingoperator.PaddleJobReplicaTypeMaster)), ""),
+				field.Required(paddleReplicaSpecPath.Key(string(trainingoperator.PaddleJobReplicaTypeWorker)), ""),
+			},
+		},
+		"heterogeneous paddleJob with an elastic policy": {
+			paddleJob: &trainingoperator.PaddleJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test",
+				},
+				Spec: trainingoperator.PaddleJobSpec{
+					ElasticPolicy: &trainingoperator.PaddleElasticPolicy{MaxReplicas: ptr.To[int32](2)},
+					PaddleReplicaSpecs: map[trainingoperator.ReplicaType]*trainingoperator.ReplicaSpec{
+						trainingoperator.PaddleJobReplicaTypePServer: validPaddleReplicaSpecs[trainingoperator.PaddleJobReplicaTypeWorker],
+						trainingoperator.PaddleJobReplicaTypeWorker:  validPaddleReplicaSpecs[trainingoperator.PaddleJobReplicaTypeWorker],
+					},
+				},
+			},
+			wantErr: field.ErrorList{
+				field.Forbidden(specPath.Child("elasticPolicy"), ""),
+			},
+		},
 		"attempt to set unsupported managedBy controller name gets rejected": {
 			paddleJob: &trainingoperator.PaddleJob{
 				ObjectMeta: metav1.ObjectMeta{
@@ -27,6 +27,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
@@ -39,15 +40,28 @@ var (
 	pytorchReplicaSpecPath = specPath.Child("pytorchReplicaSpecs")
 )
 
-type Webhook struct{}
+type Webhook struct {
+	// client reads the jobs the validated jobs depend on.
+	client client.Reader
+}
 
 func SetupWebhook(mgr ctrl.Manager) error {
+	mgr.GetWebhookServer().Register("/mutate-kubeflow-org-v1-pytorchjob", &webhook.Admission{
+		Handler: util.NewJobDefaulterHandler(admission.NewDecoder(mgr.GetScheme()),
+			func() runtime.Object { return &trainingoperator.PyTorchJob{} },
+			func(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+				job := obj.(*trainingoperator.PyTorchJob)
+				return nil, util.ApplyJobClass(ctx, mgr.GetClient(), job, job.Spec.JobClassName, job.Spec.PyTorchReplicaSpecs, &job.Spec.RunPolicy)
+			}),
+	})
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(&trainingoperator.PyTorchJob{}).
-		WithValidator(&Webhook{}).
+		WithValidator(&Webhook{client: mgr.GetClient()}).
 		Complete()
 }
 
+// +kubebuilder:webhook:path=/mutate-kubeflow-org-v1-pytorchjob,mutating=true,failurePolicy=fail,sideEffects=None,groups=kubeflow.org,resources=pytorchjobs,verbs=create,versions=v1,name=defaulter.pytorchjob.training-operator.kubeflow.org,admissionReviewVersions=v1
+
 // +kubebuilder:webhook:path=/validate-kubeflow-org-v1-pytorchjob,mutating=false,failurePolicy=fail,sideEffects=None,groups=kubeflow.org,resources=pytorchjobs,verbs=create;update,versions=v1,name=validator.pytorchjob.training-operator.kubeflow.org,admissionReviewVersions=v1
 
 var _ webhook.CustomValidator = &Webhook{}
@@ -57,6 +71,7 @@ func (w *Webhook) ValidateCreate(ctx context.Context, obj runtime.Object) (admis
 	log := ctrl.LoggerFrom(ctx).WithName("pytorchjob-webhook")
 	log.V(5).Info("Validating create", "pytorchJob", klog.KObj(job))
 	warnings, errs := validatePyTorchJob(nil, job)
+	errs = append(errs, util.ValidateJobDependencies(ctx, w.client, job, trainingoperator.PyTorchJobKind, job.Spec.DependsOn)...)
 	return warnings, errs.ToAggregate()
 }
 
@@ -66,6 +81,7 @@ func (w *Webhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Obj
 	log := ctrl.LoggerFrom(ctx).WithName("pytorchjob-webhook")
 	log.V(5).Info("Validating update", "pytorchJob", klog.KObj(newJob))
 	warnings, errs := validatePyTorchJob(oldJob, newJob)
+	errs = append(errs, util.ValidateJobDependencies(ctx, w.client, newJob, trainingoperator.PyTorchJobKind, newJob.Spec.DependsOn)...)
 	return warnings, errs.ToAggregate()
 }
 
@@ -84,6 +100,12 @@ func validatePyTorchJob(oldJob, newJob *trainingoperator.PyTorchJob) (admission.
 		allErrs = append(allErrs, util.ValidateRunPolicyUpdate(&oldJob.Spec.RunPolicy, &newJob.Spec.RunPolicy)...)
 	}
 	allErrs = append(allErrs, util.ValidateRunPolicy(&newJob.Spec.RunPolicy)...)
+	allErrs = append(allErrs, util.ValidateRunIDAnnotation(newJob.Annotations)...)
+	allErrs = append(allErrs, util.ValidateMetricsCollectorAnnotations(newJob)...)
+	allErrs = append(allErrs, util.ValidateSuccessPolicy(newJob.Spec.SuccessPolicy)...)
+	allErrs = append(allErrs, util.ValidateToleratedFailures(pytorchReplicaSpecPath, newJob.Spec.PyTorchReplicaSpecs)...)
+	allErrs = append(allErrs, util.ValidateResourceProfiles(pytorchReplicaSpecPath, newJob.Spec.PyTorchReplicaSpecs)...)
+	allErrs = append(allErrs, util.ValidateDatasets(newJob.Spec.Datasets)...)
 	ws, err := validateSpec(newJob.Spec)
 	warnings = append(warnings, ws...)
 	allErrs = append(allErrs, err...)
@@ -102,6 +124,13 @@ func validateSpec(spec trainingoperator.PyTorchJobSpec) (admission.Warnings, fie
 			allErrs = append(allErrs, field.Forbidden(elasticNProcPerNodePath, fmt.Sprintf("must not be used with %s", nprocPerNodePath)))
 		}
 	}
+	// Without a Master replica, the c10d rendezvous is hosted by worker-0.
+	if spec.PyTorchReplicaSpecs != nil && spec.PyTorchReplicaSpecs[trainingoperator.PyTorchJobReplicaTypeMaster] == nil &&
+		spec.ElasticPolicy != nil && spec.ElasticPolicy.RDZVBackend != nil && *spec.ElasticPolicy.RDZVBackend == trainingoperator.BackendC10D &&
+		spec.PyTorchReplicaSpecs[trainingoperator.PyTorchJobReplicaTypeWorker] == nil {
+		allErrs = append(allErrs, field.Required(pytorchReplicaSpecPath.Key(string(trainingoperator.PyTorchJobReplicaTypeWorker)),
+			"must be specified to host the c10d rendezvous when there is no Master replica"))
+	}
 	allErrs = append(allErrs, validatePyTorchReplicaSpecs(spec.PyTorchReplicaSpecs)...)
 	return warnings, allErrs
 }
@@ -335,6 +335,51 @@ func TestValidateV1PyTorchJob(t *testing.T) {
 				field.Invalid(field.NewPath("spec", "runPolicy", "managedBy"), trainingoperator.MultiKueueController, apivalidation.FieldImmutableErrorMsg),
 			},
 		},
+		"attempt to set unsupported successPolicy gets rejected": {
+			pytorchJob: &trainingoperator.PyTorchJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test",
+				},
+				Spec: trainingoperator.PyTorchJobSpec{
+					SuccessPolicy:       ptr.To[trainingoperator.SuccessPolicy]("AnyWorker"),
+					PyTorchReplicaSpecs: validPyTorchReplicaSpecs,
+				},
+			},
+			wantErr: field.ErrorList{
+				field.NotSupported(field.NewPath("spec", "successPolicy"), "", []string{}),
+			},
+		},
+		"c10d rendezvous without Master replica": {
+			pytorchJob: &trainingoperator.PyTorchJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test",
+				},
+				Spec: trainingoperator.PyTorchJobSpec{
+					ElasticPolicy: &trainingoperator.ElasticPolicy{
+						RDZVBackend: ptr.To(trainingoperator.BackendC10D),
+					},
+					PyTorchReplicaSpecs: map[trainingoperator.ReplicaType]*trainingoperator.ReplicaSpec{
+						trainingoperator.PyTorchJobReplicaTypeWorker: validPyTorchReplicaSpecs[trainingoperator.PyTorchJobReplicaTypeWorker],
+					},
+				},
+			},
+		},
+		"c10d rendezvous without Master and Worker replicas": {
+			pytorchJob: &trainingoperator.PyTorchJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test",
+				},
+				Spec: trainingoperator.PyTorchJobSpec{
+					ElasticPolicy: &trainingoperator.ElasticPolicy{
+						RDZVBackend: ptr.To(trainingoperator.BackendC10D),
+					},
+					PyTorchReplicaSpecs: map[trainingoperator.ReplicaType]*trainingoperator.ReplicaSpec{},
+				},
+			},
+			wantErr: field.ErrorList{
+				field.Required(pytorchReplicaSpecPath.Key(string(trainingoperator.PyTorchJobReplicaTypeWorker)), ""),
+			},
+		},
 	}
 
 	for name, tc := range testCases {
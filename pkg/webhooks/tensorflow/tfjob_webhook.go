@@ -26,6 +26,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
@@ -36,17 +37,42 @@ import (
 var (
 	specPath          = field.NewPath("spec")
 	tfReplicaSpecPath = specPath.Child("tfReplicaSpecs")
+
+	// legacyReplicaTypes are the replica types which are converted to their supported equivalents
+	// when a TFJob is created.
+	legacyReplicaTypes = map[trainingoperator.ReplicaType]trainingoperator.ReplicaType{
+		trainingoperator.TFJobReplicaTypeMaster: trainingoperator.TFJobReplicaTypeChief,
+	}
 )
 
-type Webhook struct{}
+type Webhook struct {
+	// client reads the jobs the validated jobs depend on.
+	client client.Reader
+}
 
 func SetupWebhook(mgr ctrl.Manager) error {
+	mgr.GetWebhookServer().Register("/mutate-kubeflow-org-v1-tfjob", &webhook.Admission{
+		Handler: util.NewJobDefaulterHandler(admission.NewDecoder(mgr.GetScheme()),
+			func() runtime.Object { return &trainingoperator.TFJob{} },
+			func(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+				job := obj.(*trainingoperator.TFJob)
+				warnings := convertLegacyReplicaTypes(job)
+				return warnings, util.ApplyJobClass(ctx, mgr.GetClient(), job, job.Spec.JobClassName, job.Spec.TFReplicaSpecs, &job.Spec.RunPolicy)
+			}),
+	})
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(&trainingoperator.TFJob{}).
-		WithValidator(&Webhook{}).
+		WithValidator(&Webhook{client: mgr.GetClient()}).
 		Complete()
 }
 
+// +kubebuilder:webhook:path=/mutate-kubeflow-org-v1-tfjob,mutating=true,failurePolicy=fail,sideEffects=None,groups=kubeflow.org,resources=tfjobs,verbs=create,versions=v1,name=defaulter.tfjob.training-operator.kubeflow.org,admissionReviewVersions=v1
+
+// convertLegacyReplicaTypes converts the legacy replica types of the TFJob to their supported equivalents.
+func convertLegacyReplicaTypes(job *trainingoperator.TFJob) admission.Warnings {
+	return util.ConvertLegacyReplicaTypes(tfReplicaSpecPath, job.Spec.TFReplicaSpecs, legacyReplicaTypes)
+}
+
 // +kubebuilder:webhook:path=/validate-kubeflow-org-v1-tfjob,mutating=false,failurePolicy=fail,sideEffects=None,groups=kubeflow.org,resources=tfjobs,verbs=create;update,versions=v1,name=validator.tfjob.training-operator.kubeflow.org,admissionReviewVersions=v1
 
 var _ webhook.CustomValidator = &Webhook{}
@@ -55,7 +81,9 @@ func (w *Webhook) ValidateCreate(ctx context.Context, obj runtime.Object) (admis
 	job := obj.(*trainingoperator.TFJob)
 	log := ctrl.LoggerFrom(ctx).WithName("tfjob-webhook")
 	log.V(5).Info("Validating create", "TFJob", klog.KObj(job))
-	return nil, validateTFJob(nil, job).ToAggregate()
+	allErrs := validateTFJob(nil, job)
+	allErrs = append(allErrs, util.ValidateJobDependencies(ctx, w.client, job, trainingoperator.TFJobKind, job.Spec.DependsOn)...)
+	return nil, allErrs.ToAggregate()
 }
 
 func (w *Webhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
@@ -63,7 +91,9 @@ func (w *Webhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Obj
 	newJob := newObj.(*trainingoperator.TFJob)
 	log := ctrl.LoggerFrom(ctx).WithName("tfjob-webhook")
 	log.V(5).Info("Validating update", "NewTFJob", klog.KObj(newJob))
-	return nil, validateTFJob(oldJob, newJob).ToAggregate()
+	allErrs := validateTFJob(oldJob, newJob)
+	allErrs = append(allErrs, util.ValidateJobDependencies(ctx, w.client, newJob, trainingoperator.TFJobKind, newJob.Spec.DependsOn)...)
+	return nil, allErrs.ToAggregate()
 }
 
 func (w *Webhook) ValidateDelete(context.Context, runtime.Object) (admission.Warnings, error) {
@@ -79,6 +109,12 @@ func validateTFJob(oldJob, newJob *trainingoperator.TFJob) field.ErrorList {
 		allErrs = append(allErrs, util.ValidateRunPolicyUpdate(&oldJob.Spec.RunPolicy, &newJob.Spec.RunPolicy)...)
 	}
 	allErrs = append(allErrs, util.ValidateRunPolicy(&newJob.Spec.RunPolicy)...)
+	allErrs = append(allErrs, util.ValidateRunIDAnnotation(newJob.Annotations)...)
+	allErrs = append(allErrs, util.ValidateMetricsCollectorAnnotations(newJob)...)
+	allErrs = append(allErrs, util.ValidateSuccessPolicy(newJob.Spec.SuccessPolicy)...)
+	allErrs = append(allErrs, util.ValidateToleratedFailures(tfReplicaSpecPath, newJob.Spec.TFReplicaSpecs, trainingoperator.TFJobReplicaTypeEval)...)
+	allErrs = append(allErrs, util.ValidateResourceProfiles(tfReplicaSpecPath, newJob.Spec.TFReplicaSpecs)...)
+	allErrs = append(allErrs, util.ValidateDatasets(newJob.Spec.Datasets)...)
 	allErrs = append(allErrs, validateSpec(newJob.Spec)...)
 	return allErrs
 }
@@ -23,9 +23,11 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	trainingoperator "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
 )
@@ -202,6 +204,294 @@ func TestValidateTFJob(t *testing.T) {
 					trainingoperator.KubeflowJobsController))),
 			},
 		},
+		"valid successPolicy": {
+			tfJob: &trainingoperator.TFJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test",
+				},
+				Spec: trainingoperator.TFJobSpec{
+					SuccessPolicy:  ptr.To(trainingoperator.SuccessPolicyChiefOrMaster),
+					TFReplicaSpecs: validTFReplicaSpecs,
+				},
+			},
+		},
+		"attempt to set unsupported successPolicy gets rejected": {
+			tfJob: &trainingoperator.TFJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test",
+				},
+				Spec: trainingoperator.TFJobSpec{
+					SuccessPolicy:  ptr.To[trainingoperator.SuccessPolicy]("AnyWorker"),
+					TFReplicaSpecs: validTFReplicaSpecs,
+				},
+			},
+			wantErr: field.ErrorList{
+				field.NotSupported(field.NewPath("spec", "successPolicy"), "", []string{}),
+			},
+		},
+		"valid failurePolicy": {
+			tfJob: &trainingoperator.TFJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test",
+				},
+				Spec: trainingoperator.TFJobSpec{
+					RunPolicy: trainingoperator.RunPolicy{
+						FailurePolicy: &trainingoperator.FailurePolicy{
+							Rules: []trainingoperator.FailurePolicyRule{
+								{Action: trainingoperator.FailurePolicyActionIgnore, ExitCodes: []int32{137}},
+								{Action: trainingoperator.FailurePolicyActionFailJob, ExitCodes: []int32{1, 2}},
+							},
+						},
+					},
+					TFReplicaSpecs: validTFReplicaSpecs,
+				},
+			},
+		},
+		"attempt to set invalid failurePolicy rules gets rejected": {
+			tfJob: &trainingoperator.TFJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test",
+				},
+				Spec: trainingoperator.TFJobSpec{
+					RunPolicy: trainingoperator.RunPolicy{
+						FailurePolicy: &trainingoperator.FailurePolicy{
+							Rules: []trainingoperator.FailurePolicyRule{
+								{Action: "Retry", ExitCodes: []int32{137}},
+								{Action: trainingoperator.FailurePolicyActionRestart},
+								{Action: trainingoperator.FailurePolicyActionFailJob, ExitCodes: []int32{1, 0}},
+								{Action: trainingoperator.FailurePolicyActionIgnore, FailureReasons: []trainingoperator.PodFailureReason{"Preempted"}},
+							},
+						},
+					},
+					TFReplicaSpecs: validTFReplicaSpecs,
+				},
+			},
+			wantErr: field.ErrorList{
+				field.NotSupported(field.NewPath("spec", "runPolicy", "failurePolicy", "rules").Index(0).Child("action"), "", []string{}),
+				field.Required(field.NewPath("spec", "runPolicy", "failurePolicy", "rules").Index(1).Child("exitCodes"), ""),
+				field.Invalid(field.NewPath("spec", "runPolicy", "failurePolicy", "rules").Index(2).Child("exitCodes").Index(1), "", ""),
+				field.NotSupported(field.NewPath("spec", "runPolicy", "failurePolicy", "rules").Index(3).Child("failureReasons").Index(0), "", []string{}),
+			},
+		},
+		"attempt to set checkpointPolicy without command gets rejected": {
+			tfJob: &trainingoperator.TFJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test",
+				},
+				Spec: trainingoperator.TFJobSpec{
+					RunPolicy: trainingoperator.RunPolicy{
+						CheckpointPolicy: &trainingoperator.CheckpointPolicy{
+							GracefulCheckpointSeconds: ptr.To[int32](60),
+						},
+					},
+					TFReplicaSpecs: validTFReplicaSpecs,
+				},
+			},
+			wantErr: field.ErrorList{
+				field.Required(field.NewPath("spec", "runPolicy", "checkpointPolicy", "command"), ""),
+			},
+		},
+		"valid startPolicy": {
+			tfJob: &trainingoperator.TFJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test",
+				},
+				Spec: trainingoperator.TFJobSpec{
+					RunPolicy: trainingoperator.RunPolicy{
+						StartPolicy: &trainingoperator.StartPolicy{WaveSize: intstr.FromString("25%")},
+					},
+					TFReplicaSpecs: validTFReplicaSpecs,
+				},
+			},
+		},
+		"attempt to set an invalid waveSize gets rejected": {
+			tfJob: &trainingoperator.TFJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test",
+				},
+				Spec: trainingoperator.TFJobSpec{
+					RunPolicy: trainingoperator.RunPolicy{
+						StartPolicy: &trainingoperator.StartPolicy{WaveSize: intstr.FromString("0%")},
+					},
+					TFReplicaSpecs: validTFReplicaSpecs,
+				},
+			},
+			wantErr: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "runPolicy", "startPolicy", "waveSize"), "", ""),
+			},
+		},
+		"valid sameTopology": {
+			tfJob: &trainingoperator.TFJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test",
+				},
+				Spec: trainingoperator.TFJobSpec{
+					RunPolicy: trainingoperator.RunPolicy{
+						SchedulingPolicy: &trainingoperator.SchedulingPolicy{
+							SameTopology: corev1.LabelTopologyZone,
+						},
+					},
+					TFReplicaSpecs: validTFReplicaSpecs,
+				},
+			},
+		},
+		"attempt to set sameTopology to an invalid label key gets rejected": {
+			tfJob: &trainingoperator.TFJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test",
+				},
+				Spec: trainingoperator.TFJobSpec{
+					RunPolicy: trainingoperator.RunPolicy{
+						SchedulingPolicy: &trainingoperator.SchedulingPolicy{
+							SameTopology: "topology zone",
+						},
+					},
+					TFReplicaSpecs: validTFReplicaSpecs,
+				},
+			},
+			wantErr: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "runPolicy", "schedulingPolicy", "sameTopology"), "", ""),
+			},
+		},
+		"attempt to set the topologyKey of the topologyPolicy to an invalid label key gets rejected": {
+			tfJob: &trainingoperator.TFJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test",
+				},
+				Spec: trainingoperator.TFJobSpec{
+					RunPolicy: trainingoperator.RunPolicy{
+						TopologyPolicy: &trainingoperator.TopologyPolicy{
+							Placement:   trainingoperator.TopologyPlacementPack,
+							TopologyKey: "topology rack",
+						},
+					},
+					TFReplicaSpecs: validTFReplicaSpecs,
+				},
+			},
+			wantErr: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "runPolicy", "topologyPolicy", "topologyKey"), "", ""),
+			},
+		},
+		"attempt to set the run ID to an invalid label value gets rejected": {
+			tfJob: &trainingoperator.TFJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test",
+					Annotations: map[string]string{trainingoperator.RunIDAnnotation: "run 1"},
+				},
+				Spec: trainingoperator.TFJobSpec{
+					TFReplicaSpecs: validTFReplicaSpecs,
+				},
+			},
+			wantErr: field.ErrorList{
+				field.Invalid(field.NewPath("metadata", "annotations").Key(trainingoperator.RunIDAnnotation), "", ""),
+			},
+		},
+		"attempt to set an unknown resource profile gets rejected": {
+			tfJob: &trainingoperator.TFJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test",
+				},
+				Spec: trainingoperator.TFJobSpec{
+					TFReplicaSpecs: map[trainingoperator.ReplicaType]*trainingoperator.ReplicaSpec{
+						trainingoperator.TFJobReplicaTypeWorker: {
+							Replicas:        ptr.To[int32](2),
+							RestartPolicy:   trainingoperator.RestartPolicyOnFailure,
+							ResourceProfile: "gpu-large",
+							Template:        validTFReplicaSpecs[trainingoperator.TFJobReplicaTypeWorker].Template,
+						},
+					},
+				},
+			},
+			wantErr: field.ErrorList{
+				field.Invalid(tfReplicaSpecPath.Key(string(trainingoperator.TFJobReplicaTypeWorker)).Child("resourceProfile"), "", ""),
+			},
+		},
+		"attempt to collect the metrics of a file without its path gets rejected": {
+			tfJob: &trainingoperator.TFJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "test",
+					Labels: map[string]string{trainingoperator.KatibTrialLabel: "trial"},
+					Annotations: map[string]string{
+						trainingoperator.MetricsCollectorAnnotation:   trainingoperator.MetricsCollectorFile,
+						trainingoperator.MetricsNamesAnnotation:       "accuracy",
+						trainingoperator.MetricsDestinationAnnotation: trainingoperator.MetricsDestinationKatib,
+					},
+				},
+				Spec: trainingoperator.TFJobSpec{
+					TFReplicaSpecs: validTFReplicaSpecs,
+				},
+			},
+			wantErr: field.ErrorList{
+				field.Invalid(field.NewPath("metadata", "annotations").Key(trainingoperator.MetricsFileAnnotation), "", ""),
+			},
+		},
+		"attempt to push the metrics of a job which is not a Katib trial to Katib gets rejected": {
+			tfJob: &trainingoperator.TFJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test",
+					Annotations: map[string]string{
+						trainingoperator.MetricsCollectorAnnotation:   trainingoperator.MetricsCollectorStdOut,
+						trainingoperator.MetricsNamesAnnotation:       "accuracy",
+						trainingoperator.MetricsDestinationAnnotation: trainingoperator.MetricsDestinationKatib,
+					},
+				},
+				Spec: trainingoperator.TFJobSpec{
+					TFReplicaSpecs: validTFReplicaSpecs,
+				},
+			},
+			wantErr: field.ErrorList{
+				field.Required(field.NewPath("metadata", "labels").Key(trainingoperator.KatibTrialLabel), ""),
+			},
+		},
+		"valid tfJob with tolerated evaluator failures": {
+			tfJob: &trainingoperator.TFJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test",
+				},
+				Spec: trainingoperator.TFJobSpec{
+					TFReplicaSpecs: map[trainingoperator.ReplicaType]*trainingoperator.ReplicaSpec{
+						trainingoperator.TFJobReplicaTypeEval: {
+							Replicas:          ptr.To[int32](2),
+							ToleratedFailures: ptr.To[int32](1),
+							Template: corev1.PodTemplateSpec{
+								Spec: corev1.PodSpec{
+									Containers: []corev1.Container{{
+										Name:  "tensorflow",
+										Image: "kubeflow/tf-mnist-with-summaries:latest",
+									}},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		"attempt to set toleratedFailures for workers gets rejected": {
+			tfJob: &trainingoperator.TFJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test",
+				},
+				Spec: trainingoperator.TFJobSpec{
+					TFReplicaSpecs: map[trainingoperator.ReplicaType]*trainingoperator.ReplicaSpec{
+						trainingoperator.TFJobReplicaTypeWorker: {
+							Replicas:          ptr.To[int32](2),
+							ToleratedFailures: ptr.To[int32](1),
+							Template: corev1.PodTemplateSpec{
+								Spec: corev1.PodSpec{
+									Containers: []corev1.Container{{
+										Name:  "tensorflow",
+										Image: "kubeflow/tf-mnist-with-summaries:latest",
+									}},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: field.ErrorList{
+				field.Forbidden(tfReplicaSpecPath.Key(string(trainingoperator.TFJobReplicaTypeWorker)).Child("toleratedFailures"), ""),
+			},
+		},
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
@@ -212,3 +502,57 @@ func TestValidateTFJob(t *testing.T) {
 		})
 	}
 }
+
+func TestConvertLegacyReplicaTypes(t *testing.T) {
+	chiefSpec := &trainingoperator.ReplicaSpec{Replicas: ptr.To[int32](1)}
+	masterSpec := &trainingoperator.ReplicaSpec{Replicas: ptr.To[int32](1)}
+	workerSpec := &trainingoperator.ReplicaSpec{Replicas: ptr.To[int32](2)}
+
+	testCases := map[string]struct {
+		replicaSpecs     map[trainingoperator.ReplicaType]*trainingoperator.ReplicaSpec
+		wantReplicaSpecs map[trainingoperator.ReplicaType]*trainingoperator.ReplicaSpec
+		wantWarnings     admission.Warnings
+	}{
+		"master is converted to chief": {
+			replicaSpecs: map[trainingoperator.ReplicaType]*trainingoperator.ReplicaSpec{
+				trainingoperator.TFJobReplicaTypeMaster: masterSpec,
+				trainingoperator.TFJobReplicaTypeWorker: workerSpec,
+			},
+			wantReplicaSpecs: map[trainingoperator.ReplicaType]*trainingoperator.ReplicaSpec{
+				trainingoperator.TFJobReplicaTypeChief:  masterSpec,
+				trainingoperator.TFJobReplicaTypeWorker: workerSpec,
+			},
+			wantWarnings: admission.Warnings{"spec.tfReplicaSpecs[Master]: the Master replica type is deprecated, converted to Chief"},
+		},
+		"master is kept with chief": {
+			replicaSpecs: map[trainingoperator.ReplicaType]*trainingoperator.ReplicaSpec{
+				trainingoperator.TFJobReplicaTypeChief:  chiefSpec,
+				trainingoperator.TFJobReplicaTypeMaster: masterSpec,
+			},
+			wantReplicaSpecs: map[trainingoperator.ReplicaType]*trainingoperator.ReplicaSpec{
+				trainingoperator.TFJobReplicaTypeChief:  chiefSpec,
+				trainingoperator.TFJobReplicaTypeMaster: masterSpec,
+			},
+		},
+		"no legacy replica type": {
+			replicaSpecs: map[trainingoperator.ReplicaType]*trainingoperator.ReplicaSpec{
+				trainingoperator.TFJobReplicaTypeWorker: workerSpec,
+			},
+			wantReplicaSpecs: map[trainingoperator.ReplicaType]*trainingoperator.ReplicaSpec{
+				trainingoperator.TFJobReplicaTypeWorker: workerSpec,
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			job := &trainingoperator.TFJob{Spec: trainingoperator.TFJobSpec{TFReplicaSpecs: tc.replicaSpecs}}
+			gotWarnings := convertLegacyReplicaTypes(job)
+			if diff := cmp.Diff(tc.wantWarnings, gotWarnings); len(diff) != 0 {
+				t.Errorf("Unexpected warnings (-want,+got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantReplicaSpecs, job.Spec.TFReplicaSpecs); len(diff) != 0 {
+				t.Errorf("Unexpected replica specs (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -21,12 +21,16 @@ import (
 
 	trainingoperator "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
 	"github.com/kubeflow/training-operator/pkg/webhooks/jax"
+	"github.com/kubeflow/training-operator/pkg/webhooks/mpi"
 	"github.com/kubeflow/training-operator/pkg/webhooks/paddlepaddle"
 	"github.com/kubeflow/training-operator/pkg/webhooks/pytorch"
 	"github.com/kubeflow/training-operator/pkg/webhooks/tensorflow"
 	"github.com/kubeflow/training-operator/pkg/webhooks/xgboost"
 )
 
+// The mutating webhooks merge the defaults of the TrainingJobClasses into the created jobs.
+// +kubebuilder:rbac:groups=kubeflow.org,resources=trainingjobclasses,verbs=get;list;watch
+
 type WebhookSetupFunc func(manager manager.Manager) error
 
 var (
@@ -34,12 +38,8 @@ var (
 		trainingoperator.PyTorchJobKind: pytorch.SetupWebhook,
 		trainingoperator.TFJobKind:      tensorflow.SetupWebhook,
 		trainingoperator.XGBoostJobKind: xgboost.SetupWebhook,
-		trainingoperator.MPIJobKind:     scaffold,
+		trainingoperator.MPIJobKind:     mpi.SetupWebhook,
 		trainingoperator.PaddleJobKind:  paddlepaddle.SetupWebhook,
 		trainingoperator.JAXJobKind:     jax.SetupWebhook,
 	}
 )
-
-func scaffold(manager.Manager) error {
-	return nil
-}
@@ -27,6 +27,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
@@ -37,17 +38,34 @@ import (
 var (
 	specPath           = field.NewPath("spec")
 	xgbReplicaSpecPath = specPath.Child("xgbReplicaSpecs")
+	rabitPolicyPath    = specPath.Child("rabitPolicy")
+
+	// controllerEnvNames are the env vars set by the controller in the containers of the XGBoostJob.
+	controllerEnvNames = []string{"MASTER_PORT", "MASTER_ADDR", "WORLD_SIZE", "RANK", "PYTHONUNBUFFERED", "WORKER_PORT", "WORKER_ADDRS"}
 )
 
-type Webhook struct{}
+type Webhook struct {
+	// client reads the jobs the validated jobs depend on.
+	client client.Reader
+}
 
 func SetupWebhook(mgr ctrl.Manager) error {
+	mgr.GetWebhookServer().Register("/mutate-kubeflow-org-v1-xgboostjob", &webhook.Admission{
+		Handler: util.NewJobDefaulterHandler(admission.NewDecoder(mgr.GetScheme()),
+			func() runtime.Object { return &trainingoperator.XGBoostJob{} },
+			func(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+				job := obj.(*trainingoperator.XGBoostJob)
+				return nil, util.ApplyJobClass(ctx, mgr.GetClient(), job, job.Spec.JobClassName, job.Spec.XGBReplicaSpecs, &job.Spec.RunPolicy)
+			}),
+	})
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(&trainingoperator.XGBoostJob{}).
-		WithValidator(&Webhook{}).
+		WithValidator(&Webhook{client: mgr.GetClient()}).
 		Complete()
 }
 
+// +kubebuilder:webhook:path=/mutate-kubeflow-org-v1-xgboostjob,mutating=true,failurePolicy=fail,sideEffects=None,groups=kubeflow.org,resources=xgboostjobs,verbs=create,versions=v1,name=defaulter.xgboostjob.training-operator.kubeflow.org,admissionReviewVersions=v1
+
 // +kubebuilder:webhook:path=/validate-kubeflow-org-v1-xgboostjob,mutating=false,failurePolicy=fail,sideEffects=None,groups=kubeflow.org,resources=xgboostjobs,verbs=create;update,versions=v1,name=validator.xgboostjob.training-operator.kubeflow.org,admissionReviewVersions=v1
 
 var _ webhook.CustomValidator = &Webhook{}
@@ -56,7 +74,9 @@ func (w *Webhook) ValidateCreate(ctx context.Context, obj runtime.Object) (admis
 	job := obj.(*trainingoperator.XGBoostJob)
 	log := ctrl.LoggerFrom(ctx).WithName("xgboostjob-webhook")
 	log.V(5).Info("Validating create", "xgboostJob", klog.KObj(job))
-	return nil, validateXGBoostJob(nil, job).ToAggregate()
+	allErrs := validateXGBoostJob(nil, job)
+	allErrs = append(allErrs, util.ValidateJobDependencies(ctx, w.client, job, trainingoperator.XGBoostJobKind, job.Spec.DependsOn)...)
+	return nil, allErrs.ToAggregate()
 }
 
 func (w *Webhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
@@ -64,7 +84,9 @@ func (w *Webhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Obj
 	newJob := newObj.(*trainingoperator.XGBoostJob)
 	log := ctrl.LoggerFrom(ctx).WithName("xgboostjob-webhook")
 	log.V(5).Info("Validating create", "xgboostJob", klog.KObj(newJob))
-	return nil, validateXGBoostJob(oldJob, newJob).ToAggregate()
+	allErrs := validateXGBoostJob(oldJob, newJob)
+	allErrs = append(allErrs, util.ValidateJobDependencies(ctx, w.client, newJob, trainingoperator.XGBoostJobKind, newJob.Spec.DependsOn)...)
+	return nil, allErrs.ToAggregate()
 }
 
 func (w *Webhook) ValidateDelete(context.Context, runtime.Object) (admission.Warnings, error) {
@@ -80,12 +102,46 @@ func validateXGBoostJob(oldJob, newJob *trainingoperator.XGBoostJob) field.Error
 		allErrs = append(allErrs, util.ValidateRunPolicyUpdate(&oldJob.Spec.RunPolicy, &newJob.Spec.RunPolicy)...)
 	}
 	allErrs = append(allErrs, util.ValidateRunPolicy(&newJob.Spec.RunPolicy)...)
+	allErrs = append(allErrs, util.ValidateRunIDAnnotation(newJob.Annotations)...)
+	allErrs = append(allErrs, util.ValidateMetricsCollectorAnnotations(newJob)...)
+	allErrs = append(allErrs, util.ValidateToleratedFailures(xgbReplicaSpecPath, newJob.Spec.XGBReplicaSpecs)...)
+	allErrs = append(allErrs, util.ValidateResourceProfiles(xgbReplicaSpecPath, newJob.Spec.XGBReplicaSpecs)...)
+	allErrs = append(allErrs, util.ValidateDatasets(newJob.Spec.Datasets)...)
 	allErrs = append(allErrs, validateSpec(newJob.Spec)...)
 	return allErrs
 }
 
 func validateSpec(spec trainingoperator.XGBoostJobSpec) field.ErrorList {
-	return validateXGBReplicaSpecs(spec.XGBReplicaSpecs)
+	allErrs := validateXGBReplicaSpecs(spec.XGBReplicaSpecs)
+	if spec.RabitPolicy != nil {
+		allErrs = append(allErrs, validateRabitPolicy(spec.RabitPolicy, spec.XGBReplicaSpecs)...)
+	}
+	return allErrs
+}
+
+func validateRabitPolicy(rabitPolicy *trainingoperator.RabitPolicy, rSpecs map[trainingoperator.ReplicaType]*trainingoperator.ReplicaSpec) field.ErrorList {
+	var allErrs field.ErrorList
+
+	// The Rabit tracker would wait forever for more workers than the replicas of the job.
+	if numWorkers := rabitPolicy.NumWorkers; numWorkers != nil {
+		var totalReplicas int32
+		for _, rSpec := range rSpecs {
+			if rSpec != nil && rSpec.Replicas != nil {
+				totalReplicas += *rSpec.Replicas
+			}
+		}
+		if *numWorkers > totalReplicas {
+			allErrs = append(allErrs, field.Invalid(rabitPolicyPath.Child("numWorkers"), *numWorkers,
+				fmt.Sprintf("must not be greater than the total number of replicas %d", totalReplicas)))
+		}
+	}
+	for idx, env := range rabitPolicy.Env {
+		if slices.Contains(controllerEnvNames, env.Name) {
+			allErrs = append(allErrs, field.Forbidden(rabitPolicyPath.Child("env").Index(idx).Child("name"),
+				fmt.Sprintf("%s is set by the controller", env.Name)))
+		}
+	}
+	return allErrs
 }
 
 func validateXGBReplicaSpecs(rSpecs map[trainingoperator.ReplicaType]*trainingoperator.ReplicaSpec) field.ErrorList {
@@ -253,6 +253,56 @@ func TestValidateXGBoostJob(t *testing.T) {
 					trainingoperator.KubeflowJobsController))),
 			},
 		},
+		"valid rabitPolicy": {
+			xgboostJob: &trainingoperator.XGBoostJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test",
+				},
+				Spec: trainingoperator.XGBoostJobSpec{
+					XGBReplicaSpecs: validXGBoostReplicaSpecs,
+					RabitPolicy: &trainingoperator.RabitPolicy{
+						TrackerPort: ptr.To[int32](9091),
+						NumWorkers:  ptr.To[int32](2),
+						Env:         []corev1.EnvVar{{Name: "DMLC_WORKER_CONNECT_RETRY", Value: "10"}},
+					},
+				},
+			},
+		},
+		"rabitPolicy waits for more workers than the replicas": {
+			xgboostJob: &trainingoperator.XGBoostJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test",
+				},
+				Spec: trainingoperator.XGBoostJobSpec{
+					XGBReplicaSpecs: validXGBoostReplicaSpecs,
+					RabitPolicy: &trainingoperator.RabitPolicy{
+						NumWorkers: ptr.To[int32](4),
+					},
+				},
+			},
+			wantErr: field.ErrorList{
+				field.Invalid(rabitPolicyPath.Child("numWorkers"), "", ""),
+			},
+		},
+		"rabitPolicy overrides an env var set by the controller": {
+			xgboostJob: &trainingoperator.XGBoostJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test",
+				},
+				Spec: trainingoperator.XGBoostJobSpec{
+					XGBReplicaSpecs: validXGBoostReplicaSpecs,
+					RabitPolicy: &trainingoperator.RabitPolicy{
+						Env: []corev1.EnvVar{
+							{Name: "DMLC_WORKER_CONNECT_RETRY", Value: "10"},
+							{Name: "WORLD_SIZE", Value: "2"},
+						},
+					},
+				},
+			},
+			wantErr: field.ErrorList{
+				field.Forbidden(rabitPolicyPath.Child("env").Index(1).Child("name"), ""),
+			},
+		},
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {